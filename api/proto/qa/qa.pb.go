@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.9
+// 	protoc-gen-go v1.36.10
 // 	protoc        v6.32.1
 // source: api/proto/qa/qa.proto
 
@@ -26,15 +26,17 @@ const (
 )
 
 type Question struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	UserId        int64                  `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title            string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content          string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	UserId           int64                  `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	AcceptedAnswerId int64                  `protobuf:"varint,7,opt,name=accepted_answer_id,json=acceptedAnswerId,proto3" json:"accepted_answer_id,omitempty"` // 被采纳的回答ID，0 表示尚未采纳
+	IsAnonymous      bool                   `protobuf:"varint,8,opt,name=is_anonymous,json=isAnonymous,proto3" json:"is_anonymous,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Question) Reset() {
@@ -109,19 +111,40 @@ func (x *Question) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Question) GetAcceptedAnswerId() int64 {
+	if x != nil {
+		return x.AcceptedAnswerId
+	}
+	return 0
+}
+
+func (x *Question) GetIsAnonymous() bool {
+	if x != nil {
+		return x.IsAnonymous
+	}
+	return false
+}
+
 // QuestionResponse 包含问题信息及额外的展示字段
 type QuestionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	UserId        int64                  `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	AuthorName    string                 `protobuf:"bytes,7,opt,name=author_name,json=authorName,proto3" json:"author_name,omitempty"`     // 提问者的用户名
-	AnswerCount   int64                  `protobuf:"varint,8,opt,name=answer_count,json=answerCount,proto3" json:"answer_count,omitempty"` // 回答数量
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title            string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content          string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	UserId           int64                  `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	AuthorName       string                 `protobuf:"bytes,7,opt,name=author_name,json=authorName,proto3" json:"author_name,omitempty"`                      // 提问者的用户名
+	AnswerCount      int64                  `protobuf:"varint,8,opt,name=answer_count,json=answerCount,proto3" json:"answer_count,omitempty"`                  // 回答数量
+	AcceptedAnswerId int64                  `protobuf:"varint,9,opt,name=accepted_answer_id,json=acceptedAnswerId,proto3" json:"accepted_answer_id,omitempty"` // 被采纳的回答ID，0 表示尚未采纳
+	CommentCount     int64                  `protobuf:"varint,10,opt,name=comment_count,json=commentCount,proto3" json:"comment_count,omitempty"`              // 问题下所有回答的评论总数
+	LastActivityAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_activity_at,json=lastActivityAt,proto3" json:"last_activity_at,omitempty"`       // 最近活动时间
+	// 匿名问题对作者本人和管理员以外的用户隐藏提问者，此时 user_id 为 0，author_name 为 "匿名用户"
+	IsAnonymous     bool   `protobuf:"varint,12,opt,name=is_anonymous,json=isAnonymous,proto3" json:"is_anonymous,omitempty"`
+	AuthorIsBot     bool   `protobuf:"varint,13,opt,name=author_is_bot,json=authorIsBot,proto3" json:"author_is_bot,omitempty"`            // 提问者是否为机器人账户
+	AuthorAvatarUrl string `protobuf:"bytes,14,opt,name=author_avatar_url,json=authorAvatarUrl,proto3" json:"author_avatar_url,omitempty"` // 提问者头像的小尺寸缩略图地址，没有头像或匿名时为空
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *QuestionResponse) Reset() {
@@ -210,6 +233,48 @@ func (x *QuestionResponse) GetAnswerCount() int64 {
 	return 0
 }
 
+func (x *QuestionResponse) GetAcceptedAnswerId() int64 {
+	if x != nil {
+		return x.AcceptedAnswerId
+	}
+	return 0
+}
+
+func (x *QuestionResponse) GetCommentCount() int64 {
+	if x != nil {
+		return x.CommentCount
+	}
+	return 0
+}
+
+func (x *QuestionResponse) GetLastActivityAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivityAt
+	}
+	return nil
+}
+
+func (x *QuestionResponse) GetIsAnonymous() bool {
+	if x != nil {
+		return x.IsAnonymous
+	}
+	return false
+}
+
+func (x *QuestionResponse) GetAuthorIsBot() bool {
+	if x != nil {
+		return x.AuthorIsBot
+	}
+	return false
+}
+
+func (x *QuestionResponse) GetAuthorAvatarUrl() string {
+	if x != nil {
+		return x.AuthorAvatarUrl
+	}
+	return ""
+}
+
 type Answer struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -219,6 +284,7 @@ type Answer struct {
 	UpvoteCount   int32                  `protobuf:"varint,5,opt,name=upvote_count,json=upvoteCount,proto3" json:"upvote_count,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	IsAnonymous   bool                   `protobuf:"varint,8,opt,name=is_anonymous,json=isAnonymous,proto3" json:"is_anonymous,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -302,6 +368,13 @@ func (x *Answer) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Answer) GetIsAnonymous() bool {
+	if x != nil {
+		return x.IsAnonymous
+	}
+	return false
+}
+
 // AnswerResponse 包含回答信息及额外的展示字段
 type AnswerResponse struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -314,6 +387,10 @@ type AnswerResponse struct {
 	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	Username        string                 `protobuf:"bytes,8,opt,name=username,proto3" json:"username,omitempty"`                                           // 回答者的用户名
 	IsUpvotedByUser bool                   `protobuf:"varint,9,opt,name=is_upvoted_by_user,json=isUpvotedByUser,proto3" json:"is_upvoted_by_user,omitempty"` // 当前用户是否点赞了该答案
+	// 匿名回答对作者本人和管理员以外的用户隐藏回答者，此时 user_id 为 0，username 为 "匿名用户"
+	IsAnonymous     bool   `protobuf:"varint,10,opt,name=is_anonymous,json=isAnonymous,proto3" json:"is_anonymous,omitempty"`
+	AuthorIsBot     bool   `protobuf:"varint,11,opt,name=author_is_bot,json=authorIsBot,proto3" json:"author_is_bot,omitempty"`            // 回答者是否为机器人账户
+	AuthorAvatarUrl string `protobuf:"bytes,12,opt,name=author_avatar_url,json=authorAvatarUrl,proto3" json:"author_avatar_url,omitempty"` // 回答者头像的小尺寸缩略图地址，没有头像或匿名时为空
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -411,6 +488,27 @@ func (x *AnswerResponse) GetIsUpvotedByUser() bool {
 	return false
 }
 
+func (x *AnswerResponse) GetIsAnonymous() bool {
+	if x != nil {
+		return x.IsAnonymous
+	}
+	return false
+}
+
+func (x *AnswerResponse) GetAuthorIsBot() bool {
+	if x != nil {
+		return x.AuthorIsBot
+	}
+	return false
+}
+
+func (x *AnswerResponse) GetAuthorAvatarUrl() string {
+	if x != nil {
+		return x.AuthorAvatarUrl
+	}
+	return ""
+}
+
 type Comment struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -497,16 +595,18 @@ func (x *Comment) GetUpdatedAt() *timestamppb.Timestamp {
 
 // CommentResponse 包含评论信息及额外的展示字段
 type CommentResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	AnswerId      int64                  `protobuf:"varint,2,opt,name=answer_id,json=answerId,proto3" json:"answer_id,omitempty"`
-	UserId        int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Username      string                 `protobuf:"bytes,7,opt,name=username,proto3" json:"username,omitempty"` // 评论者的用户名
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AnswerId        int64                  `protobuf:"varint,2,opt,name=answer_id,json=answerId,proto3" json:"answer_id,omitempty"`
+	UserId          int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Content         string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Username        string                 `protobuf:"bytes,7,opt,name=username,proto3" json:"username,omitempty"`                                        // 评论者的用户名
+	AuthorIsBot     bool                   `protobuf:"varint,8,opt,name=author_is_bot,json=authorIsBot,proto3" json:"author_is_bot,omitempty"`            // 评论者是否为机器人账户
+	AuthorAvatarUrl string                 `protobuf:"bytes,9,opt,name=author_avatar_url,json=authorAvatarUrl,proto3" json:"author_avatar_url,omitempty"` // 评论者头像的小尺寸缩略图地址，没有头像时为空
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *CommentResponse) Reset() {
@@ -588,10 +688,25 @@ func (x *CommentResponse) GetUsername() string {
 	return ""
 }
 
+func (x *CommentResponse) GetAuthorIsBot() bool {
+	if x != nil {
+		return x.AuthorIsBot
+	}
+	return false
+}
+
+func (x *CommentResponse) GetAuthorAvatarUrl() string {
+	if x != nil {
+		return x.AuthorAvatarUrl
+	}
+	return ""
+}
+
 type CreateQuestionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
 	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Anonymous     bool                   `protobuf:"varint,3,opt,name=anonymous,proto3" json:"anonymous,omitempty"` // 匿名提问
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -640,6 +755,13 @@ func (x *CreateQuestionRequest) GetContent() string {
 	return ""
 }
 
+func (x *CreateQuestionRequest) GetAnonymous() bool {
+	if x != nil {
+		return x.Anonymous
+	}
+	return false
+}
+
 type GetQuestionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -736,28 +858,29 @@ func (x *ListQuestionsRequest) GetPageSize() int32 {
 	return 0
 }
 
-type ListQuestionsResponse struct {
+type ListTrendingQuestionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Questions     []*QuestionResponse    `protobuf:"bytes,1,rep,name=questions,proto3" json:"questions,omitempty"`
-	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Window        string                 `protobuf:"bytes,1,opt,name=window,proto3" json:"window,omitempty"` // day、week 或 month，为空时默认为 week
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListQuestionsResponse) Reset() {
-	*x = ListQuestionsResponse{}
+func (x *ListTrendingQuestionsRequest) Reset() {
+	*x = ListTrendingQuestionsRequest{}
 	mi := &file_api_proto_qa_qa_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListQuestionsResponse) String() string {
+func (x *ListTrendingQuestionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListQuestionsResponse) ProtoMessage() {}
+func (*ListTrendingQuestionsRequest) ProtoMessage() {}
 
-func (x *ListQuestionsResponse) ProtoReflect() protoreflect.Message {
+func (x *ListTrendingQuestionsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_qa_qa_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -769,49 +892,53 @@ func (x *ListQuestionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListQuestionsResponse.ProtoReflect.Descriptor instead.
-func (*ListQuestionsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListTrendingQuestionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTrendingQuestionsRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ListQuestionsResponse) GetQuestions() []*QuestionResponse {
+func (x *ListTrendingQuestionsRequest) GetWindow() string {
 	if x != nil {
-		return x.Questions
+		return x.Window
 	}
-	return nil
+	return ""
 }
 
-func (x *ListQuestionsResponse) GetTotalCount() int64 {
+func (x *ListTrendingQuestionsRequest) GetPage() int32 {
 	if x != nil {
-		return x.TotalCount
+		return x.Page
 	}
 	return 0
 }
 
-type UpdateQuestionRequest struct {
+func (x *ListTrendingQuestionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ReconcileCountersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	DryRun        bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // 为 true 时只报告偏差，不做修正
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateQuestionRequest) Reset() {
-	*x = UpdateQuestionRequest{}
+func (x *ReconcileCountersRequest) Reset() {
+	*x = ReconcileCountersRequest{}
 	mi := &file_api_proto_qa_qa_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateQuestionRequest) String() string {
+func (x *ReconcileCountersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateQuestionRequest) ProtoMessage() {}
+func (*ReconcileCountersRequest) ProtoMessage() {}
 
-func (x *UpdateQuestionRequest) ProtoReflect() protoreflect.Message {
+func (x *ReconcileCountersRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_qa_qa_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -823,60 +950,43 @@ func (x *UpdateQuestionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateQuestionRequest.ProtoReflect.Descriptor instead.
-func (*UpdateQuestionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReconcileCountersRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileCountersRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *UpdateQuestionRequest) GetId() int64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-func (x *UpdateQuestionRequest) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
-}
-
-func (x *UpdateQuestionRequest) GetContent() string {
-	if x != nil {
-		return x.Content
-	}
-	return ""
-}
-
-func (x *UpdateQuestionRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+func (x *ReconcileCountersRequest) GetDryRun() bool {
 	if x != nil {
-		return x.UpdateMask
+		return x.DryRun
 	}
-	return nil
+	return false
 }
 
-type DeleteQuestionRequest struct {
+// CounterDrift 是一条与源表统计结果不一致的冗余计数
+type CounterDrift struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Counter       string                 `protobuf:"bytes,1,opt,name=counter,proto3" json:"counter,omitempty"`    // question_answer_count、question_comment_count 或 answer_upvote_count
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`             // 问题或回答的ID
+	Stored        int64                  `protobuf:"varint,3,opt,name=stored,proto3" json:"stored,omitempty"`     // 表中保存的计数
+	Expected      int64                  `protobuf:"varint,4,opt,name=expected,proto3" json:"expected,omitempty"` // 根据源表重新统计的计数
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteQuestionRequest) Reset() {
-	*x = DeleteQuestionRequest{}
+func (x *CounterDrift) Reset() {
+	*x = CounterDrift{}
 	mi := &file_api_proto_qa_qa_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteQuestionRequest) String() string {
+func (x *CounterDrift) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteQuestionRequest) ProtoMessage() {}
+func (*CounterDrift) ProtoMessage() {}
 
-func (x *DeleteQuestionRequest) ProtoReflect() protoreflect.Message {
+func (x *CounterDrift) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_qa_qa_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -888,40 +998,61 @@ func (x *DeleteQuestionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteQuestionRequest.ProtoReflect.Descriptor instead.
-func (*DeleteQuestionRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CounterDrift.ProtoReflect.Descriptor instead.
+func (*CounterDrift) Descriptor() ([]byte, []int) {
 	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *DeleteQuestionRequest) GetId() int64 {
+func (x *CounterDrift) GetCounter() string {
+	if x != nil {
+		return x.Counter
+	}
+	return ""
+}
+
+func (x *CounterDrift) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-type CreateAnswerRequest struct {
+func (x *CounterDrift) GetStored() int64 {
+	if x != nil {
+		return x.Stored
+	}
+	return 0
+}
+
+func (x *CounterDrift) GetExpected() int64 {
+	if x != nil {
+		return x.Expected
+	}
+	return 0
+}
+
+type ReconcileCountersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	QuestionId    int64                  `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
-	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Drifts        []*CounterDrift        `protobuf:"bytes,1,rep,name=drifts,proto3" json:"drifts,omitempty"`
+	FixedCount    int32                  `protobuf:"varint,2,opt,name=fixed_count,json=fixedCount,proto3" json:"fixed_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateAnswerRequest) Reset() {
-	*x = CreateAnswerRequest{}
+func (x *ReconcileCountersResponse) Reset() {
+	*x = ReconcileCountersResponse{}
 	mi := &file_api_proto_qa_qa_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateAnswerRequest) String() string {
+func (x *ReconcileCountersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateAnswerRequest) ProtoMessage() {}
+func (*ReconcileCountersResponse) ProtoMessage() {}
 
-func (x *CreateAnswerRequest) ProtoReflect() protoreflect.Message {
+func (x *ReconcileCountersResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_qa_qa_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -933,48 +1064,47 @@ func (x *CreateAnswerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateAnswerRequest.ProtoReflect.Descriptor instead.
-func (*CreateAnswerRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ReconcileCountersResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileCountersResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *CreateAnswerRequest) GetQuestionId() int64 {
+func (x *ReconcileCountersResponse) GetDrifts() []*CounterDrift {
 	if x != nil {
-		return x.QuestionId
+		return x.Drifts
 	}
-	return 0
+	return nil
 }
 
-func (x *CreateAnswerRequest) GetContent() string {
+func (x *ReconcileCountersResponse) GetFixedCount() int32 {
 	if x != nil {
-		return x.Content
+		return x.FixedCount
 	}
-	return ""
+	return 0
 }
 
-type UpdateAnswerRequest struct {
+type ListQuestionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	Questions     []*QuestionResponse    `protobuf:"bytes,1,rep,name=questions,proto3" json:"questions,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAnswerRequest) Reset() {
-	*x = UpdateAnswerRequest{}
+func (x *ListQuestionsResponse) Reset() {
+	*x = ListQuestionsResponse{}
 	mi := &file_api_proto_qa_qa_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAnswerRequest) String() string {
+func (x *ListQuestionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAnswerRequest) ProtoMessage() {}
+func (*ListQuestionsResponse) ProtoMessage() {}
 
-func (x *UpdateAnswerRequest) ProtoReflect() protoreflect.Message {
+func (x *ListQuestionsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_qa_qa_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -986,53 +1116,46 @@ func (x *UpdateAnswerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAnswerRequest.ProtoReflect.Descriptor instead.
-func (*UpdateAnswerRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListQuestionsResponse.ProtoReflect.Descriptor instead.
+func (*ListQuestionsResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *UpdateAnswerRequest) GetId() int64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-func (x *UpdateAnswerRequest) GetContent() string {
+func (x *ListQuestionsResponse) GetQuestions() []*QuestionResponse {
 	if x != nil {
-		return x.Content
+		return x.Questions
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateAnswerRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+func (x *ListQuestionsResponse) GetTotalCount() int64 {
 	if x != nil {
-		return x.UpdateMask
+		return x.TotalCount
 	}
-	return nil
+	return 0
 }
 
-type DeleteAnswerRequest struct {
+type BatchGetQuestionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"` // 单次最多 100 个
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteAnswerRequest) Reset() {
-	*x = DeleteAnswerRequest{}
+func (x *BatchGetQuestionsRequest) Reset() {
+	*x = BatchGetQuestionsRequest{}
 	mi := &file_api_proto_qa_qa_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAnswerRequest) String() string {
+func (x *BatchGetQuestionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAnswerRequest) ProtoMessage() {}
+func (*BatchGetQuestionsRequest) ProtoMessage() {}
 
-func (x *DeleteAnswerRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchGetQuestionsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_qa_qa_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1044,41 +1167,40 @@ func (x *DeleteAnswerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAnswerRequest.ProtoReflect.Descriptor instead.
-func (*DeleteAnswerRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetQuestionsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetQuestionsRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *DeleteAnswerRequest) GetId() int64 {
+func (x *BatchGetQuestionsRequest) GetIds() []int64 {
 	if x != nil {
-		return x.Id
+		return x.Ids
 	}
-	return 0
+	return nil
 }
 
-type ListAnswersRequest struct {
+type BatchGetQuestionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	QuestionId    int64                  `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Questions     []*QuestionResponse    `protobuf:"bytes,1,rep,name=questions,proto3" json:"questions,omitempty"`                                  // 按请求中 ID 的顺序返回
+	NotFoundIds   []int64                `protobuf:"varint,2,rep,packed,name=not_found_ids,json=notFoundIds,proto3" json:"not_found_ids,omitempty"` // 不存在的问题ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAnswersRequest) Reset() {
-	*x = ListAnswersRequest{}
+func (x *BatchGetQuestionsResponse) Reset() {
+	*x = BatchGetQuestionsResponse{}
 	mi := &file_api_proto_qa_qa_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAnswersRequest) String() string {
+func (x *BatchGetQuestionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAnswersRequest) ProtoMessage() {}
+func (*BatchGetQuestionsResponse) ProtoMessage() {}
 
-func (x *ListAnswersRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchGetQuestionsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_qa_qa_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1090,54 +1212,49 @@ func (x *ListAnswersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAnswersRequest.ProtoReflect.Descriptor instead.
-func (*ListAnswersRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetQuestionsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetQuestionsResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ListAnswersRequest) GetQuestionId() int64 {
-	if x != nil {
-		return x.QuestionId
-	}
-	return 0
-}
-
-func (x *ListAnswersRequest) GetPage() int32 {
+func (x *BatchGetQuestionsResponse) GetQuestions() []*QuestionResponse {
 	if x != nil {
-		return x.Page
+		return x.Questions
 	}
-	return 0
+	return nil
 }
 
-func (x *ListAnswersRequest) GetPageSize() int32 {
+func (x *BatchGetQuestionsResponse) GetNotFoundIds() []int64 {
 	if x != nil {
-		return x.PageSize
+		return x.NotFoundIds
 	}
-	return 0
+	return nil
 }
 
-type ListAnswersResponse struct {
+type UpdateQuestionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Answers       []*AnswerResponse      `protobuf:"bytes,1,rep,name=answers,proto3" json:"answers,omitempty"`
-	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAnswersResponse) Reset() {
-	*x = ListAnswersResponse{}
+func (x *UpdateQuestionRequest) Reset() {
+	*x = UpdateQuestionRequest{}
 	mi := &file_api_proto_qa_qa_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAnswersResponse) String() string {
+func (x *UpdateQuestionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAnswersResponse) ProtoMessage() {}
+func (*UpdateQuestionRequest) ProtoMessage() {}
 
-func (x *ListAnswersResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateQuestionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_qa_qa_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1149,47 +1266,60 @@ func (x *ListAnswersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAnswersResponse.ProtoReflect.Descriptor instead.
-func (*ListAnswersResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateQuestionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateQuestionRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *ListAnswersResponse) GetAnswers() []*AnswerResponse {
+func (x *UpdateQuestionRequest) GetId() int64 {
 	if x != nil {
-		return x.Answers
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-func (x *ListAnswersResponse) GetTotalCount() int64 {
+func (x *UpdateQuestionRequest) GetTitle() string {
 	if x != nil {
-		return x.TotalCount
+		return x.Title
 	}
-	return 0
+	return ""
 }
 
-type CreateCommentRequest struct {
+func (x *UpdateQuestionRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *UpdateQuestionRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteQuestionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AnswerId      int64                  `protobuf:"varint,1,opt,name=answer_id,json=answerId,proto3" json:"answer_id,omitempty"`
-	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateCommentRequest) Reset() {
-	*x = CreateCommentRequest{}
+func (x *DeleteQuestionRequest) Reset() {
+	*x = DeleteQuestionRequest{}
 	mi := &file_api_proto_qa_qa_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateCommentRequest) String() string {
+func (x *DeleteQuestionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateCommentRequest) ProtoMessage() {}
+func (*DeleteQuestionRequest) ProtoMessage() {}
 
-func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
+func (x *DeleteQuestionRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_qa_qa_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1201,48 +1331,41 @@ func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateCommentRequest.ProtoReflect.Descriptor instead.
-func (*CreateCommentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteQuestionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteQuestionRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *CreateCommentRequest) GetAnswerId() int64 {
+func (x *DeleteQuestionRequest) GetId() int64 {
 	if x != nil {
-		return x.AnswerId
+		return x.Id
 	}
 	return 0
 }
 
-func (x *CreateCommentRequest) GetContent() string {
-	if x != nil {
-		return x.Content
-	}
-	return ""
-}
-
-type UpdateCommentRequest struct {
+type CreateAnswerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	QuestionId    int64                  `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
 	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	Anonymous     bool                   `protobuf:"varint,3,opt,name=anonymous,proto3" json:"anonymous,omitempty"` // 匿名回答
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateCommentRequest) Reset() {
-	*x = UpdateCommentRequest{}
+func (x *CreateAnswerRequest) Reset() {
+	*x = CreateAnswerRequest{}
 	mi := &file_api_proto_qa_qa_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateCommentRequest) String() string {
+func (x *CreateAnswerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateCommentRequest) ProtoMessage() {}
+func (*CreateAnswerRequest) ProtoMessage() {}
 
-func (x *UpdateCommentRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateAnswerRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_qa_qa_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1254,53 +1377,53 @@ func (x *UpdateCommentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateCommentRequest.ProtoReflect.Descriptor instead.
-func (*UpdateCommentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateAnswerRequest.ProtoReflect.Descriptor instead.
+func (*CreateAnswerRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *UpdateCommentRequest) GetId() int64 {
+func (x *CreateAnswerRequest) GetQuestionId() int64 {
 	if x != nil {
-		return x.Id
+		return x.QuestionId
 	}
 	return 0
 }
 
-func (x *UpdateCommentRequest) GetContent() string {
+func (x *CreateAnswerRequest) GetContent() string {
 	if x != nil {
 		return x.Content
 	}
 	return ""
 }
 
-func (x *UpdateCommentRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+func (x *CreateAnswerRequest) GetAnonymous() bool {
 	if x != nil {
-		return x.UpdateMask
+		return x.Anonymous
 	}
-	return nil
+	return false
 }
 
-type DeleteCommentRequest struct {
+type BatchGetAnswersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"` // 单次最多 100 个
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteCommentRequest) Reset() {
-	*x = DeleteCommentRequest{}
+func (x *BatchGetAnswersRequest) Reset() {
+	*x = BatchGetAnswersRequest{}
 	mi := &file_api_proto_qa_qa_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteCommentRequest) String() string {
+func (x *BatchGetAnswersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteCommentRequest) ProtoMessage() {}
+func (*BatchGetAnswersRequest) ProtoMessage() {}
 
-func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchGetAnswersRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_qa_qa_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1312,41 +1435,40 @@ func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
-func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetAnswersRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetAnswersRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *DeleteCommentRequest) GetId() int64 {
+func (x *BatchGetAnswersRequest) GetIds() []int64 {
 	if x != nil {
-		return x.Id
+		return x.Ids
 	}
-	return 0
+	return nil
 }
 
-type ListCommentsRequest struct {
+type BatchGetAnswersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AnswerId      int64                  `protobuf:"varint,1,opt,name=answer_id,json=answerId,proto3" json:"answer_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Answers       []*AnswerResponse      `protobuf:"bytes,1,rep,name=answers,proto3" json:"answers,omitempty"`                                      // 按请求中 ID 的顺序返回
+	NotFoundIds   []int64                `protobuf:"varint,2,rep,packed,name=not_found_ids,json=notFoundIds,proto3" json:"not_found_ids,omitempty"` // 不存在的回答ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListCommentsRequest) Reset() {
-	*x = ListCommentsRequest{}
+func (x *BatchGetAnswersResponse) Reset() {
+	*x = BatchGetAnswersResponse{}
 	mi := &file_api_proto_qa_qa_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListCommentsRequest) String() string {
+func (x *BatchGetAnswersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCommentsRequest) ProtoMessage() {}
+func (*BatchGetAnswersResponse) ProtoMessage() {}
 
-func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchGetAnswersResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_qa_qa_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1358,54 +1480,48 @@ func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
-func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetAnswersResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetAnswersResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ListCommentsRequest) GetAnswerId() int64 {
-	if x != nil {
-		return x.AnswerId
-	}
-	return 0
-}
-
-func (x *ListCommentsRequest) GetPage() int32 {
+func (x *BatchGetAnswersResponse) GetAnswers() []*AnswerResponse {
 	if x != nil {
-		return x.Page
+		return x.Answers
 	}
-	return 0
+	return nil
 }
 
-func (x *ListCommentsRequest) GetPageSize() int32 {
+func (x *BatchGetAnswersResponse) GetNotFoundIds() []int64 {
 	if x != nil {
-		return x.PageSize
+		return x.NotFoundIds
 	}
-	return 0
+	return nil
 }
 
-type ListCommentsResponse struct {
+type UpdateAnswerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Comments      []*CommentResponse     `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
-	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListCommentsResponse) Reset() {
-	*x = ListCommentsResponse{}
+func (x *UpdateAnswerRequest) Reset() {
+	*x = UpdateAnswerRequest{}
 	mi := &file_api_proto_qa_qa_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListCommentsResponse) String() string {
+func (x *UpdateAnswerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCommentsResponse) ProtoMessage() {}
+func (*UpdateAnswerRequest) ProtoMessage() {}
 
-func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateAnswerRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_qa_qa_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1417,46 +1533,53 @@ func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
-func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateAnswerRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAnswerRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *ListCommentsResponse) GetComments() []*CommentResponse {
+func (x *UpdateAnswerRequest) GetId() int64 {
 	if x != nil {
-		return x.Comments
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-func (x *ListCommentsResponse) GetTotalCount() int64 {
+func (x *UpdateAnswerRequest) GetContent() string {
 	if x != nil {
-		return x.TotalCount
+		return x.Content
 	}
-	return 0
+	return ""
 }
 
-type UpvoteAnswerRequest struct {
+func (x *UpdateAnswerRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteAnswerRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AnswerId      int64                  `protobuf:"varint,1,opt,name=answer_id,json=answerId,proto3" json:"answer_id,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpvoteAnswerRequest) Reset() {
-	*x = UpvoteAnswerRequest{}
+func (x *DeleteAnswerRequest) Reset() {
+	*x = DeleteAnswerRequest{}
 	mi := &file_api_proto_qa_qa_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpvoteAnswerRequest) String() string {
+func (x *DeleteAnswerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpvoteAnswerRequest) ProtoMessage() {}
+func (*DeleteAnswerRequest) ProtoMessage() {}
 
-func (x *UpvoteAnswerRequest) ProtoReflect() protoreflect.Message {
+func (x *DeleteAnswerRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_qa_qa_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1468,40 +1591,2092 @@ func (x *UpvoteAnswerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpvoteAnswerRequest.ProtoReflect.Descriptor instead.
-func (*UpvoteAnswerRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteAnswerRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAnswerRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *UpvoteAnswerRequest) GetAnswerId() int64 {
+func (x *DeleteAnswerRequest) GetId() int64 {
 	if x != nil {
-		return x.AnswerId
+		return x.Id
 	}
 	return 0
 }
 
-type DownvoteAnswerRequest struct {
+type ListAnswersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AnswerId      int64                  `protobuf:"varint,1,opt,name=answer_id,json=answerId,proto3" json:"answer_id,omitempty"`
+	QuestionId    int64                  `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DownvoteAnswerRequest) Reset() {
-	*x = DownvoteAnswerRequest{}
+func (x *ListAnswersRequest) Reset() {
+	*x = ListAnswersRequest{}
 	mi := &file_api_proto_qa_qa_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
+func (x *ListAnswersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAnswersRequest) ProtoMessage() {}
+
+func (x *ListAnswersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAnswersRequest.ProtoReflect.Descriptor instead.
+func (*ListAnswersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListAnswersRequest) GetQuestionId() int64 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+func (x *ListAnswersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListAnswersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListAnswersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Answers       []*AnswerResponse      `protobuf:"bytes,1,rep,name=answers,proto3" json:"answers,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAnswersResponse) Reset() {
+	*x = ListAnswersResponse{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAnswersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAnswersResponse) ProtoMessage() {}
+
+func (x *ListAnswersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAnswersResponse.ProtoReflect.Descriptor instead.
+func (*ListAnswersResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListAnswersResponse) GetAnswers() []*AnswerResponse {
+	if x != nil {
+		return x.Answers
+	}
+	return nil
+}
+
+func (x *ListAnswersResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type CreateCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnswerId      int64                  `protobuf:"varint,1,opt,name=answer_id,json=answerId,proto3" json:"answer_id,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCommentRequest) Reset() {
+	*x = CreateCommentRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCommentRequest) ProtoMessage() {}
+
+func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCommentRequest.ProtoReflect.Descriptor instead.
+func (*CreateCommentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateCommentRequest) GetAnswerId() int64 {
+	if x != nil {
+		return x.AnswerId
+	}
+	return 0
+}
+
+func (x *CreateCommentRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type UpdateCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCommentRequest) Reset() {
+	*x = UpdateCommentRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCommentRequest) ProtoMessage() {}
+
+func (x *UpdateCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCommentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCommentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateCommentRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateCommentRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *UpdateCommentRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type DeleteCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommentRequest) Reset() {
+	*x = DeleteCommentRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentRequest) ProtoMessage() {}
+
+func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteCommentRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnswerId      int64                  `protobuf:"varint,1,opt,name=answer_id,json=answerId,proto3" json:"answer_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsRequest) Reset() {
+	*x = ListCommentsRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsRequest) ProtoMessage() {}
+
+func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListCommentsRequest) GetAnswerId() int64 {
+	if x != nil {
+		return x.AnswerId
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListCommentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comments      []*CommentResponse     `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsResponse) Reset() {
+	*x = ListCommentsResponse{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsResponse) ProtoMessage() {}
+
+func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListCommentsResponse) GetComments() []*CommentResponse {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ListCommentsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type UpvoteAnswerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnswerId      int64                  `protobuf:"varint,1,opt,name=answer_id,json=answerId,proto3" json:"answer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpvoteAnswerRequest) Reset() {
+	*x = UpvoteAnswerRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpvoteAnswerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpvoteAnswerRequest) ProtoMessage() {}
+
+func (x *UpvoteAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpvoteAnswerRequest.ProtoReflect.Descriptor instead.
+func (*UpvoteAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpvoteAnswerRequest) GetAnswerId() int64 {
+	if x != nil {
+		return x.AnswerId
+	}
+	return 0
+}
+
+type DownvoteAnswerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnswerId      int64                  `protobuf:"varint,1,opt,name=answer_id,json=answerId,proto3" json:"answer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownvoteAnswerRequest) Reset() {
+	*x = DownvoteAnswerRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
 func (x *DownvoteAnswerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DownvoteAnswerRequest) ProtoMessage() {}
+func (*DownvoteAnswerRequest) ProtoMessage() {}
+
+func (x *DownvoteAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownvoteAnswerRequest.ProtoReflect.Descriptor instead.
+func (*DownvoteAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DownvoteAnswerRequest) GetAnswerId() int64 {
+	if x != nil {
+		return x.AnswerId
+	}
+	return 0
+}
+
+type RetractAnswerVoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnswerId      int64                  `protobuf:"varint,1,opt,name=answer_id,json=answerId,proto3" json:"answer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetractAnswerVoteRequest) Reset() {
+	*x = RetractAnswerVoteRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetractAnswerVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetractAnswerVoteRequest) ProtoMessage() {}
+
+func (x *RetractAnswerVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetractAnswerVoteRequest.ProtoReflect.Descriptor instead.
+func (*RetractAnswerVoteRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RetractAnswerVoteRequest) GetAnswerId() int64 {
+	if x != nil {
+		return x.AnswerId
+	}
+	return 0
+}
+
+type AcceptAnswerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AnswerId      int64                  `protobuf:"varint,1,opt,name=answer_id,json=answerId,proto3" json:"answer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptAnswerRequest) Reset() {
+	*x = AcceptAnswerRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptAnswerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptAnswerRequest) ProtoMessage() {}
+
+func (x *AcceptAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptAnswerRequest.ProtoReflect.Descriptor instead.
+func (*AcceptAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AcceptAnswerRequest) GetAnswerId() int64 {
+	if x != nil {
+		return x.AnswerId
+	}
+	return 0
+}
+
+type ListUserQuestionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserQuestionsRequest) Reset() {
+	*x = ListUserQuestionsRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserQuestionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserQuestionsRequest) ProtoMessage() {}
+
+func (x *ListUserQuestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserQuestionsRequest.ProtoReflect.Descriptor instead.
+func (*ListUserQuestionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListUserQuestionsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListUserQuestionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUserQuestionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListUserAnswersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserAnswersRequest) Reset() {
+	*x = ListUserAnswersRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserAnswersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserAnswersRequest) ProtoMessage() {}
+
+func (x *ListUserAnswersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserAnswersRequest.ProtoReflect.Descriptor instead.
+func (*ListUserAnswersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListUserAnswersRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListUserAnswersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUserAnswersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// UserAnswerResponse 是用户回答列表中的一项，附带所属问题的标题
+type UserAnswerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	QuestionId    int64                  `protobuf:"varint,2,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	UserId        int64                  `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UpvoteCount   int32                  `protobuf:"varint,5,opt,name=upvote_count,json=upvoteCount,proto3" json:"upvote_count,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Username      string                 `protobuf:"bytes,8,opt,name=username,proto3" json:"username,omitempty"`                                // 回答者的用户名
+	QuestionTitle string                 `protobuf:"bytes,9,opt,name=question_title,json=questionTitle,proto3" json:"question_title,omitempty"` // 所属问题的标题
+	IsAnonymous   bool                   `protobuf:"varint,10,opt,name=is_anonymous,json=isAnonymous,proto3" json:"is_anonymous,omitempty"`     // 只有本人和管理员能在个人主页看到匿名回答
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserAnswerResponse) Reset() {
+	*x = UserAnswerResponse{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserAnswerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserAnswerResponse) ProtoMessage() {}
+
+func (x *UserAnswerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserAnswerResponse.ProtoReflect.Descriptor instead.
+func (*UserAnswerResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UserAnswerResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UserAnswerResponse) GetQuestionId() int64 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+func (x *UserAnswerResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *UserAnswerResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UserAnswerResponse) GetUpvoteCount() int32 {
+	if x != nil {
+		return x.UpvoteCount
+	}
+	return 0
+}
+
+func (x *UserAnswerResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *UserAnswerResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *UserAnswerResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserAnswerResponse) GetQuestionTitle() string {
+	if x != nil {
+		return x.QuestionTitle
+	}
+	return ""
+}
+
+func (x *UserAnswerResponse) GetIsAnonymous() bool {
+	if x != nil {
+		return x.IsAnonymous
+	}
+	return false
+}
+
+type ListUserAnswersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Answers       []*UserAnswerResponse  `protobuf:"bytes,1,rep,name=answers,proto3" json:"answers,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserAnswersResponse) Reset() {
+	*x = ListUserAnswersResponse{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserAnswersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserAnswersResponse) ProtoMessage() {}
+
+func (x *ListUserAnswersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserAnswersResponse.ProtoReflect.Descriptor instead.
+func (*ListUserAnswersResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListUserAnswersResponse) GetAnswers() []*UserAnswerResponse {
+	if x != nil {
+		return x.Answers
+	}
+	return nil
+}
+
+func (x *ListUserAnswersResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type ListUserCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserCommentsRequest) Reset() {
+	*x = ListUserCommentsRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserCommentsRequest) ProtoMessage() {}
+
+func (x *ListUserCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListUserCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListUserCommentsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListUserCommentsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUserCommentsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// UserCommentResponse 是用户评论列表中的一项，附带所属问题的信息
+type UserCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AnswerId      int64                  `protobuf:"varint,2,opt,name=answer_id,json=answerId,proto3" json:"answer_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Username      string                 `protobuf:"bytes,7,opt,name=username,proto3" json:"username,omitempty"`                                // 评论者的用户名
+	QuestionId    int64                  `protobuf:"varint,8,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`         // 所属问题的ID
+	QuestionTitle string                 `protobuf:"bytes,9,opt,name=question_title,json=questionTitle,proto3" json:"question_title,omitempty"` // 所属问题的标题
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserCommentResponse) Reset() {
+	*x = UserCommentResponse{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserCommentResponse) ProtoMessage() {}
+
+func (x *UserCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserCommentResponse.ProtoReflect.Descriptor instead.
+func (*UserCommentResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UserCommentResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UserCommentResponse) GetAnswerId() int64 {
+	if x != nil {
+		return x.AnswerId
+	}
+	return 0
+}
+
+func (x *UserCommentResponse) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UserCommentResponse) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *UserCommentResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *UserCommentResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *UserCommentResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserCommentResponse) GetQuestionId() int64 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+func (x *UserCommentResponse) GetQuestionTitle() string {
+	if x != nil {
+		return x.QuestionTitle
+	}
+	return ""
+}
+
+type ListUserCommentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comments      []*UserCommentResponse `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserCommentsResponse) Reset() {
+	*x = ListUserCommentsResponse{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserCommentsResponse) ProtoMessage() {}
+
+func (x *ListUserCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListUserCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListUserCommentsResponse) GetComments() []*UserCommentResponse {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ListUserCommentsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type GetUserActivityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserActivityRequest) Reset() {
+	*x = GetUserActivityRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserActivityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserActivityRequest) ProtoMessage() {}
+
+func (x *GetUserActivityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserActivityRequest.ProtoReflect.Descriptor instead.
+func (*GetUserActivityRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetUserActivityRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetUserActivityRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetUserActivityRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// ActivityItem 是用户动态时间线中的一项
+type ActivityItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "question", "answer" 或 "comment"
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`    // 对应问题、回答或评论的ID
+	QuestionId    int64                  `protobuf:"varint,3,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	QuestionTitle string                 `protobuf:"bytes,4,opt,name=question_title,json=questionTitle,proto3" json:"question_title,omitempty"`
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivityItem) Reset() {
+	*x = ActivityItem{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivityItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivityItem) ProtoMessage() {}
+
+func (x *ActivityItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivityItem.ProtoReflect.Descriptor instead.
+func (*ActivityItem) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ActivityItem) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ActivityItem) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ActivityItem) GetQuestionId() int64 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+func (x *ActivityItem) GetQuestionTitle() string {
+	if x != nil {
+		return x.QuestionTitle
+	}
+	return ""
+}
+
+func (x *ActivityItem) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *ActivityItem) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetUserActivityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Activities    []*ActivityItem        `protobuf:"bytes,1,rep,name=activities,proto3" json:"activities,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserActivityResponse) Reset() {
+	*x = GetUserActivityResponse{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserActivityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserActivityResponse) ProtoMessage() {}
+
+func (x *GetUserActivityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserActivityResponse.ProtoReflect.Descriptor instead.
+func (*GetUserActivityResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetUserActivityResponse) GetActivities() []*ActivityItem {
+	if x != nil {
+		return x.Activities
+	}
+	return nil
+}
+
+func (x *GetUserActivityResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// SuggestedEdit 是对问题或回答的一条修改建议
+type SuggestedEdit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TargetType    string                 `protobuf:"bytes,2,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"` // "question" 或 "answer"
+	TargetId      int64                  `protobuf:"varint,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	ProposerId    int64                  `protobuf:"varint,4,opt,name=proposer_id,json=proposerId,proto3" json:"proposer_id,omitempty"`
+	ProposerName  string                 `protobuf:"bytes,5,opt,name=proposer_name,json=proposerName,proto3" json:"proposer_name,omitempty"`
+	BaseTitle     string                 `protobuf:"bytes,6,opt,name=base_title,json=baseTitle,proto3" json:"base_title,omitempty"`       // 提出建议时的标题，回答为空
+	BaseContent   string                 `protobuf:"bytes,7,opt,name=base_content,json=baseContent,proto3" json:"base_content,omitempty"` // 提出建议时的内容，用于展示差异
+	Title         string                 `protobuf:"bytes,8,opt,name=title,proto3" json:"title,omitempty"`
+	Content       string                 `protobuf:"bytes,9,opt,name=content,proto3" json:"content,omitempty"`
+	Reason        string                 `protobuf:"bytes,10,opt,name=reason,proto3" json:"reason,omitempty"`
+	Status        string                 `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"` // "pending"、"approved" 或 "rejected"
+	ReviewerId    int64                  `protobuf:"varint,12,opt,name=reviewer_id,json=reviewerId,proto3" json:"reviewer_id,omitempty"`
+	ReviewComment string                 `protobuf:"bytes,13,opt,name=review_comment,json=reviewComment,proto3" json:"review_comment,omitempty"`
+	ReviewedAt    *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=reviewed_at,json=reviewedAt,proto3" json:"reviewed_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestedEdit) Reset() {
+	*x = SuggestedEdit{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestedEdit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestedEdit) ProtoMessage() {}
+
+func (x *SuggestedEdit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestedEdit.ProtoReflect.Descriptor instead.
+func (*SuggestedEdit) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SuggestedEdit) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SuggestedEdit) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *SuggestedEdit) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+func (x *SuggestedEdit) GetProposerId() int64 {
+	if x != nil {
+		return x.ProposerId
+	}
+	return 0
+}
+
+func (x *SuggestedEdit) GetProposerName() string {
+	if x != nil {
+		return x.ProposerName
+	}
+	return ""
+}
+
+func (x *SuggestedEdit) GetBaseTitle() string {
+	if x != nil {
+		return x.BaseTitle
+	}
+	return ""
+}
+
+func (x *SuggestedEdit) GetBaseContent() string {
+	if x != nil {
+		return x.BaseContent
+	}
+	return ""
+}
+
+func (x *SuggestedEdit) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SuggestedEdit) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *SuggestedEdit) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SuggestedEdit) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SuggestedEdit) GetReviewerId() int64 {
+	if x != nil {
+		return x.ReviewerId
+	}
+	return 0
+}
+
+func (x *SuggestedEdit) GetReviewComment() string {
+	if x != nil {
+		return x.ReviewComment
+	}
+	return ""
+}
+
+func (x *SuggestedEdit) GetReviewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return nil
+}
+
+func (x *SuggestedEdit) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type SuggestEditRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetType    string                 `protobuf:"bytes,1,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"`
+	TargetId      int64                  `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"` // 仅对问题有效
+	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"` // 修改理由
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestEditRequest) Reset() {
+	*x = SuggestEditRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestEditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestEditRequest) ProtoMessage() {}
+
+func (x *SuggestEditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestEditRequest.ProtoReflect.Descriptor instead.
+func (*SuggestEditRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *SuggestEditRequest) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *SuggestEditRequest) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+func (x *SuggestEditRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SuggestEditRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *SuggestEditRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ListSuggestedEditsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetType    string                 `protobuf:"bytes,1,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"`
+	TargetId      int64                  `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // 为空时列出全部状态
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSuggestedEditsRequest) Reset() {
+	*x = ListSuggestedEditsRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSuggestedEditsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSuggestedEditsRequest) ProtoMessage() {}
+
+func (x *ListSuggestedEditsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSuggestedEditsRequest.ProtoReflect.Descriptor instead.
+func (*ListSuggestedEditsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListSuggestedEditsRequest) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *ListSuggestedEditsRequest) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+func (x *ListSuggestedEditsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListSuggestedEditsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListSuggestedEditsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListSuggestedEditsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Edits         []*SuggestedEdit       `protobuf:"bytes,1,rep,name=edits,proto3" json:"edits,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSuggestedEditsResponse) Reset() {
+	*x = ListSuggestedEditsResponse{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSuggestedEditsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSuggestedEditsResponse) ProtoMessage() {}
+
+func (x *ListSuggestedEditsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSuggestedEditsResponse.ProtoReflect.Descriptor instead.
+func (*ListSuggestedEditsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListSuggestedEditsResponse) GetEdits() []*SuggestedEdit {
+	if x != nil {
+		return x.Edits
+	}
+	return nil
+}
+
+func (x *ListSuggestedEditsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type ReviewSuggestedEditRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Approve       bool                   `protobuf:"varint,2,opt,name=approve,proto3" json:"approve,omitempty"`
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewSuggestedEditRequest) Reset() {
+	*x = ReviewSuggestedEditRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewSuggestedEditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewSuggestedEditRequest) ProtoMessage() {}
+
+func (x *ReviewSuggestedEditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewSuggestedEditRequest.ProtoReflect.Descriptor instead.
+func (*ReviewSuggestedEditRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ReviewSuggestedEditRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ReviewSuggestedEditRequest) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
+func (x *ReviewSuggestedEditRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type FlagContentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TargetType    string                 `protobuf:"bytes,1,opt,name=target_type,json=targetType,proto3" json:"target_type,omitempty"` // "question" 或 "answer"
+	TargetId      int64                  `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlagContentRequest) Reset() {
+	*x = FlagContentRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlagContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlagContentRequest) ProtoMessage() {}
+
+func (x *FlagContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlagContentRequest.ProtoReflect.Descriptor instead.
+func (*FlagContentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *FlagContentRequest) GetTargetType() string {
+	if x != nil {
+		return x.TargetType
+	}
+	return ""
+}
+
+func (x *FlagContentRequest) GetTargetId() int64 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+func (x *FlagContentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ExportContentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 是否导出用户的密码哈希，默认不导出。不导出时导入的用户需要通过找回密码设置新密码
+	IncludePasswordHashes bool `protobuf:"varint,1,opt,name=include_password_hashes,json=includePasswordHashes,proto3" json:"include_password_hashes,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ExportContentRequest) Reset() {
+	*x = ExportContentRequest{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportContentRequest) ProtoMessage() {}
+
+func (x *ExportContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportContentRequest.ProtoReflect.Descriptor instead.
+func (*ExportContentRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ExportContentRequest) GetIncludePasswordHashes() bool {
+	if x != nil {
+		return x.IncludePasswordHashes
+	}
+	return false
+}
+
+// ContentHeader 是导出文件的第一条记录
+type ContentHeader struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FormatVersion int32                  `protobuf:"varint,1,opt,name=format_version,json=formatVersion,proto3" json:"format_version,omitempty"` // 当前为 2，导入仍支持版本 1
+	ExportedAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=exported_at,json=exportedAt,proto3" json:"exported_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContentHeader) Reset() {
+	*x = ContentHeader{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContentHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContentHeader) ProtoMessage() {}
+
+func (x *ContentHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContentHeader.ProtoReflect.Descriptor instead.
+func (*ContentHeader) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ContentHeader) GetFormatVersion() int32 {
+	if x != nil {
+		return x.FormatVersion
+	}
+	return 0
+}
+
+func (x *ContentHeader) GetExportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExportedAt
+	}
+	return nil
+}
+
+// ContentUser 是导出的用户。导出时指定包含密码哈希，迁移后用户才能使用原密码登录
+type ContentUser struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username        string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email           string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Bio             string                 `protobuf:"bytes,4,opt,name=bio,proto3" json:"bio,omitempty"`
+	PasswordHash    string                 `protobuf:"bytes,5,opt,name=password_hash,json=passwordHash,proto3" json:"password_hash,omitempty"` // 未指定导出密码哈希时为空
+	Role            string                 `protobuf:"bytes,6,opt,name=role,proto3" json:"role,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	EmailVerifiedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=email_verified_at,json=emailVerifiedAt,proto3" json:"email_verified_at,omitempty"` // 未设置表示邮箱尚未验证
+	UserType        string                 `protobuf:"bytes,9,opt,name=user_type,json=userType,proto3" json:"user_type,omitempty"`                        // human 或 bot，为空时按 human 导入
+	Language        string                 `protobuf:"bytes,10,opt,name=language,proto3" json:"language,omitempty"`                                       // 为空时使用默认语言
+	AvatarKey       string                 `protobuf:"bytes,11,opt,name=avatar_key,json=avatarKey,proto3" json:"avatar_key,omitempty"`                    // 头像在文件存储中的目录，头像文件需要另行复制到新实例的文件存储
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ContentUser) Reset() {
+	*x = ContentUser{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContentUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContentUser) ProtoMessage() {}
+
+func (x *ContentUser) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContentUser.ProtoReflect.Descriptor instead.
+func (*ContentUser) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ContentUser) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ContentUser) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ContentUser) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ContentUser) GetBio() string {
+	if x != nil {
+		return x.Bio
+	}
+	return ""
+}
+
+func (x *ContentUser) GetPasswordHash() string {
+	if x != nil {
+		return x.PasswordHash
+	}
+	return ""
+}
+
+func (x *ContentUser) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ContentUser) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ContentUser) GetEmailVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EmailVerifiedAt
+	}
+	return nil
+}
+
+func (x *ContentUser) GetUserType() string {
+	if x != nil {
+		return x.UserType
+	}
+	return ""
+}
+
+func (x *ContentUser) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ContentUser) GetAvatarKey() string {
+	if x != nil {
+		return x.AvatarKey
+	}
+	return ""
+}
+
+type ContentVote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	AnswerId      int64                  `protobuf:"varint,2,opt,name=answer_id,json=answerId,proto3" json:"answer_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	IsUpvote      bool                   `protobuf:"varint,4,opt,name=is_upvote,json=isUpvote,proto3" json:"is_upvote,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContentVote) Reset() {
+	*x = ContentVote{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContentVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContentVote) ProtoMessage() {}
+
+func (x *ContentVote) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContentVote.ProtoReflect.Descriptor instead.
+func (*ContentVote) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ContentVote) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ContentVote) GetAnswerId() int64 {
+	if x != nil {
+		return x.AnswerId
+	}
+	return 0
+}
+
+func (x *ContentVote) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ContentVote) GetIsUpvote() bool {
+	if x != nil {
+		return x.IsUpvote
+	}
+	return false
+}
+
+func (x *ContentVote) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// ContentRecord 是导出文件中的一行
+type ContentRecord struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Record:
+	//
+	//	*ContentRecord_Header
+	//	*ContentRecord_User
+	//	*ContentRecord_Question
+	//	*ContentRecord_Answer
+	//	*ContentRecord_Comment
+	//	*ContentRecord_Vote
+	Record        isContentRecord_Record `protobuf_oneof:"record"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContentRecord) Reset() {
+	*x = ContentRecord{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContentRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContentRecord) ProtoMessage() {}
 
-func (x *DownvoteAnswerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_qa_qa_proto_msgTypes[23]
+func (x *ContentRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1512,14 +3687,278 @@ func (x *DownvoteAnswerRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DownvoteAnswerRequest.ProtoReflect.Descriptor instead.
-func (*DownvoteAnswerRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use ContentRecord.ProtoReflect.Descriptor instead.
+func (*ContentRecord) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *DownvoteAnswerRequest) GetAnswerId() int64 {
+func (x *ContentRecord) GetRecord() isContentRecord_Record {
 	if x != nil {
-		return x.AnswerId
+		return x.Record
+	}
+	return nil
+}
+
+func (x *ContentRecord) GetHeader() *ContentHeader {
+	if x != nil {
+		if x, ok := x.Record.(*ContentRecord_Header); ok {
+			return x.Header
+		}
+	}
+	return nil
+}
+
+func (x *ContentRecord) GetUser() *ContentUser {
+	if x != nil {
+		if x, ok := x.Record.(*ContentRecord_User); ok {
+			return x.User
+		}
+	}
+	return nil
+}
+
+func (x *ContentRecord) GetQuestion() *Question {
+	if x != nil {
+		if x, ok := x.Record.(*ContentRecord_Question); ok {
+			return x.Question
+		}
+	}
+	return nil
+}
+
+func (x *ContentRecord) GetAnswer() *Answer {
+	if x != nil {
+		if x, ok := x.Record.(*ContentRecord_Answer); ok {
+			return x.Answer
+		}
+	}
+	return nil
+}
+
+func (x *ContentRecord) GetComment() *Comment {
+	if x != nil {
+		if x, ok := x.Record.(*ContentRecord_Comment); ok {
+			return x.Comment
+		}
+	}
+	return nil
+}
+
+func (x *ContentRecord) GetVote() *ContentVote {
+	if x != nil {
+		if x, ok := x.Record.(*ContentRecord_Vote); ok {
+			return x.Vote
+		}
+	}
+	return nil
+}
+
+type isContentRecord_Record interface {
+	isContentRecord_Record()
+}
+
+type ContentRecord_Header struct {
+	Header *ContentHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type ContentRecord_User struct {
+	User *ContentUser `protobuf:"bytes,2,opt,name=user,proto3,oneof"`
+}
+
+type ContentRecord_Question struct {
+	Question *Question `protobuf:"bytes,3,opt,name=question,proto3,oneof"`
+}
+
+type ContentRecord_Answer struct {
+	Answer *Answer `protobuf:"bytes,4,opt,name=answer,proto3,oneof"`
+}
+
+type ContentRecord_Comment struct {
+	Comment *Comment `protobuf:"bytes,5,opt,name=comment,proto3,oneof"`
+}
+
+type ContentRecord_Vote struct {
+	Vote *ContentVote `protobuf:"bytes,6,opt,name=vote,proto3,oneof"`
+}
+
+func (*ContentRecord_Header) isContentRecord_Record() {}
+
+func (*ContentRecord_User) isContentRecord_Record() {}
+
+func (*ContentRecord_Question) isContentRecord_Record() {}
+
+func (*ContentRecord_Answer) isContentRecord_Record() {}
+
+func (*ContentRecord_Comment) isContentRecord_Record() {}
+
+func (*ContentRecord_Vote) isContentRecord_Record() {}
+
+type ImportContentResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Users       int64                  `protobuf:"varint,1,opt,name=users,proto3" json:"users,omitempty"`                                // 新建的用户数
+	ReusedUsers int64                  `protobuf:"varint,2,opt,name=reused_users,json=reusedUsers,proto3" json:"reused_users,omitempty"` // 邮箱已存在、直接复用的用户数
+	Questions   int64                  `protobuf:"varint,3,opt,name=questions,proto3" json:"questions,omitempty"`
+	Answers     int64                  `protobuf:"varint,4,opt,name=answers,proto3" json:"answers,omitempty"`
+	Comments    int64                  `protobuf:"varint,5,opt,name=comments,proto3" json:"comments,omitempty"`
+	Votes       int64                  `protobuf:"varint,6,opt,name=votes,proto3" json:"votes,omitempty"`
+	// 用户名与目标实例的已有用户冲突（不区分大小写）而被改名的用户
+	RenamedUsers         []*RenamedUser `protobuf:"bytes,7,rep,name=renamed_users,json=renamedUsers,proto3" json:"renamed_users,omitempty"`
+	UsersWithoutPassword int64          `protobuf:"varint,8,opt,name=users_without_password,json=usersWithoutPassword,proto3" json:"users_without_password,omitempty"` // 导入时没有密码哈希、需要重设密码的新用户数
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ImportContentResponse) Reset() {
+	*x = ImportContentResponse{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportContentResponse) ProtoMessage() {}
+
+func (x *ImportContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportContentResponse.ProtoReflect.Descriptor instead.
+func (*ImportContentResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ImportContentResponse) GetUsers() int64 {
+	if x != nil {
+		return x.Users
+	}
+	return 0
+}
+
+func (x *ImportContentResponse) GetReusedUsers() int64 {
+	if x != nil {
+		return x.ReusedUsers
+	}
+	return 0
+}
+
+func (x *ImportContentResponse) GetQuestions() int64 {
+	if x != nil {
+		return x.Questions
+	}
+	return 0
+}
+
+func (x *ImportContentResponse) GetAnswers() int64 {
+	if x != nil {
+		return x.Answers
+	}
+	return 0
+}
+
+func (x *ImportContentResponse) GetComments() int64 {
+	if x != nil {
+		return x.Comments
+	}
+	return 0
+}
+
+func (x *ImportContentResponse) GetVotes() int64 {
+	if x != nil {
+		return x.Votes
+	}
+	return 0
+}
+
+func (x *ImportContentResponse) GetRenamedUsers() []*RenamedUser {
+	if x != nil {
+		return x.RenamedUsers
+	}
+	return nil
+}
+
+func (x *ImportContentResponse) GetUsersWithoutPassword() int64 {
+	if x != nil {
+		return x.UsersWithoutPassword
+	}
+	return 0
+}
+
+// RenamedUser 是导入时被改名的用户
+type RenamedUser struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	OriginalId       int64                  `protobuf:"varint,1,opt,name=original_id,json=originalId,proto3" json:"original_id,omitempty"` // 导入文件中的用户ID
+	OriginalUsername string                 `protobuf:"bytes,2,opt,name=original_username,json=originalUsername,proto3" json:"original_username,omitempty"`
+	Username         string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`            // 实际使用的用户名
+	UserId           int64                  `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 新实例中的用户ID
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RenamedUser) Reset() {
+	*x = RenamedUser{}
+	mi := &file_api_proto_qa_qa_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenamedUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenamedUser) ProtoMessage() {}
+
+func (x *RenamedUser) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_qa_qa_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenamedUser.ProtoReflect.Descriptor instead.
+func (*RenamedUser) Descriptor() ([]byte, []int) {
+	return file_api_proto_qa_qa_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *RenamedUser) GetOriginalId() int64 {
+	if x != nil {
+		return x.OriginalId
+	}
+	return 0
+}
+
+func (x *RenamedUser) GetOriginalUsername() string {
+	if x != nil {
+		return x.OriginalUsername
+	}
+	return ""
+}
+
+func (x *RenamedUser) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *RenamedUser) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
 	}
 	return 0
 }
@@ -1528,7 +3967,7 @@ var File_api_proto_qa_qa_proto protoreflect.FileDescriptor
 
 const file_api_proto_qa_qa_proto_rawDesc = "" +
 	"\n" +
-	"\x15api/proto/qa/qa.proto\x12\x02qa\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/api/annotations.proto\"\xd9\x01\n" +
+	"\x15api/proto/qa/qa.proto\x12\x02qa\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a\x1cgoogle/api/annotations.proto\"\xaa\x02\n" +
 	"\bQuestion\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
@@ -1537,7 +3976,9 @@ const file_api_proto_qa_qa_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa5\x02\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12,\n" +
+	"\x12accepted_answer_id\x18\a \x01(\x03R\x10acceptedAnswerId\x12!\n" +
+	"\fis_anonymous\x18\b \x01(\bR\visAnonymous\"\xb1\x04\n" +
 	"\x10QuestionResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
@@ -1549,7 +3990,14 @@ const file_api_proto_qa_qa_proto_rawDesc = "" +
 	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1f\n" +
 	"\vauthor_name\x18\a \x01(\tR\n" +
 	"authorName\x12!\n" +
-	"\fanswer_count\x18\b \x01(\x03R\vanswerCount\"\x85\x02\n" +
+	"\fanswer_count\x18\b \x01(\x03R\vanswerCount\x12,\n" +
+	"\x12accepted_answer_id\x18\t \x01(\x03R\x10acceptedAnswerId\x12#\n" +
+	"\rcomment_count\x18\n" +
+	" \x01(\x03R\fcommentCount\x12D\n" +
+	"\x10last_activity_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x0elastActivityAt\x12!\n" +
+	"\fis_anonymous\x18\f \x01(\bR\visAnonymous\x12\"\n" +
+	"\rauthor_is_bot\x18\r \x01(\bR\vauthorIsBot\x12*\n" +
+	"\x11author_avatar_url\x18\x0e \x01(\tR\x0fauthorAvatarUrl\"\xa8\x02\n" +
 	"\x06Answer\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
 	"\vquestion_id\x18\x02 \x01(\x03R\n" +
@@ -1560,7 +4008,8 @@ const file_api_proto_qa_qa_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xd6\x02\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12!\n" +
+	"\fis_anonymous\x18\b \x01(\bR\visAnonymous\"\xc9\x03\n" +
 	"\x0eAnswerResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
 	"\vquestion_id\x18\x02 \x01(\x03R\n" +
@@ -1573,7 +4022,11 @@ const file_api_proto_qa_qa_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1a\n" +
 	"\busername\x18\b \x01(\tR\busername\x12+\n" +
-	"\x12is_upvoted_by_user\x18\t \x01(\bR\x0fisUpvotedByUser\"\xdf\x01\n" +
+	"\x12is_upvoted_by_user\x18\t \x01(\bR\x0fisUpvotedByUser\x12!\n" +
+	"\fis_anonymous\x18\n" +
+	" \x01(\bR\visAnonymous\x12\"\n" +
+	"\rauthor_is_bot\x18\v \x01(\bR\vauthorIsBot\x12*\n" +
+	"\x11author_avatar_url\x18\f \x01(\tR\x0fauthorAvatarUrl\"\xdf\x01\n" +
 	"\aComment\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
 	"\tanswer_id\x18\x02 \x01(\x03R\banswerId\x12\x17\n" +
@@ -1582,7 +4035,7 @@ const file_api_proto_qa_qa_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x83\x02\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xd3\x02\n" +
 	"\x0fCommentResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
 	"\tanswer_id\x18\x02 \x01(\x03R\banswerId\x12\x17\n" +
@@ -1592,19 +4045,42 @@ const file_api_proto_qa_qa_proto_rawDesc = "" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1a\n" +
-	"\busername\x18\a \x01(\tR\busername\"G\n" +
+	"\busername\x18\a \x01(\tR\busername\x12\"\n" +
+	"\rauthor_is_bot\x18\b \x01(\bR\vauthorIsBot\x12*\n" +
+	"\x11author_avatar_url\x18\t \x01(\tR\x0fauthorAvatarUrl\"e\n" +
 	"\x15CreateQuestionRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
-	"\acontent\x18\x02 \x01(\tR\acontent\"$\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1c\n" +
+	"\tanonymous\x18\x03 \x01(\bR\tanonymous\"$\n" +
 	"\x12GetQuestionRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\"G\n" +
 	"\x14ListQuestionsRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"l\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"g\n" +
+	"\x1cListTrendingQuestionsRequest\x12\x16\n" +
+	"\x06window\x18\x01 \x01(\tR\x06window\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"3\n" +
+	"\x18ReconcileCountersRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\"l\n" +
+	"\fCounterDrift\x12\x18\n" +
+	"\acounter\x18\x01 \x01(\tR\acounter\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06stored\x18\x03 \x01(\x03R\x06stored\x12\x1a\n" +
+	"\bexpected\x18\x04 \x01(\x03R\bexpected\"f\n" +
+	"\x19ReconcileCountersResponse\x12(\n" +
+	"\x06drifts\x18\x01 \x03(\v2\x10.qa.CounterDriftR\x06drifts\x12\x1f\n" +
+	"\vfixed_count\x18\x02 \x01(\x05R\n" +
+	"fixedCount\"l\n" +
 	"\x15ListQuestionsResponse\x122\n" +
 	"\tquestions\x18\x01 \x03(\v2\x14.qa.QuestionResponseR\tquestions\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x03R\n" +
-	"totalCount\"\x94\x01\n" +
+	"totalCount\",\n" +
+	"\x18BatchGetQuestionsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"s\n" +
+	"\x19BatchGetQuestionsResponse\x122\n" +
+	"\tquestions\x18\x01 \x03(\v2\x14.qa.QuestionResponseR\tquestions\x12\"\n" +
+	"\rnot_found_ids\x18\x02 \x03(\x03R\vnotFoundIds\"\x94\x01\n" +
 	"\x15UpdateQuestionRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
@@ -1612,11 +4088,17 @@ const file_api_proto_qa_qa_proto_rawDesc = "" +
 	"\vupdate_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
 	"updateMask\"'\n" +
 	"\x15DeleteQuestionRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"P\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"n\n" +
 	"\x13CreateAnswerRequest\x12\x1f\n" +
 	"\vquestion_id\x18\x01 \x01(\x03R\n" +
 	"questionId\x12\x18\n" +
-	"\acontent\x18\x02 \x01(\tR\acontent\"|\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1c\n" +
+	"\tanonymous\x18\x03 \x01(\bR\tanonymous\"*\n" +
+	"\x16BatchGetAnswersRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"k\n" +
+	"\x17BatchGetAnswersResponse\x12,\n" +
+	"\aanswers\x18\x01 \x03(\v2\x12.qa.AnswerResponseR\aanswers\x12\"\n" +
+	"\rnot_found_ids\x18\x02 \x03(\x03R\vnotFoundIds\"|\n" +
 	"\x13UpdateAnswerRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12;\n" +
@@ -1654,23 +4136,212 @@ const file_api_proto_qa_qa_proto_rawDesc = "" +
 	"\x13UpvoteAnswerRequest\x12\x1b\n" +
 	"\tanswer_id\x18\x01 \x01(\x03R\banswerId\"4\n" +
 	"\x15DownvoteAnswerRequest\x12\x1b\n" +
-	"\tanswer_id\x18\x01 \x01(\x03R\banswerId2\x9a\f\n" +
+	"\tanswer_id\x18\x01 \x01(\x03R\banswerId\"7\n" +
+	"\x18RetractAnswerVoteRequest\x12\x1b\n" +
+	"\tanswer_id\x18\x01 \x01(\x03R\banswerId\"2\n" +
+	"\x13AcceptAnswerRequest\x12\x1b\n" +
+	"\tanswer_id\x18\x01 \x01(\x03R\banswerId\"d\n" +
+	"\x18ListUserQuestionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"b\n" +
+	"\x16ListUserAnswersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\xf7\x02\n" +
+	"\x12UserAnswerResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vquestion_id\x18\x02 \x01(\x03R\n" +
+	"questionId\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\x03R\x06userId\x12!\n" +
+	"\fupvote_count\x18\x05 \x01(\x05R\vupvoteCount\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1a\n" +
+	"\busername\x18\b \x01(\tR\busername\x12%\n" +
+	"\x0equestion_title\x18\t \x01(\tR\rquestionTitle\x12!\n" +
+	"\fis_anonymous\x18\n" +
+	" \x01(\bR\visAnonymous\"l\n" +
+	"\x17ListUserAnswersResponse\x120\n" +
+	"\aanswers\x18\x01 \x03(\v2\x16.qa.UserAnswerResponseR\aanswers\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\"c\n" +
+	"\x17ListUserCommentsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\xcf\x02\n" +
+	"\x13UserCommentResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\tanswer_id\x18\x02 \x01(\x03R\banswerId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x03R\x06userId\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1a\n" +
+	"\busername\x18\a \x01(\tR\busername\x12\x1f\n" +
+	"\vquestion_id\x18\b \x01(\x03R\n" +
+	"questionId\x12%\n" +
+	"\x0equestion_title\x18\t \x01(\tR\rquestionTitle\"p\n" +
+	"\x18ListUserCommentsResponse\x123\n" +
+	"\bcomments\x18\x01 \x03(\v2\x17.qa.UserCommentResponseR\bcomments\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\"b\n" +
+	"\x16GetUserActivityRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\xcf\x01\n" +
+	"\fActivityItem\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vquestion_id\x18\x03 \x01(\x03R\n" +
+	"questionId\x12%\n" +
+	"\x0equestion_title\x18\x04 \x01(\tR\rquestionTitle\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"l\n" +
+	"\x17GetUserActivityResponse\x120\n" +
+	"\n" +
+	"activities\x18\x01 \x03(\v2\x10.qa.ActivityItemR\n" +
+	"activities\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\"\x85\x04\n" +
+	"\rSuggestedEdit\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1f\n" +
+	"\vtarget_type\x18\x02 \x01(\tR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\x03R\btargetId\x12\x1f\n" +
+	"\vproposer_id\x18\x04 \x01(\x03R\n" +
+	"proposerId\x12#\n" +
+	"\rproposer_name\x18\x05 \x01(\tR\fproposerName\x12\x1d\n" +
+	"\n" +
+	"base_title\x18\x06 \x01(\tR\tbaseTitle\x12!\n" +
+	"\fbase_content\x18\a \x01(\tR\vbaseContent\x12\x14\n" +
+	"\x05title\x18\b \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\t \x01(\tR\acontent\x12\x16\n" +
+	"\x06reason\x18\n" +
+	" \x01(\tR\x06reason\x12\x16\n" +
+	"\x06status\x18\v \x01(\tR\x06status\x12\x1f\n" +
+	"\vreviewer_id\x18\f \x01(\x03R\n" +
+	"reviewerId\x12%\n" +
+	"\x0ereview_comment\x18\r \x01(\tR\rreviewComment\x12;\n" +
+	"\vreviewed_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reviewedAt\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x9a\x01\n" +
+	"\x12SuggestEditRequest\x12\x1f\n" +
+	"\vtarget_type\x18\x01 \x01(\tR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\x03R\btargetId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\"\xa2\x01\n" +
+	"\x19ListSuggestedEditsRequest\x12\x1f\n" +
+	"\vtarget_type\x18\x01 \x01(\tR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\x03R\btargetId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\"f\n" +
+	"\x1aListSuggestedEditsResponse\x12'\n" +
+	"\x05edits\x18\x01 \x03(\v2\x11.qa.SuggestedEditR\x05edits\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\"`\n" +
+	"\x1aReviewSuggestedEditRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\aapprove\x18\x02 \x01(\bR\aapprove\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\"j\n" +
+	"\x12FlagContentRequest\x12\x1f\n" +
+	"\vtarget_type\x18\x01 \x01(\tR\n" +
+	"targetType\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\x03R\btargetId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"N\n" +
+	"\x14ExportContentRequest\x126\n" +
+	"\x17include_password_hashes\x18\x01 \x01(\bR\x15includePasswordHashes\"s\n" +
+	"\rContentHeader\x12%\n" +
+	"\x0eformat_version\x18\x01 \x01(\x05R\rformatVersion\x12;\n" +
+	"\vexported_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"exportedAt\"\xf5\x02\n" +
+	"\vContentUser\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x10\n" +
+	"\x03bio\x18\x04 \x01(\tR\x03bio\x12#\n" +
+	"\rpassword_hash\x18\x05 \x01(\tR\fpasswordHash\x12\x12\n" +
+	"\x04role\x18\x06 \x01(\tR\x04role\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12F\n" +
+	"\x11email_verified_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x0femailVerifiedAt\x12\x1b\n" +
+	"\tuser_type\x18\t \x01(\tR\buserType\x12\x1a\n" +
+	"\blanguage\x18\n" +
+	" \x01(\tR\blanguage\x12\x1d\n" +
+	"\n" +
+	"avatar_key\x18\v \x01(\tR\tavatarKey\"\xab\x01\n" +
+	"\vContentVote\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
+	"\tanswer_id\x18\x02 \x01(\x03R\banswerId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x03R\x06userId\x12\x1b\n" +
+	"\tis_upvote\x18\x04 \x01(\bR\bisUpvote\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x8f\x02\n" +
+	"\rContentRecord\x12+\n" +
+	"\x06header\x18\x01 \x01(\v2\x11.qa.ContentHeaderH\x00R\x06header\x12%\n" +
+	"\x04user\x18\x02 \x01(\v2\x0f.qa.ContentUserH\x00R\x04user\x12*\n" +
+	"\bquestion\x18\x03 \x01(\v2\f.qa.QuestionH\x00R\bquestion\x12$\n" +
+	"\x06answer\x18\x04 \x01(\v2\n" +
+	".qa.AnswerH\x00R\x06answer\x12'\n" +
+	"\acomment\x18\x05 \x01(\v2\v.qa.CommentH\x00R\acomment\x12%\n" +
+	"\x04vote\x18\x06 \x01(\v2\x0f.qa.ContentVoteH\x00R\x04voteB\b\n" +
+	"\x06record\"\xa6\x02\n" +
+	"\x15ImportContentResponse\x12\x14\n" +
+	"\x05users\x18\x01 \x01(\x03R\x05users\x12!\n" +
+	"\freused_users\x18\x02 \x01(\x03R\vreusedUsers\x12\x1c\n" +
+	"\tquestions\x18\x03 \x01(\x03R\tquestions\x12\x18\n" +
+	"\aanswers\x18\x04 \x01(\x03R\aanswers\x12\x1a\n" +
+	"\bcomments\x18\x05 \x01(\x03R\bcomments\x12\x14\n" +
+	"\x05votes\x18\x06 \x01(\x03R\x05votes\x124\n" +
+	"\rrenamed_users\x18\a \x03(\v2\x0f.qa.RenamedUserR\frenamedUsers\x124\n" +
+	"\x16users_without_password\x18\b \x01(\x03R\x14usersWithoutPassword\"\x90\x01\n" +
+	"\vRenamedUser\x12\x1f\n" +
+	"\voriginal_id\x18\x01 \x01(\x03R\n" +
+	"originalId\x12+\n" +
+	"\x11original_username\x18\x02 \x01(\tR\x10originalUsername\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\x03R\x06userId2\xe8\x19\n" +
 	"\tQAService\x12_\n" +
 	"\x0eCreateQuestion\x12\x19.qa.CreateQuestionRequest\x1a\x14.qa.QuestionResponse\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/api/v1/questions\x12[\n" +
 	"\vGetQuestion\x12\x16.qa.GetQuestionRequest\x1a\x14.qa.QuestionResponse\"\x1e\x82\xd3\xe4\x93\x02\x18\x12\x16/api/v1/questions/{id}\x12_\n" +
-	"\rListQuestions\x12\x18.qa.ListQuestionsRequest\x1a\x19.qa.ListQuestionsResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/api/v1/questions\x12d\n" +
+	"\rListQuestions\x12\x18.qa.ListQuestionsRequest\x1a\x19.qa.ListQuestionsResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/api/v1/questions\x12x\n" +
+	"\x15ListTrendingQuestions\x12 .qa.ListTrendingQuestionsRequest\x1a\x19.qa.ListQuestionsResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/api/v1/questions:trending\x12\x80\x01\n" +
+	"\x11ReconcileCounters\x12\x1c.qa.ReconcileCountersRequest\x1a\x1d.qa.ReconcileCountersResponse\".\x82\xd3\xe4\x93\x02(:\x01*\"#/api/v1/questions:reconcileCounters\x12w\n" +
+	"\x11BatchGetQuestions\x12\x1c.qa.BatchGetQuestionsRequest\x1a\x1d.qa.BatchGetQuestionsResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/api/v1/questions:batchGet\x12d\n" +
 	"\x0eUpdateQuestion\x12\x19.qa.UpdateQuestionRequest\x1a\x14.qa.QuestionResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\x1a\x16/api/v1/questions/{id}\x12c\n" +
 	"\x0eDeleteQuestion\x12\x19.qa.DeleteQuestionRequest\x1a\x16.google.protobuf.Empty\"\x1e\x82\xd3\xe4\x93\x02\x18*\x16/api/v1/questions/{id}\x12o\n" +
-	"\fCreateAnswer\x12\x17.qa.CreateAnswerRequest\x1a\x12.qa.AnswerResponse\"2\x82\xd3\xe4\x93\x02,:\x01*\"'/api/v1/questions/{question_id}/answers\x12\\\n" +
+	"\fCreateAnswer\x12\x17.qa.CreateAnswerRequest\x1a\x12.qa.AnswerResponse\"2\x82\xd3\xe4\x93\x02,:\x01*\"'/api/v1/questions/{question_id}/answers\x12o\n" +
+	"\x0fBatchGetAnswers\x12\x1a.qa.BatchGetAnswersRequest\x1a\x1b.qa.BatchGetAnswersResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/api/v1/answers:batchGet\x12\\\n" +
 	"\fUpdateAnswer\x12\x17.qa.UpdateAnswerRequest\x1a\x12.qa.AnswerResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\x1a\x14/api/v1/answers/{id}\x12]\n" +
 	"\fDeleteAnswer\x12\x17.qa.DeleteAnswerRequest\x1a\x16.google.protobuf.Empty\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/api/v1/answers/{id}\x12o\n" +
 	"\vListAnswers\x12\x16.qa.ListAnswersRequest\x1a\x17.qa.ListAnswersResponse\"/\x82\xd3\xe4\x93\x02)\x12'/api/v1/questions/{question_id}/answers\x12o\n" +
 	"\rCreateComment\x12\x18.qa.CreateCommentRequest\x1a\x13.qa.CommentResponse\"/\x82\xd3\xe4\x93\x02):\x01*\"$/api/v1/answers/{answer_id}/comments\x12`\n" +
 	"\rUpdateComment\x12\x18.qa.UpdateCommentRequest\x1a\x13.qa.CommentResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\x1a\x15/api/v1/comments/{id}\x12`\n" +
 	"\rDeleteComment\x12\x18.qa.DeleteCommentRequest\x1a\x16.google.protobuf.Empty\"\x1d\x82\xd3\xe4\x93\x02\x17*\x15/api/v1/comments/{id}\x12o\n" +
-	"\fListComments\x12\x17.qa.ListCommentsRequest\x1a\x18.qa.ListCommentsResponse\",\x82\xd3\xe4\x93\x02&\x12$/api/v1/answers/{answer_id}/comments\x12k\n" +
+	"\fListComments\x12\x17.qa.ListCommentsRequest\x1a\x18.qa.ListCommentsResponse\",\x82\xd3\xe4\x93\x02&\x12$/api/v1/answers/{answer_id}/comments\x12w\n" +
+	"\x11ListUserQuestions\x12\x1c.qa.ListUserQuestionsRequest\x1a\x19.qa.ListQuestionsResponse\")\x82\xd3\xe4\x93\x02#\x12!/api/v1/users/{user_id}/questions\x12s\n" +
+	"\x0fListUserAnswers\x12\x1a.qa.ListUserAnswersRequest\x1a\x1b.qa.ListUserAnswersResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/users/{user_id}/answers\x12w\n" +
+	"\x10ListUserComments\x12\x1b.qa.ListUserCommentsRequest\x1a\x1c.qa.ListUserCommentsResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /api/v1/users/{user_id}/comments\x12t\n" +
+	"\x0fGetUserActivity\x12\x1a.qa.GetUserActivityRequest\x1a\x1b.qa.GetUserActivityResponse\"(\x82\xd3\xe4\x93\x02\"\x12 /api/v1/users/{user_id}/activity\x12k\n" +
 	"\fUpvoteAnswer\x12\x17.qa.UpvoteAnswerRequest\x1a\x16.google.protobuf.Empty\"*\x82\xd3\xe4\x93\x02$\"\"/api/v1/answers/{answer_id}/upvote\x12q\n" +
-	"\x0eDownvoteAnswer\x12\x19.qa.DownvoteAnswerRequest\x1a\x16.google.protobuf.Empty\",\x82\xd3\xe4\x93\x02&\"$/api/v1/answers/{answer_id}/downvoteB\aZ\x05./;qab\x06proto3"
+	"\x0eDownvoteAnswer\x12\x19.qa.DownvoteAnswerRequest\x1a\x16.google.protobuf.Empty\",\x82\xd3\xe4\x93\x02&\"$/api/v1/answers/{answer_id}/downvote\x12s\n" +
+	"\x11RetractAnswerVote\x12\x1c.qa.RetractAnswerVoteRequest\x1a\x16.google.protobuf.Empty\"(\x82\xd3\xe4\x93\x02\"* /api/v1/answers/{answer_id}/vote\x12k\n" +
+	"\fAcceptAnswer\x12\x17.qa.AcceptAnswerRequest\x1a\x16.google.protobuf.Empty\"*\x82\xd3\xe4\x93\x02$\"\"/api/v1/answers/{answer_id}/accept\x12\\\n" +
+	"\vSuggestEdit\x12\x16.qa.SuggestEditRequest\x1a\x11.qa.SuggestedEdit\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/v1/suggested-edits\x12t\n" +
+	"\x12ListSuggestedEdits\x12\x1d.qa.ListSuggestedEditsRequest\x1a\x1e.qa.ListSuggestedEditsResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/suggested-edits\x12x\n" +
+	"\x13ReviewSuggestedEdit\x12\x1e.qa.ReviewSuggestedEditRequest\x1a\x11.qa.SuggestedEdit\".\x82\xd3\xe4\x93\x02(:\x01*\"#/api/v1/suggested-edits/{id}:review\x12W\n" +
+	"\vFlagContent\x12\x16.qa.FlagContentRequest\x1a\x16.google.protobuf.Empty\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/api/v1/flags\x12>\n" +
+	"\rExportContent\x12\x18.qa.ExportContentRequest\x1a\x11.qa.ContentRecord0\x01\x12?\n" +
+	"\rImportContent\x12\x11.qa.ContentRecord\x1a\x19.qa.ImportContentResponse(\x01B\aZ\x05./;qab\x06proto3"
 
 var (
 	file_api_proto_qa_qa_proto_rawDescOnce sync.Once
@@ -1684,90 +4355,181 @@ func file_api_proto_qa_qa_proto_rawDescGZIP() []byte {
 	return file_api_proto_qa_qa_proto_rawDescData
 }
 
-var file_api_proto_qa_qa_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_api_proto_qa_qa_proto_msgTypes = make([]protoimpl.MessageInfo, 57)
 var file_api_proto_qa_qa_proto_goTypes = []any{
-	(*Question)(nil),              // 0: qa.Question
-	(*QuestionResponse)(nil),      // 1: qa.QuestionResponse
-	(*Answer)(nil),                // 2: qa.Answer
-	(*AnswerResponse)(nil),        // 3: qa.AnswerResponse
-	(*Comment)(nil),               // 4: qa.Comment
-	(*CommentResponse)(nil),       // 5: qa.CommentResponse
-	(*CreateQuestionRequest)(nil), // 6: qa.CreateQuestionRequest
-	(*GetQuestionRequest)(nil),    // 7: qa.GetQuestionRequest
-	(*ListQuestionsRequest)(nil),  // 8: qa.ListQuestionsRequest
-	(*ListQuestionsResponse)(nil), // 9: qa.ListQuestionsResponse
-	(*UpdateQuestionRequest)(nil), // 10: qa.UpdateQuestionRequest
-	(*DeleteQuestionRequest)(nil), // 11: qa.DeleteQuestionRequest
-	(*CreateAnswerRequest)(nil),   // 12: qa.CreateAnswerRequest
-	(*UpdateAnswerRequest)(nil),   // 13: qa.UpdateAnswerRequest
-	(*DeleteAnswerRequest)(nil),   // 14: qa.DeleteAnswerRequest
-	(*ListAnswersRequest)(nil),    // 15: qa.ListAnswersRequest
-	(*ListAnswersResponse)(nil),   // 16: qa.ListAnswersResponse
-	(*CreateCommentRequest)(nil),  // 17: qa.CreateCommentRequest
-	(*UpdateCommentRequest)(nil),  // 18: qa.UpdateCommentRequest
-	(*DeleteCommentRequest)(nil),  // 19: qa.DeleteCommentRequest
-	(*ListCommentsRequest)(nil),   // 20: qa.ListCommentsRequest
-	(*ListCommentsResponse)(nil),  // 21: qa.ListCommentsResponse
-	(*UpvoteAnswerRequest)(nil),   // 22: qa.UpvoteAnswerRequest
-	(*DownvoteAnswerRequest)(nil), // 23: qa.DownvoteAnswerRequest
-	(*timestamppb.Timestamp)(nil), // 24: google.protobuf.Timestamp
-	(*fieldmaskpb.FieldMask)(nil), // 25: google.protobuf.FieldMask
-	(*emptypb.Empty)(nil),         // 26: google.protobuf.Empty
+	(*Question)(nil),                     // 0: qa.Question
+	(*QuestionResponse)(nil),             // 1: qa.QuestionResponse
+	(*Answer)(nil),                       // 2: qa.Answer
+	(*AnswerResponse)(nil),               // 3: qa.AnswerResponse
+	(*Comment)(nil),                      // 4: qa.Comment
+	(*CommentResponse)(nil),              // 5: qa.CommentResponse
+	(*CreateQuestionRequest)(nil),        // 6: qa.CreateQuestionRequest
+	(*GetQuestionRequest)(nil),           // 7: qa.GetQuestionRequest
+	(*ListQuestionsRequest)(nil),         // 8: qa.ListQuestionsRequest
+	(*ListTrendingQuestionsRequest)(nil), // 9: qa.ListTrendingQuestionsRequest
+	(*ReconcileCountersRequest)(nil),     // 10: qa.ReconcileCountersRequest
+	(*CounterDrift)(nil),                 // 11: qa.CounterDrift
+	(*ReconcileCountersResponse)(nil),    // 12: qa.ReconcileCountersResponse
+	(*ListQuestionsResponse)(nil),        // 13: qa.ListQuestionsResponse
+	(*BatchGetQuestionsRequest)(nil),     // 14: qa.BatchGetQuestionsRequest
+	(*BatchGetQuestionsResponse)(nil),    // 15: qa.BatchGetQuestionsResponse
+	(*UpdateQuestionRequest)(nil),        // 16: qa.UpdateQuestionRequest
+	(*DeleteQuestionRequest)(nil),        // 17: qa.DeleteQuestionRequest
+	(*CreateAnswerRequest)(nil),          // 18: qa.CreateAnswerRequest
+	(*BatchGetAnswersRequest)(nil),       // 19: qa.BatchGetAnswersRequest
+	(*BatchGetAnswersResponse)(nil),      // 20: qa.BatchGetAnswersResponse
+	(*UpdateAnswerRequest)(nil),          // 21: qa.UpdateAnswerRequest
+	(*DeleteAnswerRequest)(nil),          // 22: qa.DeleteAnswerRequest
+	(*ListAnswersRequest)(nil),           // 23: qa.ListAnswersRequest
+	(*ListAnswersResponse)(nil),          // 24: qa.ListAnswersResponse
+	(*CreateCommentRequest)(nil),         // 25: qa.CreateCommentRequest
+	(*UpdateCommentRequest)(nil),         // 26: qa.UpdateCommentRequest
+	(*DeleteCommentRequest)(nil),         // 27: qa.DeleteCommentRequest
+	(*ListCommentsRequest)(nil),          // 28: qa.ListCommentsRequest
+	(*ListCommentsResponse)(nil),         // 29: qa.ListCommentsResponse
+	(*UpvoteAnswerRequest)(nil),          // 30: qa.UpvoteAnswerRequest
+	(*DownvoteAnswerRequest)(nil),        // 31: qa.DownvoteAnswerRequest
+	(*RetractAnswerVoteRequest)(nil),     // 32: qa.RetractAnswerVoteRequest
+	(*AcceptAnswerRequest)(nil),          // 33: qa.AcceptAnswerRequest
+	(*ListUserQuestionsRequest)(nil),     // 34: qa.ListUserQuestionsRequest
+	(*ListUserAnswersRequest)(nil),       // 35: qa.ListUserAnswersRequest
+	(*UserAnswerResponse)(nil),           // 36: qa.UserAnswerResponse
+	(*ListUserAnswersResponse)(nil),      // 37: qa.ListUserAnswersResponse
+	(*ListUserCommentsRequest)(nil),      // 38: qa.ListUserCommentsRequest
+	(*UserCommentResponse)(nil),          // 39: qa.UserCommentResponse
+	(*ListUserCommentsResponse)(nil),     // 40: qa.ListUserCommentsResponse
+	(*GetUserActivityRequest)(nil),       // 41: qa.GetUserActivityRequest
+	(*ActivityItem)(nil),                 // 42: qa.ActivityItem
+	(*GetUserActivityResponse)(nil),      // 43: qa.GetUserActivityResponse
+	(*SuggestedEdit)(nil),                // 44: qa.SuggestedEdit
+	(*SuggestEditRequest)(nil),           // 45: qa.SuggestEditRequest
+	(*ListSuggestedEditsRequest)(nil),    // 46: qa.ListSuggestedEditsRequest
+	(*ListSuggestedEditsResponse)(nil),   // 47: qa.ListSuggestedEditsResponse
+	(*ReviewSuggestedEditRequest)(nil),   // 48: qa.ReviewSuggestedEditRequest
+	(*FlagContentRequest)(nil),           // 49: qa.FlagContentRequest
+	(*ExportContentRequest)(nil),         // 50: qa.ExportContentRequest
+	(*ContentHeader)(nil),                // 51: qa.ContentHeader
+	(*ContentUser)(nil),                  // 52: qa.ContentUser
+	(*ContentVote)(nil),                  // 53: qa.ContentVote
+	(*ContentRecord)(nil),                // 54: qa.ContentRecord
+	(*ImportContentResponse)(nil),        // 55: qa.ImportContentResponse
+	(*RenamedUser)(nil),                  // 56: qa.RenamedUser
+	(*timestamppb.Timestamp)(nil),        // 57: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),        // 58: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),                // 59: google.protobuf.Empty
 }
 var file_api_proto_qa_qa_proto_depIdxs = []int32{
-	24, // 0: qa.Question.created_at:type_name -> google.protobuf.Timestamp
-	24, // 1: qa.Question.updated_at:type_name -> google.protobuf.Timestamp
-	24, // 2: qa.QuestionResponse.created_at:type_name -> google.protobuf.Timestamp
-	24, // 3: qa.QuestionResponse.updated_at:type_name -> google.protobuf.Timestamp
-	24, // 4: qa.Answer.created_at:type_name -> google.protobuf.Timestamp
-	24, // 5: qa.Answer.updated_at:type_name -> google.protobuf.Timestamp
-	24, // 6: qa.AnswerResponse.created_at:type_name -> google.protobuf.Timestamp
-	24, // 7: qa.AnswerResponse.updated_at:type_name -> google.protobuf.Timestamp
-	24, // 8: qa.Comment.created_at:type_name -> google.protobuf.Timestamp
-	24, // 9: qa.Comment.updated_at:type_name -> google.protobuf.Timestamp
-	24, // 10: qa.CommentResponse.created_at:type_name -> google.protobuf.Timestamp
-	24, // 11: qa.CommentResponse.updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 12: qa.ListQuestionsResponse.questions:type_name -> qa.QuestionResponse
-	25, // 13: qa.UpdateQuestionRequest.update_mask:type_name -> google.protobuf.FieldMask
-	25, // 14: qa.UpdateAnswerRequest.update_mask:type_name -> google.protobuf.FieldMask
-	3,  // 15: qa.ListAnswersResponse.answers:type_name -> qa.AnswerResponse
-	25, // 16: qa.UpdateCommentRequest.update_mask:type_name -> google.protobuf.FieldMask
-	5,  // 17: qa.ListCommentsResponse.comments:type_name -> qa.CommentResponse
-	6,  // 18: qa.QAService.CreateQuestion:input_type -> qa.CreateQuestionRequest
-	7,  // 19: qa.QAService.GetQuestion:input_type -> qa.GetQuestionRequest
-	8,  // 20: qa.QAService.ListQuestions:input_type -> qa.ListQuestionsRequest
-	10, // 21: qa.QAService.UpdateQuestion:input_type -> qa.UpdateQuestionRequest
-	11, // 22: qa.QAService.DeleteQuestion:input_type -> qa.DeleteQuestionRequest
-	12, // 23: qa.QAService.CreateAnswer:input_type -> qa.CreateAnswerRequest
-	13, // 24: qa.QAService.UpdateAnswer:input_type -> qa.UpdateAnswerRequest
-	14, // 25: qa.QAService.DeleteAnswer:input_type -> qa.DeleteAnswerRequest
-	15, // 26: qa.QAService.ListAnswers:input_type -> qa.ListAnswersRequest
-	17, // 27: qa.QAService.CreateComment:input_type -> qa.CreateCommentRequest
-	18, // 28: qa.QAService.UpdateComment:input_type -> qa.UpdateCommentRequest
-	19, // 29: qa.QAService.DeleteComment:input_type -> qa.DeleteCommentRequest
-	20, // 30: qa.QAService.ListComments:input_type -> qa.ListCommentsRequest
-	22, // 31: qa.QAService.UpvoteAnswer:input_type -> qa.UpvoteAnswerRequest
-	23, // 32: qa.QAService.DownvoteAnswer:input_type -> qa.DownvoteAnswerRequest
-	1,  // 33: qa.QAService.CreateQuestion:output_type -> qa.QuestionResponse
-	1,  // 34: qa.QAService.GetQuestion:output_type -> qa.QuestionResponse
-	9,  // 35: qa.QAService.ListQuestions:output_type -> qa.ListQuestionsResponse
-	1,  // 36: qa.QAService.UpdateQuestion:output_type -> qa.QuestionResponse
-	26, // 37: qa.QAService.DeleteQuestion:output_type -> google.protobuf.Empty
-	3,  // 38: qa.QAService.CreateAnswer:output_type -> qa.AnswerResponse
-	3,  // 39: qa.QAService.UpdateAnswer:output_type -> qa.AnswerResponse
-	26, // 40: qa.QAService.DeleteAnswer:output_type -> google.protobuf.Empty
-	16, // 41: qa.QAService.ListAnswers:output_type -> qa.ListAnswersResponse
-	5,  // 42: qa.QAService.CreateComment:output_type -> qa.CommentResponse
-	5,  // 43: qa.QAService.UpdateComment:output_type -> qa.CommentResponse
-	26, // 44: qa.QAService.DeleteComment:output_type -> google.protobuf.Empty
-	21, // 45: qa.QAService.ListComments:output_type -> qa.ListCommentsResponse
-	26, // 46: qa.QAService.UpvoteAnswer:output_type -> google.protobuf.Empty
-	26, // 47: qa.QAService.DownvoteAnswer:output_type -> google.protobuf.Empty
-	33, // [33:48] is the sub-list for method output_type
-	18, // [18:33] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	57, // 0: qa.Question.created_at:type_name -> google.protobuf.Timestamp
+	57, // 1: qa.Question.updated_at:type_name -> google.protobuf.Timestamp
+	57, // 2: qa.QuestionResponse.created_at:type_name -> google.protobuf.Timestamp
+	57, // 3: qa.QuestionResponse.updated_at:type_name -> google.protobuf.Timestamp
+	57, // 4: qa.QuestionResponse.last_activity_at:type_name -> google.protobuf.Timestamp
+	57, // 5: qa.Answer.created_at:type_name -> google.protobuf.Timestamp
+	57, // 6: qa.Answer.updated_at:type_name -> google.protobuf.Timestamp
+	57, // 7: qa.AnswerResponse.created_at:type_name -> google.protobuf.Timestamp
+	57, // 8: qa.AnswerResponse.updated_at:type_name -> google.protobuf.Timestamp
+	57, // 9: qa.Comment.created_at:type_name -> google.protobuf.Timestamp
+	57, // 10: qa.Comment.updated_at:type_name -> google.protobuf.Timestamp
+	57, // 11: qa.CommentResponse.created_at:type_name -> google.protobuf.Timestamp
+	57, // 12: qa.CommentResponse.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 13: qa.ReconcileCountersResponse.drifts:type_name -> qa.CounterDrift
+	1,  // 14: qa.ListQuestionsResponse.questions:type_name -> qa.QuestionResponse
+	1,  // 15: qa.BatchGetQuestionsResponse.questions:type_name -> qa.QuestionResponse
+	58, // 16: qa.UpdateQuestionRequest.update_mask:type_name -> google.protobuf.FieldMask
+	3,  // 17: qa.BatchGetAnswersResponse.answers:type_name -> qa.AnswerResponse
+	58, // 18: qa.UpdateAnswerRequest.update_mask:type_name -> google.protobuf.FieldMask
+	3,  // 19: qa.ListAnswersResponse.answers:type_name -> qa.AnswerResponse
+	58, // 20: qa.UpdateCommentRequest.update_mask:type_name -> google.protobuf.FieldMask
+	5,  // 21: qa.ListCommentsResponse.comments:type_name -> qa.CommentResponse
+	57, // 22: qa.UserAnswerResponse.created_at:type_name -> google.protobuf.Timestamp
+	57, // 23: qa.UserAnswerResponse.updated_at:type_name -> google.protobuf.Timestamp
+	36, // 24: qa.ListUserAnswersResponse.answers:type_name -> qa.UserAnswerResponse
+	57, // 25: qa.UserCommentResponse.created_at:type_name -> google.protobuf.Timestamp
+	57, // 26: qa.UserCommentResponse.updated_at:type_name -> google.protobuf.Timestamp
+	39, // 27: qa.ListUserCommentsResponse.comments:type_name -> qa.UserCommentResponse
+	57, // 28: qa.ActivityItem.created_at:type_name -> google.protobuf.Timestamp
+	42, // 29: qa.GetUserActivityResponse.activities:type_name -> qa.ActivityItem
+	57, // 30: qa.SuggestedEdit.reviewed_at:type_name -> google.protobuf.Timestamp
+	57, // 31: qa.SuggestedEdit.created_at:type_name -> google.protobuf.Timestamp
+	44, // 32: qa.ListSuggestedEditsResponse.edits:type_name -> qa.SuggestedEdit
+	57, // 33: qa.ContentHeader.exported_at:type_name -> google.protobuf.Timestamp
+	57, // 34: qa.ContentUser.created_at:type_name -> google.protobuf.Timestamp
+	57, // 35: qa.ContentUser.email_verified_at:type_name -> google.protobuf.Timestamp
+	57, // 36: qa.ContentVote.created_at:type_name -> google.protobuf.Timestamp
+	51, // 37: qa.ContentRecord.header:type_name -> qa.ContentHeader
+	52, // 38: qa.ContentRecord.user:type_name -> qa.ContentUser
+	0,  // 39: qa.ContentRecord.question:type_name -> qa.Question
+	2,  // 40: qa.ContentRecord.answer:type_name -> qa.Answer
+	4,  // 41: qa.ContentRecord.comment:type_name -> qa.Comment
+	53, // 42: qa.ContentRecord.vote:type_name -> qa.ContentVote
+	56, // 43: qa.ImportContentResponse.renamed_users:type_name -> qa.RenamedUser
+	6,  // 44: qa.QAService.CreateQuestion:input_type -> qa.CreateQuestionRequest
+	7,  // 45: qa.QAService.GetQuestion:input_type -> qa.GetQuestionRequest
+	8,  // 46: qa.QAService.ListQuestions:input_type -> qa.ListQuestionsRequest
+	9,  // 47: qa.QAService.ListTrendingQuestions:input_type -> qa.ListTrendingQuestionsRequest
+	10, // 48: qa.QAService.ReconcileCounters:input_type -> qa.ReconcileCountersRequest
+	14, // 49: qa.QAService.BatchGetQuestions:input_type -> qa.BatchGetQuestionsRequest
+	16, // 50: qa.QAService.UpdateQuestion:input_type -> qa.UpdateQuestionRequest
+	17, // 51: qa.QAService.DeleteQuestion:input_type -> qa.DeleteQuestionRequest
+	18, // 52: qa.QAService.CreateAnswer:input_type -> qa.CreateAnswerRequest
+	19, // 53: qa.QAService.BatchGetAnswers:input_type -> qa.BatchGetAnswersRequest
+	21, // 54: qa.QAService.UpdateAnswer:input_type -> qa.UpdateAnswerRequest
+	22, // 55: qa.QAService.DeleteAnswer:input_type -> qa.DeleteAnswerRequest
+	23, // 56: qa.QAService.ListAnswers:input_type -> qa.ListAnswersRequest
+	25, // 57: qa.QAService.CreateComment:input_type -> qa.CreateCommentRequest
+	26, // 58: qa.QAService.UpdateComment:input_type -> qa.UpdateCommentRequest
+	27, // 59: qa.QAService.DeleteComment:input_type -> qa.DeleteCommentRequest
+	28, // 60: qa.QAService.ListComments:input_type -> qa.ListCommentsRequest
+	34, // 61: qa.QAService.ListUserQuestions:input_type -> qa.ListUserQuestionsRequest
+	35, // 62: qa.QAService.ListUserAnswers:input_type -> qa.ListUserAnswersRequest
+	38, // 63: qa.QAService.ListUserComments:input_type -> qa.ListUserCommentsRequest
+	41, // 64: qa.QAService.GetUserActivity:input_type -> qa.GetUserActivityRequest
+	30, // 65: qa.QAService.UpvoteAnswer:input_type -> qa.UpvoteAnswerRequest
+	31, // 66: qa.QAService.DownvoteAnswer:input_type -> qa.DownvoteAnswerRequest
+	32, // 67: qa.QAService.RetractAnswerVote:input_type -> qa.RetractAnswerVoteRequest
+	33, // 68: qa.QAService.AcceptAnswer:input_type -> qa.AcceptAnswerRequest
+	45, // 69: qa.QAService.SuggestEdit:input_type -> qa.SuggestEditRequest
+	46, // 70: qa.QAService.ListSuggestedEdits:input_type -> qa.ListSuggestedEditsRequest
+	48, // 71: qa.QAService.ReviewSuggestedEdit:input_type -> qa.ReviewSuggestedEditRequest
+	49, // 72: qa.QAService.FlagContent:input_type -> qa.FlagContentRequest
+	50, // 73: qa.QAService.ExportContent:input_type -> qa.ExportContentRequest
+	54, // 74: qa.QAService.ImportContent:input_type -> qa.ContentRecord
+	1,  // 75: qa.QAService.CreateQuestion:output_type -> qa.QuestionResponse
+	1,  // 76: qa.QAService.GetQuestion:output_type -> qa.QuestionResponse
+	13, // 77: qa.QAService.ListQuestions:output_type -> qa.ListQuestionsResponse
+	13, // 78: qa.QAService.ListTrendingQuestions:output_type -> qa.ListQuestionsResponse
+	12, // 79: qa.QAService.ReconcileCounters:output_type -> qa.ReconcileCountersResponse
+	15, // 80: qa.QAService.BatchGetQuestions:output_type -> qa.BatchGetQuestionsResponse
+	1,  // 81: qa.QAService.UpdateQuestion:output_type -> qa.QuestionResponse
+	59, // 82: qa.QAService.DeleteQuestion:output_type -> google.protobuf.Empty
+	3,  // 83: qa.QAService.CreateAnswer:output_type -> qa.AnswerResponse
+	20, // 84: qa.QAService.BatchGetAnswers:output_type -> qa.BatchGetAnswersResponse
+	3,  // 85: qa.QAService.UpdateAnswer:output_type -> qa.AnswerResponse
+	59, // 86: qa.QAService.DeleteAnswer:output_type -> google.protobuf.Empty
+	24, // 87: qa.QAService.ListAnswers:output_type -> qa.ListAnswersResponse
+	5,  // 88: qa.QAService.CreateComment:output_type -> qa.CommentResponse
+	5,  // 89: qa.QAService.UpdateComment:output_type -> qa.CommentResponse
+	59, // 90: qa.QAService.DeleteComment:output_type -> google.protobuf.Empty
+	29, // 91: qa.QAService.ListComments:output_type -> qa.ListCommentsResponse
+	13, // 92: qa.QAService.ListUserQuestions:output_type -> qa.ListQuestionsResponse
+	37, // 93: qa.QAService.ListUserAnswers:output_type -> qa.ListUserAnswersResponse
+	40, // 94: qa.QAService.ListUserComments:output_type -> qa.ListUserCommentsResponse
+	43, // 95: qa.QAService.GetUserActivity:output_type -> qa.GetUserActivityResponse
+	59, // 96: qa.QAService.UpvoteAnswer:output_type -> google.protobuf.Empty
+	59, // 97: qa.QAService.DownvoteAnswer:output_type -> google.protobuf.Empty
+	59, // 98: qa.QAService.RetractAnswerVote:output_type -> google.protobuf.Empty
+	59, // 99: qa.QAService.AcceptAnswer:output_type -> google.protobuf.Empty
+	44, // 100: qa.QAService.SuggestEdit:output_type -> qa.SuggestedEdit
+	47, // 101: qa.QAService.ListSuggestedEdits:output_type -> qa.ListSuggestedEditsResponse
+	44, // 102: qa.QAService.ReviewSuggestedEdit:output_type -> qa.SuggestedEdit
+	59, // 103: qa.QAService.FlagContent:output_type -> google.protobuf.Empty
+	54, // 104: qa.QAService.ExportContent:output_type -> qa.ContentRecord
+	55, // 105: qa.QAService.ImportContent:output_type -> qa.ImportContentResponse
+	75, // [75:106] is the sub-list for method output_type
+	44, // [44:75] is the sub-list for method input_type
+	44, // [44:44] is the sub-list for extension type_name
+	44, // [44:44] is the sub-list for extension extendee
+	0,  // [0:44] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_qa_qa_proto_init() }
@@ -1775,13 +4537,21 @@ func file_api_proto_qa_qa_proto_init() {
 	if File_api_proto_qa_qa_proto != nil {
 		return
 	}
+	file_api_proto_qa_qa_proto_msgTypes[54].OneofWrappers = []any{
+		(*ContentRecord_Header)(nil),
+		(*ContentRecord_User)(nil),
+		(*ContentRecord_Question)(nil),
+		(*ContentRecord_Answer)(nil),
+		(*ContentRecord_Comment)(nil),
+		(*ContentRecord_Vote)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_qa_qa_proto_rawDesc), len(file_api_proto_qa_qa_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   24,
+			NumMessages:   57,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
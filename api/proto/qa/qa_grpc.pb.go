@@ -20,21 +20,37 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	QAService_CreateQuestion_FullMethodName = "/qa.QAService/CreateQuestion"
-	QAService_GetQuestion_FullMethodName    = "/qa.QAService/GetQuestion"
-	QAService_ListQuestions_FullMethodName  = "/qa.QAService/ListQuestions"
-	QAService_UpdateQuestion_FullMethodName = "/qa.QAService/UpdateQuestion"
-	QAService_DeleteQuestion_FullMethodName = "/qa.QAService/DeleteQuestion"
-	QAService_CreateAnswer_FullMethodName   = "/qa.QAService/CreateAnswer"
-	QAService_UpdateAnswer_FullMethodName   = "/qa.QAService/UpdateAnswer"
-	QAService_DeleteAnswer_FullMethodName   = "/qa.QAService/DeleteAnswer"
-	QAService_ListAnswers_FullMethodName    = "/qa.QAService/ListAnswers"
-	QAService_CreateComment_FullMethodName  = "/qa.QAService/CreateComment"
-	QAService_UpdateComment_FullMethodName  = "/qa.QAService/UpdateComment"
-	QAService_DeleteComment_FullMethodName  = "/qa.QAService/DeleteComment"
-	QAService_ListComments_FullMethodName   = "/qa.QAService/ListComments"
-	QAService_UpvoteAnswer_FullMethodName   = "/qa.QAService/UpvoteAnswer"
-	QAService_DownvoteAnswer_FullMethodName = "/qa.QAService/DownvoteAnswer"
+	QAService_CreateQuestion_FullMethodName        = "/qa.QAService/CreateQuestion"
+	QAService_GetQuestion_FullMethodName           = "/qa.QAService/GetQuestion"
+	QAService_ListQuestions_FullMethodName         = "/qa.QAService/ListQuestions"
+	QAService_ListTrendingQuestions_FullMethodName = "/qa.QAService/ListTrendingQuestions"
+	QAService_ReconcileCounters_FullMethodName     = "/qa.QAService/ReconcileCounters"
+	QAService_BatchGetQuestions_FullMethodName     = "/qa.QAService/BatchGetQuestions"
+	QAService_UpdateQuestion_FullMethodName        = "/qa.QAService/UpdateQuestion"
+	QAService_DeleteQuestion_FullMethodName        = "/qa.QAService/DeleteQuestion"
+	QAService_CreateAnswer_FullMethodName          = "/qa.QAService/CreateAnswer"
+	QAService_BatchGetAnswers_FullMethodName       = "/qa.QAService/BatchGetAnswers"
+	QAService_UpdateAnswer_FullMethodName          = "/qa.QAService/UpdateAnswer"
+	QAService_DeleteAnswer_FullMethodName          = "/qa.QAService/DeleteAnswer"
+	QAService_ListAnswers_FullMethodName           = "/qa.QAService/ListAnswers"
+	QAService_CreateComment_FullMethodName         = "/qa.QAService/CreateComment"
+	QAService_UpdateComment_FullMethodName         = "/qa.QAService/UpdateComment"
+	QAService_DeleteComment_FullMethodName         = "/qa.QAService/DeleteComment"
+	QAService_ListComments_FullMethodName          = "/qa.QAService/ListComments"
+	QAService_ListUserQuestions_FullMethodName     = "/qa.QAService/ListUserQuestions"
+	QAService_ListUserAnswers_FullMethodName       = "/qa.QAService/ListUserAnswers"
+	QAService_ListUserComments_FullMethodName      = "/qa.QAService/ListUserComments"
+	QAService_GetUserActivity_FullMethodName       = "/qa.QAService/GetUserActivity"
+	QAService_UpvoteAnswer_FullMethodName          = "/qa.QAService/UpvoteAnswer"
+	QAService_DownvoteAnswer_FullMethodName        = "/qa.QAService/DownvoteAnswer"
+	QAService_RetractAnswerVote_FullMethodName     = "/qa.QAService/RetractAnswerVote"
+	QAService_AcceptAnswer_FullMethodName          = "/qa.QAService/AcceptAnswer"
+	QAService_SuggestEdit_FullMethodName           = "/qa.QAService/SuggestEdit"
+	QAService_ListSuggestedEdits_FullMethodName    = "/qa.QAService/ListSuggestedEdits"
+	QAService_ReviewSuggestedEdit_FullMethodName   = "/qa.QAService/ReviewSuggestedEdit"
+	QAService_FlagContent_FullMethodName           = "/qa.QAService/FlagContent"
+	QAService_ExportContent_FullMethodName         = "/qa.QAService/ExportContent"
+	QAService_ImportContent_FullMethodName         = "/qa.QAService/ImportContent"
 )
 
 // QAServiceClient is the client API for QAService service.
@@ -47,10 +63,18 @@ type QAServiceClient interface {
 	CreateQuestion(ctx context.Context, in *CreateQuestionRequest, opts ...grpc.CallOption) (*QuestionResponse, error)
 	GetQuestion(ctx context.Context, in *GetQuestionRequest, opts ...grpc.CallOption) (*QuestionResponse, error)
 	ListQuestions(ctx context.Context, in *ListQuestionsRequest, opts ...grpc.CallOption) (*ListQuestionsResponse, error)
+	// ListTrendingQuestions 按时间衰减后的热度分数列出指定窗口内的热门问题
+	ListTrendingQuestions(ctx context.Context, in *ListTrendingQuestionsRequest, opts ...grpc.CallOption) (*ListQuestionsResponse, error)
+	// ReconcileCounters 根据源表重新统计回答数、评论数和点赞数并修正偏差，仅管理员可用
+	ReconcileCounters(ctx context.Context, in *ReconcileCountersRequest, opts ...grpc.CallOption) (*ReconcileCountersResponse, error)
+	// BatchGetQuestions 按 ID 批量获取问题，结果保持请求顺序
+	BatchGetQuestions(ctx context.Context, in *BatchGetQuestionsRequest, opts ...grpc.CallOption) (*BatchGetQuestionsResponse, error)
 	UpdateQuestion(ctx context.Context, in *UpdateQuestionRequest, opts ...grpc.CallOption) (*QuestionResponse, error)
 	DeleteQuestion(ctx context.Context, in *DeleteQuestionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// --- 回答 (Answer) ---
 	CreateAnswer(ctx context.Context, in *CreateAnswerRequest, opts ...grpc.CallOption) (*AnswerResponse, error)
+	// BatchGetAnswers 按 ID 批量获取回答，结果保持请求顺序
+	BatchGetAnswers(ctx context.Context, in *BatchGetAnswersRequest, opts ...grpc.CallOption) (*BatchGetAnswersResponse, error)
 	UpdateAnswer(ctx context.Context, in *UpdateAnswerRequest, opts ...grpc.CallOption) (*AnswerResponse, error)
 	DeleteAnswer(ctx context.Context, in *DeleteAnswerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ListAnswers(ctx context.Context, in *ListAnswersRequest, opts ...grpc.CallOption) (*ListAnswersResponse, error)
@@ -59,8 +83,33 @@ type QAServiceClient interface {
 	UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*CommentResponse, error)
 	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
+	// --- 用户动态 (User Activity) ---
+	ListUserQuestions(ctx context.Context, in *ListUserQuestionsRequest, opts ...grpc.CallOption) (*ListQuestionsResponse, error)
+	ListUserAnswers(ctx context.Context, in *ListUserAnswersRequest, opts ...grpc.CallOption) (*ListUserAnswersResponse, error)
+	ListUserComments(ctx context.Context, in *ListUserCommentsRequest, opts ...grpc.CallOption) (*ListUserCommentsResponse, error)
+	// GetUserActivity 返回用户提问、回答、评论按时间倒序合并后的时间线
+	GetUserActivity(ctx context.Context, in *GetUserActivityRequest, opts ...grpc.CallOption) (*GetUserActivityResponse, error)
 	UpvoteAnswer(ctx context.Context, in *UpvoteAnswerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// DownvoteAnswer 点踩回答，不影响点赞数
 	DownvoteAnswer(ctx context.Context, in *DownvoteAnswerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// RetractAnswerVote 撤销当前用户对回答的点赞或点踩
+	RetractAnswerVote(ctx context.Context, in *RetractAnswerVoteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// AcceptAnswer 由提问者采纳一个回答，采纳后不可更改
+	AcceptAnswer(ctx context.Context, in *AcceptAnswerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// --- 修改建议 (Suggested edit) ---
+	// SuggestEdit 为他人的问题或回答提出修改建议，等待作者或版主审核
+	SuggestEdit(ctx context.Context, in *SuggestEditRequest, opts ...grpc.CallOption) (*SuggestedEdit, error)
+	ListSuggestedEdits(ctx context.Context, in *ListSuggestedEditsRequest, opts ...grpc.CallOption) (*ListSuggestedEditsResponse, error)
+	// ReviewSuggestedEdit 由作者或版主通过或拒绝修改建议，通过后修改立即生效
+	ReviewSuggestedEdit(ctx context.Context, in *ReviewSuggestedEditRequest, opts ...grpc.CallOption) (*SuggestedEdit, error)
+	// --- 违规标记 (Content flag) ---
+	// FlagContent 由版主将问题或回答标记为违规，作者会被扣除声望
+	FlagContent(ctx context.Context, in *FlagContentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// --- 内容导出导入 (Content export/import)，仅管理员可用，只通过 gRPC 提供 ---
+	// ExportContent 按用户、问题、回答、评论、投票的顺序流式导出全部内容，第一条为文件头
+	ExportContent(ctx context.Context, in *ExportContentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ContentRecord], error)
+	// ImportContent 将 ExportContent 导出的记录写入没有问答内容的实例，所有ID都会重新生成
+	ImportContent(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ContentRecord, ImportContentResponse], error)
 }
 
 type qAServiceClient struct {
@@ -101,6 +150,36 @@ func (c *qAServiceClient) ListQuestions(ctx context.Context, in *ListQuestionsRe
 	return out, nil
 }
 
+func (c *qAServiceClient) ListTrendingQuestions(ctx context.Context, in *ListTrendingQuestionsRequest, opts ...grpc.CallOption) (*ListQuestionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListQuestionsResponse)
+	err := c.cc.Invoke(ctx, QAService_ListTrendingQuestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qAServiceClient) ReconcileCounters(ctx context.Context, in *ReconcileCountersRequest, opts ...grpc.CallOption) (*ReconcileCountersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcileCountersResponse)
+	err := c.cc.Invoke(ctx, QAService_ReconcileCounters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qAServiceClient) BatchGetQuestions(ctx context.Context, in *BatchGetQuestionsRequest, opts ...grpc.CallOption) (*BatchGetQuestionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetQuestionsResponse)
+	err := c.cc.Invoke(ctx, QAService_BatchGetQuestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *qAServiceClient) UpdateQuestion(ctx context.Context, in *UpdateQuestionRequest, opts ...grpc.CallOption) (*QuestionResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(QuestionResponse)
@@ -131,6 +210,16 @@ func (c *qAServiceClient) CreateAnswer(ctx context.Context, in *CreateAnswerRequ
 	return out, nil
 }
 
+func (c *qAServiceClient) BatchGetAnswers(ctx context.Context, in *BatchGetAnswersRequest, opts ...grpc.CallOption) (*BatchGetAnswersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetAnswersResponse)
+	err := c.cc.Invoke(ctx, QAService_BatchGetAnswers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *qAServiceClient) UpdateAnswer(ctx context.Context, in *UpdateAnswerRequest, opts ...grpc.CallOption) (*AnswerResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AnswerResponse)
@@ -201,6 +290,46 @@ func (c *qAServiceClient) ListComments(ctx context.Context, in *ListCommentsRequ
 	return out, nil
 }
 
+func (c *qAServiceClient) ListUserQuestions(ctx context.Context, in *ListUserQuestionsRequest, opts ...grpc.CallOption) (*ListQuestionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListQuestionsResponse)
+	err := c.cc.Invoke(ctx, QAService_ListUserQuestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qAServiceClient) ListUserAnswers(ctx context.Context, in *ListUserAnswersRequest, opts ...grpc.CallOption) (*ListUserAnswersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserAnswersResponse)
+	err := c.cc.Invoke(ctx, QAService_ListUserAnswers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qAServiceClient) ListUserComments(ctx context.Context, in *ListUserCommentsRequest, opts ...grpc.CallOption) (*ListUserCommentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserCommentsResponse)
+	err := c.cc.Invoke(ctx, QAService_ListUserComments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qAServiceClient) GetUserActivity(ctx context.Context, in *GetUserActivityRequest, opts ...grpc.CallOption) (*GetUserActivityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserActivityResponse)
+	err := c.cc.Invoke(ctx, QAService_GetUserActivity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *qAServiceClient) UpvoteAnswer(ctx context.Context, in *UpvoteAnswerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
@@ -221,6 +350,98 @@ func (c *qAServiceClient) DownvoteAnswer(ctx context.Context, in *DownvoteAnswer
 	return out, nil
 }
 
+func (c *qAServiceClient) RetractAnswerVote(ctx context.Context, in *RetractAnswerVoteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, QAService_RetractAnswerVote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qAServiceClient) AcceptAnswer(ctx context.Context, in *AcceptAnswerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, QAService_AcceptAnswer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qAServiceClient) SuggestEdit(ctx context.Context, in *SuggestEditRequest, opts ...grpc.CallOption) (*SuggestedEdit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestedEdit)
+	err := c.cc.Invoke(ctx, QAService_SuggestEdit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qAServiceClient) ListSuggestedEdits(ctx context.Context, in *ListSuggestedEditsRequest, opts ...grpc.CallOption) (*ListSuggestedEditsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSuggestedEditsResponse)
+	err := c.cc.Invoke(ctx, QAService_ListSuggestedEdits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qAServiceClient) ReviewSuggestedEdit(ctx context.Context, in *ReviewSuggestedEditRequest, opts ...grpc.CallOption) (*SuggestedEdit, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestedEdit)
+	err := c.cc.Invoke(ctx, QAService_ReviewSuggestedEdit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qAServiceClient) FlagContent(ctx context.Context, in *FlagContentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, QAService_FlagContent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *qAServiceClient) ExportContent(ctx context.Context, in *ExportContentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ContentRecord], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &QAService_ServiceDesc.Streams[0], QAService_ExportContent_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportContentRequest, ContentRecord]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QAService_ExportContentClient = grpc.ServerStreamingClient[ContentRecord]
+
+func (c *qAServiceClient) ImportContent(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ContentRecord, ImportContentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &QAService_ServiceDesc.Streams[1], QAService_ImportContent_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ContentRecord, ImportContentResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QAService_ImportContentClient = grpc.ClientStreamingClient[ContentRecord, ImportContentResponse]
+
 // QAServiceServer is the server API for QAService service.
 // All implementations must embed UnimplementedQAServiceServer
 // for forward compatibility.
@@ -231,10 +452,18 @@ type QAServiceServer interface {
 	CreateQuestion(context.Context, *CreateQuestionRequest) (*QuestionResponse, error)
 	GetQuestion(context.Context, *GetQuestionRequest) (*QuestionResponse, error)
 	ListQuestions(context.Context, *ListQuestionsRequest) (*ListQuestionsResponse, error)
+	// ListTrendingQuestions 按时间衰减后的热度分数列出指定窗口内的热门问题
+	ListTrendingQuestions(context.Context, *ListTrendingQuestionsRequest) (*ListQuestionsResponse, error)
+	// ReconcileCounters 根据源表重新统计回答数、评论数和点赞数并修正偏差，仅管理员可用
+	ReconcileCounters(context.Context, *ReconcileCountersRequest) (*ReconcileCountersResponse, error)
+	// BatchGetQuestions 按 ID 批量获取问题，结果保持请求顺序
+	BatchGetQuestions(context.Context, *BatchGetQuestionsRequest) (*BatchGetQuestionsResponse, error)
 	UpdateQuestion(context.Context, *UpdateQuestionRequest) (*QuestionResponse, error)
 	DeleteQuestion(context.Context, *DeleteQuestionRequest) (*emptypb.Empty, error)
 	// --- 回答 (Answer) ---
 	CreateAnswer(context.Context, *CreateAnswerRequest) (*AnswerResponse, error)
+	// BatchGetAnswers 按 ID 批量获取回答，结果保持请求顺序
+	BatchGetAnswers(context.Context, *BatchGetAnswersRequest) (*BatchGetAnswersResponse, error)
 	UpdateAnswer(context.Context, *UpdateAnswerRequest) (*AnswerResponse, error)
 	DeleteAnswer(context.Context, *DeleteAnswerRequest) (*emptypb.Empty, error)
 	ListAnswers(context.Context, *ListAnswersRequest) (*ListAnswersResponse, error)
@@ -243,8 +472,33 @@ type QAServiceServer interface {
 	UpdateComment(context.Context, *UpdateCommentRequest) (*CommentResponse, error)
 	DeleteComment(context.Context, *DeleteCommentRequest) (*emptypb.Empty, error)
 	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
+	// --- 用户动态 (User Activity) ---
+	ListUserQuestions(context.Context, *ListUserQuestionsRequest) (*ListQuestionsResponse, error)
+	ListUserAnswers(context.Context, *ListUserAnswersRequest) (*ListUserAnswersResponse, error)
+	ListUserComments(context.Context, *ListUserCommentsRequest) (*ListUserCommentsResponse, error)
+	// GetUserActivity 返回用户提问、回答、评论按时间倒序合并后的时间线
+	GetUserActivity(context.Context, *GetUserActivityRequest) (*GetUserActivityResponse, error)
 	UpvoteAnswer(context.Context, *UpvoteAnswerRequest) (*emptypb.Empty, error)
+	// DownvoteAnswer 点踩回答，不影响点赞数
 	DownvoteAnswer(context.Context, *DownvoteAnswerRequest) (*emptypb.Empty, error)
+	// RetractAnswerVote 撤销当前用户对回答的点赞或点踩
+	RetractAnswerVote(context.Context, *RetractAnswerVoteRequest) (*emptypb.Empty, error)
+	// AcceptAnswer 由提问者采纳一个回答，采纳后不可更改
+	AcceptAnswer(context.Context, *AcceptAnswerRequest) (*emptypb.Empty, error)
+	// --- 修改建议 (Suggested edit) ---
+	// SuggestEdit 为他人的问题或回答提出修改建议，等待作者或版主审核
+	SuggestEdit(context.Context, *SuggestEditRequest) (*SuggestedEdit, error)
+	ListSuggestedEdits(context.Context, *ListSuggestedEditsRequest) (*ListSuggestedEditsResponse, error)
+	// ReviewSuggestedEdit 由作者或版主通过或拒绝修改建议，通过后修改立即生效
+	ReviewSuggestedEdit(context.Context, *ReviewSuggestedEditRequest) (*SuggestedEdit, error)
+	// --- 违规标记 (Content flag) ---
+	// FlagContent 由版主将问题或回答标记为违规，作者会被扣除声望
+	FlagContent(context.Context, *FlagContentRequest) (*emptypb.Empty, error)
+	// --- 内容导出导入 (Content export/import)，仅管理员可用，只通过 gRPC 提供 ---
+	// ExportContent 按用户、问题、回答、评论、投票的顺序流式导出全部内容，第一条为文件头
+	ExportContent(*ExportContentRequest, grpc.ServerStreamingServer[ContentRecord]) error
+	// ImportContent 将 ExportContent 导出的记录写入没有问答内容的实例，所有ID都会重新生成
+	ImportContent(grpc.ClientStreamingServer[ContentRecord, ImportContentResponse]) error
 	mustEmbedUnimplementedQAServiceServer()
 }
 
@@ -264,6 +518,15 @@ func (UnimplementedQAServiceServer) GetQuestion(context.Context, *GetQuestionReq
 func (UnimplementedQAServiceServer) ListQuestions(context.Context, *ListQuestionsRequest) (*ListQuestionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListQuestions not implemented")
 }
+func (UnimplementedQAServiceServer) ListTrendingQuestions(context.Context, *ListTrendingQuestionsRequest) (*ListQuestionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrendingQuestions not implemented")
+}
+func (UnimplementedQAServiceServer) ReconcileCounters(context.Context, *ReconcileCountersRequest) (*ReconcileCountersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileCounters not implemented")
+}
+func (UnimplementedQAServiceServer) BatchGetQuestions(context.Context, *BatchGetQuestionsRequest) (*BatchGetQuestionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetQuestions not implemented")
+}
 func (UnimplementedQAServiceServer) UpdateQuestion(context.Context, *UpdateQuestionRequest) (*QuestionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateQuestion not implemented")
 }
@@ -273,6 +536,9 @@ func (UnimplementedQAServiceServer) DeleteQuestion(context.Context, *DeleteQuest
 func (UnimplementedQAServiceServer) CreateAnswer(context.Context, *CreateAnswerRequest) (*AnswerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateAnswer not implemented")
 }
+func (UnimplementedQAServiceServer) BatchGetAnswers(context.Context, *BatchGetAnswersRequest) (*BatchGetAnswersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetAnswers not implemented")
+}
 func (UnimplementedQAServiceServer) UpdateAnswer(context.Context, *UpdateAnswerRequest) (*AnswerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateAnswer not implemented")
 }
@@ -294,12 +560,48 @@ func (UnimplementedQAServiceServer) DeleteComment(context.Context, *DeleteCommen
 func (UnimplementedQAServiceServer) ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListComments not implemented")
 }
+func (UnimplementedQAServiceServer) ListUserQuestions(context.Context, *ListUserQuestionsRequest) (*ListQuestionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserQuestions not implemented")
+}
+func (UnimplementedQAServiceServer) ListUserAnswers(context.Context, *ListUserAnswersRequest) (*ListUserAnswersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserAnswers not implemented")
+}
+func (UnimplementedQAServiceServer) ListUserComments(context.Context, *ListUserCommentsRequest) (*ListUserCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserComments not implemented")
+}
+func (UnimplementedQAServiceServer) GetUserActivity(context.Context, *GetUserActivityRequest) (*GetUserActivityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserActivity not implemented")
+}
 func (UnimplementedQAServiceServer) UpvoteAnswer(context.Context, *UpvoteAnswerRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpvoteAnswer not implemented")
 }
 func (UnimplementedQAServiceServer) DownvoteAnswer(context.Context, *DownvoteAnswerRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DownvoteAnswer not implemented")
 }
+func (UnimplementedQAServiceServer) RetractAnswerVote(context.Context, *RetractAnswerVoteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetractAnswerVote not implemented")
+}
+func (UnimplementedQAServiceServer) AcceptAnswer(context.Context, *AcceptAnswerRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptAnswer not implemented")
+}
+func (UnimplementedQAServiceServer) SuggestEdit(context.Context, *SuggestEditRequest) (*SuggestedEdit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestEdit not implemented")
+}
+func (UnimplementedQAServiceServer) ListSuggestedEdits(context.Context, *ListSuggestedEditsRequest) (*ListSuggestedEditsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSuggestedEdits not implemented")
+}
+func (UnimplementedQAServiceServer) ReviewSuggestedEdit(context.Context, *ReviewSuggestedEditRequest) (*SuggestedEdit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewSuggestedEdit not implemented")
+}
+func (UnimplementedQAServiceServer) FlagContent(context.Context, *FlagContentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FlagContent not implemented")
+}
+func (UnimplementedQAServiceServer) ExportContent(*ExportContentRequest, grpc.ServerStreamingServer[ContentRecord]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportContent not implemented")
+}
+func (UnimplementedQAServiceServer) ImportContent(grpc.ClientStreamingServer[ContentRecord, ImportContentResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportContent not implemented")
+}
 func (UnimplementedQAServiceServer) mustEmbedUnimplementedQAServiceServer() {}
 func (UnimplementedQAServiceServer) testEmbeddedByValue()                   {}
 
@@ -375,6 +677,60 @@ func _QAService_ListQuestions_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QAService_ListTrendingQuestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrendingQuestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QAServiceServer).ListTrendingQuestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QAService_ListTrendingQuestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QAServiceServer).ListTrendingQuestions(ctx, req.(*ListTrendingQuestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QAService_ReconcileCounters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileCountersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QAServiceServer).ReconcileCounters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QAService_ReconcileCounters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QAServiceServer).ReconcileCounters(ctx, req.(*ReconcileCountersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QAService_BatchGetQuestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetQuestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QAServiceServer).BatchGetQuestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QAService_BatchGetQuestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QAServiceServer).BatchGetQuestions(ctx, req.(*BatchGetQuestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _QAService_UpdateQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateQuestionRequest)
 	if err := dec(in); err != nil {
@@ -429,6 +785,24 @@ func _QAService_CreateAnswer_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QAService_BatchGetAnswers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetAnswersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QAServiceServer).BatchGetAnswers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QAService_BatchGetAnswers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QAServiceServer).BatchGetAnswers(ctx, req.(*BatchGetAnswersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _QAService_UpdateAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateAnswerRequest)
 	if err := dec(in); err != nil {
@@ -555,6 +929,78 @@ func _QAService_ListComments_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QAService_ListUserQuestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserQuestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QAServiceServer).ListUserQuestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QAService_ListUserQuestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QAServiceServer).ListUserQuestions(ctx, req.(*ListUserQuestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QAService_ListUserAnswers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserAnswersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QAServiceServer).ListUserAnswers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QAService_ListUserAnswers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QAServiceServer).ListUserAnswers(ctx, req.(*ListUserAnswersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QAService_ListUserComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QAServiceServer).ListUserComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QAService_ListUserComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QAServiceServer).ListUserComments(ctx, req.(*ListUserCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QAService_GetUserActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserActivityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QAServiceServer).GetUserActivity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QAService_GetUserActivity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QAServiceServer).GetUserActivity(ctx, req.(*GetUserActivityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _QAService_UpvoteAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpvoteAnswerRequest)
 	if err := dec(in); err != nil {
@@ -591,6 +1037,132 @@ func _QAService_DownvoteAnswer_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QAService_RetractAnswerVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetractAnswerVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QAServiceServer).RetractAnswerVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QAService_RetractAnswerVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QAServiceServer).RetractAnswerVote(ctx, req.(*RetractAnswerVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QAService_AcceptAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QAServiceServer).AcceptAnswer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QAService_AcceptAnswer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QAServiceServer).AcceptAnswer(ctx, req.(*AcceptAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QAService_SuggestEdit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestEditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QAServiceServer).SuggestEdit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QAService_SuggestEdit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QAServiceServer).SuggestEdit(ctx, req.(*SuggestEditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QAService_ListSuggestedEdits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSuggestedEditsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QAServiceServer).ListSuggestedEdits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QAService_ListSuggestedEdits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QAServiceServer).ListSuggestedEdits(ctx, req.(*ListSuggestedEditsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QAService_ReviewSuggestedEdit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewSuggestedEditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QAServiceServer).ReviewSuggestedEdit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QAService_ReviewSuggestedEdit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QAServiceServer).ReviewSuggestedEdit(ctx, req.(*ReviewSuggestedEditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QAService_FlagContent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FlagContentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QAServiceServer).FlagContent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QAService_FlagContent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QAServiceServer).FlagContent(ctx, req.(*FlagContentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QAService_ExportContent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportContentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QAServiceServer).ExportContent(m, &grpc.GenericServerStream[ExportContentRequest, ContentRecord]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QAService_ExportContentServer = grpc.ServerStreamingServer[ContentRecord]
+
+func _QAService_ImportContent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(QAServiceServer).ImportContent(&grpc.GenericServerStream[ContentRecord, ImportContentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type QAService_ImportContentServer = grpc.ClientStreamingServer[ContentRecord, ImportContentResponse]
+
 // QAService_ServiceDesc is the grpc.ServiceDesc for QAService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -610,6 +1182,18 @@ var QAService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListQuestions",
 			Handler:    _QAService_ListQuestions_Handler,
 		},
+		{
+			MethodName: "ListTrendingQuestions",
+			Handler:    _QAService_ListTrendingQuestions_Handler,
+		},
+		{
+			MethodName: "ReconcileCounters",
+			Handler:    _QAService_ReconcileCounters_Handler,
+		},
+		{
+			MethodName: "BatchGetQuestions",
+			Handler:    _QAService_BatchGetQuestions_Handler,
+		},
 		{
 			MethodName: "UpdateQuestion",
 			Handler:    _QAService_UpdateQuestion_Handler,
@@ -622,6 +1206,10 @@ var QAService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateAnswer",
 			Handler:    _QAService_CreateAnswer_Handler,
 		},
+		{
+			MethodName: "BatchGetAnswers",
+			Handler:    _QAService_BatchGetAnswers_Handler,
+		},
 		{
 			MethodName: "UpdateAnswer",
 			Handler:    _QAService_UpdateAnswer_Handler,
@@ -650,6 +1238,22 @@ var QAService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListComments",
 			Handler:    _QAService_ListComments_Handler,
 		},
+		{
+			MethodName: "ListUserQuestions",
+			Handler:    _QAService_ListUserQuestions_Handler,
+		},
+		{
+			MethodName: "ListUserAnswers",
+			Handler:    _QAService_ListUserAnswers_Handler,
+		},
+		{
+			MethodName: "ListUserComments",
+			Handler:    _QAService_ListUserComments_Handler,
+		},
+		{
+			MethodName: "GetUserActivity",
+			Handler:    _QAService_GetUserActivity_Handler,
+		},
 		{
 			MethodName: "UpvoteAnswer",
 			Handler:    _QAService_UpvoteAnswer_Handler,
@@ -658,7 +1262,42 @@ var QAService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DownvoteAnswer",
 			Handler:    _QAService_DownvoteAnswer_Handler,
 		},
+		{
+			MethodName: "RetractAnswerVote",
+			Handler:    _QAService_RetractAnswerVote_Handler,
+		},
+		{
+			MethodName: "AcceptAnswer",
+			Handler:    _QAService_AcceptAnswer_Handler,
+		},
+		{
+			MethodName: "SuggestEdit",
+			Handler:    _QAService_SuggestEdit_Handler,
+		},
+		{
+			MethodName: "ListSuggestedEdits",
+			Handler:    _QAService_ListSuggestedEdits_Handler,
+		},
+		{
+			MethodName: "ReviewSuggestedEdit",
+			Handler:    _QAService_ReviewSuggestedEdit_Handler,
+		},
+		{
+			MethodName: "FlagContent",
+			Handler:    _QAService_FlagContent_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportContent",
+			Handler:       _QAService_ExportContent_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportContent",
+			Handler:       _QAService_ImportContent_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/qa/qa.proto",
 }
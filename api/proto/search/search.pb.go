@@ -10,7 +10,6 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
-	_ "google.golang.org/protobuf/types/known/emptypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -128,6 +127,102 @@ func (x *SearchQuestionsResponse) GetQuestions() []*Question {
 	return nil
 }
 
+type GetRelatedQuestionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId    int64                  `protobuf:"varint,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // 返回结果的最大数量，默认 5，最大 20
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedQuestionsRequest) Reset() {
+	*x = GetRelatedQuestionsRequest{}
+	mi := &file_search_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedQuestionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedQuestionsRequest) ProtoMessage() {}
+
+func (x *GetRelatedQuestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedQuestionsRequest.ProtoReflect.Descriptor instead.
+func (*GetRelatedQuestionsRequest) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetRelatedQuestionsRequest) GetQuestionId() int64 {
+	if x != nil {
+		return x.QuestionId
+	}
+	return 0
+}
+
+func (x *GetRelatedQuestionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetRelatedQuestionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Questions     []*Question            `protobuf:"bytes,1,rep,name=questions,proto3" json:"questions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedQuestionsResponse) Reset() {
+	*x = GetRelatedQuestionsResponse{}
+	mi := &file_search_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedQuestionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedQuestionsResponse) ProtoMessage() {}
+
+func (x *GetRelatedQuestionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedQuestionsResponse.ProtoReflect.Descriptor instead.
+func (*GetRelatedQuestionsResponse) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetRelatedQuestionsResponse) GetQuestions() []*Question {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
 type Question struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -137,13 +232,14 @@ type Question struct {
 	AuthorName    string                 `protobuf:"bytes,5,opt,name=author_name,json=authorName,proto3" json:"author_name,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	IsAnonymous   bool                   `protobuf:"varint,8,opt,name=is_anonymous,json=isAnonymous,proto3" json:"is_anonymous,omitempty"` // 匿名问题不会返回作者信息
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Question) Reset() {
 	*x = Question{}
-	mi := &file_search_proto_msgTypes[2]
+	mi := &file_search_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -155,7 +251,7 @@ func (x *Question) String() string {
 func (*Question) ProtoMessage() {}
 
 func (x *Question) ProtoReflect() protoreflect.Message {
-	mi := &file_search_proto_msgTypes[2]
+	mi := &file_search_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -168,7 +264,7 @@ func (x *Question) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Question.ProtoReflect.Descriptor instead.
 func (*Question) Descriptor() ([]byte, []int) {
-	return file_search_proto_rawDescGZIP(), []int{2}
+	return file_search_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Question) GetId() int64 {
@@ -220,6 +316,13 @@ func (x *Question) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Question) GetIsAnonymous() bool {
+	if x != nil {
+		return x.IsAnonymous
+	}
+	return false
+}
+
 // 索引管理消息
 type IndexAllQuestionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -229,7 +332,7 @@ type IndexAllQuestionsRequest struct {
 
 func (x *IndexAllQuestionsRequest) Reset() {
 	*x = IndexAllQuestionsRequest{}
-	mi := &file_search_proto_msgTypes[3]
+	mi := &file_search_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -241,7 +344,7 @@ func (x *IndexAllQuestionsRequest) String() string {
 func (*IndexAllQuestionsRequest) ProtoMessage() {}
 
 func (x *IndexAllQuestionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_search_proto_msgTypes[3]
+	mi := &file_search_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -254,7 +357,7 @@ func (x *IndexAllQuestionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IndexAllQuestionsRequest.ProtoReflect.Descriptor instead.
 func (*IndexAllQuestionsRequest) Descriptor() ([]byte, []int) {
-	return file_search_proto_rawDescGZIP(), []int{3}
+	return file_search_proto_rawDescGZIP(), []int{5}
 }
 
 type IndexAllQuestionsResponse struct {
@@ -267,7 +370,7 @@ type IndexAllQuestionsResponse struct {
 
 func (x *IndexAllQuestionsResponse) Reset() {
 	*x = IndexAllQuestionsResponse{}
-	mi := &file_search_proto_msgTypes[4]
+	mi := &file_search_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -279,7 +382,7 @@ func (x *IndexAllQuestionsResponse) String() string {
 func (*IndexAllQuestionsResponse) ProtoMessage() {}
 
 func (x *IndexAllQuestionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_search_proto_msgTypes[4]
+	mi := &file_search_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -292,7 +395,7 @@ func (x *IndexAllQuestionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IndexAllQuestionsResponse.ProtoReflect.Descriptor instead.
 func (*IndexAllQuestionsResponse) Descriptor() ([]byte, []int) {
-	return file_search_proto_rawDescGZIP(), []int{4}
+	return file_search_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *IndexAllQuestionsResponse) GetMessage() string {
@@ -317,7 +420,7 @@ type DeleteIndexAllQuestionsRequest struct {
 
 func (x *DeleteIndexAllQuestionsRequest) Reset() {
 	*x = DeleteIndexAllQuestionsRequest{}
-	mi := &file_search_proto_msgTypes[5]
+	mi := &file_search_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -329,7 +432,7 @@ func (x *DeleteIndexAllQuestionsRequest) String() string {
 func (*DeleteIndexAllQuestionsRequest) ProtoMessage() {}
 
 func (x *DeleteIndexAllQuestionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_search_proto_msgTypes[5]
+	mi := &file_search_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -342,7 +445,7 @@ func (x *DeleteIndexAllQuestionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteIndexAllQuestionsRequest.ProtoReflect.Descriptor instead.
 func (*DeleteIndexAllQuestionsRequest) Descriptor() ([]byte, []int) {
-	return file_search_proto_rawDescGZIP(), []int{5}
+	return file_search_proto_rawDescGZIP(), []int{7}
 }
 
 type DeleteIndexAllQuestionsResponse struct {
@@ -354,7 +457,7 @@ type DeleteIndexAllQuestionsResponse struct {
 
 func (x *DeleteIndexAllQuestionsResponse) Reset() {
 	*x = DeleteIndexAllQuestionsResponse{}
-	mi := &file_search_proto_msgTypes[6]
+	mi := &file_search_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -366,7 +469,7 @@ func (x *DeleteIndexAllQuestionsResponse) String() string {
 func (*DeleteIndexAllQuestionsResponse) ProtoMessage() {}
 
 func (x *DeleteIndexAllQuestionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_search_proto_msgTypes[6]
+	mi := &file_search_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -379,7 +482,7 @@ func (x *DeleteIndexAllQuestionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteIndexAllQuestionsResponse.ProtoReflect.Descriptor instead.
 func (*DeleteIndexAllQuestionsResponse) Descriptor() ([]byte, []int) {
-	return file_search_proto_rawDescGZIP(), []int{6}
+	return file_search_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DeleteIndexAllQuestionsResponse) GetMessage() string {
@@ -393,13 +496,19 @@ var File_search_proto protoreflect.FileDescriptor
 
 const file_search_proto_rawDesc = "" +
 	"\n" +
-	"\fsearch.proto\x12\x06search\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\\\n" +
+	"\fsearch.proto\x12\x06search\x1a\x1cgoogle/api/annotations.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\\\n" +
 	"\x16SearchQuestionsRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06offset\x18\x03 \x01(\x05R\x06offset\"I\n" +
 	"\x17SearchQuestionsResponse\x12.\n" +
-	"\tquestions\x18\x01 \x03(\v2\x10.search.QuestionR\tquestions\"\xfe\x01\n" +
+	"\tquestions\x18\x01 \x03(\v2\x10.search.QuestionR\tquestions\"S\n" +
+	"\x1aGetRelatedQuestionsRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\x03R\n" +
+	"questionId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"M\n" +
+	"\x1bGetRelatedQuestionsResponse\x12.\n" +
+	"\tquestions\x18\x01 \x03(\v2\x10.search.QuestionR\tquestions\"\xa1\x02\n" +
 	"\bQuestion\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
@@ -410,16 +519,18 @@ const file_search_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x1a\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12!\n" +
+	"\fis_anonymous\x18\b \x01(\bR\visAnonymous\"\x1a\n" +
 	"\x18IndexAllQuestionsRequest\"Z\n" +
 	"\x19IndexAllQuestionsResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12#\n" +
 	"\rindexed_count\x18\x02 \x01(\x05R\findexedCount\" \n" +
 	"\x1eDeleteIndexAllQuestionsRequest\";\n" +
 	"\x1fDeleteIndexAllQuestionsResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage2\x88\x03\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage2\xa1\x04\n" +
 	"\rSearchService\x12t\n" +
-	"\x0fSearchQuestions\x12\x1e.search.SearchQuestionsRequest\x1a\x1f.search.SearchQuestionsResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/search/questions\x12v\n" +
+	"\x0fSearchQuestions\x12\x1e.search.SearchQuestionsRequest\x1a\x1f.search.SearchQuestionsResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/search/questions\x12\x96\x01\n" +
+	"\x13GetRelatedQuestions\x12\".search.GetRelatedQuestionsRequest\x1a#.search.GetRelatedQuestionsResponse\"6\x82\xd3\xe4\x93\x020\x12./api/v1/search/questions/{question_id}/related\x12v\n" +
 	"\x11IndexAllQuestions\x12 .search.IndexAllQuestionsRequest\x1a!.search.IndexAllQuestionsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\"\x14/api/v1/search/index\x12\x88\x01\n" +
 	"\x17DeleteIndexAllQuestions\x12&.search.DeleteIndexAllQuestionsRequest\x1a'.search.DeleteIndexAllQuestionsResponse\"\x1c\x82\xd3\xe4\x93\x02\x16*\x14/api/v1/search/indexB\vZ\t./;searchb\x06proto3"
 
@@ -435,32 +546,37 @@ func file_search_proto_rawDescGZIP() []byte {
 	return file_search_proto_rawDescData
 }
 
-var file_search_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_search_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_search_proto_goTypes = []any{
 	(*SearchQuestionsRequest)(nil),          // 0: search.SearchQuestionsRequest
 	(*SearchQuestionsResponse)(nil),         // 1: search.SearchQuestionsResponse
-	(*Question)(nil),                        // 2: search.Question
-	(*IndexAllQuestionsRequest)(nil),        // 3: search.IndexAllQuestionsRequest
-	(*IndexAllQuestionsResponse)(nil),       // 4: search.IndexAllQuestionsResponse
-	(*DeleteIndexAllQuestionsRequest)(nil),  // 5: search.DeleteIndexAllQuestionsRequest
-	(*DeleteIndexAllQuestionsResponse)(nil), // 6: search.DeleteIndexAllQuestionsResponse
-	(*timestamppb.Timestamp)(nil),           // 7: google.protobuf.Timestamp
+	(*GetRelatedQuestionsRequest)(nil),      // 2: search.GetRelatedQuestionsRequest
+	(*GetRelatedQuestionsResponse)(nil),     // 3: search.GetRelatedQuestionsResponse
+	(*Question)(nil),                        // 4: search.Question
+	(*IndexAllQuestionsRequest)(nil),        // 5: search.IndexAllQuestionsRequest
+	(*IndexAllQuestionsResponse)(nil),       // 6: search.IndexAllQuestionsResponse
+	(*DeleteIndexAllQuestionsRequest)(nil),  // 7: search.DeleteIndexAllQuestionsRequest
+	(*DeleteIndexAllQuestionsResponse)(nil), // 8: search.DeleteIndexAllQuestionsResponse
+	(*timestamppb.Timestamp)(nil),           // 9: google.protobuf.Timestamp
 }
 var file_search_proto_depIdxs = []int32{
-	2, // 0: search.SearchQuestionsResponse.questions:type_name -> search.Question
-	7, // 1: search.Question.created_at:type_name -> google.protobuf.Timestamp
-	7, // 2: search.Question.updated_at:type_name -> google.protobuf.Timestamp
-	0, // 3: search.SearchService.SearchQuestions:input_type -> search.SearchQuestionsRequest
-	3, // 4: search.SearchService.IndexAllQuestions:input_type -> search.IndexAllQuestionsRequest
-	5, // 5: search.SearchService.DeleteIndexAllQuestions:input_type -> search.DeleteIndexAllQuestionsRequest
-	1, // 6: search.SearchService.SearchQuestions:output_type -> search.SearchQuestionsResponse
-	4, // 7: search.SearchService.IndexAllQuestions:output_type -> search.IndexAllQuestionsResponse
-	6, // 8: search.SearchService.DeleteIndexAllQuestions:output_type -> search.DeleteIndexAllQuestionsResponse
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	4, // 0: search.SearchQuestionsResponse.questions:type_name -> search.Question
+	4, // 1: search.GetRelatedQuestionsResponse.questions:type_name -> search.Question
+	9, // 2: search.Question.created_at:type_name -> google.protobuf.Timestamp
+	9, // 3: search.Question.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 4: search.SearchService.SearchQuestions:input_type -> search.SearchQuestionsRequest
+	2, // 5: search.SearchService.GetRelatedQuestions:input_type -> search.GetRelatedQuestionsRequest
+	5, // 6: search.SearchService.IndexAllQuestions:input_type -> search.IndexAllQuestionsRequest
+	7, // 7: search.SearchService.DeleteIndexAllQuestions:input_type -> search.DeleteIndexAllQuestionsRequest
+	1, // 8: search.SearchService.SearchQuestions:output_type -> search.SearchQuestionsResponse
+	3, // 9: search.SearchService.GetRelatedQuestions:output_type -> search.GetRelatedQuestionsResponse
+	6, // 10: search.SearchService.IndexAllQuestions:output_type -> search.IndexAllQuestionsResponse
+	8, // 11: search.SearchService.DeleteIndexAllQuestions:output_type -> search.DeleteIndexAllQuestionsResponse
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_search_proto_init() }
@@ -474,7 +590,7 @@ func file_search_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_search_proto_rawDesc), len(file_search_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	SearchService_SearchQuestions_FullMethodName         = "/search.SearchService/SearchQuestions"
+	SearchService_GetRelatedQuestions_FullMethodName     = "/search.SearchService/GetRelatedQuestions"
 	SearchService_IndexAllQuestions_FullMethodName       = "/search.SearchService/IndexAllQuestions"
 	SearchService_DeleteIndexAllQuestions_FullMethodName = "/search.SearchService/DeleteIndexAllQuestions"
 )
@@ -29,6 +30,8 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type SearchServiceClient interface {
 	SearchQuestions(ctx context.Context, in *SearchQuestionsRequest, opts ...grpc.CallOption) (*SearchQuestionsResponse, error)
+	// GetRelatedQuestions 基于 more_like_this 返回与指定问题相似的问题，不包含问题本身
+	GetRelatedQuestions(ctx context.Context, in *GetRelatedQuestionsRequest, opts ...grpc.CallOption) (*GetRelatedQuestionsResponse, error)
 	// 索引管理方法（仅用于测试/管理）
 	IndexAllQuestions(ctx context.Context, in *IndexAllQuestionsRequest, opts ...grpc.CallOption) (*IndexAllQuestionsResponse, error)
 	DeleteIndexAllQuestions(ctx context.Context, in *DeleteIndexAllQuestionsRequest, opts ...grpc.CallOption) (*DeleteIndexAllQuestionsResponse, error)
@@ -52,6 +55,16 @@ func (c *searchServiceClient) SearchQuestions(ctx context.Context, in *SearchQue
 	return out, nil
 }
 
+func (c *searchServiceClient) GetRelatedQuestions(ctx context.Context, in *GetRelatedQuestionsRequest, opts ...grpc.CallOption) (*GetRelatedQuestionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelatedQuestionsResponse)
+	err := c.cc.Invoke(ctx, SearchService_GetRelatedQuestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *searchServiceClient) IndexAllQuestions(ctx context.Context, in *IndexAllQuestionsRequest, opts ...grpc.CallOption) (*IndexAllQuestionsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(IndexAllQuestionsResponse)
@@ -77,6 +90,8 @@ func (c *searchServiceClient) DeleteIndexAllQuestions(ctx context.Context, in *D
 // for forward compatibility.
 type SearchServiceServer interface {
 	SearchQuestions(context.Context, *SearchQuestionsRequest) (*SearchQuestionsResponse, error)
+	// GetRelatedQuestions 基于 more_like_this 返回与指定问题相似的问题，不包含问题本身
+	GetRelatedQuestions(context.Context, *GetRelatedQuestionsRequest) (*GetRelatedQuestionsResponse, error)
 	// 索引管理方法（仅用于测试/管理）
 	IndexAllQuestions(context.Context, *IndexAllQuestionsRequest) (*IndexAllQuestionsResponse, error)
 	DeleteIndexAllQuestions(context.Context, *DeleteIndexAllQuestionsRequest) (*DeleteIndexAllQuestionsResponse, error)
@@ -93,6 +108,9 @@ type UnimplementedSearchServiceServer struct{}
 func (UnimplementedSearchServiceServer) SearchQuestions(context.Context, *SearchQuestionsRequest) (*SearchQuestionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SearchQuestions not implemented")
 }
+func (UnimplementedSearchServiceServer) GetRelatedQuestions(context.Context, *GetRelatedQuestionsRequest) (*GetRelatedQuestionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelatedQuestions not implemented")
+}
 func (UnimplementedSearchServiceServer) IndexAllQuestions(context.Context, *IndexAllQuestionsRequest) (*IndexAllQuestionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IndexAllQuestions not implemented")
 }
@@ -138,6 +156,24 @@ func _SearchService_SearchQuestions_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SearchService_GetRelatedQuestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelatedQuestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).GetRelatedQuestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchService_GetRelatedQuestions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).GetRelatedQuestions(ctx, req.(*GetRelatedQuestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SearchService_IndexAllQuestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IndexAllQuestionsRequest)
 	if err := dec(in); err != nil {
@@ -185,6 +221,10 @@ var SearchService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SearchQuestions",
 			Handler:    _SearchService_SearchQuestions_Handler,
 		},
+		{
+			MethodName: "GetRelatedQuestions",
+			Handler:    _SearchService_GetRelatedQuestions_Handler,
+		},
 		{
 			MethodName: "IndexAllQuestions",
 			Handler:    _SearchService_IndexAllQuestions_Handler,
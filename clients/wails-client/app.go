@@ -165,6 +165,209 @@ func (a *App) GetCurrentUser() (*services.UserProfile, error) {
 	return a.UserService.GetCurrentUser(a.ctx)
 }
 
+// UpdateLanguage 更新当前用户的语言偏好
+func (a *App) UpdateLanguage(language string) error {
+	if a.UserService == nil {
+		return fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.UpdateLanguage(a.ctx, language)
+}
+
+// ListSessions 获取当前用户所有已登录的设备
+func (a *App) ListSessions() ([]services.Session, error) {
+	if a.UserService == nil {
+		return nil, fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.ListSessions(a.ctx)
+}
+
+// RevokeSession 将指定设备踢下线
+func (a *App) RevokeSession(sessionID string) error {
+	if a.UserService == nil {
+		return fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.RevokeSession(a.ctx, sessionID)
+}
+
+// RevokeAllOtherSessions 将除本机以外的所有设备踢下线
+func (a *App) RevokeAllOtherSessions() (int32, error) {
+	if a.UserService == nil {
+		return 0, fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.RevokeAllOtherSessions(a.ctx)
+}
+
+// ChangeUsername 修改当前用户的用户名
+func (a *App) ChangeUsername(username string) (string, error) {
+	if a.UserService == nil {
+		return "", fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.ChangeUsername(a.ctx, username)
+}
+
+// UploadAvatar 打开文件选择框，把选中的图片上传为当前用户的头像。用户取消选择时返回空字符串
+func (a *App) UploadAvatar() (string, error) {
+	if a.UserService == nil {
+		return "", fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "选择头像",
+		Filters: []runtime.FileFilter{
+			{DisplayName: "图片 (*.png;*.jpg;*.jpeg;*.webp)", Pattern: "*.png;*.jpg;*.jpeg;*.webp"},
+		},
+	})
+	if err != nil || path == "" {
+		return "", err
+	}
+	return a.UserService.UploadAvatar(a.ctx, path)
+}
+
+// ChangePassword 修改当前用户的密码
+func (a *App) ChangePassword(currentPassword, newPassword string) error {
+	if a.UserService == nil {
+		return fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.ChangePassword(a.ctx, currentPassword, newPassword)
+}
+
+// RequestPasswordReset 请求向邮箱发送重置码
+func (a *App) RequestPasswordReset(email string) error {
+	if a.UserService == nil {
+		return fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.RequestPasswordReset(a.ctx, email)
+}
+
+// ConfirmPasswordReset 使用重置码设置新密码
+func (a *App) ConfirmPasswordReset(token, newPassword string) error {
+	if a.UserService == nil {
+		return fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.ConfirmPasswordReset(a.ctx, token, newPassword)
+}
+
+// VerifyEmail 使用验证码验证邮箱
+func (a *App) VerifyEmail(token string) error {
+	if a.UserService == nil {
+		return fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.VerifyEmail(a.ctx, token)
+}
+
+// ResendVerification 重新发送邮箱验证码
+func (a *App) ResendVerification() error {
+	if a.UserService == nil {
+		return fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.ResendVerification(a.ctx)
+}
+
+// Verify2FA 使用验证码或恢复码完成两步验证登录
+func (a *App) Verify2FA(challengeToken, code, username string) (*services.LoginResponse, error) {
+	if a.UserService == nil {
+		return nil, fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.Verify2FA(a.ctx, challengeToken, code, username)
+}
+
+// Enroll2FA 生成两步验证的密钥
+func (a *App) Enroll2FA() (*services.TwoFactorEnrollment, error) {
+	if a.UserService == nil {
+		return nil, fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.Enroll2FA(a.ctx)
+}
+
+// Confirm2FA 确认绑定并启用两步验证，返回恢复码
+func (a *App) Confirm2FA(code string) ([]string, error) {
+	if a.UserService == nil {
+		return nil, fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.Confirm2FA(a.ctx, code)
+}
+
+// Disable2FA 停用两步验证
+func (a *App) Disable2FA(code string) error {
+	if a.UserService == nil {
+		return fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.Disable2FA(a.ctx, code)
+}
+
+// UnlockUser 解除用户的登录锁定
+func (a *App) UnlockUser(userID int64) error {
+	if a.UserService == nil {
+		return fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.UnlockUser(a.ctx, userID)
+}
+
+// CreateBot 创建机器人账户
+func (a *App) CreateBot(username, bio string) (int64, error) {
+	if a.UserService == nil {
+		return 0, fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.CreateBot(a.ctx, username, bio)
+}
+
+// CreateAccessToken 创建个人访问令牌
+func (a *App) CreateAccessToken(userID int64, name string, scopes []string, expiresInDays int32) (*services.CreatedAccessToken, error) {
+	if a.UserService == nil {
+		return nil, fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.CreateAccessToken(a.ctx, userID, name, scopes, expiresInDays)
+}
+
+// ListAccessTokens 获取访问令牌列表
+func (a *App) ListAccessTokens(userID int64) ([]services.AccessToken, error) {
+	if a.UserService == nil {
+		return nil, fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.ListAccessTokens(a.ctx, userID)
+}
+
+// RevokeAccessToken 撤销访问令牌
+func (a *App) RevokeAccessToken(userID, tokenID int64) error {
+	if a.UserService == nil {
+		return fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.RevokeAccessToken(a.ctx, userID, tokenID)
+}
+
+// FeedResult 关注动态列表结果
+type FeedResult struct {
+	Items []services.FeedItem `json:"items"`
+	Total int64               `json:"total"`
+}
+
+// FollowUser 关注用户
+func (a *App) FollowUser(userID int64) error {
+	if a.UserService == nil {
+		return fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.FollowUser(a.ctx, userID)
+}
+
+// UnfollowUser 取消关注用户
+func (a *App) UnfollowUser(userID int64) error {
+	if a.UserService == nil {
+		return fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	return a.UserService.UnfollowUser(a.ctx, userID)
+}
+
+// GetFeed 获取当前用户的关注动态
+func (a *App) GetFeed(page, pageSize int32) (*FeedResult, error) {
+	if a.UserService == nil {
+		return nil, fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	items, total, err := a.UserService.GetFeed(a.ctx, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &FeedResult{Items: items, Total: total}, nil
+}
+
 // IsLoggedIn 检查是否已登录
 func (a *App) IsLoggedIn() bool {
 	if a.UserService == nil {
@@ -192,6 +395,15 @@ func (a *App) ListQuestions(page, pageSize int32) ([]services.Question, error) {
 	return questions, err
 }
 
+// ListTrendingQuestions 获取热门问题列表
+func (a *App) ListTrendingQuestions(window string, page, pageSize int32) ([]services.Question, error) {
+	if a.QAService == nil {
+		return nil, fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	questions, _, err := a.QAService.ListTrendingQuestions(a.ctx, window, page, pageSize)
+	return questions, err
+}
+
 // GetQuestion 获取问题详情
 func (a *App) GetQuestion(id int64) (*services.Question, error) {
 	if a.QAService == nil {
@@ -200,12 +412,21 @@ func (a *App) GetQuestion(id int64) (*services.Question, error) {
 	return a.QAService.GetQuestion(a.ctx, id)
 }
 
+// BatchGetQuestions 按 ID 批量获取问题，不存在的问题会被忽略
+func (a *App) BatchGetQuestions(ids []int64) ([]services.Question, error) {
+	if a.QAService == nil {
+		return nil, fmt.Errorf("服务未连接,请先启动后端服务")
+	}
+	questions, _, err := a.QAService.BatchGetQuestions(a.ctx, ids)
+	return questions, err
+}
+
 // CreateQuestion 创建问题
-func (a *App) CreateQuestion(title, content string) (*services.Question, error) {
+func (a *App) CreateQuestion(title, content string, anonymous bool) (*services.Question, error) {
 	if a.QAService == nil {
 		return nil, fmt.Errorf("服务未连接,请先启动后端服务")
 	}
-	return a.QAService.CreateQuestion(a.ctx, title, content)
+	return a.QAService.CreateQuestion(a.ctx, title, content, anonymous)
 }
 
 // UpdateQuestion 更新问题
@@ -225,8 +446,8 @@ func (a *App) ListAnswers(questionID int64, page, pageSize int32) ([]services.An
 }
 
 // CreateAnswer 创建回答
-func (a *App) CreateAnswer(questionID int64, content string) (*services.Answer, error) {
-	return a.QAService.CreateAnswer(a.ctx, questionID, content)
+func (a *App) CreateAnswer(questionID int64, content string, anonymous bool) (*services.Answer, error) {
+	return a.QAService.CreateAnswer(a.ctx, questionID, content, anonymous)
 }
 
 // UpdateAnswer 更新回答
@@ -244,11 +465,37 @@ func (a *App) UpvoteAnswer(answerID int64) error {
 	return a.QAService.UpvoteAnswer(a.ctx, answerID)
 }
 
-// DownvoteAnswer 取消点赞
+// DownvoteAnswer 点踩回答
 func (a *App) DownvoteAnswer(answerID int64) error {
 	return a.QAService.DownvoteAnswer(a.ctx, answerID)
 }
 
+// RetractAnswerVote 撤销对回答的点赞或点踩
+func (a *App) RetractAnswerVote(answerID int64) error {
+	return a.QAService.RetractAnswerVote(a.ctx, answerID)
+}
+
+// AcceptAnswer 采纳回答
+func (a *App) AcceptAnswer(answerID int64) error {
+	return a.QAService.AcceptAnswer(a.ctx, answerID)
+}
+
+// SuggestEdit 对他人的问题或回答提出修改建议
+func (a *App) SuggestEdit(targetType string, targetID int64, title, content, reason string) (*services.SuggestedEdit, error) {
+	return a.QAService.SuggestEdit(a.ctx, targetType, targetID, title, content, reason)
+}
+
+// ListPendingSuggestedEdits 获取问题或回答待审核的修改建议
+func (a *App) ListPendingSuggestedEdits(targetType string, targetID int64) ([]services.SuggestedEdit, error) {
+	edits, _, err := a.QAService.ListSuggestedEdits(a.ctx, targetType, targetID, "pending", 1, 50)
+	return edits, err
+}
+
+// ReviewSuggestedEdit 通过或拒绝修改建议
+func (a *App) ReviewSuggestedEdit(editID int64, approve bool, comment string) (*services.SuggestedEdit, error) {
+	return a.QAService.ReviewSuggestedEdit(a.ctx, editID, approve, comment)
+}
+
 // ListComments 获取评论列表
 func (a *App) ListComments(answerID int64, page, pageSize int32) ([]services.Comment, error) {
 	comments, _, err := a.QAService.ListComments(a.ctx, answerID, page, pageSize)
@@ -270,6 +517,68 @@ func (a *App) DeleteComment(id int64) error {
 	return a.QAService.DeleteComment(a.ctx, id)
 }
 
+// ===== 用户动态相关方法 (供前端调用) =====
+
+// UserQuestionsResult 用户问题列表结果
+type UserQuestionsResult struct {
+	Questions []services.Question `json:"questions"`
+	Total     int64               `json:"total"`
+}
+
+// UserAnswersResult 用户回答列表结果
+type UserAnswersResult struct {
+	Answers []services.UserAnswer `json:"answers"`
+	Total   int64                 `json:"total"`
+}
+
+// UserCommentsResult 用户评论列表结果
+type UserCommentsResult struct {
+	Comments []services.UserComment `json:"comments"`
+	Total    int64                  `json:"total"`
+}
+
+// UserActivityResult 用户动态时间线结果
+type UserActivityResult struct {
+	Activities []services.Activity `json:"activities"`
+	Total      int64               `json:"total"`
+}
+
+// ListUserQuestions 获取指定用户的问题
+func (a *App) ListUserQuestions(userID int64, page, pageSize int32) (*UserQuestionsResult, error) {
+	questions, total, err := a.QAService.ListUserQuestions(a.ctx, userID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &UserQuestionsResult{Questions: questions, Total: total}, nil
+}
+
+// ListUserAnswers 获取指定用户的回答
+func (a *App) ListUserAnswers(userID int64, page, pageSize int32) (*UserAnswersResult, error) {
+	answers, total, err := a.QAService.ListUserAnswers(a.ctx, userID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &UserAnswersResult{Answers: answers, Total: total}, nil
+}
+
+// ListUserComments 获取指定用户的评论
+func (a *App) ListUserComments(userID int64, page, pageSize int32) (*UserCommentsResult, error) {
+	comments, total, err := a.QAService.ListUserComments(a.ctx, userID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &UserCommentsResult{Comments: comments, Total: total}, nil
+}
+
+// GetUserActivity 获取指定用户的动态时间线
+func (a *App) GetUserActivity(userID int64, page, pageSize int32) (*UserActivityResult, error) {
+	activities, total, err := a.QAService.GetUserActivity(a.ctx, userID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &UserActivityResult{Activities: activities, Total: total}, nil
+}
+
 // ===== 搜索相关方法 (供前端调用) =====
 
 // SearchQuestions 搜索问题
@@ -277,6 +586,11 @@ func (a *App) SearchQuestions(query string, limit, offset int32) ([]services.Sea
 	return a.SearchService.SearchQuestions(a.ctx, query, limit, offset)
 }
 
+// GetRelatedQuestions 获取相关问题
+func (a *App) GetRelatedQuestions(questionID int64, limit int32) ([]services.SearchResult, error) {
+	return a.SearchService.GetRelatedQuestions(a.ctx, questionID, limit)
+}
+
 // IndexAllQuestions 索引所有问题（仅用于测试/管理）
 func (a *App) IndexAllQuestions() (string, error) {
 	return a.SearchService.IndexAllQuestions(a.ctx)
@@ -3,11 +3,27 @@
 import {services} from '../models';
 import {main} from '../models';
 
-export function CreateAnswer(arg1:number,arg2:string):Promise<services.Answer>;
+export function AcceptAnswer(arg1:number):Promise<void>;
+
+export function BatchGetQuestions(arg1:Array<number>):Promise<Array<services.Question>>;
+
+export function ChangePassword(arg1:string,arg2:string):Promise<void>;
+
+export function ChangeUsername(arg1:string):Promise<string>;
+
+export function Confirm2FA(arg1:string):Promise<Array<string>>;
+
+export function ConfirmPasswordReset(arg1:string,arg2:string):Promise<void>;
+
+export function CreateAccessToken(arg1:number,arg2:string,arg3:Array<string>,arg4:number):Promise<services.CreatedAccessToken>;
+
+export function CreateAnswer(arg1:number,arg2:string,arg3:boolean):Promise<services.Answer>;
+
+export function CreateBot(arg1:string,arg2:string):Promise<number>;
 
 export function CreateComment(arg1:number,arg2:string):Promise<services.Comment>;
 
-export function CreateQuestion(arg1:string,arg2:string):Promise<services.Question>;
+export function CreateQuestion(arg1:string,arg2:string,arg3:boolean):Promise<services.Question>;
 
 export function DeleteAnswer(arg1:number):Promise<void>;
 
@@ -19,18 +35,30 @@ export function DeleteNotification(arg1:string):Promise<void>;
 
 export function DeleteQuestion(arg1:number):Promise<void>;
 
+export function Disable2FA(arg1:string):Promise<void>;
+
 export function DownvoteAnswer(arg1:number):Promise<void>;
 
+export function Enroll2FA():Promise<services.TwoFactorEnrollment>;
+
+export function FollowUser(arg1:number):Promise<void>;
+
 export function GetCurrentUser():Promise<services.UserProfile>;
 
+export function GetFeed(arg1:number,arg2:number):Promise<main.FeedResult>;
+
 export function GetNotifications(arg1:number,arg2:number,arg3:boolean):Promise<main.NotificationListResult>;
 
 export function GetQuestion(arg1:number):Promise<services.Question>;
 
+export function GetRelatedQuestions(arg1:number,arg2:number):Promise<Array<services.SearchResult>>;
+
 export function GetServiceStatus():Promise<Record<string, any>>;
 
 export function GetUnreadCount():Promise<number>;
 
+export function GetUserActivity(arg1:number,arg2:number,arg3:number):Promise<main.UserActivityResult>;
+
 export function GetUsername():Promise<string>;
 
 export function IndexAllQuestions():Promise<string>;
@@ -41,12 +69,26 @@ export function IsNotificationStreamConnected():Promise<boolean>;
 
 export function IsServiceConnected():Promise<boolean>;
 
+export function ListAccessTokens(arg1:number):Promise<Array<services.AccessToken>>;
+
 export function ListAnswers(arg1:number,arg2:number,arg3:number):Promise<Array<services.Answer>>;
 
 export function ListComments(arg1:number,arg2:number,arg3:number):Promise<Array<services.Comment>>;
 
+export function ListPendingSuggestedEdits(arg1:string,arg2:number):Promise<Array<services.SuggestedEdit>>;
+
 export function ListQuestions(arg1:number,arg2:number):Promise<Array<services.Question>>;
 
+export function ListSessions():Promise<Array<services.Session>>;
+
+export function ListTrendingQuestions(arg1:string,arg2:number,arg3:number):Promise<Array<services.Question>>;
+
+export function ListUserAnswers(arg1:number,arg2:number,arg3:number):Promise<main.UserAnswersResult>;
+
+export function ListUserComments(arg1:number,arg2:number,arg3:number):Promise<main.UserCommentsResult>;
+
+export function ListUserQuestions(arg1:number,arg2:number,arg3:number):Promise<main.UserQuestionsResult>;
+
 export function Login(arg1:string,arg2:string):Promise<services.LoginResponse>;
 
 export function Logout():Promise<void>;
@@ -55,16 +97,44 @@ export function MarkAsRead(arg1:Array<string>,arg2:boolean):Promise<number>;
 
 export function Register(arg1:string,arg2:string,arg3:string):Promise<services.RegisterResponse>;
 
+export function RequestPasswordReset(arg1:string):Promise<void>;
+
+export function ResendVerification():Promise<void>;
+
+export function RetractAnswerVote(arg1:number):Promise<void>;
+
+export function ReviewSuggestedEdit(arg1:number,arg2:boolean,arg3:string):Promise<services.SuggestedEdit>;
+
+export function RevokeAccessToken(arg1:number,arg2:number):Promise<void>;
+
+export function RevokeAllOtherSessions():Promise<number>;
+
+export function RevokeSession(arg1:string):Promise<void>;
+
 export function SearchQuestions(arg1:string,arg2:number,arg3:number):Promise<Array<services.SearchResult>>;
 
 export function StartNotificationStream():Promise<void>;
 
 export function StopNotificationStream():Promise<void>;
 
+export function SuggestEdit(arg1:string,arg2:number,arg3:string,arg4:string,arg5:string):Promise<services.SuggestedEdit>;
+
+export function UnfollowUser(arg1:number):Promise<void>;
+
+export function UnlockUser(arg1:number):Promise<void>;
+
 export function UpdateAnswer(arg1:number,arg2:string):Promise<services.Answer>;
 
 export function UpdateComment(arg1:number,arg2:string):Promise<services.Comment>;
 
+export function UpdateLanguage(arg1:string):Promise<void>;
+
 export function UpdateQuestion(arg1:number,arg2:string,arg3:string):Promise<services.Question>;
 
+export function UploadAvatar():Promise<string>;
+
 export function UpvoteAnswer(arg1:number):Promise<void>;
+
+export function Verify2FA(arg1:string,arg2:string,arg3:string):Promise<services.LoginResponse>;
+
+export function VerifyEmail(arg1:string):Promise<void>;
@@ -2,16 +2,48 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
-export function CreateAnswer(arg1, arg2) {
-  return window['go']['main']['App']['CreateAnswer'](arg1, arg2);
+export function AcceptAnswer(arg1) {
+  return window['go']['main']['App']['AcceptAnswer'](arg1);
+}
+
+export function BatchGetQuestions(arg1) {
+  return window['go']['main']['App']['BatchGetQuestions'](arg1);
+}
+
+export function ChangePassword(arg1, arg2) {
+  return window['go']['main']['App']['ChangePassword'](arg1, arg2);
+}
+
+export function ChangeUsername(arg1) {
+  return window['go']['main']['App']['ChangeUsername'](arg1);
+}
+
+export function Confirm2FA(arg1) {
+  return window['go']['main']['App']['Confirm2FA'](arg1);
+}
+
+export function ConfirmPasswordReset(arg1, arg2) {
+  return window['go']['main']['App']['ConfirmPasswordReset'](arg1, arg2);
+}
+
+export function CreateAccessToken(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['CreateAccessToken'](arg1, arg2, arg3, arg4);
+}
+
+export function CreateAnswer(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CreateAnswer'](arg1, arg2, arg3);
+}
+
+export function CreateBot(arg1, arg2) {
+  return window['go']['main']['App']['CreateBot'](arg1, arg2);
 }
 
 export function CreateComment(arg1, arg2) {
   return window['go']['main']['App']['CreateComment'](arg1, arg2);
 }
 
-export function CreateQuestion(arg1, arg2) {
-  return window['go']['main']['App']['CreateQuestion'](arg1, arg2);
+export function CreateQuestion(arg1, arg2, arg3) {
+  return window['go']['main']['App']['CreateQuestion'](arg1, arg2, arg3);
 }
 
 export function DeleteAnswer(arg1) {
@@ -34,14 +66,30 @@ export function DeleteQuestion(arg1) {
   return window['go']['main']['App']['DeleteQuestion'](arg1);
 }
 
+export function Disable2FA(arg1) {
+  return window['go']['main']['App']['Disable2FA'](arg1);
+}
+
 export function DownvoteAnswer(arg1) {
   return window['go']['main']['App']['DownvoteAnswer'](arg1);
 }
 
+export function Enroll2FA() {
+  return window['go']['main']['App']['Enroll2FA']();
+}
+
+export function FollowUser(arg1) {
+  return window['go']['main']['App']['FollowUser'](arg1);
+}
+
 export function GetCurrentUser() {
   return window['go']['main']['App']['GetCurrentUser']();
 }
 
+export function GetFeed(arg1, arg2) {
+  return window['go']['main']['App']['GetFeed'](arg1, arg2);
+}
+
 export function GetNotifications(arg1, arg2, arg3) {
   return window['go']['main']['App']['GetNotifications'](arg1, arg2, arg3);
 }
@@ -50,6 +98,10 @@ export function GetQuestion(arg1) {
   return window['go']['main']['App']['GetQuestion'](arg1);
 }
 
+export function GetRelatedQuestions(arg1, arg2) {
+  return window['go']['main']['App']['GetRelatedQuestions'](arg1, arg2);
+}
+
 export function GetServiceStatus() {
   return window['go']['main']['App']['GetServiceStatus']();
 }
@@ -58,6 +110,10 @@ export function GetUnreadCount() {
   return window['go']['main']['App']['GetUnreadCount']();
 }
 
+export function GetUserActivity(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetUserActivity'](arg1, arg2, arg3);
+}
+
 export function GetUsername() {
   return window['go']['main']['App']['GetUsername']();
 }
@@ -78,6 +134,10 @@ export function IsServiceConnected() {
   return window['go']['main']['App']['IsServiceConnected']();
 }
 
+export function ListAccessTokens(arg1) {
+  return window['go']['main']['App']['ListAccessTokens'](arg1);
+}
+
 export function ListAnswers(arg1, arg2, arg3) {
   return window['go']['main']['App']['ListAnswers'](arg1, arg2, arg3);
 }
@@ -86,10 +146,34 @@ export function ListComments(arg1, arg2, arg3) {
   return window['go']['main']['App']['ListComments'](arg1, arg2, arg3);
 }
 
+export function ListPendingSuggestedEdits(arg1, arg2) {
+  return window['go']['main']['App']['ListPendingSuggestedEdits'](arg1, arg2);
+}
+
 export function ListQuestions(arg1, arg2) {
   return window['go']['main']['App']['ListQuestions'](arg1, arg2);
 }
 
+export function ListSessions() {
+  return window['go']['main']['App']['ListSessions']();
+}
+
+export function ListTrendingQuestions(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ListTrendingQuestions'](arg1, arg2, arg3);
+}
+
+export function ListUserAnswers(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ListUserAnswers'](arg1, arg2, arg3);
+}
+
+export function ListUserComments(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ListUserComments'](arg1, arg2, arg3);
+}
+
+export function ListUserQuestions(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ListUserQuestions'](arg1, arg2, arg3);
+}
+
 export function Login(arg1, arg2) {
   return window['go']['main']['App']['Login'](arg1, arg2);
 }
@@ -106,6 +190,34 @@ export function Register(arg1, arg2, arg3) {
   return window['go']['main']['App']['Register'](arg1, arg2, arg3);
 }
 
+export function RequestPasswordReset(arg1) {
+  return window['go']['main']['App']['RequestPasswordReset'](arg1);
+}
+
+export function ResendVerification() {
+  return window['go']['main']['App']['ResendVerification']();
+}
+
+export function RetractAnswerVote(arg1) {
+  return window['go']['main']['App']['RetractAnswerVote'](arg1);
+}
+
+export function ReviewSuggestedEdit(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ReviewSuggestedEdit'](arg1, arg2, arg3);
+}
+
+export function RevokeAccessToken(arg1, arg2) {
+  return window['go']['main']['App']['RevokeAccessToken'](arg1, arg2);
+}
+
+export function RevokeAllOtherSessions() {
+  return window['go']['main']['App']['RevokeAllOtherSessions']();
+}
+
+export function RevokeSession(arg1) {
+  return window['go']['main']['App']['RevokeSession'](arg1);
+}
+
 export function SearchQuestions(arg1, arg2, arg3) {
   return window['go']['main']['App']['SearchQuestions'](arg1, arg2, arg3);
 }
@@ -118,6 +230,18 @@ export function StopNotificationStream() {
   return window['go']['main']['App']['StopNotificationStream']();
 }
 
+export function SuggestEdit(arg1, arg2, arg3, arg4, arg5) {
+  return window['go']['main']['App']['SuggestEdit'](arg1, arg2, arg3, arg4, arg5);
+}
+
+export function UnfollowUser(arg1) {
+  return window['go']['main']['App']['UnfollowUser'](arg1);
+}
+
+export function UnlockUser(arg1) {
+  return window['go']['main']['App']['UnlockUser'](arg1);
+}
+
 export function UpdateAnswer(arg1, arg2) {
   return window['go']['main']['App']['UpdateAnswer'](arg1, arg2);
 }
@@ -126,10 +250,26 @@ export function UpdateComment(arg1, arg2) {
   return window['go']['main']['App']['UpdateComment'](arg1, arg2);
 }
 
+export function UpdateLanguage(arg1) {
+  return window['go']['main']['App']['UpdateLanguage'](arg1);
+}
+
 export function UpdateQuestion(arg1, arg2, arg3) {
   return window['go']['main']['App']['UpdateQuestion'](arg1, arg2, arg3);
 }
 
+export function UploadAvatar() {
+  return window['go']['main']['App']['UploadAvatar']();
+}
+
 export function UpvoteAnswer(arg1) {
   return window['go']['main']['App']['UpvoteAnswer'](arg1);
 }
+
+export function Verify2FA(arg1, arg2, arg3) {
+  return window['go']['main']['App']['Verify2FA'](arg1, arg2, arg3);
+}
+
+export function VerifyEmail(arg1) {
+  return window['go']['main']['App']['VerifyEmail'](arg1);
+}
@@ -1,5 +1,37 @@
 export namespace main {
 	
+	export class FeedResult {
+	    items: services.FeedItem[];
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new FeedResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], services.FeedItem);
+	        this.total = source["total"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class NotificationListResult {
 	    notifications: services.Notification[];
 	    total: number;
@@ -34,19 +66,196 @@ export namespace main {
 		    return a;
 		}
 	}
+	export class UserActivityResult {
+	    activities: services.Activity[];
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UserActivityResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.activities = this.convertValues(source["activities"], services.Activity);
+	        this.total = source["total"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UserAnswersResult {
+	    answers: services.UserAnswer[];
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UserAnswersResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.answers = this.convertValues(source["answers"], services.UserAnswer);
+	        this.total = source["total"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UserCommentsResult {
+	    comments: services.UserComment[];
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UserCommentsResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.comments = this.convertValues(source["comments"], services.UserComment);
+	        this.total = source["total"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class UserQuestionsResult {
+	    questions: services.Question[];
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new UserQuestionsResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.questions = this.convertValues(source["questions"], services.Question);
+	        this.total = source["total"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 
 }
 
 export namespace services {
 	
+	export class AccessToken {
+	    id: number;
+	    name: string;
+	    token_hint: string;
+	    scopes: string[];
+	    expires_at: string;
+	    last_used_at: string;
+	    created_at: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AccessToken(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.token_hint = source["token_hint"];
+	        this.scopes = source["scopes"];
+	        this.expires_at = source["expires_at"];
+	        this.last_used_at = source["last_used_at"];
+	        this.created_at = source["created_at"];
+	    }
+	}
+	export class Activity {
+	    type: string;
+	    id: number;
+	    question_id: number;
+	    question_title: string;
+	    content: string;
+	    created_at: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Activity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.id = source["id"];
+	        this.question_id = source["question_id"];
+	        this.question_title = source["question_title"];
+	        this.content = source["content"];
+	        this.created_at = source["created_at"];
+	    }
+	}
 	export class Answer {
 	    id: number;
 	    question_id: number;
 	    content: string;
 	    user_id: number;
 	    username: string;
+	    author_is_bot: boolean;
+	    author_avatar_url: string;
 	    upvote_count: number;
 	    is_upvoted: boolean;
+	    is_anonymous: boolean;
 	    created_at: string;
 	    updated_at: string;
 	
@@ -61,8 +270,11 @@ export namespace services {
 	        this.content = source["content"];
 	        this.user_id = source["user_id"];
 	        this.username = source["username"];
+	        this.author_is_bot = source["author_is_bot"];
+	        this.author_avatar_url = source["author_avatar_url"];
 	        this.upvote_count = source["upvote_count"];
 	        this.is_upvoted = source["is_upvoted"];
+	        this.is_anonymous = source["is_anonymous"];
 	        this.created_at = source["created_at"];
 	        this.updated_at = source["updated_at"];
 	    }
@@ -72,6 +284,8 @@ export namespace services {
 	    answer_id: number;
 	    user_id: number;
 	    username: string;
+	    author_is_bot: boolean;
+	    author_avatar_url: string;
 	    content: string;
 	    created_at: string;
 	    updated_at: string;
@@ -86,15 +300,78 @@ export namespace services {
 	        this.answer_id = source["answer_id"];
 	        this.user_id = source["user_id"];
 	        this.username = source["username"];
+	        this.author_is_bot = source["author_is_bot"];
+	        this.author_avatar_url = source["author_avatar_url"];
 	        this.content = source["content"];
 	        this.created_at = source["created_at"];
 	        this.updated_at = source["updated_at"];
 	    }
 	}
+	export class CreatedAccessToken {
+	    token: string;
+	    access_token: AccessToken;
+	
+	    static createFrom(source: any = {}) {
+	        return new CreatedAccessToken(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.token = source["token"];
+	        this.access_token = this.convertValues(source["access_token"], AccessToken);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FeedItem {
+	    type: string;
+	    question_id: number;
+	    answer_id: number;
+	    title: string;
+	    excerpt: string;
+	    author_id: number;
+	    author_name: string;
+	    created_at: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FeedItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.question_id = source["question_id"];
+	        this.answer_id = source["answer_id"];
+	        this.title = source["title"];
+	        this.excerpt = source["excerpt"];
+	        this.author_id = source["author_id"];
+	        this.author_name = source["author_name"];
+	        this.created_at = source["created_at"];
+	    }
+	}
 	export class LoginResponse {
 	    success: boolean;
 	    token: string;
 	    message: string;
+	    two_factor_required: boolean;
+	    challenge_token: string;
+	    two_factor_setup_required: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new LoginResponse(source);
@@ -105,6 +382,9 @@ export namespace services {
 	        this.success = source["success"];
 	        this.token = source["token"];
 	        this.message = source["message"];
+	        this.two_factor_required = source["two_factor_required"];
+	        this.challenge_token = source["challenge_token"];
+	        this.two_factor_setup_required = source["two_factor_setup_required"];
 	    }
 	}
 	export class Notification {
@@ -141,9 +421,14 @@ export namespace services {
 	    content: string;
 	    user_id: number;
 	    author_name: string;
+	    author_is_bot: boolean;
+	    author_avatar_url: string;
 	    answer_count: number;
+	    comment_count: number;
 	    created_at: string;
 	    updated_at: string;
+	    accepted_answer_id: number;
+	    is_anonymous: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new Question(source);
@@ -156,9 +441,14 @@ export namespace services {
 	        this.content = source["content"];
 	        this.user_id = source["user_id"];
 	        this.author_name = source["author_name"];
+	        this.author_is_bot = source["author_is_bot"];
+	        this.author_avatar_url = source["author_avatar_url"];
 	        this.answer_count = source["answer_count"];
+	        this.comment_count = source["comment_count"];
 	        this.created_at = source["created_at"];
 	        this.updated_at = source["updated_at"];
+	        this.accepted_answer_id = source["accepted_answer_id"];
+	        this.is_anonymous = source["is_anonymous"];
 	    }
 	}
 	export class RegisterResponse {
@@ -185,6 +475,7 @@ export namespace services {
 	    author_name: string;
 	    created_at: string;
 	    updated_at: string;
+	    is_anonymous: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new SearchResult(source);
@@ -199,6 +490,149 @@ export namespace services {
 	        this.author_name = source["author_name"];
 	        this.created_at = source["created_at"];
 	        this.updated_at = source["updated_at"];
+	        this.is_anonymous = source["is_anonymous"];
+	    }
+	}
+	export class Session {
+	    id: string;
+	    device: string;
+	    ip: string;
+	    user_agent: string;
+	    created_at: string;
+	    last_seen_at: string;
+	    current: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Session(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.device = source["device"];
+	        this.ip = source["ip"];
+	        this.user_agent = source["user_agent"];
+	        this.created_at = source["created_at"];
+	        this.last_seen_at = source["last_seen_at"];
+	        this.current = source["current"];
+	    }
+	}
+	export class SuggestedEdit {
+	    id: number;
+	    target_type: string;
+	    target_id: number;
+	    proposer_id: number;
+	    proposer_name: string;
+	    base_title: string;
+	    base_content: string;
+	    title: string;
+	    content: string;
+	    reason: string;
+	    status: string;
+	    review_comment: string;
+	    created_at: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SuggestedEdit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.target_type = source["target_type"];
+	        this.target_id = source["target_id"];
+	        this.proposer_id = source["proposer_id"];
+	        this.proposer_name = source["proposer_name"];
+	        this.base_title = source["base_title"];
+	        this.base_content = source["base_content"];
+	        this.title = source["title"];
+	        this.content = source["content"];
+	        this.reason = source["reason"];
+	        this.status = source["status"];
+	        this.review_comment = source["review_comment"];
+	        this.created_at = source["created_at"];
+	    }
+	}
+	export class TwoFactorEnrollment {
+	    secret: string;
+	    otpauth_uri: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TwoFactorEnrollment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.secret = source["secret"];
+	        this.otpauth_uri = source["otpauth_uri"];
+	    }
+	}
+	export class UserAnswer {
+	    id: number;
+	    question_id: number;
+	    content: string;
+	    user_id: number;
+	    username: string;
+	    author_is_bot: boolean;
+	    author_avatar_url: string;
+	    upvote_count: number;
+	    is_upvoted: boolean;
+	    is_anonymous: boolean;
+	    created_at: string;
+	    updated_at: string;
+	    question_title: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UserAnswer(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.question_id = source["question_id"];
+	        this.content = source["content"];
+	        this.user_id = source["user_id"];
+	        this.username = source["username"];
+	        this.author_is_bot = source["author_is_bot"];
+	        this.author_avatar_url = source["author_avatar_url"];
+	        this.upvote_count = source["upvote_count"];
+	        this.is_upvoted = source["is_upvoted"];
+	        this.is_anonymous = source["is_anonymous"];
+	        this.created_at = source["created_at"];
+	        this.updated_at = source["updated_at"];
+	        this.question_title = source["question_title"];
+	    }
+	}
+	export class UserComment {
+	    id: number;
+	    answer_id: number;
+	    user_id: number;
+	    username: string;
+	    author_is_bot: boolean;
+	    author_avatar_url: string;
+	    content: string;
+	    created_at: string;
+	    updated_at: string;
+	    question_id: number;
+	    question_title: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new UserComment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.answer_id = source["answer_id"];
+	        this.user_id = source["user_id"];
+	        this.username = source["username"];
+	        this.author_is_bot = source["author_is_bot"];
+	        this.author_avatar_url = source["author_avatar_url"];
+	        this.content = source["content"];
+	        this.created_at = source["created_at"];
+	        this.updated_at = source["updated_at"];
+	        this.question_id = source["question_id"];
+	        this.question_title = source["question_title"];
 	    }
 	}
 	export class UserProfile {
@@ -206,7 +640,15 @@ export namespace services {
 	    username: string;
 	    email: string;
 	    bio: string;
+	    avatar_url: string;
+	    language: string;
 	    created_at: string;
+	    email_verified: boolean;
+	    two_factor_enabled: boolean;
+	    reputation: number;
+	    question_count: number;
+	    answer_count: number;
+	    accepted_answer_count: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new UserProfile(source);
@@ -218,7 +660,15 @@ export namespace services {
 	        this.username = source["username"];
 	        this.email = source["email"];
 	        this.bio = source["bio"];
+	        this.avatar_url = source["avatar_url"];
+	        this.language = source["language"];
 	        this.created_at = source["created_at"];
+	        this.email_verified = source["email_verified"];
+	        this.two_factor_enabled = source["two_factor_enabled"];
+	        this.reputation = source["reputation"];
+	        this.question_count = source["question_count"];
+	        this.answer_count = source["answer_count"];
+	        this.accepted_answer_count = source["accepted_answer_count"];
 	    }
 	}
 
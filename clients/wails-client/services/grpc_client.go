@@ -7,6 +7,7 @@ import (
 	"log"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
@@ -32,10 +33,13 @@ type GRPCClient struct {
 	SearchClient       searchpb.SearchServiceClient
 	NotificationClient ntpb.NotificationServiceClient
 
-	// 存储当前用户的 token 和信息
-	token    string
-	userID   int64
-	username string
+	// 存储当前用户的 token 和信息，后台刷新会并发修改，因此需要加锁
+	mu           sync.RWMutex
+	token        string
+	refreshToken string
+	expiresAt    time.Time // 访问令牌的过期时间
+	userID       int64
+	username     string
 }
 
 // NewGRPCClient 创建新的 gRPC 客户端连接
@@ -156,42 +160,77 @@ func (c *GRPCClient) Close() error {
 
 // SetAuth 设置认证信息
 func (c *GRPCClient) SetAuth(token string, userID int64, username string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.token = token
 	c.userID = userID
 	c.username = username
 }
 
+// SetTokens 保存登录或刷新后得到的令牌对，用户信息保持不变
+func (c *GRPCClient) SetTokens(token, refreshToken string, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+	c.refreshToken = refreshToken
+	c.expiresAt = expiresAt
+}
+
 // ClearAuth 清除认证信息
 func (c *GRPCClient) ClearAuth() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.token = ""
+	c.refreshToken = ""
+	c.expiresAt = time.Time{}
 	c.userID = 0
 	c.username = ""
 }
 
 // GetToken 获取当前 token
 func (c *GRPCClient) GetToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.token
 }
 
+// GetRefreshToken 获取当前的刷新令牌
+func (c *GRPCClient) GetRefreshToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.refreshToken
+}
+
+// GetTokenExpiry 获取访问令牌的过期时间，未知时返回零值
+func (c *GRPCClient) GetTokenExpiry() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.expiresAt
+}
+
 // GetUserID 获取用户 ID
 func (c *GRPCClient) GetUserID() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.userID
 }
 
 // GetUsername 获取用户名
 func (c *GRPCClient) GetUsername() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.username
 }
 
 // IsAuthenticated 检查是否已登录
 func (c *GRPCClient) IsAuthenticated() bool {
-	return c.token != ""
+	return c.GetToken() != ""
 }
 
 // NewAuthContext 创建带 token 的 context
 func (c *GRPCClient) NewAuthContext(ctx context.Context) context.Context {
-	if c.token != "" {
-		md := metadata.Pairs("authorization", "Bearer "+c.token)
+	if token := c.GetToken(); token != "" {
+		md := metadata.Pairs("authorization", "Bearer "+token)
 		return metadata.NewOutgoingContext(ctx, md)
 	}
 	return ctx
@@ -22,9 +22,18 @@ type Question struct {
 	Content     string `json:"content"`
 	UserID      int64  `json:"user_id"`
 	AuthorName  string `json:"author_name"`
-	AnswerCount int64  `json:"answer_count"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	AuthorIsBot bool   `json:"author_is_bot"` // 作者是否为机器人账户
+	// AuthorAvatarURL 作者头像的小尺寸缩略图地址，没有头像或匿名时为空
+	AuthorAvatarURL string `json:"author_avatar_url"`
+	AnswerCount     int64  `json:"answer_count"`
+	// CommentCount 问题下所有回答的评论总数
+	CommentCount int64  `json:"comment_count"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+	// AcceptedAnswerID 被采纳的回答ID，0 表示尚未采纳
+	AcceptedAnswerID int64 `json:"accepted_answer_id"`
+	// IsAnonymous 匿名问题对其他用户隐藏作者
+	IsAnonymous bool `json:"is_anonymous"`
 }
 
 // Answer 回答结构
@@ -34,21 +43,28 @@ type Answer struct {
 	Content     string `json:"content"`
 	UserID      int64  `json:"user_id"`
 	Username    string `json:"username"`
-	UpvoteCount int32  `json:"upvote_count"`
-	IsUpvoted   bool   `json:"is_upvoted"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	AuthorIsBot bool   `json:"author_is_bot"`
+	// AuthorAvatarURL 回答者头像的小尺寸缩略图地址，没有头像或匿名时为空
+	AuthorAvatarURL string `json:"author_avatar_url"`
+	UpvoteCount     int32  `json:"upvote_count"`
+	IsUpvoted       bool   `json:"is_upvoted"`
+	IsAnonymous     bool   `json:"is_anonymous"`
+	CreatedAt       string `json:"created_at"`
+	UpdatedAt       string `json:"updated_at"`
 }
 
 // Comment 评论结构
 type Comment struct {
-	ID        int64  `json:"id"`
-	AnswerID  int64  `json:"answer_id"`
-	UserID    int64  `json:"user_id"`
-	Username  string `json:"username"`
-	Content   string `json:"content"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID          int64  `json:"id"`
+	AnswerID    int64  `json:"answer_id"`
+	UserID      int64  `json:"user_id"`
+	Username    string `json:"username"`
+	AuthorIsBot bool   `json:"author_is_bot"`
+	// AuthorAvatarURL 评论者头像的小尺寸缩略图地址，没有头像时为空
+	AuthorAvatarURL string `json:"author_avatar_url"`
+	Content         string `json:"content"`
+	CreatedAt       string `json:"created_at"`
+	UpdatedAt       string `json:"updated_at"`
 }
 
 // ListQuestions 获取问题列表
@@ -65,14 +81,53 @@ func (s *QAService) ListQuestions(ctx context.Context, page, pageSize int32) ([]
 	questions := make([]Question, 0, len(resp.Questions))
 	for _, q := range resp.Questions {
 		questions = append(questions, Question{
-			ID:          q.Id,
-			Title:       q.Title,
-			Content:     q.Content,
-			UserID:      q.UserId,
-			AuthorName:  q.AuthorName,
-			AnswerCount: q.AnswerCount,
-			CreatedAt:   q.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
-			UpdatedAt:   q.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			ID:               q.Id,
+			Title:            q.Title,
+			Content:          q.Content,
+			UserID:           q.UserId,
+			AuthorName:       q.AuthorName,
+			AuthorIsBot:      q.AuthorIsBot,
+			AuthorAvatarURL:  q.AuthorAvatarUrl,
+			IsAnonymous:      q.IsAnonymous,
+			AnswerCount:      q.AnswerCount,
+			CommentCount:     q.CommentCount,
+			AcceptedAnswerID: q.AcceptedAnswerId,
+			CreatedAt:        q.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			UpdatedAt:        q.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return questions, resp.TotalCount, nil
+}
+
+// ListTrendingQuestions 获取指定时间窗口内的热门问题，window 为 day、week 或 month
+func (s *QAService) ListTrendingQuestions(ctx context.Context, window string, page, pageSize int32) ([]Question, int64, error) {
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.QAClient.ListTrendingQuestions(authCtx, &qapb.ListTrendingQuestionsRequest{
+		Window:   window,
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取热门问题失败: %w", err)
+	}
+
+	questions := make([]Question, 0, len(resp.Questions))
+	for _, q := range resp.Questions {
+		questions = append(questions, Question{
+			ID:               q.Id,
+			Title:            q.Title,
+			Content:          q.Content,
+			UserID:           q.UserId,
+			AuthorName:       q.AuthorName,
+			AuthorIsBot:      q.AuthorIsBot,
+			AuthorAvatarURL:  q.AuthorAvatarUrl,
+			IsAnonymous:      q.IsAnonymous,
+			AnswerCount:      q.AnswerCount,
+			CommentCount:     q.CommentCount,
+			AcceptedAnswerID: q.AcceptedAnswerId,
+			CreatedAt:        q.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			UpdatedAt:        q.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
 		})
 	}
 
@@ -90,37 +145,80 @@ func (s *QAService) GetQuestion(ctx context.Context, id int64) (*Question, error
 	}
 
 	return &Question{
-		ID:          resp.Id,
-		Title:       resp.Title,
-		Content:     resp.Content,
-		UserID:      resp.UserId,
-		AuthorName:  resp.AuthorName,
-		AnswerCount: resp.AnswerCount,
-		CreatedAt:   resp.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
-		UpdatedAt:   resp.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		ID:               resp.Id,
+		Title:            resp.Title,
+		Content:          resp.Content,
+		UserID:           resp.UserId,
+		AuthorName:       resp.AuthorName,
+		AuthorIsBot:      resp.AuthorIsBot,
+		AuthorAvatarURL:  resp.AuthorAvatarUrl,
+		IsAnonymous:      resp.IsAnonymous,
+		AnswerCount:      resp.AnswerCount,
+		CommentCount:     resp.CommentCount,
+		AcceptedAnswerID: resp.AcceptedAnswerId,
+		CreatedAt:        resp.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		UpdatedAt:        resp.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
 	}, nil
 }
 
+// BatchGetQuestions 按 ID 批量获取问题，返回结果与不存在的问题ID
+func (s *QAService) BatchGetQuestions(ctx context.Context, ids []int64) ([]Question, []int64, error) {
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.QAClient.BatchGetQuestions(authCtx, &qapb.BatchGetQuestionsRequest{
+		Ids: ids,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("批量获取问题失败: %w", err)
+	}
+
+	questions := make([]Question, 0, len(resp.Questions))
+	for _, q := range resp.Questions {
+		questions = append(questions, Question{
+			ID:               q.Id,
+			Title:            q.Title,
+			Content:          q.Content,
+			UserID:           q.UserId,
+			AuthorName:       q.AuthorName,
+			AuthorIsBot:      q.AuthorIsBot,
+			AuthorAvatarURL:  q.AuthorAvatarUrl,
+			IsAnonymous:      q.IsAnonymous,
+			AnswerCount:      q.AnswerCount,
+			CommentCount:     q.CommentCount,
+			AcceptedAnswerID: q.AcceptedAnswerId,
+			CreatedAt:        q.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			UpdatedAt:        q.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return questions, resp.NotFoundIds, nil
+}
+
 // CreateQuestion 创建问题
-func (s *QAService) CreateQuestion(ctx context.Context, title, content string) (*Question, error) {
+func (s *QAService) CreateQuestion(ctx context.Context, title, content string, anonymous bool) (*Question, error) {
 	authCtx := s.client.NewAuthContext(ctx)
 	resp, err := s.client.QAClient.CreateQuestion(authCtx, &qapb.CreateQuestionRequest{
-		Title:   title,
-		Content: content,
+		Title:     title,
+		Content:   content,
+		Anonymous: anonymous,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("创建问题失败: %w", err)
 	}
 
 	return &Question{
-		ID:          resp.Id,
-		Title:       resp.Title,
-		Content:     resp.Content,
-		UserID:      resp.UserId,
-		AuthorName:  resp.AuthorName,
-		AnswerCount: resp.AnswerCount,
-		CreatedAt:   resp.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
-		UpdatedAt:   resp.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		ID:               resp.Id,
+		Title:            resp.Title,
+		Content:          resp.Content,
+		UserID:           resp.UserId,
+		AuthorName:       resp.AuthorName,
+		AuthorIsBot:      resp.AuthorIsBot,
+		AuthorAvatarURL:  resp.AuthorAvatarUrl,
+		IsAnonymous:      resp.IsAnonymous,
+		AnswerCount:      resp.AnswerCount,
+		CommentCount:     resp.CommentCount,
+		AcceptedAnswerID: resp.AcceptedAnswerId,
+		CreatedAt:        resp.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		UpdatedAt:        resp.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
 	}, nil
 }
 
@@ -137,14 +235,19 @@ func (s *QAService) UpdateQuestion(ctx context.Context, id int64, title, content
 	}
 
 	return &Question{
-		ID:          resp.Id,
-		Title:       resp.Title,
-		Content:     resp.Content,
-		UserID:      resp.UserId,
-		AuthorName:  resp.AuthorName,
-		AnswerCount: resp.AnswerCount,
-		CreatedAt:   resp.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
-		UpdatedAt:   resp.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		ID:               resp.Id,
+		Title:            resp.Title,
+		Content:          resp.Content,
+		UserID:           resp.UserId,
+		AuthorName:       resp.AuthorName,
+		AuthorIsBot:      resp.AuthorIsBot,
+		AuthorAvatarURL:  resp.AuthorAvatarUrl,
+		IsAnonymous:      resp.IsAnonymous,
+		AnswerCount:      resp.AnswerCount,
+		CommentCount:     resp.CommentCount,
+		AcceptedAnswerID: resp.AcceptedAnswerId,
+		CreatedAt:        resp.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		UpdatedAt:        resp.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
 	}, nil
 }
 
@@ -175,15 +278,18 @@ func (s *QAService) ListAnswers(ctx context.Context, questionID int64, page, pag
 	answers := make([]Answer, 0, len(resp.Answers))
 	for _, a := range resp.Answers {
 		answers = append(answers, Answer{
-			ID:          a.Id,
-			QuestionID:  a.QuestionId,
-			Content:     a.Content,
-			UserID:      a.UserId,
-			Username:    a.Username,
-			UpvoteCount: a.UpvoteCount,
-			IsUpvoted:   a.IsUpvotedByUser,
-			CreatedAt:   a.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
-			UpdatedAt:   a.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			ID:              a.Id,
+			QuestionID:      a.QuestionId,
+			Content:         a.Content,
+			UserID:          a.UserId,
+			Username:        a.Username,
+			AuthorIsBot:     a.AuthorIsBot,
+			AuthorAvatarURL: a.AuthorAvatarUrl,
+			UpvoteCount:     a.UpvoteCount,
+			IsAnonymous:     a.IsAnonymous,
+			IsUpvoted:       a.IsUpvotedByUser,
+			CreatedAt:       a.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			UpdatedAt:       a.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
 		})
 	}
 
@@ -191,26 +297,30 @@ func (s *QAService) ListAnswers(ctx context.Context, questionID int64, page, pag
 }
 
 // CreateAnswer 创建回答
-func (s *QAService) CreateAnswer(ctx context.Context, questionID int64, content string) (*Answer, error) {
+func (s *QAService) CreateAnswer(ctx context.Context, questionID int64, content string, anonymous bool) (*Answer, error) {
 	authCtx := s.client.NewAuthContext(ctx)
 	resp, err := s.client.QAClient.CreateAnswer(authCtx, &qapb.CreateAnswerRequest{
 		QuestionId: questionID,
 		Content:    content,
+		Anonymous:  anonymous,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("创建回答失败: %w", err)
 	}
 
 	return &Answer{
-		ID:          resp.Id,
-		QuestionID:  resp.QuestionId,
-		Content:     resp.Content,
-		UserID:      resp.UserId,
-		Username:    resp.Username,
-		UpvoteCount: resp.UpvoteCount,
-		IsUpvoted:   resp.IsUpvotedByUser,
-		CreatedAt:   resp.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
-		UpdatedAt:   resp.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		ID:              resp.Id,
+		QuestionID:      resp.QuestionId,
+		Content:         resp.Content,
+		UserID:          resp.UserId,
+		Username:        resp.Username,
+		AuthorIsBot:     resp.AuthorIsBot,
+		AuthorAvatarURL: resp.AuthorAvatarUrl,
+		UpvoteCount:     resp.UpvoteCount,
+		IsAnonymous:     resp.IsAnonymous,
+		IsUpvoted:       resp.IsUpvotedByUser,
+		CreatedAt:       resp.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		UpdatedAt:       resp.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
 	}, nil
 }
 
@@ -226,15 +336,18 @@ func (s *QAService) UpdateAnswer(ctx context.Context, id int64, content string)
 	}
 
 	return &Answer{
-		ID:          resp.Id,
-		QuestionID:  resp.QuestionId,
-		Content:     resp.Content,
-		UserID:      resp.UserId,
-		Username:    resp.Username,
-		UpvoteCount: resp.UpvoteCount,
-		IsUpvoted:   resp.IsUpvotedByUser,
-		CreatedAt:   resp.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
-		UpdatedAt:   resp.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		ID:              resp.Id,
+		QuestionID:      resp.QuestionId,
+		Content:         resp.Content,
+		UserID:          resp.UserId,
+		Username:        resp.Username,
+		AuthorIsBot:     resp.AuthorIsBot,
+		AuthorAvatarURL: resp.AuthorAvatarUrl,
+		UpvoteCount:     resp.UpvoteCount,
+		IsAnonymous:     resp.IsAnonymous,
+		IsUpvoted:       resp.IsUpvotedByUser,
+		CreatedAt:       resp.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		UpdatedAt:       resp.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
 	}, nil
 }
 
@@ -262,18 +375,130 @@ func (s *QAService) UpvoteAnswer(ctx context.Context, answerID int64) error {
 	return nil
 }
 
-// DownvoteAnswer 取消点赞
+// DownvoteAnswer 点踩回答
 func (s *QAService) DownvoteAnswer(ctx context.Context, answerID int64) error {
 	authCtx := s.client.NewAuthContext(ctx)
 	_, err := s.client.QAClient.DownvoteAnswer(authCtx, &qapb.DownvoteAnswerRequest{
 		AnswerId: answerID,
 	})
 	if err != nil {
-		return fmt.Errorf("取消点赞失败: %w", err)
+		return fmt.Errorf("点踩失败: %w", err)
+	}
+	return nil
+}
+
+// RetractAnswerVote 撤销对回答的点赞或点踩
+func (s *QAService) RetractAnswerVote(ctx context.Context, answerID int64) error {
+	authCtx := s.client.NewAuthContext(ctx)
+	_, err := s.client.QAClient.RetractAnswerVote(authCtx, &qapb.RetractAnswerVoteRequest{
+		AnswerId: answerID,
+	})
+	if err != nil {
+		return fmt.Errorf("取消投票失败: %w", err)
+	}
+	return nil
+}
+
+// AcceptAnswer 采纳回答（仅提问者可用）
+func (s *QAService) AcceptAnswer(ctx context.Context, answerID int64) error {
+	authCtx := s.client.NewAuthContext(ctx)
+	_, err := s.client.QAClient.AcceptAnswer(authCtx, &qapb.AcceptAnswerRequest{
+		AnswerId: answerID,
+	})
+	if err != nil {
+		return fmt.Errorf("采纳回答失败: %w", err)
 	}
 	return nil
 }
 
+// SuggestedEdit 修改建议结构
+type SuggestedEdit struct {
+	ID            int64  `json:"id"`
+	TargetType    string `json:"target_type"` // "question" 或 "answer"
+	TargetID      int64  `json:"target_id"`
+	ProposerID    int64  `json:"proposer_id"`
+	ProposerName  string `json:"proposer_name"`
+	BaseTitle     string `json:"base_title"`
+	BaseContent   string `json:"base_content"`
+	Title         string `json:"title"`
+	Content       string `json:"content"`
+	Reason        string `json:"reason"`
+	Status        string `json:"status"` // "pending"、"approved" 或 "rejected"
+	ReviewComment string `json:"review_comment"`
+	CreatedAt     string `json:"created_at"`
+}
+
+func toSuggestedEdit(e *qapb.SuggestedEdit) SuggestedEdit {
+	return SuggestedEdit{
+		ID:            e.Id,
+		TargetType:    e.TargetType,
+		TargetID:      e.TargetId,
+		ProposerID:    e.ProposerId,
+		ProposerName:  e.ProposerName,
+		BaseTitle:     e.BaseTitle,
+		BaseContent:   e.BaseContent,
+		Title:         e.Title,
+		Content:       e.Content,
+		Reason:        e.Reason,
+		Status:        e.Status,
+		ReviewComment: e.ReviewComment,
+		CreatedAt:     e.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+	}
+}
+
+// SuggestEdit 对他人的问题或回答提出修改建议
+func (s *QAService) SuggestEdit(ctx context.Context, targetType string, targetID int64, title, content, reason string) (*SuggestedEdit, error) {
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.QAClient.SuggestEdit(authCtx, &qapb.SuggestEditRequest{
+		TargetType: targetType,
+		TargetId:   targetID,
+		Title:      title,
+		Content:    content,
+		Reason:     reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("提交修改建议失败: %w", err)
+	}
+	edit := toSuggestedEdit(resp)
+	return &edit, nil
+}
+
+// ListSuggestedEdits 获取问题或回答的修改建议，status 为空时返回全部状态
+func (s *QAService) ListSuggestedEdits(ctx context.Context, targetType string, targetID int64, status string, page, pageSize int32) ([]SuggestedEdit, int64, error) {
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.QAClient.ListSuggestedEdits(authCtx, &qapb.ListSuggestedEditsRequest{
+		TargetType: targetType,
+		TargetId:   targetID,
+		Status:     status,
+		Page:       page,
+		PageSize:   pageSize,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取修改建议失败: %w", err)
+	}
+
+	edits := make([]SuggestedEdit, 0, len(resp.Edits))
+	for _, e := range resp.Edits {
+		edits = append(edits, toSuggestedEdit(e))
+	}
+	return edits, resp.TotalCount, nil
+}
+
+// ReviewSuggestedEdit 通过或拒绝修改建议（作者或版主可用）
+func (s *QAService) ReviewSuggestedEdit(ctx context.Context, editID int64, approve bool, comment string) (*SuggestedEdit, error) {
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.QAClient.ReviewSuggestedEdit(authCtx, &qapb.ReviewSuggestedEditRequest{
+		Id:      editID,
+		Approve: approve,
+		Comment: comment,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("审核修改建议失败: %w", err)
+	}
+	edit := toSuggestedEdit(resp)
+	return &edit, nil
+}
+
 // ListComments 获取回答的评论列表
 func (s *QAService) ListComments(ctx context.Context, answerID int64, page, pageSize int32) ([]Comment, int64, error) {
 	authCtx := s.client.NewAuthContext(ctx)
@@ -289,13 +514,15 @@ func (s *QAService) ListComments(ctx context.Context, answerID int64, page, page
 	comments := make([]Comment, 0, len(resp.Comments))
 	for _, c := range resp.Comments {
 		comments = append(comments, Comment{
-			ID:        c.Id,
-			AnswerID:  c.AnswerId,
-			UserID:    c.UserId,
-			Username:  c.Username,
-			Content:   c.Content,
-			CreatedAt: c.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
-			UpdatedAt: c.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			ID:              c.Id,
+			AnswerID:        c.AnswerId,
+			UserID:          c.UserId,
+			Username:        c.Username,
+			AuthorIsBot:     c.AuthorIsBot,
+			AuthorAvatarURL: c.AuthorAvatarUrl,
+			Content:         c.Content,
+			CreatedAt:       c.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			UpdatedAt:       c.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
 		})
 	}
 
@@ -314,13 +541,15 @@ func (s *QAService) CreateComment(ctx context.Context, answerID int64, content s
 	}
 
 	return &Comment{
-		ID:        resp.Id,
-		AnswerID:  resp.AnswerId,
-		UserID:    resp.UserId,
-		Username:  resp.Username,
-		Content:   resp.Content,
-		CreatedAt: resp.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
-		UpdatedAt: resp.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		ID:              resp.Id,
+		AnswerID:        resp.AnswerId,
+		UserID:          resp.UserId,
+		Username:        resp.Username,
+		AuthorIsBot:     resp.AuthorIsBot,
+		AuthorAvatarURL: resp.AuthorAvatarUrl,
+		Content:         resp.Content,
+		CreatedAt:       resp.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		UpdatedAt:       resp.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
 	}, nil
 }
 
@@ -336,13 +565,15 @@ func (s *QAService) UpdateComment(ctx context.Context, id int64, content string)
 	}
 
 	return &Comment{
-		ID:        resp.Id,
-		AnswerID:  resp.AnswerId,
-		UserID:    resp.UserId,
-		Username:  resp.Username,
-		Content:   resp.Content,
-		CreatedAt: resp.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
-		UpdatedAt: resp.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		ID:              resp.Id,
+		AnswerID:        resp.AnswerId,
+		UserID:          resp.UserId,
+		Username:        resp.Username,
+		AuthorIsBot:     resp.AuthorIsBot,
+		AuthorAvatarURL: resp.AuthorAvatarUrl,
+		Content:         resp.Content,
+		CreatedAt:       resp.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		UpdatedAt:       resp.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
 	}, nil
 }
 
@@ -357,3 +588,152 @@ func (s *QAService) DeleteComment(ctx context.Context, id int64) error {
 	}
 	return nil
 }
+
+// UserAnswer 用户回答列表中的一项，附带所属问题标题
+type UserAnswer struct {
+	Answer
+	QuestionTitle string `json:"question_title"`
+}
+
+// UserComment 用户评论列表中的一项，附带所属问题信息
+type UserComment struct {
+	Comment
+	QuestionID    int64  `json:"question_id"`
+	QuestionTitle string `json:"question_title"`
+}
+
+// Activity 用户动态时间线中的一项
+type Activity struct {
+	Type          string `json:"type"` // question, answer, comment
+	ID            int64  `json:"id"`
+	QuestionID    int64  `json:"question_id"`
+	QuestionTitle string `json:"question_title"`
+	Content       string `json:"content"`
+	CreatedAt     string `json:"created_at"`
+}
+
+// ListUserQuestions 获取指定用户的问题列表
+func (s *QAService) ListUserQuestions(ctx context.Context, userID int64, page, pageSize int32) ([]Question, int64, error) {
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.QAClient.ListUserQuestions(authCtx, &qapb.ListUserQuestionsRequest{
+		UserId:   userID,
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取用户问题失败: %w", err)
+	}
+
+	questions := make([]Question, 0, len(resp.Questions))
+	for _, q := range resp.Questions {
+		questions = append(questions, Question{
+			ID:               q.Id,
+			Title:            q.Title,
+			Content:          q.Content,
+			UserID:           q.UserId,
+			AuthorName:       q.AuthorName,
+			AuthorIsBot:      q.AuthorIsBot,
+			AuthorAvatarURL:  q.AuthorAvatarUrl,
+			IsAnonymous:      q.IsAnonymous,
+			AnswerCount:      q.AnswerCount,
+			CommentCount:     q.CommentCount,
+			AcceptedAnswerID: q.AcceptedAnswerId,
+			CreatedAt:        q.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			UpdatedAt:        q.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return questions, resp.TotalCount, nil
+}
+
+// ListUserAnswers 获取指定用户的回答列表
+func (s *QAService) ListUserAnswers(ctx context.Context, userID int64, page, pageSize int32) ([]UserAnswer, int64, error) {
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.QAClient.ListUserAnswers(authCtx, &qapb.ListUserAnswersRequest{
+		UserId:   userID,
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取用户回答失败: %w", err)
+	}
+
+	answers := make([]UserAnswer, 0, len(resp.Answers))
+	for _, a := range resp.Answers {
+		answers = append(answers, UserAnswer{
+			Answer: Answer{
+				ID:          a.Id,
+				QuestionID:  a.QuestionId,
+				Content:     a.Content,
+				UserID:      a.UserId,
+				Username:    a.Username,
+				UpvoteCount: a.UpvoteCount,
+				IsAnonymous: a.IsAnonymous,
+				CreatedAt:   a.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+				UpdatedAt:   a.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			},
+			QuestionTitle: a.QuestionTitle,
+		})
+	}
+
+	return answers, resp.TotalCount, nil
+}
+
+// ListUserComments 获取指定用户的评论列表
+func (s *QAService) ListUserComments(ctx context.Context, userID int64, page, pageSize int32) ([]UserComment, int64, error) {
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.QAClient.ListUserComments(authCtx, &qapb.ListUserCommentsRequest{
+		UserId:   userID,
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取用户评论失败: %w", err)
+	}
+
+	comments := make([]UserComment, 0, len(resp.Comments))
+	for _, c := range resp.Comments {
+		comments = append(comments, UserComment{
+			Comment: Comment{
+				ID:        c.Id,
+				AnswerID:  c.AnswerId,
+				UserID:    c.UserId,
+				Username:  c.Username,
+				Content:   c.Content,
+				CreatedAt: c.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+				UpdatedAt: c.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			},
+			QuestionID:    c.QuestionId,
+			QuestionTitle: c.QuestionTitle,
+		})
+	}
+
+	return comments, resp.TotalCount, nil
+}
+
+// GetUserActivity 获取指定用户的动态时间线
+func (s *QAService) GetUserActivity(ctx context.Context, userID int64, page, pageSize int32) ([]Activity, int64, error) {
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.QAClient.GetUserActivity(authCtx, &qapb.GetUserActivityRequest{
+		UserId:   userID,
+		Page:     page,
+		PageSize: pageSize,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取用户动态失败: %w", err)
+	}
+
+	activities := make([]Activity, 0, len(resp.Activities))
+	for _, a := range resp.Activities {
+		activities = append(activities, Activity{
+			Type:          a.Type,
+			ID:            a.Id,
+			QuestionID:    a.QuestionId,
+			QuestionTitle: a.QuestionTitle,
+			Content:       a.Content,
+			CreatedAt:     a.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return activities, resp.TotalCount, nil
+}
@@ -24,6 +24,8 @@ type SearchResult struct {
 	AuthorName string `json:"author_name"`
 	CreatedAt  string `json:"created_at"`
 	UpdatedAt  string `json:"updated_at"`
+	// IsAnonymous 匿名问题的作者信息为空
+	IsAnonymous bool `json:"is_anonymous"`
 }
 
 // SearchQuestions 搜索问题
@@ -43,20 +45,44 @@ func (s *SearchService) SearchQuestions(ctx context.Context, query string, limit
 		return nil, fmt.Errorf("搜索失败: %w", err)
 	}
 
-	results := make([]SearchResult, 0, len(resp.Questions))
-	for _, q := range resp.Questions {
+	return toSearchResults(resp.Questions), nil
+}
+
+// GetRelatedQuestions 获取与指定问题相似的问题
+func (s *SearchService) GetRelatedQuestions(ctx context.Context, questionID int64, limit int32) ([]SearchResult, error) {
+	if s.client == nil || s.client.SearchClient == nil {
+		return nil, fmt.Errorf("搜索服务未初始化")
+	}
+
+	authCtx := s.client.NewAuthContext(ctx)
+
+	resp, err := s.client.SearchClient.GetRelatedQuestions(authCtx, &searchpb.GetRelatedQuestionsRequest{
+		QuestionId: questionID,
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("获取相关问题失败: %w", err)
+	}
+
+	return toSearchResults(resp.Questions), nil
+}
+
+// toSearchResults 将 gRPC 返回的问题转换为前端使用的搜索结果
+func toSearchResults(questions []*searchpb.Question) []SearchResult {
+	results := make([]SearchResult, 0, len(questions))
+	for _, q := range questions {
 		results = append(results, SearchResult{
-			ID:         q.Id,
-			Title:      q.Title,
-			Content:    q.Content,
-			AuthorID:   q.AuthorId,
-			AuthorName: q.AuthorName,
-			CreatedAt:  q.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
-			UpdatedAt:  q.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			ID:          q.Id,
+			Title:       q.Title,
+			Content:     q.Content,
+			AuthorID:    q.AuthorId,
+			AuthorName:  q.AuthorName,
+			CreatedAt:   q.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			UpdatedAt:   q.UpdatedAt.AsTime().Format("2006-01-02 15:04:05"),
+			IsAnonymous: q.IsAnonymous,
 		})
 	}
-
-	return results, nil
+	return results
 }
 
 // IndexAllQuestions 索引所有问题（仅用于测试/管理）
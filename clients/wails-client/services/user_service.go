@@ -3,16 +3,31 @@ package services
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
+	"os"
+	"runtime"
+	"sync"
+	"time"
 
 	userpb "wails-client/api/proto/user"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
+// refreshAhead 在访问令牌过期前多久进行静默刷新
+const refreshAhead = time.Minute
+
 // UserService 用户服务的业务逻辑层
 type UserService struct {
 	client *GRPCClient
+
+	refreshMu    sync.Mutex
+	refreshTimer *time.Timer // 访问令牌过期前触发的静默刷新
 }
 
 // NewUserService 创建用户服务实例
@@ -31,6 +46,17 @@ type LoginResponse struct {
 	Success bool   `json:"success"`
 	Token   string `json:"token"`
 	Message string `json:"message"`
+
+	// 启用了两步验证时密码校验通过后返回挑战令牌，需要再调用 Verify2FA 完成登录
+	TwoFactorRequired      bool   `json:"two_factor_required"`
+	ChallengeToken         string `json:"challenge_token"`
+	TwoFactorSetupRequired bool   `json:"two_factor_setup_required"` // 角色要求两步验证但尚未启用
+}
+
+// TwoFactorEnrollment 两步验证的绑定信息
+type TwoFactorEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
 }
 
 // RegisterRequest 注册请求
@@ -53,31 +79,138 @@ type UserProfile struct {
 	Username  string `json:"username"`
 	Email     string `json:"email"`
 	Bio       string `json:"bio"`
+	AvatarURL string `json:"avatar_url"` // 头像缩略图地址，没有上传头像时为空
+	Language  string `json:"language"`   // 语言偏好，决定通知的展示语言
 	CreatedAt string `json:"created_at"`
+
+	EmailVerified    bool `json:"email_verified"`     // 邮箱未验证时只能浏览，不能发帖
+	TwoFactorEnabled bool `json:"two_factor_enabled"` // 只有查看自己的资料时才会返回
+
+	Reputation          int64 `json:"reputation"`
+	QuestionCount       int64 `json:"question_count"`
+	AnswerCount         int64 `json:"answer_count"`
+	AcceptedAnswerCount int64 `json:"accepted_answer_count"`
+}
+
+// Session 登录会话，即一台已登录的设备
+type Session struct {
+	ID         string `json:"id"`
+	Device     string `json:"device"`
+	IP         string `json:"ip"`
+	UserAgent  string `json:"user_agent"`
+	CreatedAt  string `json:"created_at"`
+	LastSeenAt string `json:"last_seen_at"`
+	Current    bool   `json:"current"` // 是否为本机的会话
+}
+
+// AccessToken 个人访问令牌，令牌本身只在创建时返回一次
+type AccessToken struct {
+	ID         int64    `json:"id"`
+	Name       string   `json:"name"`
+	TokenHint  string   `json:"token_hint"` // 令牌的最后几个字符
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  string   `json:"expires_at"` // 为空表示永不过期
+	LastUsedAt string   `json:"last_used_at"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+// FeedItem 关注动态中的一条内容，即关注的用户新发布的问题或回答
+type FeedItem struct {
+	Type       string `json:"type"` // question 或 answer
+	QuestionID int64  `json:"question_id"`
+	AnswerID   int64  `json:"answer_id"`
+	Title      string `json:"title"` // 只有问题有标题
+	Excerpt    string `json:"excerpt"`
+	AuthorID   int64  `json:"author_id"`
+	AuthorName string `json:"author_name"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// CreatedAccessToken 新创建的访问令牌
+type CreatedAccessToken struct {
+	Token       string      `json:"token"`
+	AccessToken AccessToken `json:"access_token"`
+}
+
+// deviceName 返回上报给服务端的设备名称，用于在会话列表中区分设备
+func deviceName() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s (%s)", host, runtime.GOOS)
+}
+
+// withDevice 在 context 中附加设备名称
+func withDevice(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "x-device-name", deviceName())
 }
 
 // Login 用户登录
 func (s *UserService) Login(ctx context.Context, req LoginRequest) (*LoginResponse, error) {
 	// 调用 gRPC 登录
-	resp, err := s.client.UserClient.Login(ctx, &userpb.LoginRequest{
+	resp, err := s.client.UserClient.Login(withDevice(ctx), &userpb.LoginRequest{
 		Username: req.Username,
 		Password: req.Password,
 	})
 	if err != nil {
 		return &LoginResponse{
 			Success: false,
-			Message: fmt.Sprintf("登录失败: %v", err),
+			Message: fmt.Sprintf("登录失败: %s", status.Convert(err).Message()),
+		}, nil
+	}
+
+	if resp.ChallengeToken != "" {
+		return &LoginResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    resp.ChallengeToken,
+			Message:           "请输入身份验证器中的验证码或恢复码",
 		}, nil
 	}
 
+	return s.completeLogin(resp, req.Username), nil
+}
+
+// Verify2FA 使用登录时返回的挑战令牌和验证码完成两步验证登录
+func (s *UserService) Verify2FA(ctx context.Context, challengeToken, code, username string) (*LoginResponse, error) {
+	resp, err := s.client.UserClient.Verify2FA(withDevice(ctx), &userpb.Verify2FARequest{
+		ChallengeToken: challengeToken,
+		Code:           code,
+	})
+	if err != nil {
+		// 挑战已失效时需要重新输入用户名和密码，其余错误可以继续输入验证码重试
+		retry := status.Code(err) != codes.Unauthenticated
+		resp := &LoginResponse{
+			Success:           false,
+			TwoFactorRequired: retry,
+			Message:           fmt.Sprintf("登录失败: %s", status.Convert(err).Message()),
+		}
+		if retry {
+			resp.ChallengeToken = challengeToken
+		}
+		return resp, nil
+	}
+
+	return s.completeLogin(resp, username), nil
+}
+
+// completeLogin 保存登录得到的令牌并安排静默刷新
+func (s *UserService) completeLogin(resp *userpb.LoginResponse, username string) *LoginResponse {
 	// 保存 token (username 暂时从请求中获取，后续可以从 token 解析)
-	s.client.SetAuth(resp.Token, 0, req.Username)
+	s.client.SetAuth(resp.Token, 0, username)
+	s.client.SetTokens(resp.Token, resp.RefreshToken, resp.ExpiresAt.AsTime())
+	s.scheduleRefresh()
 
+	message := "登录成功"
+	if resp.TwoFactorSetupRequired {
+		message = "登录成功，你的角色要求启用两步验证，启用前只拥有普通用户权限"
+	}
 	return &LoginResponse{
-		Success: true,
-		Token:   resp.Token,
-		Message: "登录成功",
-	}, nil
+		Success:                true,
+		Token:                  resp.Token,
+		Message:                message,
+		TwoFactorSetupRequired: resp.TwoFactorSetupRequired,
+	}
 }
 
 // Register 用户注册
@@ -117,7 +250,17 @@ func (s *UserService) GetProfile(ctx context.Context, userID int64) (*UserProfil
 		Username:  resp.User.Username,
 		Email:     resp.User.Email,
 		Bio:       resp.User.Bio,
+		AvatarURL: resp.User.AvatarUrl,
+		Language:  resp.User.Language,
 		CreatedAt: resp.User.CreatedAt.AsTime().Format("2006-01-02 15:04:05"),
+
+		EmailVerified:    resp.User.EmailVerified,
+		TwoFactorEnabled: resp.User.TwoFactorEnabled,
+
+		Reputation:          resp.User.Reputation,
+		QuestionCount:       resp.User.QuestionCount,
+		AnswerCount:         resp.User.AnswerCount,
+		AcceptedAnswerCount: resp.User.AcceptedAnswerCount,
 	}, nil
 }
 
@@ -147,6 +290,376 @@ func (s *UserService) GetCurrentUser(ctx context.Context) (*UserProfile, error)
 	return s.GetProfile(ctx, validateResp.UserId)
 }
 
+// UpdateLanguage 更新当前用户的语言偏好，只修改 language 字段
+func (s *UserService) UpdateLanguage(ctx context.Context, language string) error {
+	if !s.client.IsAuthenticated() {
+		return fmt.Errorf("用户未登录")
+	}
+
+	// 刚登录时还没有 userID，先通过 token 获取
+	userID := s.client.GetUserID()
+	if userID == 0 {
+		profile, err := s.GetCurrentUser(ctx)
+		if err != nil {
+			return err
+		}
+		userID = profile.UserID
+	}
+
+	authCtx := s.client.NewAuthContext(ctx)
+	_, err := s.client.UserClient.UpdateUserProfile(authCtx, &userpb.UpdateUserProfileRequest{
+		UserId:     userID,
+		Language:   language,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"language"}},
+	})
+	if err != nil {
+		return fmt.Errorf("更新语言偏好失败: %w", err)
+	}
+	return nil
+}
+
+// ListSessions 获取当前用户所有已登录的设备
+func (s *UserService) ListSessions(ctx context.Context) ([]Session, error) {
+	if !s.client.IsAuthenticated() {
+		return nil, fmt.Errorf("用户未登录")
+	}
+
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.UserClient.ListSessions(authCtx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("获取登录设备失败: %w", err)
+	}
+
+	sessions := make([]Session, 0, len(resp.Sessions))
+	for _, session := range resp.Sessions {
+		sessions = append(sessions, Session{
+			ID:         session.Id,
+			Device:     session.Device,
+			IP:         session.Ip,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  session.CreatedAt.AsTime().Local().Format("2006-01-02 15:04:05"),
+			LastSeenAt: session.LastSeenAt.AsTime().Local().Format("2006-01-02 15:04:05"),
+			Current:    session.Current,
+		})
+	}
+	return sessions, nil
+}
+
+// RevokeSession 将指定设备踢下线
+func (s *UserService) RevokeSession(ctx context.Context, sessionID string) error {
+	if !s.client.IsAuthenticated() {
+		return fmt.Errorf("用户未登录")
+	}
+
+	authCtx := s.client.NewAuthContext(ctx)
+	_, err := s.client.UserClient.RevokeSession(authCtx, &userpb.RevokeSessionRequest{SessionId: sessionID})
+	if err != nil {
+		return fmt.Errorf("撤销会话失败: %w", err)
+	}
+	return nil
+}
+
+// RevokeAllOtherSessions 将除本机以外的所有设备踢下线，返回下线的设备数
+func (s *UserService) RevokeAllOtherSessions(ctx context.Context) (int32, error) {
+	if !s.client.IsAuthenticated() {
+		return 0, fmt.Errorf("用户未登录")
+	}
+
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.UserClient.RevokeAllOtherSessions(authCtx, &emptypb.Empty{})
+	if err != nil {
+		return 0, fmt.Errorf("撤销其他会话失败: %w", err)
+	}
+	return resp.RevokedCount, nil
+}
+
+// ChangeUsername 修改当前用户的用户名，返回修改后的用户名。两次修改之间有冷却期
+func (s *UserService) ChangeUsername(ctx context.Context, username string) (string, error) {
+	if !s.client.IsAuthenticated() {
+		return "", fmt.Errorf("用户未登录")
+	}
+
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.UserClient.ChangeUsername(authCtx, &userpb.ChangeUsernameRequest{Username: username})
+	if err != nil {
+		return "", fmt.Errorf("修改用户名失败: %s", status.Convert(err).Message())
+	}
+	s.client.SetAuth(s.client.GetToken(), resp.User.Id, resp.User.Username)
+	return resp.User.Username, nil
+}
+
+// avatarChunkSize 是上传头像时每个分块的大小
+const avatarChunkSize = 64 * 1024
+
+// UploadAvatar 读取本地图片文件并分块上传为当前用户的头像，返回新的头像地址
+func (s *UserService) UploadAvatar(ctx context.Context, path string) (string, error) {
+	if !s.client.IsAuthenticated() {
+		return "", fmt.Errorf("用户未登录")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("读取图片失败: %w", err)
+	}
+
+	authCtx := s.client.NewAuthContext(ctx)
+	stream, err := s.client.UserClient.UploadAvatar(authCtx)
+	if err != nil {
+		return "", fmt.Errorf("上传头像失败: %s", status.Convert(err).Message())
+	}
+	for start := 0; start < len(data); start += avatarChunkSize {
+		end := min(start+avatarChunkSize, len(data))
+		if err := stream.Send(&userpb.UploadAvatarRequest{Chunk: data[start:end]}); err != nil {
+			// 服务端提前结束时，真正的错误原因在 CloseAndRecv 中返回
+			if err == io.EOF {
+				break
+			}
+			return "", fmt.Errorf("上传头像失败: %s", status.Convert(err).Message())
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return "", fmt.Errorf("上传头像失败: %s", status.Convert(err).Message())
+	}
+	return resp.User.AvatarUrl, nil
+}
+
+// ChangePassword 修改密码，成功后其他设备会被下线
+func (s *UserService) ChangePassword(ctx context.Context, currentPassword, newPassword string) error {
+	if !s.client.IsAuthenticated() {
+		return fmt.Errorf("用户未登录")
+	}
+
+	authCtx := s.client.NewAuthContext(ctx)
+	_, err := s.client.UserClient.ChangePassword(authCtx, &userpb.ChangePasswordRequest{
+		CurrentPassword: currentPassword,
+		NewPassword:     newPassword,
+	})
+	if err != nil {
+		return fmt.Errorf("修改密码失败: %s", status.Convert(err).Message())
+	}
+	return nil
+}
+
+// RequestPasswordReset 请求向邮箱发送重置码
+func (s *UserService) RequestPasswordReset(ctx context.Context, email string) error {
+	_, err := s.client.UserClient.RequestPasswordReset(withDevice(ctx), &userpb.RequestPasswordResetRequest{
+		Email: email,
+	})
+	if err != nil {
+		return fmt.Errorf("发送重置码失败: %s", status.Convert(err).Message())
+	}
+	return nil
+}
+
+// ConfirmPasswordReset 使用邮件中的重置码设置新密码
+func (s *UserService) ConfirmPasswordReset(ctx context.Context, token, newPassword string) error {
+	_, err := s.client.UserClient.ConfirmPasswordReset(ctx, &userpb.ConfirmPasswordResetRequest{
+		Token:       token,
+		NewPassword: newPassword,
+	})
+	if err != nil {
+		return fmt.Errorf("重置密码失败: %s", status.Convert(err).Message())
+	}
+	return nil
+}
+
+// VerifyEmail 使用邮件中的验证码验证邮箱
+func (s *UserService) VerifyEmail(ctx context.Context, token string) error {
+	_, err := s.client.UserClient.VerifyEmail(ctx, &userpb.VerifyEmailRequest{Token: token})
+	if err != nil {
+		return fmt.Errorf("验证邮箱失败: %s", status.Convert(err).Message())
+	}
+
+	// 访问令牌中的验证状态要等到下次刷新才会更新，立即刷新一次以便马上可以发帖
+	if s.client.IsAuthenticated() {
+		s.stopRefresh()
+		s.refreshSession()
+	}
+	return nil
+}
+
+// ResendVerification 向当前用户的邮箱重新发送验证码
+func (s *UserService) ResendVerification(ctx context.Context) error {
+	authCtx := s.client.NewAuthContext(ctx)
+	_, err := s.client.UserClient.ResendVerification(authCtx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("发送验证邮件失败: %s", status.Convert(err).Message())
+	}
+	return nil
+}
+
+// Enroll2FA 生成两步验证的密钥，需要再调用 Confirm2FA 才会启用
+func (s *UserService) Enroll2FA(ctx context.Context) (*TwoFactorEnrollment, error) {
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.UserClient.Enroll2FA(authCtx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("绑定两步验证失败: %s", status.Convert(err).Message())
+	}
+	return &TwoFactorEnrollment{Secret: resp.Secret, OTPAuthURI: resp.OtpauthUri}, nil
+}
+
+// Confirm2FA 使用身份验证器中的验证码启用两步验证，返回只展示一次的恢复码
+func (s *UserService) Confirm2FA(ctx context.Context, code string) ([]string, error) {
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.UserClient.Confirm2FA(authCtx, &userpb.Confirm2FARequest{Code: code})
+	if err != nil {
+		return nil, fmt.Errorf("启用两步验证失败: %s", status.Convert(err).Message())
+	}
+
+	// 版主和管理员启用后才拥有对应的权限，立即刷新一次令牌
+	s.stopRefresh()
+	s.refreshSession()
+	return resp.RecoveryCodes, nil
+}
+
+// Disable2FA 使用验证码或恢复码停用两步验证
+func (s *UserService) Disable2FA(ctx context.Context, code string) error {
+	authCtx := s.client.NewAuthContext(ctx)
+	_, err := s.client.UserClient.Disable2FA(authCtx, &userpb.Disable2FARequest{Code: code})
+	if err != nil {
+		return fmt.Errorf("停用两步验证失败: %s", status.Convert(err).Message())
+	}
+
+	s.stopRefresh()
+	s.refreshSession()
+	return nil
+}
+
+// UnlockUser 解除用户的登录锁定，仅管理员可用
+func (s *UserService) UnlockUser(ctx context.Context, userID int64) error {
+	authCtx := s.client.NewAuthContext(ctx)
+	_, err := s.client.UserClient.UnlockUser(authCtx, &userpb.UnlockUserRequest{UserId: userID})
+	if err != nil {
+		return fmt.Errorf("解锁用户失败: %s", status.Convert(err).Message())
+	}
+	return nil
+}
+
+// CreateBot 创建机器人账户，仅管理员可用，返回机器人的用户ID
+func (s *UserService) CreateBot(ctx context.Context, username, bio string) (int64, error) {
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.UserClient.CreateBot(authCtx, &userpb.CreateBotRequest{Username: username, Bio: bio})
+	if err != nil {
+		return 0, fmt.Errorf("创建机器人账户失败: %s", status.Convert(err).Message())
+	}
+	return resp.User.Id, nil
+}
+
+// CreateAccessToken 创建个人访问令牌，expiresInDays 为 0 表示永不过期。
+// userID 为 0 时为当前用户创建，管理员可以为机器人账户创建。
+func (s *UserService) CreateAccessToken(ctx context.Context, userID int64, name string, scopes []string, expiresInDays int32) (*CreatedAccessToken, error) {
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.UserClient.CreateAccessToken(authCtx, &userpb.CreateAccessTokenRequest{
+		UserId:           userID,
+		Name:             name,
+		Scopes:           scopes,
+		ExpiresInSeconds: int64(expiresInDays) * 24 * 60 * 60,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("创建访问令牌失败: %s", status.Convert(err).Message())
+	}
+	return &CreatedAccessToken{Token: resp.Token, AccessToken: newAccessToken(resp.AccessToken)}, nil
+}
+
+// ListAccessTokens 获取尚未撤销的访问令牌，userID 为 0 时为当前用户
+func (s *UserService) ListAccessTokens(ctx context.Context, userID int64) ([]AccessToken, error) {
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.UserClient.ListAccessTokens(authCtx, &userpb.ListAccessTokensRequest{UserId: userID})
+	if err != nil {
+		return nil, fmt.Errorf("获取访问令牌失败: %s", status.Convert(err).Message())
+	}
+
+	tokens := make([]AccessToken, 0, len(resp.AccessTokens))
+	for _, token := range resp.AccessTokens {
+		tokens = append(tokens, newAccessToken(token))
+	}
+	return tokens, nil
+}
+
+// RevokeAccessToken 撤销访问令牌，userID 为 0 时为当前用户
+func (s *UserService) RevokeAccessToken(ctx context.Context, userID, tokenID int64) error {
+	authCtx := s.client.NewAuthContext(ctx)
+	_, err := s.client.UserClient.RevokeAccessToken(authCtx, &userpb.RevokeAccessTokenRequest{UserId: userID, TokenId: tokenID})
+	if err != nil {
+		return fmt.Errorf("撤销访问令牌失败: %s", status.Convert(err).Message())
+	}
+	return nil
+}
+
+func newAccessToken(token *userpb.AccessToken) AccessToken {
+	result := AccessToken{
+		ID:        token.Id,
+		Name:      token.Name,
+		TokenHint: token.TokenHint,
+		Scopes:    token.Scopes,
+		CreatedAt: token.CreatedAt.AsTime().Local().Format("2006-01-02 15:04:05"),
+	}
+	if token.ExpiresAt != nil {
+		result.ExpiresAt = token.ExpiresAt.AsTime().Local().Format("2006-01-02 15:04:05")
+	}
+	if token.LastUsedAt != nil {
+		result.LastUsedAt = token.LastUsedAt.AsTime().Local().Format("2006-01-02 15:04:05")
+	}
+	return result
+}
+
+// FollowUser 关注用户，之后其新发布的问题和回答会出现在关注动态中
+func (s *UserService) FollowUser(ctx context.Context, userID int64) error {
+	if !s.client.IsAuthenticated() {
+		return fmt.Errorf("用户未登录")
+	}
+
+	authCtx := s.client.NewAuthContext(ctx)
+	_, err := s.client.UserClient.FollowUser(authCtx, &userpb.FollowUserRequest{UserId: userID})
+	if err != nil {
+		return fmt.Errorf("关注失败: %s", status.Convert(err).Message())
+	}
+	return nil
+}
+
+// UnfollowUser 取消关注用户
+func (s *UserService) UnfollowUser(ctx context.Context, userID int64) error {
+	if !s.client.IsAuthenticated() {
+		return fmt.Errorf("用户未登录")
+	}
+
+	authCtx := s.client.NewAuthContext(ctx)
+	_, err := s.client.UserClient.UnfollowUser(authCtx, &userpb.FollowUserRequest{UserId: userID})
+	if err != nil {
+		return fmt.Errorf("取消关注失败: %s", status.Convert(err).Message())
+	}
+	return nil
+}
+
+// GetFeed 分页获取当前用户的关注动态
+func (s *UserService) GetFeed(ctx context.Context, page, pageSize int32) ([]FeedItem, int64, error) {
+	if !s.client.IsAuthenticated() {
+		return nil, 0, fmt.Errorf("用户未登录")
+	}
+
+	authCtx := s.client.NewAuthContext(ctx)
+	resp, err := s.client.UserClient.GetFeed(authCtx, &userpb.GetFeedRequest{Page: page, PageSize: pageSize})
+	if err != nil {
+		return nil, 0, fmt.Errorf("获取关注动态失败: %w", err)
+	}
+
+	items := make([]FeedItem, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		items = append(items, FeedItem{
+			Type:       item.Type,
+			QuestionID: item.QuestionId,
+			AnswerID:   item.AnswerId,
+			Title:      item.Title,
+			Excerpt:    item.Excerpt,
+			AuthorID:   item.AuthorId,
+			AuthorName: item.AuthorName,
+			CreatedAt:  item.CreatedAt.AsTime().Local().Format("2006-01-02 15:04:05"),
+		})
+	}
+	return items, resp.TotalCount, nil
+}
+
 // Logout 用户登出
 func (s *UserService) Logout() {
 	if !s.client.IsAuthenticated() {
@@ -154,6 +667,8 @@ func (s *UserService) Logout() {
 		return
 	}
 
+	s.stopRefresh()
+
 	authCtx := s.client.NewAuthContext(context.Background())
 	_, err := s.client.UserClient.Logout(authCtx, &emptypb.Empty{})
 	if err != nil {
@@ -164,6 +679,74 @@ func (s *UserService) Logout() {
 	s.client.ClearAuth()
 }
 
+// scheduleRefresh 安排在访问令牌过期前静默刷新，没有刷新令牌时不做任何事
+func (s *UserService) scheduleRefresh() {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	if s.refreshTimer != nil {
+		s.refreshTimer.Stop()
+		s.refreshTimer = nil
+	}
+	if s.client.GetRefreshToken() == "" {
+		return
+	}
+
+	delay := time.Until(s.client.GetTokenExpiry()) - refreshAhead
+	if delay < 0 {
+		delay = 0
+	}
+	s.refreshTimer = time.AfterFunc(delay, s.refreshSession)
+}
+
+// stopRefresh 取消尚未触发的静默刷新
+func (s *UserService) stopRefresh() {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+
+	if s.refreshTimer != nil {
+		s.refreshTimer.Stop()
+		s.refreshTimer = nil
+	}
+}
+
+// refreshSession 使用刷新令牌换取新的令牌对，失败时清除登录状态
+func (s *UserService) refreshSession() {
+	refreshToken := s.client.GetRefreshToken()
+	if refreshToken == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	resp, err := s.client.UserClient.RefreshToken(withDevice(ctx), &userpb.RefreshTokenRequest{
+		RefreshToken: refreshToken,
+	})
+	if err != nil {
+		if status.Code(err) == codes.Unauthenticated {
+			// 刷新令牌已失效或被撤销，只能重新登录
+			log.Printf("刷新令牌失效，需要重新登录: %v", err)
+			s.client.ClearAuth()
+			return
+		}
+		// 网络等临时错误，稍后重试
+		log.Printf("静默刷新令牌失败，稍后重试: %v", err)
+		s.refreshMu.Lock()
+		s.refreshTimer = time.AfterFunc(refreshAhead/4, s.refreshSession)
+		s.refreshMu.Unlock()
+		return
+	}
+
+	// 刷新期间用户可能已登出，此时丢弃新令牌
+	if s.client.GetRefreshToken() != refreshToken {
+		return
+	}
+	s.client.SetTokens(resp.Token, resp.RefreshToken, resp.ExpiresAt.AsTime())
+	log.Println("访问令牌已静默刷新")
+	s.scheduleRefresh()
+}
+
 // IsLoggedIn 检查是否已登录
 func (s *UserService) IsLoggedIn() bool {
 	return s.client.IsAuthenticated()
@@ -159,9 +159,10 @@ func (s *NotificationGrpcServer) SubscribeNotifications(req *pb.SubscribeNotific
 
 	// 创建流客户端
 	streamClient := &service.StreamClient{
-		UserID: userID,
-		Stream: stream,
-		Done:   make(chan struct{}),
+		UserID:   userID,
+		Language: s.notificationService.UserLanguage(stream.Context(), userID),
+		Stream:   stream,
+		Done:     make(chan struct{}),
 	}
 
 	// 注册到 StreamHub
@@ -14,10 +14,13 @@ type Notification struct {
 	SenderID    int64              `bson:"sender_id,omitempty" json:"sender_id,omitempty"`     // 发送者的用户ID，可选
 	SenderName  string             `bson:"sender_name,omitempty" json:"sender_name,omitempty"` // 发送者的用户名，可选
 	Type        string             `bson:"type" json:"type"`                                   // 通知类型，如 "comment", "like", "follow"
-	Content     string             `bson:"content" json:"content"`                             // 通知内容
+	Content     string             `bson:"content" json:"content"`                             // 通知内容，新通知在读取时按 Params 渲染
 	TargetURL   string             `bson:"target_url" json:"target_url"`                       // 相关链接，如评论或帖子链接
 	IsRead      bool               `bson:"is_read" json:"is_read"`                             // 是否已读
 	CreatedAt   time.Time          `bson:"created_at" json:"created_at"`                       // 创建时间
+
+	// Params 是渲染通知模板的参数，旧通知没有该字段，直接展示 Content
+	Params map[string]string `bson:"params,omitempty" json:"params,omitempty"`
 }
 
 // MarshalJSON 自定义JSON序列化，将ObjectID转换为字符串
@@ -5,8 +5,10 @@ import (
 	"encoding/json"
 	"log/slog"
 	pb "qahub/api/proto/notification"
+	userpb "qahub/api/proto/user"
 	"qahub/notification-service/internal/model"
 	"qahub/notification-service/internal/store"
+	"qahub/pkg/i18n"
 	"qahub/pkg/log"
 	"qahub/pkg/messaging"
 	"time"
@@ -26,24 +28,48 @@ type NotificationService interface {
 	DeleteNotification(ctx context.Context, userID int64, notificationID string) error
 	DeleteNotifications(ctx context.Context, userID int64, notificationIDs []string) (int64, error)
 	GetUnreadCount(ctx context.Context, userID int64) (int64, error)
+	UserLanguage(ctx context.Context, userID int64) string
 	GetStreamHub() *StreamHub
 }
 
+// UserProfileGetter 用于查询通知接收者的资料，以获取其语言偏好
+type UserProfileGetter interface {
+	GetUserProfile(ctx context.Context, userID int64) (*userpb.GetUserProfileResponse, error)
+}
+
 // notificationService 是 NotificationService 接口的具体实现
 type notificationService struct {
 	store     store.NotificationStore
 	streamHub *StreamHub
+	users     UserProfileGetter
 }
 
 // NewNotificationService 创建一个新的 NotificationService 实例
-func NewNotificationService(store store.NotificationStore, streamHub *StreamHub) *notificationService {
+func NewNotificationService(store store.NotificationStore, streamHub *StreamHub, users UserProfileGetter) *notificationService {
 	service := &notificationService{
 		store:     store,
 		streamHub: streamHub,
+		users:     users,
 	}
 	return service
 }
 
+// UserLanguage 查询用户的语言偏好，查询失败时使用默认语言
+func (s *notificationService) UserLanguage(ctx context.Context, userID int64) string {
+	if s.users == nil {
+		return i18n.DefaultLanguage
+	}
+	resp, err := s.users.GetUserProfile(ctx, userID)
+	if err != nil {
+		log.FromContext(ctx).Warn("获取用户语言偏好失败，使用默认语言",
+			slog.Int64("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return i18n.DefaultLanguage
+	}
+	return i18n.Normalize(resp.GetUser().GetLanguage())
+}
+
 // GetStreamHub 返回 StreamHub 实例
 func (s *notificationService) GetStreamHub() *StreamHub {
 	return s.streamHub
@@ -61,6 +87,12 @@ func (s *notificationService) GetNotifications(ctx context.Context, userID int64
 		)
 		return nil, err
 	}
+
+	// 按接收者的语言渲染通知内容
+	lang := s.UserLanguage(ctx, userID)
+	for _, n := range notifications {
+		n.Content, n.SenderName = localize(n, lang)
+	}
 	
 	logger.Debug("获取用户通知成功",
 		slog.Int64("user_id", userID),
@@ -209,6 +241,7 @@ func (s *notificationService) handleNotificationTriggered(ctx context.Context, e
 		TargetURL:   event.Payload.TargetURL,
 		IsRead:      false,
 		CreatedAt:   time.Now(),
+		Params:      event.Payload.Params,
 	}
 
 	// 2. 将通知存入数据库
@@ -229,8 +262,7 @@ func (s *notificationService) handleNotificationTriggered(ctx context.Context, e
 
 	// 3. 通过 gRPC StreamHub 推送给在线用户
 	if s.streamHub != nil {
-		pbNotification := convertModelToProto(notification)
-		s.streamHub.SendToUser(notification.RecipientID, pbNotification)
+		s.streamHub.SendToUser(notification.RecipientID, notification)
 		
 		logger.Debug("通知已通过gRPC流推送给用户",
 			slog.Int64("recipient_id", notification.RecipientID),
@@ -240,15 +272,16 @@ func (s *notificationService) handleNotificationTriggered(ctx context.Context, e
 	return nil
 }
 
-// convertModelToProto 将 model.Notification 转换为 pb.Notification
-func convertModelToProto(n *model.Notification) *pb.Notification {
+// convertModelToProto 将 model.Notification 按指定语言渲染并转换为 pb.Notification
+func convertModelToProto(n *model.Notification, lang string) *pb.Notification {
+	content, senderName := localize(n, lang)
 	return &pb.Notification{
 		Id:          n.ID.Hex(),
 		RecipientId: n.RecipientID,
 		SenderId:    n.SenderID,
-		SenderName:  n.SenderName,
+		SenderName:  senderName,
 		Type:        n.Type,
-		Content:     n.Content,
+		Content:     content,
 		TargetUrl:   n.TargetURL,
 		IsRead:      n.IsRead,
 		CreatedAt:   timestamppb.New(n.CreatedAt),
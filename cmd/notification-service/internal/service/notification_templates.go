@@ -0,0 +1,103 @@
+package service
+
+import (
+	"bytes"
+	"maps"
+	"strconv"
+	"text/template"
+
+	"qahub/notification-service/internal/model"
+	"qahub/pkg/i18n"
+	"qahub/pkg/messaging"
+)
+
+// localeCatalog 是一种语言下的通知模板
+type localeCatalog struct {
+	anonymous string            // 匿名作者的展示名称
+	targets   map[string]string // 修改建议目标类型的展示名称
+	templates map[string]string // key 为通知类型
+}
+
+// catalogs 按语言组织的通知模板，模板参数见 messaging.NotificationParam* 常量
+var catalogs = map[string]localeCatalog{
+	i18n.LanguageZH: {
+		anonymous: "匿名用户",
+		targets:   map[string]string{"question": "问题", "answer": "回答"},
+		templates: map[string]string{
+			messaging.NotificationTypeNewAnswer:     `'{{.sender}}' 回答了你的问题: '{{.question_title}}',内容是'{{.excerpt}}'`,
+			messaging.NotificationTypeNewComment:    `'{{.sender}}' 评论了你的答案: '{{.excerpt}}'`,
+			messaging.NotificationTypeUpvote:        `'{{.sender}}' {{if .count}}等 {{.count}} 人{{end}}赞了你的回答`,
+			messaging.NotificationTypeEditSuggested: `'{{.sender}}' 对你在 '{{.question_title}}' 下的{{target .target}}提出了修改建议`,
+			messaging.NotificationTypeEditApproved:  `{{if .proposer}}'{{.sender}}' 通过了 '{{.proposer}}' 对你在 '{{.question_title}}' 下的{{target .target}}的修改建议{{else}}'{{.sender}}' 通过了你对 '{{.question_title}}' 下{{target .target}}的修改建议{{end}}`,
+			messaging.NotificationTypeEditRejected:  `{{if .proposer}}'{{.sender}}' 拒绝了 '{{.proposer}}' 对你在 '{{.question_title}}' 下的{{target .target}}的修改建议{{else}}'{{.sender}}' 拒绝了你对 '{{.question_title}}' 下{{target .target}}的修改建议{{end}}`,
+		},
+	},
+	i18n.LanguageEN: {
+		anonymous: "Anonymous",
+		targets:   map[string]string{"question": "question", "answer": "answer"},
+		templates: map[string]string{
+			messaging.NotificationTypeNewAnswer:     `{{.sender}} answered your question "{{.question_title}}": "{{.excerpt}}"`,
+			messaging.NotificationTypeNewComment:    `{{.sender}} commented on your answer: "{{.excerpt}}"`,
+			messaging.NotificationTypeUpvote:        `{{.sender}}{{if .count}} and {{others .count}} others{{end}} upvoted your answer`,
+			messaging.NotificationTypeEditSuggested: `{{.sender}} suggested an edit to your {{target .target}} on "{{.question_title}}"`,
+			messaging.NotificationTypeEditApproved:  `{{if .proposer}}{{.sender}} approved {{.proposer}}'s suggested edit to your {{target .target}} on "{{.question_title}}"{{else}}{{.sender}} approved your suggested edit to the {{target .target}} on "{{.question_title}}"{{end}}`,
+			messaging.NotificationTypeEditRejected:  `{{if .proposer}}{{.sender}} rejected {{.proposer}}'s suggested edit to your {{target .target}} on "{{.question_title}}"{{else}}{{.sender}} rejected your suggested edit to the {{target .target}} on "{{.question_title}}"{{end}}`,
+		},
+	},
+}
+
+// compiledTemplates 在启动时解析好的模板，key 为 语言 -> 通知类型
+var compiledTemplates = compileCatalogs()
+
+func compileCatalogs() map[string]map[string]*template.Template {
+	compiled := make(map[string]map[string]*template.Template, len(catalogs))
+	for lang, catalog := range catalogs {
+		funcs := template.FuncMap{
+			"target": func(t string) string { return catalog.targets[t] },
+			// others 返回聚合通知中除了展示的发送者之外的人数
+			"others": func(count string) string {
+				n, _ := strconv.Atoi(count)
+				return strconv.Itoa(n - 1)
+			},
+		}
+		compiled[lang] = make(map[string]*template.Template, len(catalog.templates))
+		for notificationType, text := range catalog.templates {
+			compiled[lang][notificationType] = template.Must(
+				template.New(lang + "/" + notificationType).Funcs(funcs).Option("missingkey=zero").Parse(text),
+			)
+		}
+	}
+	return compiled
+}
+
+// localize 按语言渲染通知的内容和发送者名称。
+// 没有模板参数的旧通知直接使用保存时的内容；找不到模板时依次回退到默认语言和保存的内容。
+func localize(n *model.Notification, lang string) (content, senderName string) {
+	if len(n.Params) == 0 {
+		return n.Content, n.SenderName
+	}
+
+	lang = i18n.Normalize(lang)
+	params := n.Params
+	senderName = n.SenderName
+	if params[messaging.NotificationParamSenderAnonymous] == "true" {
+		senderName = catalogs[lang].anonymous
+		// 复制一份参数，避免修改通知本身
+		params = maps.Clone(n.Params)
+		params[messaging.NotificationParamSender] = senderName
+	}
+
+	tmpl, ok := compiledTemplates[lang][n.Type]
+	if !ok {
+		tmpl, ok = compiledTemplates[i18n.DefaultLanguage][n.Type]
+	}
+	if !ok {
+		return n.Content, senderName
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, params); err != nil {
+		return n.Content, senderName
+	}
+	return buf.String(), senderName
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"testing"
+
+	"qahub/notification-service/internal/model"
+	"qahub/pkg/messaging"
+)
+
+func TestLocalize(t *testing.T) {
+	t.Run("按接收者语言渲染模板", func(t *testing.T) {
+		n := &model.Notification{
+			Type:       messaging.NotificationTypeNewComment,
+			SenderName: "alice",
+			Params: map[string]string{
+				messaging.NotificationParamSender:  "alice",
+				messaging.NotificationParamExcerpt: "好问题",
+			},
+		}
+
+		zh, _ := localize(n, "zh")
+		en, _ := localize(n, "en-US")
+
+		if zh != "'alice' 评论了你的答案: '好问题'" {
+			t.Errorf("中文渲染结果不符: %q", zh)
+		}
+		if en != `alice commented on your answer: "好问题"` {
+			t.Errorf("英文渲染结果不符: %q", en)
+		}
+	})
+
+	t.Run("没有参数的旧通知直接使用保存的内容", func(t *testing.T) {
+		n := &model.Notification{
+			Type:       messaging.NotificationTypeNewAnswer,
+			SenderName: "bob",
+			Content:    "'bob' 回答了你的问题",
+		}
+
+		content, sender := localize(n, "en")
+
+		if content != n.Content || sender != "bob" {
+			t.Errorf("旧通知不应被重新渲染: %q %q", content, sender)
+		}
+	})
+
+	t.Run("未知的通知类型回退到保存的内容", func(t *testing.T) {
+		n := &model.Notification{
+			Type:    "unknown",
+			Content: "原始内容",
+			Params:  map[string]string{messaging.NotificationParamSender: "alice"},
+		}
+
+		if content, _ := localize(n, "en"); content != "原始内容" {
+			t.Errorf("未知类型应回退到保存的内容: %q", content)
+		}
+	})
+
+	t.Run("匿名发送者使用本地化名称", func(t *testing.T) {
+		n := &model.Notification{
+			Type: messaging.NotificationTypeNewAnswer,
+			Params: map[string]string{
+				messaging.NotificationParamSenderAnonymous: "true",
+				messaging.NotificationParamQuestionTitle:   "Go 泛型",
+				messaging.NotificationParamExcerpt:         "看文档",
+			},
+		}
+
+		content, sender := localize(n, "en")
+
+		if sender != "Anonymous" {
+			t.Errorf("匿名发送者名称不符: %q", sender)
+		}
+		if content != `Anonymous answered your question "Go 泛型": "看文档"` {
+			t.Errorf("英文渲染结果不符: %q", content)
+		}
+		if _, ok := n.Params[messaging.NotificationParamSender]; ok {
+			t.Error("渲染不应修改通知本身的参数")
+		}
+	})
+
+	t.Run("聚合点赞和修改建议的变体", func(t *testing.T) {
+		upvote := &model.Notification{
+			Type: messaging.NotificationTypeUpvote,
+			Params: map[string]string{
+				messaging.NotificationParamSender: "carol",
+				messaging.NotificationParamCount:  "3",
+			},
+		}
+		approved := &model.Notification{
+			Type: messaging.NotificationTypeEditApproved,
+			Params: map[string]string{
+				messaging.NotificationParamSender:        "mod",
+				messaging.NotificationParamProposer:      "dave",
+				messaging.NotificationParamQuestionTitle: "标题",
+				messaging.NotificationParamTarget:        "answer",
+			},
+		}
+
+		if content, _ := localize(upvote, "zh"); content != "'carol' 等 3 人赞了你的回答" {
+			t.Errorf("中文点赞渲染结果不符: %q", content)
+		}
+		if content, _ := localize(upvote, "en"); content != "carol and 2 others upvoted your answer" {
+			t.Errorf("英文点赞渲染结果不符: %q", content)
+		}
+		if content, _ := localize(approved, "zh"); content != "'mod' 通过了 'dave' 对你在 '标题' 下的回答的修改建议" {
+			t.Errorf("中文修改建议渲染结果不符: %q", content)
+		}
+	})
+}
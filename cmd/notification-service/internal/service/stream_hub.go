@@ -5,13 +5,15 @@ import (
 	"sync"
 
 	pb "qahub/api/proto/notification"
+	"qahub/notification-service/internal/model"
 )
 
 // StreamClient 表示一个通过 gRPC 流连接的客户端
 type StreamClient struct {
-	UserID int64
-	Stream pb.NotificationService_SubscribeNotificationsServer
-	Done   chan struct{}
+	UserID   int64
+	Language string // 订阅时查询的语言偏好，推送的通知按该语言渲染
+	Stream   pb.NotificationService_SubscribeNotificationsServer
+	Done     chan struct{}
 }
 
 // StreamHub 管理所有活跃的 gRPC 流式连接
@@ -31,7 +33,7 @@ type StreamHub struct {
 // NotificationMessage 包含要发送的通知和目标用户ID
 type NotificationMessage struct {
 	UserID       int64
-	Notification *pb.Notification
+	Notification *model.Notification
 }
 
 // NewStreamHub 创建一个新的流式通知中心
@@ -73,7 +75,7 @@ func (h *StreamHub) Run() {
 			h.mu.RLock()
 			if client, ok := h.clients[message.UserID]; ok {
 				// 尝试发送通知到客户端流
-				if err := client.Stream.Send(message.Notification); err != nil {
+				if err := client.Stream.Send(convertModelToProto(message.Notification, client.Language)); err != nil {
 					log.Printf("Failed to send notification to user %d: %v", message.UserID, err)
 					// 发送失败，注销该客户端
 					go func() {
@@ -99,7 +101,7 @@ func (h *StreamHub) Unregister(client *StreamClient) {
 }
 
 // SendToUser 向指定用户发送通知（如果用户已连接）
-func (h *StreamHub) SendToUser(userID int64, notification *pb.Notification) {
+func (h *StreamHub) SendToUser(userID int64, notification *model.Notification) {
 	h.broadcast <- &NotificationMessage{
 		UserID:       userID,
 		Notification: notification,
@@ -7,6 +7,7 @@ import (
 	"qahub/notification-service/internal/handler"
 	"qahub/notification-service/internal/service"
 	"qahub/notification-service/internal/store"
+	"qahub/pkg/auth"
 	"qahub/pkg/clients"
 	"qahub/pkg/config"
 	"qahub/pkg/database"
@@ -14,6 +15,7 @@ import (
 	"qahub/pkg/interceptor"
 	logpkg "qahub/pkg/log"
 	"qahub/pkg/messaging"
+	"qahub/pkg/redis"
 	"qahub/pkg/server"
 	"qahub/pkg/util"
 
@@ -57,14 +59,8 @@ func main() {
 
 	logger.Info("初始化 Kafka 消费者...")
 	consumer := messaging.NewKafkaConsumer(config.Conf.Kafka, service.TopicNotifications, service.GroupID, nil)
-	ntService := service.NewNotificationService(ntStore, streamHub)
-	ntHandler := handler.NewNotificationGrpcServer(ntService)
 
-	// 注册事件处理器
-	consumer.SetHandlers(ntService.RegisterHandlers())
-	logger.Info("Kafka 消费者初始化成功")
-
-	// 初始化 user-service 的客户端连接
+	// 初始化 user-service 的客户端连接，用于认证和查询接收者的语言偏好
 	logger.Info("连接到 user-service...",
 		slog.String("endpoint", config.Conf.Services.Gateway.UserServiceEndpoint),
 	)
@@ -77,6 +73,28 @@ func main() {
 	}
 	logger.Info("user-service 连接成功")
 
+	// 在本地校验访问令牌，开启撤销检查时需要连接 user-service 使用的 Redis
+	var revocations auth.RevocationList
+	if config.Conf.Auth.CheckRevocation {
+		redisClient, err := redis.NewClient(config.Conf.Redis)
+		if err != nil {
+			logger.Error("Redis 连接失败",
+				slog.String("error", err.Error()),
+			)
+			log.Fatalf("Redis connection failed: %v", err)
+		}
+		defer util.Cleanup("Redis client", redisClient.Close)
+		revocations = auth.NewRedisRevocationList(redisClient)
+	}
+	verifier := auth.NewVerifier(userClient.GetJWKS, config.Conf.Auth.JWKSCacheTTL, revocations)
+
+	ntService := service.NewNotificationService(ntStore, streamHub, userClient)
+	ntHandler := handler.NewNotificationGrpcServer(ntService)
+
+	// 注册事件处理器
+	consumer.SetHandlers(ntService.RegisterHandlers())
+	logger.Info("Kafka 消费者初始化成功")
+
 	// 启动 gRPC 服务器
 	logger.Info("初始化 gRPC 服务器...",
 		slog.String("service_name", serviceName),
@@ -84,11 +102,13 @@ func main() {
 	serverOpts := []grpc.ServerOption{
 		grpc.ChainUnaryInterceptor(
 			interceptor.LogUnaryServerInterceptor(),
-			interceptor.AuthUnaryServerInterceptor(userClient, config.Conf.Services.NotificationService.PublicMethods...),
+			interceptor.AuthUnaryServerInterceptor(userClient, verifier, config.Conf.Services.NotificationService.PublicMethods...),
+			interceptor.AccessTokenScopeUnaryServerInterceptor(config.Conf.Services.NotificationService.AccessTokenScopes),
 		),
 		grpc.ChainStreamInterceptor(
 			interceptor.LogStreamServerInterceptor(),
-			interceptor.AuthStreamServerInterceptor(userClient, config.Conf.Services.NotificationService.PublicMethods...),
+			interceptor.AuthStreamServerInterceptor(userClient, verifier, config.Conf.Services.NotificationService.PublicMethods...),
+			interceptor.AccessTokenScopeStreamServerInterceptor(config.Conf.Services.NotificationService.AccessTokenScopes),
 		),
 	}
 	grpcSrv := server.NewGrpcServer(serviceName, config.Conf.Services.NotificationService.GrpcPort, serverOpts...)
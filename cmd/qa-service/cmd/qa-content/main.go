@@ -0,0 +1,174 @@
+// qa-content 通过 qa-service 的 ExportContent / ImportContent RPC 导出和导入全部问答内容。
+//
+// 导出文件为 JSON Lines 格式，每行是一条 qa.ContentRecord，第一行为带格式版本号的文件头：
+//
+//	go run ./cmd/qa-content export -token <管理员token> -o dump.jsonl
+//	go run ./cmd/qa-content import -token <管理员token> -i dump.jsonl
+//
+// 导入只能在没有任何问题的实例上进行，所有记录都会获得新的ID。
+// 默认不导出用户的密码哈希，导入后用户需要通过找回密码设置新密码；
+// 迁移时如需保留原密码，可以指定 -include-password-hashes，并妥善保管导出文件。
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	pb "qahub/api/proto/qa"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// maxLineSize 是导入文件中单行记录的最大长度
+const maxLineSize = 16 * 1024 * 1024
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "用法: qa-content <export|import> [参数]\n")
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	cmd := os.Args[1]
+
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	addr := fs.String("addr", "localhost:50052", "qa-service 的 gRPC 地址")
+	token := fs.String("token", os.Getenv("QAHUB_TOKEN"), "管理员的访问 token，默认读取环境变量 QAHUB_TOKEN")
+	output := fs.String("o", "-", "导出文件路径，- 表示标准输出")
+	input := fs.String("i", "-", "导入文件路径，- 表示标准输入")
+	includePasswords := fs.Bool("include-password-hashes", false, "导出用户的密码哈希，导出文件需要妥善保管")
+	_ = fs.Parse(os.Args[2:])
+
+	if *token == "" {
+		log.Fatal("缺少管理员 token，请使用 -token 或设置 QAHUB_TOKEN")
+	}
+
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("连接 qa-service 失败: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewQAServiceClient(conn)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+*token)
+
+	switch cmd {
+	case "export":
+		err = exportContent(ctx, client, *output, *includePasswords)
+	case "import":
+		err = importContent(ctx, client, *input)
+	default:
+		usage()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func exportContent(ctx context.Context, client pb.QAServiceClient, path string, includePasswords bool) error {
+	out := os.Stdout
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("创建导出文件失败: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	w := bufio.NewWriter(out)
+
+	stream, err := client.ExportContent(ctx, &pb.ExportContentRequest{IncludePasswordHashes: includePasswords})
+	if err != nil {
+		return fmt.Errorf("导出失败: %w", err)
+	}
+
+	marshaler := protojson.MarshalOptions{UseProtoNames: true}
+	var count int
+	for {
+		record, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("导出失败: %w", err)
+		}
+		line, err := marshaler.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("序列化记录失败: %w", err)
+		}
+		w.Write(line)
+		w.WriteByte('\n')
+		count++
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("写入导出文件失败: %w", err)
+	}
+	log.Printf("导出完成，共 %d 条记录", count)
+	return nil
+}
+
+func importContent(ctx context.Context, client pb.QAServiceClient, path string) error {
+	in := os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("打开导入文件失败: %w", err)
+		}
+		defer f.Close()
+		in = f
+	}
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	stream, err := client.ImportContent(ctx)
+	if err != nil {
+		return fmt.Errorf("导入失败: %w", err)
+	}
+
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := &pb.ContentRecord{}
+		if err := protojson.Unmarshal(scanner.Bytes(), record); err != nil {
+			return fmt.Errorf("第 %d 行格式错误: %w", lineNo, err)
+		}
+		if err := stream.Send(record); err != nil {
+			// 服务端提前结束时，真正的错误要通过 CloseAndRecv 获取
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("发送第 %d 行失败: %w", lineNo, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("读取导入文件失败: %w", err)
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("导入失败: %w", err)
+	}
+	log.Printf("导入完成: 新建用户 %d, 复用用户 %d, 问题 %d, 回答 %d, 评论 %d, 投票 %d",
+		resp.Users, resp.ReusedUsers, resp.Questions, resp.Answers, resp.Comments, resp.Votes)
+	for _, u := range resp.RenamedUsers {
+		log.Printf("用户名冲突，用户 %d 由 %s 改名为 %s (新ID: %d)", u.OriginalId, u.OriginalUsername, u.Username, u.UserId)
+	}
+	if resp.UsersWithoutPassword > 0 {
+		log.Printf("%d 个新用户没有密码哈希，需要通过找回密码设置新密码后才能登录", resp.UsersWithoutPassword)
+	}
+	return nil
+}
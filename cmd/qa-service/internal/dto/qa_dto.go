@@ -4,17 +4,64 @@ import "qahub/qa-service/internal/model"
 
 type QuestionResponse struct {
 	model.Question
-	AuthorName  string `json:"author_name"`  // 提问者的用户名
-	AnswerCount int64  `json:"answer_count"` // 回答数量
+	AuthorName      string `json:"author_name"`       // 提问者的用户名
+	AuthorIsBot     bool   `json:"author_is_bot"`     // 提问者是否为机器人账户
+	AuthorAvatarURL string `json:"author_avatar_url"` // 提问者头像的缩略图地址，没有头像时为空
 }
 
 type AnswerResponse struct {
 	model.Answer
 	Username        string `json:"username"`           // 回答者的用户名
 	IsUpvotedByUser bool   `json:"is_upvoted_by_user"` // 当前用户是否点赞了该答案
+	AuthorIsBot     bool   `json:"author_is_bot"`      // 回答者是否为机器人账户
+	AuthorAvatarURL string `json:"author_avatar_url"`  // 回答者头像的缩略图地址，没有头像时为空
 }
 
 type CommentResponse struct {
 	model.Comment
+	Username        string `json:"username"`          // 评论者的用户名
+	AuthorIsBot     bool   `json:"author_is_bot"`     // 评论者是否为机器人账户
+	AuthorAvatarURL string `json:"author_avatar_url"` // 评论者头像的缩略图地址，没有头像时为空
+}
+
+type UserAnswerResponse struct {
+	model.AnswerWithQuestion
+	Username string `json:"username"` // 回答者的用户名
+}
+
+// ReconcileReport 是一次计数校对的结果
+type ReconcileReport struct {
+	Drifts []*model.CounterDrift `json:"drifts"`      // 发现的偏差
+	Fixed  int                   `json:"fixed_count"` // 已修正的偏差数量，只报告不修正时为 0
+}
+
+type UserCommentResponse struct {
+	model.CommentWithQuestion
 	Username string `json:"username"` // 评论者的用户名
 }
+
+type SuggestedEditResponse struct {
+	model.SuggestedEdit
+	ProposerName string `json:"proposer_name"` // 提出建议的用户名
+}
+
+// ImportReport 是一次内容导入的结果
+type ImportReport struct {
+	Users       int64 `json:"users"`        // 新建的用户数
+	ReusedUsers int64 `json:"reused_users"` // 邮箱已存在、直接复用的用户数
+	Questions   int64 `json:"questions"`
+	Answers     int64 `json:"answers"`
+	Comments    int64 `json:"comments"`
+	Votes       int64 `json:"votes"`
+
+	RenamedUsers         []RenamedUser `json:"renamed_users"`          // 用户名与已有用户冲突而被改名的用户
+	UsersWithoutPassword int64         `json:"users_without_password"` // 没有密码哈希、需要重设密码的新用户数
+}
+
+// RenamedUser 是导入时因用户名冲突（不区分大小写）而被改名的用户
+type RenamedUser struct {
+	OriginalID       int64  `json:"original_id"` // 导入文件中的用户ID
+	OriginalUsername string `json:"original_username"`
+	Username         string `json:"username"` // 实际使用的用户名
+	UserID           int64  `json:"user_id"`  // 新实例中的用户ID
+}
@@ -2,11 +2,14 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	pb "qahub/api/proto/qa"
 	"qahub/pkg/auth"
 	pkglog "qahub/pkg/log"
 	"qahub/pkg/pagination"
+	"qahub/qa-service/internal/dto"
+	"qahub/qa-service/internal/model"
 	"qahub/qa-service/internal/service"
 
 	"google.golang.org/grpc"
@@ -19,12 +22,16 @@ import (
 // QAGrpcServer 实现了 pb.QAServiceServer 接口，处理 gRPC 请求
 type QAGrpcServer struct {
 	pb.UnimplementedQAServiceServer
-	qaService service.QAService
+	qaService        service.QAService
+	trendingService  service.TrendingService
+	reconcileService service.ReconcileService
 }
 
-func NewQAGrpcServer(svc service.QAService) *QAGrpcServer {
+func NewQAGrpcServer(svc service.QAService, trendingSvc service.TrendingService, reconcileSvc service.ReconcileService) *QAGrpcServer {
 	return &QAGrpcServer{
-		qaService: svc,
+		qaService:        svc,
+		trendingService:  trendingSvc,
+		reconcileService: reconcileSvc,
 	}
 }
 
@@ -42,7 +49,7 @@ func (s *QAGrpcServer) CreateQuestion(ctx context.Context, req *pb.CreateQuestio
 		return nil, status.Errorf(codes.Internal, "无法从context获取用户信息")
 	}
 
-	question, err := s.qaService.CreateQuestion(ctx, req.Title, req.Content, identity.UserID)
+	question, err := s.qaService.CreateQuestion(ctx, req.Title, req.Content, req.Anonymous, identity.UserID)
 	if err != nil {
 		logger.Error("创建问题失败",
 			slog.Int64("user_id", identity.UserID),
@@ -59,12 +66,13 @@ func (s *QAGrpcServer) CreateQuestion(ctx context.Context, req *pb.CreateQuestio
 	)
 
 	return &pb.QuestionResponse{
-		Id:        question.ID,
-		Title:     question.Title,
-		Content:   question.Content,
-		UserId:    question.UserID,
-		CreatedAt: timestamppb.New(question.CreatedAt),
-		UpdatedAt: timestamppb.New(question.UpdatedAt),
+		Id:          question.ID,
+		Title:       question.Title,
+		Content:     question.Content,
+		UserId:      question.UserID,
+		IsAnonymous: question.IsAnonymous,
+		CreatedAt:   timestamppb.New(question.CreatedAt),
+		UpdatedAt:   timestamppb.New(question.UpdatedAt),
 	}, nil
 }
 
@@ -90,14 +98,20 @@ func (s *QAGrpcServer) GetQuestion(ctx context.Context, req *pb.GetQuestionReque
 	)
 
 	return &pb.QuestionResponse{
-		Id:          question.ID,
-		Title:       question.Title,
-		Content:     question.Content,
-		UserId:      question.UserID,
-		CreatedAt:   timestamppb.New(question.CreatedAt),
-		UpdatedAt:   timestamppb.New(question.UpdatedAt),
-		AuthorName:  question.AuthorName,
-		AnswerCount: question.AnswerCount,
+		Id:               question.ID,
+		Title:            question.Title,
+		Content:          question.Content,
+		UserId:           question.UserID,
+		IsAnonymous:      question.IsAnonymous,
+		CreatedAt:        timestamppb.New(question.CreatedAt),
+		UpdatedAt:        timestamppb.New(question.UpdatedAt),
+		AuthorName:       question.AuthorName,
+		AuthorIsBot:      question.AuthorIsBot,
+		AuthorAvatarUrl:  question.AuthorAvatarURL,
+		AnswerCount:      question.AnswerCount,
+		AcceptedAnswerId: question.AcceptedAnswerID,
+		CommentCount:     question.CommentCount,
+		LastActivityAt:   timestamppb.New(question.LastActivityAt),
 	}, nil
 }
 
@@ -127,14 +141,67 @@ func (s *QAGrpcServer) ListQuestions(ctx context.Context, req *pb.ListQuestionsR
 	var pbQuestions []*pb.QuestionResponse
 	for _, q := range questions {
 		pbQuestions = append(pbQuestions, &pb.QuestionResponse{
-			Id:          q.ID,
-			Title:       q.Title,
-			Content:     q.Content,
-			UserId:      q.UserID,
-			CreatedAt:   timestamppb.New(q.CreatedAt),
-			UpdatedAt:   timestamppb.New(q.UpdatedAt),
-			AuthorName:  q.AuthorName,
-			AnswerCount: q.AnswerCount,
+			Id:               q.ID,
+			Title:            q.Title,
+			Content:          q.Content,
+			UserId:           q.UserID,
+			IsAnonymous:      q.IsAnonymous,
+			CreatedAt:        timestamppb.New(q.CreatedAt),
+			UpdatedAt:        timestamppb.New(q.UpdatedAt),
+			AuthorName:       q.AuthorName,
+			AuthorIsBot:      q.AuthorIsBot,
+			AuthorAvatarUrl:  q.AuthorAvatarURL,
+			AnswerCount:      q.AnswerCount,
+			AcceptedAnswerId: q.AcceptedAnswerID,
+			CommentCount:     q.CommentCount,
+			LastActivityAt:   timestamppb.New(q.LastActivityAt),
+		})
+	}
+	return &pb.ListQuestionsResponse{
+		Questions:  pbQuestions,
+		TotalCount: count,
+	}, nil
+}
+
+func (s *QAGrpcServer) ListTrendingQuestions(ctx context.Context, req *pb.ListTrendingQuestionsRequest) (*pb.ListQuestionsResponse, error) {
+	logger := pkglog.FromContext(ctx)
+
+	page, pageSize := pagination.NormalizePageAndSize(req)
+	logger.Info("列出热门问题请求",
+		slog.String("window", req.Window),
+		slog.Int64("page", page),
+		slog.Any("page_size", pageSize),
+	)
+
+	questions, count, err := s.trendingService.ListTrendingQuestions(ctx, req.Window, page, pageSize)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTrendingWindow) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		logger.Error("列出热门问题失败",
+			slog.String("window", req.Window),
+			slog.String("error", err.Error()),
+		)
+		return nil, status.Error(codes.Internal, "获取热门问题失败")
+	}
+
+	pbQuestions := make([]*pb.QuestionResponse, 0, len(questions))
+	for _, q := range questions {
+		pbQuestions = append(pbQuestions, &pb.QuestionResponse{
+			Id:               q.ID,
+			Title:            q.Title,
+			Content:          q.Content,
+			UserId:           q.UserID,
+			IsAnonymous:      q.IsAnonymous,
+			CreatedAt:        timestamppb.New(q.CreatedAt),
+			UpdatedAt:        timestamppb.New(q.UpdatedAt),
+			AuthorName:       q.AuthorName,
+			AuthorIsBot:      q.AuthorIsBot,
+			AuthorAvatarUrl:  q.AuthorAvatarURL,
+			AnswerCount:      q.AnswerCount,
+			AcceptedAnswerId: q.AcceptedAnswerID,
+			CommentCount:     q.CommentCount,
+			LastActivityAt:   timestamppb.New(q.LastActivityAt),
 		})
 	}
 	return &pb.ListQuestionsResponse{
@@ -143,6 +210,88 @@ func (s *QAGrpcServer) ListQuestions(ctx context.Context, req *pb.ListQuestionsR
 	}, nil
 }
 
+func (s *QAGrpcServer) ReconcileCounters(ctx context.Context, req *pb.ReconcileCountersRequest) (*pb.ReconcileCountersResponse, error) {
+	logger := pkglog.FromContext(ctx)
+
+	logger.Info("校对计数请求",
+		slog.Bool("dry_run", req.DryRun),
+	)
+
+	report, err := s.reconcileService.ReconcileCounters(ctx, req.DryRun)
+	if err != nil {
+		if errors.Is(err, service.ErrAdminRequired) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		logger.Error("校对计数失败",
+			slog.String("error", err.Error()),
+		)
+		return nil, status.Error(codes.Internal, "校对计数失败")
+	}
+
+	pbDrifts := make([]*pb.CounterDrift, 0, len(report.Drifts))
+	for _, d := range report.Drifts {
+		pbDrifts = append(pbDrifts, &pb.CounterDrift{
+			Counter:  d.Counter,
+			Id:       d.ID,
+			Stored:   d.Stored,
+			Expected: d.Expected,
+		})
+	}
+	return &pb.ReconcileCountersResponse{
+		Drifts:     pbDrifts,
+		FixedCount: int32(report.Fixed),
+	}, nil
+}
+
+func (s *QAGrpcServer) BatchGetQuestions(ctx context.Context, req *pb.BatchGetQuestionsRequest) (*pb.BatchGetQuestionsResponse, error) {
+	logger := pkglog.FromContext(ctx)
+
+	logger.Info("批量获取问题请求",
+		slog.Int("count", len(req.Ids)),
+	)
+
+	questions, notFound, err := s.qaService.BatchGetQuestions(ctx, req.Ids)
+	if err != nil {
+		logger.Error("批量获取问题失败",
+			slog.Int("count", len(req.Ids)),
+			slog.String("error", err.Error()),
+		)
+		if errors.Is(err, service.ErrEmptyBatch) || errors.Is(err, service.ErrBatchTooLarge) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+
+	logger.Info("批量获取问题成功",
+		slog.Int("returned_count", len(questions)),
+		slog.Int("not_found_count", len(notFound)),
+	)
+
+	pbQuestions := make([]*pb.QuestionResponse, 0, len(questions))
+	for _, q := range questions {
+		pbQuestions = append(pbQuestions, &pb.QuestionResponse{
+			Id:               q.ID,
+			Title:            q.Title,
+			Content:          q.Content,
+			UserId:           q.UserID,
+			IsAnonymous:      q.IsAnonymous,
+			CreatedAt:        timestamppb.New(q.CreatedAt),
+			UpdatedAt:        timestamppb.New(q.UpdatedAt),
+			AuthorName:       q.AuthorName,
+			AuthorIsBot:      q.AuthorIsBot,
+			AuthorAvatarUrl:  q.AuthorAvatarURL,
+			AnswerCount:      q.AnswerCount,
+			AcceptedAnswerId: q.AcceptedAnswerID,
+			CommentCount:     q.CommentCount,
+			LastActivityAt:   timestamppb.New(q.LastActivityAt),
+		})
+	}
+	return &pb.BatchGetQuestionsResponse{
+		Questions:   pbQuestions,
+		NotFoundIds: notFound,
+	}, nil
+}
+
 func (s *QAGrpcServer) UpdateQuestion(ctx context.Context, req *pb.UpdateQuestionRequest) (*pb.QuestionResponse, error) {
 	logger := pkglog.FromContext(ctx)
 
@@ -177,12 +326,13 @@ func (s *QAGrpcServer) UpdateQuestion(ctx context.Context, req *pb.UpdateQuestio
 	)
 
 	return &pb.QuestionResponse{
-		Id:        question.ID,
-		Title:     question.Title,
-		Content:   question.Content,
-		UserId:    question.UserID,
-		CreatedAt: timestamppb.New(question.CreatedAt),
-		UpdatedAt: timestamppb.New(question.UpdatedAt),
+		Id:          question.ID,
+		Title:       question.Title,
+		Content:     question.Content,
+		UserId:      question.UserID,
+		IsAnonymous: question.IsAnonymous,
+		CreatedAt:   timestamppb.New(question.CreatedAt),
+		UpdatedAt:   timestamppb.New(question.UpdatedAt),
 	}, nil
 }
 
@@ -237,7 +387,7 @@ func (s *QAGrpcServer) CreateAnswer(ctx context.Context, req *pb.CreateAnswerReq
 		slog.Int64("user_id", identity.UserID),
 	)
 
-	answer, err := s.qaService.CreateAnswer(ctx, req.QuestionId, req.Content, identity.UserID)
+	answer, err := s.qaService.CreateAnswer(ctx, req.QuestionId, req.Content, req.Anonymous, identity.UserID)
 	if err != nil {
 		logger.Error("创建回答失败",
 			slog.Int64("question_id", req.QuestionId),
@@ -257,6 +407,7 @@ func (s *QAGrpcServer) CreateAnswer(ctx context.Context, req *pb.CreateAnswerReq
 		QuestionId:  answer.QuestionID,
 		Content:     answer.Content,
 		UserId:      answer.UserID,
+		IsAnonymous: answer.IsAnonymous,
 		UpvoteCount: int32(answer.UpvoteCount),
 		CreatedAt:   timestamppb.New(answer.CreatedAt),
 		UpdatedAt:   timestamppb.New(answer.UpdatedAt),
@@ -302,11 +453,14 @@ func (s *QAGrpcServer) ListAnswers(ctx context.Context, req *pb.ListAnswersReque
 			QuestionId:      a.QuestionID,
 			Content:         a.Content,
 			UserId:          a.UserID,
+			IsAnonymous:     a.IsAnonymous,
 			UpvoteCount:     int32(a.UpvoteCount),
 			CreatedAt:       timestamppb.New(a.CreatedAt),
 			UpdatedAt:       timestamppb.New(a.UpdatedAt),
 			Username:        a.Username,
 			IsUpvotedByUser: a.IsUpvotedByUser,
+			AuthorIsBot:     a.AuthorIsBot,
+			AuthorAvatarUrl: a.AuthorAvatarURL,
 		})
 	}
 	return &pb.ListAnswersResponse{
@@ -315,6 +469,57 @@ func (s *QAGrpcServer) ListAnswers(ctx context.Context, req *pb.ListAnswersReque
 	}, nil
 }
 
+func (s *QAGrpcServer) BatchGetAnswers(ctx context.Context, req *pb.BatchGetAnswersRequest) (*pb.BatchGetAnswersResponse, error) {
+	logger := pkglog.FromContext(ctx)
+
+	// 该接口对匿名调用开放，携带令牌时认证拦截器会注入身份，未登录时不返回点赞状态
+	identity, _ := auth.FromContext(ctx)
+
+	logger.Info("批量获取回答请求",
+		slog.Int("count", len(req.Ids)),
+		slog.Int64("user_id", identity.UserID),
+	)
+
+	answers, notFound, err := s.qaService.BatchGetAnswers(ctx, req.Ids, identity.UserID)
+	if err != nil {
+		logger.Error("批量获取回答失败",
+			slog.Int("count", len(req.Ids)),
+			slog.String("error", err.Error()),
+		)
+		if errors.Is(err, service.ErrEmptyBatch) || errors.Is(err, service.ErrBatchTooLarge) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+
+	logger.Info("批量获取回答成功",
+		slog.Int("returned_count", len(answers)),
+		slog.Int("not_found_count", len(notFound)),
+	)
+
+	pbAnswers := make([]*pb.AnswerResponse, 0, len(answers))
+	for _, a := range answers {
+		pbAnswers = append(pbAnswers, &pb.AnswerResponse{
+			Id:              a.ID,
+			QuestionId:      a.QuestionID,
+			Content:         a.Content,
+			UserId:          a.UserID,
+			IsAnonymous:     a.IsAnonymous,
+			UpvoteCount:     int32(a.UpvoteCount),
+			CreatedAt:       timestamppb.New(a.CreatedAt),
+			UpdatedAt:       timestamppb.New(a.UpdatedAt),
+			Username:        a.Username,
+			IsUpvotedByUser: a.IsUpvotedByUser,
+			AuthorIsBot:     a.AuthorIsBot,
+			AuthorAvatarUrl: a.AuthorAvatarURL,
+		})
+	}
+	return &pb.BatchGetAnswersResponse{
+		Answers:     pbAnswers,
+		NotFoundIds: notFound,
+	}, nil
+}
+
 func (s *QAGrpcServer) UpdateAnswer(ctx context.Context, req *pb.UpdateAnswerRequest) (*pb.AnswerResponse, error) {
 	logger := pkglog.FromContext(ctx)
 
@@ -350,6 +555,7 @@ func (s *QAGrpcServer) UpdateAnswer(ctx context.Context, req *pb.UpdateAnswerReq
 		QuestionId:  answer.QuestionID,
 		Content:     answer.Content,
 		UserId:      answer.UserID,
+		IsAnonymous: answer.IsAnonymous,
 		UpvoteCount: int32(answer.UpvoteCount),
 		CreatedAt:   timestamppb.New(answer.CreatedAt),
 		UpdatedAt:   timestamppb.New(answer.UpdatedAt),
@@ -428,27 +634,102 @@ func (s *QAGrpcServer) DownvoteAnswer(ctx context.Context, req *pb.DownvoteAnswe
 	//从context中获取用户信息
 	identity, ok := auth.FromContext(ctx)
 	if !ok {
-		logger.Error("取消点赞回答失败：无法从context获取用户信息",
+		logger.Error("点踩回答失败：无法从context获取用户信息",
 			slog.Int64("answer_id", req.AnswerId),
 		)
 		return nil, status.Errorf(codes.Internal, "无法从context获取用户信息")
 	}
 
-	logger.Info("取消点赞回答请求",
+	logger.Info("点踩回答请求",
 		slog.Int64("answer_id", req.AnswerId),
 		slog.Int64("user_id", identity.UserID),
 	)
 
 	err := s.qaService.DownvoteAnswer(ctx, req.AnswerId, identity.UserID)
 	if err != nil {
-		logger.Error("取消点赞回答失败",
+		logger.Error("点踩回答失败",
+			slog.Int64("answer_id", req.AnswerId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	logger.Info("点踩回答成功",
+		slog.Int64("answer_id", req.AnswerId),
+	)
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *QAGrpcServer) RetractAnswerVote(ctx context.Context, req *pb.RetractAnswerVoteRequest) (*emptypb.Empty, error) {
+	logger := pkglog.FromContext(ctx)
+
+	//从context中获取用户信息
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		logger.Error("撤销投票失败：无法从context获取用户信息",
+			slog.Int64("answer_id", req.AnswerId),
+		)
+		return nil, status.Errorf(codes.Internal, "无法从context获取用户信息")
+	}
+
+	logger.Info("撤销投票请求",
+		slog.Int64("answer_id", req.AnswerId),
+		slog.Int64("user_id", identity.UserID),
+	)
+
+	err := s.qaService.RetractAnswerVote(ctx, req.AnswerId, identity.UserID)
+	if err != nil {
+		logger.Error("撤销投票失败",
+			slog.Int64("answer_id", req.AnswerId),
+			slog.String("error", err.Error()),
+		)
+		if errors.Is(err, service.ErrVoteNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, err
+	}
+
+	logger.Info("撤销投票成功",
+		slog.Int64("answer_id", req.AnswerId),
+	)
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *QAGrpcServer) AcceptAnswer(ctx context.Context, req *pb.AcceptAnswerRequest) (*emptypb.Empty, error) {
+	logger := pkglog.FromContext(ctx)
+
+	//从context中获取用户信息
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		logger.Error("采纳回答失败：无法从context获取用户信息",
+			slog.Int64("answer_id", req.AnswerId),
+		)
+		return nil, status.Errorf(codes.Internal, "无法从context获取用户信息")
+	}
+
+	logger.Info("采纳回答请求",
+		slog.Int64("answer_id", req.AnswerId),
+		slog.Int64("user_id", identity.UserID),
+	)
+
+	err := s.qaService.AcceptAnswer(ctx, req.AnswerId, identity.UserID)
+	if err != nil {
+		logger.Error("采纳回答失败",
 			slog.Int64("answer_id", req.AnswerId),
 			slog.String("error", err.Error()),
 		)
+		switch {
+		case errors.Is(err, service.ErrNotQuestionOwner):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, service.ErrAnswerAlreadyAccepted):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
 		return nil, err
 	}
 
-	logger.Info("取消点赞回答成功",
+	logger.Info("采纳回答成功",
 		slog.Int64("answer_id", req.AnswerId),
 	)
 
@@ -538,6 +819,9 @@ func (s *QAGrpcServer) ListComments(ctx context.Context, req *pb.ListCommentsReq
 			CreatedAt: timestamppb.New(c.CreatedAt),
 			UpdatedAt: timestamppb.New(c.UpdatedAt),
 			Username:  c.Username,
+
+			AuthorIsBot:     c.AuthorIsBot,
+			AuthorAvatarUrl: c.AuthorAvatarURL,
 		})
 	}
 	return &pb.ListCommentsResponse{
@@ -619,6 +903,581 @@ func (s *QAGrpcServer) DeleteComment(ctx context.Context, req *pb.DeleteCommentR
 	return &emptypb.Empty{}, nil
 }
 
+// --- 用户动态 ---
+
+func (s *QAGrpcServer) ListUserQuestions(ctx context.Context, req *pb.ListUserQuestionsRequest) (*pb.ListQuestionsResponse, error) {
+	logger := pkglog.FromContext(ctx)
+
+	if req.UserId <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "用户ID无效")
+	}
+
+	page, pageSize := pagination.NormalizePageAndSize(req)
+	logger.Info("列出用户问题请求",
+		slog.Int64("user_id", req.UserId),
+		slog.Int64("page", page),
+	)
+
+	questions, count, err := s.qaService.ListQuestionsByUserID(ctx, req.UserId, page, pageSize)
+	if err != nil {
+		logger.Error("列出用户问题失败",
+			slog.Int64("user_id", req.UserId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	logger.Info("列出用户问题成功",
+		slog.Int64("user_id", req.UserId),
+		slog.Int64("total_count", count),
+		slog.Int("returned_count", len(questions)),
+	)
+
+	var pbQuestions []*pb.QuestionResponse
+	for _, q := range questions {
+		pbQuestions = append(pbQuestions, &pb.QuestionResponse{
+			Id:               q.ID,
+			Title:            q.Title,
+			Content:          q.Content,
+			UserId:           q.UserID,
+			IsAnonymous:      q.IsAnonymous,
+			CreatedAt:        timestamppb.New(q.CreatedAt),
+			UpdatedAt:        timestamppb.New(q.UpdatedAt),
+			AuthorName:       q.AuthorName,
+			AuthorIsBot:      q.AuthorIsBot,
+			AuthorAvatarUrl:  q.AuthorAvatarURL,
+			AnswerCount:      q.AnswerCount,
+			AcceptedAnswerId: q.AcceptedAnswerID,
+			CommentCount:     q.CommentCount,
+			LastActivityAt:   timestamppb.New(q.LastActivityAt),
+		})
+	}
+	return &pb.ListQuestionsResponse{
+		Questions:  pbQuestions,
+		TotalCount: count,
+	}, nil
+}
+
+func (s *QAGrpcServer) ListUserAnswers(ctx context.Context, req *pb.ListUserAnswersRequest) (*pb.ListUserAnswersResponse, error) {
+	logger := pkglog.FromContext(ctx)
+
+	if req.UserId <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "用户ID无效")
+	}
+
+	page, pageSize := pagination.NormalizePageAndSize(req)
+	logger.Info("列出用户回答请求",
+		slog.Int64("user_id", req.UserId),
+		slog.Int64("page", page),
+	)
+
+	answers, count, err := s.qaService.ListAnswersByUserID(ctx, req.UserId, page, pageSize)
+	if err != nil {
+		logger.Error("列出用户回答失败",
+			slog.Int64("user_id", req.UserId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	logger.Info("列出用户回答成功",
+		slog.Int64("user_id", req.UserId),
+		slog.Int64("total_count", count),
+		slog.Int("returned_count", len(answers)),
+	)
+
+	var pbAnswers []*pb.UserAnswerResponse
+	for _, a := range answers {
+		pbAnswers = append(pbAnswers, &pb.UserAnswerResponse{
+			Id:            a.ID,
+			QuestionId:    a.QuestionID,
+			Content:       a.Content,
+			UserId:        a.UserID,
+			IsAnonymous:   a.IsAnonymous,
+			UpvoteCount:   int32(a.UpvoteCount),
+			CreatedAt:     timestamppb.New(a.CreatedAt),
+			UpdatedAt:     timestamppb.New(a.UpdatedAt),
+			Username:      a.Username,
+			QuestionTitle: a.QuestionTitle,
+		})
+	}
+	return &pb.ListUserAnswersResponse{
+		Answers:    pbAnswers,
+		TotalCount: count,
+	}, nil
+}
+
+func (s *QAGrpcServer) ListUserComments(ctx context.Context, req *pb.ListUserCommentsRequest) (*pb.ListUserCommentsResponse, error) {
+	logger := pkglog.FromContext(ctx)
+
+	if req.UserId <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "用户ID无效")
+	}
+
+	page, pageSize := pagination.NormalizePageAndSize(req)
+	logger.Info("列出用户评论请求",
+		slog.Int64("user_id", req.UserId),
+		slog.Int64("page", page),
+	)
+
+	comments, count, err := s.qaService.ListCommentsByUserID(ctx, req.UserId, page, pageSize)
+	if err != nil {
+		logger.Error("列出用户评论失败",
+			slog.Int64("user_id", req.UserId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	logger.Info("列出用户评论成功",
+		slog.Int64("user_id", req.UserId),
+		slog.Int64("total_count", count),
+		slog.Int("returned_count", len(comments)),
+	)
+
+	var pbComments []*pb.UserCommentResponse
+	for _, c := range comments {
+		pbComments = append(pbComments, &pb.UserCommentResponse{
+			Id:            c.ID,
+			AnswerId:      c.AnswerID,
+			UserId:        c.UserID,
+			Content:       c.Content,
+			CreatedAt:     timestamppb.New(c.CreatedAt),
+			UpdatedAt:     timestamppb.New(c.UpdatedAt),
+			Username:      c.Username,
+			QuestionId:    c.QuestionID,
+			QuestionTitle: c.QuestionTitle,
+		})
+	}
+	return &pb.ListUserCommentsResponse{
+		Comments:   pbComments,
+		TotalCount: count,
+	}, nil
+}
+
+func (s *QAGrpcServer) GetUserActivity(ctx context.Context, req *pb.GetUserActivityRequest) (*pb.GetUserActivityResponse, error) {
+	logger := pkglog.FromContext(ctx)
+
+	if req.UserId <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "用户ID无效")
+	}
+
+	page, pageSize := pagination.NormalizePageAndSize(req)
+	logger.Info("获取用户动态请求",
+		slog.Int64("user_id", req.UserId),
+		slog.Int64("page", page),
+	)
+
+	activities, count, err := s.qaService.GetUserActivity(ctx, req.UserId, page, pageSize)
+	if err != nil {
+		logger.Error("获取用户动态失败",
+			slog.Int64("user_id", req.UserId),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	logger.Info("获取用户动态成功",
+		slog.Int64("user_id", req.UserId),
+		slog.Int64("total_count", count),
+		slog.Int("returned_count", len(activities)),
+	)
+
+	var pbActivities []*pb.ActivityItem
+	for _, a := range activities {
+		pbActivities = append(pbActivities, &pb.ActivityItem{
+			Type:          a.Type,
+			Id:            a.ID,
+			QuestionId:    a.QuestionID,
+			QuestionTitle: a.QuestionTitle,
+			Content:       a.Content,
+			CreatedAt:     timestamppb.New(a.CreatedAt),
+		})
+	}
+	return &pb.GetUserActivityResponse{
+		Activities: pbActivities,
+		TotalCount: count,
+	}, nil
+}
+
+func (s *QAGrpcServer) SuggestEdit(ctx context.Context, req *pb.SuggestEditRequest) (*pb.SuggestedEdit, error) {
+	logger := pkglog.FromContext(ctx)
+
+	//从context中获取用户信息
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		logger.Error("提出修改建议失败：无法从context获取用户信息",
+			slog.String("target_type", req.TargetType),
+			slog.Int64("target_id", req.TargetId),
+		)
+		return nil, status.Errorf(codes.Internal, "无法从context获取用户信息")
+	}
+
+	logger.Info("提出修改建议请求",
+		slog.String("target_type", req.TargetType),
+		slog.Int64("target_id", req.TargetId),
+		slog.Int64("user_id", identity.UserID),
+	)
+
+	edit, err := s.qaService.SuggestEdit(ctx, req.TargetType, req.TargetId, req.Title, req.Content, req.Reason, identity.UserID)
+	if err != nil {
+		return nil, suggestedEditError(err)
+	}
+	pbEdit := toPbSuggestedEdit(edit)
+	pbEdit.ProposerName = identity.Username
+	return pbEdit, nil
+}
+
+func (s *QAGrpcServer) ListSuggestedEdits(ctx context.Context, req *pb.ListSuggestedEditsRequest) (*pb.ListSuggestedEditsResponse, error) {
+	logger := pkglog.FromContext(ctx)
+
+	page, pageSize := pagination.NormalizePageAndSize(req)
+	logger.Info("列出修改建议请求",
+		slog.String("target_type", req.TargetType),
+		slog.Int64("target_id", req.TargetId),
+		slog.String("status", req.Status),
+		slog.Int64("page", page),
+	)
+
+	edits, count, err := s.qaService.ListSuggestedEdits(ctx, req.TargetType, req.TargetId, req.Status, page, pageSize)
+	if err != nil {
+		logger.Error("列出修改建议失败",
+			slog.String("target_type", req.TargetType),
+			slog.Int64("target_id", req.TargetId),
+			slog.String("error", err.Error()),
+		)
+		return nil, suggestedEditError(err)
+	}
+
+	pbEdits := make([]*pb.SuggestedEdit, 0, len(edits))
+	for _, e := range edits {
+		pbEdit := toPbSuggestedEdit(&e.SuggestedEdit)
+		pbEdit.ProposerName = e.ProposerName
+		pbEdits = append(pbEdits, pbEdit)
+	}
+	return &pb.ListSuggestedEditsResponse{
+		Edits:      pbEdits,
+		TotalCount: count,
+	}, nil
+}
+
+func (s *QAGrpcServer) ReviewSuggestedEdit(ctx context.Context, req *pb.ReviewSuggestedEditRequest) (*pb.SuggestedEdit, error) {
+	logger := pkglog.FromContext(ctx)
+
+	//从context中获取用户信息
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		logger.Error("审核修改建议失败：无法从context获取用户信息",
+			slog.Int64("edit_id", req.Id),
+		)
+		return nil, status.Errorf(codes.Internal, "无法从context获取用户信息")
+	}
+
+	logger.Info("审核修改建议请求",
+		slog.Int64("edit_id", req.Id),
+		slog.Bool("approve", req.Approve),
+		slog.Int64("user_id", identity.UserID),
+	)
+
+	edit, err := s.qaService.ReviewSuggestedEdit(ctx, req.Id, req.Approve, req.Comment, identity.UserID)
+	if err != nil {
+		return nil, suggestedEditError(err)
+	}
+	return toPbSuggestedEdit(edit), nil
+}
+
+// suggestedEditError 将修改建议相关的业务错误转换为 gRPC 状态码
+func suggestedEditError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidEditTarget),
+		errors.Is(err, service.ErrEmptySuggestedEdit),
+		errors.Is(err, service.ErrSuggestedEditUnchanged),
+		errors.Is(err, service.ErrSuggestOwnPost):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrEditTargetNotFound),
+		errors.Is(err, service.ErrSuggestedEditNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrNotEditReviewer):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrSuggestedEditReviewed),
+		errors.Is(err, service.ErrSuggestedEditStale):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+func toPbSuggestedEdit(e *model.SuggestedEdit) *pb.SuggestedEdit {
+	pbEdit := &pb.SuggestedEdit{
+		Id:            e.ID,
+		TargetType:    e.TargetType,
+		TargetId:      e.TargetID,
+		ProposerId:    e.ProposerID,
+		BaseTitle:     e.BaseTitle,
+		BaseContent:   e.BaseContent,
+		Title:         e.Title,
+		Content:       e.Content,
+		Reason:        e.Reason,
+		Status:        e.Status,
+		ReviewerId:    e.ReviewerID,
+		ReviewComment: e.ReviewComment,
+		CreatedAt:     timestamppb.New(e.CreatedAt),
+	}
+	if e.ReviewedAt.Valid {
+		pbEdit.ReviewedAt = timestamppb.New(e.ReviewedAt.Time)
+	}
+	return pbEdit
+}
+
+func (s *QAGrpcServer) FlagContent(ctx context.Context, req *pb.FlagContentRequest) (*emptypb.Empty, error) {
+	logger := pkglog.FromContext(ctx)
+
+	//从context中获取用户信息
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		logger.Error("标记违规内容失败：无法从context获取用户信息",
+			slog.String("target_type", req.TargetType),
+			slog.Int64("target_id", req.TargetId),
+		)
+		return nil, status.Errorf(codes.Internal, "无法从context获取用户信息")
+	}
+
+	logger.Info("标记违规内容请求",
+		slog.String("target_type", req.TargetType),
+		slog.Int64("target_id", req.TargetId),
+		slog.Int64("user_id", identity.UserID),
+	)
+
+	if _, err := s.qaService.FlagContent(ctx, req.TargetType, req.TargetId, req.Reason, identity.UserID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidFlagTarget):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrFlagTargetNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, service.ErrNotFlagModerator):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, service.ErrContentAlreadyFlagged):
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *QAGrpcServer) ExportContent(req *pb.ExportContentRequest, stream pb.QAService_ExportContentServer) error {
+	ctx := stream.Context()
+	logger := pkglog.FromContext(ctx)
+
+	logger.Info("导出内容请求", slog.Bool("include_password_hashes", req.IncludePasswordHashes))
+
+	var count int64
+	err := s.qaService.ExportContent(ctx, req.IncludePasswordHashes, func(record *model.ContentRecord) error {
+		count++
+		return stream.Send(toPbContentRecord(record))
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrAdminRequired) {
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+		return status.Error(codes.Internal, "导出内容失败")
+	}
+
+	logger.Info("导出内容成功", slog.Int64("records", count))
+	return nil
+}
+
+func (s *QAGrpcServer) ImportContent(stream pb.QAService_ImportContentServer) error {
+	ctx := stream.Context()
+	logger := pkglog.FromContext(ctx)
+
+	logger.Info("导入内容请求")
+
+	report, err := s.qaService.ImportContent(ctx, func() (*model.ContentRecord, error) {
+		record, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		return fromPbContentRecord(record), nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrAdminRequired):
+			return status.Error(codes.PermissionDenied, err.Error())
+		case errors.Is(err, service.ErrContentNotEmpty):
+			return status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, service.ErrMissingContentHeader),
+			errors.Is(err, service.ErrUnsupportedContentVersion),
+			errors.Is(err, service.ErrInvalidContentRecord),
+			errors.Is(err, service.ErrDanglingReference),
+			errors.Is(err, service.ErrInvalidUserRecord):
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Error(codes.Internal, "导入内容失败")
+	}
+
+	return stream.SendAndClose(&pb.ImportContentResponse{
+		Users:       report.Users,
+		ReusedUsers: report.ReusedUsers,
+		Questions:   report.Questions,
+		Answers:     report.Answers,
+		Comments:    report.Comments,
+		Votes:       report.Votes,
+
+		RenamedUsers:         toPbRenamedUsers(report.RenamedUsers),
+		UsersWithoutPassword: report.UsersWithoutPassword,
+	})
+}
+
+func toPbRenamedUsers(users []dto.RenamedUser) []*pb.RenamedUser {
+	pbUsers := make([]*pb.RenamedUser, 0, len(users))
+	for _, u := range users {
+		pbUsers = append(pbUsers, &pb.RenamedUser{
+			OriginalId:       u.OriginalID,
+			OriginalUsername: u.OriginalUsername,
+			Username:         u.Username,
+			UserId:           u.UserID,
+		})
+	}
+	return pbUsers
+}
+
+func toPbContentRecord(r *model.ContentRecord) *pb.ContentRecord {
+	switch {
+	case r.Header != nil:
+		return &pb.ContentRecord{Record: &pb.ContentRecord_Header{Header: &pb.ContentHeader{
+			FormatVersion: int32(r.Header.FormatVersion),
+			ExportedAt:    timestamppb.New(r.Header.ExportedAt),
+		}}}
+	case r.User != nil:
+		pbUser := &pb.ContentUser{
+			Id:           r.User.ID,
+			Username:     r.User.Username,
+			Email:        r.User.Email,
+			Bio:          r.User.Bio,
+			PasswordHash: r.User.Password,
+			Role:         r.User.Role,
+			CreatedAt:    timestamppb.New(r.User.CreatedAt),
+			UserType:     r.User.UserType,
+			Language:     r.User.Language,
+			AvatarKey:    r.User.AvatarKey,
+		}
+		if r.User.EmailVerifiedAt != nil {
+			pbUser.EmailVerifiedAt = timestamppb.New(*r.User.EmailVerifiedAt)
+		}
+		return &pb.ContentRecord{Record: &pb.ContentRecord_User{User: pbUser}}
+	case r.Question != nil:
+		return &pb.ContentRecord{Record: &pb.ContentRecord_Question{Question: &pb.Question{
+			Id:               r.Question.ID,
+			Title:            r.Question.Title,
+			Content:          r.Question.Content,
+			UserId:           r.Question.UserID,
+			IsAnonymous:      r.Question.IsAnonymous,
+			CreatedAt:        timestamppb.New(r.Question.CreatedAt),
+			UpdatedAt:        timestamppb.New(r.Question.UpdatedAt),
+			AcceptedAnswerId: r.Question.AcceptedAnswerID,
+		}}}
+	case r.Answer != nil:
+		return &pb.ContentRecord{Record: &pb.ContentRecord_Answer{Answer: &pb.Answer{
+			Id:          r.Answer.ID,
+			QuestionId:  r.Answer.QuestionID,
+			Content:     r.Answer.Content,
+			UserId:      r.Answer.UserID,
+			IsAnonymous: r.Answer.IsAnonymous,
+			UpvoteCount: int32(r.Answer.UpvoteCount),
+			CreatedAt:   timestamppb.New(r.Answer.CreatedAt),
+			UpdatedAt:   timestamppb.New(r.Answer.UpdatedAt),
+		}}}
+	case r.Comment != nil:
+		return &pb.ContentRecord{Record: &pb.ContentRecord_Comment{Comment: &pb.Comment{
+			Id:        r.Comment.ID,
+			AnswerId:  r.Comment.AnswerID,
+			UserId:    r.Comment.UserID,
+			Content:   r.Comment.Content,
+			CreatedAt: timestamppb.New(r.Comment.CreatedAt),
+			UpdatedAt: timestamppb.New(r.Comment.UpdatedAt),
+		}}}
+	case r.Vote != nil:
+		return &pb.ContentRecord{Record: &pb.ContentRecord_Vote{Vote: &pb.ContentVote{
+			Id:        r.Vote.ID,
+			AnswerId:  r.Vote.AnswerID,
+			UserId:    r.Vote.UserID,
+			IsUpvote:  r.Vote.IsUpvote,
+			CreatedAt: timestamppb.New(r.Vote.CreatedAt),
+		}}}
+	}
+	return &pb.ContentRecord{}
+}
+
+// fromPbContentRecord 转换导入的记录，未设置任何字段的记录会被服务层视为无效记录
+func fromPbContentRecord(r *pb.ContentRecord) *model.ContentRecord {
+	switch rec := r.Record.(type) {
+	case *pb.ContentRecord_Header:
+		return &model.ContentRecord{Header: &model.ContentHeader{
+			FormatVersion: int(rec.Header.FormatVersion),
+			ExportedAt:    rec.Header.ExportedAt.AsTime(),
+		}}
+	case *pb.ContentRecord_User:
+		user := &model.ContentUser{
+			ID:        rec.User.Id,
+			Username:  rec.User.Username,
+			Email:     rec.User.Email,
+			Bio:       rec.User.Bio,
+			AvatarKey: rec.User.AvatarKey,
+			Password:  rec.User.PasswordHash,
+			Role:      rec.User.Role,
+			UserType:  rec.User.UserType,
+			Language:  rec.User.Language,
+			CreatedAt: rec.User.CreatedAt.AsTime(),
+		}
+		if rec.User.EmailVerifiedAt != nil {
+			verifiedAt := rec.User.EmailVerifiedAt.AsTime()
+			user.EmailVerifiedAt = &verifiedAt
+		}
+		return &model.ContentRecord{User: user}
+	case *pb.ContentRecord_Question:
+		return &model.ContentRecord{Question: &model.Question{
+			ID:               rec.Question.Id,
+			Title:            rec.Question.Title,
+			Content:          rec.Question.Content,
+			UserID:           rec.Question.UserId,
+			IsAnonymous:      rec.Question.IsAnonymous,
+			AcceptedAnswerID: rec.Question.AcceptedAnswerId,
+			CreatedAt:        rec.Question.CreatedAt.AsTime(),
+			UpdatedAt:        rec.Question.UpdatedAt.AsTime(),
+		}}
+	case *pb.ContentRecord_Answer:
+		return &model.ContentRecord{Answer: &model.Answer{
+			ID:          rec.Answer.Id,
+			QuestionID:  rec.Answer.QuestionId,
+			Content:     rec.Answer.Content,
+			UserID:      rec.Answer.UserId,
+			IsAnonymous: rec.Answer.IsAnonymous,
+			CreatedAt:   rec.Answer.CreatedAt.AsTime(),
+			UpdatedAt:   rec.Answer.UpdatedAt.AsTime(),
+		}}
+	case *pb.ContentRecord_Comment:
+		return &model.ContentRecord{Comment: &model.Comment{
+			ID:        rec.Comment.Id,
+			AnswerID:  rec.Comment.AnswerId,
+			UserID:    rec.Comment.UserId,
+			Content:   rec.Comment.Content,
+			CreatedAt: rec.Comment.CreatedAt.AsTime(),
+			UpdatedAt: rec.Comment.UpdatedAt.AsTime(),
+		}}
+	case *pb.ContentRecord_Vote:
+		return &model.ContentRecord{Vote: &model.ContentVote{
+			ID:        rec.Vote.Id,
+			AnswerID:  rec.Vote.AnswerId,
+			UserID:    rec.Vote.UserId,
+			IsUpvote:  rec.Vote.IsUpvote,
+			CreatedAt: rec.Vote.CreatedAt.AsTime(),
+		}}
+	}
+	return &model.ContentRecord{}
+}
+
 func (s *QAGrpcServer) RegisterServer(grpcServer *grpc.Server) {
 	pb.RegisterQAServiceServer(grpcServer, s)
 }
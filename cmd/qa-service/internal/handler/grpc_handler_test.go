@@ -0,0 +1,261 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	pb "qahub/api/proto/qa"
+	userpb "qahub/api/proto/user"
+	"qahub/pkg/auth"
+	"qahub/pkg/config"
+	"qahub/pkg/interceptor"
+	"qahub/pkg/messaging"
+	"qahub/qa-service/internal/handler"
+	"qahub/qa-service/internal/model"
+	"qahub/qa-service/internal/service"
+	"qahub/qa-service/internal/store"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// testPublicMethods 与配置文件中的 public_methods 一致，这些方法允许匿名调用
+var testPublicMethods = []string{
+	pb.QAService_GetQuestion_FullMethodName,
+	pb.QAService_BatchGetAnswers_FullMethodName,
+}
+
+// newTestServer 启动一个经过认证拦截器的 QA gRPC 服务，返回客户端和签发测试令牌的函数，
+// 签发令牌时可以额外指定角色
+func newTestServer(t *testing.T, mockStore *service.MockQAStore) (pb.QAServiceClient, func(userID int64, role ...string) string) {
+	t.Helper()
+	return newTestServerWithUsers(t, mockStore, nil)
+}
+
+// newTestServerWithUsers 与 newTestServer 相同，导入内容时使用 users 创建用户
+func newTestServerWithUsers(t *testing.T, mockStore *service.MockQAStore, users service.UserImporter) (pb.QAServiceClient, func(userID int64, role ...string) string) {
+	t.Helper()
+
+	key, err := auth.GenerateSigningKey()
+	require.NoError(t, err)
+	signer, err := auth.NewSigner(key.ID, key)
+	require.NoError(t, err)
+	verifier := auth.NewVerifier(func(context.Context) (*auth.JWKS, error) {
+		jwks := signer.JWKS()
+		return &jwks, nil
+	}, 0, nil)
+
+	qaService := service.NewQAService(mockStore, messaging.NewKafkaProducer(config.Conf.Kafka), &config.Conf, users)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(interceptor.AuthUnaryServerInterceptor(nil, verifier, testPublicMethods...)),
+		grpc.ChainStreamInterceptor(interceptor.AuthStreamServerInterceptor(nil, verifier, testPublicMethods...)),
+	)
+	handler.NewQAGrpcServer(qaService, nil, nil).RegisterServer(srv)
+
+	lis := bufconn.Listen(1 << 20)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	issue := func(userID int64, role ...string) string {
+		claims := jwt.MapClaims{
+			"user_id":  userID,
+			"username": "tester",
+			"exp":      time.Now().Add(time.Hour).Unix(),
+		}
+		if len(role) > 0 {
+			claims["role"] = role[0]
+		}
+		token, err := signer.Sign(claims)
+		require.NoError(t, err)
+		return token
+	}
+	return pb.NewQAServiceClient(conn), issue
+}
+
+// userImporterFunc 让测试用普通函数代替用户服务的客户端
+type userImporterFunc func(ctx context.Context, req *userpb.ImportUserRequest) (*userpb.ImportUserResponse, error)
+
+func (f userImporterFunc) ImportUser(ctx context.Context, req *userpb.ImportUserRequest) (*userpb.ImportUserResponse, error) {
+	return f(ctx, req)
+}
+
+// withToken 在请求的 metadata 中附带访问令牌
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestGetQuestionThroughAuthInterceptor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	client, issue := newTestServer(t, mockStore)
+
+	question := &model.Question{ID: 1, Title: "匿名问题", UserID: 100, IsAnonymous: true}
+	mockStore.EXPECT().GetQuestionByID(gomock.Any(), int64(1)).Return(question, nil).AnyTimes()
+	mockStore.EXPECT().GetUsernamesByIDs(gomock.Any(), []int64{100}).Return(map[int64]string{100: "alice"}, nil).AnyTimes()
+	mockStore.EXPECT().GetUserTypesByIDs(gomock.Any(), []int64{100}).Return(map[int64]string{100: auth.UserTypeHuman}, nil).AnyTimes()
+	mockStore.EXPECT().GetUserAvatarsByIDs(gomock.Any(), []int64{100}).Return(map[int64]string{}, nil).AnyTimes()
+	mockStore.EXPECT().IncrementQuestionViewCount(gomock.Any(), int64(1)).Return(nil).AnyTimes()
+
+	t.Run("未登录时隐藏匿名问题的作者", func(t *testing.T) {
+		resp, err := client.GetQuestion(context.Background(), &pb.GetQuestionRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Zero(t, resp.UserId)
+		assert.Equal(t, service.AnonymousAuthorName, resp.AuthorName)
+	})
+
+	t.Run("作者本人携带令牌时可以看到自己", func(t *testing.T) {
+		resp, err := client.GetQuestion(withToken(issue(100)), &pb.GetQuestionRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), resp.UserId)
+		assert.Equal(t, "alice", resp.AuthorName)
+	})
+
+	t.Run("其他用户仍然看不到作者", func(t *testing.T) {
+		resp, err := client.GetQuestion(withToken(issue(200)), &pb.GetQuestionRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Zero(t, resp.UserId)
+	})
+
+	t.Run("携带无效令牌时拒绝请求", func(t *testing.T) {
+		_, err := client.GetQuestion(withToken("not-a-token"), &pb.GetQuestionRequest{Id: 1})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+func TestBatchGetAnswersReturnsVoteStatusForLoggedInCaller(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	client, issue := newTestServer(t, mockStore)
+
+	answer := &model.Answer{ID: 10, QuestionID: 1, UserID: 100, UpvoteCount: 1}
+	mockStore.EXPECT().GetAnswersByIDs(gomock.Any(), []int64{10}).Return([]*model.Answer{answer}, nil).Times(2)
+	mockStore.EXPECT().GetUsernamesByIDs(gomock.Any(), []int64{100}).Return(map[int64]string{100: "alice"}, nil).Times(2)
+	mockStore.EXPECT().GetUserTypesByIDs(gomock.Any(), []int64{100}).Return(map[int64]string{100: auth.UserTypeHuman}, nil).Times(2)
+	mockStore.EXPECT().GetUserAvatarsByIDs(gomock.Any(), []int64{100}).Return(map[int64]string{}, nil).Times(2)
+	mockStore.EXPECT().GetUserVotesForAnswers(gomock.Any(), int64(0), []int64{10}).Return(map[int64]bool{}, nil).AnyTimes()
+	mockStore.EXPECT().GetUserVotesForAnswers(gomock.Any(), int64(300), []int64{10}).Return(map[int64]bool{10: true}, nil).Times(1)
+
+	resp, err := client.BatchGetAnswers(context.Background(), &pb.BatchGetAnswersRequest{Ids: []int64{10}})
+	require.NoError(t, err)
+	require.Len(t, resp.Answers, 1)
+	assert.False(t, resp.Answers[0].IsUpvotedByUser, "未登录时不返回点赞状态")
+
+	resp, err = client.BatchGetAnswers(withToken(issue(300)), &pb.BatchGetAnswersRequest{Ids: []int64{10}})
+	require.NoError(t, err)
+	require.Len(t, resp.Answers, 1)
+	assert.True(t, resp.Answers[0].IsUpvotedByUser, "已登录用户应能看到自己的点赞状态")
+}
+
+func TestContentUserRoundTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	var imported []*userpb.ImportUserRequest
+	client, issue := newTestServerWithUsers(t, mockStore, userImporterFunc(
+		func(ctx context.Context, req *userpb.ImportUserRequest) (*userpb.ImportUserResponse, error) {
+			imported = append(imported, req)
+			return &userpb.ImportUserResponse{UserId: int64(100 + len(imported)), Username: req.Username}, nil
+		},
+	))
+	adminCtx := withToken(issue(1, auth.RoleAdmin))
+
+	verifiedAt := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	exported := &model.ContentUser{
+		ID:              7,
+		Username:        "bot",
+		Email:           "bot@example.com",
+		EmailVerifiedAt: &verifiedAt,
+		Bio:             "自动回答常见问题",
+		AvatarKey:       "avatars/7/0123456789abcdef",
+		Password:        "$2a$10$hash",
+		Role:            auth.RoleUser,
+		UserType:        auth.UserTypeBot,
+		Language:        "en",
+		CreatedAt:       time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	unverified := &model.ContentUser{ID: 8, Username: "new", Email: "new@example.com", CreatedAt: exported.CreatedAt}
+
+	runInTx := func(ctx context.Context, fn func(store.QAStore) error) error { return fn(mockStore) }
+	mockStore.EXPECT().ExecTx(gomock.Any(), gomock.Any()).DoAndReturn(runInTx).Times(2)
+	mockStore.EXPECT().ListUsersForExport(gomock.Any(), int64(0), gomock.Any()).Return([]*model.ContentUser{exported, unverified}, nil).Times(1)
+	mockStore.EXPECT().ListQuestionsForExport(gomock.Any(), int64(0), gomock.Any()).Return([]*model.Question{}, nil).Times(1)
+	mockStore.EXPECT().ListAnswersForExport(gomock.Any(), int64(0), gomock.Any()).Return([]*model.Answer{}, nil).Times(1)
+	mockStore.EXPECT().ListCommentsForExport(gomock.Any(), int64(0), gomock.Any()).Return([]*model.Comment{}, nil).Times(1)
+	mockStore.EXPECT().ListVotesForExport(gomock.Any(), int64(0), gomock.Any()).Return([]*model.ContentVote{}, nil).Times(1)
+
+	// 导出
+	exportStream, err := client.ExportContent(adminCtx, &pb.ExportContentRequest{IncludePasswordHashes: true})
+	require.NoError(t, err)
+	var records []*pb.ContentRecord
+	for {
+		record, err := exportStream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		records = append(records, record)
+	}
+	require.Len(t, records, 3)
+
+	// 经过一次序列化，与写入导出文件后再读出一致
+	for i, record := range records {
+		data, err := proto.Marshal(record)
+		require.NoError(t, err)
+		records[i] = &pb.ContentRecord{}
+		require.NoError(t, proto.Unmarshal(data, records[i]))
+	}
+
+	// 导入到空实例
+	mockStore.EXPECT().HasQAContent(gomock.Any()).Return(false, nil).Times(1)
+
+	// 导入完成后异步重放事件时获取用户名
+	mockStore.EXPECT().GetUsernamesByIDs(gomock.Any(), gomock.Any()).Return(map[int64]string{}, nil).AnyTimes()
+
+	importStream, err := client.ImportContent(adminCtx)
+	require.NoError(t, err)
+	for _, record := range records {
+		require.NoError(t, importStream.Send(record))
+	}
+	resp, err := importStream.CloseAndRecv()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), resp.Users)
+
+	require.Len(t, imported, 2)
+	got := imported[0]
+	require.NotNil(t, got.EmailVerifiedAt)
+	assert.True(t, verifiedAt.Equal(got.EmailVerifiedAt.AsTime()))
+	assert.Equal(t, exported.UserType, got.UserType)
+	assert.Equal(t, exported.Language, got.Language)
+	assert.Equal(t, exported.AvatarKey, got.AvatarKey)
+	assert.Equal(t, exported.Password, got.PasswordHash)
+	assert.Equal(t, exported.Role, got.Role)
+	assert.Equal(t, exported.Bio, got.Bio)
+	assert.True(t, exported.CreatedAt.Equal(got.CreatedAt.AsTime()))
+
+	assert.Nil(t, imported[1].EmailVerifiedAt, "未验证的邮箱导入后仍然未验证")
+}
@@ -1,15 +1,32 @@
 package model
 
-import "time"
+import (
+	"database/sql"
+	"time"
+)
 
 // Question 对应于数据库中的 questions 表
 type Question struct {
-	ID        int64     `db:"id"`
-	Title     string    `db:"title"`
-	Content   string    `db:"content"`
-	UserID    int64     `db:"user_id"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID               int64     `db:"id"`
+	Title            string    `db:"title"`
+	Content          string    `db:"content"`
+	UserID           int64     `db:"user_id"`
+	IsAnonymous      bool      `db:"is_anonymous"`       // 匿名发布时只有作者本人和管理员能看到 UserID
+	AcceptedAnswerID int64     `db:"accepted_answer_id"` // 被采纳的回答ID，0 表示尚未采纳
+	AnswerCount      int64     `db:"answer_count"`       // 回答数，与回答的写入在同一事务中维护
+	CommentCount     int64     `db:"comment_count"`      // 问题下所有回答的评论总数
+	LastActivityAt   time.Time `db:"last_activity_at"`   // 最近一次编辑问题、新增或编辑回答、新增评论的时间
+	CreatedAt        time.Time `db:"created_at"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
+
+// QuestionStats 是计算热门分数所需的问题统计数据
+type QuestionStats struct {
+	ID          int64     `db:"id"`
+	CreatedAt   time.Time `db:"created_at"`
+	ViewCount   int64     `db:"view_count"`
+	AnswerCount int64     `db:"answer_count"`
+	UpvoteCount int64     `db:"upvote_count"`
 }
 
 // Answer 对应于数据库中的 answers 表
@@ -18,6 +35,7 @@ type Answer struct {
 	QuestionID  int64     `db:"question_id"`
 	Content     string    `db:"content"`
 	UserID      int64     `db:"user_id"`
+	IsAnonymous bool      `db:"is_anonymous"` // 匿名发布时只有作者本人和管理员能看到 UserID
 	UpvoteCount int       `db:"upvote_count"`
 	CreatedAt   time.Time `db:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at"`
@@ -32,3 +50,141 @@ type Comment struct {
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
 }
+
+// AnswerWithQuestion 是附带所属问题标题的回答，用于用户回答列表
+type AnswerWithQuestion struct {
+	Answer
+	QuestionTitle string `db:"question_title"`
+}
+
+// CommentWithQuestion 是附带所属问题信息的评论，用于用户评论列表
+type CommentWithQuestion struct {
+	Comment
+	QuestionID    int64  `db:"question_id"`
+	QuestionTitle string `db:"question_title"`
+}
+
+// 用户动态的类型
+const (
+	ActivityTypeQuestion = "question"
+	ActivityTypeAnswer   = "answer"
+	ActivityTypeComment  = "comment"
+)
+
+// Activity 是用户动态时间线中的一项，由问题、回答、评论合并而成
+type Activity struct {
+	Type          string    `db:"type"`
+	ID            int64     `db:"id"`
+	QuestionID    int64     `db:"question_id"`
+	QuestionTitle string    `db:"question_title"`
+	Content       string    `db:"content"`
+	CreatedAt     time.Time `db:"created_at"`
+}
+
+// 计数校对的对象
+const (
+	CounterQuestionAnswers  = "question_answer_count"
+	CounterQuestionComments = "question_comment_count"
+	CounterAnswerUpvotes    = "answer_upvote_count"
+)
+
+// CounterDrift 是一条冗余计数与源表重新统计结果不一致的记录
+type CounterDrift struct {
+	Counter  string `db:"counter"`
+	ID       int64  `db:"id"`       // 问题或回答的ID
+	Stored   int64  `db:"stored"`   // 表中保存的计数
+	Expected int64  `db:"expected"` // 根据源表重新统计的计数
+}
+
+// 修改建议的目标类型
+const (
+	EditTargetQuestion = "question"
+	EditTargetAnswer   = "answer"
+)
+
+// 修改建议的状态
+const (
+	EditStatusPending  = "pending"
+	EditStatusApproved = "approved"
+	EditStatusRejected = "rejected"
+)
+
+// SuggestedEdit 对应于数据库中的 suggested_edits 表，是其他用户对问题或回答提出的修改建议
+type SuggestedEdit struct {
+	ID          int64  `db:"id"`
+	TargetType  string `db:"target_type"`
+	TargetID    int64  `db:"target_id"`
+	ProposerID  int64  `db:"proposer_id"`
+	BaseTitle   string `db:"base_title"`   // 提出建议时目标的标题，回答为空
+	BaseContent string `db:"base_content"` // 提出建议时目标的内容，审核时用于判断建议是否过期
+	Title       string `db:"title"`
+	Content     string `db:"content"`
+	Reason      string `db:"reason"`
+	Status      string `db:"status"`
+	// ReviewerID 审核人ID，0 表示尚未审核
+	ReviewerID    int64        `db:"reviewer_id"`
+	ReviewComment string       `db:"review_comment"`
+	ReviewedAt    sql.NullTime `db:"reviewed_at"`
+	CreatedAt     time.Time    `db:"created_at"`
+}
+
+// ContentFlag 对应于数据库中的 content_flags 表，是版主将问题或回答标记为违规的记录，
+// TargetType 取值同 EditTargetQuestion / EditTargetAnswer
+type ContentFlag struct {
+	ID         int64     `db:"id"`
+	TargetType string    `db:"target_type"`
+	TargetID   int64     `db:"target_id"`
+	QuestionID int64     `db:"question_id"`
+	AuthorID   int64     `db:"author_id"` // 被标记内容的作者ID
+	ReporterID int64     `db:"reporter_id"`
+	Reason     string    `db:"reason"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// 导出文件格式的版本号，格式发生不兼容变化时递增 ContentFormatVersion。
+// 版本 2 起用户记录包含邮箱验证时间、用户类型、语言和头像；
+// 导入版本 1 的文件时这些字段按迁移 000017 的做法取默认值，用户视为已验证邮箱。
+const (
+	ContentFormatVersion    = 2
+	MinContentFormatVersion = 1 // 导入仍然支持的最早版本
+)
+
+// ContentHeader 是导出文件的第一条记录
+type ContentHeader struct {
+	FormatVersion int
+	ExportedAt    time.Time
+}
+
+// ContentUser 是导出和导入时的用户记录，包含密码哈希，迁移后用户仍可使用原密码登录
+type ContentUser struct {
+	ID              int64      `db:"id"`
+	Username        string     `db:"username"`
+	Email           string     `db:"email"`
+	EmailVerifiedAt *time.Time `db:"email_verified_at"` // 为 nil 表示邮箱尚未验证
+	Bio             string     `db:"bio"`
+	AvatarKey       string     `db:"avatar_key"` // 头像文件不在导出内容中，需要另行复制到新实例的文件存储
+	Password        string     `db:"password"`
+	Role            string     `db:"role"`
+	UserType        string     `db:"user_type"`
+	Language        string     `db:"language"`
+	CreatedAt       time.Time  `db:"created_at"`
+}
+
+// ContentVote 是导出和导入时的回答投票记录
+type ContentVote struct {
+	ID        int64     `db:"id"` // 仅用于分批导出，导入时重新生成
+	AnswerID  int64     `db:"answer_id"`
+	UserID    int64     `db:"user_id"`
+	IsUpvote  bool      `db:"is_upvote"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ContentRecord 是导出流中的一条记录，每条记录只设置其中一个字段
+type ContentRecord struct {
+	Header   *ContentHeader
+	User     *ContentUser
+	Question *Question
+	Answer   *Answer
+	Comment  *Comment
+	Vote     *ContentVote
+}
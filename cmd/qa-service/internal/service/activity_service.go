@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"qahub/pkg/log"
+	"qahub/pkg/pagination"
+	"qahub/qa-service/internal/dto"
+	"qahub/qa-service/internal/model"
+)
+
+// ListAnswersByUserID 返回用户发布的回答，每条回答附带所属问题的标题
+func (s *qaService) ListAnswersByUserID(ctx context.Context, userID int64, page int64, pageSize int32) ([]*dto.UserAnswerResponse, int64, error) {
+	logger := log.FromContext(ctx)
+
+	// 他人匿名发布的回答不出现在其个人主页中
+	includeAnonymous := canSeeAuthor(ctx, userID)
+	limit, offset := pagination.CalculateOffset(page, pageSize)
+	answers, err := s.store.ListAnswersByUserID(ctx, userID, includeAnonymous, offset, limit)
+	if err != nil {
+		logger.Error("按用户ID列表查询回答失败",
+			slog.Int64("user_id", userID),
+			slog.Int64("page", page),
+			slog.String("error", err.Error()),
+		)
+		return nil, 0, err
+	}
+	count, err := s.store.CountAnswersByUserID(ctx, userID, includeAnonymous)
+	if err != nil {
+		logger.Error("统计用户回答失败",
+			slog.Int64("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return nil, 0, err
+	}
+
+	responses := make([]*dto.UserAnswerResponse, 0, len(answers))
+	if len(answers) == 0 {
+		return responses, count, nil
+	}
+
+	usernames, err := s.store.GetUsernamesByIDs(ctx, []int64{userID})
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, a := range answers {
+		responses = append(responses, &dto.UserAnswerResponse{
+			AnswerWithQuestion: *a,
+			Username:           usernames[userID],
+		})
+	}
+
+	logger.Debug("用户回答列表查询成功",
+		slog.Int64("user_id", userID),
+		slog.Int("count", len(answers)),
+		slog.Int64("total", count),
+	)
+	return responses, count, nil
+}
+
+// ListCommentsByUserID 返回用户发布的评论，每条评论附带所属问题的ID和标题
+func (s *qaService) ListCommentsByUserID(ctx context.Context, userID int64, page int64, pageSize int32) ([]*dto.UserCommentResponse, int64, error) {
+	logger := log.FromContext(ctx)
+
+	limit, offset := pagination.CalculateOffset(page, pageSize)
+	comments, err := s.store.ListCommentsByUserID(ctx, userID, offset, limit)
+	if err != nil {
+		logger.Error("按用户ID列表查询评论失败",
+			slog.Int64("user_id", userID),
+			slog.Int64("page", page),
+			slog.String("error", err.Error()),
+		)
+		return nil, 0, err
+	}
+	count, err := s.store.CountCommentsByUserID(ctx, userID)
+	if err != nil {
+		logger.Error("统计用户评论失败",
+			slog.Int64("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return nil, 0, err
+	}
+
+	responses := make([]*dto.UserCommentResponse, 0, len(comments))
+	if len(comments) == 0 {
+		return responses, count, nil
+	}
+
+	usernames, err := s.store.GetUsernamesByIDs(ctx, []int64{userID})
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, c := range comments {
+		responses = append(responses, &dto.UserCommentResponse{
+			CommentWithQuestion: *c,
+			Username:            usernames[userID],
+		})
+	}
+
+	logger.Debug("用户评论列表查询成功",
+		slog.Int64("user_id", userID),
+		slog.Int("count", len(comments)),
+		slog.Int64("total", count),
+	)
+	return responses, count, nil
+}
+
+// GetUserActivity 返回用户的提问、回答、评论按时间倒序合并后的时间线
+func (s *qaService) GetUserActivity(ctx context.Context, userID int64, page int64, pageSize int32) ([]*model.Activity, int64, error) {
+	logger := log.FromContext(ctx)
+
+	includeAnonymous := canSeeAuthor(ctx, userID)
+	limit, offset := pagination.CalculateOffset(page, pageSize)
+	activities, err := s.store.ListActivitiesByUserID(ctx, userID, includeAnonymous, offset, limit)
+	if err != nil {
+		logger.Error("查询用户动态失败",
+			slog.Int64("user_id", userID),
+			slog.Int64("page", page),
+			slog.String("error", err.Error()),
+		)
+		return nil, 0, err
+	}
+
+	// 时间线的总数是三类内容数量之和
+	var total int64
+	for _, countFn := range []func(context.Context, int64) (int64, error){
+		func(ctx context.Context, userID int64) (int64, error) {
+			return s.store.CountQuestionsByUserID(ctx, userID, includeAnonymous)
+		},
+		func(ctx context.Context, userID int64) (int64, error) {
+			return s.store.CountAnswersByUserID(ctx, userID, includeAnonymous)
+		},
+		s.store.CountCommentsByUserID,
+	} {
+		count, err := countFn(ctx, userID)
+		if err != nil {
+			logger.Error("统计用户动态失败",
+				slog.Int64("user_id", userID),
+				slog.String("error", err.Error()),
+			)
+			return nil, 0, err
+		}
+		total += count
+	}
+
+	if activities == nil {
+		activities = []*model.Activity{}
+	}
+	logger.Debug("用户动态查询成功",
+		slog.Int64("user_id", userID),
+		slog.Int("count", len(activities)),
+		slog.Int64("total", total),
+	)
+	return activities, total, nil
+}
@@ -0,0 +1,146 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"qahub/pkg/config"
+	"qahub/pkg/messaging"
+	"qahub/qa-service/internal/model"
+	"qahub/qa-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListAnswersByUserID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
+	ctx := context.Background()
+
+	t.Run("成功获取用户回答", func(t *testing.T) {
+		userID := int64(100)
+		answers := []*model.AnswerWithQuestion{
+			{Answer: model.Answer{ID: 1, QuestionID: 10, UserID: userID, Content: "回答1"}, QuestionTitle: "问题10"},
+			{Answer: model.Answer{ID: 2, QuestionID: 11, UserID: userID, Content: "回答2"}, QuestionTitle: "问题11"},
+		}
+
+		// Mock: 分页查询用户回答 (page=1, pageSize=10 => offset=0, limit=10)
+		mockStore.EXPECT().
+			ListAnswersByUserID(ctx, userID, false, int64(0), int32(10)).
+			Return(answers, nil).
+			Times(1)
+
+		mockStore.EXPECT().
+			CountAnswersByUserID(ctx, userID, false).
+			Return(int64(2), nil).
+			Times(1)
+
+		mockStore.EXPECT().
+			GetUsernamesByIDs(ctx, []int64{userID}).
+			Return(map[int64]string{userID: "testuser"}, nil).
+			Times(1)
+
+		// 执行测试
+		result, total, err := qaService.ListAnswersByUserID(ctx, userID, 1, 10)
+
+		// 验证结果
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Len(t, result, 2)
+		assert.Equal(t, "问题10", result[0].QuestionTitle)
+		assert.Equal(t, "testuser", result[0].Username)
+	})
+
+	t.Run("用户没有回答", func(t *testing.T) {
+		userID := int64(101)
+
+		mockStore.EXPECT().
+			ListAnswersByUserID(ctx, userID, false, int64(0), int32(10)).
+			Return([]*model.AnswerWithQuestion{}, nil).
+			Times(1)
+
+		mockStore.EXPECT().
+			CountAnswersByUserID(ctx, userID, false).
+			Return(int64(0), nil).
+			Times(1)
+
+		// 执行测试
+		result, total, err := qaService.ListAnswersByUserID(ctx, userID, 1, 10)
+
+		// 验证结果
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), total)
+		assert.Empty(t, result)
+	})
+}
+
+func TestListCommentsByUserID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
+	ctx := context.Background()
+
+	t.Run("查询失败-数据库错误", func(t *testing.T) {
+		userID := int64(100)
+
+		mockStore.EXPECT().
+			ListCommentsByUserID(ctx, userID, int64(0), int32(10)).
+			Return(nil, errors.New("database error")).
+			Times(1)
+
+		// 执行测试
+		result, _, err := qaService.ListCommentsByUserID(ctx, userID, 1, 10)
+
+		// 验证结果
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestGetUserActivity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
+	ctx := context.Background()
+
+	t.Run("合并三类内容的时间线", func(t *testing.T) {
+		userID := int64(100)
+		now := time.Now()
+		activities := []*model.Activity{
+			{Type: model.ActivityTypeComment, ID: 3, QuestionID: 10, CreatedAt: now},
+			{Type: model.ActivityTypeAnswer, ID: 2, QuestionID: 10, CreatedAt: now.Add(-time.Minute)},
+			{Type: model.ActivityTypeQuestion, ID: 10, QuestionID: 10, CreatedAt: now.Add(-time.Hour)},
+		}
+
+		mockStore.EXPECT().
+			ListActivitiesByUserID(ctx, userID, false, int64(0), int32(10)).
+			Return(activities, nil).
+			Times(1)
+
+		mockStore.EXPECT().CountQuestionsByUserID(ctx, userID, false).Return(int64(1), nil).Times(1)
+		mockStore.EXPECT().CountAnswersByUserID(ctx, userID, false).Return(int64(2), nil).Times(1)
+		mockStore.EXPECT().CountCommentsByUserID(ctx, userID).Return(int64(3), nil).Times(1)
+
+		// 执行测试
+		result, total, err := qaService.GetUserActivity(ctx, userID, 1, 10)
+
+		// 验证结果
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6), total)
+		assert.Len(t, result, 3)
+		assert.Equal(t, model.ActivityTypeComment, result[0].Type)
+	})
+}
@@ -12,16 +12,18 @@ import (
 	"qahub/qa-service/internal/dto"
 	"qahub/qa-service/internal/model"
 	"qahub/qa-service/internal/store"
+	"strconv"
 	"time"
 )
 
-func (s *qaService) CreateAnswer(ctx context.Context, questionID int64, content string, userID int64) (*model.Answer, error) {
+func (s *qaService) CreateAnswer(ctx context.Context, questionID int64, content string, anonymous bool, userID int64) (*model.Answer, error) {
 	logger := log.FromContext(ctx)
 	
 	answer := &model.Answer{
-		QuestionID: questionID,
-		Content:    content,
-		UserID:     userID,
+		QuestionID:  questionID,
+		Content:     content,
+		UserID:      userID,
+		IsAnonymous: anonymous,
 	}
 	// 从上下文中提前获取用户信息
 	identity, ok := auth.FromContext(ctx)
@@ -33,7 +35,19 @@ func (s *qaService) CreateAnswer(ctx context.Context, questionID int64, content
 		)
 		return nil, errors.New("user identity not found in context")
 	}
-	answerID, err := s.store.CreateAnswer(ctx, answer)
+	// 回答与问题的回答数、最近活动时间在同一事务中写入
+	var answerID int64
+	err := s.store.ExecTx(ctx, func(tx store.QAStore) error {
+		id, err := tx.CreateAnswer(ctx, answer)
+		if err != nil {
+			return err
+		}
+		answerID = id
+		if err := tx.AdjustQuestionCounters(ctx, questionID, 1, 0); err != nil {
+			return err
+		}
+		return tx.TouchQuestionActivity(ctx, questionID)
+	})
 	if err != nil {
 		logger.Error("创建回答失败",
 			slog.Int64("question_id", questionID),
@@ -60,6 +74,28 @@ func (s *qaService) CreateAnswer(ctx context.Context, questionID int64, content
 		if err != nil {
 			return
 		}
+		// 匿名回答的事件和通知都不带回答者的身份
+		senderID, eventAuthorName := newAnswer.UserID, senderUsername
+		params := map[string]string{
+			messaging.NotificationParamSender:        senderUsername,
+			messaging.NotificationParamQuestionTitle: question.Title,
+			messaging.NotificationParamExcerpt:       newAnswer.Content,
+		}
+		if newAnswer.IsAnonymous {
+			senderID, eventAuthorName, senderUsername = 0, "", ""
+			params[messaging.NotificationParamSender] = ""
+			params[messaging.NotificationParamSenderAnonymous] = "true"
+		}
+		s.publishAnswerEvent(notifyCtx, messaging.EventAnswerCreated, messaging.AnswerPayload{
+			ID:               newAnswer.ID,
+			QuestionID:       question.ID,
+			QuestionAuthorID: question.UserID,
+			Content:          newAnswer.Content,
+			AuthorID:         newAnswer.UserID,
+			AuthorName:       eventAuthorName,
+			Anonymous:        newAnswer.IsAnonymous,
+			CreatedAt:        time.Now(),
+		})
 		if question.UserID == userID {
 			// 如果回答者是问题的作者自己，则不发送通知
 			return
@@ -67,11 +103,11 @@ func (s *qaService) CreateAnswer(ctx context.Context, questionID int64, content
 		// 发布通知事件，通知问题的作者有了新的回答
 		notificationPayload := messaging.NotificationPayload{
 			RecipientID:      question.UserID,
-			SenderID:         newAnswer.UserID,
+			SenderID:         senderID,
 			SenderName:       senderUsername,
 			NotificationType: messaging.NotificationTypeNewAnswer,
-			Content:          fmt.Sprintf("'%s' 回答了你的问题: '%s',内容是'%s'", senderUsername, question.Title, newAnswer.Content),
 			TargetURL:        fmt.Sprintf("/questions/%d#answer-%d", question.ID, newAnswer.ID),
+			Params:           params,
 		}
 		s.publishNotificationEvent(notifyCtx, notificationPayload)
 	}(identity.Username, *answer)
@@ -100,6 +136,67 @@ func (s *qaService) ListAnswers(ctx context.Context, questionID int64, page int6
 		return []*dto.AnswerResponse{}, count, nil
 	}
 
+	answerResponses, err := s.buildAnswerResponses(ctx, answers, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return answerResponses, count, nil
+}
+
+// BatchGetAnswers 批量获取回答，结果按请求中 ID 的顺序排列
+func (s *qaService) BatchGetAnswers(ctx context.Context, answerIDs []int64, userID int64) ([]*dto.AnswerResponse, []int64, error) {
+	logger := log.FromContext(ctx)
+
+	ids, err := normalizeBatchIDs(answerIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	answers, err := s.store.GetAnswersByIDs(ctx, ids)
+	if err != nil {
+		logger.Error("批量获取回答失败",
+			slog.Int("count", len(ids)),
+			slog.String("error", err.Error()),
+		)
+		return nil, nil, err
+	}
+
+	byID := make(map[int64]*model.Answer, len(answers))
+	for _, a := range answers {
+		byID[a.ID] = a
+	}
+	ordered := make([]*model.Answer, 0, len(answers))
+	notFound := make([]int64, 0)
+	for _, id := range ids {
+		if a, ok := byID[id]; ok {
+			ordered = append(ordered, a)
+		} else {
+			notFound = append(notFound, id)
+		}
+	}
+
+	responses, err := s.buildAnswerResponses(ctx, ordered, userID)
+	if err != nil {
+		logger.Error("构建回答响应失败",
+			slog.String("error", err.Error()),
+		)
+		return nil, nil, err
+	}
+
+	logger.Debug("批量获取回答成功",
+		slog.Int("requested", len(ids)),
+		slog.Int("not_found", len(notFound)),
+	)
+	return responses, notFound, nil
+}
+
+// buildAnswerResponses 为回答补充回答者用户名和当前用户的点赞状态
+func (s *qaService) buildAnswerResponses(ctx context.Context, answers []*model.Answer, userID int64) ([]*dto.AnswerResponse, error) {
+	if len(answers) == 0 {
+		return []*dto.AnswerResponse{}, nil
+	}
+
 	userIDSet := make(map[int64]struct{})
 	for _, answer := range answers {
 		userIDSet[answer.UserID] = struct{}{}
@@ -111,7 +208,15 @@ func (s *qaService) ListAnswers(ctx context.Context, questionID int64, page int6
 
 	usernames, err := s.store.GetUsernamesByIDs(ctx, userIDs)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	userTypes, err := s.store.GetUserTypesByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	avatars, err := s.store.GetUserAvatarsByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
 	}
 
 	// 提取所有回答的 ID
@@ -123,20 +228,26 @@ func (s *qaService) ListAnswers(ctx context.Context, questionID int64, page int6
 	// 获取当前用户对这些回答的点赞状态
 	votes, err := s.store.GetUserVotesForAnswers(ctx, userID, answerIDs)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 
-	// 构建响应
 	answerResponses := make([]*dto.AnswerResponse, len(answers))
 	for i, answer := range answers {
 		answerResponses[i] = &dto.AnswerResponse{
 			Answer:          *answer,
 			Username:        usernames[answer.UserID],
 			IsUpvotedByUser: votes[answer.ID],
+			AuthorIsBot:     userTypes[answer.UserID] == auth.UserTypeBot,
+			AuthorAvatarURL: authorAvatarURL(avatars[answer.UserID]),
+		}
+		if answer.IsAnonymous && !canSeeAuthor(ctx, answer.UserID) {
+			answerResponses[i].UserID = 0
+			answerResponses[i].Username = AnonymousAuthorName
+			answerResponses[i].AuthorIsBot = false
+			answerResponses[i].AuthorAvatarURL = ""
 		}
 	}
-
-	return answerResponses, count, nil
+	return answerResponses, nil
 }
 
 func (s *qaService) UpdateAnswer(ctx context.Context, answerID int64, content string, userID int64) (*model.Answer, error) {
@@ -158,8 +269,10 @@ func (s *qaService) UpdateAnswer(ctx context.Context, answerID int64, content st
 		)
 		return nil, errors.New("无权限修改该回答")
 	}
-	answer.Content = content
-	if err := s.store.UpdateAnswer(ctx, answer); err != nil {
+	err = s.store.ExecTx(ctx, func(tx store.QAStore) error {
+		return s.applyAnswerEdit(ctx, tx, answer, content)
+	})
+	if err != nil {
 		logger.Error("更新回答失败",
 			slog.Int64("answer_id", answerID),
 			slog.String("error", err.Error()),
@@ -175,6 +288,16 @@ func (s *qaService) UpdateAnswer(ctx context.Context, answerID int64, content st
 	return answer, nil
 }
 
+// applyAnswerEdit 写入回答的新内容并刷新问题的最后活跃时间，UpdateAnswer 和通过修改建议共用这一写入路径，
+// 调用方负责权限校验，并应在事务中调用
+func (s *qaService) applyAnswerEdit(ctx context.Context, tx store.QAStore, answer *model.Answer, content string) error {
+	answer.Content = content
+	if err := tx.UpdateAnswer(ctx, answer); err != nil {
+		return err
+	}
+	return tx.TouchQuestionActivity(ctx, answer.QuestionID)
+}
+
 func (s *qaService) DeleteAnswer(ctx context.Context, answerID, userID int64) error {
 	logger := log.FromContext(ctx)
 	
@@ -195,7 +318,20 @@ func (s *qaService) DeleteAnswer(ctx context.Context, answerID, userID int64) er
 		return errors.New("无权限删除该回答")
 	}
 	
-	err = s.store.DeleteAnswer(ctx, answerID)
+	// 被采纳的回答删除后，问题回到未采纳状态；回答下的评论会被级联删除，一并从问题的评论数中扣除
+	err = s.store.ExecTx(ctx, func(tx store.QAStore) error {
+		if err := tx.ClearAcceptedAnswer(ctx, answerID); err != nil {
+			return err
+		}
+		commentCount, err := tx.CountCommentsByAnswerID(ctx, answerID)
+		if err != nil {
+			return err
+		}
+		if err := tx.DeleteAnswer(ctx, answerID); err != nil {
+			return err
+		}
+		return tx.AdjustQuestionCounters(ctx, answer.QuestionID, -1, -commentCount)
+	})
 	if err != nil {
 		logger.Error("删除回答失败",
 			slog.Int64("answer_id", answerID),
@@ -208,7 +344,14 @@ func (s *qaService) DeleteAnswer(ctx context.Context, answerID, userID int64) er
 		slog.Int64("answer_id", answerID),
 		slog.Int64("user_id", userID),
 	)
-	// TODO: 发布回答删除事件
+
+	// 发布回答删除事件
+	go s.publishAnswerEvent(context.Background(), messaging.EventAnswerDeleted, messaging.AnswerPayload{
+		ID:         answer.ID,
+		QuestionID: answer.QuestionID,
+		AuthorID:   answer.UserID,
+		CreatedAt:  answer.CreatedAt,
+	})
 	return nil
 }
 
@@ -240,37 +383,218 @@ func (s *qaService) UpvoteAnswer(ctx context.Context, answerID, userID int64) er
 		slog.Int64("answer_id", answerID),
 		slog.Int64("user_id", userID),
 	)
+
+	answer, err := s.store.GetAnswerByID(ctx, answerID)
+	if err != nil {
+		// 点赞已经生效，只是无法发送事件和通知
+		logger.Warn("获取被点赞的回答失败",
+			slog.Int64("answer_id", answerID),
+			slog.String("error", err.Error()),
+		)
+		return nil
+	}
+
+	// 在请求内同步加入聚合器，保证随后的取消点赞一定能撤销这次通知
+	identity, _ := auth.FromContext(ctx)
+	if answer.UserID != userID {
+		// 给自己的回答点赞不发送通知
+		s.votes.Add(answer.ID, answer.QuestionID, answer.UserID, voter{ID: userID, Name: identity.Username})
+	}
+
+	// 发布点赞事件
+	go func() {
+		eventCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		s.publishVoteEvent(eventCtx, messaging.EventAnswerUpvoted, messaging.VotePayload{
+			AnswerID:   answer.ID,
+			QuestionID: answer.QuestionID,
+			AuthorID:   answer.UserID,
+			VoterID:    userID,
+			VoterName:  identity.Username,
+			CreatedAt:  time.Now(),
+		})
+	}()
 	return nil
 }
 
+// notifyAnswerUpvoted 在聚合窗口结束时给回答作者发送一条合并后的点赞通知
+func (s *qaService) notifyAnswerUpvoted(p *pendingVotes) {
+	notifyCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	latest := p.Voters[len(p.Voters)-1]
+	params := map[string]string{messaging.NotificationParamSender: latest.Name}
+	if len(p.Voters) > 1 {
+		params[messaging.NotificationParamCount] = strconv.Itoa(len(p.Voters))
+	}
+	s.publishNotificationEvent(notifyCtx, messaging.NotificationPayload{
+		RecipientID:      p.RecipientID,
+		SenderID:         latest.ID,
+		SenderName:       latest.Name,
+		NotificationType: messaging.NotificationTypeUpvote,
+		TargetURL:        fmt.Sprintf("/questions/%d#answer-%d", p.QuestionID, p.AnswerID),
+		Params:           params,
+	})
+}
+
+// DownvoteAnswer 点踩回答，点踩不影响点赞数，也不通知回答作者
 func (s *qaService) DownvoteAnswer(ctx context.Context, answerID, userID int64) error {
 	logger := log.FromContext(ctx)
-	
+
+	answer, err := s.store.GetAnswerByID(ctx, answerID)
+	if err != nil {
+		logger.Error("获取回答失败",
+			slog.Int64("answer_id", answerID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	if err := s.store.CreateAnswerVote(ctx, answerID, userID, false); err != nil {
+		logger.Error("点踩回答失败",
+			slog.Int64("answer_id", answerID),
+			slog.Int64("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	logger.Debug("回答被点踩",
+		slog.Int64("answer_id", answerID),
+		slog.Int64("user_id", userID),
+	)
+
+	// 发布点踩事件，供声望服务扣除回答作者的积分
+	go func() {
+		eventCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		s.publishVoteEvent(eventCtx, messaging.EventAnswerDownvoted, messaging.VotePayload{
+			AnswerID:   answer.ID,
+			QuestionID: answer.QuestionID,
+			AuthorID:   answer.UserID,
+			VoterID:    userID,
+			CreatedAt:  time.Now(),
+		})
+	}()
+	return nil
+}
+
+// RetractAnswerVote 撤销用户对回答的点赞或点踩
+func (s *qaService) RetractAnswerVote(ctx context.Context, answerID, userID int64) error {
+	logger := log.FromContext(ctx)
+
+	var isUpvote bool
 	err := s.store.ExecTx(ctx, func(tx store.QAStore) error {
-		err := tx.DeleteAnswerVote(ctx, answerID, userID)
+		var err error
+		isUpvote, err = tx.GetAnswerVoteForUpdate(ctx, answerID, userID)
 		if err != nil {
-			return err
+			return notFoundAs(err, ErrVoteNotFound)
 		}
-		err = tx.DecrementAnswerUpvoteCount(ctx, answerID)
-		if err != nil {
+		if err := tx.DeleteAnswerVote(ctx, answerID, userID); err != nil {
 			return err
 		}
-		return nil
+		if !isUpvote {
+			return nil
+		}
+		return tx.DecrementAnswerUpvoteCount(ctx, answerID)
 	})
-	
+
 	if err != nil {
-		logger.Error("取消点赞回答失败",
+		logger.Error("撤销投票失败",
 			slog.Int64("answer_id", answerID),
 			slog.Int64("user_id", userID),
 			slog.String("error", err.Error()),
 		)
 		return err
 	}
-	
-	logger.Debug("取消对回答的点赞",
+
+	logger.Debug("撤销对回答的投票",
+		slog.Int64("answer_id", answerID),
+		slog.Int64("user_id", userID),
+		slog.Bool("is_upvote", isUpvote),
+	)
+	if isUpvote {
+		// 撤销的点赞不触发任何通知，窗口内尚未发出的也一并移除
+		s.votes.Remove(answerID, userID)
+	}
+
+	// 发布撤销投票事件，供声望等下游服务撤销对应的积分
+	go func() {
+		eventCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		answer, err := s.store.GetAnswerByID(eventCtx, answerID)
+		if err != nil {
+			return
+		}
+		s.publishVoteEvent(eventCtx, messaging.EventAnswerVoteRetracted, messaging.VotePayload{
+			AnswerID:   answer.ID,
+			QuestionID: answer.QuestionID,
+			AuthorID:   answer.UserID,
+			VoterID:    userID,
+			IsUpvote:   isUpvote,
+			CreatedAt:  time.Now(),
+		})
+	}()
+	return nil
+}
+
+func (s *qaService) AcceptAnswer(ctx context.Context, answerID, userID int64) error {
+	logger := log.FromContext(ctx)
+
+	answer, err := s.store.GetAnswerByID(ctx, answerID)
+	if err != nil {
+		logger.Error("获取回答失败",
+			slog.Int64("answer_id", answerID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+	question, err := s.store.GetQuestionByID(ctx, answer.QuestionID)
+	if err != nil {
+		logger.Error("获取问题失败",
+			slog.Int64("question_id", answer.QuestionID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+	if question.UserID != userID {
+		logger.Warn("无权限采纳回答",
+			slog.Int64("answer_id", answerID),
+			slog.Int64("user_id", userID),
+			slog.Int64("owner_id", question.UserID),
+		)
+		return ErrNotQuestionOwner
+	}
+	if question.AcceptedAnswerID != 0 {
+		return ErrAnswerAlreadyAccepted
+	}
+
+	accepted, err := s.store.SetAcceptedAnswer(ctx, question.ID, answerID)
+	if err != nil {
+		logger.Error("采纳回答失败",
+			slog.Int64("answer_id", answerID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+	if !accepted {
+		// 并发采纳时以先写入者为准
+		return ErrAnswerAlreadyAccepted
+	}
+
+	logger.Info("回答被采纳",
 		slog.Int64("answer_id", answerID),
+		slog.Int64("question_id", question.ID),
 		slog.Int64("user_id", userID),
 	)
+
+	go s.publishAnswerEvent(context.Background(), messaging.EventAnswerAccepted, messaging.AnswerPayload{
+		ID:               answer.ID,
+		QuestionID:       question.ID,
+		QuestionAuthorID: question.UserID,
+		AuthorID:         answer.UserID,
+		CreatedAt:        time.Now(),
+	})
 	return nil
 }
 
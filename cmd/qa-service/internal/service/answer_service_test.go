@@ -2,6 +2,7 @@ package service_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 
@@ -22,7 +23,7 @@ func TestCreateAnswer(t *testing.T) {
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 
 	t.Run("成功创建回答", func(t *testing.T) {
 		questionID := int64(1)
@@ -37,6 +38,14 @@ func TestCreateAnswer(t *testing.T) {
 		}
 		ctx := auth.WithIdentity(context.Background(), identity)
 
+		// Mock: 执行事务
+		mockStore.EXPECT().
+			ExecTx(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(store.QAStore) error) error {
+				return fn(mockStore)
+			}).
+			Times(1)
+
 		// Mock: 创建回答成功
 		mockStore.EXPECT().
 			CreateAnswer(ctx, gomock.Any()).
@@ -48,6 +57,16 @@ func TestCreateAnswer(t *testing.T) {
 			}).
 			Times(1)
 
+		// Mock: 回答数加一并刷新问题的最后活跃时间
+		mockStore.EXPECT().
+			AdjustQuestionCounters(ctx, questionID, int64(1), int64(0)).
+			Return(nil).
+			Times(1)
+		mockStore.EXPECT().
+			TouchQuestionActivity(ctx, questionID).
+			Return(nil).
+			Times(1)
+
 		// Mock: 获取问题（用于通知）- 这是异步的，可能不会被调用
 		mockStore.EXPECT().
 			GetQuestionByID(gomock.Any(), questionID).
@@ -58,7 +77,7 @@ func TestCreateAnswer(t *testing.T) {
 			AnyTimes()
 
 		// 执行测试
-		result, err := qaService.CreateAnswer(ctx, questionID, content, userID)
+		result, err := qaService.CreateAnswer(ctx, questionID, content, false, userID)
 
 		// 验证结果
 		assert.NoError(t, err)
@@ -73,7 +92,7 @@ func TestCreateAnswer(t *testing.T) {
 		ctx := context.Background() // 没有用户身份信息
 
 		// 执行测试
-		result, err := qaService.CreateAnswer(ctx, 1, "内容", 100)
+		result, err := qaService.CreateAnswer(ctx, 1, "内容", false, 100)
 
 		// 验证结果
 		assert.Error(t, err)
@@ -92,6 +111,14 @@ func TestCreateAnswer(t *testing.T) {
 		}
 		ctx := auth.WithIdentity(context.Background(), identity)
 
+		// Mock: 执行事务
+		mockStore.EXPECT().
+			ExecTx(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(store.QAStore) error) error {
+				return fn(mockStore)
+			}).
+			Times(1)
+
 		// Mock: 数据库错误
 		mockStore.EXPECT().
 			CreateAnswer(ctx, gomock.Any()).
@@ -99,7 +126,7 @@ func TestCreateAnswer(t *testing.T) {
 			Times(1)
 
 		// 执行测试
-		result, err := qaService.CreateAnswer(ctx, questionID, content, userID)
+		result, err := qaService.CreateAnswer(ctx, questionID, content, false, userID)
 
 		// 验证结果
 		assert.Error(t, err)
@@ -114,7 +141,7 @@ func TestGetAnswer(t *testing.T) {
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 	ctx := context.Background()
 
 	t.Run("成功获取回答", func(t *testing.T) {
@@ -166,7 +193,7 @@ func TestListAnswers(t *testing.T) {
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 	ctx := context.Background()
 
 	t.Run("成功获取回答列表", func(t *testing.T) {
@@ -197,6 +224,14 @@ func TestListAnswers(t *testing.T) {
 			GetUsernamesByIDs(ctx, gomock.Any()).
 			Return(map[int64]string{100: "user1", 101: "user2"}, nil).
 			Times(1)
+		mockStore.EXPECT().
+			GetUserTypesByIDs(ctx, gomock.Any()).
+			Return(map[int64]string{100: auth.UserTypeHuman, 101: auth.UserTypeHuman}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserAvatarsByIDs(ctx, gomock.Any()).
+			Return(map[int64]string{}, nil).
+			Times(1)
 
 		// Mock: 获取用户投票信息
 		mockStore.EXPECT().
@@ -215,6 +250,51 @@ func TestListAnswers(t *testing.T) {
 		assert.True(t, results[0].IsUpvotedByUser)
 	})
 
+	t.Run("匿名回答只对作者本人显示作者信息", func(t *testing.T) {
+		questionID := int64(2)
+		pageSize := int32(10)
+		viewerCtx := auth.WithIdentity(ctx, auth.Identity{UserID: 100})
+
+		answers := []*model.Answer{
+			{ID: 3, QuestionID: questionID, Content: "自己的匿名回答", UserID: 100, IsAnonymous: true},
+			{ID: 4, QuestionID: questionID, Content: "他人的匿名回答", UserID: 101, IsAnonymous: true},
+		}
+
+		mockStore.EXPECT().
+			ListAnswersByQuestionID(viewerCtx, questionID, int64(0), pageSize).
+			Return(answers, nil).
+			Times(1)
+		mockStore.EXPECT().
+			CountAnswersByQuestionID(viewerCtx, questionID).
+			Return(int64(2), nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUsernamesByIDs(viewerCtx, gomock.Any()).
+			Return(map[int64]string{100: "user1", 101: "user2"}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserTypesByIDs(viewerCtx, gomock.Any()).
+			Return(map[int64]string{100: auth.UserTypeHuman, 101: auth.UserTypeHuman}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserAvatarsByIDs(viewerCtx, gomock.Any()).
+			Return(map[int64]string{}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserVotesForAnswers(viewerCtx, int64(100), gomock.Any()).
+			Return(map[int64]bool{}, nil).
+			Times(1)
+
+		results, _, err := qaService.ListAnswers(viewerCtx, questionID, 1, pageSize, 100)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, int64(100), results[0].UserID)
+		assert.Equal(t, "user1", results[0].Username)
+		assert.Zero(t, results[1].UserID)
+		assert.Equal(t, service.AnonymousAuthorName, results[1].Username)
+	})
+
 	t.Run("空回答列表", func(t *testing.T) {
 		questionID := int64(1)
 		userID := int64(100)
@@ -243,13 +323,78 @@ func TestListAnswers(t *testing.T) {
 	})
 }
 
+func TestBatchGetAnswers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
+	ctx := context.Background()
+
+	t.Run("按请求顺序返回并报告不存在的ID", func(t *testing.T) {
+		userID := int64(100)
+
+		mockStore.EXPECT().
+			GetAnswersByIDs(ctx, []int64{20, 10, 30}).
+			Return([]*model.Answer{
+				{ID: 10, QuestionID: 1, UserID: 200},
+				{ID: 20, QuestionID: 1, UserID: 201},
+			}, nil).
+			Times(1)
+
+		mockStore.EXPECT().
+			GetUsernamesByIDs(ctx, gomock.Any()).
+			Return(map[int64]string{200: "user1", 201: "user2"}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserTypesByIDs(ctx, gomock.Any()).
+			Return(map[int64]string{200: auth.UserTypeHuman, 201: auth.UserTypeHuman}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserAvatarsByIDs(ctx, gomock.Any()).
+			Return(map[int64]string{}, nil).
+			Times(1)
+
+		mockStore.EXPECT().
+			GetUserVotesForAnswers(ctx, userID, []int64{20, 10}).
+			Return(map[int64]bool{10: true}, nil).
+			Times(1)
+
+		// 执行测试
+		result, notFound, err := qaService.BatchGetAnswers(ctx, []int64{20, 10, 30}, userID)
+
+		// 验证结果
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, int64(20), result[0].ID)
+		assert.Equal(t, "user2", result[0].Username)
+		assert.False(t, result[0].IsUpvotedByUser)
+		assert.Equal(t, int64(10), result[1].ID)
+		assert.True(t, result[1].IsUpvotedByUser)
+		assert.Equal(t, []int64{30}, notFound)
+	})
+
+	t.Run("数据库错误", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetAnswersByIDs(ctx, []int64{10}).
+			Return(nil, errors.New("database error")).
+			Times(1)
+
+		result, _, err := qaService.BatchGetAnswers(ctx, []int64{10}, 0)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
 func TestUpvoteAnswer(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 	ctx := context.Background()
 
 	t.Run("成功点赞回答", func(t *testing.T) {
@@ -277,6 +422,12 @@ func TestUpvoteAnswer(t *testing.T) {
 			Return(nil).
 			Times(1)
 
+		// Mock: 获取回答（用于点赞事件和通知）
+		mockStore.EXPECT().
+			GetAnswerByID(ctx, answerID).
+			Return(&model.Answer{ID: answerID, QuestionID: 1, UserID: 999}, nil).
+			Times(1)
+
 		// 执行测试
 		err := qaService.UpvoteAnswer(ctx, answerID, userID)
 
@@ -309,39 +460,87 @@ func TestDownvoteAnswer(t *testing.T) {
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 	ctx := context.Background()
 
-	t.Run("成功取消点赞", func(t *testing.T) {
+	t.Run("点踩只记录投票，不改变点赞数", func(t *testing.T) {
 		answerID := int64(200)
 		userID := int64(100)
 
-		// Mock: 执行事务
 		mockStore.EXPECT().
-			ExecTx(ctx, gomock.Any()).
-			DoAndReturn(func(ctx context.Context, fn func(store.QAStore) error) error {
-				return fn(mockStore)
-			}).
+			GetAnswerByID(ctx, answerID).
+			Return(&model.Answer{ID: answerID, QuestionID: 1, UserID: 300}, nil).
 			Times(1)
 
-		// Mock: 删除投票记录
+		// Mock: 写入点踩记录，不应调用 IncrementAnswerUpvoteCount / DecrementAnswerUpvoteCount
 		mockStore.EXPECT().
-			DeleteAnswerVote(ctx, answerID, userID).
+			CreateAnswerVote(ctx, answerID, userID, false).
 			Return(nil).
 			Times(1)
 
-		// Mock: 减少点赞数
+		err := qaService.DownvoteAnswer(ctx, answerID, userID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("回答不存在", func(t *testing.T) {
 		mockStore.EXPECT().
-			DecrementAnswerUpvoteCount(ctx, answerID).
-			Return(nil).
+			GetAnswerByID(ctx, int64(404)).
+			Return(nil, sql.ErrNoRows).
 			Times(1)
 
-		// 执行测试
-		err := qaService.DownvoteAnswer(ctx, answerID, userID)
+		err := qaService.DownvoteAnswer(ctx, 404, 100)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
 
-		// 验证结果
+func TestRetractAnswerVote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
+	ctx := context.Background()
+
+	answerID := int64(200)
+	userID := int64(100)
+
+	mockStore.EXPECT().
+		ExecTx(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(store.QAStore) error) error {
+			return fn(mockStore)
+		}).
+		AnyTimes()
+
+	// Mock: 异步发布撤销投票事件时获取回答
+	mockStore.EXPECT().
+		GetAnswerByID(gomock.Any(), answerID).
+		Return(&model.Answer{ID: answerID, QuestionID: 1, UserID: 300}, nil).
+		AnyTimes()
+
+	t.Run("撤销点赞时减少点赞数", func(t *testing.T) {
+		mockStore.EXPECT().GetAnswerVoteForUpdate(ctx, answerID, userID).Return(true, nil).Times(1)
+		mockStore.EXPECT().DeleteAnswerVote(ctx, answerID, userID).Return(nil).Times(1)
+		mockStore.EXPECT().DecrementAnswerUpvoteCount(ctx, answerID).Return(nil).Times(1)
+
+		err := qaService.RetractAnswerVote(ctx, answerID, userID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("撤销点踩时点赞数不变", func(t *testing.T) {
+		mockStore.EXPECT().GetAnswerVoteForUpdate(ctx, answerID, userID).Return(false, nil).Times(1)
+		mockStore.EXPECT().DeleteAnswerVote(ctx, answerID, userID).Return(nil).Times(1)
+
+		err := qaService.RetractAnswerVote(ctx, answerID, userID)
 		assert.NoError(t, err)
 	})
+
+	t.Run("没有投票时返回 ErrVoteNotFound", func(t *testing.T) {
+		mockStore.EXPECT().GetAnswerVoteForUpdate(ctx, answerID, userID).Return(false, sql.ErrNoRows).Times(1)
+
+		err := qaService.RetractAnswerVote(ctx, answerID, userID)
+		assert.ErrorIs(t, err, service.ErrVoteNotFound)
+	})
 }
 
 func TestUpdateAnswer(t *testing.T) {
@@ -350,7 +549,7 @@ func TestUpdateAnswer(t *testing.T) {
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 	ctx := context.Background()
 
 	t.Run("成功更新回答", func(t *testing.T) {
@@ -371,6 +570,14 @@ func TestUpdateAnswer(t *testing.T) {
 			Return(existingAnswer, nil).
 			Times(1)
 
+		// Mock: 执行事务
+		mockStore.EXPECT().
+			ExecTx(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(store.QAStore) error) error {
+				return fn(mockStore)
+			}).
+			Times(1)
+
 		// Mock: 更新回答
 		mockStore.EXPECT().
 			UpdateAnswer(ctx, gomock.Any()).
@@ -381,6 +588,12 @@ func TestUpdateAnswer(t *testing.T) {
 			}).
 			Times(1)
 
+		// Mock: 刷新问题的最后活跃时间
+		mockStore.EXPECT().
+			TouchQuestionActivity(ctx, int64(1)).
+			Return(nil).
+			Times(1)
+
 		// 执行测试
 		result, err := qaService.UpdateAnswer(ctx, answerID, newContent, userID)
 
@@ -424,7 +637,7 @@ func TestDeleteAnswer(t *testing.T) {
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 	ctx := context.Background()
 
 	t.Run("成功删除回答", func(t *testing.T) {
@@ -443,12 +656,38 @@ func TestDeleteAnswer(t *testing.T) {
 			Return(answer, nil).
 			Times(1)
 
+		// Mock: 执行事务
+		mockStore.EXPECT().
+			ExecTx(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(store.QAStore) error) error {
+				return fn(mockStore)
+			}).
+			Times(1)
+
+		// Mock: 清除采纳记录
+		mockStore.EXPECT().
+			ClearAcceptedAnswer(ctx, answerID).
+			Return(nil).
+			Times(1)
+
+		// Mock: 统计回答下的评论数
+		mockStore.EXPECT().
+			CountCommentsByAnswerID(ctx, answerID).
+			Return(int64(2), nil).
+			Times(1)
+
 		// Mock: 删除回答
 		mockStore.EXPECT().
 			DeleteAnswer(ctx, answerID).
 			Return(nil).
 			Times(1)
 
+		// Mock: 回答数和评论数随之扣减
+		mockStore.EXPECT().
+			AdjustQuestionCounters(ctx, int64(1), int64(-1), int64(-2)).
+			Return(nil).
+			Times(1)
+
 		// 执行测试
 		err := qaService.DeleteAnswer(ctx, answerID, userID)
 
@@ -498,3 +737,67 @@ func TestDeleteAnswer(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestAcceptAnswer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
+	ctx := context.Background()
+
+	answerID := int64(200)
+	ownerID := int64(100)
+	answer := &model.Answer{ID: answerID, QuestionID: 1, UserID: 300}
+
+	t.Run("提问者成功采纳回答", func(t *testing.T) {
+		mockStore.EXPECT().GetAnswerByID(ctx, answerID).Return(answer, nil).Times(1)
+		mockStore.EXPECT().
+			GetQuestionByID(ctx, int64(1)).
+			Return(&model.Question{ID: 1, UserID: ownerID}, nil).
+			Times(1)
+		mockStore.EXPECT().SetAcceptedAnswer(ctx, int64(1), answerID).Return(true, nil).Times(1)
+
+		err := qaService.AcceptAnswer(ctx, answerID, ownerID)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("非提问者无权采纳", func(t *testing.T) {
+		mockStore.EXPECT().GetAnswerByID(ctx, answerID).Return(answer, nil).Times(1)
+		mockStore.EXPECT().
+			GetQuestionByID(ctx, int64(1)).
+			Return(&model.Question{ID: 1, UserID: ownerID}, nil).
+			Times(1)
+
+		err := qaService.AcceptAnswer(ctx, answerID, int64(999))
+
+		assert.ErrorIs(t, err, service.ErrNotQuestionOwner)
+	})
+
+	t.Run("问题已采纳过回答", func(t *testing.T) {
+		mockStore.EXPECT().GetAnswerByID(ctx, answerID).Return(answer, nil).Times(1)
+		mockStore.EXPECT().
+			GetQuestionByID(ctx, int64(1)).
+			Return(&model.Question{ID: 1, UserID: ownerID, AcceptedAnswerID: 201}, nil).
+			Times(1)
+
+		err := qaService.AcceptAnswer(ctx, answerID, ownerID)
+
+		assert.ErrorIs(t, err, service.ErrAnswerAlreadyAccepted)
+	})
+
+	t.Run("并发采纳时未能写入", func(t *testing.T) {
+		mockStore.EXPECT().GetAnswerByID(ctx, answerID).Return(answer, nil).Times(1)
+		mockStore.EXPECT().
+			GetQuestionByID(ctx, int64(1)).
+			Return(&model.Question{ID: 1, UserID: ownerID}, nil).
+			Times(1)
+		mockStore.EXPECT().SetAcceptedAnswer(ctx, int64(1), answerID).Return(false, nil).Times(1)
+
+		err := qaService.AcceptAnswer(ctx, answerID, ownerID)
+
+		assert.ErrorIs(t, err, service.ErrAnswerAlreadyAccepted)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"qahub/pkg/pagination"
 	"qahub/qa-service/internal/dto"
 	"qahub/qa-service/internal/model"
+	"qahub/qa-service/internal/store"
 	"time"
 )
 
@@ -35,7 +36,28 @@ func (s *qaService) CreateComment(ctx context.Context, answerID int64, content s
 		return nil, errors.New("user identity not found in context")
 	}
 
-	commentID, err := s.store.CreateComment(ctx, comment)
+	// 评论数按问题统计，需要先找到回答所属的问题
+	answer, err := s.store.GetAnswerByID(ctx, answerID)
+	if err != nil {
+		logger.Error("获取回答失败",
+			slog.Int64("answer_id", answerID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	var commentID int64
+	err = s.store.ExecTx(ctx, func(tx store.QAStore) error {
+		id, err := tx.CreateComment(ctx, comment)
+		if err != nil {
+			return err
+		}
+		commentID = id
+		if err := tx.AdjustQuestionCounters(ctx, answer.QuestionID, 0, 1); err != nil {
+			return err
+		}
+		return tx.TouchQuestionActivity(ctx, answer.QuestionID)
+	})
 	if err != nil {
 		logger.Error("创建评论失败",
 			slog.Int64("answer_id", answerID),
@@ -80,8 +102,11 @@ func (s *qaService) CreateComment(ctx context.Context, answerID int64, content s
 			SenderID:         newComment.UserID,
 			SenderName:       senderUsername,
 			NotificationType: messaging.NotificationTypeNewComment,
-			Content:          fmt.Sprintf("'%s' 评论了你的答案: '%s'", senderUsername, newComment.Content),
 			TargetURL:        fmt.Sprintf("/questions/%d#comment-%d", answer.QuestionID, newComment.ID),
+			Params: map[string]string{
+				messaging.NotificationParamSender:  senderUsername,
+				messaging.NotificationParamExcerpt: newComment.Content,
+			},
 		}
 		s.publishNotificationEvent(notifyCtx, notificationPayload)
 	}(identity.Username, *comment)
@@ -123,12 +148,22 @@ func (s *qaService) ListComments(ctx context.Context, answerID int64, page int64
 	if err != nil {
 		return nil, 0, err
 	}
+	userTypes, err := s.store.GetUserTypesByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+	avatars, err := s.store.GetUserAvatarsByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	responses := make([]*dto.CommentResponse, len(comments))
 	for i, comment := range comments {
 		responses[i] = &dto.CommentResponse{
-			Comment:  *comment,
-			Username: usernames[comment.UserID],
+			Comment:         *comment,
+			Username:        usernames[comment.UserID],
+			AuthorIsBot:     userTypes[comment.UserID] == auth.UserTypeBot,
+			AuthorAvatarURL: authorAvatarURL(avatars[comment.UserID]),
 		}
 	}
 
@@ -192,7 +227,21 @@ func (s *qaService) DeleteComment(ctx context.Context, commentID, userID int64)
 		return errors.New("无权限删除该评论")
 	}
 	
-	err = s.store.DeleteComment(ctx, commentID)
+	answer, err := s.store.GetAnswerByID(ctx, comment.AnswerID)
+	if err != nil {
+		logger.Error("获取回答失败",
+			slog.Int64("answer_id", comment.AnswerID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	err = s.store.ExecTx(ctx, func(tx store.QAStore) error {
+		if err := tx.DeleteComment(ctx, commentID); err != nil {
+			return err
+		}
+		return tx.AdjustQuestionCounters(ctx, answer.QuestionID, 0, -1)
+	})
 	if err != nil {
 		logger.Error("删除评论失败",
 			slog.Int64("comment_id", commentID),
@@ -10,6 +10,7 @@ import (
 	"qahub/pkg/messaging"
 	"qahub/qa-service/internal/model"
 	"qahub/qa-service/internal/service"
+	"qahub/qa-service/internal/store"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -21,7 +22,7 @@ func TestCreateComment(t *testing.T) {
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 
 	t.Run("成功创建评论", func(t *testing.T) {
 		answerID := int64(200)
@@ -36,6 +37,14 @@ func TestCreateComment(t *testing.T) {
 		}
 		ctx := auth.WithIdentity(context.Background(), identity)
 
+		// Mock: 执行事务
+		mockStore.EXPECT().
+			ExecTx(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(store.QAStore) error) error {
+				return fn(mockStore)
+			}).
+			Times(1)
+
 		// Mock: 创建评论成功
 		mockStore.EXPECT().
 			CreateComment(ctx, gomock.Any()).
@@ -47,7 +56,17 @@ func TestCreateComment(t *testing.T) {
 			}).
 			Times(1)
 
-		// Mock: 获取回答（用于通知）- 这是异步的，可能不会被调用
+		// Mock: 评论数加一并刷新问题的最后活跃时间
+		mockStore.EXPECT().
+			AdjustQuestionCounters(ctx, int64(1), int64(0), int64(1)).
+			Return(nil).
+			Times(1)
+		mockStore.EXPECT().
+			TouchQuestionActivity(ctx, int64(1)).
+			Return(nil).
+			Times(1)
+
+		// Mock: 获取回答（用于计数和通知）- 通知是异步的
 		mockStore.EXPECT().
 			GetAnswerByID(gomock.Any(), answerID).
 			Return(&model.Answer{
@@ -92,6 +111,14 @@ func TestCreateComment(t *testing.T) {
 		}
 		ctx := auth.WithIdentity(context.Background(), identity)
 
+		// Mock: 执行事务
+		mockStore.EXPECT().
+			ExecTx(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(store.QAStore) error) error {
+				return fn(mockStore)
+			}).
+			Times(1)
+
 		// Mock: 数据库错误
 		mockStore.EXPECT().
 			CreateComment(ctx, gomock.Any()).
@@ -114,7 +141,7 @@ func TestGetComment(t *testing.T) {
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 	ctx := context.Background()
 
 	t.Run("成功获取评论", func(t *testing.T) {
@@ -166,7 +193,7 @@ func TestListComments(t *testing.T) {
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 	ctx := context.Background()
 
 	t.Run("成功获取评论列表", func(t *testing.T) {
@@ -196,6 +223,14 @@ func TestListComments(t *testing.T) {
 			GetUsernamesByIDs(ctx, gomock.Any()).
 			Return(map[int64]string{100: "user1", 101: "user2"}, nil).
 			Times(1)
+		mockStore.EXPECT().
+			GetUserTypesByIDs(ctx, gomock.Any()).
+			Return(map[int64]string{100: auth.UserTypeHuman, 101: auth.UserTypeHuman}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserAvatarsByIDs(ctx, gomock.Any()).
+			Return(map[int64]string{}, nil).
+			Times(1)
 
 		// 执行测试
 		results, total, err := qaService.ListComments(ctx, answerID, page, pageSize)
@@ -261,7 +296,7 @@ func TestUpdateComment(t *testing.T) {
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 	ctx := context.Background()
 
 	t.Run("成功更新评论", func(t *testing.T) {
@@ -353,7 +388,7 @@ func TestDeleteComment(t *testing.T) {
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 	ctx := context.Background()
 
 	t.Run("成功删除评论", func(t *testing.T) {
@@ -372,12 +407,32 @@ func TestDeleteComment(t *testing.T) {
 			Return(comment, nil).
 			Times(1)
 
+		// Mock: 获取评论所属的回答
+		mockStore.EXPECT().
+			GetAnswerByID(ctx, int64(200)).
+			Return(&model.Answer{ID: 200, QuestionID: 1}, nil).
+			Times(1)
+
+		// Mock: 执行事务
+		mockStore.EXPECT().
+			ExecTx(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(store.QAStore) error) error {
+				return fn(mockStore)
+			}).
+			Times(1)
+
 		// Mock: 删除评论
 		mockStore.EXPECT().
 			DeleteComment(ctx, commentID).
 			Return(nil).
 			Times(1)
 
+		// Mock: 评论数减一
+		mockStore.EXPECT().
+			AdjustQuestionCounters(ctx, int64(1), int64(0), int64(-1)).
+			Return(nil).
+			Times(1)
+
 		// 执行测试
 		err := qaService.DeleteComment(ctx, commentID, userID)
 
@@ -443,6 +498,20 @@ func TestDeleteComment(t *testing.T) {
 			Return(comment, nil).
 			Times(1)
 
+		// Mock: 获取评论所属的回答
+		mockStore.EXPECT().
+			GetAnswerByID(ctx, int64(200)).
+			Return(&model.Answer{ID: 200, QuestionID: 1}, nil).
+			Times(1)
+
+		// Mock: 执行事务
+		mockStore.EXPECT().
+			ExecTx(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(store.QAStore) error) error {
+				return fn(mockStore)
+			}).
+			Times(1)
+
 		// Mock: 删除失败
 		mockStore.EXPECT().
 			DeleteComment(ctx, commentID).
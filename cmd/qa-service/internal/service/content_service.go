@@ -0,0 +1,483 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	userpb "qahub/api/proto/user"
+	"qahub/pkg/auth"
+	"qahub/pkg/log"
+	"qahub/pkg/messaging"
+	"qahub/qa-service/internal/dto"
+	"qahub/qa-service/internal/model"
+	"qahub/qa-service/internal/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// exportBatchSize 是导出时每次从数据库读取的记录数
+const exportBatchSize = 500
+
+var (
+	// ErrContentNotEmpty 表示目标实例已有问答内容，导入只允许在空实例上进行
+	ErrContentNotEmpty = errors.New("目标实例已有问答内容，只能导入到空实例")
+	// ErrMissingContentHeader 表示导入数据的第一条记录不是文件头
+	ErrMissingContentHeader = errors.New("导入数据缺少文件头")
+	// ErrUnsupportedContentVersion 表示导入数据的格式版本不受支持
+	ErrUnsupportedContentVersion = fmt.Errorf("导入数据的格式版本不受支持，当前支持版本 %d 至 %d", model.MinContentFormatVersion, model.ContentFormatVersion)
+	// ErrInvalidContentRecord 表示导入数据中存在空记录或重复的文件头
+	ErrInvalidContentRecord = errors.New("导入数据中存在无效记录")
+	// ErrDanglingReference 表示导入的记录引用了此前没有出现过的记录
+	ErrDanglingReference = errors.New("导入数据引用了不存在的记录")
+	// ErrInvalidUserRecord 表示导入数据中的用户没有通过用户服务的校验
+	ErrInvalidUserRecord = errors.New("导入数据中存在无效的用户")
+)
+
+// ExportContent 按用户、问题、回答、评论、投票的顺序导出全部内容，第一条记录为文件头。
+// 导出在一个只读事务中进行，保证各类记录之间的引用一致。
+// 密码哈希只在 includePasswords 为 true 时导出，否则导入的用户需要通过找回密码设置新密码。
+func (s *qaService) ExportContent(ctx context.Context, includePasswords bool, emit func(*model.ContentRecord) error) error {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || !identity.IsAdmin() {
+		return ErrAdminRequired
+	}
+	logger := log.FromContext(ctx)
+
+	err := s.store.ExecTx(ctx, func(tx store.QAStore) error {
+		header := &model.ContentHeader{FormatVersion: model.ContentFormatVersion, ExportedAt: time.Now()}
+		if err := emit(&model.ContentRecord{Header: header}); err != nil {
+			return err
+		}
+		if err := exportBatches(ctx, tx.ListUsersForExport, func(u *model.ContentUser) (int64, *model.ContentRecord) {
+			if !includePasswords {
+				u.Password = ""
+			}
+			return u.ID, &model.ContentRecord{User: u}
+		}, emit); err != nil {
+			return err
+		}
+		if err := exportBatches(ctx, tx.ListQuestionsForExport, func(q *model.Question) (int64, *model.ContentRecord) {
+			return q.ID, &model.ContentRecord{Question: q}
+		}, emit); err != nil {
+			return err
+		}
+		if err := exportBatches(ctx, tx.ListAnswersForExport, func(a *model.Answer) (int64, *model.ContentRecord) {
+			return a.ID, &model.ContentRecord{Answer: a}
+		}, emit); err != nil {
+			return err
+		}
+		if err := exportBatches(ctx, tx.ListCommentsForExport, func(c *model.Comment) (int64, *model.ContentRecord) {
+			return c.ID, &model.ContentRecord{Comment: c}
+		}, emit); err != nil {
+			return err
+		}
+		return exportBatches(ctx, tx.ListVotesForExport, func(v *model.ContentVote) (int64, *model.ContentRecord) {
+			return v.ID, &model.ContentRecord{Vote: v}
+		}, emit)
+	})
+	if err != nil {
+		logger.Error("导出内容失败", slog.String("error", err.Error()))
+		return err
+	}
+	logger.Info("导出内容完成",
+		slog.Int64("admin_id", identity.UserID),
+		slog.Bool("include_password_hashes", includePasswords),
+	)
+	return nil
+}
+
+// exportBatches 按 ID 分批读取一类记录并逐条输出
+func exportBatches[T any](
+	ctx context.Context,
+	list func(ctx context.Context, afterID int64, limit int32) ([]T, error),
+	wrap func(T) (int64, *model.ContentRecord),
+	emit func(*model.ContentRecord) error,
+) error {
+	var afterID int64
+	for {
+		items, err := list(ctx, afterID, exportBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			id, record := wrap(item)
+			if err := emit(record); err != nil {
+				return err
+			}
+			afterID = id
+		}
+		if len(items) < exportBatchSize {
+			return nil
+		}
+	}
+}
+
+// ImportContent 将 ExportContent 导出的记录写入一个没有问答内容的实例。
+// 所有记录都会获得新的ID，引用关系按导入过程中建立的新旧ID映射改写。
+// 用户通过用户服务创建，由其负责校验、复用邮箱已存在的用户以及为冲突的用户名追加后缀；
+// 问答内容在一个事务中写入，任何一条记录出错都会整体回滚，但已创建的用户会保留，重新导入时按邮箱复用。
+// next 在没有更多记录时返回 io.EOF。
+func (s *qaService) ImportContent(ctx context.Context, next func() (*model.ContentRecord, error)) (*dto.ImportReport, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || !identity.IsAdmin() {
+		return nil, ErrAdminRequired
+	}
+	logger := log.FromContext(ctx)
+
+	var imp *contentImporter
+	err := s.store.ExecTx(ctx, func(tx store.QAStore) error {
+		hasContent, err := tx.HasQAContent(ctx)
+		if err != nil {
+			return err
+		}
+		if hasContent {
+			return ErrContentNotEmpty
+		}
+
+		first, err := next()
+		if errors.Is(err, io.EOF) || (err == nil && first.Header == nil) {
+			return ErrMissingContentHeader
+		}
+		if err != nil {
+			return err
+		}
+		version := first.Header.FormatVersion
+		if version < model.MinContentFormatVersion || version > model.ContentFormatVersion {
+			return ErrUnsupportedContentVersion
+		}
+
+		imp = newContentImporter(tx, s.users, version)
+		for {
+			record, err := next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return err
+			}
+			if err := imp.add(ctx, record); err != nil {
+				return err
+			}
+		}
+		return imp.finish(ctx)
+	})
+	if err != nil {
+		logger.Error("导入内容失败", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	logger.Info("导入内容完成",
+		slog.Int64("admin_id", identity.UserID),
+		slog.Int64("users", imp.report.Users),
+		slog.Int64("reused_users", imp.report.ReusedUsers),
+		slog.Int("renamed_users", len(imp.report.RenamedUsers)),
+		slog.Int64("users_without_password", imp.report.UsersWithoutPassword),
+		slog.Int64("questions", imp.report.Questions),
+		slog.Int64("answers", imp.report.Answers),
+		slog.Int64("comments", imp.report.Comments),
+		slog.Int64("votes", imp.report.Votes),
+	)
+	// 导入绕过了正常的写入路径，重新发布事件让搜索索引和声望与导入的内容保持一致。
+	// 导入的是历史内容，不触发任何通知。
+	go s.replayImportEvents(imp)
+	return imp.report, nil
+}
+
+// contentImporter 在一次导入中维护新旧ID的映射，以及导入完成后需要重放的事件
+type contentImporter struct {
+	tx       store.QAStore
+	importer UserImporter
+	version  int // 导入文件的格式版本
+	report   *dto.ImportReport
+
+	// 旧ID -> 新ID
+	users     map[int64]int64
+	questions map[int64]int64
+	answers   map[int64]int64
+
+	// 以下均使用新ID，用于维护计数和重放事件
+	importedQuestions map[int64]*model.Question
+	importedAnswers   map[int64]*model.Answer
+	questionOrder     []int64
+	answerOrder       []int64
+	votes             []*model.ContentVote
+	// acceptedAnswers 记录问题的原采纳回答ID（旧ID），回答全部导入后再设置
+	acceptedAnswers map[int64]int64
+	accepted        []int64
+}
+
+func newContentImporter(tx store.QAStore, importer UserImporter, version int) *contentImporter {
+	return &contentImporter{
+		tx:                tx,
+		importer:          importer,
+		version:           version,
+		report:            &dto.ImportReport{},
+		users:             make(map[int64]int64),
+		questions:         make(map[int64]int64),
+		answers:           make(map[int64]int64),
+		importedQuestions: make(map[int64]*model.Question),
+		importedAnswers:   make(map[int64]*model.Answer),
+		acceptedAnswers:   make(map[int64]int64),
+	}
+}
+
+func (imp *contentImporter) add(ctx context.Context, record *model.ContentRecord) error {
+	switch {
+	case record.User != nil:
+		return imp.addUser(ctx, record.User)
+	case record.Question != nil:
+		return imp.addQuestion(ctx, record.Question)
+	case record.Answer != nil:
+		return imp.addAnswer(ctx, record.Answer)
+	case record.Comment != nil:
+		return imp.addComment(ctx, record.Comment)
+	case record.Vote != nil:
+		return imp.addVote(ctx, record.Vote)
+	default:
+		return ErrInvalidContentRecord
+	}
+}
+
+func (imp *contentImporter) addUser(ctx context.Context, user *model.ContentUser) error {
+	req := &userpb.ImportUserRequest{
+		Username:     user.Username,
+		Email:        user.Email,
+		Bio:          user.Bio,
+		PasswordHash: user.Password,
+		Role:         user.Role,
+		UserType:     user.UserType,
+		Language:     user.Language,
+		AvatarKey:    user.AvatarKey,
+		CreatedAt:    timestamppb.New(user.CreatedAt),
+	}
+	verifiedAt := user.EmailVerifiedAt
+	if imp.version < 2 && verifiedAt == nil {
+		// 版本 1 的导出文件没有邮箱验证时间，与迁移 000017 对存量用户的处理一致，否则导入的用户无法发帖
+		verifiedAt = &user.CreatedAt
+	}
+	if verifiedAt != nil {
+		req.EmailVerifiedAt = timestamppb.New(*verifiedAt)
+	}
+
+	resp, err := imp.importer.ImportUser(ctx, req)
+	if err != nil {
+		// 被用户服务拒绝的记录指明是哪一个用户，其他错误（例如用户服务不可用）原样返回
+		if st, ok := status.FromError(err); ok && (st.Code() == codes.InvalidArgument || st.Code() == codes.AlreadyExists) {
+			return fmt.Errorf("%w: 用户 %d (%s): %s", ErrInvalidUserRecord, user.ID, user.Username, st.Message())
+		}
+		return fmt.Errorf("导入用户 %d 失败: %w", user.ID, err)
+	}
+	imp.users[user.ID] = resp.UserId
+	if resp.Reused {
+		imp.report.ReusedUsers++
+		return nil
+	}
+	imp.report.Users++
+	if user.Password == "" {
+		imp.report.UsersWithoutPassword++
+	}
+	if resp.Username != user.Username {
+		imp.report.RenamedUsers = append(imp.report.RenamedUsers, dto.RenamedUser{
+			OriginalID:       user.ID,
+			OriginalUsername: user.Username,
+			Username:         resp.Username,
+			UserID:           resp.UserId,
+		})
+	}
+	return nil
+}
+
+func (imp *contentImporter) addQuestion(ctx context.Context, question *model.Question) error {
+	userID, ok := imp.users[question.UserID]
+	if !ok {
+		return fmt.Errorf("%w: 问题 %d 的作者 %d", ErrDanglingReference, question.ID, question.UserID)
+	}
+	q := *question
+	q.UserID = userID
+	q.AcceptedAnswerID = 0
+	q.AnswerCount = 0
+	q.CommentCount = 0
+	newID, err := imp.tx.ImportQuestion(ctx, &q)
+	if err != nil {
+		return fmt.Errorf("导入问题 %d 失败: %w", question.ID, err)
+	}
+	q.ID = newID
+	imp.questions[question.ID] = newID
+	imp.importedQuestions[newID] = &q
+	imp.questionOrder = append(imp.questionOrder, newID)
+	if question.AcceptedAnswerID != 0 {
+		imp.acceptedAnswers[newID] = question.AcceptedAnswerID
+	}
+	imp.report.Questions++
+	return nil
+}
+
+func (imp *contentImporter) addAnswer(ctx context.Context, answer *model.Answer) error {
+	questionID, ok := imp.questions[answer.QuestionID]
+	if !ok {
+		return fmt.Errorf("%w: 回答 %d 所属的问题 %d", ErrDanglingReference, answer.ID, answer.QuestionID)
+	}
+	userID, ok := imp.users[answer.UserID]
+	if !ok {
+		return fmt.Errorf("%w: 回答 %d 的作者 %d", ErrDanglingReference, answer.ID, answer.UserID)
+	}
+	a := *answer
+	a.QuestionID = questionID
+	a.UserID = userID
+	a.UpvoteCount = 0
+	newID, err := imp.tx.ImportAnswer(ctx, &a)
+	if err != nil {
+		return fmt.Errorf("导入回答 %d 失败: %w", answer.ID, err)
+	}
+	if err := imp.tx.AdjustQuestionCounters(ctx, questionID, 1, 0); err != nil {
+		return err
+	}
+	a.ID = newID
+	imp.answers[answer.ID] = newID
+	imp.importedAnswers[newID] = &a
+	imp.answerOrder = append(imp.answerOrder, newID)
+	imp.report.Answers++
+	return nil
+}
+
+func (imp *contentImporter) addComment(ctx context.Context, comment *model.Comment) error {
+	answerID, ok := imp.answers[comment.AnswerID]
+	if !ok {
+		return fmt.Errorf("%w: 评论 %d 所属的回答 %d", ErrDanglingReference, comment.ID, comment.AnswerID)
+	}
+	userID, ok := imp.users[comment.UserID]
+	if !ok {
+		return fmt.Errorf("%w: 评论 %d 的作者 %d", ErrDanglingReference, comment.ID, comment.UserID)
+	}
+	c := *comment
+	c.AnswerID = answerID
+	c.UserID = userID
+	if _, err := imp.tx.ImportComment(ctx, &c); err != nil {
+		return fmt.Errorf("导入评论 %d 失败: %w", comment.ID, err)
+	}
+	if err := imp.tx.AdjustQuestionCounters(ctx, imp.importedAnswers[answerID].QuestionID, 0, 1); err != nil {
+		return err
+	}
+	imp.report.Comments++
+	return nil
+}
+
+func (imp *contentImporter) addVote(ctx context.Context, vote *model.ContentVote) error {
+	answerID, ok := imp.answers[vote.AnswerID]
+	if !ok {
+		return fmt.Errorf("%w: 投票 %d 所属的回答 %d", ErrDanglingReference, vote.ID, vote.AnswerID)
+	}
+	userID, ok := imp.users[vote.UserID]
+	if !ok {
+		return fmt.Errorf("%w: 投票 %d 的用户 %d", ErrDanglingReference, vote.ID, vote.UserID)
+	}
+	v := *vote
+	v.AnswerID = answerID
+	v.UserID = userID
+	if err := imp.tx.ImportVote(ctx, &v); err != nil {
+		return fmt.Errorf("导入投票 %d 失败: %w", vote.ID, err)
+	}
+	if v.IsUpvote {
+		if err := imp.tx.IncrementAnswerUpvoteCount(ctx, answerID); err != nil {
+			return err
+		}
+		imp.importedAnswers[answerID].UpvoteCount++
+	}
+	imp.votes = append(imp.votes, &v)
+	imp.report.Votes++
+	return nil
+}
+
+// finish 在所有回答导入之后设置问题的采纳回答
+func (imp *contentImporter) finish(ctx context.Context) error {
+	for _, questionID := range imp.questionOrder {
+		oldAnswerID, ok := imp.acceptedAnswers[questionID]
+		if !ok {
+			continue
+		}
+		answerID, ok := imp.answers[oldAnswerID]
+		if !ok || imp.importedAnswers[answerID].QuestionID != questionID {
+			return fmt.Errorf("%w: 问题 %d 采纳的回答 %d", ErrDanglingReference, questionID, oldAnswerID)
+		}
+		if _, err := imp.tx.SetAcceptedAnswer(ctx, questionID, answerID); err != nil {
+			return err
+		}
+		imp.importedQuestions[questionID].AcceptedAnswerID = answerID
+		imp.accepted = append(imp.accepted, questionID)
+	}
+	return nil
+}
+
+// replayImportEvents 为导入的内容重新发布创建、点赞和采纳事件
+func (s *qaService) replayImportEvents(imp *contentImporter) {
+	ctx := context.Background()
+	userIDs := make([]int64, 0, len(imp.users))
+	seen := make(map[int64]struct{}, len(imp.users))
+	for _, id := range imp.users {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		userIDs = append(userIDs, id)
+	}
+	usernames, err := s.store.GetUsernamesByIDs(ctx, userIDs)
+	if err != nil {
+		log.FromContext(ctx).Warn("获取导入用户的用户名失败，事件将不带作者名", slog.String("error", err.Error()))
+		usernames = map[int64]string{}
+	}
+
+	for _, questionID := range imp.questionOrder {
+		question := imp.importedQuestions[questionID]
+		authorCtx := auth.WithIdentity(ctx, auth.Identity{UserID: question.UserID, Username: usernames[question.UserID]})
+		s.publishQuestionEvent(authorCtx, messaging.EventQuestionCreated, question)
+	}
+	for _, answerID := range imp.answerOrder {
+		answer := imp.importedAnswers[answerID]
+		authorName := usernames[answer.UserID]
+		if answer.IsAnonymous {
+			authorName = ""
+		}
+		s.publishAnswerEvent(ctx, messaging.EventAnswerCreated, messaging.AnswerPayload{
+			ID:               answer.ID,
+			QuestionID:       answer.QuestionID,
+			QuestionAuthorID: imp.importedQuestions[answer.QuestionID].UserID,
+			Content:          answer.Content,
+			AuthorID:         answer.UserID,
+			AuthorName:       authorName,
+			Anonymous:        answer.IsAnonymous,
+			CreatedAt:        answer.CreatedAt,
+		})
+	}
+	for _, vote := range imp.votes {
+		answer := imp.importedAnswers[vote.AnswerID]
+		eventType := messaging.EventAnswerUpvoted
+		if !vote.IsUpvote {
+			eventType = messaging.EventAnswerDownvoted
+		}
+		s.publishVoteEvent(ctx, eventType, messaging.VotePayload{
+			AnswerID:   answer.ID,
+			QuestionID: answer.QuestionID,
+			AuthorID:   answer.UserID,
+			VoterID:    vote.UserID,
+			VoterName:  usernames[vote.UserID],
+			CreatedAt:  vote.CreatedAt,
+		})
+	}
+	for _, questionID := range imp.accepted {
+		question := imp.importedQuestions[questionID]
+		answer := imp.importedAnswers[question.AcceptedAnswerID]
+		s.publishAnswerEvent(ctx, messaging.EventAnswerAccepted, messaging.AnswerPayload{
+			ID:               answer.ID,
+			QuestionID:       question.ID,
+			QuestionAuthorID: question.UserID,
+			AuthorID:         answer.UserID,
+			CreatedAt:        answer.CreatedAt,
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package service_test
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	userpb "qahub/api/proto/user"
+	"qahub/pkg/auth"
+	"qahub/pkg/config"
+	"qahub/pkg/messaging"
+	"qahub/qa-service/internal/dto"
+	"qahub/qa-service/internal/model"
+	"qahub/qa-service/internal/service"
+	"qahub/qa-service/internal/store"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// userImporterFunc 让测试用普通函数代替用户服务的客户端
+type userImporterFunc func(ctx context.Context, req *userpb.ImportUserRequest) (*userpb.ImportUserResponse, error)
+
+func (f userImporterFunc) ImportUser(ctx context.Context, req *userpb.ImportUserRequest) (*userpb.ImportUserResponse, error) {
+	return f(ctx, req)
+}
+
+// recordSource 按顺序返回给定的记录，结束后返回 io.EOF
+func recordSource(records ...*model.ContentRecord) func() (*model.ContentRecord, error) {
+	return func() (*model.ContentRecord, error) {
+		if len(records) == 0 {
+			return nil, io.EOF
+		}
+		r := records[0]
+		records = records[1:]
+		return r, nil
+	}
+}
+
+func TestExportContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
+	adminCtx := auth.WithIdentity(context.Background(), auth.Identity{
+		UserID: 1,
+		Claims: map[string]any{"role": auth.RoleAdmin},
+	})
+
+	t.Run("非管理员无权导出", func(t *testing.T) {
+		ctx := auth.WithIdentity(context.Background(), auth.Identity{UserID: 2})
+
+		err := qaService.ExportContent(ctx, false, func(*model.ContentRecord) error { return nil })
+
+		assert.ErrorIs(t, err, service.ErrAdminRequired)
+	})
+
+	t.Run("按类型顺序导出且文件头在最前", func(t *testing.T) {
+		mockStore.EXPECT().
+			ExecTx(adminCtx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(store.QAStore) error) error {
+				return fn(mockStore)
+			}).
+			Times(1)
+		mockStore.EXPECT().
+			ListUsersForExport(adminCtx, int64(0), gomock.Any()).
+			Return([]*model.ContentUser{{ID: 1, Password: "hash"}, {ID: 2}}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			ListQuestionsForExport(adminCtx, int64(0), gomock.Any()).
+			Return([]*model.Question{{ID: 10, UserID: 1}}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			ListAnswersForExport(adminCtx, int64(0), gomock.Any()).
+			Return([]*model.Answer{{ID: 20, QuestionID: 10, UserID: 2}}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			ListCommentsForExport(adminCtx, int64(0), gomock.Any()).
+			Return([]*model.Comment{}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			ListVotesForExport(adminCtx, int64(0), gomock.Any()).
+			Return([]*model.ContentVote{{ID: 30, AnswerID: 20, UserID: 1, IsUpvote: true}}, nil).
+			Times(1)
+
+		var records []*model.ContentRecord
+		err := qaService.ExportContent(adminCtx, false, func(r *model.ContentRecord) error {
+			records = append(records, r)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, records, 6)
+		assert.Equal(t, model.ContentFormatVersion, records[0].Header.FormatVersion)
+		assert.Equal(t, int64(1), records[1].User.ID)
+		assert.Empty(t, records[1].User.Password, "默认不导出密码哈希")
+		assert.Equal(t, int64(2), records[2].User.ID)
+		assert.Equal(t, int64(10), records[3].Question.ID)
+		assert.Equal(t, int64(20), records[4].Answer.ID)
+		assert.Equal(t, int64(30), records[5].Vote.ID)
+	})
+
+	t.Run("指定后导出密码哈希", func(t *testing.T) {
+		mockStore.EXPECT().
+			ExecTx(adminCtx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(store.QAStore) error) error {
+				return fn(mockStore)
+			}).
+			Times(1)
+		mockStore.EXPECT().
+			ListUsersForExport(adminCtx, int64(0), gomock.Any()).
+			Return([]*model.ContentUser{{ID: 1, Password: "hash"}}, nil).
+			Times(1)
+		mockStore.EXPECT().ListQuestionsForExport(adminCtx, int64(0), gomock.Any()).Return([]*model.Question{}, nil).Times(1)
+		mockStore.EXPECT().ListAnswersForExport(adminCtx, int64(0), gomock.Any()).Return([]*model.Answer{}, nil).Times(1)
+		mockStore.EXPECT().ListCommentsForExport(adminCtx, int64(0), gomock.Any()).Return([]*model.Comment{}, nil).Times(1)
+		mockStore.EXPECT().ListVotesForExport(adminCtx, int64(0), gomock.Any()).Return([]*model.ContentVote{}, nil).Times(1)
+
+		var records []*model.ContentRecord
+		err := qaService.ExportContent(adminCtx, true, func(r *model.ContentRecord) error {
+			records = append(records, r)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, records, 2)
+		assert.Equal(t, "hash", records[1].User.Password)
+	})
+}
+
+func TestImportContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
+	// 每个子测试设置自己的 importUser 模拟用户服务
+	var importUser userImporterFunc
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, userImporterFunc(
+		func(ctx context.Context, req *userpb.ImportUserRequest) (*userpb.ImportUserResponse, error) {
+			return importUser(ctx, req)
+		},
+	))
+	adminCtx := auth.WithIdentity(context.Background(), auth.Identity{
+		UserID: 1,
+		Claims: map[string]any{"role": auth.RoleAdmin},
+	})
+	header := &model.ContentRecord{Header: &model.ContentHeader{FormatVersion: model.ContentFormatVersion}}
+
+	expectTx := func() {
+		mockStore.EXPECT().
+			ExecTx(adminCtx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(store.QAStore) error) error {
+				return fn(mockStore)
+			}).
+			Times(1)
+	}
+
+	// Mock: 导入完成后异步重放事件时获取用户名
+	mockStore.EXPECT().
+		GetUsernamesByIDs(gomock.Any(), gomock.Any()).
+		Return(map[int64]string{}, nil).
+		AnyTimes()
+
+	t.Run("非管理员无权导入", func(t *testing.T) {
+		ctx := auth.WithIdentity(context.Background(), auth.Identity{UserID: 2})
+
+		report, err := qaService.ImportContent(ctx, recordSource(header))
+
+		assert.ErrorIs(t, err, service.ErrAdminRequired)
+		assert.Nil(t, report)
+	})
+
+	t.Run("已有内容的实例不能导入", func(t *testing.T) {
+		expectTx()
+		mockStore.EXPECT().HasQAContent(adminCtx).Return(true, nil).Times(1)
+
+		_, err := qaService.ImportContent(adminCtx, recordSource(header))
+
+		assert.ErrorIs(t, err, service.ErrContentNotEmpty)
+	})
+
+	t.Run("缺少文件头", func(t *testing.T) {
+		expectTx()
+		mockStore.EXPECT().HasQAContent(adminCtx).Return(false, nil).Times(1)
+
+		_, err := qaService.ImportContent(adminCtx, recordSource(&model.ContentRecord{User: &model.ContentUser{ID: 1}}))
+
+		assert.ErrorIs(t, err, service.ErrMissingContentHeader)
+	})
+
+	t.Run("不支持的格式版本", func(t *testing.T) {
+		expectTx()
+		mockStore.EXPECT().HasQAContent(adminCtx).Return(false, nil).Times(1)
+
+		_, err := qaService.ImportContent(adminCtx, recordSource(
+			&model.ContentRecord{Header: &model.ContentHeader{FormatVersion: model.ContentFormatVersion + 1}},
+		))
+
+		assert.ErrorIs(t, err, service.ErrUnsupportedContentVersion)
+	})
+
+	t.Run("按新ID改写引用并维护计数", func(t *testing.T) {
+		expectTx()
+		mockStore.EXPECT().HasQAContent(adminCtx).Return(false, nil).Times(1)
+		// 用户 1 的邮箱已存在，直接复用；用户 2 新建
+		importUser = func(ctx context.Context, req *userpb.ImportUserRequest) (*userpb.ImportUserResponse, error) {
+			if req.Email == "old@example.com" {
+				return &userpb.ImportUserResponse{UserId: 500, Username: "old", Reused: true}, nil
+			}
+			assert.Equal(t, "hash", req.PasswordHash)
+			// 当前版本的文件中没有验证时间表示邮箱确实未验证
+			assert.Nil(t, req.EmailVerifiedAt)
+			return &userpb.ImportUserResponse{UserId: 501, Username: req.Username}, nil
+		}
+		mockStore.EXPECT().
+			ImportQuestion(adminCtx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, q *model.Question) (int64, error) {
+				assert.Equal(t, int64(500), q.UserID)
+				assert.Zero(t, q.AcceptedAnswerID)
+				return int64(600), nil
+			}).
+			Times(1)
+		mockStore.EXPECT().
+			ImportAnswer(adminCtx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, a *model.Answer) (int64, error) {
+				assert.Equal(t, int64(600), a.QuestionID)
+				assert.Equal(t, int64(501), a.UserID)
+				assert.Zero(t, a.UpvoteCount)
+				return int64(700), nil
+			}).
+			Times(1)
+		mockStore.EXPECT().AdjustQuestionCounters(adminCtx, int64(600), int64(1), int64(0)).Return(nil).Times(1)
+		mockStore.EXPECT().
+			ImportComment(adminCtx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, c *model.Comment) (int64, error) {
+				assert.Equal(t, int64(700), c.AnswerID)
+				assert.Equal(t, int64(500), c.UserID)
+				return int64(800), nil
+			}).
+			Times(1)
+		mockStore.EXPECT().AdjustQuestionCounters(adminCtx, int64(600), int64(0), int64(1)).Return(nil).Times(1)
+		mockStore.EXPECT().
+			ImportVote(adminCtx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, v *model.ContentVote) error {
+				assert.Equal(t, int64(700), v.AnswerID)
+				assert.Equal(t, int64(500), v.UserID)
+				return nil
+			}).
+			Times(1)
+		mockStore.EXPECT().IncrementAnswerUpvoteCount(adminCtx, int64(700)).Return(nil).Times(1)
+		mockStore.EXPECT().SetAcceptedAnswer(adminCtx, int64(600), int64(700)).Return(true, nil).Times(1)
+
+		report, err := qaService.ImportContent(adminCtx, recordSource(
+			header,
+			&model.ContentRecord{User: &model.ContentUser{ID: 1, Username: "old", Email: "old@example.com"}},
+			&model.ContentRecord{User: &model.ContentUser{ID: 2, Username: "new", Email: "new@example.com", Password: "hash"}},
+			&model.ContentRecord{Question: &model.Question{ID: 10, UserID: 1, AcceptedAnswerID: 20}},
+			&model.ContentRecord{Answer: &model.Answer{ID: 20, QuestionID: 10, UserID: 2, UpvoteCount: 1}},
+			&model.ContentRecord{Comment: &model.Comment{ID: 30, AnswerID: 20, UserID: 1}},
+			&model.ContentRecord{Vote: &model.ContentVote{ID: 40, AnswerID: 20, UserID: 1, IsUpvote: true}},
+		))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), report.Users)
+		assert.Equal(t, int64(1), report.ReusedUsers)
+		assert.Empty(t, report.RenamedUsers)
+		assert.Zero(t, report.UsersWithoutPassword)
+		assert.Equal(t, int64(1), report.Questions)
+		assert.Equal(t, int64(1), report.Answers)
+		assert.Equal(t, int64(1), report.Comments)
+		assert.Equal(t, int64(1), report.Votes)
+	})
+
+	t.Run("版本 1 的用户视为已验证邮箱", func(t *testing.T) {
+		createdAt := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+		expectTx()
+		mockStore.EXPECT().HasQAContent(adminCtx).Return(false, nil).Times(1)
+		importUser = func(ctx context.Context, req *userpb.ImportUserRequest) (*userpb.ImportUserResponse, error) {
+			if assert.NotNil(t, req.EmailVerifiedAt) {
+				assert.Equal(t, createdAt, req.EmailVerifiedAt.AsTime())
+			}
+			return &userpb.ImportUserResponse{UserId: 502, Username: req.Username}, nil
+		}
+
+		report, err := qaService.ImportContent(adminCtx, recordSource(
+			&model.ContentRecord{Header: &model.ContentHeader{FormatVersion: 1}},
+			&model.ContentRecord{User: &model.ContentUser{ID: 1, Username: "v1", Email: "v1@example.com", CreatedAt: createdAt}},
+		))
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), report.Users)
+	})
+
+	t.Run("用户名冲突时报告改名的用户", func(t *testing.T) {
+		expectTx()
+		mockStore.EXPECT().HasQAContent(adminCtx).Return(false, nil).Times(1)
+		// 目标实例已有用户 alice，用户服务为导入的 Alice 追加了后缀
+		importUser = func(ctx context.Context, req *userpb.ImportUserRequest) (*userpb.ImportUserResponse, error) {
+			return &userpb.ImportUserResponse{UserId: 503, Username: "Alice_2"}, nil
+		}
+
+		report, err := qaService.ImportContent(adminCtx, recordSource(
+			header,
+			&model.ContentRecord{User: &model.ContentUser{ID: 7, Username: "Alice", Email: "alice@example.com"}},
+		))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []dto.RenamedUser{{OriginalID: 7, OriginalUsername: "Alice", Username: "Alice_2", UserID: 503}}, report.RenamedUsers)
+		assert.Equal(t, int64(1), report.UsersWithoutPassword)
+	})
+
+	t.Run("被用户服务拒绝的用户指明是哪一条记录", func(t *testing.T) {
+		expectTx()
+		mockStore.EXPECT().HasQAContent(adminCtx).Return(false, nil).Times(1)
+		importUser = func(ctx context.Context, req *userpb.ImportUserRequest) (*userpb.ImportUserResponse, error) {
+			return nil, status.Error(codes.InvalidArgument, "导入的用户数据无效: 邮箱格式不正确")
+		}
+
+		_, err := qaService.ImportContent(adminCtx, recordSource(
+			header,
+			&model.ContentRecord{User: &model.ContentUser{ID: 8, Username: "bad", Email: "not-an-email"}},
+		))
+
+		assert.ErrorIs(t, err, service.ErrInvalidUserRecord)
+		assert.Contains(t, err.Error(), "用户 8 (bad)")
+		assert.Contains(t, err.Error(), "邮箱格式不正确")
+	})
+
+	t.Run("引用了未导入的记录", func(t *testing.T) {
+		expectTx()
+		mockStore.EXPECT().HasQAContent(adminCtx).Return(false, nil).Times(1)
+
+		_, err := qaService.ImportContent(adminCtx, recordSource(
+			header,
+			&model.ContentRecord{Question: &model.Question{ID: 10, UserID: 99}},
+		))
+
+		assert.ErrorIs(t, err, service.ErrDanglingReference)
+	})
+}
@@ -14,6 +14,10 @@ import (
 // publishQuestionEvent 是一个辅助函数，用于发布与问题相关的事件
 func (s *qaService) publishQuestionEvent(ctx context.Context, eventType messaging.EventType, question *model.Question) {
 	identity, _ := auth.FromContext(ctx)
+	authorName := identity.Username
+	if question.IsAnonymous {
+		authorName = ""
+	}
 
 	event := messaging.QuestionCreatedEvent{
 		Header: messaging.EventHeader{
@@ -27,7 +31,8 @@ func (s *qaService) publishQuestionEvent(ctx context.Context, eventType messagin
 			Title:      question.Title,
 			Content:    question.Content,
 			AuthorID:   question.UserID,
-			AuthorName: identity.Username,
+			AuthorName: authorName,
+			Anonymous:  question.IsAnonymous,
 			CreatedAt:  question.CreatedAt,
 			UpdatedAt:  question.UpdatedAt,
 			// Tags: question.Tags, // 如果有Tags字段的话
@@ -63,3 +68,85 @@ func (s *qaService) publishNotificationEvent(ctx context.Context, payload messag
 		log.Printf("Published event %s for recipient ID %d", messaging.EventNotificationTriggered, payload.RecipientID)
 	}
 }
+
+// publishVoteEvent 是一个辅助函数，用于发布与回答投票相关的事件
+func (s *qaService) publishVoteEvent(ctx context.Context, eventType messaging.EventType, payload messaging.VotePayload) {
+	event := messaging.AnswerVotedEvent{
+		Header: messaging.EventHeader{
+			ID:        uuid.New().String(),
+			Type:      eventType,
+			Source:    "qa-service",
+			Timestamp: time.Now(),
+		},
+		Payload: payload,
+	}
+
+	destination := s.topicProvider.QuestionCreatedDestination()
+	err := s.producer.SendMessage(ctx, destination, event)
+	if err != nil {
+		log.Printf("Failed to publish event %s for answer ID %d: %v", eventType, payload.AnswerID, err)
+	} else {
+		log.Printf("Published event %s for answer ID %d", eventType, payload.AnswerID)
+	}
+}
+
+// publishAnswerEvent 是一个辅助函数，用于发布与回答相关的事件
+func (s *qaService) publishAnswerEvent(ctx context.Context, eventType messaging.EventType, payload messaging.AnswerPayload) {
+	event := messaging.AnswerEvent{
+		Header: messaging.EventHeader{
+			ID:        uuid.New().String(),
+			Type:      eventType,
+			Source:    "qa-service",
+			Timestamp: time.Now(),
+		},
+		Payload: payload,
+	}
+
+	destination := s.topicProvider.QuestionCreatedDestination()
+	err := s.producer.SendMessage(ctx, destination, event)
+	if err != nil {
+		log.Printf("Failed to publish event %s for answer ID %d: %v", eventType, payload.ID, err)
+	} else {
+		log.Printf("Published event %s for answer ID %d", eventType, payload.ID)
+	}
+}
+
+// publishQuestionViewedEvent 是一个辅助函数，用于发布问题浏览事件
+func (s *qaService) publishQuestionViewedEvent(ctx context.Context, questionID, viewerID int64) {
+	event := messaging.QuestionViewedEvent{
+		Header: messaging.EventHeader{
+			ID:        uuid.New().String(),
+			Type:      messaging.EventQuestionViewed,
+			Source:    "qa-service",
+			Timestamp: time.Now(),
+		},
+	}
+	event.Payload.ID = questionID
+	event.Payload.ViewerID = viewerID
+
+	destination := s.topicProvider.QuestionCreatedDestination()
+	if err := s.producer.SendMessage(ctx, destination, event); err != nil {
+		log.Printf("Failed to publish event %s for question ID %d: %v", messaging.EventQuestionViewed, questionID, err)
+	}
+}
+
+// publishFlagEvent 是一个辅助函数，用于发布内容被标记违规的事件
+func (s *qaService) publishFlagEvent(ctx context.Context, payload messaging.FlagPayload) {
+	event := messaging.ContentFlaggedEvent{
+		Header: messaging.EventHeader{
+			ID:        uuid.New().String(),
+			Type:      messaging.EventContentFlagged,
+			Source:    "qa-service",
+			Timestamp: time.Now(),
+		},
+		Payload: payload,
+	}
+
+	destination := s.topicProvider.QuestionCreatedDestination()
+	err := s.producer.SendMessage(ctx, destination, event)
+	if err != nil {
+		log.Printf("Failed to publish event %s for %s ID %d: %v", messaging.EventContentFlagged, payload.TargetType, payload.TargetID, err)
+	} else {
+		log.Printf("Published event %s for %s ID %d", messaging.EventContentFlagged, payload.TargetType, payload.TargetID)
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/log"
+	"qahub/pkg/messaging"
+	"qahub/qa-service/internal/model"
+)
+
+var (
+	// ErrInvalidFlagTarget 表示违规标记的目标类型不受支持
+	ErrInvalidFlagTarget = errors.New("只能将问题或回答标记为违规")
+	// ErrFlagTargetNotFound 表示被标记的问题或回答不存在
+	ErrFlagTargetNotFound = errors.New("被标记的内容不存在")
+	// ErrNotFlagModerator 表示只有版主可以标记违规内容
+	ErrNotFlagModerator = errors.New("只有版主可以标记违规内容")
+	// ErrContentAlreadyFlagged 表示该内容已经被标记过
+	ErrContentAlreadyFlagged = errors.New("该内容已经被标记为违规")
+)
+
+// FlagContent 由版主将问题或回答标记为违规，并发布 content.flagged 事件供声望服务扣除作者积分
+func (s *qaService) FlagContent(ctx context.Context, targetType string, targetID int64, reason string, userID int64) (*model.ContentFlag, error) {
+	logger := log.FromContext(ctx)
+
+	identity, _ := auth.FromContext(ctx)
+	if !identity.IsModerator() {
+		logger.Warn("无权限标记违规内容",
+			slog.String("target_type", targetType),
+			slog.Int64("target_id", targetID),
+			slog.Int64("user_id", userID),
+		)
+		return nil, ErrNotFlagModerator
+	}
+
+	// 违规标记与修改建议的目标相同，沿用其读取逻辑
+	target, err := s.loadEditTarget(ctx, targetType, targetID)
+	switch {
+	case errors.Is(err, ErrInvalidEditTarget):
+		return nil, ErrInvalidFlagTarget
+	case errors.Is(err, ErrEditTargetNotFound):
+		return nil, ErrFlagTargetNotFound
+	case err != nil:
+		logger.Error("获取被标记的内容失败",
+			slog.String("target_type", targetType),
+			slog.Int64("target_id", targetID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	flag := &model.ContentFlag{
+		TargetType: targetType,
+		TargetID:   targetID,
+		QuestionID: target.question.ID,
+		AuthorID:   target.ownerID(),
+		ReporterID: userID,
+		Reason:     reason,
+		CreatedAt:  time.Now(),
+	}
+	ok, err := s.store.CreateContentFlag(ctx, flag)
+	if err != nil {
+		logger.Error("标记违规内容失败",
+			slog.String("target_type", targetType),
+			slog.Int64("target_id", targetID),
+			slog.Int64("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrContentAlreadyFlagged
+	}
+
+	logger.Info("内容已被标记为违规",
+		slog.String("target_type", targetType),
+		slog.Int64("target_id", targetID),
+		slog.Int64("author_id", flag.AuthorID),
+		slog.Int64("reporter_id", userID),
+	)
+
+	go func() {
+		eventCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		s.publishFlagEvent(eventCtx, messaging.FlagPayload{
+			TargetType: flag.TargetType,
+			TargetID:   flag.TargetID,
+			QuestionID: flag.QuestionID,
+			AuthorID:   flag.AuthorID,
+			ReporterID: flag.ReporterID,
+			Reason:     flag.Reason,
+			CreatedAt:  flag.CreatedAt,
+		})
+	}()
+	return flag, nil
+}
@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/config"
+	"qahub/pkg/messaging"
+	"qahub/qa-service/internal/model"
+	"qahub/qa-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFlagContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
+	modCtx := auth.WithIdentity(context.Background(), auth.Identity{
+		UserID: 400,
+		Claims: map[string]any{"role": auth.RoleModerator},
+	})
+
+	question := &model.Question{ID: 1, Title: "问题", Content: "内容", UserID: 100}
+
+	t.Run("版主标记回答时记录作者和所属问题", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetAnswerByID(modCtx, int64(5)).
+			Return(&model.Answer{ID: 5, QuestionID: 1, Content: "回答", UserID: 300}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetQuestionByID(modCtx, int64(1)).
+			Return(question, nil).
+			Times(1)
+		mockStore.EXPECT().
+			CreateContentFlag(modCtx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, f *model.ContentFlag) (bool, error) {
+				assert.Equal(t, model.EditTargetAnswer, f.TargetType)
+				assert.Equal(t, int64(5), f.TargetID)
+				assert.Equal(t, int64(1), f.QuestionID)
+				assert.Equal(t, int64(300), f.AuthorID)
+				assert.Equal(t, int64(400), f.ReporterID)
+				return true, nil
+			}).
+			Times(1)
+
+		flag, err := qaService.FlagContent(modCtx, model.EditTargetAnswer, 5, "广告", 400)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "广告", flag.Reason)
+	})
+
+	t.Run("同一内容不能重复标记", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetQuestionByID(modCtx, int64(1)).
+			Return(question, nil).
+			Times(1)
+		mockStore.EXPECT().
+			CreateContentFlag(modCtx, gomock.Any()).
+			Return(false, nil).
+			Times(1)
+
+		_, err := qaService.FlagContent(modCtx, model.EditTargetQuestion, 1, "", 400)
+
+		assert.ErrorIs(t, err, service.ErrContentAlreadyFlagged)
+	})
+
+	t.Run("被标记的内容不存在", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetQuestionByID(modCtx, int64(404)).
+			Return(nil, sql.ErrNoRows).
+			Times(1)
+
+		_, err := qaService.FlagContent(modCtx, model.EditTargetQuestion, 404, "", 400)
+
+		assert.ErrorIs(t, err, service.ErrFlagTargetNotFound)
+	})
+
+	t.Run("普通用户不能标记", func(t *testing.T) {
+		userCtx := auth.WithIdentity(context.Background(), auth.Identity{UserID: 200})
+
+		_, err := qaService.FlagContent(userCtx, model.EditTargetQuestion, 1, "", 200)
+
+		assert.ErrorIs(t, err, service.ErrNotFlagModerator)
+	})
+}
@@ -2,12 +2,39 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	userpb "qahub/api/proto/user"
+	"qahub/pkg/auth"
+	"qahub/pkg/blob"
+	"qahub/pkg/config"
 	"qahub/pkg/messaging"
 	"qahub/qa-service/internal/dto"
 	"qahub/qa-service/internal/model"
 	"qahub/qa-service/internal/store"
 )
 
+// MaxBatchSize 是批量查询接口单次允许的最大 ID 数量
+const MaxBatchSize = 100
+
+// AnonymousAuthorName 是匿名内容对其他用户展示的作者名，也用作通知中的发送者名
+const AnonymousAuthorName = "匿名用户"
+
+var (
+	// ErrEmptyBatch 表示批量查询没有提供任何 ID
+	ErrEmptyBatch = errors.New("批量查询的 ID 列表不能为空")
+	// ErrBatchTooLarge 表示批量查询的 ID 数量超过了 MaxBatchSize
+	ErrBatchTooLarge = fmt.Errorf("批量查询的 ID 数量不能超过 %d", MaxBatchSize)
+	// ErrNotQuestionOwner 表示只有提问者才能执行该操作
+	ErrNotQuestionOwner = errors.New("只有提问者才能采纳回答")
+	// ErrAnswerAlreadyAccepted 表示问题已经采纳过回答
+	ErrAnswerAlreadyAccepted = errors.New("该问题已经采纳过回答")
+	// ErrAdminRequired 表示该操作只有管理员可以执行
+	ErrAdminRequired = errors.New("只有管理员可以执行该操作")
+	// ErrVoteNotFound 表示用户没有对该回答投过票
+	ErrVoteNotFound = errors.New("尚未对该回答投票")
+)
+
 type EventDestinationProvider interface {
 	QuestionCreatedDestination() string
 	NotificationDestination() string
@@ -17,21 +44,32 @@ type EventDestinationProvider interface {
 type QAService interface {
 	// --- 问题相关 ---
 
-	CreateQuestion(ctx context.Context, title, content string, userID int64) (*model.Question, error)
+	// CreateQuestion 创建问题，anonymous 为 true 时只有作者本人和管理员能看到提问者
+	CreateQuestion(ctx context.Context, title, content string, anonymous bool, userID int64) (*model.Question, error)
 	GetQuestion(ctx context.Context, questionID int64) (*dto.QuestionResponse, error)
 	ListQuestions(ctx context.Context, page int64, pageSize int32) ([]*dto.QuestionResponse, int64, error)
 	ListQuestionsByUserID(ctx context.Context, userID int64, page int64, pageSize int32) ([]*dto.QuestionResponse, int64, error)
+	// BatchGetQuestions 按请求顺序返回问题，以及不存在的问题ID
+	BatchGetQuestions(ctx context.Context, questionIDs []int64) ([]*dto.QuestionResponse, []int64, error)
 	UpdateQuestion(ctx context.Context, questionID int64, title, content string, userID int64) (*model.Question, error)
 	DeleteQuestion(ctx context.Context, questionID, userID int64) error
 
 	// --- 回答相关 ---
 
-	CreateAnswer(ctx context.Context, questionID int64, content string, userID int64) (*model.Answer, error)
+	// CreateAnswer 创建回答，anonymous 为 true 时只有作者本人和管理员能看到回答者
+	CreateAnswer(ctx context.Context, questionID int64, content string, anonymous bool, userID int64) (*model.Answer, error)
 	GetAnswer(ctx context.Context, answerID int64) (*model.Answer, error)
 	ListAnswers(ctx context.Context, questionID int64, page int64, pageSize int32, userID int64) ([]*dto.AnswerResponse, int64, error)
+	// BatchGetAnswers 按请求顺序返回回答，以及不存在的回答ID
+	BatchGetAnswers(ctx context.Context, answerIDs []int64, userID int64) ([]*dto.AnswerResponse, []int64, error)
 
 	UpvoteAnswer(ctx context.Context, answerID, userID int64) error
+	// DownvoteAnswer 点踩回答，不影响点赞数
 	DownvoteAnswer(ctx context.Context, answerID, userID int64) error
+	// RetractAnswerVote 撤销用户对回答的点赞或点踩
+	RetractAnswerVote(ctx context.Context, answerID, userID int64) error
+	// AcceptAnswer 由提问者采纳回答，每个问题只能采纳一次
+	AcceptAnswer(ctx context.Context, answerID, userID int64) error
 	CountVotes(ctx context.Context, answerID int64) (int64, error)
 
 	UpdateAnswer(ctx context.Context, answerID int64, content string, userID int64) (*model.Answer, error)
@@ -44,6 +82,37 @@ type QAService interface {
 	ListComments(ctx context.Context, answerID int64, page int64, pageSize int32) ([]*dto.CommentResponse, int64, error)
 	UpdateComment(ctx context.Context, commentID int64, content string, userID int64) (*model.Comment, error)
 	DeleteComment(ctx context.Context, commentID, userID int64) error
+
+	// --- 修改建议相关 ---
+
+	// SuggestEdit 为他人的问题或回答提出修改建议，targetType 为 model.EditTargetQuestion 或 model.EditTargetAnswer
+	SuggestEdit(ctx context.Context, targetType string, targetID int64, title, content, reason string, userID int64) (*model.SuggestedEdit, error)
+	ListSuggestedEdits(ctx context.Context, targetType string, targetID int64, status string, page int64, pageSize int32) ([]*dto.SuggestedEditResponse, int64, error)
+	// ReviewSuggestedEdit 由作者或版主通过或拒绝修改建议，通过后修改立即生效
+	ReviewSuggestedEdit(ctx context.Context, editID int64, approve bool, comment string, userID int64) (*model.SuggestedEdit, error)
+
+	// --- 违规标记 (仅版主) ---
+
+	// FlagContent 将问题或回答标记为违规，同一内容只能被标记一次
+	FlagContent(ctx context.Context, targetType string, targetID int64, reason string, userID int64) (*model.ContentFlag, error)
+
+	// --- 内容导出导入 (仅管理员) ---
+
+	// ExportContent 将全部内容逐条交给 emit，第一条记录为文件头；includePasswords 为 false 时不导出密码哈希
+	ExportContent(ctx context.Context, includePasswords bool, emit func(*model.ContentRecord) error) error
+	// ImportContent 将导出的内容写入空实例，next 在没有更多记录时返回 io.EOF
+	ImportContent(ctx context.Context, next func() (*model.ContentRecord, error)) (*dto.ImportReport, error)
+
+	// --- 用户动态相关 ---
+
+	ListAnswersByUserID(ctx context.Context, userID int64, page int64, pageSize int32) ([]*dto.UserAnswerResponse, int64, error)
+	ListCommentsByUserID(ctx context.Context, userID int64, page int64, pageSize int32) ([]*dto.UserCommentResponse, int64, error)
+	GetUserActivity(ctx context.Context, userID int64, page int64, pageSize int32) ([]*model.Activity, int64, error)
+}
+
+// UserImporter 在用户服务中创建导入的用户，用户的校验和用户名冲突的处理都由用户服务负责
+type UserImporter interface {
+	ImportUser(ctx context.Context, req *userpb.ImportUserRequest) (*userpb.ImportUserResponse, error)
 }
 
 // qaService 是 QAService 接口的实现
@@ -51,13 +120,54 @@ type qaService struct {
 	store         store.QAStore
 	producer      messaging.Producer
 	topicProvider EventDestinationProvider
+	users         UserImporter
+	votes         *voteAggregator
 }
 
-// NewQAService 创建一个新的 QAService
-func NewQAService(s store.QAStore, p messaging.Producer, tp EventDestinationProvider) *qaService {
-	return &qaService{
+// NewQAService 创建一个新的 QAService，users 只在导入内容时使用
+func NewQAService(s store.QAStore, p messaging.Producer, tp EventDestinationProvider, users UserImporter) *qaService {
+	svc := &qaService{
 		store:         s,
 		producer:      p,
 		topicProvider: tp,
+		users:         users,
+	}
+	svc.votes = newVoteAggregator(config.Conf.Services.QAService.VoteNotifyWindow, svc.notifyAnswerUpvoted)
+	return svc
+}
+
+// Close 立即发送点赞聚合窗口内尚未发送的通知，应在 gRPC 服务停止之后、Kafka 生产者关闭之前调用
+func (s *qaService) Close() error {
+	return s.votes.Close()
+}
+
+// normalizeBatchIDs 校验批量查询的 ID 列表，并在保持顺序的前提下去重
+func normalizeBatchIDs(ids []int64) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, ErrEmptyBatch
 	}
+	seen := make(map[int64]struct{}, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+	if len(unique) > MaxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+	return unique, nil
+}
+
+// canSeeAuthor 判断当前请求者能否看到 authorID 匿名发布的内容的真实作者，只有作者本人和管理员可以
+func canSeeAuthor(ctx context.Context, authorID int64) bool {
+	identity, ok := auth.FromContext(ctx)
+	return ok && (identity.UserID == authorID || identity.IsAdmin())
+}
+
+// authorAvatarURL 返回作者头像的小尺寸缩略图地址，头像文件由 user-service 保存和提供下载
+func authorAvatarURL(avatarKey string) string {
+	return blob.AvatarURL(config.Conf.Blob.BaseURL, avatarKey, blob.AvatarSizeSmall)
 }
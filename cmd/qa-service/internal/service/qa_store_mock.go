@@ -15,6 +15,7 @@ import (
 	model "qahub/qa-service/internal/model"
 	store "qahub/qa-service/internal/store"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -43,6 +44,34 @@ func (m *MockQAStore) EXPECT() *MockQAStoreMockRecorder {
 	return m.recorder
 }
 
+// AdjustQuestionCounters mocks base method.
+func (m *MockQAStore) AdjustQuestionCounters(ctx context.Context, questionID, answerDelta, commentDelta int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustQuestionCounters", ctx, questionID, answerDelta, commentDelta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustQuestionCounters indicates an expected call of AdjustQuestionCounters.
+func (mr *MockQAStoreMockRecorder) AdjustQuestionCounters(ctx, questionID, answerDelta, commentDelta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustQuestionCounters", reflect.TypeOf((*MockQAStore)(nil).AdjustQuestionCounters), ctx, questionID, answerDelta, commentDelta)
+}
+
+// ClearAcceptedAnswer mocks base method.
+func (m *MockQAStore) ClearAcceptedAnswer(ctx context.Context, answerID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearAcceptedAnswer", ctx, answerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ClearAcceptedAnswer indicates an expected call of ClearAcceptedAnswer.
+func (mr *MockQAStoreMockRecorder) ClearAcceptedAnswer(ctx, answerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearAcceptedAnswer", reflect.TypeOf((*MockQAStore)(nil).ClearAcceptedAnswer), ctx, answerID)
+}
+
 // CountAnswersByQuestionID mocks base method.
 func (m *MockQAStore) CountAnswersByQuestionID(ctx context.Context, questionID int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -58,6 +87,21 @@ func (mr *MockQAStoreMockRecorder) CountAnswersByQuestionID(ctx, questionID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAnswersByQuestionID", reflect.TypeOf((*MockQAStore)(nil).CountAnswersByQuestionID), ctx, questionID)
 }
 
+// CountAnswersByUserID mocks base method.
+func (m *MockQAStore) CountAnswersByUserID(ctx context.Context, userID int64, includeAnonymous bool) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAnswersByUserID", ctx, userID, includeAnonymous)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAnswersByUserID indicates an expected call of CountAnswersByUserID.
+func (mr *MockQAStoreMockRecorder) CountAnswersByUserID(ctx, userID, includeAnonymous any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAnswersByUserID", reflect.TypeOf((*MockQAStore)(nil).CountAnswersByUserID), ctx, userID, includeAnonymous)
+}
+
 // CountCommentsByAnswerID mocks base method.
 func (m *MockQAStore) CountCommentsByAnswerID(ctx context.Context, answerID int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -73,6 +117,21 @@ func (mr *MockQAStoreMockRecorder) CountCommentsByAnswerID(ctx, answerID any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCommentsByAnswerID", reflect.TypeOf((*MockQAStore)(nil).CountCommentsByAnswerID), ctx, answerID)
 }
 
+// CountCommentsByUserID mocks base method.
+func (m *MockQAStore) CountCommentsByUserID(ctx context.Context, userID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCommentsByUserID", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCommentsByUserID indicates an expected call of CountCommentsByUserID.
+func (mr *MockQAStoreMockRecorder) CountCommentsByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCommentsByUserID", reflect.TypeOf((*MockQAStore)(nil).CountCommentsByUserID), ctx, userID)
+}
+
 // CountQuestions mocks base method.
 func (m *MockQAStore) CountQuestions(ctx context.Context) (int64, error) {
 	m.ctrl.T.Helper()
@@ -88,6 +147,36 @@ func (mr *MockQAStoreMockRecorder) CountQuestions(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountQuestions", reflect.TypeOf((*MockQAStore)(nil).CountQuestions), ctx)
 }
 
+// CountQuestionsByUserID mocks base method.
+func (m *MockQAStore) CountQuestionsByUserID(ctx context.Context, userID int64, includeAnonymous bool) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountQuestionsByUserID", ctx, userID, includeAnonymous)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountQuestionsByUserID indicates an expected call of CountQuestionsByUserID.
+func (mr *MockQAStoreMockRecorder) CountQuestionsByUserID(ctx, userID, includeAnonymous any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountQuestionsByUserID", reflect.TypeOf((*MockQAStore)(nil).CountQuestionsByUserID), ctx, userID, includeAnonymous)
+}
+
+// CountSuggestedEdits mocks base method.
+func (m *MockQAStore) CountSuggestedEdits(ctx context.Context, targetType string, targetID int64, status string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSuggestedEdits", ctx, targetType, targetID, status)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSuggestedEdits indicates an expected call of CountSuggestedEdits.
+func (mr *MockQAStoreMockRecorder) CountSuggestedEdits(ctx, targetType, targetID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSuggestedEdits", reflect.TypeOf((*MockQAStore)(nil).CountSuggestedEdits), ctx, targetType, targetID, status)
+}
+
 // CountVotesByAnswerID mocks base method.
 func (m *MockQAStore) CountVotesByAnswerID(ctx context.Context, answerID int64) (int64, error) {
 	m.ctrl.T.Helper()
@@ -147,6 +236,21 @@ func (mr *MockQAStoreMockRecorder) CreateComment(ctx, comment any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockQAStore)(nil).CreateComment), ctx, comment)
 }
 
+// CreateContentFlag mocks base method.
+func (m *MockQAStore) CreateContentFlag(ctx context.Context, flag *model.ContentFlag) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateContentFlag", ctx, flag)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateContentFlag indicates an expected call of CreateContentFlag.
+func (mr *MockQAStoreMockRecorder) CreateContentFlag(ctx, flag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContentFlag", reflect.TypeOf((*MockQAStore)(nil).CreateContentFlag), ctx, flag)
+}
+
 // CreateQuestion mocks base method.
 func (m *MockQAStore) CreateQuestion(ctx context.Context, question *model.Question) (int64, error) {
 	m.ctrl.T.Helper()
@@ -162,6 +266,21 @@ func (mr *MockQAStoreMockRecorder) CreateQuestion(ctx, question any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuestion", reflect.TypeOf((*MockQAStore)(nil).CreateQuestion), ctx, question)
 }
 
+// CreateSuggestedEdit mocks base method.
+func (m *MockQAStore) CreateSuggestedEdit(ctx context.Context, edit *model.SuggestedEdit) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSuggestedEdit", ctx, edit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSuggestedEdit indicates an expected call of CreateSuggestedEdit.
+func (mr *MockQAStoreMockRecorder) CreateSuggestedEdit(ctx, edit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSuggestedEdit", reflect.TypeOf((*MockQAStore)(nil).CreateSuggestedEdit), ctx, edit)
+}
+
 // DecrementAnswerUpvoteCount mocks base method.
 func (m *MockQAStore) DecrementAnswerUpvoteCount(ctx context.Context, answerID int64) error {
 	m.ctrl.T.Helper()
@@ -261,19 +380,49 @@ func (mr *MockQAStoreMockRecorder) GetAnswerByID(ctx, answerID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnswerByID", reflect.TypeOf((*MockQAStore)(nil).GetAnswerByID), ctx, answerID)
 }
 
-// GetAnswerCountByQuestionIDs mocks base method.
-func (m *MockQAStore) GetAnswerCountByQuestionIDs(ctx context.Context, questionIDs []int64) (map[int64]int64, error) {
+// GetAnswerForUpdate mocks base method.
+func (m *MockQAStore) GetAnswerForUpdate(ctx context.Context, answerID int64) (*model.Answer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnswerForUpdate", ctx, answerID)
+	ret0, _ := ret[0].(*model.Answer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnswerForUpdate indicates an expected call of GetAnswerForUpdate.
+func (mr *MockQAStoreMockRecorder) GetAnswerForUpdate(ctx, answerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnswerForUpdate", reflect.TypeOf((*MockQAStore)(nil).GetAnswerForUpdate), ctx, answerID)
+}
+
+// GetAnswerVoteForUpdate mocks base method.
+func (m *MockQAStore) GetAnswerVoteForUpdate(ctx context.Context, answerID, userID int64) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAnswerCountByQuestionIDs", ctx, questionIDs)
-	ret0, _ := ret[0].(map[int64]int64)
+	ret := m.ctrl.Call(m, "GetAnswerVoteForUpdate", ctx, answerID, userID)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAnswerCountByQuestionIDs indicates an expected call of GetAnswerCountByQuestionIDs.
-func (mr *MockQAStoreMockRecorder) GetAnswerCountByQuestionIDs(ctx, questionIDs any) *gomock.Call {
+// GetAnswerVoteForUpdate indicates an expected call of GetAnswerVoteForUpdate.
+func (mr *MockQAStoreMockRecorder) GetAnswerVoteForUpdate(ctx, answerID, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnswerCountByQuestionIDs", reflect.TypeOf((*MockQAStore)(nil).GetAnswerCountByQuestionIDs), ctx, questionIDs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnswerVoteForUpdate", reflect.TypeOf((*MockQAStore)(nil).GetAnswerVoteForUpdate), ctx, answerID, userID)
+}
+
+// GetAnswersByIDs mocks base method.
+func (m *MockQAStore) GetAnswersByIDs(ctx context.Context, answerIDs []int64) ([]*model.Answer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAnswersByIDs", ctx, answerIDs)
+	ret0, _ := ret[0].([]*model.Answer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAnswersByIDs indicates an expected call of GetAnswersByIDs.
+func (mr *MockQAStoreMockRecorder) GetAnswersByIDs(ctx, answerIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAnswersByIDs", reflect.TypeOf((*MockQAStore)(nil).GetAnswersByIDs), ctx, answerIDs)
 }
 
 // GetCommentByID mocks base method.
@@ -306,6 +455,96 @@ func (mr *MockQAStoreMockRecorder) GetQuestionByID(ctx, questionID any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionByID", reflect.TypeOf((*MockQAStore)(nil).GetQuestionByID), ctx, questionID)
 }
 
+// GetQuestionForUpdate mocks base method.
+func (m *MockQAStore) GetQuestionForUpdate(ctx context.Context, questionID int64) (*model.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestionForUpdate", ctx, questionID)
+	ret0, _ := ret[0].(*model.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestionForUpdate indicates an expected call of GetQuestionForUpdate.
+func (mr *MockQAStoreMockRecorder) GetQuestionForUpdate(ctx, questionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionForUpdate", reflect.TypeOf((*MockQAStore)(nil).GetQuestionForUpdate), ctx, questionID)
+}
+
+// GetQuestionStatsByIDs mocks base method.
+func (m *MockQAStore) GetQuestionStatsByIDs(ctx context.Context, questionIDs []int64) ([]*model.QuestionStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestionStatsByIDs", ctx, questionIDs)
+	ret0, _ := ret[0].([]*model.QuestionStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestionStatsByIDs indicates an expected call of GetQuestionStatsByIDs.
+func (mr *MockQAStoreMockRecorder) GetQuestionStatsByIDs(ctx, questionIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionStatsByIDs", reflect.TypeOf((*MockQAStore)(nil).GetQuestionStatsByIDs), ctx, questionIDs)
+}
+
+// GetQuestionsByIDs mocks base method.
+func (m *MockQAStore) GetQuestionsByIDs(ctx context.Context, questionIDs []int64) ([]*model.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuestionsByIDs", ctx, questionIDs)
+	ret0, _ := ret[0].([]*model.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuestionsByIDs indicates an expected call of GetQuestionsByIDs.
+func (mr *MockQAStoreMockRecorder) GetQuestionsByIDs(ctx, questionIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionsByIDs", reflect.TypeOf((*MockQAStore)(nil).GetQuestionsByIDs), ctx, questionIDs)
+}
+
+// GetSuggestedEditByID mocks base method.
+func (m *MockQAStore) GetSuggestedEditByID(ctx context.Context, editID int64) (*model.SuggestedEdit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuggestedEditByID", ctx, editID)
+	ret0, _ := ret[0].(*model.SuggestedEdit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuggestedEditByID indicates an expected call of GetSuggestedEditByID.
+func (mr *MockQAStoreMockRecorder) GetSuggestedEditByID(ctx, editID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuggestedEditByID", reflect.TypeOf((*MockQAStore)(nil).GetSuggestedEditByID), ctx, editID)
+}
+
+// GetUserAvatarsByIDs mocks base method.
+func (m *MockQAStore) GetUserAvatarsByIDs(ctx context.Context, userIDs []int64) (map[int64]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAvatarsByIDs", ctx, userIDs)
+	ret0, _ := ret[0].(map[int64]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAvatarsByIDs indicates an expected call of GetUserAvatarsByIDs.
+func (mr *MockQAStoreMockRecorder) GetUserAvatarsByIDs(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAvatarsByIDs", reflect.TypeOf((*MockQAStore)(nil).GetUserAvatarsByIDs), ctx, userIDs)
+}
+
+// GetUserTypesByIDs mocks base method.
+func (m *MockQAStore) GetUserTypesByIDs(ctx context.Context, userIDs []int64) (map[int64]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTypesByIDs", ctx, userIDs)
+	ret0, _ := ret[0].(map[int64]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTypesByIDs indicates an expected call of GetUserTypesByIDs.
+func (mr *MockQAStoreMockRecorder) GetUserTypesByIDs(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTypesByIDs", reflect.TypeOf((*MockQAStore)(nil).GetUserTypesByIDs), ctx, userIDs)
+}
+
 // GetUserVotesForAnswers mocks base method.
 func (m *MockQAStore) GetUserVotesForAnswers(ctx context.Context, userID int64, answerIDs []int64) (map[int64]bool, error) {
 	m.ctrl.T.Helper()
@@ -336,6 +575,80 @@ func (mr *MockQAStoreMockRecorder) GetUsernamesByIDs(ctx, userIDs any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsernamesByIDs", reflect.TypeOf((*MockQAStore)(nil).GetUsernamesByIDs), ctx, userIDs)
 }
 
+// HasQAContent mocks base method.
+func (m *MockQAStore) HasQAContent(ctx context.Context) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasQAContent", ctx)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasQAContent indicates an expected call of HasQAContent.
+func (mr *MockQAStoreMockRecorder) HasQAContent(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasQAContent", reflect.TypeOf((*MockQAStore)(nil).HasQAContent), ctx)
+}
+
+// ImportAnswer mocks base method.
+func (m *MockQAStore) ImportAnswer(ctx context.Context, answer *model.Answer) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportAnswer", ctx, answer)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportAnswer indicates an expected call of ImportAnswer.
+func (mr *MockQAStoreMockRecorder) ImportAnswer(ctx, answer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportAnswer", reflect.TypeOf((*MockQAStore)(nil).ImportAnswer), ctx, answer)
+}
+
+// ImportComment mocks base method.
+func (m *MockQAStore) ImportComment(ctx context.Context, comment *model.Comment) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportComment", ctx, comment)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportComment indicates an expected call of ImportComment.
+func (mr *MockQAStoreMockRecorder) ImportComment(ctx, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportComment", reflect.TypeOf((*MockQAStore)(nil).ImportComment), ctx, comment)
+}
+
+// ImportQuestion mocks base method.
+func (m *MockQAStore) ImportQuestion(ctx context.Context, question *model.Question) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportQuestion", ctx, question)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportQuestion indicates an expected call of ImportQuestion.
+func (mr *MockQAStoreMockRecorder) ImportQuestion(ctx, question any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportQuestion", reflect.TypeOf((*MockQAStore)(nil).ImportQuestion), ctx, question)
+}
+
+// ImportVote mocks base method.
+func (m *MockQAStore) ImportVote(ctx context.Context, vote *model.ContentVote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportVote", ctx, vote)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportVote indicates an expected call of ImportVote.
+func (mr *MockQAStoreMockRecorder) ImportVote(ctx, vote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportVote", reflect.TypeOf((*MockQAStore)(nil).ImportVote), ctx, vote)
+}
+
 // IncrementAnswerUpvoteCount mocks base method.
 func (m *MockQAStore) IncrementAnswerUpvoteCount(ctx context.Context, answerID int64) error {
 	m.ctrl.T.Helper()
@@ -350,6 +663,35 @@ func (mr *MockQAStoreMockRecorder) IncrementAnswerUpvoteCount(ctx, answerID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementAnswerUpvoteCount", reflect.TypeOf((*MockQAStore)(nil).IncrementAnswerUpvoteCount), ctx, answerID)
 }
 
+// IncrementQuestionViewCount mocks base method.
+func (m *MockQAStore) IncrementQuestionViewCount(ctx context.Context, questionID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementQuestionViewCount", ctx, questionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementQuestionViewCount indicates an expected call of IncrementQuestionViewCount.
+func (mr *MockQAStoreMockRecorder) IncrementQuestionViewCount(ctx, questionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementQuestionViewCount", reflect.TypeOf((*MockQAStore)(nil).IncrementQuestionViewCount), ctx, questionID)
+}
+
+// ListActivitiesByUserID mocks base method.
+func (m *MockQAStore) ListActivitiesByUserID(ctx context.Context, userID int64, includeAnonymous bool, offset int64, limit int32) ([]*model.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActivitiesByUserID", ctx, userID, includeAnonymous, offset, limit)
+	ret0, _ := ret[0].([]*model.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActivitiesByUserID indicates an expected call of ListActivitiesByUserID.
+func (mr *MockQAStoreMockRecorder) ListActivitiesByUserID(ctx, userID, includeAnonymous, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActivitiesByUserID", reflect.TypeOf((*MockQAStore)(nil).ListActivitiesByUserID), ctx, userID, includeAnonymous, offset, limit)
+}
+
 // ListAnswersByQuestionID mocks base method.
 func (m *MockQAStore) ListAnswersByQuestionID(ctx context.Context, questionID, offset int64, limit int32) ([]*model.Answer, error) {
 	m.ctrl.T.Helper()
@@ -365,6 +707,36 @@ func (mr *MockQAStoreMockRecorder) ListAnswersByQuestionID(ctx, questionID, offs
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAnswersByQuestionID", reflect.TypeOf((*MockQAStore)(nil).ListAnswersByQuestionID), ctx, questionID, offset, limit)
 }
 
+// ListAnswersByUserID mocks base method.
+func (m *MockQAStore) ListAnswersByUserID(ctx context.Context, userID int64, includeAnonymous bool, offset int64, limit int32) ([]*model.AnswerWithQuestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAnswersByUserID", ctx, userID, includeAnonymous, offset, limit)
+	ret0, _ := ret[0].([]*model.AnswerWithQuestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAnswersByUserID indicates an expected call of ListAnswersByUserID.
+func (mr *MockQAStoreMockRecorder) ListAnswersByUserID(ctx, userID, includeAnonymous, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAnswersByUserID", reflect.TypeOf((*MockQAStore)(nil).ListAnswersByUserID), ctx, userID, includeAnonymous, offset, limit)
+}
+
+// ListAnswersForExport mocks base method.
+func (m *MockQAStore) ListAnswersForExport(ctx context.Context, afterID int64, limit int32) ([]*model.Answer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAnswersForExport", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*model.Answer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAnswersForExport indicates an expected call of ListAnswersForExport.
+func (mr *MockQAStoreMockRecorder) ListAnswersForExport(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAnswersForExport", reflect.TypeOf((*MockQAStore)(nil).ListAnswersForExport), ctx, afterID, limit)
+}
+
 // ListCommentsByAnswerID mocks base method.
 func (m *MockQAStore) ListCommentsByAnswerID(ctx context.Context, answerID, offset int64, limit int32) ([]*model.Comment, error) {
 	m.ctrl.T.Helper()
@@ -380,6 +752,66 @@ func (mr *MockQAStoreMockRecorder) ListCommentsByAnswerID(ctx, answerID, offset,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommentsByAnswerID", reflect.TypeOf((*MockQAStore)(nil).ListCommentsByAnswerID), ctx, answerID, offset, limit)
 }
 
+// ListCommentsByUserID mocks base method.
+func (m *MockQAStore) ListCommentsByUserID(ctx context.Context, userID, offset int64, limit int32) ([]*model.CommentWithQuestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCommentsByUserID", ctx, userID, offset, limit)
+	ret0, _ := ret[0].([]*model.CommentWithQuestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCommentsByUserID indicates an expected call of ListCommentsByUserID.
+func (mr *MockQAStoreMockRecorder) ListCommentsByUserID(ctx, userID, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommentsByUserID", reflect.TypeOf((*MockQAStore)(nil).ListCommentsByUserID), ctx, userID, offset, limit)
+}
+
+// ListCommentsForExport mocks base method.
+func (m *MockQAStore) ListCommentsForExport(ctx context.Context, afterID int64, limit int32) ([]*model.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCommentsForExport", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*model.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCommentsForExport indicates an expected call of ListCommentsForExport.
+func (mr *MockQAStoreMockRecorder) ListCommentsForExport(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommentsForExport", reflect.TypeOf((*MockQAStore)(nil).ListCommentsForExport), ctx, afterID, limit)
+}
+
+// ListCounterDrifts mocks base method.
+func (m *MockQAStore) ListCounterDrifts(ctx context.Context) ([]*model.CounterDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCounterDrifts", ctx)
+	ret0, _ := ret[0].([]*model.CounterDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCounterDrifts indicates an expected call of ListCounterDrifts.
+func (mr *MockQAStoreMockRecorder) ListCounterDrifts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCounterDrifts", reflect.TypeOf((*MockQAStore)(nil).ListCounterDrifts), ctx)
+}
+
+// ListQuestionStatsSince mocks base method.
+func (m *MockQAStore) ListQuestionStatsSince(ctx context.Context, since time.Time) ([]*model.QuestionStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQuestionStatsSince", ctx, since)
+	ret0, _ := ret[0].([]*model.QuestionStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQuestionStatsSince indicates an expected call of ListQuestionStatsSince.
+func (mr *MockQAStoreMockRecorder) ListQuestionStatsSince(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQuestionStatsSince", reflect.TypeOf((*MockQAStore)(nil).ListQuestionStatsSince), ctx, since)
+}
+
 // ListQuestions mocks base method.
 func (m *MockQAStore) ListQuestions(ctx context.Context, offset int64, limit int32) ([]*model.Question, error) {
 	m.ctrl.T.Helper()
@@ -396,18 +828,150 @@ func (mr *MockQAStoreMockRecorder) ListQuestions(ctx, offset, limit any) *gomock
 }
 
 // ListQuestionsByUserID mocks base method.
-func (m *MockQAStore) ListQuestionsByUserID(ctx context.Context, userID, offset int64, limit int32) ([]*model.Question, error) {
+func (m *MockQAStore) ListQuestionsByUserID(ctx context.Context, userID int64, includeAnonymous bool, offset int64, limit int32) ([]*model.Question, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListQuestionsByUserID", ctx, userID, offset, limit)
+	ret := m.ctrl.Call(m, "ListQuestionsByUserID", ctx, userID, includeAnonymous, offset, limit)
 	ret0, _ := ret[0].([]*model.Question)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListQuestionsByUserID indicates an expected call of ListQuestionsByUserID.
-func (mr *MockQAStoreMockRecorder) ListQuestionsByUserID(ctx, userID, offset, limit any) *gomock.Call {
+func (mr *MockQAStoreMockRecorder) ListQuestionsByUserID(ctx, userID, includeAnonymous, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQuestionsByUserID", reflect.TypeOf((*MockQAStore)(nil).ListQuestionsByUserID), ctx, userID, includeAnonymous, offset, limit)
+}
+
+// ListQuestionsForExport mocks base method.
+func (m *MockQAStore) ListQuestionsForExport(ctx context.Context, afterID int64, limit int32) ([]*model.Question, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListQuestionsForExport", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*model.Question)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListQuestionsForExport indicates an expected call of ListQuestionsForExport.
+func (mr *MockQAStoreMockRecorder) ListQuestionsForExport(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQuestionsForExport", reflect.TypeOf((*MockQAStore)(nil).ListQuestionsForExport), ctx, afterID, limit)
+}
+
+// ListSuggestedEdits mocks base method.
+func (m *MockQAStore) ListSuggestedEdits(ctx context.Context, targetType string, targetID int64, status string, offset int64, limit int32) ([]*model.SuggestedEdit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSuggestedEdits", ctx, targetType, targetID, status, offset, limit)
+	ret0, _ := ret[0].([]*model.SuggestedEdit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSuggestedEdits indicates an expected call of ListSuggestedEdits.
+func (mr *MockQAStoreMockRecorder) ListSuggestedEdits(ctx, targetType, targetID, status, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSuggestedEdits", reflect.TypeOf((*MockQAStore)(nil).ListSuggestedEdits), ctx, targetType, targetID, status, offset, limit)
+}
+
+// ListUsersForExport mocks base method.
+func (m *MockQAStore) ListUsersForExport(ctx context.Context, afterID int64, limit int32) ([]*model.ContentUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsersForExport", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*model.ContentUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsersForExport indicates an expected call of ListUsersForExport.
+func (mr *MockQAStoreMockRecorder) ListUsersForExport(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersForExport", reflect.TypeOf((*MockQAStore)(nil).ListUsersForExport), ctx, afterID, limit)
+}
+
+// ListVotesForExport mocks base method.
+func (m *MockQAStore) ListVotesForExport(ctx context.Context, afterID int64, limit int32) ([]*model.ContentVote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVotesForExport", ctx, afterID, limit)
+	ret0, _ := ret[0].([]*model.ContentVote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVotesForExport indicates an expected call of ListVotesForExport.
+func (mr *MockQAStoreMockRecorder) ListVotesForExport(ctx, afterID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVotesForExport", reflect.TypeOf((*MockQAStore)(nil).ListVotesForExport), ctx, afterID, limit)
+}
+
+// RecountAnswerUpvotes mocks base method.
+func (m *MockQAStore) RecountAnswerUpvotes(ctx context.Context, answerID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecountAnswerUpvotes", ctx, answerID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecountAnswerUpvotes indicates an expected call of RecountAnswerUpvotes.
+func (mr *MockQAStoreMockRecorder) RecountAnswerUpvotes(ctx, answerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecountAnswerUpvotes", reflect.TypeOf((*MockQAStore)(nil).RecountAnswerUpvotes), ctx, answerID)
+}
+
+// RecountQuestionCounters mocks base method.
+func (m *MockQAStore) RecountQuestionCounters(ctx context.Context, questionID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecountQuestionCounters", ctx, questionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecountQuestionCounters indicates an expected call of RecountQuestionCounters.
+func (mr *MockQAStoreMockRecorder) RecountQuestionCounters(ctx, questionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecountQuestionCounters", reflect.TypeOf((*MockQAStore)(nil).RecountQuestionCounters), ctx, questionID)
+}
+
+// ReviewSuggestedEdit mocks base method.
+func (m *MockQAStore) ReviewSuggestedEdit(ctx context.Context, editID int64, status string, reviewerID int64, comment string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewSuggestedEdit", ctx, editID, status, reviewerID, comment)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewSuggestedEdit indicates an expected call of ReviewSuggestedEdit.
+func (mr *MockQAStoreMockRecorder) ReviewSuggestedEdit(ctx, editID, status, reviewerID, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewSuggestedEdit", reflect.TypeOf((*MockQAStore)(nil).ReviewSuggestedEdit), ctx, editID, status, reviewerID, comment)
+}
+
+// SetAcceptedAnswer mocks base method.
+func (m *MockQAStore) SetAcceptedAnswer(ctx context.Context, questionID, answerID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAcceptedAnswer", ctx, questionID, answerID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAcceptedAnswer indicates an expected call of SetAcceptedAnswer.
+func (mr *MockQAStoreMockRecorder) SetAcceptedAnswer(ctx, questionID, answerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAcceptedAnswer", reflect.TypeOf((*MockQAStore)(nil).SetAcceptedAnswer), ctx, questionID, answerID)
+}
+
+// TouchQuestionActivity mocks base method.
+func (m *MockQAStore) TouchQuestionActivity(ctx context.Context, questionID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchQuestionActivity", ctx, questionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchQuestionActivity indicates an expected call of TouchQuestionActivity.
+func (mr *MockQAStoreMockRecorder) TouchQuestionActivity(ctx, questionID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListQuestionsByUserID", reflect.TypeOf((*MockQAStore)(nil).ListQuestionsByUserID), ctx, userID, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchQuestionActivity", reflect.TypeOf((*MockQAStore)(nil).TouchQuestionActivity), ctx, questionID)
 }
 
 // UpdateAnswer mocks base method.
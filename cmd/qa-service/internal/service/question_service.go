@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"time"
 
 	"qahub/pkg/auth"
 	"qahub/pkg/log"
@@ -11,16 +12,18 @@ import (
 	"qahub/pkg/pagination"
 	"qahub/qa-service/internal/dto"
 	"qahub/qa-service/internal/model"
+	"qahub/qa-service/internal/store"
 )
 
 // CreateQuestion 创建一个新问题
-func (s *qaService) CreateQuestion(ctx context.Context, title, content string, userID int64) (*model.Question, error) {
+func (s *qaService) CreateQuestion(ctx context.Context, title, content string, anonymous bool, userID int64) (*model.Question, error) {
 	logger := log.FromContext(ctx)
 
 	question := &model.Question{
-		Title:   title,
-		Content: content,
-		UserID:  userID,
+		Title:       title,
+		Content:     content,
+		UserID:      userID,
+		IsAnonymous: anonymous,
 	}
 	questionID, err := s.store.CreateQuestion(ctx, question)
 	if err != nil {
@@ -69,24 +72,77 @@ func (s *qaService) GetQuestion(ctx context.Context, questionID int64) (*dto.Que
 		return nil, errors.New("问题未找到")
 	}
 
-	usernames, err := s.store.GetUsernamesByIDs(ctx, []int64{question.UserID})
+	responses, err := s.buildQuestionResponses(ctx, []*model.Question{question})
 	if err != nil {
 		return nil, err
 	}
-	authorName := usernames[question.UserID]
+	response := responses[0]
 
-	answerCounts, err := s.store.GetAnswerCountByQuestionIDs(ctx, []int64{question.ID})
+	// 浏览量只用于热门排行，异步累加，不影响详情页的响应
+	var viewerID int64
+	if identity, ok := auth.FromContext(ctx); ok {
+		viewerID = identity.UserID
+	}
+	go s.recordQuestionView(question.ID, viewerID)
+
+	return response, nil
+}
+
+// recordQuestionView 累加问题的浏览量并发布浏览事件
+func (s *qaService) recordQuestionView(questionID, viewerID int64) {
+	viewCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := s.store.IncrementQuestionViewCount(viewCtx, questionID); err != nil {
+		return
+	}
+	s.publishQuestionViewedEvent(viewCtx, questionID, viewerID)
+}
+
+// BatchGetQuestions 批量获取问题详情，结果按请求中 ID 的顺序排列
+func (s *qaService) BatchGetQuestions(ctx context.Context, questionIDs []int64) ([]*dto.QuestionResponse, []int64, error) {
+	logger := log.FromContext(ctx)
+
+	ids, err := normalizeBatchIDs(questionIDs)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	questions, err := s.store.GetQuestionsByIDs(ctx, ids)
+	if err != nil {
+		logger.Error("批量获取问题失败",
+			slog.Int("count", len(ids)),
+			slog.String("error", err.Error()),
+		)
+		return nil, nil, err
 	}
-	answerCount := answerCounts[question.ID]
 
-	response := &dto.QuestionResponse{
-		Question:    *question,
-		AuthorName:  authorName,
-		AnswerCount: answerCount,
+	byID := make(map[int64]*model.Question, len(questions))
+	for _, q := range questions {
+		byID[q.ID] = q
 	}
-	return response, nil
+	ordered := make([]*model.Question, 0, len(questions))
+	notFound := make([]int64, 0)
+	for _, id := range ids {
+		if q, ok := byID[id]; ok {
+			ordered = append(ordered, q)
+		} else {
+			notFound = append(notFound, id)
+		}
+	}
+
+	responses, err := s.buildQuestionResponses(ctx, ordered)
+	if err != nil {
+		logger.Error("构建问题响应失败",
+			slog.String("error", err.Error()),
+		)
+		return nil, nil, err
+	}
+
+	logger.Debug("批量获取问题成功",
+		slog.Int("requested", len(ids)),
+		slog.Int("not_found", len(notFound)),
+	)
+	return responses, notFound, nil
 }
 
 // ListQuestions 返回分页的问题列表和总数
@@ -97,10 +153,8 @@ func (s *qaService) buildQuestionResponses(ctx context.Context, questions []*mod
 		return responses, nil
 	}
 
-	questionIDs := make([]int64, 0, len(questions))
 	userIDSet := make(map[int64]struct{})
 	for _, q := range questions {
-		questionIDs = append(questionIDs, q.ID)
 		userIDSet[q.UserID] = struct{}{}
 	}
 
@@ -113,18 +167,29 @@ func (s *qaService) buildQuestionResponses(ctx context.Context, questions []*mod
 	if err != nil {
 		return nil, err
 	}
-
-	answerCounts, err := s.store.GetAnswerCountByQuestionIDs(ctx, questionIDs)
+	userTypes, err := s.store.GetUserTypesByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	avatars, err := s.store.GetUserAvatarsByIDs(ctx, userIDs)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, q := range questions {
-		responses = append(responses, &dto.QuestionResponse{
-			Question:    *q,
-			AuthorName:  usernames[q.UserID],
-			AnswerCount: answerCounts[q.ID],
-		})
+		response := &dto.QuestionResponse{
+			Question:        *q,
+			AuthorName:      usernames[q.UserID],
+			AuthorIsBot:     userTypes[q.UserID] == auth.UserTypeBot,
+			AuthorAvatarURL: authorAvatarURL(avatars[q.UserID]),
+		}
+		if q.IsAnonymous && !canSeeAuthor(ctx, q.UserID) {
+			response.UserID = 0
+			response.AuthorName = AnonymousAuthorName
+			response.AuthorIsBot = false
+			response.AuthorAvatarURL = ""
+		}
+		responses = append(responses, response)
 	}
 
 	return responses, nil
@@ -170,8 +235,10 @@ func (s *qaService) ListQuestions(ctx context.Context, page int64, pageSize int3
 func (s *qaService) ListQuestionsByUserID(ctx context.Context, userID int64, page int64, pageSize int32) ([]*dto.QuestionResponse, int64, error) {
 	logger := log.FromContext(ctx)
 	
+	// 他人匿名发布的问题不出现在其个人主页中
+	includeAnonymous := canSeeAuthor(ctx, userID)
 	limit, offset := pagination.CalculateOffset(page, pageSize)
-	questions, err := s.store.ListQuestionsByUserID(ctx, userID, offset, limit)
+	questions, err := s.store.ListQuestionsByUserID(ctx, userID, includeAnonymous, offset, limit)
 	if err != nil {
 		logger.Error("按用户ID列表查询问题失败",
 			slog.Int64("user_id", userID),
@@ -181,6 +248,14 @@ func (s *qaService) ListQuestionsByUserID(ctx context.Context, userID int64, pag
 		)
 		return nil, 0, err
 	}
+	count, err := s.store.CountQuestionsByUserID(ctx, userID, includeAnonymous)
+	if err != nil {
+		logger.Error("统计用户问题失败",
+			slog.Int64("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return nil, 0, err
+	}
 	responses, err := s.buildQuestionResponses(ctx, questions)
 	if err != nil {
 		logger.Error("构建问题响应失败",
@@ -188,7 +263,6 @@ func (s *qaService) ListQuestionsByUserID(ctx context.Context, userID int64, pag
 		)
 		return nil, 0, err
 	}
-	count := int64(len(questions))
 	
 	logger.Debug("用户问题列表查询成功",
 		slog.Int64("user_id", userID),
@@ -218,9 +292,7 @@ func (s *qaService) UpdateQuestion(ctx context.Context, questionID int64, title,
 		)
 		return nil, errors.New("无权限修改该问题")
 	}
-	question.Title = title
-	question.Content = content
-	if err := s.store.UpdateQuestion(ctx, question); err != nil {
+	if err := s.applyQuestionEdit(ctx, s.store, question, title, content); err != nil {
 		logger.Error("更新问题失败",
 			slog.Int64("question_id", questionID),
 			slog.String("error", err.Error()),
@@ -233,15 +305,26 @@ func (s *qaService) UpdateQuestion(ctx context.Context, questionID int64, title,
 		slog.Int64("user_id", userID),
 	)
 
+	s.publishQuestionUpdated(ctx, question, userID)
+	return question, nil
+}
+
+// applyQuestionEdit 写入问题的新标题和内容，UpdateQuestion 和通过修改建议共用这一写入路径，
+// 调用方负责权限校验，st 可以是事务内的 store
+func (s *qaService) applyQuestionEdit(ctx context.Context, st store.QAStore, question *model.Question, title, content string) error {
+	question.Title = title
+	question.Content = content
+	return st.UpdateQuestion(ctx, question)
+}
+
+// publishQuestionUpdated 在后台发布问题更新事件到 Kafka
+func (s *qaService) publishQuestionUpdated(ctx context.Context, question *model.Question, userID int64) {
 	identity, _ := auth.FromContext(ctx)
 	if identity.UserID == 0 {
 		identity.UserID = userID
 	}
 	eventCtx := auth.WithIdentity(context.Background(), identity)
-	// 发布问题更新事件到 Kafka
 	go s.publishQuestionEvent(eventCtx, messaging.EventQuestionUpdated, question)
-
-	return question, nil
 }
 
 func (s *qaService) DeleteQuestion(ctx context.Context, questionID, userID int64) error {
@@ -5,9 +5,11 @@ package service_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"qahub/pkg/auth"
 	"qahub/pkg/config"
 	"qahub/pkg/messaging"
 	"qahub/qa-service/internal/model"
@@ -23,7 +25,7 @@ func TestCreateQuestion(t *testing.T) {
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 	ctx := context.Background()
 
 	t.Run("成功创建问题", func(t *testing.T) {
@@ -44,7 +46,7 @@ func TestCreateQuestion(t *testing.T) {
 			Times(1)
 
 		// 执行测试
-		result, err := qaService.CreateQuestion(ctx, title, content, userID)
+		result, err := qaService.CreateQuestion(ctx, title, content, false, userID)
 
 		// 验证结果
 		assert.NoError(t, err)
@@ -67,7 +69,7 @@ func TestCreateQuestion(t *testing.T) {
 			Times(1)
 
 		// 执行测试
-		result, err := qaService.CreateQuestion(ctx, title, content, userID)
+		result, err := qaService.CreateQuestion(ctx, title, content, false, userID)
 
 		// 验证结果
 		assert.Error(t, err)
@@ -82,17 +84,18 @@ func TestGetQuestion(t *testing.T) {
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 	ctx := context.Background()
 
 	t.Run("成功获取问题详情", func(t *testing.T) {
 		questionID := int64(1)
 		question := &model.Question{
-			ID:        questionID,
-			Title:     "测试问题",
-			Content:   "测试内容",
-			UserID:    100,
-			CreatedAt: time.Now(),
+			ID:          questionID,
+			Title:       "测试问题",
+			Content:     "测试内容",
+			UserID:      100,
+			CreatedAt:   time.Now(),
+			AnswerCount: 5,
 		}
 
 		// Mock: 获取问题
@@ -106,13 +109,21 @@ func TestGetQuestion(t *testing.T) {
 			GetUsernamesByIDs(ctx, []int64{100}).
 			Return(map[int64]string{100: "testuser"}, nil).
 			Times(1)
-
-		// Mock: 获取回答数量
 		mockStore.EXPECT().
-			GetAnswerCountByQuestionIDs(ctx, []int64{questionID}).
-			Return(map[int64]int64{questionID: 5}, nil).
+			GetUserTypesByIDs(ctx, []int64{100}).
+			Return(map[int64]string{100: auth.UserTypeBot}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserAvatarsByIDs(ctx, []int64{100}).
+			Return(map[int64]string{100: "avatars/100/3f2a"}, nil).
 			Times(1)
 
+		// Mock: 异步累加浏览量
+		mockStore.EXPECT().
+			IncrementQuestionViewCount(gomock.Any(), questionID).
+			Return(nil).
+			AnyTimes()
+
 		// 执行测试
 		result, err := qaService.GetQuestion(ctx, questionID)
 
@@ -122,9 +133,70 @@ func TestGetQuestion(t *testing.T) {
 		assert.Equal(t, questionID, result.ID)
 		assert.Equal(t, "测试问题", result.Title)
 		assert.Equal(t, "testuser", result.AuthorName)
+		assert.True(t, result.AuthorIsBot)
+		assert.True(t, strings.HasSuffix(result.AuthorAvatarURL, "/avatars/100/3f2a/64.png"))
 		assert.Equal(t, int64(5), result.AnswerCount)
 	})
 
+	t.Run("匿名问题对其他用户隐藏作者", func(t *testing.T) {
+		questionID := int64(2)
+		question := &model.Question{ID: questionID, Title: "匿名问题", UserID: 100, IsAnonymous: true}
+		viewerCtx := auth.WithIdentity(ctx, auth.Identity{UserID: 200})
+
+		mockStore.EXPECT().GetQuestionByID(viewerCtx, questionID).Return(question, nil).Times(1)
+		mockStore.EXPECT().
+			GetUsernamesByIDs(viewerCtx, []int64{100}).
+			Return(map[int64]string{100: "testuser"}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserTypesByIDs(viewerCtx, []int64{100}).
+			Return(map[int64]string{100: auth.UserTypeBot}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserAvatarsByIDs(viewerCtx, []int64{100}).
+			Return(map[int64]string{100: "avatars/100/3f2a"}, nil).
+			Times(1)
+		mockStore.EXPECT().IncrementQuestionViewCount(gomock.Any(), questionID).Return(nil).AnyTimes()
+
+		result, err := qaService.GetQuestion(viewerCtx, questionID)
+
+		assert.NoError(t, err)
+		assert.Zero(t, result.UserID)
+		assert.Equal(t, service.AnonymousAuthorName, result.AuthorName)
+		assert.False(t, result.AuthorIsBot, "匿名问题不应暴露作者是否为机器人")
+		assert.Empty(t, result.AuthorAvatarURL, "匿名问题不应暴露作者头像")
+		assert.True(t, result.IsAnonymous)
+		// 缓存中的问题不应被修改
+		assert.Equal(t, int64(100), question.UserID)
+	})
+
+	t.Run("匿名问题对作者本人可见", func(t *testing.T) {
+		questionID := int64(3)
+		question := &model.Question{ID: questionID, Title: "匿名问题", UserID: 100, IsAnonymous: true}
+		authorCtx := auth.WithIdentity(ctx, auth.Identity{UserID: 100})
+
+		mockStore.EXPECT().GetQuestionByID(authorCtx, questionID).Return(question, nil).Times(1)
+		mockStore.EXPECT().
+			GetUsernamesByIDs(authorCtx, []int64{100}).
+			Return(map[int64]string{100: "testuser"}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserTypesByIDs(authorCtx, []int64{100}).
+			Return(map[int64]string{100: auth.UserTypeHuman}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserAvatarsByIDs(authorCtx, []int64{100}).
+			Return(map[int64]string{}, nil).
+			Times(1)
+		mockStore.EXPECT().IncrementQuestionViewCount(gomock.Any(), questionID).Return(nil).AnyTimes()
+
+		result, err := qaService.GetQuestion(authorCtx, questionID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), result.UserID)
+		assert.Equal(t, "testuser", result.AuthorName)
+	})
+
 	t.Run("问题不存在", func(t *testing.T) {
 		questionID := int64(999)
 
@@ -167,7 +239,7 @@ func TestListQuestions(t *testing.T) {
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 	ctx := context.Background()
 
 	t.Run("成功获取问题列表", func(t *testing.T) {
@@ -175,8 +247,8 @@ func TestListQuestions(t *testing.T) {
 		pageSize := int32(10)
 
 		questions := []*model.Question{
-			{ID: 1, Title: "问题1", Content: "内容1", UserID: 100},
-			{ID: 2, Title: "问题2", Content: "内容2", UserID: 101},
+			{ID: 1, Title: "问题1", Content: "内容1", UserID: 100, AnswerCount: 3},
+			{ID: 2, Title: "问题2", Content: "内容2", UserID: 101, AnswerCount: 5},
 		}
 
 		// Mock: 获取问题列表
@@ -196,11 +268,13 @@ func TestListQuestions(t *testing.T) {
 			GetUsernamesByIDs(ctx, gomock.Any()).
 			Return(map[int64]string{100: "user1", 101: "user2"}, nil).
 			Times(1)
-
-		// Mock: 获取回答数量 (使用 gomock.Any() 因为切片可能按任意顺序)
 		mockStore.EXPECT().
-			GetAnswerCountByQuestionIDs(ctx, gomock.Any()).
-			Return(map[int64]int64{1: 3, 2: 5}, nil).
+			GetUserTypesByIDs(ctx, gomock.Any()).
+			Return(map[int64]string{100: auth.UserTypeHuman, 101: auth.UserTypeHuman}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserAvatarsByIDs(ctx, gomock.Any()).
+			Return(map[int64]string{}, nil).
 			Times(1)
 
 		// 执行测试
@@ -240,13 +314,79 @@ func TestListQuestions(t *testing.T) {
 	})
 }
 
+func TestBatchGetQuestions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
+	ctx := context.Background()
+
+	t.Run("按请求顺序返回并报告不存在的ID", func(t *testing.T) {
+		// Mock: 数据库按 ID 升序返回，且缺少 ID 2
+		mockStore.EXPECT().
+			GetQuestionsByIDs(ctx, []int64{3, 1, 2}).
+			Return([]*model.Question{
+				{ID: 1, Title: "问题1", UserID: 100, AnswerCount: 2},
+				{ID: 3, Title: "问题3", UserID: 101},
+			}, nil).
+			Times(1)
+
+		mockStore.EXPECT().
+			GetUsernamesByIDs(ctx, gomock.Any()).
+			Return(map[int64]string{100: "user1", 101: "user2"}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserTypesByIDs(ctx, gomock.Any()).
+			Return(map[int64]string{100: auth.UserTypeHuman, 101: auth.UserTypeHuman}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserAvatarsByIDs(ctx, gomock.Any()).
+			Return(map[int64]string{}, nil).
+			Times(1)
+
+		// 执行测试，重复的 ID 只返回一次
+		result, notFound, err := qaService.BatchGetQuestions(ctx, []int64{3, 1, 3, 2})
+
+		// 验证结果
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, int64(3), result[0].ID)
+		assert.Equal(t, "user2", result[0].AuthorName)
+		assert.Equal(t, int64(1), result[1].ID)
+		assert.Equal(t, int64(2), result[1].AnswerCount)
+		assert.Equal(t, []int64{2}, notFound)
+	})
+
+	t.Run("ID列表为空", func(t *testing.T) {
+		result, notFound, err := qaService.BatchGetQuestions(ctx, nil)
+
+		assert.ErrorIs(t, err, service.ErrEmptyBatch)
+		assert.Nil(t, result)
+		assert.Nil(t, notFound)
+	})
+
+	t.Run("超过最大批量", func(t *testing.T) {
+		ids := make([]int64, service.MaxBatchSize+1)
+		for i := range ids {
+			ids[i] = int64(i + 1)
+		}
+
+		result, _, err := qaService.BatchGetQuestions(ctx, ids)
+
+		assert.ErrorIs(t, err, service.ErrBatchTooLarge)
+		assert.Nil(t, result)
+	})
+}
+
 func TestUpdateQuestion(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 	ctx := context.Background()
 
 	t.Run("成功更新问题", func(t *testing.T) {
@@ -341,7 +481,7 @@ func TestDeleteQuestion(t *testing.T) {
 
 	mockStore := service.NewMockQAStore(ctrl)
 	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
-	qaService := service.NewQAService(mockStore, producer, &config.Conf)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
 	ctx := context.Background()
 
 	t.Run("成功删除问题", func(t *testing.T) {
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/config"
+	"qahub/pkg/log"
+	"qahub/qa-service/internal/dto"
+	"qahub/qa-service/internal/model"
+	"qahub/qa-service/internal/store"
+)
+
+const defaultReconcileInterval = time.Hour
+
+// ReconcileService 校对问题的回答数、评论数和回答的点赞数，
+// 这些冗余计数在写入时增量维护，失败的事务或绕过服务直接修改源表都可能使其产生偏差
+type ReconcileService interface {
+	// ReconcileCounters 供管理员按需执行，dryRun 为 true 时只报告偏差
+	ReconcileCounters(ctx context.Context, dryRun bool) (*dto.ReconcileReport, error)
+	// Run 按配置的间隔定期校对并修正，直到 ctx 结束
+	Run(ctx context.Context)
+}
+
+type reconcileService struct {
+	store    store.QAStore
+	interval time.Duration
+}
+
+// NewReconcileService 创建一个新的 ReconcileService
+func NewReconcileService(s store.QAStore) *reconcileService {
+	interval := config.Conf.Services.QAService.ReconcileInterval
+	if interval <= 0 {
+		interval = defaultReconcileInterval
+	}
+	return &reconcileService{
+		store:    s,
+		interval: interval,
+	}
+}
+
+func (s *reconcileService) ReconcileCounters(ctx context.Context, dryRun bool) (*dto.ReconcileReport, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || !identity.IsAdmin() {
+		return nil, ErrAdminRequired
+	}
+	return s.reconcile(ctx, dryRun)
+}
+
+func (s *reconcileService) reconcile(ctx context.Context, dryRun bool) (*dto.ReconcileReport, error) {
+	logger := log.FromContext(ctx)
+
+	drifts, err := s.store.ListCounterDrifts(ctx)
+	if err != nil {
+		logger.Error("统计计数偏差失败",
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	report := &dto.ReconcileReport{Drifts: drifts}
+	for _, d := range drifts {
+		logger.Warn("发现计数偏差",
+			slog.String("counter", d.Counter),
+			slog.Int64("id", d.ID),
+			slog.Int64("stored", d.Stored),
+			slog.Int64("expected", d.Expected),
+		)
+	}
+	if dryRun || len(drifts) == 0 {
+		return report, nil
+	}
+
+	// 同一个问题的回答数和评论数由一条语句一起修正，只需执行一次
+	recounted := make(map[int64]error)
+	for _, d := range drifts {
+		var err error
+		switch d.Counter {
+		case model.CounterQuestionAnswers, model.CounterQuestionComments:
+			prev, done := recounted[d.ID]
+			if done {
+				err = prev
+			} else {
+				err = s.store.RecountQuestionCounters(ctx, d.ID)
+				recounted[d.ID] = err
+			}
+		case model.CounterAnswerUpvotes:
+			err = s.store.RecountAnswerUpvotes(ctx, d.ID)
+		default:
+			continue
+		}
+		if err != nil {
+			logger.Error("修正计数失败",
+				slog.String("counter", d.Counter),
+				slog.Int64("id", d.ID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		report.Fixed++
+	}
+
+	logger.Info("计数校对完成",
+		slog.Int("drifts", len(drifts)),
+		slog.Int("fixed", report.Fixed),
+	)
+	return report, nil
+}
+
+func (s *reconcileService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// 错误已在 reconcile 中记录，等待下一轮重试
+			_, _ = s.reconcile(ctx, false)
+		}
+	}
+}
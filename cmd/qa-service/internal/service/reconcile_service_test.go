@@ -0,0 +1,94 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"qahub/pkg/auth"
+	"qahub/qa-service/internal/model"
+	"qahub/qa-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReconcileCounters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	reconcileService := service.NewReconcileService(mockStore)
+	adminCtx := auth.WithIdentity(context.Background(), auth.Identity{
+		UserID: 1,
+		Claims: map[string]any{"role": auth.RoleAdmin},
+	})
+
+	t.Run("非管理员无权校对", func(t *testing.T) {
+		ctx := auth.WithIdentity(context.Background(), auth.Identity{UserID: 2})
+
+		report, err := reconcileService.ReconcileCounters(ctx, true)
+
+		assert.ErrorIs(t, err, service.ErrAdminRequired)
+		assert.Nil(t, report)
+	})
+
+	t.Run("试运行只报告偏差", func(t *testing.T) {
+		drifts := []*model.CounterDrift{
+			{Counter: model.CounterQuestionAnswers, ID: 1, Stored: 3, Expected: 2},
+		}
+		mockStore.EXPECT().
+			ListCounterDrifts(adminCtx).
+			Return(drifts, nil).
+			Times(1)
+
+		report, err := reconcileService.ReconcileCounters(adminCtx, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, drifts, report.Drifts)
+		assert.Equal(t, 0, report.Fixed)
+	})
+
+	t.Run("同一问题的多个偏差只重算一次", func(t *testing.T) {
+		mockStore.EXPECT().
+			ListCounterDrifts(adminCtx).
+			Return([]*model.CounterDrift{
+				{Counter: model.CounterQuestionAnswers, ID: 1, Stored: 3, Expected: 2},
+				{Counter: model.CounterQuestionComments, ID: 1, Stored: 0, Expected: 4},
+				{Counter: model.CounterAnswerUpvotes, ID: 7, Stored: 5, Expected: 6},
+			}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			RecountQuestionCounters(adminCtx, int64(1)).
+			Return(nil).
+			Times(1)
+		mockStore.EXPECT().
+			RecountAnswerUpvotes(adminCtx, int64(7)).
+			Return(nil).
+			Times(1)
+
+		report, err := reconcileService.ReconcileCounters(adminCtx, false)
+
+		assert.NoError(t, err)
+		assert.Len(t, report.Drifts, 3)
+		assert.Equal(t, 3, report.Fixed)
+	})
+
+	t.Run("修正失败不计入已修正数", func(t *testing.T) {
+		mockStore.EXPECT().
+			ListCounterDrifts(adminCtx).
+			Return([]*model.CounterDrift{
+				{Counter: model.CounterAnswerUpvotes, ID: 7, Stored: 5, Expected: 6},
+			}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			RecountAnswerUpvotes(adminCtx, int64(7)).
+			Return(errors.New("database error")).
+			Times(1)
+
+		report, err := reconcileService.ReconcileCounters(adminCtx, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, report.Fixed)
+	})
+}
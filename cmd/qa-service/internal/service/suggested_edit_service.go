@@ -0,0 +1,433 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/log"
+	"qahub/pkg/messaging"
+	"qahub/pkg/pagination"
+	"qahub/qa-service/internal/dto"
+	"qahub/qa-service/internal/model"
+	"qahub/qa-service/internal/store"
+)
+
+var (
+	// ErrInvalidEditTarget 表示修改建议的目标类型不受支持
+	ErrInvalidEditTarget = errors.New("修改建议只能针对问题或回答")
+	// ErrEditTargetNotFound 表示修改建议指向的问题或回答不存在
+	ErrEditTargetNotFound = errors.New("修改建议的目标不存在")
+	// ErrEmptySuggestedEdit 表示修改建议缺少标题或内容
+	ErrEmptySuggestedEdit = errors.New("修改建议的标题和内容不能为空")
+	// ErrSuggestedEditUnchanged 表示修改建议与当前内容相同
+	ErrSuggestedEditUnchanged = errors.New("修改建议与当前内容相同")
+	// ErrSuggestOwnPost 表示作者应直接编辑自己的内容
+	ErrSuggestOwnPost = errors.New("不能对自己的内容提出修改建议，请直接编辑")
+	// ErrSuggestedEditNotFound 表示修改建议不存在
+	ErrSuggestedEditNotFound = errors.New("修改建议不存在")
+	// ErrSuggestedEditReviewed 表示修改建议已经被审核过
+	ErrSuggestedEditReviewed = errors.New("该修改建议已经被审核")
+	// ErrNotEditReviewer 表示只有作者或版主可以审核修改建议
+	ErrNotEditReviewer = errors.New("只有作者或版主可以审核修改建议")
+	// ErrSuggestedEditStale 表示提出建议后目标已被修改，建议无法直接应用
+	ErrSuggestedEditStale = errors.New("内容在提出建议后已被修改，该建议已过期")
+)
+
+// editTarget 是修改建议指向的问题或回答
+type editTarget struct {
+	question *model.Question // 目标是回答时为回答所属的问题，用于通知
+	answer   *model.Answer   // 目标是问题时为 nil
+}
+
+func (t *editTarget) ownerID() int64 {
+	if t.answer != nil {
+		return t.answer.UserID
+	}
+	return t.question.UserID
+}
+
+// anonymous 返回目标是否为匿名发布
+func (t *editTarget) anonymous() bool {
+	if t.answer != nil {
+		return t.answer.IsAnonymous
+	}
+	return t.question.IsAnonymous
+}
+
+// title 返回目标当前的标题，回答没有标题
+func (t *editTarget) title() string {
+	if t.answer != nil {
+		return ""
+	}
+	return t.question.Title
+}
+
+func (t *editTarget) content() string {
+	if t.answer != nil {
+		return t.answer.Content
+	}
+	return t.question.Content
+}
+
+// targetType 返回目标的类型，用作通知模板参数
+func (t *editTarget) targetType() string {
+	if t.answer != nil {
+		return model.EditTargetAnswer
+	}
+	return model.EditTargetQuestion
+}
+
+func (t *editTarget) url() string {
+	if t.answer != nil {
+		return fmt.Sprintf("/questions/%d#answer-%d", t.question.ID, t.answer.ID)
+	}
+	return fmt.Sprintf("/questions/%d", t.question.ID)
+}
+
+// loadEditTarget 读取修改建议指向的问题或回答
+func (s *qaService) loadEditTarget(ctx context.Context, targetType string, targetID int64) (*editTarget, error) {
+	target := &editTarget{}
+	questionID := targetID
+	switch targetType {
+	case model.EditTargetQuestion:
+	case model.EditTargetAnswer:
+		answer, err := s.store.GetAnswerByID(ctx, targetID)
+		if err != nil {
+			return nil, notFoundAs(err, ErrEditTargetNotFound)
+		}
+		target.answer = answer
+		questionID = answer.QuestionID
+	default:
+		return nil, ErrInvalidEditTarget
+	}
+
+	question, err := s.store.GetQuestionByID(ctx, questionID)
+	if err != nil {
+		return nil, notFoundAs(err, ErrEditTargetNotFound)
+	}
+	if question == nil {
+		return nil, ErrEditTargetNotFound
+	}
+	target.question = question
+	return target, nil
+}
+
+// notFoundAs 将数据库的记录不存在错误转换为业务错误
+func notFoundAs(err, notFound error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return notFound
+	}
+	return err
+}
+
+// SuggestEdit 为他人的问题或回答提出修改建议，回答没有标题，title 会被忽略
+func (s *qaService) SuggestEdit(ctx context.Context, targetType string, targetID int64, title, content, reason string, userID int64) (*model.SuggestedEdit, error) {
+	logger := log.FromContext(ctx)
+
+	target, err := s.loadEditTarget(ctx, targetType, targetID)
+	if err != nil {
+		logger.Warn("获取修改建议目标失败",
+			slog.String("target_type", targetType),
+			slog.Int64("target_id", targetID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	if target.answer != nil {
+		title = ""
+	}
+	if content == "" || (target.answer == nil && title == "") {
+		return nil, ErrEmptySuggestedEdit
+	}
+	if target.ownerID() == userID {
+		return nil, ErrSuggestOwnPost
+	}
+	if title == target.title() && content == target.content() {
+		return nil, ErrSuggestedEditUnchanged
+	}
+
+	edit := &model.SuggestedEdit{
+		TargetType:  targetType,
+		TargetID:    targetID,
+		ProposerID:  userID,
+		BaseTitle:   target.title(),
+		BaseContent: target.content(),
+		Title:       title,
+		Content:     content,
+		Reason:      reason,
+		Status:      model.EditStatusPending,
+		CreatedAt:   time.Now(),
+	}
+	id, err := s.store.CreateSuggestedEdit(ctx, edit)
+	if err != nil {
+		logger.Error("创建修改建议失败",
+			slog.String("target_type", targetType),
+			slog.Int64("target_id", targetID),
+			slog.Int64("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	edit.ID = id
+
+	logger.Info("修改建议创建成功",
+		slog.Int64("edit_id", id),
+		slog.String("target_type", targetType),
+		slog.Int64("target_id", targetID),
+		slog.Int64("user_id", userID),
+	)
+
+	identity, _ := auth.FromContext(ctx)
+	go func(senderUsername string) {
+		notifyCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		s.publishNotificationEvent(notifyCtx, messaging.NotificationPayload{
+			RecipientID:      target.ownerID(),
+			SenderID:         userID,
+			SenderName:       senderUsername,
+			NotificationType: messaging.NotificationTypeEditSuggested,
+			TargetURL:        fmt.Sprintf("%s?suggested_edit=%d", target.url(), id),
+			Params: map[string]string{
+				messaging.NotificationParamSender:        senderUsername,
+				messaging.NotificationParamQuestionTitle: target.question.Title,
+				messaging.NotificationParamTarget:        target.targetType(),
+			},
+		})
+	}(identity.Username)
+
+	return edit, nil
+}
+
+// ListSuggestedEdits 列出某个问题或回答的修改建议，status 为空时列出全部状态
+func (s *qaService) ListSuggestedEdits(ctx context.Context, targetType string, targetID int64, status string, page int64, pageSize int32) ([]*dto.SuggestedEditResponse, int64, error) {
+	if targetType != model.EditTargetQuestion && targetType != model.EditTargetAnswer {
+		return nil, 0, ErrInvalidEditTarget
+	}
+
+	limit, offset := pagination.CalculateOffset(page, pageSize)
+	edits, err := s.store.ListSuggestedEdits(ctx, targetType, targetID, status, offset, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	count, err := s.store.CountSuggestedEdits(ctx, targetType, targetID, status)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(edits) == 0 {
+		return []*dto.SuggestedEditResponse{}, count, nil
+	}
+
+	userIDSet := make(map[int64]struct{})
+	for _, edit := range edits {
+		userIDSet[edit.ProposerID] = struct{}{}
+	}
+	userIDs := make([]int64, 0, len(userIDSet))
+	for id := range userIDSet {
+		userIDs = append(userIDs, id)
+	}
+	usernames, err := s.store.GetUsernamesByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 匿名内容的作者亲自审核时，审核人ID会暴露作者身份
+	var hiddenReviewerID int64
+	if slices.ContainsFunc(edits, func(e *model.SuggestedEdit) bool { return e.ReviewerID != 0 }) {
+		target, err := s.loadEditTarget(ctx, targetType, targetID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if target.anonymous() && !canSeeAuthor(ctx, target.ownerID()) {
+			hiddenReviewerID = target.ownerID()
+		}
+	}
+
+	responses := make([]*dto.SuggestedEditResponse, len(edits))
+	for i, edit := range edits {
+		responses[i] = &dto.SuggestedEditResponse{
+			SuggestedEdit: *edit,
+			ProposerName:  usernames[edit.ProposerID],
+		}
+		if hiddenReviewerID != 0 && edit.ReviewerID == hiddenReviewerID {
+			responses[i].ReviewerID = 0
+		}
+	}
+	return responses, count, nil
+}
+
+// ReviewSuggestedEdit 由作者或版主通过或拒绝修改建议，
+// 通过时在同一事务中记录审核结果并按 UpdateQuestion / UpdateAnswer 的写入路径应用修改
+func (s *qaService) ReviewSuggestedEdit(ctx context.Context, editID int64, approve bool, comment string, userID int64) (*model.SuggestedEdit, error) {
+	logger := log.FromContext(ctx)
+
+	edit, err := s.store.GetSuggestedEditByID(ctx, editID)
+	if err != nil {
+		err = notFoundAs(err, ErrSuggestedEditNotFound)
+		logger.Warn("获取修改建议失败",
+			slog.Int64("edit_id", editID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	if edit.Status != model.EditStatusPending {
+		return nil, ErrSuggestedEditReviewed
+	}
+
+	target, err := s.loadEditTarget(ctx, edit.TargetType, edit.TargetID)
+	if err != nil {
+		logger.Warn("获取修改建议目标失败",
+			slog.Int64("edit_id", editID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	identity, _ := auth.FromContext(ctx)
+	if target.ownerID() != userID && !identity.IsModerator() {
+		logger.Warn("无权限审核修改建议",
+			slog.Int64("edit_id", editID),
+			slog.Int64("user_id", userID),
+			slog.Int64("owner_id", target.ownerID()),
+		)
+		return nil, ErrNotEditReviewer
+	}
+
+	status := model.EditStatusRejected
+	if approve {
+		status = model.EditStatusApproved
+	}
+
+	err = s.store.ExecTx(ctx, func(tx store.QAStore) error {
+		if approve {
+			// 在事务中锁定目标后再比较，避免比较之后、写入之前作者的修改被覆盖
+			if err := lockEditTarget(ctx, tx, target); err != nil {
+				return err
+			}
+			// 目标在提出建议后被修改过，直接覆盖会丢掉这次修改
+			if target.title() != edit.BaseTitle || target.content() != edit.BaseContent {
+				return ErrSuggestedEditStale
+			}
+		}
+		ok, err := tx.ReviewSuggestedEdit(ctx, editID, status, userID, comment)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrSuggestedEditReviewed
+		}
+		if !approve {
+			return nil
+		}
+		if target.answer != nil {
+			return s.applyAnswerEdit(ctx, tx, target.answer, edit.Content)
+		}
+		return s.applyQuestionEdit(ctx, tx, target.question, edit.Title, edit.Content)
+	})
+	if err != nil {
+		logger.Error("审核修改建议失败",
+			slog.Int64("edit_id", editID),
+			slog.Int64("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	edit.Status = status
+	edit.ReviewerID = userID
+	edit.ReviewComment = comment
+	edit.ReviewedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	logger.Info("修改建议审核完成",
+		slog.Int64("edit_id", editID),
+		slog.String("status", status),
+		slog.Int64("reviewer_id", userID),
+	)
+
+	go s.notifyEditReviewed(*edit, target, identity.Username)
+	return edit, nil
+}
+
+// lockEditTarget 在事务中加锁重新读取目标，用最新的数据替换 target 中的回答或问题
+func lockEditTarget(ctx context.Context, tx store.QAStore, target *editTarget) error {
+	if target.answer != nil {
+		answer, err := tx.GetAnswerForUpdate(ctx, target.answer.ID)
+		if err != nil {
+			return notFoundAs(err, ErrEditTargetNotFound)
+		}
+		target.answer = answer
+		return nil
+	}
+	question, err := tx.GetQuestionForUpdate(ctx, target.question.ID)
+	if err != nil {
+		return notFoundAs(err, ErrEditTargetNotFound)
+	}
+	target.question = question
+	return nil
+}
+
+// notifyEditReviewed 通知建议者审核结果；审核人不是作者时，同时通知作者
+func (s *qaService) notifyEditReviewed(edit model.SuggestedEdit, target *editTarget, reviewerName string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	ownerID := target.ownerID()
+	usernames, err := s.store.GetUsernamesByIDs(ctx, []int64{ownerID, edit.ProposerID})
+	if err != nil {
+		usernames = map[int64]string{}
+	}
+
+	approved := edit.Status == model.EditStatusApproved
+	if approved && target.answer == nil {
+		// 问题事件的作者名取自上下文中的身份，这里应是提问者而不是审核人
+		ownerCtx := auth.WithIdentity(ctx, auth.Identity{UserID: ownerID, Username: usernames[ownerID]})
+		s.publishQuestionEvent(ownerCtx, messaging.EventQuestionUpdated, target.question)
+	}
+
+	params := map[string]string{
+		messaging.NotificationParamSender:        reviewerName,
+		messaging.NotificationParamQuestionTitle: target.question.Title,
+		messaging.NotificationParamTarget:        target.targetType(),
+	}
+
+	notificationType := messaging.NotificationTypeEditRejected
+	if approved {
+		notificationType = messaging.NotificationTypeEditApproved
+	}
+
+	if edit.ProposerID != edit.ReviewerID {
+		// 匿名内容的作者亲自审核时，通知中不暴露作者身份
+		senderID, senderName, proposerParams := edit.ReviewerID, reviewerName, params
+		if target.anonymous() && edit.ReviewerID == ownerID {
+			senderID, senderName = 0, ""
+			proposerParams = maps.Clone(params)
+			proposerParams[messaging.NotificationParamSender] = ""
+			proposerParams[messaging.NotificationParamSenderAnonymous] = "true"
+		}
+		s.publishNotificationEvent(ctx, messaging.NotificationPayload{
+			RecipientID:      edit.ProposerID,
+			SenderID:         senderID,
+			SenderName:       senderName,
+			NotificationType: notificationType,
+			TargetURL:        target.url(),
+			Params:           proposerParams,
+		})
+	}
+	if ownerID != edit.ReviewerID {
+		ownerParams := maps.Clone(params)
+		ownerParams[messaging.NotificationParamProposer] = usernames[edit.ProposerID]
+		s.publishNotificationEvent(ctx, messaging.NotificationPayload{
+			RecipientID:      ownerID,
+			SenderID:         edit.ReviewerID,
+			SenderName:       reviewerName,
+			NotificationType: notificationType,
+			TargetURL:        target.url(),
+			Params:           ownerParams,
+		})
+	}
+}
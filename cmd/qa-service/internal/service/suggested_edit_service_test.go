@@ -0,0 +1,294 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/config"
+	"qahub/pkg/messaging"
+	"qahub/qa-service/internal/model"
+	"qahub/qa-service/internal/service"
+	"qahub/qa-service/internal/store"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSuggestEdit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
+	ctx := auth.WithIdentity(context.Background(), auth.Identity{UserID: 200, Username: "editor"})
+
+	question := &model.Question{ID: 1, Title: "旧标题", Content: "旧内容", UserID: 100}
+
+	t.Run("成功对问题提出修改建议", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetQuestionByID(ctx, int64(1)).
+			Return(question, nil).
+			Times(1)
+		mockStore.EXPECT().
+			CreateSuggestedEdit(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, e *model.SuggestedEdit) (int64, error) {
+				assert.Equal(t, "旧标题", e.BaseTitle)
+				assert.Equal(t, "旧内容", e.BaseContent)
+				assert.Equal(t, "新标题", e.Title)
+				assert.Equal(t, int64(200), e.ProposerID)
+				return int64(10), nil
+			}).
+			Times(1)
+
+		edit, err := qaService.SuggestEdit(ctx, model.EditTargetQuestion, 1, "新标题", "新内容", "修正错别字", 200)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(10), edit.ID)
+		assert.Equal(t, model.EditStatusPending, edit.Status)
+	})
+
+	t.Run("对回答的建议忽略标题", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetAnswerByID(ctx, int64(5)).
+			Return(&model.Answer{ID: 5, QuestionID: 1, Content: "旧回答", UserID: 100}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetQuestionByID(ctx, int64(1)).
+			Return(question, nil).
+			Times(1)
+		mockStore.EXPECT().
+			CreateSuggestedEdit(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, e *model.SuggestedEdit) (int64, error) {
+				assert.Empty(t, e.Title)
+				assert.Empty(t, e.BaseTitle)
+				assert.Equal(t, "旧回答", e.BaseContent)
+				return int64(11), nil
+			}).
+			Times(1)
+
+		_, err := qaService.SuggestEdit(ctx, model.EditTargetAnswer, 5, "被忽略", "新回答", "", 200)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("不能对自己的内容提出建议", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetQuestionByID(ctx, int64(1)).
+			Return(question, nil).
+			Times(1)
+
+		_, err := qaService.SuggestEdit(ctx, model.EditTargetQuestion, 1, "新标题", "新内容", "", 100)
+
+		assert.ErrorIs(t, err, service.ErrSuggestOwnPost)
+	})
+
+	t.Run("内容没有变化", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetQuestionByID(ctx, int64(1)).
+			Return(question, nil).
+			Times(1)
+
+		_, err := qaService.SuggestEdit(ctx, model.EditTargetQuestion, 1, "旧标题", "旧内容", "", 200)
+
+		assert.ErrorIs(t, err, service.ErrSuggestedEditUnchanged)
+	})
+
+	t.Run("目标不存在", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetAnswerByID(ctx, int64(999)).
+			Return(nil, sql.ErrNoRows).
+			Times(1)
+
+		_, err := qaService.SuggestEdit(ctx, model.EditTargetAnswer, 999, "", "新内容", "", 200)
+
+		assert.ErrorIs(t, err, service.ErrEditTargetNotFound)
+	})
+
+	t.Run("不支持的目标类型", func(t *testing.T) {
+		_, err := qaService.SuggestEdit(ctx, "comment", 1, "", "新内容", "", 200)
+
+		assert.ErrorIs(t, err, service.ErrInvalidEditTarget)
+	})
+}
+
+func TestReviewSuggestedEdit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	producer := messaging.NewKafkaProducer(config.Conf.Kafka)
+	qaService := service.NewQAService(mockStore, producer, &config.Conf, nil)
+	ownerCtx := auth.WithIdentity(context.Background(), auth.Identity{UserID: 100, Username: "owner"})
+
+	pendingAnswerEdit := func() *model.SuggestedEdit {
+		return &model.SuggestedEdit{
+			ID:          10,
+			TargetType:  model.EditTargetAnswer,
+			TargetID:    5,
+			ProposerID:  200,
+			BaseContent: "旧回答",
+			Content:     "新回答",
+			Status:      model.EditStatusPending,
+		}
+	}
+	expectAnswerTarget := func(ctx context.Context, content string) {
+		mockStore.EXPECT().
+			GetAnswerByID(ctx, int64(5)).
+			Return(&model.Answer{ID: 5, QuestionID: 1, Content: content, UserID: 100}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetQuestionByID(ctx, int64(1)).
+			Return(&model.Question{ID: 1, Title: "问题", UserID: 300}, nil).
+			Times(1)
+	}
+	expectLockedAnswer := func(ctx context.Context, content string) {
+		mockStore.EXPECT().
+			GetAnswerForUpdate(ctx, int64(5)).
+			Return(&model.Answer{ID: 5, QuestionID: 1, Content: content, UserID: 100}, nil).
+			Times(1)
+	}
+	expectTx := func(ctx context.Context) {
+		mockStore.EXPECT().
+			ExecTx(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(store.QAStore) error) error {
+				return fn(mockStore)
+			}).
+			Times(1)
+	}
+
+	// Mock: 异步通知时获取用户名
+	mockStore.EXPECT().
+		GetUsernamesByIDs(gomock.Any(), gomock.Any()).
+		Return(map[int64]string{100: "owner", 200: "editor"}, nil).
+		AnyTimes()
+
+	t.Run("作者通过建议并按更新回答的路径写入", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSuggestedEditByID(ownerCtx, int64(10)).
+			Return(pendingAnswerEdit(), nil).
+			Times(1)
+		expectAnswerTarget(ownerCtx, "旧回答")
+		expectTx(ownerCtx)
+		expectLockedAnswer(ownerCtx, "旧回答")
+		mockStore.EXPECT().
+			ReviewSuggestedEdit(ownerCtx, int64(10), model.EditStatusApproved, int64(100), "谢谢").
+			Return(true, nil).
+			Times(1)
+		mockStore.EXPECT().
+			UpdateAnswer(ownerCtx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, a *model.Answer) error {
+				assert.Equal(t, "新回答", a.Content)
+				return nil
+			}).
+			Times(1)
+		mockStore.EXPECT().
+			TouchQuestionActivity(ownerCtx, int64(1)).
+			Return(nil).
+			Times(1)
+
+		edit, err := qaService.ReviewSuggestedEdit(ownerCtx, 10, true, "谢谢", 100)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.EditStatusApproved, edit.Status)
+		assert.Equal(t, int64(100), edit.ReviewerID)
+		assert.True(t, edit.ReviewedAt.Valid)
+	})
+
+	t.Run("版主可以拒绝他人的建议", func(t *testing.T) {
+		modCtx := auth.WithIdentity(context.Background(), auth.Identity{
+			UserID: 400,
+			Claims: map[string]any{"role": auth.RoleModerator},
+		})
+		mockStore.EXPECT().
+			GetSuggestedEditByID(modCtx, int64(10)).
+			Return(pendingAnswerEdit(), nil).
+			Times(1)
+		expectAnswerTarget(modCtx, "旧回答")
+		expectTx(modCtx)
+		mockStore.EXPECT().
+			ReviewSuggestedEdit(modCtx, int64(10), model.EditStatusRejected, int64(400), "").
+			Return(true, nil).
+			Times(1)
+
+		edit, err := qaService.ReviewSuggestedEdit(modCtx, 10, false, "", 400)
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.EditStatusRejected, edit.Status)
+	})
+
+	t.Run("其他用户无权审核", func(t *testing.T) {
+		otherCtx := auth.WithIdentity(context.Background(), auth.Identity{UserID: 500})
+		mockStore.EXPECT().
+			GetSuggestedEditByID(otherCtx, int64(10)).
+			Return(pendingAnswerEdit(), nil).
+			Times(1)
+		expectAnswerTarget(otherCtx, "旧回答")
+
+		_, err := qaService.ReviewSuggestedEdit(otherCtx, 10, true, "", 500)
+
+		assert.ErrorIs(t, err, service.ErrNotEditReviewer)
+	})
+
+	t.Run("内容已被修改时建议过期", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSuggestedEditByID(ownerCtx, int64(10)).
+			Return(pendingAnswerEdit(), nil).
+			Times(1)
+		expectAnswerTarget(ownerCtx, "作者后来改过的回答")
+		expectTx(ownerCtx)
+		expectLockedAnswer(ownerCtx, "作者后来改过的回答")
+
+		_, err := qaService.ReviewSuggestedEdit(ownerCtx, 10, true, "", 100)
+
+		assert.ErrorIs(t, err, service.ErrSuggestedEditStale)
+	})
+
+	t.Run("审核期间内容被修改时以事务中加锁读取的内容为准", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSuggestedEditByID(ownerCtx, int64(10)).
+			Return(pendingAnswerEdit(), nil).
+			Times(1)
+		// 事务外读取时内容尚未修改，加锁读取时作者已经改过
+		expectAnswerTarget(ownerCtx, "旧回答")
+		expectTx(ownerCtx)
+		expectLockedAnswer(ownerCtx, "作者刚刚改过的回答")
+
+		_, err := qaService.ReviewSuggestedEdit(ownerCtx, 10, true, "", 100)
+
+		assert.ErrorIs(t, err, service.ErrSuggestedEditStale)
+	})
+
+	t.Run("并发审核时只有一次生效", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetSuggestedEditByID(ownerCtx, int64(10)).
+			Return(pendingAnswerEdit(), nil).
+			Times(1)
+		expectAnswerTarget(ownerCtx, "旧回答")
+		expectTx(ownerCtx)
+		expectLockedAnswer(ownerCtx, "旧回答")
+		mockStore.EXPECT().
+			ReviewSuggestedEdit(ownerCtx, int64(10), model.EditStatusApproved, int64(100), "").
+			Return(false, nil).
+			Times(1)
+
+		_, err := qaService.ReviewSuggestedEdit(ownerCtx, 10, true, "", 100)
+
+		assert.ErrorIs(t, err, service.ErrSuggestedEditReviewed)
+	})
+
+	t.Run("已审核的建议不能再次审核", func(t *testing.T) {
+		reviewed := pendingAnswerEdit()
+		reviewed.Status = model.EditStatusRejected
+		mockStore.EXPECT().
+			GetSuggestedEditByID(ownerCtx, int64(10)).
+			Return(reviewed, nil).
+			Times(1)
+
+		_, err := qaService.ReviewSuggestedEdit(ownerCtx, 10, true, "", 100)
+
+		assert.ErrorIs(t, err, service.ErrSuggestedEditReviewed)
+	})
+}
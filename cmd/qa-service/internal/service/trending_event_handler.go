@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"qahub/pkg/messaging"
+)
+
+// RegisterHandlers 返回热门排行榜关心的问答事件处理器
+func (s *trendingService) RegisterHandlers() map[messaging.EventType]messaging.EventHandler {
+	return map[messaging.EventType]messaging.EventHandler{
+		messaging.EventQuestionCreated:     s.handleQuestionChanged,
+		messaging.EventQuestionViewed:      s.handleQuestionViewed,
+		messaging.EventQuestionDeleted:     s.handleQuestionDeleted,
+		messaging.EventAnswerCreated:       s.handleAnswerChanged,
+		messaging.EventAnswerDeleted:       s.handleAnswerChanged,
+		messaging.EventAnswerUpvoted:       s.handleAnswerVoted,
+		messaging.EventAnswerVoteRetracted: s.handleAnswerVoted,
+	}
+}
+
+func (s *trendingService) handleQuestionChanged(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.QuestionCreatedEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 QuestionCreatedEvent 失败: %w", err)
+	}
+	if err := s.Refresh(ctx, event.Payload.ID); err != nil {
+		return fmt.Errorf("更新热门分数失败 (问题ID: %d): %w", event.Payload.ID, err)
+	}
+	return nil
+}
+
+func (s *trendingService) handleQuestionViewed(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.QuestionViewedEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 QuestionViewedEvent 失败: %w", err)
+	}
+	if err := s.Refresh(ctx, event.Payload.ID); err != nil {
+		return fmt.Errorf("更新热门分数失败 (问题ID: %d): %w", event.Payload.ID, err)
+	}
+	return nil
+}
+
+func (s *trendingService) handleQuestionDeleted(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.QuestionDeletedEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 QuestionDeletedEvent 失败: %w", err)
+	}
+	if err := s.Remove(ctx, event.Payload.ID); err != nil {
+		return fmt.Errorf("移除热门问题失败 (问题ID: %d): %w", event.Payload.ID, err)
+	}
+	return nil
+}
+
+func (s *trendingService) handleAnswerChanged(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.AnswerEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 AnswerEvent 失败: %w", err)
+	}
+	if err := s.Refresh(ctx, event.Payload.QuestionID); err != nil {
+		return fmt.Errorf("更新热门分数失败 (问题ID: %d): %w", event.Payload.QuestionID, err)
+	}
+	return nil
+}
+
+func (s *trendingService) handleAnswerVoted(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.AnswerVotedEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 AnswerVotedEvent 失败: %w", err)
+	}
+	if err := s.Refresh(ctx, event.Payload.QuestionID); err != nil {
+		return fmt.Errorf("更新热门分数失败 (问题ID: %d): %w", event.Payload.QuestionID, err)
+	}
+	return nil
+}
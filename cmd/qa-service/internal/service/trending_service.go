@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"time"
+
+	"qahub/pkg/config"
+	"qahub/pkg/log"
+	"qahub/pkg/pagination"
+	"qahub/qa-service/internal/dto"
+	"qahub/qa-service/internal/model"
+	"qahub/qa-service/internal/store"
+)
+
+const (
+	TopicQAEvents   = "qa_events"         // 定义消费的主题
+	TrendingGroupID = "trending-consumer" // 定义消费者组ID
+)
+
+// 热门排行榜的时间窗口，只有在窗口内创建的问题才会进入对应的排行榜
+const (
+	TrendingWindowDay   = "day"
+	TrendingWindowWeek  = "week"
+	TrendingWindowMonth = "month"
+)
+
+// trendingWindows 是各时间窗口对应的时长
+var trendingWindows = map[string]time.Duration{
+	TrendingWindowDay:   24 * time.Hour,
+	TrendingWindowWeek:  7 * 24 * time.Hour,
+	TrendingWindowMonth: 30 * 24 * time.Hour,
+}
+
+// 热门分数中各项行为的权重
+const (
+	trendingUpvoteWeight = 1.0
+	trendingAnswerWeight = 2.0
+	trendingViewWeight   = 0.1
+)
+
+const (
+	defaultTrendingDecay           = 12 * time.Hour
+	defaultTrendingRebuildInterval = 10 * time.Minute
+)
+
+// trendingEpoch 是计算热门分数的固定参考时间，分数不随计算时间变化
+var trendingEpoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ErrInvalidTrendingWindow 表示请求了不支持的时间窗口
+var ErrInvalidTrendingWindow = errors.New("时间窗口只能是 day、week 或 month")
+
+// TrendingScore 计算问题的热门分数。
+// 分数是互动量的对数加上发布时间距 trendingEpoch 的衰减周期数，晚发布一个 decay 周期相当于互动量乘以 10，
+// 新问题即使互动较少也能上榜，而旧问题需要持续的互动才能保持排名。
+// 分数只取决于问题本身，事件触发的增量刷新和定期重建算出的分数可以直接比较。
+func TrendingScore(stats *model.QuestionStats, decay time.Duration) float64 {
+	points := float64(stats.UpvoteCount)*trendingUpvoteWeight +
+		float64(stats.AnswerCount)*trendingAnswerWeight +
+		float64(stats.ViewCount)*trendingViewWeight
+	return math.Log10(points+1) + float64(stats.CreatedAt.Sub(trendingEpoch))/float64(decay)
+}
+
+// TrendingService 维护并查询热门问题排行榜
+type TrendingService interface {
+	ListTrendingQuestions(ctx context.Context, window string, page int64, pageSize int32) ([]*dto.QuestionResponse, int64, error)
+	// Refresh 重新计算若干问题的热门分数，由问答事件触发
+	Refresh(ctx context.Context, questionIDs ...int64) error
+	// Remove 将问题从所有排行榜中移除
+	Remove(ctx context.Context, questionID int64) error
+	// Rebuild 从 MySQL 全量重建所有窗口的排行榜，移除超出时间窗口的问题并修正遗漏的事件
+	Rebuild(ctx context.Context) error
+	// Run 启动时重建一次，之后按配置的间隔定期重建，直到 ctx 结束
+	Run(ctx context.Context)
+}
+
+type trendingService struct {
+	qa       QAService
+	store    store.QAStore
+	ranking  store.TrendingStore
+	decay    time.Duration
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewTrendingService 创建一个新的 TrendingService
+func NewTrendingService(qa QAService, s store.QAStore, ranking store.TrendingStore) *trendingService {
+	cfg := config.Conf.Services.QAService
+	decay := cfg.TrendingDecay
+	if decay <= 0 {
+		decay = defaultTrendingDecay
+	}
+	interval := cfg.TrendingRebuildInterval
+	if interval <= 0 {
+		interval = defaultTrendingRebuildInterval
+	}
+	return &trendingService{
+		qa:       qa,
+		store:    s,
+		ranking:  ranking,
+		decay:    decay,
+		interval: interval,
+		now:      time.Now,
+	}
+}
+
+func (s *trendingService) ListTrendingQuestions(ctx context.Context, window string, page int64, pageSize int32) ([]*dto.QuestionResponse, int64, error) {
+	logger := log.FromContext(ctx)
+
+	if window == "" {
+		window = TrendingWindowWeek
+	}
+	if _, ok := trendingWindows[window]; !ok {
+		return nil, 0, ErrInvalidTrendingWindow
+	}
+
+	limit, offset := pagination.CalculateOffset(page, pageSize)
+	ids, total, err := s.ranking.ListTop(ctx, window, offset, limit)
+	if err != nil {
+		logger.Error("读取热门排行榜失败",
+			slog.String("window", window),
+			slog.String("error", err.Error()),
+		)
+		return nil, 0, err
+	}
+	if len(ids) == 0 {
+		return []*dto.QuestionResponse{}, total, nil
+	}
+
+	// 排行榜中可能残留刚被删除的问题，BatchGetQuestions 会将其过滤掉
+	questions, _, err := s.qa.BatchGetQuestions(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	return questions, total, nil
+}
+
+func (s *trendingService) Refresh(ctx context.Context, questionIDs ...int64) error {
+	stats, err := s.store.GetQuestionStatsByIDs(ctx, questionIDs)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	for _, st := range stats {
+		score := TrendingScore(st, s.decay)
+		var expired []string
+		for window, d := range trendingWindows {
+			if now.Sub(st.CreatedAt) > d {
+				expired = append(expired, window)
+				continue
+			}
+			if err := s.ranking.SetScore(ctx, window, st.ID, score); err != nil {
+				return err
+			}
+		}
+		// 超出窗口的问题不再参与该窗口的排名
+		if len(expired) > 0 {
+			if err := s.ranking.Remove(ctx, st.ID, expired...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *trendingService) Remove(ctx context.Context, questionID int64) error {
+	windows := make([]string, 0, len(trendingWindows))
+	for w := range trendingWindows {
+		windows = append(windows, w)
+	}
+	return s.ranking.Remove(ctx, questionID, windows...)
+}
+
+func (s *trendingService) Rebuild(ctx context.Context) error {
+	now := s.now()
+	stats, err := s.store.ListQuestionStatsSince(ctx, now.Add(-trendingWindows[TrendingWindowMonth]))
+	if err != nil {
+		return err
+	}
+
+	for window, d := range trendingWindows {
+		scores := make(map[int64]float64)
+		for _, st := range stats {
+			if now.Sub(st.CreatedAt) <= d {
+				scores[st.ID] = TrendingScore(st, s.decay)
+			}
+		}
+		if err := s.ranking.Replace(ctx, window, scores); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *trendingService) Run(ctx context.Context) {
+	logger := log.FromContext(ctx)
+
+	rebuild := func() {
+		if err := s.Rebuild(ctx); err != nil {
+			logger.Error("重建热门排行榜失败",
+				slog.String("error", err.Error()),
+			)
+			return
+		}
+		logger.Debug("热门排行榜重建完成")
+	}
+
+	rebuild()
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rebuild()
+		}
+	}
+}
@@ -0,0 +1,287 @@
+//go:generate mockgen -source=../store/trending_store.go -destination=trending_store_mock.go -package=service
+
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/config"
+	"qahub/pkg/messaging"
+	"qahub/qa-service/internal/model"
+	"qahub/qa-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTrendingScore(t *testing.T) {
+	now := time.Now()
+
+	t.Run("互动越多分数越高", func(t *testing.T) {
+		quiet := &model.QuestionStats{CreatedAt: now.Add(-time.Hour), ViewCount: 10}
+		busy := &model.QuestionStats{CreatedAt: now.Add(-time.Hour), ViewCount: 10, AnswerCount: 3, UpvoteCount: 5}
+
+		assert.Greater(t, service.TrendingScore(busy, 12*time.Hour), service.TrendingScore(quiet, 12*time.Hour))
+	})
+
+	t.Run("相同互动量下新问题排在前面", func(t *testing.T) {
+		fresh := &model.QuestionStats{CreatedAt: now.Add(-time.Hour), AnswerCount: 2}
+		stale := &model.QuestionStats{CreatedAt: now.Add(-48 * time.Hour), AnswerCount: 2}
+
+		assert.Greater(t, service.TrendingScore(fresh, 12*time.Hour), service.TrendingScore(stale, 12*time.Hour))
+	})
+
+	t.Run("旧问题需要更多互动才能超过新问题", func(t *testing.T) {
+		fresh := &model.QuestionStats{CreatedAt: now.Add(-time.Hour), UpvoteCount: 1}
+		stale := &model.QuestionStats{CreatedAt: now.Add(-72 * time.Hour), UpvoteCount: 50, AnswerCount: 10}
+
+		assert.Greater(t, service.TrendingScore(fresh, 12*time.Hour), service.TrendingScore(stale, 12*time.Hour))
+	})
+
+	t.Run("晚一个衰减周期相当于互动量乘以 10", func(t *testing.T) {
+		earlier := &model.QuestionStats{CreatedAt: now.Add(-12 * time.Hour), UpvoteCount: 99}
+		later := &model.QuestionStats{CreatedAt: now, UpvoteCount: 9}
+
+		assert.InDelta(t, service.TrendingScore(earlier, 12*time.Hour), service.TrendingScore(later, 12*time.Hour), 1e-9)
+	})
+}
+
+func TestListTrendingQuestions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	mockRanking := service.NewMockTrendingStore(ctrl)
+	qaService := service.NewQAService(mockStore, messaging.NewKafkaProducer(config.Conf.Kafka), &config.Conf, nil)
+	trendingService := service.NewTrendingService(qaService, mockStore, mockRanking)
+	ctx := context.Background()
+
+	t.Run("默认使用周榜并按排名返回问题", func(t *testing.T) {
+		mockRanking.EXPECT().
+			ListTop(ctx, service.TrendingWindowWeek, int64(0), int32(10)).
+			Return([]int64{3, 1}, int64(2), nil).
+			Times(1)
+
+		mockStore.EXPECT().
+			GetQuestionsByIDs(ctx, []int64{3, 1}).
+			Return([]*model.Question{
+				{ID: 1, Title: "问题1", UserID: 100},
+				{ID: 3, Title: "问题3", UserID: 100},
+			}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUsernamesByIDs(ctx, gomock.Any()).
+			Return(map[int64]string{100: "user1"}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserTypesByIDs(ctx, gomock.Any()).
+			Return(map[int64]string{100: auth.UserTypeHuman}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			GetUserAvatarsByIDs(ctx, gomock.Any()).
+			Return(map[int64]string{}, nil).
+			Times(1)
+
+		result, total, err := trendingService.ListTrendingQuestions(ctx, "", 1, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Len(t, result, 2)
+		assert.Equal(t, int64(3), result[0].ID)
+		assert.Equal(t, int64(1), result[1].ID)
+	})
+
+	t.Run("排行榜为空", func(t *testing.T) {
+		mockRanking.EXPECT().
+			ListTop(ctx, service.TrendingWindowDay, int64(0), int32(10)).
+			Return(nil, int64(0), nil).
+			Times(1)
+
+		result, total, err := trendingService.ListTrendingQuestions(ctx, service.TrendingWindowDay, 1, 10)
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+		assert.Equal(t, int64(0), total)
+	})
+
+	t.Run("不支持的时间窗口", func(t *testing.T) {
+		result, _, err := trendingService.ListTrendingQuestions(ctx, "year", 1, 10)
+
+		assert.ErrorIs(t, err, service.ErrInvalidTrendingWindow)
+		assert.Nil(t, result)
+	})
+}
+
+func TestTrendingRefresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	mockRanking := service.NewMockTrendingStore(ctrl)
+	qaService := service.NewQAService(mockStore, messaging.NewKafkaProducer(config.Conf.Kafka), &config.Conf, nil)
+	trendingService := service.NewTrendingService(qaService, mockStore, mockRanking)
+	ctx := context.Background()
+
+	t.Run("新问题进入所有窗口", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetQuestionStatsByIDs(ctx, []int64{1}).
+			Return([]*model.QuestionStats{{ID: 1, CreatedAt: time.Now().Add(-time.Hour)}}, nil).
+			Times(1)
+
+		for _, w := range []string{service.TrendingWindowDay, service.TrendingWindowWeek, service.TrendingWindowMonth} {
+			mockRanking.EXPECT().SetScore(ctx, w, int64(1), gomock.Any()).Return(nil).Times(1)
+		}
+
+		assert.NoError(t, trendingService.Refresh(ctx, 1))
+	})
+
+	t.Run("超出窗口的问题从对应排行榜移除", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetQuestionStatsByIDs(ctx, []int64{2}).
+			Return([]*model.QuestionStats{{ID: 2, CreatedAt: time.Now().Add(-10 * 24 * time.Hour)}}, nil).
+			Times(1)
+
+		mockRanking.EXPECT().SetScore(ctx, service.TrendingWindowMonth, int64(2), gomock.Any()).Return(nil).Times(1)
+		mockRanking.EXPECT().
+			Remove(ctx, int64(2), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int64, windows ...string) error {
+				assert.ElementsMatch(t, []string{service.TrendingWindowDay, service.TrendingWindowWeek}, windows)
+				return nil
+			}).
+			Times(1)
+
+		assert.NoError(t, trendingService.Refresh(ctx, 2))
+	})
+}
+
+func TestTrendingRebuild(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	mockRanking := service.NewMockTrendingStore(ctrl)
+	qaService := service.NewQAService(mockStore, messaging.NewKafkaProducer(config.Conf.Kafka), &config.Conf, nil)
+	trendingService := service.NewTrendingService(qaService, mockStore, mockRanking)
+	ctx := context.Background()
+
+	now := time.Now()
+	mockStore.EXPECT().
+		ListQuestionStatsSince(ctx, gomock.Any()).
+		Return([]*model.QuestionStats{
+			{ID: 1, CreatedAt: now.Add(-time.Hour)},
+			{ID: 2, CreatedAt: now.Add(-3 * 24 * time.Hour)},
+			{ID: 3, CreatedAt: now.Add(-20 * 24 * time.Hour)},
+		}, nil).
+		Times(1)
+
+	replaced := make(map[string][]int64)
+	mockRanking.EXPECT().
+		Replace(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, window string, scores map[int64]float64) error {
+			for id := range scores {
+				replaced[window] = append(replaced[window], id)
+			}
+			return nil
+		}).
+		Times(3)
+
+	assert.NoError(t, trendingService.Rebuild(ctx))
+	assert.ElementsMatch(t, []int64{1}, replaced[service.TrendingWindowDay])
+	assert.ElementsMatch(t, []int64{1, 2}, replaced[service.TrendingWindowWeek])
+	assert.ElementsMatch(t, []int64{1, 2, 3}, replaced[service.TrendingWindowMonth])
+}
+
+func TestTrendingRefreshScoresMatchRebuild(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	mockRanking := service.NewMockTrendingStore(ctrl)
+	qaService := service.NewQAService(mockStore, messaging.NewKafkaProducer(config.Conf.Kafka), &config.Conf, nil)
+	trendingService := service.NewTrendingService(qaService, mockStore, mockRanking)
+	ctx := context.Background()
+
+	// 问题 1 在重建后收到新的点赞，增量刷新的分数要能和重建时写入的问题 2 的分数直接比较
+	now := time.Now()
+	stats := []*model.QuestionStats{
+		{ID: 1, CreatedAt: now.Add(-2 * time.Hour), UpvoteCount: 3},
+		{ID: 2, CreatedAt: now.Add(-time.Hour), UpvoteCount: 3},
+	}
+	mockStore.EXPECT().ListQuestionStatsSince(ctx, gomock.Any()).Return(stats, nil).Times(1)
+	rebuilt := make(map[int64]float64)
+	mockRanking.EXPECT().
+		Replace(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, window string, scores map[int64]float64) error {
+			if window == service.TrendingWindowDay {
+				rebuilt = scores
+			}
+			return nil
+		}).
+		Times(3)
+	assert.NoError(t, trendingService.Rebuild(ctx))
+	assert.Greater(t, rebuilt[2], rebuilt[1])
+
+	upvoted := *stats[0]
+	upvoted.UpvoteCount = 300
+	mockStore.EXPECT().GetQuestionStatsByIDs(ctx, []int64{1}).Return([]*model.QuestionStats{&upvoted}, nil).Times(1)
+	refreshed := make(map[string]float64)
+	mockRanking.EXPECT().
+		SetScore(ctx, gomock.Any(), int64(1), gomock.Any()).
+		DoAndReturn(func(_ context.Context, window string, _ int64, score float64) error {
+			refreshed[window] = score
+			return nil
+		}).
+		Times(3)
+	assert.NoError(t, trendingService.Refresh(ctx, 1))
+
+	assert.Equal(t, service.TrendingScore(&upvoted, 12*time.Hour), refreshed[service.TrendingWindowDay])
+	assert.Greater(t, refreshed[service.TrendingWindowDay], rebuilt[2], "互动大幅增加后应排在重建时的分数之前")
+}
+
+func TestTrendingEventHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockQAStore(ctrl)
+	mockRanking := service.NewMockTrendingStore(ctrl)
+	qaService := service.NewQAService(mockStore, messaging.NewKafkaProducer(config.Conf.Kafka), &config.Conf, nil)
+	trendingService := service.NewTrendingService(qaService, mockStore, mockRanking)
+	handlers := trendingService.RegisterHandlers()
+	ctx := context.Background()
+
+	t.Run("点赞事件刷新所属问题", func(t *testing.T) {
+		event := messaging.AnswerVotedEvent{Payload: messaging.VotePayload{AnswerID: 10, QuestionID: 5}}
+		payload, _ := json.Marshal(event)
+
+		mockStore.EXPECT().
+			GetQuestionStatsByIDs(ctx, []int64{5}).
+			Return([]*model.QuestionStats{}, nil).
+			Times(1)
+
+		err := handlers[messaging.EventAnswerUpvoted](ctx, string(messaging.EventAnswerUpvoted), payload)
+		assert.NoError(t, err)
+	})
+
+	t.Run("删除问题时从所有窗口移除", func(t *testing.T) {
+		var event messaging.QuestionDeletedEvent
+		event.Payload.ID = 7
+		payload, _ := json.Marshal(event)
+
+		mockRanking.EXPECT().
+			Remove(ctx, int64(7), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(1)
+
+		err := handlers[messaging.EventQuestionDeleted](ctx, string(messaging.EventQuestionDeleted), payload)
+		assert.NoError(t, err)
+	})
+
+	t.Run("无法解析的事件", func(t *testing.T) {
+		err := handlers[messaging.EventQuestionViewed](ctx, string(messaging.EventQuestionViewed), []byte("not json"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../store/trending_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../store/trending_store.go -destination=trending_store_mock.go -package=service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTrendingStore is a mock of TrendingStore interface.
+type MockTrendingStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrendingStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockTrendingStoreMockRecorder is the mock recorder for MockTrendingStore.
+type MockTrendingStoreMockRecorder struct {
+	mock *MockTrendingStore
+}
+
+// NewMockTrendingStore creates a new mock instance.
+func NewMockTrendingStore(ctrl *gomock.Controller) *MockTrendingStore {
+	mock := &MockTrendingStore{ctrl: ctrl}
+	mock.recorder = &MockTrendingStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrendingStore) EXPECT() *MockTrendingStoreMockRecorder {
+	return m.recorder
+}
+
+// ListTop mocks base method.
+func (m *MockTrendingStore) ListTop(ctx context.Context, window string, offset int64, limit int32) ([]int64, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTop", ctx, window, offset, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTop indicates an expected call of ListTop.
+func (mr *MockTrendingStoreMockRecorder) ListTop(ctx, window, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTop", reflect.TypeOf((*MockTrendingStore)(nil).ListTop), ctx, window, offset, limit)
+}
+
+// Remove mocks base method.
+func (m *MockTrendingStore) Remove(ctx context.Context, questionID int64, windows ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, questionID}
+	for _, a := range windows {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Remove", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockTrendingStoreMockRecorder) Remove(ctx, questionID any, windows ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, questionID}, windows...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockTrendingStore)(nil).Remove), varargs...)
+}
+
+// Replace mocks base method.
+func (m *MockTrendingStore) Replace(ctx context.Context, window string, scores map[int64]float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, window, scores)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockTrendingStoreMockRecorder) Replace(ctx, window, scores any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockTrendingStore)(nil).Replace), ctx, window, scores)
+}
+
+// SetScore mocks base method.
+func (m *MockTrendingStore) SetScore(ctx context.Context, window string, questionID int64, score float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetScore", ctx, window, questionID, score)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetScore indicates an expected call of SetScore.
+func (mr *MockTrendingStoreMockRecorder) SetScore(ctx, window, questionID, score any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetScore", reflect.TypeOf((*MockTrendingStore)(nil).SetScore), ctx, window, questionID, score)
+}
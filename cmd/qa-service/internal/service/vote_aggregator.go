@@ -0,0 +1,132 @@
+package service
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultVoteNotifyWindow 是点赞通知聚合窗口的默认值
+const defaultVoteNotifyWindow = time.Minute
+
+// voter 记录一次点赞的投票人
+type voter struct {
+	ID   int64
+	Name string
+}
+
+// pendingVotes 是某个回答在当前聚合窗口内累积的点赞
+type pendingVotes struct {
+	AnswerID    int64
+	QuestionID  int64
+	RecipientID int64 // 回答作者ID
+	Voters      []voter
+
+	timer *time.Timer
+}
+
+// voteAggregator 按回答聚合点赞，窗口结束时统一回调 flush，
+// 避免每一次点赞都给作者发送一条通知。
+//
+// 聚合窗口只保存在当前进程的内存中：部署多个 qa-service 实例时，
+// 同一回答的点赞会在各实例分别聚合，作者可能收到多条通知。
+// 服务关闭时需要调用 Close 立即发送尚未发送的通知，否则这些点赞的通知会丢失。
+type voteAggregator struct {
+	mu      sync.Mutex
+	window  time.Duration
+	pending map[int64]*pendingVotes
+	flush   func(p *pendingVotes)
+	closed  bool
+}
+
+func newVoteAggregator(window time.Duration, flush func(p *pendingVotes)) *voteAggregator {
+	if window <= 0 {
+		window = defaultVoteNotifyWindow
+	}
+	return &voteAggregator{
+		window:  window,
+		pending: make(map[int64]*pendingVotes),
+		flush:   flush,
+	}
+}
+
+// Add 记录一次点赞，窗口内的第一次点赞会启动该回答的聚合计时器
+// 聚合器关闭后不再聚合，每次点赞直接发送通知
+func (a *voteAggregator) Add(answerID, questionID, recipientID int64, v voter) {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		a.flush(&pendingVotes{
+			AnswerID:    answerID,
+			QuestionID:  questionID,
+			RecipientID: recipientID,
+			Voters:      []voter{v},
+		})
+		return
+	}
+	defer a.mu.Unlock()
+
+	p, ok := a.pending[answerID]
+	if !ok {
+		p = &pendingVotes{
+			AnswerID:    answerID,
+			QuestionID:  questionID,
+			RecipientID: recipientID,
+		}
+		a.pending[answerID] = p
+		p.timer = time.AfterFunc(a.window, func() { a.flushAnswer(answerID) })
+	}
+	for _, existing := range p.Voters {
+		if existing.ID == v.ID {
+			return
+		}
+	}
+	p.Voters = append(p.Voters, v)
+}
+
+// Remove 撤销窗口内尚未发送的点赞，已经发出的通知不受影响
+func (a *voteAggregator) Remove(answerID, voterID int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	p, ok := a.pending[answerID]
+	if !ok {
+		return
+	}
+	for i, existing := range p.Voters {
+		if existing.ID == voterID {
+			p.Voters = append(p.Voters[:i], p.Voters[i+1:]...)
+			return
+		}
+	}
+}
+
+func (a *voteAggregator) flushAnswer(answerID int64) {
+	a.mu.Lock()
+	p, ok := a.pending[answerID]
+	delete(a.pending, answerID)
+	a.mu.Unlock()
+
+	// 窗口内的点赞全部被撤销时不发送通知
+	if !ok || len(p.Voters) == 0 {
+		return
+	}
+	a.flush(p)
+}
+
+// Close 停止所有聚合计时器并立即发送尚未发送的通知，在服务关闭时调用
+func (a *voteAggregator) Close() error {
+	a.mu.Lock()
+	a.closed = true
+	pending := a.pending
+	a.pending = make(map[int64]*pendingVotes)
+	a.mu.Unlock()
+
+	for _, p := range pending {
+		// 计时器已经触发时 flushAnswer 会发现回答不在 pending 中，不会重复发送
+		p.timer.Stop()
+		if len(p.Voters) > 0 {
+			a.flush(p)
+		}
+	}
+	return nil
+}
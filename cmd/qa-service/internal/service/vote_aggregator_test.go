@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/config"
+	"qahub/pkg/messaging"
+	"qahub/qa-service/internal/model"
+	"qahub/qa-service/internal/store"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVoteAggregator(t *testing.T) {
+	t.Run("窗口内的点赞合并为一次通知", func(t *testing.T) {
+		flushed := make(chan *pendingVotes, 1)
+		agg := newVoteAggregator(50*time.Millisecond, func(p *pendingVotes) { flushed <- p })
+
+		agg.Add(200, 1, 999, voter{ID: 100, Name: "alice"})
+		agg.Add(200, 1, 999, voter{ID: 101, Name: "bob"})
+		agg.Add(200, 1, 999, voter{ID: 100, Name: "alice"}) // 重复点赞只计一次
+
+		select {
+		case p := <-flushed:
+			assert.Equal(t, int64(200), p.AnswerID)
+			assert.Equal(t, int64(999), p.RecipientID)
+			assert.Len(t, p.Voters, 2)
+		case <-time.After(time.Second):
+			t.Fatal("聚合窗口结束后没有发送通知")
+		}
+	})
+
+	t.Run("撤销的点赞不触发通知", func(t *testing.T) {
+		flushed := make(chan *pendingVotes, 1)
+		agg := newVoteAggregator(50*time.Millisecond, func(p *pendingVotes) { flushed <- p })
+
+		agg.Add(200, 1, 999, voter{ID: 100, Name: "alice"})
+		agg.Remove(200, 100)
+
+		select {
+		case <-flushed:
+			t.Fatal("撤销后不应发送通知")
+		case <-time.After(200 * time.Millisecond):
+		}
+	})
+
+	t.Run("关闭时立即发送窗口内的通知", func(t *testing.T) {
+		flushed := make(chan *pendingVotes, 2)
+		agg := newVoteAggregator(time.Hour, func(p *pendingVotes) { flushed <- p })
+
+		agg.Add(200, 1, 999, voter{ID: 100, Name: "alice"})
+		agg.Add(200, 1, 999, voter{ID: 101, Name: "bob"})
+		assert.NoError(t, agg.Close())
+
+		select {
+		case p := <-flushed:
+			assert.Equal(t, int64(200), p.AnswerID)
+			assert.Len(t, p.Voters, 2)
+		default:
+			t.Fatal("关闭时没有发送尚未发送的通知")
+		}
+
+		// 关闭后的点赞不再聚合，直接发送
+		agg.Add(201, 1, 999, voter{ID: 100, Name: "alice"})
+		select {
+		case p := <-flushed:
+			assert.Equal(t, int64(201), p.AnswerID)
+		default:
+			t.Fatal("关闭后的点赞没有直接发送通知")
+		}
+	})
+}
+
+func TestUpvoteThenRetractSkipsNotification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := NewMockQAStore(ctrl)
+	svc := NewQAService(mockStore, messaging.NewKafkaProducer(config.Conf.Kafka), &config.Conf, nil)
+	flushed := make(chan *pendingVotes, 1)
+	svc.votes = newVoteAggregator(50*time.Millisecond, func(p *pendingVotes) { flushed <- p })
+	ctx := auth.WithIdentity(context.Background(), auth.Identity{UserID: 100, Username: "alice"})
+
+	mockStore.EXPECT().ExecTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(store.QAStore) error) error {
+		return fn(mockStore)
+	}).Times(2)
+	mockStore.EXPECT().CreateAnswerVote(ctx, int64(200), int64(100), true).Return(nil)
+	mockStore.EXPECT().IncrementAnswerUpvoteCount(ctx, int64(200)).Return(nil)
+	mockStore.EXPECT().GetAnswerVoteForUpdate(ctx, int64(200), int64(100)).Return(true, nil)
+	mockStore.EXPECT().DeleteAnswerVote(ctx, int64(200), int64(100)).Return(nil)
+	mockStore.EXPECT().DecrementAnswerUpvoteCount(ctx, int64(200)).Return(nil)
+	mockStore.EXPECT().GetAnswerByID(gomock.Any(), int64(200)).Return(&model.Answer{ID: 200, QuestionID: 1, UserID: 999}, nil).AnyTimes()
+
+	// 点赞后立即取消，取消时点赞必须已经进入聚合器，否则会被漏掉
+	assert.NoError(t, svc.UpvoteAnswer(ctx, 200, 100))
+	assert.NoError(t, svc.RetractAnswerVote(ctx, 200, 100))
+
+	select {
+	case <-flushed:
+		t.Fatal("取消点赞后不应发送通知")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+
+	"qahub/qa-service/internal/model"
+)
+
+// --- 内容导出导入相关方法 ---
+
+func (s *sqlxQAStore) ListUsersForExport(ctx context.Context, afterID int64, limit int32) ([]*model.ContentUser, error) {
+	query := `SELECT id, username, email, email_verified_at, COALESCE(bio, '') AS bio, avatar_key,
+		password, role, user_type, language, created_at
+		FROM users WHERE id > ? ORDER BY id LIMIT ?`
+	users := []*model.ContentUser{}
+	err := s.db.SelectContext(ctx, &users, query, afterID, limit)
+	return users, err
+}
+
+func (s *sqlxQAStore) ListQuestionsForExport(ctx context.Context, afterID int64, limit int32) ([]*model.Question, error) {
+	query := questionSelect + " WHERE id > ? ORDER BY id LIMIT ?"
+	questions := []*model.Question{}
+	err := s.db.SelectContext(ctx, &questions, query, afterID, limit)
+	return questions, err
+}
+
+func (s *sqlxQAStore) ListAnswersForExport(ctx context.Context, afterID int64, limit int32) ([]*model.Answer, error) {
+	query := "SELECT id, question_id, content, user_id, is_anonymous, upvote_count, created_at, updated_at FROM answers WHERE id > ? ORDER BY id LIMIT ?"
+	answers := []*model.Answer{}
+	err := s.db.SelectContext(ctx, &answers, query, afterID, limit)
+	return answers, err
+}
+
+func (s *sqlxQAStore) ListCommentsForExport(ctx context.Context, afterID int64, limit int32) ([]*model.Comment, error) {
+	query := "SELECT id, answer_id, user_id, content, created_at, updated_at FROM comments WHERE id > ? ORDER BY id LIMIT ?"
+	comments := []*model.Comment{}
+	err := s.db.SelectContext(ctx, &comments, query, afterID, limit)
+	return comments, err
+}
+
+func (s *sqlxQAStore) ListVotesForExport(ctx context.Context, afterID int64, limit int32) ([]*model.ContentVote, error) {
+	query := "SELECT id, answer_id, user_id, is_upvote, created_at FROM answers_votes WHERE id > ? ORDER BY id LIMIT ?"
+	votes := []*model.ContentVote{}
+	err := s.db.SelectContext(ctx, &votes, query, afterID, limit)
+	return votes, err
+}
+
+func (s *sqlxQAStore) HasQAContent(ctx context.Context) (bool, error) {
+	var exists bool
+	err := s.db.GetContext(ctx, &exists, "SELECT EXISTS(SELECT 1 FROM questions)")
+	return exists, err
+}
+
+func (s *sqlxQAStore) ImportQuestion(ctx context.Context, question *model.Question) (int64, error) {
+	query := `INSERT INTO questions (title, content, user_id, is_anonymous, last_activity_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+	result, err := s.db.ExecContext(ctx, query, question.Title, question.Content, question.UserID, question.IsAnonymous,
+		question.UpdatedAt, question.CreatedAt, question.UpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (s *sqlxQAStore) ImportAnswer(ctx context.Context, answer *model.Answer) (int64, error) {
+	query := "INSERT INTO answers (question_id, content, user_id, is_anonymous, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)"
+	result, err := s.db.ExecContext(ctx, query, answer.QuestionID, answer.Content, answer.UserID, answer.IsAnonymous, answer.CreatedAt, answer.UpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (s *sqlxQAStore) ImportComment(ctx context.Context, comment *model.Comment) (int64, error) {
+	query := "INSERT INTO comments (answer_id, user_id, content, created_at, updated_at) VALUES (?, ?, ?, ?, ?)"
+	result, err := s.db.ExecContext(ctx, query, comment.AnswerID, comment.UserID, comment.Content, comment.CreatedAt, comment.UpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (s *sqlxQAStore) ImportVote(ctx context.Context, vote *model.ContentVote) error {
+	query := "INSERT INTO answers_votes (answer_id, user_id, is_upvote, created_at) VALUES (?, ?, ?, ?)"
+	_, err := s.db.ExecContext(ctx, query, vote.AnswerID, vote.UserID, vote.IsUpvote, vote.CreatedAt)
+	return err
+}
@@ -0,0 +1,702 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"qahub/pkg/config"
+	"qahub/pkg/health"
+	"qahub/qa-service/internal/model"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultCacheExpiration = 5 * time.Minute
+	defaultCacheListPages  = 3
+)
+
+// qaCacheStore 是一个为 QAStore 实现的装饰器，它使用 Redis 缓存问题详情、问题列表的前几页、
+// 问题总数和用户名。写操作成功后只删除受影响的键。
+type qaCacheStore struct {
+	redisClient   *redis.Client // Redis 客户端
+	next          QAStore       // 链中的下一个 store (例如，数据库 store)
+	expiration    time.Duration // 缓存过期时间
+	listPages     int           // 只缓存问题列表的前 listPages 页
+	group         *singleflight.Group
+	healthChecker *health.Checker
+
+	// tx 非空表示当前实例包装的是事务内的 store：读操作直接访问事务，
+	// 缓存失效推迟到事务提交之后，避免其他请求在提交前重新读到旧数据并写回缓存
+	tx *cacheTx
+}
+
+// cacheTx 记录事务中需要失效的缓存
+type cacheTx struct {
+	keys  []string
+	lists bool
+}
+
+// NewQACacheStore 创建一个带有缓存装饰的 QAStore 新实例。
+func NewQACacheStore(redisClient *redis.Client, next QAStore, cfg config.QACache) *qaCacheStore {
+	expiration := cfg.TTL
+	if expiration <= 0 {
+		expiration = defaultCacheExpiration
+	}
+	listPages := cfg.ListPages
+	if listPages <= 0 {
+		listPages = defaultCacheListPages
+	}
+	return &qaCacheStore{
+		redisClient: redisClient,
+		next:        next,
+		expiration:  expiration,
+		listPages:   listPages,
+		group:       &singleflight.Group{},
+	}
+}
+
+func (s *qaCacheStore) SetHealthUpdater(updater health.StatusUpdater, serviceName string) {
+	s.healthChecker = health.NewChecker(updater, serviceName)
+	go s.startHealthCheck()
+
+	// 传递给下一层 store
+	if awareStore, ok := s.next.(health.HealthAware); ok {
+		awareStore.SetHealthUpdater(updater, serviceName)
+	}
+}
+
+func (s *qaCacheStore) startHealthCheck() {
+	// 定期检查 Redis 连接健康状况
+	ticker := time.NewTicker(15 * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.healthChecker.CheckAndSetStatus(func(ctx context.Context) error {
+			return s.redisClient.Ping(ctx).Err()
+		}, "Redis Connection")
+	}
+}
+
+// --- 缓存键生成函数 ---
+
+// questionKey 根据问题ID生成缓存键
+func questionKey(id int64) string {
+	return fmt.Sprintf("qa:question:%d", id)
+}
+
+// usernameKey 根据用户ID生成用户名的缓存键
+func usernameKey(userID int64) string {
+	return fmt.Sprintf("qa:username:%d", userID)
+}
+
+// userTypeKey 根据用户ID生成用户类型的缓存键
+func userTypeKey(userID int64) string {
+	return fmt.Sprintf("qa:user_type:%d", userID)
+}
+
+// userAvatarKey 根据用户ID生成头像对象键的缓存键
+func userAvatarKey(userID int64) string {
+	return fmt.Sprintf("qa:user_avatar:%d", userID)
+}
+
+// questionListKey 根据分页参数生成问题列表页的缓存键
+func questionListKey(offset int64, limit int32) string {
+	return fmt.Sprintf("qa:questions:list:%d:%d", offset, limit)
+}
+
+// questionCountKey 是问题总数的缓存键
+const questionCountKey = "qa:questions:count"
+
+// questionListIndexKey 是一个集合，记录当前已缓存的所有列表页键，用于整体失效
+const questionListIndexKey = "qa:questions:lists"
+
+// --- 读穿透辅助函数 ---
+
+// cached 实现了"读穿透"缓存逻辑，缓存未命中时通过 singleflight 合并同一个键的并发回源请求。
+func cached[T any](ctx context.Context, s *qaCacheStore, key string, load func() (T, error)) (T, error) {
+	val, err := s.redisClient.Get(ctx, key).Bytes()
+	if err == nil {
+		var v T
+		if json.Unmarshal(val, &v) == nil {
+			return v, nil
+		}
+	}
+
+	v, err, _ := s.group.Do(key, func() (any, error) {
+		v, err := load()
+		if err != nil {
+			return nil, err
+		}
+		if data, err := json.Marshal(v); err == nil {
+			s.redisClient.Set(ctx, key, data, s.expiration)
+		}
+		return v, nil
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v.(T), nil
+}
+
+// cachedBatch 是批量版本的读穿透：先用 MGET 取出已缓存的值，再一次性从下一层加载缺失的部分。
+// 下一层没有返回的 ID 不会写入缓存。
+func cachedBatch[V any](ctx context.Context, s *qaCacheStore, ids []int64, keyFn func(int64) string, load func([]int64) (map[int64]V, error)) (map[int64]V, error) {
+	result := make(map[int64]V, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = keyFn(id)
+	}
+
+	var missing []int64
+	vals, err := s.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		missing = ids
+	} else {
+		for i, raw := range vals {
+			str, ok := raw.(string)
+			if !ok {
+				missing = append(missing, ids[i])
+				continue
+			}
+			var v V
+			if json.Unmarshal([]byte(str), &v) != nil {
+				missing = append(missing, ids[i])
+				continue
+			}
+			result[ids[i]] = v
+		}
+	}
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	loaded, err := load(missing)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := s.redisClient.Pipeline()
+	for _, id := range missing {
+		v, ok := loaded[id]
+		if !ok {
+			continue
+		}
+		result[id] = v
+		if data, err := json.Marshal(v); err == nil {
+			pipe.Set(ctx, keyFn(id), data, s.expiration)
+		}
+	}
+	_, _ = pipe.Exec(ctx)
+
+	return result, nil
+}
+
+// --- 缓存失效辅助函数 ---
+
+// invalidate 删除给定的缓存键，事务中则推迟到提交之后
+func (s *qaCacheStore) invalidate(ctx context.Context, keys ...string) {
+	if s.tx != nil {
+		s.tx.keys = append(s.tx.keys, keys...)
+		return
+	}
+	if len(keys) > 0 {
+		s.redisClient.Del(ctx, keys...)
+	}
+}
+
+// invalidateLists 删除所有已缓存的列表页。新问题会让每一页的内容都向后移动，
+// 因此列表页只能整体失效。
+func (s *qaCacheStore) invalidateLists(ctx context.Context) {
+	if s.tx != nil {
+		s.tx.lists = true
+		return
+	}
+	keys, err := s.redisClient.SMembers(ctx, questionListIndexKey).Result()
+	if err != nil {
+		return
+	}
+	s.redisClient.Del(ctx, append(keys, questionListIndexKey)...)
+}
+
+// --- 问题相关 (Question) ---
+
+// CreateQuestion 写入数据库后，使问题总数和列表页缓存失效。
+func (s *qaCacheStore) CreateQuestion(ctx context.Context, question *model.Question) (int64, error) {
+	id, err := s.next.CreateQuestion(ctx, question)
+	if err != nil {
+		return 0, err
+	}
+	s.invalidate(ctx, questionCountKey)
+	s.invalidateLists(ctx)
+	return id, nil
+}
+
+// GetQuestionForUpdate 直接穿透到下一层，加锁读取不能使用缓存。
+func (s *qaCacheStore) GetQuestionForUpdate(ctx context.Context, questionID int64) (*model.Question, error) {
+	return s.next.GetQuestionForUpdate(ctx, questionID)
+}
+
+// GetQuestionByID 实现了"读穿透"缓存逻辑。
+func (s *qaCacheStore) GetQuestionByID(ctx context.Context, questionID int64) (*model.Question, error) {
+	if s.tx != nil {
+		return s.next.GetQuestionByID(ctx, questionID)
+	}
+	return cached(ctx, s, questionKey(questionID), func() (*model.Question, error) {
+		return s.next.GetQuestionByID(ctx, questionID)
+	})
+}
+
+// GetQuestionsByIDs 与 GetQuestionByID 共用问题详情的缓存。
+func (s *qaCacheStore) GetQuestionsByIDs(ctx context.Context, questionIDs []int64) ([]*model.Question, error) {
+	if s.tx != nil {
+		return s.next.GetQuestionsByIDs(ctx, questionIDs)
+	}
+	byID, err := cachedBatch(ctx, s, questionIDs, questionKey, func(missing []int64) (map[int64]*model.Question, error) {
+		questions, err := s.next.GetQuestionsByIDs(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		m := make(map[int64]*model.Question, len(questions))
+		for _, q := range questions {
+			m[q.ID] = q
+		}
+		return m, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	questions := make([]*model.Question, 0, len(byID))
+	for _, q := range byID {
+		questions = append(questions, q)
+	}
+	return questions, nil
+}
+
+// ListQuestions 只缓存前 listPages 页，更深的分页直接穿透到下一层。
+func (s *qaCacheStore) ListQuestions(ctx context.Context, offset int64, limit int32) ([]*model.Question, error) {
+	if s.tx != nil || limit <= 0 || offset%int64(limit) != 0 || offset/int64(limit) >= int64(s.listPages) {
+		return s.next.ListQuestions(ctx, offset, limit)
+	}
+	key := questionListKey(offset, limit)
+	return cached(ctx, s, key, func() ([]*model.Question, error) {
+		questions, err := s.next.ListQuestions(ctx, offset, limit)
+		if err != nil {
+			return nil, err
+		}
+		s.redisClient.SAdd(ctx, questionListIndexKey, key)
+		return questions, nil
+	})
+}
+
+// ListQuestionsByUserID 直接穿透到下一层。
+func (s *qaCacheStore) ListQuestionsByUserID(ctx context.Context, userID int64, includeAnonymous bool, offset int64, limit int32) ([]*model.Question, error) {
+	return s.next.ListQuestionsByUserID(ctx, userID, includeAnonymous, offset, limit)
+}
+
+// CountQuestions 缓存问题总数，用于列表分页。
+func (s *qaCacheStore) CountQuestions(ctx context.Context) (int64, error) {
+	if s.tx != nil {
+		return s.next.CountQuestions(ctx)
+	}
+	return cached(ctx, s, questionCountKey, func() (int64, error) {
+		return s.next.CountQuestions(ctx)
+	})
+}
+
+// CountQuestionsByUserID 直接穿透到下一层。
+func (s *qaCacheStore) CountQuestionsByUserID(ctx context.Context, userID int64, includeAnonymous bool) (int64, error) {
+	return s.next.CountQuestionsByUserID(ctx, userID, includeAnonymous)
+}
+
+// UpdateQuestion 更新数据库后，使问题详情和列表页缓存失效。
+func (s *qaCacheStore) UpdateQuestion(ctx context.Context, question *model.Question) error {
+	if err := s.next.UpdateQuestion(ctx, question); err != nil {
+		return err
+	}
+	s.invalidate(ctx, questionKey(question.ID))
+	s.invalidateLists(ctx)
+	return nil
+}
+
+// DeleteQuestion 删除数据库记录后，使问题相关的所有缓存失效。
+func (s *qaCacheStore) DeleteQuestion(ctx context.Context, questionID int64) error {
+	if err := s.next.DeleteQuestion(ctx, questionID); err != nil {
+		return err
+	}
+	s.invalidate(ctx, questionKey(questionID), questionCountKey)
+	s.invalidateLists(ctx)
+	return nil
+}
+
+// GetUsernamesByIDs 按用户缓存用户名，用户名变更时依赖过期时间刷新。
+func (s *qaCacheStore) GetUsernamesByIDs(ctx context.Context, userIDs []int64) (map[int64]string, error) {
+	if s.tx != nil {
+		return s.next.GetUsernamesByIDs(ctx, userIDs)
+	}
+	return cachedBatch(ctx, s, userIDs, usernameKey, func(missing []int64) (map[int64]string, error) {
+		return s.next.GetUsernamesByIDs(ctx, missing)
+	})
+}
+
+// GetUserTypesByIDs 按用户缓存用户类型，用户类型变更时依赖过期时间刷新。
+func (s *qaCacheStore) GetUserTypesByIDs(ctx context.Context, userIDs []int64) (map[int64]string, error) {
+	if s.tx != nil {
+		return s.next.GetUserTypesByIDs(ctx, userIDs)
+	}
+	return cachedBatch(ctx, s, userIDs, userTypeKey, func(missing []int64) (map[int64]string, error) {
+		return s.next.GetUserTypesByIDs(ctx, missing)
+	})
+}
+
+// GetUserAvatarsByIDs 按用户缓存头像对象键，用户更换头像后依赖过期时间刷新。
+func (s *qaCacheStore) GetUserAvatarsByIDs(ctx context.Context, userIDs []int64) (map[int64]string, error) {
+	if s.tx != nil {
+		return s.next.GetUserAvatarsByIDs(ctx, userIDs)
+	}
+	return cachedBatch(ctx, s, userIDs, userAvatarKey, func(missing []int64) (map[int64]string, error) {
+		return s.next.GetUserAvatarsByIDs(ctx, missing)
+	})
+}
+
+// IncrementQuestionViewCount 直接穿透到下一层，浏览量不在缓存的数据中。
+func (s *qaCacheStore) IncrementQuestionViewCount(ctx context.Context, questionID int64) error {
+	return s.next.IncrementQuestionViewCount(ctx, questionID)
+}
+
+// AdjustQuestionCounters 更新计数后，使问题详情和列表页缓存失效。
+func (s *qaCacheStore) AdjustQuestionCounters(ctx context.Context, questionID int64, answerDelta, commentDelta int64) error {
+	if err := s.next.AdjustQuestionCounters(ctx, questionID, answerDelta, commentDelta); err != nil {
+		return err
+	}
+	s.invalidate(ctx, questionKey(questionID))
+	s.invalidateLists(ctx)
+	return nil
+}
+
+// TouchQuestionActivity 更新最近活动时间后，使问题详情和列表页缓存失效。
+func (s *qaCacheStore) TouchQuestionActivity(ctx context.Context, questionID int64) error {
+	if err := s.next.TouchQuestionActivity(ctx, questionID); err != nil {
+		return err
+	}
+	s.invalidate(ctx, questionKey(questionID))
+	s.invalidateLists(ctx)
+	return nil
+}
+
+// GetQuestionStatsByIDs 直接穿透到下一层。
+func (s *qaCacheStore) GetQuestionStatsByIDs(ctx context.Context, questionIDs []int64) ([]*model.QuestionStats, error) {
+	return s.next.GetQuestionStatsByIDs(ctx, questionIDs)
+}
+
+// ListQuestionStatsSince 直接穿透到下一层。
+func (s *qaCacheStore) ListQuestionStatsSince(ctx context.Context, since time.Time) ([]*model.QuestionStats, error) {
+	return s.next.ListQuestionStatsSince(ctx, since)
+}
+
+// --- 回答相关 (Answer) ---
+
+// CreateAnswer 直接穿透到下一层，问题的回答数由 AdjustQuestionCounters 维护。
+func (s *qaCacheStore) CreateAnswer(ctx context.Context, answer *model.Answer) (int64, error) {
+	return s.next.CreateAnswer(ctx, answer)
+}
+
+// GetAnswerForUpdate 直接穿透到下一层。
+func (s *qaCacheStore) GetAnswerForUpdate(ctx context.Context, answerID int64) (*model.Answer, error) {
+	return s.next.GetAnswerForUpdate(ctx, answerID)
+}
+
+// GetAnswerByID 直接穿透到下一层。
+func (s *qaCacheStore) GetAnswerByID(ctx context.Context, answerID int64) (*model.Answer, error) {
+	return s.next.GetAnswerByID(ctx, answerID)
+}
+
+// GetAnswersByIDs 直接穿透到下一层。
+func (s *qaCacheStore) GetAnswersByIDs(ctx context.Context, answerIDs []int64) ([]*model.Answer, error) {
+	return s.next.GetAnswersByIDs(ctx, answerIDs)
+}
+
+// ListAnswersByQuestionID 直接穿透到下一层。
+func (s *qaCacheStore) ListAnswersByQuestionID(ctx context.Context, questionID int64, offset int64, limit int32) ([]*model.Answer, error) {
+	return s.next.ListAnswersByQuestionID(ctx, questionID, offset, limit)
+}
+
+// ListAnswersByUserID 直接穿透到下一层。
+func (s *qaCacheStore) ListAnswersByUserID(ctx context.Context, userID int64, includeAnonymous bool, offset int64, limit int32) ([]*model.AnswerWithQuestion, error) {
+	return s.next.ListAnswersByUserID(ctx, userID, includeAnonymous, offset, limit)
+}
+
+// CountAnswersByQuestionID 直接穿透到下一层。
+func (s *qaCacheStore) CountAnswersByQuestionID(ctx context.Context, questionID int64) (int64, error) {
+	return s.next.CountAnswersByQuestionID(ctx, questionID)
+}
+
+// CountAnswersByUserID 直接穿透到下一层。
+func (s *qaCacheStore) CountAnswersByUserID(ctx context.Context, userID int64, includeAnonymous bool) (int64, error) {
+	return s.next.CountAnswersByUserID(ctx, userID, includeAnonymous)
+}
+
+// GetUserVotesForAnswers 直接穿透到下一层。
+func (s *qaCacheStore) GetUserVotesForAnswers(ctx context.Context, userID int64, answerIDs []int64) (map[int64]bool, error) {
+	return s.next.GetUserVotesForAnswers(ctx, userID, answerIDs)
+}
+
+// CreateAnswerVote 直接穿透到下一层，投票数据不在缓存中。
+func (s *qaCacheStore) CreateAnswerVote(ctx context.Context, answerID, userID int64, isUpvote bool) error {
+	return s.next.CreateAnswerVote(ctx, answerID, userID, isUpvote)
+}
+
+// GetAnswerVoteForUpdate 直接穿透到下一层。
+func (s *qaCacheStore) GetAnswerVoteForUpdate(ctx context.Context, answerID, userID int64) (bool, error) {
+	return s.next.GetAnswerVoteForUpdate(ctx, answerID, userID)
+}
+
+// DeleteAnswerVote 直接穿透到下一层。
+func (s *qaCacheStore) DeleteAnswerVote(ctx context.Context, answerID, userID int64) error {
+	return s.next.DeleteAnswerVote(ctx, answerID, userID)
+}
+
+// IncrementAnswerUpvoteCount 直接穿透到下一层。
+func (s *qaCacheStore) IncrementAnswerUpvoteCount(ctx context.Context, answerID int64) error {
+	return s.next.IncrementAnswerUpvoteCount(ctx, answerID)
+}
+
+// DecrementAnswerUpvoteCount 直接穿透到下一层。
+func (s *qaCacheStore) DecrementAnswerUpvoteCount(ctx context.Context, answerID int64) error {
+	return s.next.DecrementAnswerUpvoteCount(ctx, answerID)
+}
+
+// CountVotesByAnswerID 直接穿透到下一层。
+func (s *qaCacheStore) CountVotesByAnswerID(ctx context.Context, answerID int64) (int64, error) {
+	return s.next.CountVotesByAnswerID(ctx, answerID)
+}
+
+// UpdateAnswer 直接穿透到下一层，回答内容不在缓存中。
+func (s *qaCacheStore) UpdateAnswer(ctx context.Context, answer *model.Answer) error {
+	return s.next.UpdateAnswer(ctx, answer)
+}
+
+// DeleteAnswer 直接穿透到下一层，问题的回答数由 AdjustQuestionCounters 维护。
+func (s *qaCacheStore) DeleteAnswer(ctx context.Context, answerID int64) error {
+	return s.next.DeleteAnswer(ctx, answerID)
+}
+
+// SetAcceptedAnswer 设置成功后，使问题详情和列表页缓存失效。
+func (s *qaCacheStore) SetAcceptedAnswer(ctx context.Context, questionID, answerID int64) (bool, error) {
+	ok, err := s.next.SetAcceptedAnswer(ctx, questionID, answerID)
+	if err != nil || !ok {
+		return ok, err
+	}
+	s.invalidate(ctx, questionKey(questionID))
+	s.invalidateLists(ctx)
+	return true, nil
+}
+
+// ClearAcceptedAnswer 清除采纳记录后，使所属问题的详情和列表页缓存失效。
+func (s *qaCacheStore) ClearAcceptedAnswer(ctx context.Context, answerID int64) error {
+	answer, err := s.next.GetAnswerByID(ctx, answerID)
+	if err != nil {
+		return err
+	}
+	if err := s.next.ClearAcceptedAnswer(ctx, answerID); err != nil {
+		return err
+	}
+	s.invalidate(ctx, questionKey(answer.QuestionID))
+	s.invalidateLists(ctx)
+	return nil
+}
+
+// --- 评论相关 (Comment) ---
+// 评论本身不在缓存中，问题的评论数由 AdjustQuestionCounters 维护，所有评论操作都直接穿透到下一层。
+
+func (s *qaCacheStore) CreateComment(ctx context.Context, comment *model.Comment) (int64, error) {
+	return s.next.CreateComment(ctx, comment)
+}
+
+func (s *qaCacheStore) GetCommentByID(ctx context.Context, commentID int64) (*model.Comment, error) {
+	return s.next.GetCommentByID(ctx, commentID)
+}
+
+func (s *qaCacheStore) ListCommentsByAnswerID(ctx context.Context, answerID int64, offset int64, limit int32) ([]*model.Comment, error) {
+	return s.next.ListCommentsByAnswerID(ctx, answerID, offset, limit)
+}
+
+func (s *qaCacheStore) CountCommentsByAnswerID(ctx context.Context, answerID int64) (int64, error) {
+	return s.next.CountCommentsByAnswerID(ctx, answerID)
+}
+
+func (s *qaCacheStore) ListCommentsByUserID(ctx context.Context, userID int64, offset int64, limit int32) ([]*model.CommentWithQuestion, error) {
+	return s.next.ListCommentsByUserID(ctx, userID, offset, limit)
+}
+
+func (s *qaCacheStore) CountCommentsByUserID(ctx context.Context, userID int64) (int64, error) {
+	return s.next.CountCommentsByUserID(ctx, userID)
+}
+
+func (s *qaCacheStore) UpdateComment(ctx context.Context, comment *model.Comment) error {
+	return s.next.UpdateComment(ctx, comment)
+}
+
+func (s *qaCacheStore) DeleteComment(ctx context.Context, commentID int64) error {
+	return s.next.DeleteComment(ctx, commentID)
+}
+
+// --- 用户动态 (Activity) ---
+
+// ListActivitiesByUserID 直接穿透到下一层。
+func (s *qaCacheStore) ListActivitiesByUserID(ctx context.Context, userID int64, includeAnonymous bool, offset int64, limit int32) ([]*model.Activity, error) {
+	return s.next.ListActivitiesByUserID(ctx, userID, includeAnonymous, offset, limit)
+}
+
+// --- 计数校对 (Counter reconciliation) ---
+
+// ListCounterDrifts 直接穿透到下一层。
+func (s *qaCacheStore) ListCounterDrifts(ctx context.Context) ([]*model.CounterDrift, error) {
+	return s.next.ListCounterDrifts(ctx)
+}
+
+// RecountQuestionCounters 修正计数后，使问题详情和列表页缓存失效。
+func (s *qaCacheStore) RecountQuestionCounters(ctx context.Context, questionID int64) error {
+	if err := s.next.RecountQuestionCounters(ctx, questionID); err != nil {
+		return err
+	}
+	s.invalidate(ctx, questionKey(questionID))
+	s.invalidateLists(ctx)
+	return nil
+}
+
+// RecountAnswerUpvotes 直接穿透到下一层，回答不在缓存中。
+func (s *qaCacheStore) RecountAnswerUpvotes(ctx context.Context, answerID int64) error {
+	return s.next.RecountAnswerUpvotes(ctx, answerID)
+}
+
+// --- 修改建议 (Suggested edit) ---
+
+// CreateSuggestedEdit 直接穿透到下一层。
+func (s *qaCacheStore) CreateSuggestedEdit(ctx context.Context, edit *model.SuggestedEdit) (int64, error) {
+	return s.next.CreateSuggestedEdit(ctx, edit)
+}
+
+// GetSuggestedEditByID 直接穿透到下一层。
+func (s *qaCacheStore) GetSuggestedEditByID(ctx context.Context, editID int64) (*model.SuggestedEdit, error) {
+	return s.next.GetSuggestedEditByID(ctx, editID)
+}
+
+// ListSuggestedEdits 直接穿透到下一层。
+func (s *qaCacheStore) ListSuggestedEdits(ctx context.Context, targetType string, targetID int64, status string, offset int64, limit int32) ([]*model.SuggestedEdit, error) {
+	return s.next.ListSuggestedEdits(ctx, targetType, targetID, status, offset, limit)
+}
+
+// CountSuggestedEdits 直接穿透到下一层。
+func (s *qaCacheStore) CountSuggestedEdits(ctx context.Context, targetType string, targetID int64, status string) (int64, error) {
+	return s.next.CountSuggestedEdits(ctx, targetType, targetID, status)
+}
+
+// ReviewSuggestedEdit 直接穿透到下一层，建议通过后对问题的修改由 UpdateQuestion 负责失效缓存。
+func (s *qaCacheStore) ReviewSuggestedEdit(ctx context.Context, editID int64, status string, reviewerID int64, comment string) (bool, error) {
+	return s.next.ReviewSuggestedEdit(ctx, editID, status, reviewerID, comment)
+}
+
+// --- 违规标记 (Content flag) ---
+
+// CreateContentFlag 直接穿透到下一层，标记记录不在缓存中。
+func (s *qaCacheStore) CreateContentFlag(ctx context.Context, flag *model.ContentFlag) (bool, error) {
+	return s.next.CreateContentFlag(ctx, flag)
+}
+
+// --- 内容导出导入 (Content export/import) ---
+
+// ListUsersForExport 直接穿透到下一层。
+func (s *qaCacheStore) ListUsersForExport(ctx context.Context, afterID int64, limit int32) ([]*model.ContentUser, error) {
+	return s.next.ListUsersForExport(ctx, afterID, limit)
+}
+
+// ListQuestionsForExport 直接穿透到下一层。
+func (s *qaCacheStore) ListQuestionsForExport(ctx context.Context, afterID int64, limit int32) ([]*model.Question, error) {
+	return s.next.ListQuestionsForExport(ctx, afterID, limit)
+}
+
+// ListAnswersForExport 直接穿透到下一层。
+func (s *qaCacheStore) ListAnswersForExport(ctx context.Context, afterID int64, limit int32) ([]*model.Answer, error) {
+	return s.next.ListAnswersForExport(ctx, afterID, limit)
+}
+
+// ListCommentsForExport 直接穿透到下一层。
+func (s *qaCacheStore) ListCommentsForExport(ctx context.Context, afterID int64, limit int32) ([]*model.Comment, error) {
+	return s.next.ListCommentsForExport(ctx, afterID, limit)
+}
+
+// ListVotesForExport 直接穿透到下一层。
+func (s *qaCacheStore) ListVotesForExport(ctx context.Context, afterID int64, limit int32) ([]*model.ContentVote, error) {
+	return s.next.ListVotesForExport(ctx, afterID, limit)
+}
+
+// HasQAContent 直接穿透到下一层，不能使用可能过期的问题总数缓存。
+func (s *qaCacheStore) HasQAContent(ctx context.Context) (bool, error) {
+	return s.next.HasQAContent(ctx)
+}
+
+// ImportQuestion 导入问题后，使列表页和总数缓存失效。
+func (s *qaCacheStore) ImportQuestion(ctx context.Context, question *model.Question) (int64, error) {
+	id, err := s.next.ImportQuestion(ctx, question)
+	if err != nil {
+		return 0, err
+	}
+	s.invalidate(ctx, questionCountKey)
+	s.invalidateLists(ctx)
+	return id, nil
+}
+
+// ImportAnswer 直接穿透到下一层，问题的回答数由 AdjustQuestionCounters 负责失效。
+func (s *qaCacheStore) ImportAnswer(ctx context.Context, answer *model.Answer) (int64, error) {
+	return s.next.ImportAnswer(ctx, answer)
+}
+
+// ImportComment 直接穿透到下一层。
+func (s *qaCacheStore) ImportComment(ctx context.Context, comment *model.Comment) (int64, error) {
+	return s.next.ImportComment(ctx, comment)
+}
+
+// ImportVote 直接穿透到下一层。
+func (s *qaCacheStore) ImportVote(ctx context.Context, vote *model.ContentVote) error {
+	return s.next.ImportVote(ctx, vote)
+}
+
+// --- 事务 ---
+
+// ExecTx 在下一层开启事务，事务内的写操作收集到的缓存失效在提交成功后统一执行。
+func (s *qaCacheStore) ExecTx(ctx context.Context, fn func(QAStore) error) error {
+	if s.tx != nil {
+		return fn(s)
+	}
+
+	tx := &cacheTx{}
+	err := s.next.ExecTx(ctx, func(inner QAStore) error {
+		return fn(&qaCacheStore{
+			redisClient: s.redisClient,
+			next:        inner,
+			expiration:  s.expiration,
+			listPages:   s.listPages,
+			group:       s.group,
+			tx:          tx,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	s.invalidate(ctx, tx.keys...)
+	if tx.lists {
+		s.invalidateLists(ctx)
+	}
+	return nil
+}
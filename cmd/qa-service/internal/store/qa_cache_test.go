@@ -0,0 +1,331 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"qahub/pkg/config"
+	"qahub/qa-service/internal/model"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQAStore 是缓存装饰器的下一层，只实现测试用到的方法，并记录每个方法的回源次数
+type fakeQAStore struct {
+	QAStore
+
+	mu        sync.Mutex
+	questions map[int64]*model.Question
+	nextID    int64
+	calls     map[string]int
+	ids       [][]int64     // 每次 GetQuestionsByIDs 回源时请求的 ID
+	release   chan struct{} // 非空时 GetQuestionByID 阻塞到通道关闭，用于制造并发的缓存未命中
+}
+
+func newFakeQAStore() *fakeQAStore {
+	return &fakeQAStore{
+		questions: map[int64]*model.Question{
+			1: {ID: 1, Title: "第一个问题"},
+			2: {ID: 2, Title: "第二个问题"},
+		},
+		nextID: 3,
+		calls:  make(map[string]int),
+	}
+}
+
+func (f *fakeQAStore) called(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[method]
+}
+
+func (f *fakeQAStore) record(method string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[method]++
+}
+
+func (f *fakeQAStore) GetQuestionByID(ctx context.Context, questionID int64) (*model.Question, error) {
+	f.record("GetQuestionByID")
+	if f.release != nil {
+		<-f.release
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	q, ok := f.questions[questionID]
+	if !ok {
+		return nil, errors.New("question not found")
+	}
+	copied := *q
+	return &copied, nil
+}
+
+func (f *fakeQAStore) GetQuestionsByIDs(ctx context.Context, questionIDs []int64) ([]*model.Question, error) {
+	f.record("GetQuestionsByIDs")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.ids = append(f.ids, questionIDs)
+	var questions []*model.Question
+	for _, id := range questionIDs {
+		if q, ok := f.questions[id]; ok {
+			copied := *q
+			questions = append(questions, &copied)
+		}
+	}
+	return questions, nil
+}
+
+func (f *fakeQAStore) ListQuestions(ctx context.Context, offset int64, limit int32) ([]*model.Question, error) {
+	f.record("ListQuestions")
+	return []*model.Question{{ID: 1}}, nil
+}
+
+func (f *fakeQAStore) CountQuestions(ctx context.Context) (int64, error) {
+	f.record("CountQuestions")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return int64(len(f.questions)), nil
+}
+
+func (f *fakeQAStore) CreateQuestion(ctx context.Context, question *model.Question) (int64, error) {
+	f.record("CreateQuestion")
+	return f.insert(question), nil
+}
+
+func (f *fakeQAStore) ImportQuestion(ctx context.Context, question *model.Question) (int64, error) {
+	f.record("ImportQuestion")
+	return f.insert(question), nil
+}
+
+func (f *fakeQAStore) insert(question *model.Question) int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	question.ID = f.nextID
+	f.nextID++
+	f.questions[question.ID] = question
+	return question.ID
+}
+
+func (f *fakeQAStore) UpdateQuestion(ctx context.Context, question *model.Question) error {
+	f.record("UpdateQuestion")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.questions[question.ID] = question
+	return nil
+}
+
+func (f *fakeQAStore) DeleteQuestion(ctx context.Context, questionID int64) error {
+	f.record("DeleteQuestion")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.questions, questionID)
+	return nil
+}
+
+// ExecTx 直接在当前 store 上执行，fn 返回错误即视为回滚
+func (f *fakeQAStore) ExecTx(ctx context.Context, fn func(QAStore) error) error {
+	return fn(f)
+}
+
+func newTestCacheStore(t *testing.T) (*qaCacheStore, *fakeQAStore, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	next := newFakeQAStore()
+	return NewQACacheStore(client, next, config.QACache{TTL: time.Minute, ListPages: 2}), next, mr
+}
+
+// warm 读取一次问题详情、问题总数和第一页列表，使它们进入缓存
+func warm(t *testing.T, s *qaCacheStore, mr *miniredis.Miniredis) {
+	t.Helper()
+	ctx := context.Background()
+	_, err := s.GetQuestionByID(ctx, 1)
+	require.NoError(t, err)
+	_, err = s.CountQuestions(ctx)
+	require.NoError(t, err)
+	_, err = s.ListQuestions(ctx, 0, 10)
+	require.NoError(t, err)
+	require.True(t, mr.Exists(questionKey(1)))
+	require.True(t, mr.Exists(questionCountKey))
+	require.True(t, mr.Exists(questionListKey(0, 10)))
+}
+
+func TestQACacheReadThrough(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("未命中时回源并写入缓存，之后直接命中", func(t *testing.T) {
+		s, next, mr := newTestCacheStore(t)
+
+		for range 3 {
+			q, err := s.GetQuestionByID(ctx, 1)
+			require.NoError(t, err)
+			assert.Equal(t, "第一个问题", q.Title)
+		}
+		assert.Equal(t, 1, next.called("GetQuestionByID"))
+		assert.Equal(t, time.Minute, mr.TTL(questionKey(1)))
+	})
+
+	t.Run("回源失败时不写入缓存", func(t *testing.T) {
+		s, next, mr := newTestCacheStore(t)
+
+		_, err := s.GetQuestionByID(ctx, 404)
+		assert.Error(t, err)
+		_, err = s.GetQuestionByID(ctx, 404)
+		assert.Error(t, err)
+		assert.Equal(t, 2, next.called("GetQuestionByID"))
+		assert.False(t, mr.Exists(questionKey(404)))
+	})
+
+	t.Run("批量读取只回源缺失的部分", func(t *testing.T) {
+		s, next, _ := newTestCacheStore(t)
+
+		_, err := s.GetQuestionByID(ctx, 1)
+		require.NoError(t, err)
+		questions, err := s.GetQuestionsByIDs(ctx, []int64{1, 2})
+		require.NoError(t, err)
+		assert.Len(t, questions, 2)
+		assert.Equal(t, [][]int64{{2}}, next.ids)
+
+		_, err = s.GetQuestionsByIDs(ctx, []int64{1, 2})
+		require.NoError(t, err)
+		assert.Equal(t, 1, next.called("GetQuestionsByIDs"))
+	})
+
+	t.Run("只缓存前 listPages 页", func(t *testing.T) {
+		s, next, mr := newTestCacheStore(t)
+
+		for range 2 {
+			_, err := s.ListQuestions(ctx, 10, 10)
+			require.NoError(t, err)
+			_, err = s.ListQuestions(ctx, 20, 10)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 3, next.called("ListQuestions"))
+		assert.True(t, mr.Exists(questionListKey(10, 10)))
+		assert.False(t, mr.Exists(questionListKey(20, 10)))
+	})
+}
+
+func TestQACacheInvalidation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("创建问题后总数和列表页失效", func(t *testing.T) {
+		s, _, mr := newTestCacheStore(t)
+		warm(t, s, mr)
+
+		_, err := s.CreateQuestion(ctx, &model.Question{Title: "新问题"})
+		require.NoError(t, err)
+		assert.False(t, mr.Exists(questionCountKey))
+		assert.False(t, mr.Exists(questionListKey(0, 10)))
+		assert.False(t, mr.Exists(questionListIndexKey))
+		assert.True(t, mr.Exists(questionKey(1)), "其他问题的详情不受影响")
+	})
+
+	t.Run("删除问题后详情、总数和列表页失效", func(t *testing.T) {
+		s, _, mr := newTestCacheStore(t)
+		warm(t, s, mr)
+
+		require.NoError(t, s.DeleteQuestion(ctx, 1))
+		assert.False(t, mr.Exists(questionKey(1)))
+		assert.False(t, mr.Exists(questionCountKey))
+		assert.False(t, mr.Exists(questionListKey(0, 10)))
+	})
+
+	t.Run("导入问题后总数和列表页失效", func(t *testing.T) {
+		s, next, mr := newTestCacheStore(t)
+		warm(t, s, mr)
+
+		_, err := s.ImportQuestion(ctx, &model.Question{Title: "导入的问题"})
+		require.NoError(t, err)
+		assert.False(t, mr.Exists(questionCountKey))
+		assert.False(t, mr.Exists(questionListKey(0, 10)))
+
+		count, err := s.CountQuestions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+		assert.Equal(t, 2, next.called("CountQuestions"))
+	})
+}
+
+func TestQACacheTxInvalidation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("失效推迟到事务提交之后", func(t *testing.T) {
+		s, next, mr := newTestCacheStore(t)
+		warm(t, s, mr)
+
+		err := s.ExecTx(ctx, func(tx QAStore) error {
+			if err := tx.UpdateQuestion(ctx, &model.Question{ID: 1, Title: "修改后的问题"}); err != nil {
+				return err
+			}
+			// 提交前缓存仍然有效，事务内的读取直接访问下一层
+			assert.True(t, mr.Exists(questionKey(1)))
+			assert.True(t, mr.Exists(questionListKey(0, 10)))
+			q, err := tx.GetQuestionByID(ctx, 1)
+			require.NoError(t, err)
+			assert.Equal(t, "修改后的问题", q.Title)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, next.called("GetQuestionByID"))
+
+		assert.False(t, mr.Exists(questionKey(1)))
+		assert.False(t, mr.Exists(questionListKey(0, 10)))
+		assert.True(t, mr.Exists(questionCountKey))
+	})
+
+	t.Run("事务回滚时不失效", func(t *testing.T) {
+		s, _, mr := newTestCacheStore(t)
+		warm(t, s, mr)
+
+		rollback := errors.New("rollback")
+		err := s.ExecTx(ctx, func(tx QAStore) error {
+			if _, err := tx.CreateQuestion(ctx, &model.Question{Title: "新问题"}); err != nil {
+				return err
+			}
+			require.NoError(t, tx.DeleteQuestion(ctx, 1))
+			return rollback
+		})
+		assert.ErrorIs(t, err, rollback)
+
+		assert.True(t, mr.Exists(questionKey(1)))
+		assert.True(t, mr.Exists(questionCountKey))
+		assert.True(t, mr.Exists(questionListKey(0, 10)))
+	})
+}
+
+func TestQACacheCollapsesConcurrentMisses(t *testing.T) {
+	s, next, _ := newTestCacheStore(t)
+	next.release = make(chan struct{})
+	ctx := context.Background()
+
+	const callers = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.GetQuestionByID(ctx, 1)
+			errs <- err
+		}()
+	}
+
+	// 等所有请求都在等待同一次回源之后再放行
+	time.Sleep(50 * time.Millisecond)
+	close(next.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, next.called("GetQuestionByID"))
+}
@@ -14,23 +14,45 @@ type QAStore interface {
 	// --- 问题相关 (Question) ---
 	CreateQuestion(ctx context.Context, question *model.Question) (int64, error)
 	GetQuestionByID(ctx context.Context, questionID int64) (*model.Question, error)
+	// GetQuestionForUpdate 锁定并返回问题，应在事务中调用
+	GetQuestionForUpdate(ctx context.Context, questionID int64) (*model.Question, error)
+	GetQuestionsByIDs(ctx context.Context, questionIDs []int64) ([]*model.Question, error)
 	ListQuestions(ctx context.Context, offset int64, limit int32) ([]*model.Question, error)
-	ListQuestionsByUserID(ctx context.Context, userID int64, offset int64, limit int32) ([]*model.Question, error)
+	// ListQuestionsByUserID 和 CountQuestionsByUserID 在 includeAnonymous 为 false 时排除用户匿名发布的问题
+	ListQuestionsByUserID(ctx context.Context, userID int64, includeAnonymous bool, offset int64, limit int32) ([]*model.Question, error)
 	CountQuestions(ctx context.Context) (int64, error)
+	CountQuestionsByUserID(ctx context.Context, userID int64, includeAnonymous bool) (int64, error)
 	UpdateQuestion(ctx context.Context, question *model.Question) error
 	DeleteQuestion(ctx context.Context, questionID int64) error
-	GetAnswerCountByQuestionIDs(ctx context.Context, questionIDs []int64) (map[int64]int64, error)
 	GetUsernamesByIDs(ctx context.Context, userIDs []int64) (map[int64]string, error)
+	GetUserTypesByIDs(ctx context.Context, userIDs []int64) (map[int64]string, error)
+	// GetUserAvatarsByIDs 批量获取用户头像的对象键前缀，没有头像的用户对应空字符串
+	GetUserAvatarsByIDs(ctx context.Context, userIDs []int64) (map[int64]string, error)
+	IncrementQuestionViewCount(ctx context.Context, questionID int64) error
+	// AdjustQuestionCounters 增减问题的回答数和评论数，应与回答、评论的写入放在同一事务中
+	AdjustQuestionCounters(ctx context.Context, questionID int64, answerDelta, commentDelta int64) error
+	// TouchQuestionActivity 将问题的最近活动时间更新为当前时间
+	TouchQuestionActivity(ctx context.Context, questionID int64) error
+	// GetQuestionStatsByIDs 和 ListQuestionStatsSince 返回计算热门分数所需的统计数据
+	GetQuestionStatsByIDs(ctx context.Context, questionIDs []int64) ([]*model.QuestionStats, error)
+	ListQuestionStatsSince(ctx context.Context, since time.Time) ([]*model.QuestionStats, error)
 
 	// --- 回答相关 (Answer) ---
 	CreateAnswer(ctx context.Context, answer *model.Answer) (int64, error)
 	GetAnswerByID(ctx context.Context, answerID int64) (*model.Answer, error)
+	// GetAnswerForUpdate 锁定并返回回答，应在事务中调用
+	GetAnswerForUpdate(ctx context.Context, answerID int64) (*model.Answer, error)
+	GetAnswersByIDs(ctx context.Context, answerIDs []int64) ([]*model.Answer, error)
 	ListAnswersByQuestionID(ctx context.Context, questionID int64, offset int64, limit int32) ([]*model.Answer, error)
-	// ListAnswersByUserID(ctx context.Context, userID int64, offset int, limit int) ([]*model.Answer, error)
+	// ListAnswersByUserID 和 CountAnswersByUserID 在 includeAnonymous 为 false 时排除用户匿名发布的回答
+	ListAnswersByUserID(ctx context.Context, userID int64, includeAnonymous bool, offset int64, limit int32) ([]*model.AnswerWithQuestion, error)
 	CountAnswersByQuestionID(ctx context.Context, questionID int64) (int64, error)
+	CountAnswersByUserID(ctx context.Context, userID int64, includeAnonymous bool) (int64, error)
 	GetUserVotesForAnswers(ctx context.Context, userID int64, answerIDs []int64) (map[int64]bool, error)
 
 	CreateAnswerVote(ctx context.Context, answerID, userID int64, isUpvote bool) error
+	// GetAnswerVoteForUpdate 锁定并返回用户对回答的投票是否为点赞，没有投票时返回 sql.ErrNoRows，应在事务中调用
+	GetAnswerVoteForUpdate(ctx context.Context, answerID, userID int64) (bool, error)
 	DeleteAnswerVote(ctx context.Context, answerID, userID int64) error
 	IncrementAnswerUpvoteCount(ctx context.Context, answerID int64) error
 	DecrementAnswerUpvoteCount(ctx context.Context, answerID int64) error
@@ -38,17 +60,67 @@ type QAStore interface {
 
 	UpdateAnswer(ctx context.Context, answer *model.Answer) error
 	DeleteAnswer(ctx context.Context, answerID int64) error
+	// SetAcceptedAnswer 仅在问题尚未采纳回答时设置采纳，返回是否设置成功
+	SetAcceptedAnswer(ctx context.Context, questionID, answerID int64) (bool, error)
+	// ClearAcceptedAnswer 在回答被删除前清除引用它的采纳记录
+	ClearAcceptedAnswer(ctx context.Context, answerID int64) error
 
 	// --- 评论相关 (Comment) ---
 	CreateComment(ctx context.Context, comment *model.Comment) (int64, error)
 	GetCommentByID(ctx context.Context, commentID int64) (*model.Comment, error)
 	ListCommentsByAnswerID(ctx context.Context, answerID int64, offset int64, limit int32) ([]*model.Comment, error)
 	CountCommentsByAnswerID(ctx context.Context, answerID int64) (int64, error)
+	ListCommentsByUserID(ctx context.Context, userID int64, offset int64, limit int32) ([]*model.CommentWithQuestion, error)
+	CountCommentsByUserID(ctx context.Context, userID int64) (int64, error)
 	UpdateComment(ctx context.Context, comment *model.Comment) error
 	DeleteComment(ctx context.Context, commentID int64) error
 
+	// --- 用户动态 (Activity) ---
+	// ListActivitiesByUserID 在 includeAnonymous 为 false 时排除用户匿名发布的问题和回答
+	ListActivitiesByUserID(ctx context.Context, userID int64, includeAnonymous bool, offset int64, limit int32) ([]*model.Activity, error)
+
+	// --- 计数校对 (Counter reconciliation) ---
+	// ListCounterDrifts 根据源表重新统计所有冗余计数，返回与保存值不一致的记录
+	ListCounterDrifts(ctx context.Context) ([]*model.CounterDrift, error)
+	// RecountQuestionCounters 根据源表重新计算并写回问题的回答数和评论数
+	RecountQuestionCounters(ctx context.Context, questionID int64) error
+	// RecountAnswerUpvotes 根据投票表重新计算并写回回答的点赞数
+	RecountAnswerUpvotes(ctx context.Context, answerID int64) error
+
+	// --- 修改建议 (Suggested edit) ---
+	CreateSuggestedEdit(ctx context.Context, edit *model.SuggestedEdit) (int64, error)
+	GetSuggestedEditByID(ctx context.Context, editID int64) (*model.SuggestedEdit, error)
+	// ListSuggestedEdits 列出某个问题或回答的修改建议，status 为空时不按状态过滤
+	ListSuggestedEdits(ctx context.Context, targetType string, targetID int64, status string, offset int64, limit int32) ([]*model.SuggestedEdit, error)
+	CountSuggestedEdits(ctx context.Context, targetType string, targetID int64, status string) (int64, error)
+	// ReviewSuggestedEdit 仅在建议仍待审核时记录审核结果，返回是否记录成功
+	ReviewSuggestedEdit(ctx context.Context, editID int64, status string, reviewerID int64, comment string) (bool, error)
+
+	// --- 违规标记 (Content flag) ---
+	// CreateContentFlag 仅在内容尚未被标记时写入，返回是否写入成功
+	CreateContentFlag(ctx context.Context, flag *model.ContentFlag) (bool, error)
+
+	// --- 内容导出导入 (Content export/import) ---
+	// List*ForExport 按 ID 升序分批读取 ID 大于 afterID 的记录
+	ListUsersForExport(ctx context.Context, afterID int64, limit int32) ([]*model.ContentUser, error)
+	ListQuestionsForExport(ctx context.Context, afterID int64, limit int32) ([]*model.Question, error)
+	ListAnswersForExport(ctx context.Context, afterID int64, limit int32) ([]*model.Answer, error)
+	ListCommentsForExport(ctx context.Context, afterID int64, limit int32) ([]*model.Comment, error)
+	ListVotesForExport(ctx context.Context, afterID int64, limit int32) ([]*model.ContentVote, error)
+	// HasQAContent 返回是否已存在任何问题，导入只允许在没有问答数据的实例上进行
+	HasQAContent(ctx context.Context) (bool, error)
+	// Import* 写入导入的记录并保留原有的创建和更新时间，返回新生成的ID。用户由用户服务负责导入
+	ImportQuestion(ctx context.Context, question *model.Question) (int64, error)
+	ImportAnswer(ctx context.Context, answer *model.Answer) (int64, error)
+	ImportComment(ctx context.Context, comment *model.Comment) (int64, error)
+	ImportVote(ctx context.Context, vote *model.ContentVote) error
+
 	ExecTx(ctx context.Context, fn func(QAStore) error) error
 }
+
+// questionSelect 是读取问题时统一使用的查询列
+const questionSelect = "SELECT id, title, content, user_id, is_anonymous, COALESCE(accepted_answer_id, 0) AS accepted_answer_id, answer_count, comment_count, last_activity_at, created_at, updated_at FROM questions"
+
 type querier interface {
 	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
 	GetContext(ctx context.Context, dest any, query string, args ...any) error
@@ -85,8 +157,8 @@ func (s *sqlxQAStore) startCheckHealth() {
 // --- 问题相关 (Question) ---
 
 func (s *sqlxQAStore) CreateQuestion(ctx context.Context, question *model.Question) (int64, error) {
-	query := "INSERT INTO questions (title, content, user_id) VALUES (?, ?, ?)"
-	result, err := s.db.ExecContext(ctx, query, question.Title, question.Content, question.UserID)
+	query := "INSERT INTO questions (title, content, user_id, is_anonymous) VALUES (?, ?, ?, ?)"
+	result, err := s.db.ExecContext(ctx, query, question.Title, question.Content, question.UserID, question.IsAnonymous)
 	if err != nil {
 		return 0, err
 	}
@@ -98,7 +170,7 @@ func (s *sqlxQAStore) CreateQuestion(ctx context.Context, question *model.Questi
 }
 
 func (s *sqlxQAStore) GetQuestionByID(ctx context.Context, questionID int64) (*model.Question, error) {
-	query := "SELECT id, title, content, user_id, created_at, updated_at FROM questions WHERE id = ?"
+	query := questionSelect + " WHERE id = ?"
 	var question model.Question
 	err := s.db.GetContext(ctx, &question, query, questionID)
 	if err != nil {
@@ -107,8 +179,37 @@ func (s *sqlxQAStore) GetQuestionByID(ctx context.Context, questionID int64) (*m
 	return &question, nil
 }
 
+func (s *sqlxQAStore) GetQuestionForUpdate(ctx context.Context, questionID int64) (*model.Question, error) {
+	query := questionSelect + " WHERE id = ? FOR UPDATE"
+	var question model.Question
+	err := s.db.GetContext(ctx, &question, query, questionID)
+	if err != nil {
+		return nil, err
+	}
+	return &question, nil
+}
+
+// GetQuestionsByIDs 批量获取问题，不保证返回顺序，不存在的 ID 会被忽略
+func (s *sqlxQAStore) GetQuestionsByIDs(ctx context.Context, questionIDs []int64) ([]*model.Question, error) {
+	questions := []*model.Question{}
+	if len(questionIDs) == 0 {
+		return questions, nil
+	}
+
+	query, args, err := sqlx.In(questionSelect+" WHERE id IN (?)", questionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query = s.dbConn.Rebind(query)
+	if err := s.db.SelectContext(ctx, &questions, query, args...); err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
 func (s *sqlxQAStore) ListQuestions(ctx context.Context, offset int64, limit int32) ([]*model.Question, error) {
-	query := "SELECT id, title, content, user_id, created_at, updated_at FROM questions ORDER BY created_at DESC LIMIT ? OFFSET ?"
+	query := questionSelect + " ORDER BY created_at DESC LIMIT ? OFFSET ?"
 	var questions []*model.Question
 	err := s.db.SelectContext(ctx, &questions, query, limit, offset)
 	if err != nil {
@@ -117,10 +218,10 @@ func (s *sqlxQAStore) ListQuestions(ctx context.Context, offset int64, limit int
 	return questions, nil
 }
 
-func (s *sqlxQAStore) ListQuestionsByUserID(ctx context.Context, userID int64, offset int64, limit int32) ([]*model.Question, error) {
-	query := "SELECT id, title, content, user_id, created_at, updated_at FROM questions WHERE user_id = ? ORDER BY created_at DESC LIMIT ? OFFSET ?"
+func (s *sqlxQAStore) ListQuestionsByUserID(ctx context.Context, userID int64, includeAnonymous bool, offset int64, limit int32) ([]*model.Question, error) {
+	query := questionSelect + " WHERE user_id = ? AND (? OR is_anonymous = FALSE) ORDER BY created_at DESC LIMIT ? OFFSET ?"
 	var questions []*model.Question
-	err := s.db.SelectContext(ctx, &questions, query, userID, limit, offset)
+	err := s.db.SelectContext(ctx, &questions, query, userID, includeAnonymous, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -137,8 +238,18 @@ func (s *sqlxQAStore) CountQuestions(ctx context.Context) (int64, error) {
 	return count, nil
 }
 
+func (s *sqlxQAStore) CountQuestionsByUserID(ctx context.Context, userID int64, includeAnonymous bool) (int64, error) {
+	var count int64
+	query := "SELECT COUNT(*) FROM questions WHERE user_id = ? AND (? OR is_anonymous = FALSE)"
+	err := s.db.GetContext(ctx, &count, query, userID, includeAnonymous)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (s *sqlxQAStore) UpdateQuestion(ctx context.Context, question *model.Question) error {
-	query := "UPDATE questions SET title = ?, content = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
+	query := "UPDATE questions SET title = ?, content = ?, updated_at = CURRENT_TIMESTAMP, last_activity_at = CURRENT_TIMESTAMP WHERE id = ?"
 	_, err := s.db.ExecContext(ctx, query, question.Title, question.Content, question.ID)
 	return err
 }
@@ -152,8 +263,8 @@ func (s *sqlxQAStore) DeleteQuestion(ctx context.Context, questionID int64) erro
 // --- 回答相关 (Answer) ---
 
 func (s *sqlxQAStore) CreateAnswer(ctx context.Context, answer *model.Answer) (int64, error) {
-	query := "INSERT INTO answers (question_id, content, user_id) VALUES (?, ?, ?)"
-	result, err := s.db.ExecContext(ctx, query, answer.QuestionID, answer.Content, answer.UserID)
+	query := "INSERT INTO answers (question_id, content, user_id, is_anonymous) VALUES (?, ?, ?, ?)"
+	result, err := s.db.ExecContext(ctx, query, answer.QuestionID, answer.Content, answer.UserID, answer.IsAnonymous)
 	if err != nil {
 		return 0, err
 	}
@@ -165,7 +276,17 @@ func (s *sqlxQAStore) CreateAnswer(ctx context.Context, answer *model.Answer) (i
 }
 
 func (s *sqlxQAStore) GetAnswerByID(ctx context.Context, answerID int64) (*model.Answer, error) {
-	query := "SELECT id, question_id, content, user_id, upvote_count, created_at, updated_at FROM answers WHERE id = ?"
+	query := "SELECT id, question_id, content, user_id, is_anonymous, upvote_count, created_at, updated_at FROM answers WHERE id = ?"
+	var answer model.Answer
+	err := s.db.GetContext(ctx, &answer, query, answerID)
+	if err != nil {
+		return nil, err
+	}
+	return &answer, nil
+}
+
+func (s *sqlxQAStore) GetAnswerForUpdate(ctx context.Context, answerID int64) (*model.Answer, error) {
+	query := "SELECT id, question_id, content, user_id, is_anonymous, upvote_count, created_at, updated_at FROM answers WHERE id = ? FOR UPDATE"
 	var answer model.Answer
 	err := s.db.GetContext(ctx, &answer, query, answerID)
 	if err != nil {
@@ -174,8 +295,27 @@ func (s *sqlxQAStore) GetAnswerByID(ctx context.Context, answerID int64) (*model
 	return &answer, nil
 }
 
+// GetAnswersByIDs 批量获取回答，不保证返回顺序，不存在的 ID 会被忽略
+func (s *sqlxQAStore) GetAnswersByIDs(ctx context.Context, answerIDs []int64) ([]*model.Answer, error) {
+	answers := []*model.Answer{}
+	if len(answerIDs) == 0 {
+		return answers, nil
+	}
+
+	query, args, err := sqlx.In("SELECT id, question_id, content, user_id, is_anonymous, upvote_count, created_at, updated_at FROM answers WHERE id IN (?)", answerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query = s.dbConn.Rebind(query)
+	if err := s.db.SelectContext(ctx, &answers, query, args...); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
 func (s *sqlxQAStore) ListAnswersByQuestionID(ctx context.Context, questionID int64, offset int64, limit int32) ([]*model.Answer, error) {
-	query := "SELECT id, question_id, content, user_id, upvote_count, created_at, updated_at FROM answers WHERE question_id = ? ORDER BY created_at DESC LIMIT ? OFFSET ?"
+	query := "SELECT id, question_id, content, user_id, is_anonymous, upvote_count, created_at, updated_at FROM answers WHERE question_id = ? ORDER BY created_at DESC LIMIT ? OFFSET ?"
 	var answers []*model.Answer
 	err := s.db.SelectContext(ctx, &answers, query, questionID, limit, offset)
 	if err != nil {
@@ -184,6 +324,29 @@ func (s *sqlxQAStore) ListAnswersByQuestionID(ctx context.Context, questionID in
 	return answers, nil
 }
 
+// ListAnswersByUserID 分页获取用户的回答，并附带所属问题的标题
+func (s *sqlxQAStore) ListAnswersByUserID(ctx context.Context, userID int64, includeAnonymous bool, offset int64, limit int32) ([]*model.AnswerWithQuestion, error) {
+	query := `SELECT a.id, a.question_id, a.content, a.user_id, a.is_anonymous, a.upvote_count, a.created_at, a.updated_at, q.title AS question_title
+		FROM answers a JOIN questions q ON q.id = a.question_id
+		WHERE a.user_id = ? AND (? OR a.is_anonymous = FALSE) ORDER BY a.created_at DESC LIMIT ? OFFSET ?`
+	var answers []*model.AnswerWithQuestion
+	err := s.db.SelectContext(ctx, &answers, query, userID, includeAnonymous, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+func (s *sqlxQAStore) CountAnswersByUserID(ctx context.Context, userID int64, includeAnonymous bool) (int64, error) {
+	var count int64
+	query := "SELECT COUNT(*) FROM answers WHERE user_id = ? AND (? OR is_anonymous = FALSE)"
+	err := s.db.GetContext(ctx, &count, query, userID, includeAnonymous)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (s *sqlxQAStore) CountAnswersByQuestionID(ctx context.Context, questionID int64) (int64, error) {
 	var count int64
 	query := "SELECT COUNT(*) FROM answers WHERE question_id = ?"
@@ -194,33 +357,55 @@ func (s *sqlxQAStore) CountAnswersByQuestionID(ctx context.Context, questionID i
 	return count, nil
 }
 
-// GetAnswerCountByQuestionIDs 批量获取多个问题的回答数量
-func (s *sqlxQAStore) GetAnswerCountByQuestionIDs(ctx context.Context, questionIDs []int64) (map[int64]int64, error) {
-	counts := make(map[int64]int64)
+func (s *sqlxQAStore) IncrementQuestionViewCount(ctx context.Context, questionID int64) error {
+	// 显式保留 updated_at，浏览和计数变化不算作对问题的编辑
+	query := "UPDATE questions SET view_count = view_count + 1, updated_at = updated_at WHERE id = ?"
+	_, err := s.db.ExecContext(ctx, query, questionID)
+	return err
+}
+
+func (s *sqlxQAStore) AdjustQuestionCounters(ctx context.Context, questionID int64, answerDelta, commentDelta int64) error {
+	query := `UPDATE questions SET answer_count = GREATEST(answer_count + ?, 0), comment_count = GREATEST(comment_count + ?, 0),
+		updated_at = updated_at WHERE id = ?`
+	_, err := s.db.ExecContext(ctx, query, answerDelta, commentDelta, questionID)
+	return err
+}
+
+func (s *sqlxQAStore) TouchQuestionActivity(ctx context.Context, questionID int64) error {
+	query := "UPDATE questions SET last_activity_at = CURRENT_TIMESTAMP, updated_at = updated_at WHERE id = ?"
+	_, err := s.db.ExecContext(ctx, query, questionID)
+	return err
+}
+
+// questionStatsSelect 汇总问题的浏览数、回答数和回答获得的点赞总数
+const questionStatsSelect = `SELECT q.id, q.created_at, q.view_count, q.answer_count,
+	(SELECT COALESCE(SUM(a.upvote_count), 0) FROM answers a WHERE a.question_id = q.id) AS upvote_count
+	FROM questions q`
+
+func (s *sqlxQAStore) GetQuestionStatsByIDs(ctx context.Context, questionIDs []int64) ([]*model.QuestionStats, error) {
+	var stats []*model.QuestionStats
 	if len(questionIDs) == 0 {
-		return counts, nil
+		return stats, nil
 	}
 
-	query, args, err := sqlx.In("SELECT question_id, COUNT(*) AS cnt FROM answers WHERE question_id IN (?) GROUP BY question_id", questionIDs)
+	query, args, err := sqlx.In(questionStatsSelect+" WHERE q.id IN (?)", questionIDs)
 	if err != nil {
 		return nil, err
 	}
-
 	query = s.dbConn.Rebind(query)
-	var rows []struct {
-		QuestionID int64 `db:"question_id"`
-		Count      int64 `db:"cnt"`
-	}
-
-	if err := s.db.SelectContext(ctx, &rows, query, args...); err != nil {
+	if err := s.db.SelectContext(ctx, &stats, query, args...); err != nil {
 		return nil, err
 	}
+	return stats, nil
+}
 
-	for _, row := range rows {
-		counts[row.QuestionID] = row.Count
+func (s *sqlxQAStore) ListQuestionStatsSince(ctx context.Context, since time.Time) ([]*model.QuestionStats, error) {
+	var stats []*model.QuestionStats
+	query := questionStatsSelect + " WHERE q.created_at >= ?"
+	if err := s.db.SelectContext(ctx, &stats, query, since); err != nil {
+		return nil, err
 	}
-
-	return counts, nil
+	return stats, nil
 }
 
 // GetUserVotesForAnswers 获取用户对一组答案的投票状态
@@ -279,6 +464,64 @@ func (s *sqlxQAStore) GetUsernamesByIDs(ctx context.Context, userIDs []int64) (m
 	return result, nil
 }
 
+// GetUserTypesByIDs 批量获取用户ID对应的用户类型 (human 或 bot)
+func (s *sqlxQAStore) GetUserTypesByIDs(ctx context.Context, userIDs []int64) (map[int64]string, error) {
+	result := make(map[int64]string)
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	query, args, err := sqlx.In("SELECT id, user_type FROM users WHERE id IN (?)", userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query = s.dbConn.Rebind(query)
+	var rows []struct {
+		ID       int64  `db:"id"`
+		UserType string `db:"user_type"`
+	}
+
+	if err := s.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.ID] = row.UserType
+	}
+
+	return result, nil
+}
+
+// GetUserAvatarsByIDs 批量获取用户ID对应的头像对象键前缀
+func (s *sqlxQAStore) GetUserAvatarsByIDs(ctx context.Context, userIDs []int64) (map[int64]string, error) {
+	result := make(map[int64]string)
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	query, args, err := sqlx.In("SELECT id, avatar_key FROM users WHERE id IN (?)", userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query = s.dbConn.Rebind(query)
+	var rows []struct {
+		ID        int64  `db:"id"`
+		AvatarKey string `db:"avatar_key"`
+	}
+
+	if err := s.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		result[row.ID] = row.AvatarKey
+	}
+
+	return result, nil
+}
+
 func (s *sqlxQAStore) UpdateAnswer(ctx context.Context, answer *model.Answer) error {
 	query := "UPDATE answers SET content = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
 	_, err := s.db.ExecContext(ctx, query, answer.Content, answer.ID)
@@ -291,6 +534,25 @@ func (s *sqlxQAStore) DeleteAnswer(ctx context.Context, answerID int64) error {
 	return err
 }
 
+func (s *sqlxQAStore) SetAcceptedAnswer(ctx context.Context, questionID, answerID int64) (bool, error) {
+	query := "UPDATE questions SET accepted_answer_id = ?, updated_at = updated_at WHERE id = ? AND accepted_answer_id IS NULL"
+	result, err := s.db.ExecContext(ctx, query, answerID, questionID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (s *sqlxQAStore) ClearAcceptedAnswer(ctx context.Context, answerID int64) error {
+	query := "UPDATE questions SET accepted_answer_id = NULL, updated_at = updated_at WHERE accepted_answer_id = ?"
+	_, err := s.db.ExecContext(ctx, query, answerID)
+	return err
+}
+
 // --- 评论相关 (Comment) ---
 
 func (s *sqlxQAStore) CreateComment(ctx context.Context, comment *model.Comment) (int64, error) {
@@ -336,6 +598,31 @@ func (s *sqlxQAStore) CountCommentsByAnswerID(ctx context.Context, answerID int6
 	return count, nil
 }
 
+// ListCommentsByUserID 分页获取用户的评论，并附带所属问题的ID和标题
+func (s *sqlxQAStore) ListCommentsByUserID(ctx context.Context, userID int64, offset int64, limit int32) ([]*model.CommentWithQuestion, error) {
+	query := `SELECT c.id, c.answer_id, c.user_id, c.content, c.created_at, c.updated_at, a.question_id, q.title AS question_title
+		FROM comments c
+		JOIN answers a ON a.id = c.answer_id
+		JOIN questions q ON q.id = a.question_id
+		WHERE c.user_id = ? ORDER BY c.created_at DESC LIMIT ? OFFSET ?`
+	var comments []*model.CommentWithQuestion
+	err := s.db.SelectContext(ctx, &comments, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (s *sqlxQAStore) CountCommentsByUserID(ctx context.Context, userID int64) (int64, error) {
+	var count int64
+	query := "SELECT COUNT(*) FROM comments WHERE user_id = ?"
+	err := s.db.GetContext(ctx, &count, query, userID)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (s *sqlxQAStore) UpdateComment(ctx context.Context, comment *model.Comment) error {
 	query := "UPDATE comments SET content = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
 	_, err := s.db.ExecContext(ctx, query, comment.Content, comment.ID)
@@ -348,6 +635,27 @@ func (s *sqlxQAStore) DeleteComment(ctx context.Context, commentID int64) error
 	return err
 }
 
+// --- 用户动态相关方法 ---
+
+// ListActivitiesByUserID 将用户的提问、回答、评论按创建时间倒序合并后分页返回
+func (s *sqlxQAStore) ListActivitiesByUserID(ctx context.Context, userID int64, includeAnonymous bool, offset int64, limit int32) ([]*model.Activity, error) {
+	query := `SELECT 'question' AS type, q.id AS id, q.id AS question_id, q.title AS question_title, q.content AS content, q.created_at AS created_at
+		FROM questions q WHERE q.user_id = ? AND (? OR q.is_anonymous = FALSE)
+		UNION ALL
+		SELECT 'answer', a.id, a.question_id, q.title, a.content, a.created_at
+		FROM answers a JOIN questions q ON q.id = a.question_id WHERE a.user_id = ? AND (? OR a.is_anonymous = FALSE)
+		UNION ALL
+		SELECT 'comment', c.id, a.question_id, q.title, c.content, c.created_at
+		FROM comments c JOIN answers a ON a.id = c.answer_id JOIN questions q ON q.id = a.question_id WHERE c.user_id = ?
+		ORDER BY created_at DESC LIMIT ? OFFSET ?`
+	var activities []*model.Activity
+	err := s.db.SelectContext(ctx, &activities, query, userID, includeAnonymous, userID, includeAnonymous, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
+
 // --- 投票相关方法 ---
 
 func (s *sqlxQAStore) CreateAnswerVote(ctx context.Context, answerID, userID int64, isUpvote bool) error {
@@ -356,6 +664,13 @@ func (s *sqlxQAStore) CreateAnswerVote(ctx context.Context, answerID, userID int
 	return err
 }
 
+func (s *sqlxQAStore) GetAnswerVoteForUpdate(ctx context.Context, answerID, userID int64) (bool, error) {
+	var isUpvote bool
+	query := "SELECT is_upvote FROM answers_votes WHERE answer_id = ? AND user_id = ? FOR UPDATE"
+	err := s.db.GetContext(ctx, &isUpvote, query, answerID, userID)
+	return isUpvote, err
+}
+
 func (s *sqlxQAStore) DeleteAnswerVote(ctx context.Context, answerID, userID int64) error {
 	query := "DELETE FROM answers_votes WHERE answer_id = ? AND user_id = ?"
 	_, err := s.db.ExecContext(ctx, query, answerID, userID)
@@ -363,13 +678,13 @@ func (s *sqlxQAStore) DeleteAnswerVote(ctx context.Context, answerID, userID int
 }
 
 func (s *sqlxQAStore) IncrementAnswerUpvoteCount(ctx context.Context, answerID int64) error {
-	query := "UPDATE answers SET upvote_count = upvote_count + 1 WHERE id = ?"
+	query := "UPDATE answers SET upvote_count = upvote_count + 1, updated_at = updated_at WHERE id = ?"
 	_, err := s.db.ExecContext(ctx, query, answerID)
 	return err
 }
 
 func (s *sqlxQAStore) DecrementAnswerUpvoteCount(ctx context.Context, answerID int64) error {
-	query := "UPDATE answers SET upvote_count = upvote_count - 1 WHERE id = ?"
+	query := "UPDATE answers SET upvote_count = upvote_count - 1, updated_at = updated_at WHERE id = ?"
 	_, err := s.db.ExecContext(ctx, query, answerID)
 	return err
 }
@@ -384,6 +699,120 @@ func (s *sqlxQAStore) CountVotesByAnswerID(ctx context.Context, answerID int64)
 	return count, nil
 }
 
+// --- 计数校对相关方法 ---
+
+// counterDriftQuery 分别统计三类冗余计数，只返回与源表不一致的记录
+const counterDriftQuery = `SELECT 'question_answer_count' AS counter, q.id, q.answer_count AS stored, COUNT(a.id) AS expected
+		FROM questions q LEFT JOIN answers a ON a.question_id = q.id
+		GROUP BY q.id HAVING stored <> expected
+	UNION ALL
+	SELECT 'question_comment_count', q.id, q.comment_count, COUNT(c.id)
+		FROM questions q LEFT JOIN answers a ON a.question_id = q.id LEFT JOIN comments c ON c.answer_id = a.id
+		GROUP BY q.id HAVING q.comment_count <> COUNT(c.id)
+	UNION ALL
+	SELECT 'answer_upvote_count', a.id, COALESCE(a.upvote_count, 0), COUNT(v.answer_id)
+		FROM answers a LEFT JOIN answers_votes v ON v.answer_id = a.id AND v.is_upvote = 1
+		GROUP BY a.id HAVING COALESCE(a.upvote_count, 0) <> COUNT(v.answer_id)`
+
+func (s *sqlxQAStore) ListCounterDrifts(ctx context.Context) ([]*model.CounterDrift, error) {
+	drifts := []*model.CounterDrift{}
+	if err := s.db.SelectContext(ctx, &drifts, counterDriftQuery); err != nil {
+		return nil, err
+	}
+	return drifts, nil
+}
+
+// RecountQuestionCounters 在 UPDATE 中直接用子查询统计，避免覆盖统计与写回之间发生的并发变更
+func (s *sqlxQAStore) RecountQuestionCounters(ctx context.Context, questionID int64) error {
+	query := `UPDATE questions q SET
+		q.answer_count = (SELECT COUNT(*) FROM answers a WHERE a.question_id = q.id),
+		q.comment_count = (SELECT COUNT(*) FROM comments c JOIN answers a ON a.id = c.answer_id WHERE a.question_id = q.id),
+		q.updated_at = q.updated_at
+		WHERE q.id = ?`
+	_, err := s.db.ExecContext(ctx, query, questionID)
+	return err
+}
+
+func (s *sqlxQAStore) RecountAnswerUpvotes(ctx context.Context, answerID int64) error {
+	query := `UPDATE answers a SET
+		a.upvote_count = (SELECT COUNT(*) FROM answers_votes v WHERE v.answer_id = a.id AND v.is_upvote = 1),
+		a.updated_at = a.updated_at
+		WHERE a.id = ?`
+	_, err := s.db.ExecContext(ctx, query, answerID)
+	return err
+}
+
+// --- 修改建议相关方法 ---
+
+const suggestedEditSelect = "SELECT id, target_type, target_id, proposer_id, base_title, base_content, title, content, reason, status, COALESCE(reviewer_id, 0) AS reviewer_id, review_comment, reviewed_at, created_at FROM suggested_edits"
+
+func (s *sqlxQAStore) CreateSuggestedEdit(ctx context.Context, edit *model.SuggestedEdit) (int64, error) {
+	query := `INSERT INTO suggested_edits (target_type, target_id, proposer_id, base_title, base_content, title, content, reason)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := s.db.ExecContext(ctx, query, edit.TargetType, edit.TargetID, edit.ProposerID,
+		edit.BaseTitle, edit.BaseContent, edit.Title, edit.Content, edit.Reason)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+func (s *sqlxQAStore) GetSuggestedEditByID(ctx context.Context, editID int64) (*model.SuggestedEdit, error) {
+	query := suggestedEditSelect + " WHERE id = ?"
+	var edit model.SuggestedEdit
+	if err := s.db.GetContext(ctx, &edit, query, editID); err != nil {
+		return nil, err
+	}
+	return &edit, nil
+}
+
+func (s *sqlxQAStore) ListSuggestedEdits(ctx context.Context, targetType string, targetID int64, status string, offset int64, limit int32) ([]*model.SuggestedEdit, error) {
+	query := suggestedEditSelect + " WHERE target_type = ? AND target_id = ? AND (? = '' OR status = ?) ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?"
+	var edits []*model.SuggestedEdit
+	err := s.db.SelectContext(ctx, &edits, query, targetType, targetID, status, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return edits, nil
+}
+
+func (s *sqlxQAStore) CountSuggestedEdits(ctx context.Context, targetType string, targetID int64, status string) (int64, error) {
+	query := "SELECT COUNT(*) FROM suggested_edits WHERE target_type = ? AND target_id = ? AND (? = '' OR status = ?)"
+	var count int64
+	err := s.db.GetContext(ctx, &count, query, targetType, targetID, status, status)
+	return count, err
+}
+
+func (s *sqlxQAStore) ReviewSuggestedEdit(ctx context.Context, editID int64, status string, reviewerID int64, comment string) (bool, error) {
+	query := "UPDATE suggested_edits SET status = ?, reviewer_id = ?, review_comment = ?, reviewed_at = NOW() WHERE id = ? AND status = ?"
+	result, err := s.db.ExecContext(ctx, query, status, reviewerID, comment, editID, model.EditStatusPending)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// --- 违规标记相关方法 ---
+
+func (s *sqlxQAStore) CreateContentFlag(ctx context.Context, flag *model.ContentFlag) (bool, error) {
+	query := `INSERT IGNORE INTO content_flags (target_type, target_id, question_id, author_id, reporter_id, reason)
+		VALUES (?, ?, ?, ?, ?, ?)`
+	result, err := s.db.ExecContext(ctx, query, flag.TargetType, flag.TargetID, flag.QuestionID,
+		flag.AuthorID, flag.ReporterID, flag.Reason)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
 // ExecTx 用于执行一个包含多个数据库操作的事务
 func (s *sqlxQAStore) ExecTx(ctx context.Context, fn func(QAStore) error) error {
 	tx, err := s.dbConn.BeginTxx(ctx, nil)
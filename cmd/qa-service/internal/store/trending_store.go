@@ -0,0 +1,93 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// TrendingStore 定义了热门问题排行榜的存储接口，每个时间窗口对应一个有序集合
+type TrendingStore interface {
+	// SetScore 更新问题在指定窗口中的热门分数
+	SetScore(ctx context.Context, window string, questionID int64, score float64) error
+	// Remove 将问题从所有给定窗口中移除
+	Remove(ctx context.Context, questionID int64, windows ...string) error
+	// Replace 用全量重建的结果原子地替换指定窗口的排行榜
+	Replace(ctx context.Context, window string, scores map[int64]float64) error
+	// ListTop 按分数从高到低返回指定窗口中的问题ID及窗口内的问题总数
+	ListTop(ctx context.Context, window string, offset int64, limit int32) ([]int64, int64, error)
+}
+
+type redisTrendingStore struct {
+	redisClient *redis.Client
+}
+
+func NewRedisTrendingStore(redisClient *redis.Client) *redisTrendingStore {
+	return &redisTrendingStore{redisClient: redisClient}
+}
+
+// trendingKey 根据时间窗口生成排行榜的键
+func trendingKey(window string) string {
+	return fmt.Sprintf("trending:questions:%s", window)
+}
+
+func (s *redisTrendingStore) SetScore(ctx context.Context, window string, questionID int64, score float64) error {
+	return s.redisClient.ZAdd(ctx, trendingKey(window), redis.Z{
+		Score:  score,
+		Member: strconv.FormatInt(questionID, 10),
+	}).Err()
+}
+
+func (s *redisTrendingStore) Remove(ctx context.Context, questionID int64, windows ...string) error {
+	member := strconv.FormatInt(questionID, 10)
+	pipe := s.redisClient.TxPipeline()
+	for _, w := range windows {
+		pipe.ZRem(ctx, trendingKey(w), member)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (s *redisTrendingStore) Replace(ctx context.Context, window string, scores map[int64]float64) error {
+	key := trendingKey(window)
+	if len(scores) == 0 {
+		return s.redisClient.Del(ctx, key).Err()
+	}
+
+	// 先写入临时键再 RENAME，读请求不会看到重建到一半的排行榜
+	tmpKey := key + ":rebuild"
+	members := make([]redis.Z, 0, len(scores))
+	for id, score := range scores {
+		members = append(members, redis.Z{Score: score, Member: strconv.FormatInt(id, 10)})
+	}
+	pipe := s.redisClient.TxPipeline()
+	pipe.Del(ctx, tmpKey)
+	pipe.ZAdd(ctx, tmpKey, members...)
+	pipe.Rename(ctx, tmpKey, key)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (s *redisTrendingStore) ListTop(ctx context.Context, window string, offset int64, limit int32) ([]int64, int64, error) {
+	key := trendingKey(window)
+	total, err := s.redisClient.ZCard(ctx, key).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+	members, err := s.redisClient.ZRevRange(ctx, key, offset, offset+int64(limit)-1).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]int64, 0, len(members))
+	for _, m := range members {
+		id, err := strconv.ParseInt(m, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, total, nil
+}
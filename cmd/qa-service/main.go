@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"log"
 	"log/slog"
 	"os"
+	"qahub/pkg/auth"
 	"qahub/pkg/clients"
 	"qahub/pkg/config"
 	"qahub/pkg/database"
@@ -11,6 +13,7 @@ import (
 	"qahub/pkg/interceptor"
 	logpkg "qahub/pkg/log"
 	"qahub/pkg/messaging"
+	"qahub/pkg/redis"
 	"qahub/pkg/server"
 	"qahub/pkg/util"
 	"qahub/qa-service/internal/handler"
@@ -55,10 +58,17 @@ func main() {
 	defer util.Cleanup("Kafka producer", kafkaProducer.Close)
 	logger.Info("Kafka 生产者初始化成功")
 
-	// 依赖注入：初始化 store, service, handler
-	qaStore := store.NewQAStore(db)
-	qaService := service.NewQAService(qaStore, kafkaProducer, &config.Conf)
-	qaHandler := handler.NewQAGrpcServer(qaService)
+	// 初始化 Redis 连接，用于缓存和热门问题排行榜
+	logger.Info("初始化 Redis 连接...")
+	redisClient, err := redis.NewClient(config.Conf.Redis)
+	if err != nil {
+		logger.Error("Redis 连接失败",
+			slog.String("error", err.Error()),
+		)
+		log.Fatalf("Redis connection failed: %v", err)
+	}
+	defer util.Cleanup("Redis client", redisClient.Close)
+	logger.Info("Redis 连接成功")
 
 	// 初始化 user-service 的客户端连接
 	logger.Info("连接到 user-service...",
@@ -73,6 +83,38 @@ func main() {
 	}
 	logger.Info("user-service 连接成功")
 
+	// 依赖注入：初始化 store, service, handler
+	mysqlStore := store.NewQAStore(db)
+	var qaStore store.QAStore = mysqlStore
+	var qaStoreHealth health.HealthAware = mysqlStore
+	if cacheCfg := config.Conf.Services.QAService.Cache; cacheCfg.Enabled {
+		logger.Info("启用问答缓存",
+			slog.Duration("ttl", cacheCfg.TTL),
+			slog.Int("list_pages", cacheCfg.ListPages),
+		)
+		cacheStore := store.NewQACacheStore(redisClient, mysqlStore, cacheCfg)
+		qaStore = cacheStore
+		qaStoreHealth = cacheStore
+	}
+	qaService := service.NewQAService(qaStore, kafkaProducer, &config.Conf, userClient)
+	// 晚于 Kafka 生产者注册，保证关闭时先发送聚合中的点赞通知
+	defer util.Cleanup("vote notifications", qaService.Close)
+	trendingService := service.NewTrendingService(qaService, qaStore, store.NewRedisTrendingStore(redisClient))
+	reconcileService := service.NewReconcileService(qaStore)
+	qaHandler := handler.NewQAGrpcServer(qaService, trendingService, reconcileService)
+
+	// 初始化热门排行榜的事件消费者
+	consumer := messaging.NewKafkaConsumer(config.Conf.Kafka, service.TopicQAEvents, service.TrendingGroupID, nil)
+	consumer.SetHandlers(trendingService.RegisterHandlers())
+	defer util.Cleanup("Kafka consumer", consumer.Close)
+
+	// 在本地校验访问令牌，撤销检查复用 user-service 写入 Redis 的会话撤销标记
+	var revocations auth.RevocationList
+	if config.Conf.Auth.CheckRevocation {
+		revocations = auth.NewRedisRevocationList(redisClient)
+	}
+	verifier := auth.NewVerifier(userClient.GetJWKS, config.Conf.Auth.JWKSCacheTTL, revocations)
+
 	// 启动 gRPC 服务器
 	logger.Info("初始化 gRPC 服务器...",
 		slog.String("service_name", serviceName),
@@ -80,7 +122,14 @@ func main() {
 	serverOpts := []grpc.ServerOption{
 		grpc.ChainUnaryInterceptor(
 			interceptor.LogUnaryServerInterceptor(),
-			interceptor.AuthUnaryServerInterceptor(userClient, config.Conf.Services.QAService.PublicMethods...),
+			interceptor.AuthUnaryServerInterceptor(userClient, verifier, config.Conf.Services.QAService.PublicMethods...),
+			interceptor.VerifiedEmailUnaryServerInterceptor(config.Conf.Services.QAService.VerifiedEmailMethods...),
+			interceptor.AccessTokenScopeUnaryServerInterceptor(config.Conf.Services.QAService.AccessTokenScopes),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptor.LogStreamServerInterceptor(),
+			interceptor.AuthStreamServerInterceptor(userClient, verifier, config.Conf.Services.QAService.PublicMethods...),
+			interceptor.AccessTokenScopeStreamServerInterceptor(config.Conf.Services.QAService.AccessTokenScopes),
 		),
 	}
 	grpcSrv := server.NewGrpcServer(serviceName, config.Conf.Services.QAService.GrpcPort, serverOpts...)
@@ -88,7 +137,11 @@ func main() {
 	// 设置健康检查
 	healthUpdater := grpcSrv.HealthServer()
 	health.SetHealthChecks(healthUpdater, serviceName,
-		kafkaProducer, qaStore)
+		kafkaProducer, qaStoreHealth, consumer)
+
+	go consumer.Start(context.Background())
+	go trendingService.Run(context.Background())
+	go reconcileService.Run(context.Background())
 
 	logger.Info("问答服务准备就绪，开始监听请求",
 		slog.String("grpc_port", config.Conf.Services.QAService.GrpcPort),
@@ -2,13 +2,18 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	pb "qahub/api/proto/search"
 	pkglog "qahub/pkg/log"
 	"qahub/search-service/internal/service"
 
+	"qahub/pkg/messaging"
+
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -43,22 +48,52 @@ func (h *SearchGrpcServer) SearchQuestions(ctx context.Context, req *pb.SearchQu
 	)
 
 	// 将结果转换为 gRPC 响应格式
-	resp := &pb.SearchQuestionsResponse{
-		Questions: make([]*pb.Question, len(results)),
+	return &pb.SearchQuestionsResponse{
+		Questions: toPbQuestions(results),
+	}, nil
+}
+
+func (h *SearchGrpcServer) GetRelatedQuestions(ctx context.Context, req *pb.GetRelatedQuestionsRequest) (*pb.GetRelatedQuestionsResponse, error) {
+	logger := pkglog.FromContext(ctx)
+
+	logger.Info("相关问题请求",
+		slog.Int64("question_id", req.QuestionId),
+		slog.Int("limit", int(req.Limit)),
+	)
+
+	results, err := h.service.GetRelatedQuestions(ctx, req.QuestionId, req.Limit)
+	if err != nil {
+		logger.Error("获取相关问题失败",
+			slog.Int64("question_id", req.QuestionId),
+			slog.String("error", err.Error()),
+		)
+		if errors.Is(err, service.ErrInvalidQuestionID) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
 	}
+
+	return &pb.GetRelatedQuestionsResponse{
+		Questions: toPbQuestions(results),
+	}, nil
+}
+
+// toPbQuestions 将搜索结果转换为 gRPC 的问题列表
+func toPbQuestions(results []messaging.QuestionPayload) []*pb.Question {
+	questions := make([]*pb.Question, len(results))
 	for i, q := range results {
-		resp.Questions[i] = &pb.Question{
-			Id:         q.ID,
-			Title:      q.Title,
-			Content:    q.Content,
-			AuthorId:   q.AuthorID,
-			AuthorName: q.AuthorName,
-			CreatedAt:  timestamppb.New(q.CreatedAt),
-			UpdatedAt:  timestamppb.New(q.UpdatedAt),
+		questions[i] = &pb.Question{
+			Id:          q.ID,
+			Title:       q.Title,
+			Content:     q.Content,
+			AuthorId:    q.AuthorID,
+			AuthorName:  q.AuthorName,
+			CreatedAt:   timestamppb.New(q.CreatedAt),
+			UpdatedAt:   timestamppb.New(q.UpdatedAt),
+			IsAnonymous: q.Anonymous,
 		}
 	}
-
-	return resp, nil
+	return questions
 }
 
 func (h *SearchGrpcServer) IndexAllQuestions(ctx context.Context, req *pb.IndexAllQuestionsRequest) (*pb.IndexAllQuestionsResponse, error) {
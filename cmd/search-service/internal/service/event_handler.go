@@ -16,6 +16,13 @@ func (s *searchService) RegisterHandlers() map[messaging.EventType]messaging.Eve
 	}
 }
 
+// RegisterUserHandlers 返回用户事件的处理器，用户事件与问答事件使用不同的主题
+func (s *searchService) RegisterUserHandlers() map[messaging.EventType]messaging.EventHandler {
+	return map[messaging.EventType]messaging.EventHandler{
+		messaging.EventUserUpdated: s.handleUserUpdated,
+	}
+}
+
 func (s *searchService) handleQuestionCreated(ctx context.Context, eventType string, payload []byte) error {
 	var event messaging.QuestionCreatedEvent
 	if err := json.Unmarshal(payload, &event); err != nil {
@@ -35,6 +42,7 @@ func (s *searchService) handleQuestionUpdated(ctx context.Context, eventType str
 	if err := s.store.IndexQuestion(ctx, event.Payload); err != nil {
 		return fmt.Errorf("更新问题索引失败 (ID: %d): %w", event.Payload.ID, err)
 	}
+	s.related.Invalidate(event.Payload.ID)
 	log.Printf("成功更新问题索引 (ID: %d)", event.Payload.ID)
 	return nil
 }
@@ -47,6 +55,20 @@ func (s *searchService) handleQuestionDeleted(ctx context.Context, eventType str
 	if err := s.store.DeleteQuestion(ctx, event.Payload.ID); err != nil {
 		return fmt.Errorf("删除问题索引失败 (ID: %d): %w", event.Payload.ID, err)
 	}
+	s.related.Invalidate(event.Payload.ID)
 	log.Printf("成功删除问题索引 (ID: %d)", event.Payload.ID)
 	return nil
 }
+
+func (s *searchService) handleUserUpdated(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.UserUpdatedEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 UserUpdatedEvent 失败: %w", err)
+	}
+	updated, err := s.store.UpdateAuthorName(ctx, event.Payload.ID, event.Payload.Username)
+	if err != nil {
+		return fmt.Errorf("更新作者名失败 (用户ID: %d): %w", event.Payload.ID, err)
+	}
+	log.Printf("成功更新作者名 (用户ID: %d, 文档数: %d)", event.Payload.ID, updated)
+	return nil
+}
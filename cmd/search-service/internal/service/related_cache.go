@@ -0,0 +1,92 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"qahub/pkg/messaging"
+)
+
+// defaultRelatedCacheTTL 是未配置时相关问题结果的缓存时间
+const defaultRelatedCacheTTL = time.Minute
+
+// relatedCacheMaxEntries 超过该数量时写入前先清理过期条目
+const relatedCacheMaxEntries = 1000
+
+type relatedKey struct {
+	questionID int64
+	limit      int
+}
+
+type relatedEntry struct {
+	questions []messaging.QuestionPayload
+	expiresAt time.Time
+}
+
+// relatedCache 是相关问题结果的进程内短期缓存。
+// 相关问题对实时性要求不高，短时间内的重复打开详情页可以直接复用结果。
+type relatedCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[relatedKey]relatedEntry
+}
+
+func newRelatedCache(ttl time.Duration) *relatedCache {
+	if ttl <= 0 {
+		ttl = defaultRelatedCacheTTL
+	}
+	return &relatedCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[relatedKey]relatedEntry),
+	}
+}
+
+// Get 返回未过期的缓存结果
+func (c *relatedCache) Get(questionID int64, limit int) ([]messaging.QuestionPayload, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := relatedKey{questionID: questionID, limit: limit}
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if c.now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.questions, true
+}
+
+// Set 缓存一次查询结果
+func (c *relatedCache) Set(questionID int64, limit int, questions []messaging.QuestionPayload) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if len(c.entries) >= relatedCacheMaxEntries {
+		for k, e := range c.entries {
+			if now.After(e.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	c.entries[relatedKey{questionID: questionID, limit: limit}] = relatedEntry{
+		questions: questions,
+		expiresAt: now.Add(c.ttl),
+	}
+}
+
+// Invalidate 删除某个问题的所有缓存结果，在问题更新或删除时调用
+func (c *relatedCache) Invalidate(questionID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k := range c.entries {
+		if k.questionID == questionID {
+			delete(c.entries, k)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"qahub/pkg/messaging"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelatedCache(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache := newRelatedCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	related := []messaging.QuestionPayload{{ID: 2}, {ID: 3}}
+
+	t.Run("命中未过期的缓存", func(t *testing.T) {
+		cache.Set(1, 5, related)
+
+		got, ok := cache.Get(1, 5)
+
+		assert.True(t, ok)
+		assert.Equal(t, related, got)
+	})
+
+	t.Run("不同 limit 分别缓存", func(t *testing.T) {
+		_, ok := cache.Get(1, 10)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("过期后不再命中", func(t *testing.T) {
+		cache.Set(1, 5, related)
+		now = now.Add(2 * time.Minute)
+
+		_, ok := cache.Get(1, 5)
+
+		assert.False(t, ok)
+	})
+
+	t.Run("问题变更后失效", func(t *testing.T) {
+		cache.Set(1, 5, related)
+		cache.Set(1, 10, related)
+		cache.Set(4, 5, related)
+
+		cache.Invalidate(1)
+
+		_, ok := cache.Get(1, 5)
+		assert.False(t, ok)
+		_, ok = cache.Get(1, 10)
+		assert.False(t, ok)
+		_, ok = cache.Get(4, 5)
+		assert.True(t, ok)
+	})
+}
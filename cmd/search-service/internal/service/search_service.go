@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
+	"qahub/pkg/config"
 	"qahub/pkg/log"
 	"qahub/search-service/internal/store"
 
@@ -11,25 +13,39 @@ import (
 )
 
 const (
-	TopicQuestions = "qa_events"       // 定义消费的主题
-	GroupID        = "search-consumer" // 定义消费者组ID
+	TopicQuestions  = "qa_events"            // 定义消费的主题
+	GroupID         = "search-consumer"      // 定义消费者组ID
+	TopicUserEvents = "user_events"          // 用户资料变更事件的主题，用于刷新作者名
+	UserGroupID     = "search-user-consumer" // 用户事件的消费者组ID
 )
 
+const (
+	DefaultRelatedLimit = 5  // 相关问题默认返回数量
+	MaxRelatedLimit     = 20 // 相关问题最大返回数量
+)
+
+// ErrInvalidQuestionID 表示请求中的问题ID不合法
+var ErrInvalidQuestionID = errors.New("问题ID必须为正数")
+
 type SearchService interface {
 	SearchQuestions(ctx context.Context, query string) ([]messaging.QuestionPayload, error)
+	// GetRelatedQuestions 返回与指定问题相似的问题，结果会被短暂缓存
+	GetRelatedQuestions(ctx context.Context, questionID int64, limit int32) ([]messaging.QuestionPayload, error)
 	IndexAllQuestions(ctx context.Context) error
 	DeleteIndexAllQuestions(ctx context.Context) error
 }
 
 // searchService 结构体封装了搜索服务的所有业务逻辑
 type searchService struct {
-	store store.SearchStore
+	store   store.SearchStore
+	related *relatedCache
 }
 
 // New 函数创建一个新的 searchService 实例
 func NewSearchService(s store.SearchStore) *searchService {
 	return &searchService{
-		store: s,
+		store:   s,
+		related: newRelatedCache(config.Conf.Services.SearchService.RelatedCacheTTL),
 	}
 }
 
@@ -53,6 +69,45 @@ func (s *searchService) SearchQuestions(ctx context.Context, query string) ([]me
 	return results, nil
 }
 
+// GetRelatedQuestions 优先返回缓存结果，未命中时查询 Elasticsearch
+func (s *searchService) GetRelatedQuestions(ctx context.Context, questionID int64, limit int32) ([]messaging.QuestionPayload, error) {
+	logger := log.FromContext(ctx)
+
+	if questionID <= 0 {
+		return nil, ErrInvalidQuestionID
+	}
+	size := int(limit)
+	if size <= 0 {
+		size = DefaultRelatedLimit
+	}
+	if size > MaxRelatedLimit {
+		size = MaxRelatedLimit
+	}
+
+	if cached, ok := s.related.Get(questionID, size); ok {
+		logger.Debug("相关问题命中缓存",
+			slog.Int64("question_id", questionID),
+		)
+		return cached, nil
+	}
+
+	results, err := s.store.GetRelatedQuestions(ctx, questionID, size)
+	if err != nil {
+		logger.Error("查询相关问题失败",
+			slog.Int64("question_id", questionID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	s.related.Set(questionID, size, results)
+
+	logger.Info("相关问题查询成功",
+		slog.Int64("question_id", questionID),
+		slog.Int("result_count", len(results)),
+	)
+	return results, nil
+}
+
 // IndexAllQuestions 从 QA Service 获取所有问题并建立索引
 func (s *searchService) IndexAllQuestions(ctx context.Context) error {
 	logger := log.FromContext(ctx)
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"strconv"
 	"time"
@@ -25,7 +26,9 @@ const (
 type SearchStore interface {
 	IndexQuestion(ctx context.Context, question messaging.QuestionPayload) error
 	SearchQuestions(ctx context.Context, query string) ([]messaging.QuestionPayload, error)
+	GetRelatedQuestions(ctx context.Context, questionID int64, limit int) ([]messaging.QuestionPayload, error)
 	DeleteQuestion(ctx context.Context, questionID int64) error
+	UpdateAuthorName(ctx context.Context, authorID int64, authorName string) (int64, error)
 	ClearIndex(ctx context.Context) error
 	IndexAllQuestions(ctx context.Context) error
 	DeleteIndexAllQuestions(ctx context.Context) error
@@ -106,6 +109,12 @@ func (s *esStore) startHealthCheck() {
 
 // IndexQuestion 将一个问题文档索引到 Elasticsearch 中
 func (s *esStore) IndexQuestion(ctx context.Context, question messaging.QuestionPayload) error {
+	// 匿名问题不在索引中保存作者信息
+	if question.Anonymous {
+		question.AuthorID = 0
+		question.AuthorName = ""
+	}
+
 	// 将 question 对象序列化为 JSON
 	body, err := json.Marshal(question)
 	if err != nil {
@@ -166,9 +175,64 @@ func (s *esStore) SearchQuestions(ctx context.Context, query string) ([]messagin
 		return nil, fmt.Errorf("搜索响应错误: %s", res.String())
 	}
 
+	return decodeQuestionHits(res.Body)
+}
+
+// GetRelatedQuestions 使用 more_like_this 在标题和内容上查找与指定问题相似的问题
+func (s *esStore) GetRelatedQuestions(ctx context.Context, questionID int64, limit int) ([]messaging.QuestionPayload, error) {
+	docID := strconv.FormatInt(questionID, 10)
+
+	var buf bytes.Buffer
+	relatedQuery := map[string]any{
+		"size": limit,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"must": map[string]any{
+					"more_like_this": map[string]any{
+						"fields": []string{"title", "content"},
+						"like": []map[string]any{
+							{"_index": IndexQuestions, "_id": docID},
+						},
+						// 问题文本普遍较短，放宽词频限制以免查不到结果
+						"min_term_freq":   1,
+						"min_doc_freq":    1,
+						"max_query_terms": 25,
+					},
+				},
+				// more_like_this 默认不返回输入文档，这里显式排除以防配置变化
+				"must_not": map[string]any{
+					"ids": map[string]any{"values": []string{docID}},
+				},
+			},
+		},
+	}
+
+	if err := json.NewEncoder(&buf).Encode(relatedQuery); err != nil {
+		return nil, fmt.Errorf("编码查询体失败: %w", err)
+	}
+
+	res, err := s.client.Search(
+		s.client.Search.WithContext(ctx),
+		s.client.Search.WithIndex(IndexQuestions),
+		s.client.Search.WithBody(&buf),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("执行相关问题查询失败: %w", err)
+	}
+	defer util.Cleanup("Elasticsearch related search", res.Body.Close)
+
+	if res.IsError() {
+		return nil, fmt.Errorf("相关问题查询响应错误: %s", res.String())
+	}
+
+	return decodeQuestionHits(res.Body)
+}
+
+// decodeQuestionHits 从搜索响应中解析出问题文档
+func decodeQuestionHits(body io.Reader) ([]messaging.QuestionPayload, error) {
 	// 解析响应
 	var r map[string]any
-	if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+	if err := json.NewDecoder(body).Decode(&r); err != nil {
 		return nil, fmt.Errorf("解析响应体失败: %w", err)
 	}
 
@@ -224,6 +288,49 @@ func (s *esStore) DeleteQuestion(ctx context.Context, questionID int64) error {
 	return nil
 }
 
+// UpdateAuthorName 更新指定作者所有问题文档中的作者名，返回更新的文档数。
+// 匿名问题在索引中的作者ID为 0，不会被更新
+func (s *esStore) UpdateAuthorName(ctx context.Context, authorID int64, authorName string) (int64, error) {
+	var buf bytes.Buffer
+	updateQuery := map[string]any{
+		"query": map[string]any{
+			"term": map[string]any{"author_id": authorID},
+		},
+		"script": map[string]any{
+			"source": "ctx._source.author_name = params.author_name",
+			"lang":   "painless",
+			"params": map[string]any{"author_name": authorName},
+		},
+	}
+	if err := json.NewEncoder(&buf).Encode(updateQuery); err != nil {
+		return 0, fmt.Errorf("编码更新请求失败: %w", err)
+	}
+
+	res, err := s.client.UpdateByQuery(
+		[]string{IndexQuestions},
+		s.client.UpdateByQuery.WithContext(ctx),
+		s.client.UpdateByQuery.WithBody(&buf),
+		s.client.UpdateByQuery.WithConflicts("proceed"), // 与问题更新事件冲突的文档已经带有最新的作者名
+		s.client.UpdateByQuery.WithRefresh(true),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("更新作者名失败: %w", err)
+	}
+	defer util.Cleanup("Elasticsearch update by query", res.Body.Close)
+
+	if res.IsError() {
+		return 0, fmt.Errorf("更新作者名响应错误: %s", res.String())
+	}
+
+	var result struct {
+		Updated int64 `json:"updated"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("解析更新响应失败: %w", err)
+	}
+	return result.Updated, nil
+}
+
 func (s *esStore) ClearIndex(ctx context.Context) error {
 	// 删除索引
 	res, err := s.client.Indices.Delete([]string{IndexQuestions}, s.client.Indices.Delete.WithContext(ctx))
@@ -280,6 +387,7 @@ func (s *esStore) IndexAllQuestions(ctx context.Context) error {
 				AuthorName: q.AuthorName,
 				CreatedAt:  q.CreatedAt.AsTime(),
 				UpdatedAt:  q.UpdatedAt.AsTime(),
+				Anonymous:  q.IsAnonymous,
 			}
 
 			if err := s.IndexQuestion(ctx, question); err != nil {
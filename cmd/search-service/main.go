@@ -9,12 +9,14 @@ import (
 	"qahub/search-service/internal/handler"
 	"qahub/search-service/internal/service"
 
+	"qahub/pkg/auth"
 	"qahub/pkg/clients"
 	"qahub/pkg/config"
 	"qahub/pkg/health"
 	"qahub/pkg/interceptor"
 	logpkg "qahub/pkg/log"
 	"qahub/pkg/messaging"
+	"qahub/pkg/redis"
 	"qahub/pkg/server"
 	"qahub/pkg/util"
 	"qahub/search-service/internal/store"
@@ -61,6 +63,14 @@ func main() {
 
 	// 注册事件处理器
 	consumer.SetHandlers(searchService.RegisterHandlers())
+
+	// 用户改名后刷新索引中的作者名
+	userEventsTopic := config.Conf.UserEventsDestination()
+	if userEventsTopic == "" {
+		userEventsTopic = service.TopicUserEvents
+	}
+	userConsumer := messaging.NewKafkaConsumer(config.Conf.Kafka, userEventsTopic, service.UserGroupID, searchService.RegisterUserHandlers())
+	defer util.Cleanup("Kafka user events consumer", userConsumer.Close)
 	logger.Info("Kafka 消费者初始化成功")
 
 	searchHandler := handler.NewSearchServer(searchService)
@@ -78,6 +88,21 @@ func main() {
 	}
 	logger.Info("user-service 连接成功")
 
+	// 在本地校验访问令牌，开启撤销检查时需要连接 user-service 使用的 Redis
+	var revocations auth.RevocationList
+	if config.Conf.Auth.CheckRevocation {
+		redisClient, err := redis.NewClient(config.Conf.Redis)
+		if err != nil {
+			logger.Error("Redis 连接失败",
+				slog.String("error", err.Error()),
+			)
+			log.Fatalf("Redis connection failed: %v", err)
+		}
+		defer util.Cleanup("Redis client", redisClient.Close)
+		revocations = auth.NewRedisRevocationList(redisClient)
+	}
+	verifier := auth.NewVerifier(userClient.GetJWKS, config.Conf.Auth.JWKSCacheTTL, revocations)
+
 	// 创建并运行 gRPC 服务器
 	logger.Info("初始化 gRPC 服务器...",
 		slog.String("service_name", serviceName),
@@ -85,7 +110,8 @@ func main() {
 	serverOpts := []grpc.ServerOption{
 		grpc.ChainUnaryInterceptor(
 			interceptor.LogUnaryServerInterceptor(),
-			interceptor.AuthUnaryServerInterceptor(userClient, config.Conf.Services.SearchService.PublicMethods...),
+			interceptor.AuthUnaryServerInterceptor(userClient, verifier, config.Conf.Services.SearchService.PublicMethods...),
+			interceptor.AccessTokenScopeUnaryServerInterceptor(config.Conf.Services.SearchService.AccessTokenScopes),
 		),
 	}
 	grpcSrv := server.NewGrpcServer(serviceName, config.Conf.Services.SearchService.GrpcPort, serverOpts...)
@@ -95,11 +121,12 @@ func main() {
 	health.SetHealthChecks(
 		healthUpdater,
 		serviceName,
-		consumer, esStore)
+		consumer, userConsumer, esStore)
 
 	// 启动后台任务
 	logger.Info("启动 Kafka 消费者后台任务...")
 	go consumer.Start(context.Background())
+	go userConsumer.Start(context.Background())
 
 	logger.Info("搜索服务准备就绪，开始监听请求",
 		slog.String("grpc_port", config.Conf.Services.SearchService.GrpcPort),
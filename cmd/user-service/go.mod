@@ -5,9 +5,11 @@ go 1.25.1
 require (
 	github.com/go-sql-driver/mysql v1.9.3
 	github.com/golang-jwt/jwt/v5 v5.3.0
+	github.com/google/uuid v1.6.0
 	github.com/jmoiron/sqlx v1.4.0
 	github.com/redis/go-redis/v9 v9.14.0
 	golang.org/x/crypto v0.42.0
+	golang.org/x/image v0.31.0
 	google.golang.org/grpc v1.75.1
 	google.golang.org/protobuf v1.36.9
 )
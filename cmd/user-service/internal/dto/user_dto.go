@@ -1,8 +1,13 @@
 package dto
 
 import (
+	"strings"
 	"time"
 
+	"qahub/pkg/auth"
+	"qahub/pkg/blob"
+	"qahub/pkg/config"
+	"qahub/pkg/i18n"
 	"qahub/user-service/internal/model"
 )
 
@@ -12,7 +17,17 @@ type UserResponse struct {
 	Username  string    `json:"username"`
 	Email     string    `json:"email"`
 	Bio       string    `json:"bio,omitempty"`
+	AvatarURL string    `json:"avatar_url,omitempty"` // 256 像素的头像缩略图，没有上传头像时为空
+	Language  string    `json:"language"`
+	UserType  string    `json:"user_type"` // human 或 bot
 	CreatedAt time.Time `json:"created_at"`
+
+	EmailVerified bool `json:"email_verified"`
+
+	Reputation          int64 `json:"reputation"`
+	QuestionCount       int64 `json:"question_count"`
+	AnswerCount         int64 `json:"answer_count"`
+	AcceptedAnswerCount int64 `json:"accepted_answer_count"`
 }
 
 // FromUser 从 User 模型创建 UserResponse
@@ -21,7 +36,15 @@ func (r *UserResponse) FromUser(user *model.User) *UserResponse {
 	r.Username = user.Username
 	r.Email = user.Email
 	r.Bio = user.Bio
+	r.AvatarURL = AvatarURL(user)
+	r.Language = i18n.Normalize(user.Language)
+	r.UserType = UserType(user)
 	r.CreatedAt = user.CreatedAt
+	r.EmailVerified = user.EmailVerifiedAt != nil
+	r.Reputation = user.Reputation
+	r.QuestionCount = user.QuestionCount
+	r.AnswerCount = user.AnswerCount
+	r.AcceptedAnswerCount = user.AcceptedAnswerCount
 	return r
 }
 
@@ -32,8 +55,31 @@ func NewUserResponse(user *model.User) *UserResponse {
 		Username:  user.Username,
 		Email:     user.Email,
 		Bio:       user.Bio,
+		AvatarURL: AvatarURL(user),
+		Language:  i18n.Normalize(user.Language), // 缓存中的旧数据可能没有语言字段
+		UserType:  UserType(user),
 		CreatedAt: user.CreatedAt,
+
+		EmailVerified: user.EmailVerifiedAt != nil,
+
+		Reputation:          user.Reputation,
+		QuestionCount:       user.QuestionCount,
+		AnswerCount:         user.AnswerCount,
+		AcceptedAnswerCount: user.AcceptedAnswerCount,
+	}
+}
+
+// AvatarURL 返回用户头像缩略图的地址，没有上传头像时为空
+func AvatarURL(user *model.User) string {
+	return blob.AvatarURL(config.Conf.Blob.BaseURL, user.AvatarKey, blob.AvatarSizeLarge)
+}
+
+// UserType 返回用户类型，缓存中的旧用户数据可能没有该字段
+func UserType(user *model.User) string {
+	if user.UserType == "" {
+		return auth.UserTypeHuman
 	}
+	return user.UserType
 }
 
 // RegisterRequest 定义了用户注册请求的结构。
@@ -53,3 +99,157 @@ func (r *RegisterRequest) ToUser(hashedPassword string) *model.User {
 		Bio:      r.Bio,
 	}
 }
+
+// TokenResponse 定义了登录和刷新令牌后返回的令牌对。
+type TokenResponse struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"` // store 不支持刷新令牌时为空
+	ExpiresAt    time.Time `json:"expires_at"`              // 访问令牌的过期时间
+
+	// ChallengeToken 非空时表示用户启用了两步验证，此时不包含上面的令牌，需要通过 Verify2FA 换取
+	ChallengeToken string `json:"challenge_token,omitempty"`
+	// TwoFactorSetupRequired 表示角色要求两步验证但用户尚未启用，令牌中只有普通用户权限
+	TwoFactorSetupRequired bool `json:"two_factor_setup_required,omitempty"`
+}
+
+// TwoFactorEnrollment 定义了生成两步验证密钥后返回的信息。
+type TwoFactorEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// OAuthLoginStart 定义了开始单点登录后返回的信息。
+type OAuthLoginStart struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+// SessionResponse 定义了会话列表中返回的单个登录会话。
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device"`
+	IP         string    `json:"ip"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"` // 是否为发起请求的会话
+}
+
+// NewSessionResponse 从 Session 模型创建 SessionResponse
+func NewSessionResponse(session *model.Session, current bool) *SessionResponse {
+	return &SessionResponse{
+		ID:         session.ID,
+		Device:     session.Device,
+		IP:         session.IP,
+		UserAgent:  session.UserAgent,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		Current:    current,
+	}
+}
+
+// CreateBotRequest 定义了管理员创建机器人账户的请求。
+type CreateBotRequest struct {
+	Username string
+	Email    string // 为空时使用不可投递的占位邮箱
+	Bio      string
+}
+
+// ImportUserRequest 定义了导入其他实例导出的用户的请求。
+type ImportUserRequest struct {
+	Username        string
+	Email           string
+	Bio             string
+	PasswordHash    string // 为空时生成随机密码，用户需要通过找回密码设置新密码
+	Role            string
+	UserType        string
+	Language        string
+	AvatarKey       string
+	CreatedAt       time.Time
+	EmailVerifiedAt *time.Time
+}
+
+// ImportUserResult 是导入单个用户的结果。
+type ImportUserResult struct {
+	UserID   int64
+	Username string // 实际使用的用户名，用户名冲突时与请求中的不同
+	Reused   bool   // 邮箱已存在，复用了已有用户
+}
+
+// CreateAccessTokenRequest 定义了创建个人访问令牌的请求。
+type CreateAccessTokenRequest struct {
+	UserID    int64 // 令牌所属的用户，为 0 时为当前用户；管理员可以为机器人账户创建令牌
+	Name      string
+	Scopes    []string
+	ExpiresIn time.Duration // 为 0 时永不过期
+}
+
+// AccessTokenResponse 定义了令牌列表中返回的单个个人访问令牌，不包含令牌本身。
+type AccessTokenResponse struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	TokenHint  string     `json:"token_hint"` // 令牌的最后几个字符
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// NewAccessTokenResponse 从 AccessToken 模型创建 AccessTokenResponse
+func NewAccessTokenResponse(token *model.AccessToken) *AccessTokenResponse {
+	return &AccessTokenResponse{
+		ID:         token.ID,
+		Name:       token.Name,
+		TokenHint:  token.TokenHint,
+		Scopes:     strings.Fields(token.Scopes),
+		ExpiresAt:  token.ExpiresAt,
+		LastUsedAt: token.LastUsedAt,
+		CreatedAt:  token.CreatedAt,
+	}
+}
+
+// AccessTokenCreated 定义了创建个人访问令牌后返回的信息，令牌只在此时返回一次。
+type AccessTokenCreated struct {
+	Token string `json:"token"`
+	*AccessTokenResponse
+}
+
+// SuspendUserRequest 定义了管理员封禁用户的请求。
+type SuspendUserRequest struct {
+	UserID int64
+	Reason string
+	Until  *time.Time // 为 nil 表示永久封禁
+}
+
+// AdminUserResponse 定义了管理员看到的用户信息，包含角色和封禁状态。
+type AdminUserResponse struct {
+	*UserResponse
+	Role             string     `json:"role"`
+	Suspended        bool       `json:"suspended"` // 当前是否处于封禁状态
+	SuspendedAt      *time.Time `json:"suspended_at,omitempty"`
+	SuspendedUntil   *time.Time `json:"suspended_until,omitempty"` // 为空表示永久封禁
+	SuspensionReason string     `json:"suspension_reason,omitempty"`
+	SuspendedBy      int64      `json:"suspended_by,omitempty"`
+}
+
+// NewAdminUserResponse 从 User 模型创建 AdminUserResponse，只返回仍然有效的封禁信息
+func NewAdminUserResponse(user *model.User, now time.Time) *AdminUserResponse {
+	role := user.Role
+	if role == "" {
+		role = auth.RoleUser
+	}
+	resp := &AdminUserResponse{
+		UserResponse: NewUserResponse(user),
+		Role:         role,
+	}
+	if user.Suspended(now) {
+		resp.Suspended = true
+		resp.SuspendedAt = user.SuspendedAt
+		resp.SuspendedUntil = user.SuspendedUntil
+		resp.SuspensionReason = user.SuspensionReason
+		if user.SuspendedBy != nil {
+			resp.SuspendedBy = *user.SuspendedBy
+		}
+	}
+	return resp
+}
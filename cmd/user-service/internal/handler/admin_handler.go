@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	pb "qahub/api/proto/user"
+	"qahub/pkg/auth"
+	"qahub/pkg/log"
+	"qahub/pkg/pagination"
+	"qahub/user-service/internal/dto"
+	"qahub/user-service/internal/model"
+	"qahub/user-service/internal/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// 封禁状态筛选条件
+const (
+	userStatusActive    = "active"
+	userStatusSuspended = "suspended"
+)
+
+// maxSuspensionReasonLength 是封禁原因的最大字符数，与 users.suspension_reason 的列宽一致
+const maxSuspensionReasonLength = 500
+
+// AdminUserGrpcServer 实现了 user_grpc.pb.go 中定义的 AdminUserServiceServer 接口
+type AdminUserGrpcServer struct {
+	pb.UnimplementedAdminUserServiceServer // 必须嵌入，以实现向前兼容
+	userService                            service.UserService
+}
+
+// NewAdminUserGrpcServer 创建用户管理的 gRPC 服务端处理器
+func NewAdminUserGrpcServer(svc service.UserService) *AdminUserGrpcServer {
+	return &AdminUserGrpcServer{
+		userService: svc,
+	}
+}
+
+// requireAdmin 从 context 中取出当前身份，并确认其为管理员
+func requireAdmin(ctx context.Context, action string) (auth.Identity, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return auth.Identity{}, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+	if !identity.IsAdmin() {
+		log.FromContext(ctx).Warn("权限校验失败：非管理员尝试"+action,
+			slog.Int64("authenticated_user_id", identity.UserID),
+		)
+		return auth.Identity{}, status.Errorf(codes.PermissionDenied, "没有权限执行此操作")
+	}
+	return identity, nil
+}
+
+func (s *AdminUserGrpcServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	if _, err := requireAdmin(ctx, "查询用户列表"); err != nil {
+		return nil, err
+	}
+
+	filter := model.UserFilter{
+		Query:    strings.TrimSpace(req.Query),
+		Role:     req.Role,
+		UserType: req.UserType,
+	}
+	switch req.Status {
+	case "":
+	case userStatusActive, userStatusSuspended:
+		suspended := req.Status == userStatusSuspended
+		filter.Suspended = &suspended
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "无效的状态，可选值为 %s、%s", userStatusActive, userStatusSuspended)
+	}
+
+	page, pageSize := pagination.NormalizePageAndSize(req)
+	users, total, err := s.userService.ListUsers(ctx, filter, page, pageSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "查询用户列表失败")
+	}
+
+	pbUsers := make([]*pb.AdminUser, 0, len(users))
+	for _, user := range users {
+		pbUsers = append(pbUsers, newPbAdminUser(user))
+	}
+	return &pb.ListUsersResponse{Users: pbUsers, TotalCount: total}, nil
+}
+
+func (s *AdminUserGrpcServer) SuspendUser(ctx context.Context, req *pb.SuspendUserRequest) (*pb.AdminUser, error) {
+	identity, err := requireAdmin(ctx, "封禁用户")
+	if err != nil {
+		return nil, err
+	}
+	if utf8.RuneCountInString(req.Reason) > maxSuspensionReasonLength {
+		return nil, status.Errorf(codes.InvalidArgument, "封禁原因不能超过 %d 个字符", maxSuspensionReasonLength)
+	}
+
+	suspendReq := dto.SuspendUserRequest{UserID: req.UserId, Reason: req.Reason}
+	if req.Until != nil {
+		until := req.Until.AsTime()
+		suspendReq.Until = &until
+	}
+	user, err := s.userService.SuspendUser(ctx, identity, suspendReq)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return newPbAdminUser(user), nil
+}
+
+func (s *AdminUserGrpcServer) UnsuspendUser(ctx context.Context, req *pb.UnsuspendUserRequest) (*pb.AdminUser, error) {
+	identity, err := requireAdmin(ctx, "解除封禁")
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userService.UnsuspendUser(ctx, identity, req.UserId)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return newPbAdminUser(user), nil
+}
+
+func (s *AdminUserGrpcServer) SetUserRole(ctx context.Context, req *pb.SetUserRoleRequest) (*pb.AdminUser, error) {
+	identity, err := requireAdmin(ctx, "修改用户角色")
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userService.SetUserRole(ctx, identity, req.UserId, req.Role)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return newPbAdminUser(user), nil
+}
+
+func (s *AdminUserGrpcServer) ForceLogout(ctx context.Context, req *pb.ForceLogoutRequest) (*pb.ForceLogoutResponse, error) {
+	identity, err := requireAdmin(ctx, "强制用户下线")
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.userService.ForceLogout(ctx, identity, req.UserId)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return &pb.ForceLogoutResponse{RevokedCount: int32(revoked)}, nil
+}
+
+func (s *AdminUserGrpcServer) ImportUser(ctx context.Context, req *pb.ImportUserRequest) (*pb.ImportUserResponse, error) {
+	identity, err := requireAdmin(ctx, "导入用户")
+	if err != nil {
+		return nil, err
+	}
+
+	importReq := dto.ImportUserRequest{
+		Username:     req.Username,
+		Email:        req.Email,
+		Bio:          req.Bio,
+		PasswordHash: req.PasswordHash,
+		Role:         req.Role,
+		UserType:     req.UserType,
+		Language:     req.Language,
+		AvatarKey:    req.AvatarKey,
+	}
+	if req.CreatedAt != nil {
+		importReq.CreatedAt = req.CreatedAt.AsTime()
+	}
+	if req.EmailVerifiedAt != nil {
+		verifiedAt := req.EmailVerifiedAt.AsTime()
+		importReq.EmailVerifiedAt = &verifiedAt
+	}
+	result, err := s.userService.ImportUser(ctx, identity, importReq)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return &pb.ImportUserResponse{
+		UserId:   result.UserID,
+		Username: result.Username,
+		Reused:   result.Reused,
+	}, nil
+}
+
+// newPbAdminUser 将 AdminUserResponse 转换为 protobuf 消息，未设置的时间保持为空
+func newPbAdminUser(user *dto.AdminUserResponse) *pb.AdminUser {
+	pbUser := &pb.AdminUser{
+		User: &pb.User{
+			Id:        user.ID,
+			Username:  user.Username,
+			Email:     user.Email,
+			Bio:       user.Bio,
+			AvatarUrl: user.AvatarURL,
+			Language:  user.Language,
+			UserType:  user.UserType,
+			CreatedAt: timestamppb.New(user.CreatedAt),
+
+			EmailVerified: user.EmailVerified,
+
+			Reputation:          user.Reputation,
+			QuestionCount:       user.QuestionCount,
+			AnswerCount:         user.AnswerCount,
+			AcceptedAnswerCount: user.AcceptedAnswerCount,
+		},
+		Role:             user.Role,
+		Suspended:        user.Suspended,
+		SuspensionReason: user.SuspensionReason,
+		SuspendedBy:      user.SuspendedBy,
+	}
+	if user.SuspendedAt != nil {
+		pbUser.SuspendedAt = timestamppb.New(*user.SuspendedAt)
+	}
+	if user.SuspendedUntil != nil {
+		pbUser.SuspendedUntil = timestamppb.New(*user.SuspendedUntil)
+	}
+	return pbUser
+}
+
+// adminError 将用户管理的业务错误转换为 gRPC 状态码
+func adminError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrSuspensionReasonRequired),
+		errors.Is(err, service.ErrInvalidSuspensionExpiry),
+		errors.Is(err, service.ErrInvalidRole),
+		errors.Is(err, service.ErrInvalidImportUser):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrUsernameTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, service.ErrCannotModifySelf):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "操作失败，请稍后再试")
+	}
+}
+
+// RegisterServer 将此 handler 注册到给定的 gRPC 服务器上
+func (s *AdminUserGrpcServer) RegisterServer(grpcServer *grpc.Server) {
+	pb.RegisterAdminUserServiceServer(grpcServer, s)
+}
@@ -2,11 +2,15 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"time"
+	"unicode/utf8"
 
 	pb "qahub/api/proto/user"
 	"qahub/pkg/auth"
 	"qahub/pkg/log"
+	"qahub/pkg/pagination"
 	"qahub/user-service/internal/dto"
 	"qahub/user-service/internal/model"
 	"qahub/user-service/internal/service"
@@ -52,6 +56,9 @@ func (s *UserGrpcServer) Register(ctx context.Context, req *pb.RegisterRequest)
 			slog.String("email", req.Email),
 			slog.String("error", err.Error()),
 		)
+		if errors.Is(err, service.ErrUsernameTaken) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
 		return nil, err
 	}
 
@@ -66,6 +73,8 @@ func (s *UserGrpcServer) Register(ctx context.Context, req *pb.RegisterRequest)
 			Username: userResponse.Username,
 			Email:    userResponse.Email,
 			Bio:      userResponse.Bio,
+
+			EmailVerified: userResponse.EmailVerified,
 		},
 	}, nil
 }
@@ -77,20 +86,227 @@ func (s *UserGrpcServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.L
 		slog.String("username", req.Username),
 	)
 
-	token, err := s.userService.Login(ctx, req.Username, req.Password)
+	tokens, err := s.userService.Login(ctx, req.Username, req.Password)
 	if err != nil {
 		logger.Warn("用户登录失败",
 			slog.String("username", req.Username),
 			slog.String("error", err.Error()),
 		)
-		return nil, err
+		switch {
+		case errors.Is(err, service.ErrInvalidCredentials):
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		case errors.Is(err, service.ErrLoginLocked):
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		case errors.Is(err, service.ErrBotLogin), errors.Is(err, service.ErrUserSuspended):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		default:
+			return nil, status.Errorf(codes.Internal, "登录失败，请稍后再试")
+		}
+	}
+
+	if tokens.ChallengeToken != "" {
+		logger.Info("用户密码校验通过，等待两步验证",
+			slog.String("username", req.Username),
+		)
+		return &pb.LoginResponse{ChallengeToken: tokens.ChallengeToken}, nil
 	}
 
 	logger.Info("用户登录成功",
 		slog.String("username", req.Username),
 	)
 
-	return &pb.LoginResponse{Token: token}, nil
+	return newLoginResponse(tokens), nil
+}
+
+// newLoginResponse 将签发的令牌转换为登录响应
+func newLoginResponse(tokens *dto.TokenResponse) *pb.LoginResponse {
+	return &pb.LoginResponse{
+		Token:                  tokens.AccessToken,
+		RefreshToken:           tokens.RefreshToken,
+		ExpiresAt:              timestamppb.New(tokens.ExpiresAt),
+		TwoFactorSetupRequired: tokens.TwoFactorSetupRequired,
+	}
+}
+
+func (s *UserGrpcServer) Verify2FA(ctx context.Context, req *pb.Verify2FARequest) (*pb.LoginResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if req.ChallengeToken == "" || req.Code == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "挑战令牌和验证码不能为空")
+	}
+
+	tokens, err := s.userService.Verify2FA(ctx, req.ChallengeToken, req.Code)
+	if err != nil {
+		logger.Warn("两步验证失败",
+			slog.String("error", err.Error()),
+		)
+		switch {
+		case errors.Is(err, service.ErrInvalidLoginChallenge):
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		case errors.Is(err, service.ErrInvalidTwoFactorCode):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrLoginLocked):
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		case errors.Is(err, service.ErrUserSuspended):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		default:
+			return nil, status.Errorf(codes.Internal, "登录失败，请稍后再试")
+		}
+	}
+
+	return newLoginResponse(tokens), nil
+}
+
+func (s *UserGrpcServer) BeginOAuthLogin(ctx context.Context, req *pb.BeginOAuthLoginRequest) (*pb.BeginOAuthLoginResponse, error) {
+	if req.Provider == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "身份提供方不能为空")
+	}
+
+	start, err := s.userService.BeginOAuthLogin(ctx, req.Provider)
+	if err != nil {
+		return nil, oauthError(err)
+	}
+
+	return &pb.BeginOAuthLoginResponse{
+		AuthorizationUrl: start.AuthorizationURL,
+		State:            start.State,
+	}, nil
+}
+
+func (s *UserGrpcServer) CompleteOAuthLogin(ctx context.Context, req *pb.CompleteOAuthLoginRequest) (*pb.LoginResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if req.Error != "" {
+		logger.Warn("身份提供方拒绝了单点登录",
+			slog.String("provider", req.Provider),
+			slog.String("error", req.Error),
+			slog.String("error_description", req.ErrorDescription),
+		)
+		return nil, status.Errorf(codes.Unauthenticated, "身份提供方拒绝了登录请求: %s", req.Error)
+	}
+	if req.Provider == "" || req.Code == "" || req.State == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "身份提供方、授权码和 state 不能为空")
+	}
+
+	tokens, err := s.userService.CompleteOAuthLogin(ctx, req.Provider, req.Code, req.State)
+	if err != nil {
+		logger.Warn("单点登录失败",
+			slog.String("provider", req.Provider),
+			slog.String("error", err.Error()),
+		)
+		return nil, oauthError(err)
+	}
+
+	if tokens.ChallengeToken != "" {
+		return &pb.LoginResponse{ChallengeToken: tokens.ChallengeToken}, nil
+	}
+	return newLoginResponse(tokens), nil
+}
+
+// oauthError 将单点登录的业务错误转换为 gRPC 状态码
+func oauthError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnknownOAuthProvider):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidOAuthState):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, service.ErrOAuthFailed):
+		// 不返回包装的细节，避免泄露身份提供方的响应
+		return status.Error(codes.Unauthenticated, service.ErrOAuthFailed.Error())
+	case errors.Is(err, service.ErrOAuthEmailRequired),
+		errors.Is(err, service.ErrOAuthAccountConflict):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrBotLogin), errors.Is(err, service.ErrUserSuspended):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "登录失败，请稍后再试")
+	}
+}
+
+func (s *UserGrpcServer) Enroll2FA(ctx context.Context, _ *emptypb.Empty) (*pb.Enroll2FAResponse, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+
+	enrollment, err := s.userService.Enroll2FA(ctx, identity)
+	if err != nil {
+		return nil, twoFactorError(err)
+	}
+
+	return &pb.Enroll2FAResponse{
+		Secret:     enrollment.Secret,
+		OtpauthUri: enrollment.OTPAuthURI,
+	}, nil
+}
+
+func (s *UserGrpcServer) Confirm2FA(ctx context.Context, req *pb.Confirm2FARequest) (*pb.Confirm2FAResponse, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+
+	recoveryCodes, err := s.userService.Confirm2FA(ctx, identity, req.Code)
+	if err != nil {
+		return nil, twoFactorError(err)
+	}
+
+	return &pb.Confirm2FAResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (s *UserGrpcServer) Disable2FA(ctx context.Context, req *pb.Disable2FARequest) (*emptypb.Empty, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+
+	if err := s.userService.Disable2FA(ctx, identity, req.Code); err != nil {
+		return nil, twoFactorError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// twoFactorError 将两步验证相关的业务错误映射为 gRPC 状态码
+func twoFactorError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidTwoFactorCode):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrTwoFactorAlreadyEnabled),
+		errors.Is(err, service.ErrTwoFactorNotEnabled),
+		errors.Is(err, service.ErrTwoFactorNotEnrolled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "操作失败，请稍后再试")
+	}
+}
+
+func (s *UserGrpcServer) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if req.RefreshToken == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "刷新令牌不能为空")
+	}
+
+	tokens, err := s.userService.RefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		logger.Warn("刷新令牌失败",
+			slog.String("error", err.Error()),
+		)
+		if errors.Is(err, service.ErrInvalidRefreshToken) || errors.Is(err, service.ErrRefreshTokenReused) {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if errors.Is(err, service.ErrUserSuspended) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "刷新令牌失败")
+	}
+
+	return &pb.RefreshTokenResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    timestamppb.New(tokens.ExpiresAt),
+	}, nil
 }
 
 func (s *UserGrpcServer) Logout(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
@@ -108,7 +324,7 @@ func (s *UserGrpcServer) Logout(ctx context.Context, req *emptypb.Empty) (*empty
 		slog.String("username", identity.Username),
 	)
 
-	err := s.userService.Logout(ctx, identity.Token, identity.Claims)
+	err := s.userService.Logout(ctx, identity)
 	if err != nil {
 		logger.Error("用户登出失败",
 			slog.Int64("user_id", identity.UserID),
@@ -124,6 +340,202 @@ func (s *UserGrpcServer) Logout(ctx context.Context, req *emptypb.Empty) (*empty
 	return &emptypb.Empty{}, nil
 }
 
+func (s *UserGrpcServer) ListSessions(ctx context.Context, req *emptypb.Empty) (*pb.ListSessionsResponse, error) {
+	logger := log.FromContext(ctx)
+
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+
+	sessions, err := s.userService.ListSessions(ctx, identity)
+	if err != nil {
+		logger.Error("获取会话列表失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		return nil, status.Errorf(codes.Internal, "获取会话列表失败")
+	}
+
+	pbSessions := make([]*pb.Session, 0, len(sessions))
+	for _, session := range sessions {
+		pbSessions = append(pbSessions, &pb.Session{
+			Id:         session.ID,
+			Device:     session.Device,
+			Ip:         session.IP,
+			UserAgent:  session.UserAgent,
+			CreatedAt:  timestamppb.New(session.CreatedAt),
+			LastSeenAt: timestamppb.New(session.LastSeenAt),
+			Current:    session.Current,
+		})
+	}
+
+	return &pb.ListSessionsResponse{Sessions: pbSessions}, nil
+}
+
+func (s *UserGrpcServer) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*emptypb.Empty, error) {
+	logger := log.FromContext(ctx)
+
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+	if req.SessionId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "会话ID不能为空")
+	}
+
+	if err := s.userService.RevokeSession(ctx, identity, req.SessionId); err != nil {
+		logger.Warn("撤销会话失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("session_id", req.SessionId),
+			slog.String("error", err.Error()),
+		)
+		if errors.Is(err, service.ErrSessionNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "撤销会话失败")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserGrpcServer) RevokeAllOtherSessions(ctx context.Context, req *emptypb.Empty) (*pb.RevokeAllOtherSessionsResponse, error) {
+	logger := log.FromContext(ctx)
+
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+
+	count, err := s.userService.RevokeAllOtherSessions(ctx, identity)
+	if err != nil {
+		logger.Error("撤销其他会话失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		return nil, status.Errorf(codes.Internal, "撤销其他会话失败")
+	}
+
+	return &pb.RevokeAllOtherSessionsResponse{RevokedCount: int32(count)}, nil
+}
+
+func (s *UserGrpcServer) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*emptypb.Empty, error) {
+	logger := log.FromContext(ctx)
+
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+
+	if err := s.userService.ChangePassword(ctx, identity, req.CurrentPassword, req.NewPassword); err != nil {
+		logger.Warn("修改密码失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		return nil, passwordError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserGrpcServer) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*emptypb.Empty, error) {
+	logger := log.FromContext(ctx)
+
+	if req.Email == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "邮箱不能为空")
+	}
+
+	if err := s.userService.RequestPasswordReset(ctx, req.Email); err != nil {
+		logger.Warn("密码重置请求失败",
+			slog.String("error", err.Error()),
+		)
+		return nil, passwordError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserGrpcServer) ConfirmPasswordReset(ctx context.Context, req *pb.ConfirmPasswordResetRequest) (*emptypb.Empty, error) {
+	logger := log.FromContext(ctx)
+
+	if req.Token == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "重置码不能为空")
+	}
+
+	if err := s.userService.ConfirmPasswordReset(ctx, req.Token, req.NewPassword); err != nil {
+		logger.Warn("重置密码失败",
+			slog.String("error", err.Error()),
+		)
+		return nil, passwordError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserGrpcServer) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*emptypb.Empty, error) {
+	logger := log.FromContext(ctx)
+
+	if req.Token == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "验证码不能为空")
+	}
+
+	if err := s.userService.VerifyEmail(ctx, req.Token); err != nil {
+		logger.Warn("邮箱验证失败",
+			slog.String("error", err.Error()),
+		)
+		return nil, verificationError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserGrpcServer) ResendVerification(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	logger := log.FromContext(ctx)
+
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+
+	if err := s.userService.ResendVerification(ctx, identity); err != nil {
+		logger.Warn("重发验证邮件失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		return nil, verificationError(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// verificationError 将邮箱验证相关的业务错误映射为 gRPC 状态码
+func verificationError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidVerificationToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrEmailAlreadyVerified):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrTooManyRequests):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "操作失败，请稍后再试")
+	}
+}
+
+// passwordError 将密码相关的业务错误映射为 gRPC 状态码
+func passwordError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrIncorrectPassword),
+		errors.Is(err, service.ErrInvalidPassword),
+		errors.Is(err, service.ErrInvalidResetToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrTooManyRequests):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "操作失败，请稍后再试")
+	}
+}
+
 func (s *UserGrpcServer) ValidateToken(ctx context.Context, req *pb.ValidateTokenRequest) (*pb.ValidateTokenResponse, error) {
 	logger := log.FromContext(ctx)
 
@@ -157,6 +569,26 @@ func (s *UserGrpcServer) ValidateToken(ctx context.Context, req *pb.ValidateToke
 	}, nil
 }
 
+// GetJWKS 返回校验访问令牌签名的公钥，不需要认证
+func (s *UserGrpcServer) GetJWKS(ctx context.Context, req *emptypb.Empty) (*pb.GetJWKSResponse, error) {
+	jwks := s.userService.GetJWKS(ctx)
+
+	keys := make([]*pb.JSONWebKey, 0, len(jwks.Keys))
+	for _, key := range jwks.Keys {
+		keys = append(keys, &pb.JSONWebKey{
+			Kty: key.Kty,
+			Kid: key.Kid,
+			Use: key.Use,
+			Alg: key.Alg,
+			N:   key.N,
+			E:   key.E,
+			Crv: key.Crv,
+			X:   key.X,
+		})
+	}
+	return &pb.GetJWKSResponse{Keys: keys}, nil
+}
+
 func (s *UserGrpcServer) GetUserProfile(ctx context.Context, req *pb.GetUserProfileRequest) (*pb.GetUserProfileResponse, error) {
 	logger := log.FromContext(ctx)
 
@@ -178,13 +610,68 @@ func (s *UserGrpcServer) GetUserProfile(ctx context.Context, req *pb.GetUserProf
 		slog.String("username", userResponse.Username),
 	)
 
+	// 两步验证状态只返回给用户本人
+	var twoFactorEnabled bool
+	if identity, ok := auth.FromContext(ctx); ok && identity.UserID == req.UserId {
+		if twoFactorEnabled, err = s.userService.TwoFactorEnabled(ctx, req.UserId); err != nil {
+			logger.Warn("获取两步验证状态失败",
+				slog.Int64("user_id", req.UserId),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
 	return &pb.GetUserProfileResponse{
 		User: &pb.User{
 			Id:        userResponse.ID,
 			Username:  userResponse.Username,
 			Email:     userResponse.Email,
 			Bio:       userResponse.Bio,
+			AvatarUrl: userResponse.AvatarURL,
+			Language:  userResponse.Language,
+			UserType:  userResponse.UserType,
+			CreatedAt: timestamppb.New(userResponse.CreatedAt),
+
+			EmailVerified:    userResponse.EmailVerified,
+			TwoFactorEnabled: twoFactorEnabled,
+
+			Reputation:          userResponse.Reputation,
+			QuestionCount:       userResponse.QuestionCount,
+			AnswerCount:         userResponse.AnswerCount,
+			AcceptedAnswerCount: userResponse.AcceptedAnswerCount,
+		},
+	}, nil
+}
+
+func (s *UserGrpcServer) GetUserByUsername(ctx context.Context, req *pb.GetUserByUsernameRequest) (*pb.GetUserProfileResponse, error) {
+	logger := log.FromContext(ctx)
+
+	userResponse, err := s.userService.GetUserByUsername(ctx, req.Username)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		logger.Error("按用户名获取用户失败",
+			slog.String("username", req.Username),
+			slog.String("error", err.Error()),
+		)
+		return nil, status.Errorf(codes.Internal, "获取用户失败")
+	}
+
+	return &pb.GetUserProfileResponse{
+		User: &pb.User{
+			Id:        userResponse.ID,
+			Username:  userResponse.Username,
+			Bio:       userResponse.Bio,
+			AvatarUrl: userResponse.AvatarURL,
+			Language:  userResponse.Language,
+			UserType:  userResponse.UserType,
 			CreatedAt: timestamppb.New(userResponse.CreatedAt),
+
+			Reputation:          userResponse.Reputation,
+			QuestionCount:       userResponse.QuestionCount,
+			AnswerCount:         userResponse.AnswerCount,
+			AcceptedAnswerCount: userResponse.AcceptedAnswerCount,
 		},
 	}, nil
 }
@@ -223,6 +710,39 @@ func (s *UserGrpcServer) UpdateUserProfile(ctx context.Context, req *pb.UpdateUs
 		Username: req.Username,
 		Email:    req.Email,
 		Bio:      req.Bio,
+		Language: req.Language,
+	}
+
+	// 指定了 update_mask 时只更新其中列出的字段，其余字段保持原值
+	if paths := req.GetUpdateMask().GetPaths(); len(paths) > 0 {
+		current, err := s.userService.GetUserProfile(ctx, req.UserId)
+		if err != nil {
+			logger.Error("获取用户当前资料失败",
+				slog.Int64("user_id", req.UserId),
+				slog.String("error", err.Error()),
+			)
+			return nil, err
+		}
+		updateModel = &model.User{
+			ID:       req.UserId,
+			Username: current.Username,
+			Email:    current.Email,
+			Bio:      current.Bio,
+		}
+		for _, path := range paths {
+			switch path {
+			case "username":
+				return nil, status.Errorf(codes.InvalidArgument, "用户名请通过 ChangeUsername 修改")
+			case "email":
+				updateModel.Email = req.Email
+			case "bio":
+				updateModel.Bio = req.Bio
+			case "language":
+				updateModel.Language = req.Language
+			default:
+				return nil, status.Errorf(codes.InvalidArgument, "不支持更新的字段: %s", path)
+			}
+		}
 	}
 
 	// 调用 service 层更新用户资料
@@ -232,6 +752,9 @@ func (s *UserGrpcServer) UpdateUserProfile(ctx context.Context, req *pb.UpdateUs
 			slog.Int64("user_id", req.UserId),
 			slog.String("error", err.Error()),
 		)
+		if errors.Is(err, service.ErrUnsupportedLanguage) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, err
 	}
 
@@ -244,6 +767,391 @@ func (s *UserGrpcServer) UpdateUserProfile(ctx context.Context, req *pb.UpdateUs
 	return &emptypb.Empty{}, nil
 }
 
+func (s *UserGrpcServer) ChangeUsername(ctx context.Context, req *pb.ChangeUsernameRequest) (*pb.ChangeUsernameResponse, error) {
+	logger := log.FromContext(ctx)
+
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+	if n := utf8.RuneCountInString(req.Username); n < 3 || n > 30 {
+		return nil, status.Errorf(codes.InvalidArgument, "用户名长度必须在 3 到 30 个字符之间")
+	}
+
+	user, err := s.userService.ChangeUsername(ctx, identity.UserID, req.Username)
+	if err != nil {
+		logger.Warn("修改用户名失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		switch {
+		case errors.Is(err, service.ErrUsernameTaken):
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		case errors.Is(err, service.ErrUsernameUnchanged):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrUsernameChangeTooSoon):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		default:
+			return nil, status.Errorf(codes.Internal, "修改用户名失败")
+		}
+	}
+
+	return &pb.ChangeUsernameResponse{
+		User: &pb.User{
+			Id:        user.ID,
+			Username:  user.Username,
+			Email:     user.Email,
+			Bio:       user.Bio,
+			AvatarUrl: user.AvatarURL,
+			Language:  user.Language,
+			UserType:  user.UserType,
+			CreatedAt: timestamppb.New(user.CreatedAt),
+
+			EmailVerified: user.EmailVerified,
+
+			Reputation:          user.Reputation,
+			QuestionCount:       user.QuestionCount,
+			AnswerCount:         user.AnswerCount,
+			AcceptedAnswerCount: user.AcceptedAnswerCount,
+		},
+	}, nil
+}
+
+// UploadAvatar 接收客户端分块上传的图片，所有分块拼接后才是完整的文件
+func (s *UserGrpcServer) UploadAvatar(stream pb.UserService_UploadAvatarServer) error {
+	ctx := stream.Context()
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+
+	user, err := s.userService.UploadAvatar(ctx, identity, &avatarChunkReader{stream: stream})
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrAvatarTooLarge),
+			errors.Is(err, service.ErrUnsupportedAvatarType),
+			errors.Is(err, service.ErrInvalidAvatarDimensions),
+			errors.Is(err, service.ErrInvalidAvatar):
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		log.FromContext(ctx).Error("上传头像失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		return status.Errorf(codes.Internal, "上传头像失败")
+	}
+
+	return stream.SendAndClose(&pb.UploadAvatarResponse{
+		User: &pb.User{
+			Id:        user.ID,
+			Username:  user.Username,
+			Email:     user.Email,
+			Bio:       user.Bio,
+			AvatarUrl: user.AvatarURL,
+			Language:  user.Language,
+			UserType:  user.UserType,
+			CreatedAt: timestamppb.New(user.CreatedAt),
+
+			EmailVerified: user.EmailVerified,
+
+			Reputation:          user.Reputation,
+			QuestionCount:       user.QuestionCount,
+			AnswerCount:         user.AnswerCount,
+			AcceptedAnswerCount: user.AcceptedAnswerCount,
+		},
+	})
+}
+
+// avatarChunkReader 把上传流中的分块适配为 io.Reader，客户端关闭发送端时返回 io.EOF
+type avatarChunkReader struct {
+	stream pb.UserService_UploadAvatarServer
+	buf    []byte
+}
+
+func (r *avatarChunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = req.Chunk
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (s *UserGrpcServer) FollowUser(ctx context.Context, req *pb.FollowUserRequest) (*emptypb.Empty, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+
+	if err := s.userService.FollowUser(ctx, identity, req.UserId); err != nil {
+		switch {
+		case errors.Is(err, service.ErrUserNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, service.ErrCannotFollowSelf):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		default:
+			return nil, status.Errorf(codes.Internal, "关注用户失败")
+		}
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserGrpcServer) UnfollowUser(ctx context.Context, req *pb.FollowUserRequest) (*emptypb.Empty, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+
+	if err := s.userService.UnfollowUser(ctx, identity, req.UserId); err != nil {
+		return nil, status.Errorf(codes.Internal, "取消关注失败")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserGrpcServer) ListFollowers(ctx context.Context, req *pb.ListFollowsRequest) (*pb.ListFollowsResponse, error) {
+	page, pageSize := pagination.NormalizePageAndSize(req)
+	users, total, err := s.userService.ListFollowers(ctx, req.UserId, page, pageSize)
+	if err != nil {
+		log.FromContext(ctx).Error("获取关注者列表失败",
+			slog.Int64("user_id", req.UserId),
+			slog.String("error", err.Error()),
+		)
+		return nil, status.Errorf(codes.Internal, "获取关注者列表失败")
+	}
+	return newListFollowsResponse(users, total), nil
+}
+
+func (s *UserGrpcServer) ListFollowing(ctx context.Context, req *pb.ListFollowsRequest) (*pb.ListFollowsResponse, error) {
+	page, pageSize := pagination.NormalizePageAndSize(req)
+	users, total, err := s.userService.ListFollowing(ctx, req.UserId, page, pageSize)
+	if err != nil {
+		log.FromContext(ctx).Error("获取关注列表失败",
+			slog.Int64("user_id", req.UserId),
+			slog.String("error", err.Error()),
+		)
+		return nil, status.Errorf(codes.Internal, "获取关注列表失败")
+	}
+	return newListFollowsResponse(users, total), nil
+}
+
+// newListFollowsResponse 将关注列表转换为响应消息，与 GetUserByUsername 一样不返回邮箱
+func newListFollowsResponse(users []*dto.UserResponse, total int64) *pb.ListFollowsResponse {
+	pbUsers := make([]*pb.User, 0, len(users))
+	for _, user := range users {
+		pbUsers = append(pbUsers, &pb.User{
+			Id:        user.ID,
+			Username:  user.Username,
+			Bio:       user.Bio,
+			AvatarUrl: user.AvatarURL,
+			Language:  user.Language,
+			UserType:  user.UserType,
+			CreatedAt: timestamppb.New(user.CreatedAt),
+
+			Reputation:          user.Reputation,
+			QuestionCount:       user.QuestionCount,
+			AnswerCount:         user.AnswerCount,
+			AcceptedAnswerCount: user.AcceptedAnswerCount,
+		})
+	}
+	return &pb.ListFollowsResponse{Users: pbUsers, TotalCount: total}
+}
+
+func (s *UserGrpcServer) GetFeed(ctx context.Context, req *pb.GetFeedRequest) (*pb.GetFeedResponse, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+
+	page, pageSize := pagination.NormalizePageAndSize(req)
+	items, total, err := s.userService.GetFeed(ctx, identity, page, pageSize)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "获取关注动态失败")
+	}
+
+	pbItems := make([]*pb.FeedItem, 0, len(items))
+	for _, item := range items {
+		pbItems = append(pbItems, &pb.FeedItem{
+			Type:       item.Type,
+			QuestionId: item.QuestionID,
+			AnswerId:   item.AnswerID,
+			Title:      item.Title,
+			Excerpt:    item.Excerpt,
+			AuthorId:   item.AuthorID,
+			AuthorName: item.AuthorName,
+			CreatedAt:  timestamppb.New(item.CreatedAt),
+		})
+	}
+	return &pb.GetFeedResponse{Items: pbItems, TotalCount: total}, nil
+}
+
+func (s *UserGrpcServer) UnlockUser(ctx context.Context, req *pb.UnlockUserRequest) (*emptypb.Empty, error) {
+	logger := log.FromContext(ctx)
+
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+	if !identity.IsAdmin() {
+		logger.Warn("权限校验失败：非管理员尝试解锁用户",
+			slog.Int64("authenticated_user_id", identity.UserID),
+			slog.Int64("target_user_id", req.UserId),
+		)
+		return nil, status.Errorf(codes.PermissionDenied, "没有权限执行此操作")
+	}
+
+	if err := s.userService.UnlockUser(ctx, req.UserId); err != nil {
+		return nil, status.Errorf(codes.Internal, "解锁用户失败")
+	}
+
+	logger.Info("管理员解锁了用户",
+		slog.Int64("admin_id", identity.UserID),
+		slog.Int64("user_id", req.UserId),
+	)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *UserGrpcServer) CreateBot(ctx context.Context, req *pb.CreateBotRequest) (*pb.CreateBotResponse, error) {
+	logger := log.FromContext(ctx)
+
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+	if !identity.IsAdmin() {
+		logger.Warn("权限校验失败：非管理员尝试创建机器人账户",
+			slog.Int64("authenticated_user_id", identity.UserID),
+		)
+		return nil, status.Errorf(codes.PermissionDenied, "没有权限执行此操作")
+	}
+	if n := utf8.RuneCountInString(req.Username); n < 3 || n > 30 {
+		return nil, status.Errorf(codes.InvalidArgument, "用户名长度必须在 3 到 30 个字符之间")
+	}
+
+	bot, err := s.userService.CreateBot(ctx, dto.CreateBotRequest{
+		Username: req.Username,
+		Email:    req.Email,
+		Bio:      req.Bio,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrUsernameTaken) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "创建机器人账户失败")
+	}
+
+	logger.Info("管理员创建了机器人账户",
+		slog.Int64("admin_id", identity.UserID),
+		slog.Int64("bot_id", bot.ID),
+	)
+	return &pb.CreateBotResponse{
+		User: &pb.User{
+			Id:        bot.ID,
+			Username:  bot.Username,
+			Email:     bot.Email,
+			Bio:       bot.Bio,
+			AvatarUrl: bot.AvatarURL,
+			Language:  bot.Language,
+			UserType:  bot.UserType,
+			CreatedAt: timestamppb.New(bot.CreatedAt),
+
+			EmailVerified: bot.EmailVerified,
+		},
+	}, nil
+}
+
+func (s *UserGrpcServer) CreateAccessToken(ctx context.Context, req *pb.CreateAccessTokenRequest) (*pb.CreateAccessTokenResponse, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+
+	created, err := s.userService.CreateAccessToken(ctx, identity, dto.CreateAccessTokenRequest{
+		UserID:    req.UserId,
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresIn: time.Duration(req.ExpiresInSeconds) * time.Second,
+	})
+	if err != nil {
+		return nil, accessTokenError(err)
+	}
+
+	return &pb.CreateAccessTokenResponse{
+		Token:       created.Token,
+		AccessToken: newPbAccessToken(created.AccessTokenResponse),
+	}, nil
+}
+
+func (s *UserGrpcServer) ListAccessTokens(ctx context.Context, req *pb.ListAccessTokensRequest) (*pb.ListAccessTokensResponse, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+
+	tokens, err := s.userService.ListAccessTokens(ctx, identity, req.UserId)
+	if err != nil {
+		return nil, accessTokenError(err)
+	}
+
+	pbTokens := make([]*pb.AccessToken, 0, len(tokens))
+	for _, token := range tokens {
+		pbTokens = append(pbTokens, newPbAccessToken(token))
+	}
+	return &pb.ListAccessTokensResponse{AccessTokens: pbTokens}, nil
+}
+
+func (s *UserGrpcServer) RevokeAccessToken(ctx context.Context, req *pb.RevokeAccessTokenRequest) (*emptypb.Empty, error) {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || identity.UserID == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "用户未登录")
+	}
+
+	if err := s.userService.RevokeAccessToken(ctx, identity, req.UserId, req.TokenId); err != nil {
+		return nil, accessTokenError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// newPbAccessToken 将 AccessTokenResponse 转换为 protobuf 消息，未设置的时间保持为空
+func newPbAccessToken(token *dto.AccessTokenResponse) *pb.AccessToken {
+	pbToken := &pb.AccessToken{
+		Id:        token.ID,
+		Name:      token.Name,
+		TokenHint: token.TokenHint,
+		Scopes:    token.Scopes,
+		CreatedAt: timestamppb.New(token.CreatedAt),
+	}
+	if token.ExpiresAt != nil {
+		pbToken.ExpiresAt = timestamppb.New(*token.ExpiresAt)
+	}
+	if token.LastUsedAt != nil {
+		pbToken.LastUsedAt = timestamppb.New(*token.LastUsedAt)
+	}
+	return pbToken
+}
+
+func accessTokenError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidAccessTokenName),
+		errors.Is(err, service.ErrInvalidScope),
+		errors.Is(err, service.ErrInvalidExpiry):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrAdminScopeNotAllowed),
+		errors.Is(err, service.ErrAccessTokenForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrAccessTokenNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "操作访问令牌失败")
+	}
+}
+
 func (s *UserGrpcServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*emptypb.Empty, error) {
 	logger := log.FromContext(ctx)
 
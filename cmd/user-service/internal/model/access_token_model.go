@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// AccessToken 对应于 access_tokens 表，表示一个个人访问令牌。令牌本身只在创建时返回一次，表中只保存哈希。
+type AccessToken struct {
+	ID         int64      `db:"id"`
+	UserID     int64      `db:"user_id"`
+	Name       string     `db:"name"`       // 用户为令牌起的名称，便于区分用途
+	TokenHash  string     `db:"token_hash"` // 令牌的 SHA-256 哈希
+	TokenHint  string     `db:"token_hint"` // 令牌的最后几个字符，用于在列表中辨认令牌
+	Scopes     string     `db:"scopes"`     // 以空格分隔的权限范围
+	ExpiresAt  *time.Time `db:"expires_at"` // 为 nil 表示永不过期
+	LastUsedAt *time.Time `db:"last_used_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+// Active 判断令牌在 now 时刻是否可用
+func (t *AccessToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && (t.ExpiresAt == nil || now.Before(*t.ExpiresAt))
+}
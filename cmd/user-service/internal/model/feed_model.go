@@ -0,0 +1,37 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// 动态内容的类型
+const (
+	FeedItemQuestion = "question"
+	FeedItemAnswer   = "answer"
+)
+
+// FeedItem 是个人动态时间线中的一条内容，由问答事件生成，保存在 Redis 中
+type FeedItem struct {
+	Type       string    `json:"type"` // question 或 answer
+	QuestionID int64     `json:"question_id"`
+	AnswerID   int64     `json:"answer_id,omitempty"`
+	Title      string    `json:"title,omitempty"` // 问题标题，回答没有标题
+	Excerpt    string    `json:"excerpt"`         // 内容摘要
+	AuthorID   int64     `json:"author_id"`
+	AuthorName string    `json:"author_name"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// FeedItemKey 返回动态内容在时间线中的标识，例如 "question:12"
+func FeedItemKey(itemType string, id int64) string {
+	return fmt.Sprintf("%s:%d", itemType, id)
+}
+
+// Key 返回动态内容在时间线中的标识
+func (i *FeedItem) Key() string {
+	if i.Type == FeedItemAnswer {
+		return FeedItemKey(FeedItemAnswer, i.AnswerID)
+	}
+	return FeedItemKey(FeedItemQuestion, i.QuestionID)
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// UserIdentity 对应于 user_identities 表，将外部身份提供方的账户关联到本地用户
+type UserIdentity struct {
+	ID        int64     `db:"id"`
+	UserID    int64     `db:"user_id"`
+	Provider  string    `db:"provider"` // 配置中的身份提供方名称，仅用于展示和审计
+	Issuer    string    `db:"issuer"`
+	Subject   string    `db:"subject"` // 用户在身份提供方的唯一标识 (sub)
+	Email     string    `db:"email"`   // 关联时身份提供方返回的邮箱
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// 声望变动的原因
+const (
+	ReputationReasonQuestionAsked     = "question_asked"     // 提出问题
+	ReputationReasonAnswerPosted      = "answer_posted"      // 发布回答（只计数，不加分）
+	ReputationReasonAnswerUpvoted     = "answer_upvoted"     // 回答被点赞
+	ReputationReasonUpvoteRetracted   = "upvote_retracted"   // 回答的点赞被撤销
+	ReputationReasonAnswerDownvoted   = "answer_downvoted"   // 回答被点踩
+	ReputationReasonDownvoteRetracted = "downvote_retracted" // 回答的点踩被撤销
+	ReputationReasonAnswerAccepted    = "answer_accepted"    // 回答被采纳
+	ReputationReasonContentFlagged    = "content_flagged"    // 内容被版主标记违规
+	ReputationReasonContentRemoved    = "content_removed"    // 内容被删除，冲销该内容此前的全部积分
+)
+
+// 声望来源内容的类型
+const (
+	ReputationSourceQuestion = "question"
+	ReputationSourceAnswer   = "answer"
+)
+
+// ReputationEvent 对应于数据库中的 reputation_events 表，是声望变动的流水账
+type ReputationEvent struct {
+	ID         int64     `db:"id"`
+	UserID     int64     `db:"user_id"`
+	EventID    string    `db:"event_id"` // 来源 Kafka 事件的ID，用于去重
+	Reason     string    `db:"reason"`
+	Points     int64     `db:"points"`
+	SourceType string    `db:"source_type"`
+	SourceID   int64     `db:"source_id"`
+	QuestionID int64     `db:"question_id"` // 内容所属的问题，删除问题时据此冲销其下全部积分
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+// ReputationTotal 是某个用户从某项内容上累计获得的积分
+type ReputationTotal struct {
+	UserID     int64  `db:"user_id"`
+	SourceType string `db:"source_type"`
+	SourceID   int64  `db:"source_id"`
+	Points     int64  `db:"points"`
+}
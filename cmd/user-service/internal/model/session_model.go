@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// Session 是一次登录产生的会话，保存在 Redis 中。
+// 会话ID同时也是刷新令牌族的ID，会话被撤销后族内所有令牌随之失效。
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     int64     `json:"user_id"`
+	Device     string    `json:"device"`     // 客户端通过 x-device-name 上报的设备名称
+	IP         string    `json:"ip"`         // 最近一次登录或刷新时的客户端 IP
+	UserAgent  string    `json:"user_agent"` // 客户端的 User-Agent
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"` // 最近一次登录或刷新令牌的时间
+}
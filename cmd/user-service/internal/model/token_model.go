@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// RefreshToken 是保存在 Redis 中的刷新令牌记录，令牌本身只以 SHA-256 哈希的形式保存。
+// 同一次登录派生出的所有刷新令牌构成一个令牌族，共享所属会话的ID，用于整条链的撤销。
+type RefreshToken struct {
+	TokenHash string    `json:"token_hash"`
+	UserID    int64     `json:"user_id"`
+	SessionID string    `json:"session_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// EmailVerificationToken 是保存在 Redis 中的邮箱验证令牌记录。
+// 令牌绑定签发时的邮箱，用户在验证前修改了邮箱时旧令牌随之失效。
+type EmailVerificationToken struct {
+	UserID int64  `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+// OAuthState 是保存在 Redis 中的单点登录请求，以 state 的哈希为键。
+// 回调时凭 state 取回 nonce 和 PKCE 校验码，每个 state 只能使用一次。
+type OAuthState struct {
+	Provider string `json:"provider"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// TwoFactor 对应于 user_two_factor 表，保存用户的 TOTP 密钥
+type TwoFactor struct {
+	UserID    int64      `db:"user_id"`
+	Secret    string     `db:"secret"`     // Base32 编码的 TOTP 密钥
+	EnabledAt *time.Time `db:"enabled_at"` // 为 nil 表示密钥已生成但用户尚未确认绑定
+	CreatedAt time.Time  `db:"created_at"`
+}
+
+// Enabled 判断两步验证是否已经确认启用
+func (t *TwoFactor) Enabled() bool {
+	return t != nil && t.EnabledAt != nil
+}
@@ -8,7 +8,47 @@ type User struct {
 	Username  string    `db:"username"`
 	Email     string    `db:"email"`
 	Bio       string    `db:"bio"`
-	Password  string    `db:"password"` // 在实际应用中应存储哈希值
+	AvatarKey string    `db:"avatar_key"` // 头像缩略图在文件存储中的目录，为空表示没有上传头像
+	Password  string    `db:"password"`   // 在实际应用中应存储哈希值
+	Role      string    `db:"role"`       // user、moderator 或 admin
+	UserType  string    `db:"user_type"`  // human 或 bot，机器人账户只能通过个人访问令牌调用接口
+	Language  string    `db:"language"`   // 语言偏好，决定通知等内容的展示语言
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
+
+	// EmailVerifiedAt 为 nil 表示邮箱尚未验证，修改邮箱后会被重置
+	EmailVerifiedAt *time.Time `db:"email_verified_at"`
+
+	// 以下字段由管理员封禁用户时写入，SuspendedAt 为 nil 表示未被封禁
+	SuspendedAt      *time.Time `db:"suspended_at"`
+	SuspendedUntil   *time.Time `db:"suspended_until"` // 为 nil 表示永久封禁
+	SuspensionReason string     `db:"suspension_reason"`
+	SuspendedBy      *int64     `db:"suspended_by"` // 执行封禁的管理员
+
+	// 以下统计字段由 reputation_events 流水汇总而来
+	Reputation          int64 `db:"reputation"`
+	QuestionCount       int64 `db:"question_count"`
+	AnswerCount         int64 `db:"answer_count"`
+	AcceptedAnswerCount int64 `db:"accepted_answer_count"`
+}
+
+// Suspended 判断用户在 now 时刻是否处于封禁状态，封禁到期后自动失效
+func (u *User) Suspended(now time.Time) bool {
+	return u.SuspendedAt != nil && (u.SuspendedUntil == nil || now.Before(*u.SuspendedUntil))
+}
+
+// UserFilter 是管理员查询用户列表的筛选条件，零值表示不筛选
+type UserFilter struct {
+	Query     string // 按用户名或邮箱模糊匹配
+	Role      string
+	UserType  string
+	Suspended *bool // 只返回当前处于（或不处于）封禁状态的用户
+}
+
+// UsernameChange 对应于 username_history 表，记录用户修改前的用户名
+type UsernameChange struct {
+	ID        int64     `db:"id"`
+	UserID    int64     `db:"user_id"`
+	Username  string    `db:"username"` // 修改前的用户名
+	ChangedAt time.Time `db:"changed_at"`
 }
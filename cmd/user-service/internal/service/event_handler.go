@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"qahub/pkg/messaging"
+	"qahub/user-service/internal/model"
+)
+
+func (s *reputationService) RegisterHandlers() map[messaging.EventType]messaging.EventHandler {
+	return map[messaging.EventType]messaging.EventHandler{
+		messaging.EventQuestionCreated:     s.handleQuestionCreated,
+		messaging.EventQuestionDeleted:     s.handleQuestionDeleted,
+		messaging.EventAnswerCreated:       s.handleAnswerCreated,
+		messaging.EventAnswerDeleted:       s.handleAnswerDeleted,
+		messaging.EventAnswerUpvoted:       s.handleAnswerUpvoted,
+		messaging.EventAnswerDownvoted:     s.handleAnswerDownvoted,
+		messaging.EventAnswerVoteRetracted: s.handleAnswerVoteRetracted,
+		messaging.EventAnswerAccepted:      s.handleAnswerAccepted,
+		messaging.EventContentFlagged:      s.handleContentFlagged,
+	}
+}
+
+func (s *reputationService) handleQuestionCreated(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.QuestionCreatedEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 QuestionCreatedEvent 失败: %w", err)
+	}
+	err := s.userStore.RecordReputationEvents(ctx, []*model.ReputationEvent{{
+		UserID:     event.Payload.AuthorID,
+		EventID:    event.Header.ID,
+		Reason:     model.ReputationReasonQuestionAsked,
+		Points:     PointsQuestionAsked,
+		SourceType: model.ReputationSourceQuestion,
+		SourceID:   event.Payload.ID,
+		QuestionID: event.Payload.ID,
+	}})
+	if err != nil {
+		return fmt.Errorf("记录提问声望失败 (问题ID: %d): %w", event.Payload.ID, err)
+	}
+	log.Printf("已记录提问声望 (问题ID: %d, 用户ID: %d)", event.Payload.ID, event.Payload.AuthorID)
+	return nil
+}
+
+func (s *reputationService) handleQuestionDeleted(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.QuestionDeletedEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 QuestionDeletedEvent 失败: %w", err)
+	}
+	// 问题删除时其下的回答被级联删除，一并冲销
+	totals, err := s.userStore.ListReputationTotalsByQuestion(ctx, event.Payload.ID)
+	if err != nil {
+		return fmt.Errorf("查询问题声望流水失败 (问题ID: %d): %w", event.Payload.ID, err)
+	}
+	if err := s.revertTotals(ctx, event.Header.ID, event.Payload.ID, totals); err != nil {
+		return fmt.Errorf("冲销问题声望失败 (问题ID: %d): %w", event.Payload.ID, err)
+	}
+	log.Printf("已冲销问题声望 (问题ID: %d, 流水数: %d)", event.Payload.ID, len(totals))
+	return nil
+}
+
+func (s *reputationService) handleAnswerCreated(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.AnswerEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 AnswerEvent 失败: %w", err)
+	}
+	err := s.userStore.RecordReputationEvents(ctx, []*model.ReputationEvent{{
+		UserID:     event.Payload.AuthorID,
+		EventID:    event.Header.ID,
+		Reason:     model.ReputationReasonAnswerPosted,
+		SourceType: model.ReputationSourceAnswer,
+		SourceID:   event.Payload.ID,
+		QuestionID: event.Payload.QuestionID,
+	}})
+	if err != nil {
+		return fmt.Errorf("记录回答统计失败 (回答ID: %d): %w", event.Payload.ID, err)
+	}
+	return nil
+}
+
+func (s *reputationService) handleAnswerDeleted(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.AnswerEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 AnswerEvent 失败: %w", err)
+	}
+	totals, err := s.userStore.ListReputationTotalsByAnswer(ctx, event.Payload.ID)
+	if err != nil {
+		return fmt.Errorf("查询回答声望流水失败 (回答ID: %d): %w", event.Payload.ID, err)
+	}
+	if err := s.revertTotals(ctx, event.Header.ID, event.Payload.QuestionID, totals); err != nil {
+		return fmt.Errorf("冲销回答声望失败 (回答ID: %d): %w", event.Payload.ID, err)
+	}
+	log.Printf("已冲销回答声望 (回答ID: %d, 流水数: %d)", event.Payload.ID, len(totals))
+	return nil
+}
+
+func (s *reputationService) handleAnswerUpvoted(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.AnswerVotedEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 AnswerVotedEvent 失败: %w", err)
+	}
+	return s.recordVote(ctx, &event, model.ReputationReasonAnswerUpvoted, PointsAnswerUpvoted)
+}
+
+func (s *reputationService) handleAnswerDownvoted(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.AnswerVotedEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 AnswerVotedEvent 失败: %w", err)
+	}
+	return s.recordVote(ctx, &event, model.ReputationReasonAnswerDownvoted, PointsAnswerDownvoted)
+}
+
+// handleAnswerVoteRetracted 处理撤销投票，按被撤销的是点赞还是点踩反向记账
+func (s *reputationService) handleAnswerVoteRetracted(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.AnswerVotedEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 AnswerVotedEvent 失败: %w", err)
+	}
+	if event.Payload.IsUpvote {
+		return s.recordVote(ctx, &event, model.ReputationReasonUpvoteRetracted, -PointsAnswerUpvoted)
+	}
+	return s.recordVote(ctx, &event, model.ReputationReasonDownvoteRetracted, -PointsAnswerDownvoted)
+}
+
+func (s *reputationService) recordVote(ctx context.Context, event *messaging.AnswerVotedEvent, reason string, points int64) error {
+	if event.Payload.VoterID == event.Payload.AuthorID {
+		// 给自己的回答投票不影响声望
+		return nil
+	}
+	err := s.userStore.RecordReputationEvents(ctx, []*model.ReputationEvent{{
+		UserID:     event.Payload.AuthorID,
+		EventID:    event.Header.ID,
+		Reason:     reason,
+		Points:     points,
+		SourceType: model.ReputationSourceAnswer,
+		SourceID:   event.Payload.AnswerID,
+		QuestionID: event.Payload.QuestionID,
+	}})
+	if err != nil {
+		return fmt.Errorf("记录投票声望失败 (回答ID: %d): %w", event.Payload.AnswerID, err)
+	}
+	return nil
+}
+
+func (s *reputationService) handleAnswerAccepted(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.AnswerEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 AnswerEvent 失败: %w", err)
+	}
+	if event.Payload.AuthorID == event.Payload.QuestionAuthorID {
+		// 采纳自己的回答不计入声望和采纳数
+		return nil
+	}
+	err := s.userStore.RecordReputationEvents(ctx, []*model.ReputationEvent{{
+		UserID:     event.Payload.AuthorID,
+		EventID:    event.Header.ID,
+		Reason:     model.ReputationReasonAnswerAccepted,
+		Points:     PointsAnswerAccepted,
+		SourceType: model.ReputationSourceAnswer,
+		SourceID:   event.Payload.ID,
+		QuestionID: event.Payload.QuestionID,
+	}})
+	if err != nil {
+		return fmt.Errorf("记录采纳声望失败 (回答ID: %d): %w", event.Payload.ID, err)
+	}
+	log.Printf("已记录采纳声望 (回答ID: %d, 用户ID: %d)", event.Payload.ID, event.Payload.AuthorID)
+	return nil
+}
+
+func (s *reputationService) handleContentFlagged(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.ContentFlaggedEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 ContentFlaggedEvent 失败: %w", err)
+	}
+	err := s.userStore.RecordReputationEvents(ctx, []*model.ReputationEvent{{
+		UserID:     event.Payload.AuthorID,
+		EventID:    event.Header.ID,
+		Reason:     model.ReputationReasonContentFlagged,
+		Points:     PointsContentFlagged,
+		SourceType: event.Payload.TargetType,
+		SourceID:   event.Payload.TargetID,
+		QuestionID: event.Payload.QuestionID,
+	}})
+	if err != nil {
+		return fmt.Errorf("记录违规扣分失败 (%s ID: %d): %w", event.Payload.TargetType, event.Payload.TargetID, err)
+	}
+	log.Printf("已记录违规扣分 (%s ID: %d, 用户ID: %d)", event.Payload.TargetType, event.Payload.TargetID, event.Payload.AuthorID)
+	return nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"qahub/pkg/config"
+	"qahub/pkg/messaging"
+	"qahub/user-service/internal/model"
+	"qahub/user-service/internal/store"
+)
+
+// FeedGroupID 是关注动态消费者组的ID，与声望消费者组相互独立
+const FeedGroupID = "feed-consumer"
+
+const (
+	defaultFeedMaxItems = 500
+	defaultFeedTTL      = 30 * 24 * time.Hour
+	// feedExcerptLength 是动态中内容摘要的最大字符数
+	feedExcerptLength = 200
+)
+
+// FeedService 消费问答事件，把新发布的问题和回答写入作者关注者的时间线 (fan-out-on-write)
+type FeedService interface {
+	RegisterHandlers() map[messaging.EventType]messaging.EventHandler
+}
+
+type feedService struct {
+	userStore store.UserStore
+	feeds     store.FeedStore
+}
+
+// NewFeedService 创建关注动态服务，store 必须支持 store.FeedStore
+func NewFeedService(userStore store.UserStore) (FeedService, error) {
+	feeds, ok := userStore.(store.FeedStore)
+	if !ok {
+		return nil, fmt.Errorf("store 不支持关注动态")
+	}
+	return &feedService{userStore: userStore, feeds: feeds}, nil
+}
+
+func (s *feedService) RegisterHandlers() map[messaging.EventType]messaging.EventHandler {
+	return map[messaging.EventType]messaging.EventHandler{
+		messaging.EventQuestionCreated: s.handleQuestionCreated,
+		messaging.EventQuestionDeleted: s.handleQuestionDeleted,
+		messaging.EventAnswerCreated:   s.handleAnswerCreated,
+		messaging.EventAnswerDeleted:   s.handleAnswerDeleted,
+	}
+}
+
+func (s *feedService) handleQuestionCreated(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.QuestionCreatedEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 QuestionCreatedEvent 失败: %w", err)
+	}
+	if event.Payload.Anonymous {
+		// 匿名内容不能让关注者看出作者
+		return nil
+	}
+	return s.fanOut(ctx, &model.FeedItem{
+		Type:       model.FeedItemQuestion,
+		QuestionID: event.Payload.ID,
+		Title:      event.Payload.Title,
+		Excerpt:    excerpt(event.Payload.Content),
+		AuthorID:   event.Payload.AuthorID,
+		AuthorName: event.Payload.AuthorName,
+		CreatedAt:  event.Payload.CreatedAt,
+	})
+}
+
+func (s *feedService) handleAnswerCreated(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.AnswerEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 AnswerEvent 失败: %w", err)
+	}
+	if event.Payload.Anonymous {
+		return nil
+	}
+	return s.fanOut(ctx, &model.FeedItem{
+		Type:       model.FeedItemAnswer,
+		QuestionID: event.Payload.QuestionID,
+		AnswerID:   event.Payload.ID,
+		Excerpt:    excerpt(event.Payload.Content),
+		AuthorID:   event.Payload.AuthorID,
+		AuthorName: event.Payload.AuthorName,
+		CreatedAt:  event.Payload.CreatedAt,
+	})
+}
+
+func (s *feedService) handleQuestionDeleted(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.QuestionDeletedEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 QuestionDeletedEvent 失败: %w", err)
+	}
+	if err := s.feeds.DeleteQuestionFeedItems(ctx, event.Payload.ID); err != nil {
+		return fmt.Errorf("删除问题动态失败 (问题ID: %d): %w", event.Payload.ID, err)
+	}
+	return nil
+}
+
+func (s *feedService) handleAnswerDeleted(ctx context.Context, eventType string, payload []byte) error {
+	var event messaging.AnswerEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("解析 AnswerEvent 失败: %w", err)
+	}
+	if err := s.feeds.DeleteFeedItem(ctx, model.FeedItemKey(model.FeedItemAnswer, event.Payload.ID)); err != nil {
+		return fmt.Errorf("删除回答动态失败 (回答ID: %d): %w", event.Payload.ID, err)
+	}
+	return nil
+}
+
+// fanOut 把动态写入作者全部关注者的时间线，没有关注者时不保存
+func (s *feedService) fanOut(ctx context.Context, item *model.FeedItem) error {
+	followers, err := s.userStore.ListFollowerIDs(ctx, item.AuthorID)
+	if err != nil {
+		return fmt.Errorf("查询关注者失败 (用户ID: %d): %w", item.AuthorID, err)
+	}
+	if len(followers) == 0 {
+		return nil
+	}
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = time.Now()
+	}
+	if err := s.feeds.AddFeedItem(ctx, item, followers, feedMaxItems(), feedTTL()); err != nil {
+		return fmt.Errorf("写入关注动态失败 (%s): %w", item.Key(), err)
+	}
+	log.Printf("已分发关注动态 (%s, 作者ID: %d, 关注者数: %d)", item.Key(), item.AuthorID, len(followers))
+	return nil
+}
+
+// excerpt 截取内容的前 feedExcerptLength 个字符作为摘要
+func excerpt(content string) string {
+	runes := []rune(content)
+	if len(runes) <= feedExcerptLength {
+		return content
+	}
+	return string(runes[:feedExcerptLength]) + "…"
+}
+
+// feedMaxItems 返回时间线最多保留的条数，未配置时使用默认值
+func feedMaxItems() int {
+	if n := config.Conf.Services.UserService.FeedMaxItems; n > 0 {
+		return n
+	}
+	return defaultFeedMaxItems
+}
+
+// feedTTL 返回动态内容和时间线的保留时间，未配置时使用默认值
+func feedTTL() time.Duration {
+	if ttl := config.Conf.Services.UserService.FeedTTL; ttl > 0 {
+		return ttl
+	}
+	return defaultFeedTTL
+}
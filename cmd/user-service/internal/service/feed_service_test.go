@@ -0,0 +1,228 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"testing"
+	"time"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/config"
+	"qahub/pkg/mail"
+	"qahub/pkg/messaging"
+	"qahub/user-service/internal/model"
+	"qahub/user-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// feedStore 在 MockUserStore 的基础上用内存实现 store.FeedStore
+type feedStore struct {
+	*service.MockUserStore
+	items     map[string]*model.FeedItem
+	timelines map[int64][]string // 按发布时间倒序排列的内容标识
+}
+
+func newFeedStore(ctrl *gomock.Controller) *feedStore {
+	return &feedStore{
+		MockUserStore: service.NewMockUserStore(ctrl),
+		items:         make(map[string]*model.FeedItem),
+		timelines:     make(map[int64][]string),
+	}
+}
+
+func (s *feedStore) AddFeedItem(ctx context.Context, item *model.FeedItem, recipientIDs []int64, maxItems int, ttl time.Duration) error {
+	s.items[item.Key()] = item
+	for _, userID := range recipientIDs {
+		timeline := append(s.timelines[userID], item.Key())
+		sort.SliceStable(timeline, func(i, j int) bool {
+			return s.createdAt(timeline[i]).After(s.createdAt(timeline[j]))
+		})
+		if len(timeline) > maxItems {
+			timeline = timeline[:maxItems]
+		}
+		s.timelines[userID] = timeline
+	}
+	return nil
+}
+
+func (s *feedStore) createdAt(key string) time.Time {
+	if item, ok := s.items[key]; ok {
+		return item.CreatedAt
+	}
+	return time.Time{}
+}
+
+func (s *feedStore) DeleteFeedItem(ctx context.Context, key string) error {
+	delete(s.items, key)
+	return nil
+}
+
+func (s *feedStore) DeleteQuestionFeedItems(ctx context.Context, questionID int64) error {
+	for key, item := range s.items {
+		if item.QuestionID == questionID {
+			delete(s.items, key)
+		}
+	}
+	return nil
+}
+
+func (s *feedStore) GetFeed(ctx context.Context, userID int64, offset, limit int) ([]*model.FeedItem, int64, error) {
+	var live []string
+	for _, key := range s.timelines[userID] {
+		if _, ok := s.items[key]; ok {
+			live = append(live, key)
+		}
+	}
+	s.timelines[userID] = live
+
+	items := []*model.FeedItem{}
+	for i := offset; i < len(live) && i < offset+limit; i++ {
+		items = append(items, s.items[live[i]])
+	}
+	return items, int64(len(live)), nil
+}
+
+func (s *feedStore) RemoveAuthorFromFeed(ctx context.Context, userID, authorID int64) error {
+	var kept []string
+	for _, key := range s.timelines[userID] {
+		if item, ok := s.items[key]; ok && item.AuthorID != authorID {
+			kept = append(kept, key)
+		}
+	}
+	s.timelines[userID] = kept
+	return nil
+}
+
+func TestFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	alice := auth.Identity{UserID: 1, Username: "alice"}
+	bob := auth.Identity{UserID: 2, Username: "bob"}
+	carol := &model.User{ID: 3, Username: "carol"}
+	dave := &model.User{ID: 4, Username: "dave"}
+
+	setup := func() (*feedStore, map[messaging.EventType]messaging.EventHandler, service.UserService) {
+		store := newFeedStore(ctrl)
+		store.EXPECT().ListFollowerIDs(gomock.Any(), carol.ID).Return([]int64{alice.UserID, bob.UserID}, nil).AnyTimes()
+		store.EXPECT().ListFollowerIDs(gomock.Any(), dave.ID).Return([]int64{alice.UserID}, nil).AnyTimes()
+		feedService, err := service.NewFeedService(store)
+		assert.NoError(t, err)
+		return store, feedService.RegisterHandlers(), service.NewUserService(store, mail.NewFileMailer(""), testSigner, testProducer, testBlobs)
+	}
+	now := time.Now()
+	publishQuestion := func(handlers map[messaging.EventType]messaging.EventHandler, id int64, author *model.User, anonymous bool, createdAt time.Time) {
+		err := handlers[messaging.EventQuestionCreated](ctx, "", mustMarshal(t, messaging.QuestionCreatedEvent{
+			Header:  messaging.EventHeader{Type: messaging.EventQuestionCreated},
+			Payload: messaging.QuestionPayload{ID: id, Title: "问题", Content: "内容", AuthorID: author.ID, AuthorName: author.Username, Anonymous: anonymous, CreatedAt: createdAt},
+		}))
+		assert.NoError(t, err)
+	}
+	publishAnswer := func(handlers map[messaging.EventType]messaging.EventHandler, id, questionID int64, author *model.User, createdAt time.Time) {
+		err := handlers[messaging.EventAnswerCreated](ctx, "", mustMarshal(t, messaging.AnswerEvent{
+			Header:  messaging.EventHeader{Type: messaging.EventAnswerCreated},
+			Payload: messaging.AnswerPayload{ID: id, QuestionID: questionID, Content: "回答", AuthorID: author.ID, AuthorName: author.Username, CreatedAt: createdAt},
+		}))
+		assert.NoError(t, err)
+	}
+
+	t.Run("新内容按发布时间倒序分发到关注者的时间线", func(t *testing.T) {
+		_, handlers, userService := setup()
+
+		publishQuestion(handlers, 10, carol, false, now.Add(-2*time.Minute))
+		publishAnswer(handlers, 20, 10, dave, now.Add(-time.Minute))
+		publishQuestion(handlers, 11, carol, true, now)
+
+		items, total, err := userService.GetFeed(ctx, alice, 1, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total, "匿名内容不分发")
+		assert.Equal(t, model.FeedItemAnswer, items[0].Type)
+		assert.Equal(t, int64(20), items[0].AnswerID)
+		assert.Equal(t, int64(10), items[1].QuestionID)
+		assert.Equal(t, "carol", items[1].AuthorName)
+
+		items, total, err = userService.GetFeed(ctx, bob, 1, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, int64(10), items[0].QuestionID)
+
+		items, _, err = userService.GetFeed(ctx, alice, 2, 1)
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Equal(t, int64(10), items[0].QuestionID)
+	})
+
+	t.Run("时间线只保留最新的若干条", func(t *testing.T) {
+		_, handlers, userService := setup()
+		config.Conf.Services.UserService.FeedMaxItems = 3
+		defer func() { config.Conf.Services.UserService.FeedMaxItems = 0 }()
+
+		for i := int64(0); i < 5; i++ {
+			publishQuestion(handlers, 100+i, carol, false, now.Add(time.Duration(i)*time.Second))
+		}
+
+		items, total, err := userService.GetFeed(ctx, alice, 1, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Equal(t, int64(104), items[0].QuestionID)
+		assert.Equal(t, int64(102), items[2].QuestionID)
+	})
+
+	t.Run("删除问题时一并移除其下回答的动态", func(t *testing.T) {
+		_, handlers, userService := setup()
+
+		publishQuestion(handlers, 10, carol, false, now.Add(-time.Minute))
+		publishAnswer(handlers, 20, 10, dave, now)
+		publishAnswer(handlers, 21, 12, dave, now)
+
+		err := handlers[messaging.EventQuestionDeleted](ctx, "", mustMarshal(t, messaging.QuestionDeletedEvent{
+			Payload: struct {
+				ID int64 `json:"id"`
+			}{ID: 10},
+		}))
+		assert.NoError(t, err)
+
+		items, total, err := userService.GetFeed(ctx, alice, 1, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, int64(21), items[0].AnswerID)
+	})
+
+	t.Run("关注用户", func(t *testing.T) {
+		store, _, userService := setup()
+
+		assert.ErrorIs(t, userService.FollowUser(ctx, alice, alice.UserID), service.ErrCannotFollowSelf)
+
+		store.EXPECT().GetUserByID(gomock.Any(), int64(99)).Return(nil, sql.ErrNoRows)
+		assert.ErrorIs(t, userService.FollowUser(ctx, alice, 99), service.ErrUserNotFound)
+
+		store.EXPECT().GetUserByID(gomock.Any(), carol.ID).Return(carol, nil).Times(2)
+		store.EXPECT().FollowUser(gomock.Any(), alice.UserID, carol.ID).Return(true, nil)
+		store.EXPECT().FollowUser(gomock.Any(), alice.UserID, carol.ID).Return(false, nil)
+		assert.NoError(t, userService.FollowUser(ctx, alice, carol.ID))
+		assert.NoError(t, userService.FollowUser(ctx, alice, carol.ID), "重复关注不报错")
+	})
+
+	t.Run("取消关注后移除该作者的动态", func(t *testing.T) {
+		store, handlers, userService := setup()
+		store.EXPECT().UnfollowUser(gomock.Any(), alice.UserID, carol.ID).Return(true, nil)
+
+		publishQuestion(handlers, 10, carol, false, now.Add(-time.Minute))
+		publishAnswer(handlers, 20, 10, dave, now)
+
+		assert.NoError(t, userService.UnfollowUser(ctx, alice, carol.ID))
+
+		items, _, err := userService.GetFeed(ctx, alice, 1, 10)
+		assert.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Equal(t, dave.ID, items[0].AuthorID)
+
+		items, _, err = userService.GetFeed(ctx, bob, 1, 10)
+		assert.NoError(t, err)
+		assert.Len(t, items, 1, "不影响其他关注者")
+	})
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+
+	"qahub/pkg/messaging"
+	"qahub/user-service/internal/model"
+	"qahub/user-service/internal/store"
+)
+
+const (
+	TopicQAEvents     = "qa_events"           // 定义消费的主题
+	ReputationGroupID = "reputation-consumer" // 定义消费者组ID
+)
+
+// 各类行为对应的声望分值
+const (
+	PointsQuestionAsked   = 2
+	PointsAnswerUpvoted   = 10
+	PointsAnswerDownvoted = -2
+	PointsAnswerAccepted  = 15
+	PointsContentFlagged  = -10
+)
+
+// ReputationService 消费问答事件，维护用户的声望流水与统计数据
+type ReputationService interface {
+	RegisterHandlers() map[messaging.EventType]messaging.EventHandler
+}
+
+type reputationService struct {
+	userStore store.UserStore
+}
+
+func NewReputationService(store store.UserStore) ReputationService {
+	return &reputationService{userStore: store}
+}
+
+// revertTotals 为被删除内容上的每一笔累计积分生成一条冲销流水。
+// 即使累计为 0 也要写入，统计字段依靠 content_removed 记录排除已删除的内容。
+func (s *reputationService) revertTotals(ctx context.Context, eventID string, questionID int64, totals []*model.ReputationTotal) error {
+	events := make([]*model.ReputationEvent, 0, len(totals))
+	for _, t := range totals {
+		events = append(events, &model.ReputationEvent{
+			UserID:     t.UserID,
+			EventID:    eventID,
+			Reason:     model.ReputationReasonContentRemoved,
+			Points:     -t.Points,
+			SourceType: t.SourceType,
+			SourceID:   t.SourceID,
+			QuestionID: questionID,
+		})
+	}
+	return s.userStore.RecordReputationEvents(ctx, events)
+}
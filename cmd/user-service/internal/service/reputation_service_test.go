@@ -0,0 +1,212 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"qahub/pkg/messaging"
+	"qahub/user-service/internal/model"
+	"qahub/user-service/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	assert.NoError(t, err)
+	return data
+}
+
+func TestReputationHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockUserStore(ctrl)
+	handlers := service.NewReputationService(mockStore).RegisterHandlers()
+	ctx := context.Background()
+
+	t.Run("提问增加声望", func(t *testing.T) {
+		payload := mustMarshal(t, messaging.QuestionCreatedEvent{
+			Header:  messaging.EventHeader{ID: "evt-1", Type: messaging.EventQuestionCreated},
+			Payload: messaging.QuestionPayload{ID: 10, AuthorID: 1},
+		})
+
+		mockStore.EXPECT().
+			RecordReputationEvents(ctx, []*model.ReputationEvent{{
+				UserID:     1,
+				EventID:    "evt-1",
+				Reason:     model.ReputationReasonQuestionAsked,
+				Points:     service.PointsQuestionAsked,
+				SourceType: model.ReputationSourceQuestion,
+				SourceID:   10,
+				QuestionID: 10,
+			}}).
+			Return(nil).
+			Times(1)
+
+		err := handlers[messaging.EventQuestionCreated](ctx, string(messaging.EventQuestionCreated), payload)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("回答被点赞和取消点赞", func(t *testing.T) {
+		vote := messaging.VotePayload{AnswerID: 20, QuestionID: 10, AuthorID: 2, VoterID: 3}
+
+		mockStore.EXPECT().
+			RecordReputationEvents(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, events []*model.ReputationEvent) error {
+				assert.Len(t, events, 1)
+				assert.Equal(t, int64(2), events[0].UserID)
+				assert.Equal(t, model.ReputationReasonAnswerUpvoted, events[0].Reason)
+				assert.Equal(t, int64(service.PointsAnswerUpvoted), events[0].Points)
+				return nil
+			}).
+			Times(1)
+		err := handlers[messaging.EventAnswerUpvoted](ctx, "", mustMarshal(t, messaging.AnswerVotedEvent{
+			Header:  messaging.EventHeader{ID: "evt-2"},
+			Payload: vote,
+		}))
+		assert.NoError(t, err)
+
+		mockStore.EXPECT().
+			RecordReputationEvents(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, events []*model.ReputationEvent) error {
+				assert.Equal(t, model.ReputationReasonUpvoteRetracted, events[0].Reason)
+				assert.Equal(t, int64(-service.PointsAnswerUpvoted), events[0].Points)
+				return nil
+			}).
+			Times(1)
+		vote.IsUpvote = true
+		err = handlers[messaging.EventAnswerVoteRetracted](ctx, "", mustMarshal(t, messaging.AnswerVotedEvent{
+			Header:  messaging.EventHeader{ID: "evt-3"},
+			Payload: vote,
+		}))
+		assert.NoError(t, err)
+	})
+
+	t.Run("回答被点踩和取消点踩", func(t *testing.T) {
+		vote := messaging.VotePayload{AnswerID: 20, QuestionID: 10, AuthorID: 2, VoterID: 3}
+
+		mockStore.EXPECT().
+			RecordReputationEvents(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, events []*model.ReputationEvent) error {
+				assert.Equal(t, int64(2), events[0].UserID)
+				assert.Equal(t, model.ReputationReasonAnswerDownvoted, events[0].Reason)
+				assert.Equal(t, int64(service.PointsAnswerDownvoted), events[0].Points)
+				assert.Negative(t, events[0].Points)
+				return nil
+			}).
+			Times(1)
+		err := handlers[messaging.EventAnswerDownvoted](ctx, "", mustMarshal(t, messaging.AnswerVotedEvent{
+			Header:  messaging.EventHeader{ID: "evt-7"},
+			Payload: vote,
+		}))
+		assert.NoError(t, err)
+
+		mockStore.EXPECT().
+			RecordReputationEvents(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, events []*model.ReputationEvent) error {
+				assert.Equal(t, model.ReputationReasonDownvoteRetracted, events[0].Reason)
+				assert.Equal(t, int64(-service.PointsAnswerDownvoted), events[0].Points)
+				return nil
+			}).
+			Times(1)
+		err = handlers[messaging.EventAnswerVoteRetracted](ctx, "", mustMarshal(t, messaging.AnswerVotedEvent{
+			Header:  messaging.EventHeader{ID: "evt-8"},
+			Payload: vote,
+		}))
+		assert.NoError(t, err)
+	})
+
+	t.Run("内容被标记违规扣除作者声望", func(t *testing.T) {
+		payload := mustMarshal(t, messaging.ContentFlaggedEvent{
+			Header: messaging.EventHeader{ID: "evt-9", Type: messaging.EventContentFlagged},
+			Payload: messaging.FlagPayload{
+				TargetType: messaging.FlagTargetAnswer,
+				TargetID:   20,
+				QuestionID: 10,
+				AuthorID:   2,
+				ReporterID: 4,
+			},
+		})
+
+		mockStore.EXPECT().
+			RecordReputationEvents(ctx, []*model.ReputationEvent{{
+				UserID:     2,
+				EventID:    "evt-9",
+				Reason:     model.ReputationReasonContentFlagged,
+				Points:     service.PointsContentFlagged,
+				SourceType: model.ReputationSourceAnswer,
+				SourceID:   20,
+				QuestionID: 10,
+			}}).
+			Return(nil).
+			Times(1)
+
+		err := handlers[messaging.EventContentFlagged](ctx, "", payload)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("给自己点赞不计声望", func(t *testing.T) {
+		payload := mustMarshal(t, messaging.AnswerVotedEvent{
+			Header:  messaging.EventHeader{ID: "evt-4"},
+			Payload: messaging.VotePayload{AnswerID: 20, AuthorID: 2, VoterID: 2},
+		})
+
+		// 不应调用 RecordReputationEvents
+		err := handlers[messaging.EventAnswerUpvoted](ctx, "", payload)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("采纳自己的回答不计声望", func(t *testing.T) {
+		payload := mustMarshal(t, messaging.AnswerEvent{
+			Header:  messaging.EventHeader{ID: "evt-5"},
+			Payload: messaging.AnswerPayload{ID: 20, QuestionID: 10, QuestionAuthorID: 2, AuthorID: 2},
+		})
+
+		err := handlers[messaging.EventAnswerAccepted](ctx, "", payload)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("删除问题冲销其下全部积分", func(t *testing.T) {
+		payload := []byte(`{"header":{"id":"evt-6","type":"question.deleted"},"payload":{"id":10}}`)
+
+		mockStore.EXPECT().
+			ListReputationTotalsByQuestion(ctx, int64(10)).
+			Return([]*model.ReputationTotal{
+				{UserID: 1, SourceType: model.ReputationSourceQuestion, SourceID: 10, Points: 2},
+				{UserID: 2, SourceType: model.ReputationSourceAnswer, SourceID: 20, Points: 25},
+			}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			RecordReputationEvents(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, events []*model.ReputationEvent) error {
+				assert.Len(t, events, 2)
+				for _, e := range events {
+					assert.Equal(t, "evt-6", e.EventID)
+					assert.Equal(t, model.ReputationReasonContentRemoved, e.Reason)
+					assert.Equal(t, int64(10), e.QuestionID)
+				}
+				assert.Equal(t, int64(-2), events[0].Points)
+				assert.Equal(t, int64(-25), events[1].Points)
+				return nil
+			}).
+			Times(1)
+
+		err := handlers[messaging.EventQuestionDeleted](ctx, "", payload)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("无法解析的事件", func(t *testing.T) {
+		err := handlers[messaging.EventAnswerCreated](ctx, "", []byte("not json"))
+
+		assert.Error(t, err)
+	})
+}
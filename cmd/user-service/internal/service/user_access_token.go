@@ -0,0 +1,326 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/log"
+	"qahub/user-service/internal/dto"
+	"qahub/user-service/internal/model"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// maxAccessTokenNameLength 是令牌名称的最大长度，与 access_tokens.name 列一致
+	maxAccessTokenNameLength = 64
+	// accessTokenHintLength 是在令牌列表中展示的令牌末尾字符数
+	accessTokenHintLength = 4
+	// accessTokenTouchInterval 是两次写入令牌最近使用时间的最短间隔，避免每个请求都写数据库
+	accessTokenTouchInterval = time.Minute
+
+	// claimAccessTokenID 是个人访问令牌身份中记录令牌ID的声明
+	claimAccessTokenID = "pat_id"
+
+	// botEmailDomain 是未指定邮箱的机器人账户使用的占位域名，.invalid 保证邮件不会被投递
+	botEmailDomain = "bots.qahub.invalid"
+)
+
+var (
+	// ErrInvalidAccessToken 表示个人访问令牌不存在、已过期或已被撤销
+	ErrInvalidAccessToken = errors.New("访问令牌无效、已过期或已被撤销")
+	// ErrAccessTokenNotFound 表示要撤销的令牌不存在或不属于指定用户
+	ErrAccessTokenNotFound = errors.New("访问令牌不存在")
+	// ErrInvalidAccessTokenName 表示令牌名称为空或过长
+	ErrInvalidAccessTokenName = fmt.Errorf("令牌名称不能为空，且不能超过 %d 个字符", maxAccessTokenNameLength)
+	// ErrInvalidScope 表示请求的权限范围为空或不受支持
+	ErrInvalidScope = fmt.Errorf("无效的权限范围，可选值为 %s", strings.Join(auth.Scopes, "、"))
+	// ErrInvalidExpiry 表示令牌有效期为负数
+	ErrInvalidExpiry = errors.New("令牌有效期不能为负数")
+	// ErrAdminScopeNotAllowed 表示普通用户请求 admin 范围的令牌
+	ErrAdminScopeNotAllowed = errors.New("只有版主和管理员可以创建 admin 范围的令牌")
+	// ErrAccessTokenForbidden 表示管理其他用户的令牌，只有管理员可以为机器人账户管理令牌
+	ErrAccessTokenForbidden = errors.New("只能管理自己或机器人账户的访问令牌")
+	// ErrBotLogin 表示机器人账户尝试通过密码或单点登录获取会话
+	ErrBotLogin = errors.New("机器人账户不能登录，请使用个人访问令牌")
+	// ErrUsernameTaken 表示用户名已被使用
+	ErrUsernameTaken = errors.New("该用户名已被使用")
+)
+
+// CreateBot 创建机器人账户，仅管理员可用。机器人账户没有可用的密码，只能通过管理员为其创建的个人访问令牌调用接口。
+func (s *userService) CreateBot(ctx context.Context, req dto.CreateBotRequest) (*dto.UserResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if existing, _ := s.userStore.GetUserByUsername(ctx, req.Username); existing != nil {
+		return nil, ErrUsernameTaken
+	}
+	email := req.Email
+	if email == "" {
+		email = strings.ToLower(req.Username) + "@" + botEmailDomain
+	}
+	if existing, _ := s.userStore.GetUserByEmail(ctx, email); existing != nil {
+		return nil, errors.New("该邮箱已被注册")
+	}
+
+	// 随机密码永远不会被使用，签发会话时也会拒绝机器人账户
+	password, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	// 机器人账户由管理员创建，邮箱视为已验证，否则无法发帖
+	now := time.Now()
+	bot := &model.User{
+		Username:        req.Username,
+		Email:           email,
+		Bio:             req.Bio,
+		Password:        string(hashedPassword),
+		UserType:        auth.UserTypeBot,
+		EmailVerifiedAt: &now,
+		CreatedAt:       now,
+	}
+	id, err := s.userStore.CreateUser(ctx, bot)
+	if err != nil {
+		logger.Error("创建机器人账户失败",
+			slog.String("username", req.Username),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	bot.ID = id
+
+	logger.Info("机器人账户已创建",
+		slog.Int64("user_id", id),
+		slog.String("username", req.Username),
+	)
+	return dto.NewUserResponse(bot), nil
+}
+
+// CreateAccessToken 创建个人访问令牌，令牌本身只在返回值中出现一次
+func (s *userService) CreateAccessToken(ctx context.Context, identity auth.Identity, req dto.CreateAccessTokenRequest) (*dto.AccessTokenCreated, error) {
+	logger := log.FromContext(ctx)
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxAccessTokenNameLength {
+		return nil, ErrInvalidAccessTokenName
+	}
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if req.ExpiresIn < 0 {
+		return nil, ErrInvalidExpiry
+	}
+
+	owner, err := s.accessTokenOwner(ctx, identity, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	// admin 范围只保留令牌所属用户原有的管理权限，对普通用户没有意义；
+	// 为自己创建时以当前令牌中的角色为准，未启用两步验证的管理员同样不能创建
+	if slices.Contains(scopes, auth.ScopeAdmin) {
+		if userRole(owner) == auth.RoleUser || !identity.IsModerator() {
+			return nil, ErrAdminScopeNotAllowed
+		}
+	}
+
+	raw, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	raw = auth.AccessTokenPrefix + raw
+
+	now := time.Now()
+	token := &model.AccessToken{
+		UserID:    owner.ID,
+		Name:      name,
+		TokenHash: hashToken(raw),
+		TokenHint: raw[len(raw)-accessTokenHintLength:],
+		Scopes:    strings.Join(scopes, " "),
+		CreatedAt: now,
+	}
+	if req.ExpiresIn > 0 {
+		expiresAt := now.Add(req.ExpiresIn)
+		token.ExpiresAt = &expiresAt
+	}
+
+	id, err := s.userStore.CreateAccessToken(ctx, token)
+	if err != nil {
+		logger.Error("保存访问令牌失败",
+			slog.Int64("user_id", owner.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	token.ID = id
+
+	logger.Info("访问令牌已创建",
+		slog.Int64("user_id", owner.ID),
+		slog.Int64("created_by", identity.UserID),
+		slog.Int64("token_id", id),
+		slog.String("scopes", token.Scopes),
+	)
+	return &dto.AccessTokenCreated{Token: raw, AccessTokenResponse: dto.NewAccessTokenResponse(token)}, nil
+}
+
+// ListAccessTokens 返回用户尚未撤销的访问令牌，userID 为 0 时为当前用户
+func (s *userService) ListAccessTokens(ctx context.Context, identity auth.Identity, userID int64) ([]*dto.AccessTokenResponse, error) {
+	owner, err := s.accessTokenOwner(ctx, identity, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.userStore.ListAccessTokens(ctx, owner.ID)
+	if err != nil {
+		log.FromContext(ctx).Error("获取访问令牌列表失败",
+			slog.Int64("user_id", owner.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	result := make([]*dto.AccessTokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		result = append(result, dto.NewAccessTokenResponse(token))
+	}
+	return result, nil
+}
+
+// RevokeAccessToken 撤销访问令牌，之后使用该令牌的请求立即失败。userID 为 0 时为当前用户。
+func (s *userService) RevokeAccessToken(ctx context.Context, identity auth.Identity, userID, tokenID int64) error {
+	logger := log.FromContext(ctx)
+
+	owner, err := s.accessTokenOwner(ctx, identity, userID)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := s.userStore.RevokeAccessToken(ctx, owner.ID, tokenID)
+	if err != nil {
+		logger.Error("撤销访问令牌失败",
+			slog.Int64("user_id", owner.ID),
+			slog.Int64("token_id", tokenID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+	if !revoked {
+		return ErrAccessTokenNotFound
+	}
+
+	logger.Info("访问令牌已撤销",
+		slog.Int64("user_id", owner.ID),
+		slog.Int64("revoked_by", identity.UserID),
+		slog.Int64("token_id", tokenID),
+	)
+	return nil
+}
+
+// accessTokenOwner 返回要管理其令牌的用户。用户只能管理自己的令牌，管理员还可以管理机器人账户的令牌。
+func (s *userService) accessTokenOwner(ctx context.Context, identity auth.Identity, userID int64) (*model.User, error) {
+	if userID != 0 && userID != identity.UserID && !identity.IsAdmin() {
+		return nil, ErrAccessTokenForbidden
+	}
+	if userID == 0 {
+		userID = identity.UserID
+	}
+
+	user, err := s.userStore.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID != identity.UserID && dto.UserType(user) != auth.UserTypeBot {
+		return nil, ErrAccessTokenForbidden
+	}
+	return user, nil
+}
+
+// validateAccessToken 校验个人访问令牌并返回其所属用户的身份。令牌没有 admin 范围时，
+// 身份中只有普通用户角色，各服务现有的角色检查因此同样限制了令牌的权限。
+func (s *userService) validateAccessToken(ctx context.Context, raw string) (auth.Identity, error) {
+	logger := log.FromContext(ctx)
+
+	token, err := s.userStore.GetAccessTokenByHash(ctx, hashToken(raw))
+	if err != nil {
+		return auth.Identity{}, fmt.Errorf("failed to get access token: %w", err)
+	}
+	now := time.Now()
+	if token == nil || !token.Active(now) {
+		return auth.Identity{}, ErrInvalidAccessToken
+	}
+
+	user, err := s.userStore.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		return auth.Identity{}, ErrInvalidAccessToken
+	}
+	if err := checkSuspended(user, now); err != nil {
+		return auth.Identity{}, err
+	}
+
+	role := auth.RoleUser
+	if slices.Contains(strings.Fields(token.Scopes), auth.ScopeAdmin) {
+		// 机器人账户无法启用两步验证，其角色由管理员直接授予
+		role = userRole(user)
+		if dto.UserType(user) != auth.UserTypeBot {
+			if role, _, err = s.tokenRole(ctx, user); err != nil {
+				return auth.Identity{}, err
+			}
+		}
+	}
+
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= accessTokenTouchInterval {
+		if err := s.userStore.TouchAccessToken(ctx, token.ID, now); err != nil {
+			logger.Warn("记录访问令牌使用时间失败",
+				slog.Int64("token_id", token.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	return auth.Identity{
+		UserID:   user.ID,
+		Username: user.Username,
+		Token:    raw,
+		Claims: map[string]any{
+			"user_id":               user.ID,
+			"username":              user.Username,
+			"role":                  role,
+			auth.ClaimEmailVerified: user.EmailVerifiedAt != nil,
+			auth.ClaimUserType:      dto.UserType(user),
+			auth.ClaimScope:         token.Scopes,
+			claimAccessTokenID:      token.ID,
+		},
+	}, nil
+}
+
+// normalizeScopes 校验权限范围并去重，按从低到高排序
+func normalizeScopes(scopes []string) ([]string, error) {
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !slices.Contains(auth.Scopes, scope) {
+			return nil, ErrInvalidScope
+		}
+		if !slices.Contains(result, scope) {
+			result = append(result, scope)
+		}
+	}
+	if len(result) == 0 {
+		return nil, ErrInvalidScope
+	}
+	slices.SortFunc(result, func(a, b string) int {
+		return slices.Index(auth.Scopes, a) - slices.Index(auth.Scopes, b)
+	})
+	return result, nil
+}
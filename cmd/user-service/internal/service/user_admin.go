@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/log"
+	"qahub/pkg/pagination"
+	"qahub/user-service/internal/dto"
+	"qahub/user-service/internal/model"
+)
+
+var (
+	// ErrUserSuspended 表示账户处于封禁状态，不能登录，也不能使用访问令牌
+	ErrUserSuspended = errors.New("账户已被封禁")
+	// ErrSuspensionReasonRequired 表示封禁用户时没有填写原因
+	ErrSuspensionReasonRequired = errors.New("封禁原因不能为空")
+	// ErrInvalidSuspensionExpiry 表示封禁截止时间早于当前时间
+	ErrInvalidSuspensionExpiry = errors.New("封禁截止时间必须晚于当前时间")
+	// ErrInvalidRole 表示指定的角色不存在
+	ErrInvalidRole = fmt.Errorf("无效的角色，可选值为 %s", strings.Join(validRoles, "、"))
+	// ErrCannotModifySelf 表示管理员尝试封禁自己或修改自己的角色，避免系统失去最后一个管理员
+	ErrCannotModifySelf = errors.New("不能对自己执行此操作")
+)
+
+// validRoles 是可以授予用户的角色，按权限从低到高排列
+var validRoles = []string{auth.RoleUser, auth.RoleModerator, auth.RoleAdmin}
+
+// checkSuspended 在用户处于封禁状态时返回带有原因和截止时间的 ErrUserSuspended
+func checkSuspended(user *model.User, now time.Time) error {
+	if !user.Suspended(now) {
+		return nil
+	}
+	until := "永久"
+	if user.SuspendedUntil != nil {
+		until = user.SuspendedUntil.Format(time.DateTime)
+	}
+	if user.SuspensionReason == "" {
+		return fmt.Errorf("%w，封禁至 %s", ErrUserSuspended, until)
+	}
+	return fmt.Errorf("%w（%s），封禁至 %s", ErrUserSuspended, user.SuspensionReason, until)
+}
+
+// ListUsers 按筛选条件分页查询用户，供管理员使用
+func (s *userService) ListUsers(ctx context.Context, filter model.UserFilter, page int64, pageSize int32) ([]*dto.AdminUserResponse, int64, error) {
+	limit, offset := pagination.CalculateOffset(page, pageSize)
+	users, total, err := s.userStore.ListUsers(ctx, filter, int(limit), int(offset))
+	if err != nil {
+		log.FromContext(ctx).Error("查询用户列表失败",
+			slog.String("error", err.Error()),
+		)
+		return nil, 0, err
+	}
+
+	now := time.Now()
+	resp := make([]*dto.AdminUserResponse, 0, len(users))
+	for _, user := range users {
+		resp = append(resp, dto.NewAdminUserResponse(user, now))
+	}
+	return resp, total, nil
+}
+
+// SuspendUser 封禁用户并撤销其全部登录会话。已签发的访问令牌随会话一起失效，
+// 个人访问令牌在校验时检查封禁状态。重复封禁会覆盖之前的原因和截止时间
+func (s *userService) SuspendUser(ctx context.Context, identity auth.Identity, req dto.SuspendUserRequest) (*dto.AdminUserResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if req.UserID == identity.UserID {
+		return nil, ErrCannotModifySelf
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, ErrSuspensionReasonRequired
+	}
+	if req.Until != nil && !req.Until.After(time.Now()) {
+		return nil, ErrInvalidSuspensionExpiry
+	}
+	if _, err := s.getTargetUser(ctx, req.UserID); err != nil {
+		return nil, err
+	}
+
+	if err := s.userStore.SuspendUser(ctx, req.UserID, req.Until, reason, identity.UserID); err != nil {
+		logger.Error("封禁用户失败",
+			slog.Int64("user_id", req.UserID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	revoked, err := s.revokeUserSessions(ctx, req.UserID, "")
+	if err != nil {
+		// 封禁已经生效，之后的刷新和登录都会被拒绝
+		logger.Error("封禁用户后撤销会话失败",
+			slog.Int64("user_id", req.UserID),
+			slog.String("error", err.Error()),
+		)
+	}
+
+	logger.Info("管理员封禁了用户",
+		slog.Int64("admin_id", identity.UserID),
+		slog.Int64("user_id", req.UserID),
+		slog.String("reason", reason),
+		slog.Int("revoked_sessions", revoked),
+	)
+	return s.adminUserResponse(ctx, req.UserID)
+}
+
+// UnsuspendUser 解除封禁，用户需要重新登录
+func (s *userService) UnsuspendUser(ctx context.Context, identity auth.Identity, userID int64) (*dto.AdminUserResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if _, err := s.getTargetUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := s.userStore.UnsuspendUser(ctx, userID); err != nil {
+		logger.Error("解除封禁失败",
+			slog.Int64("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	logger.Info("管理员解除了用户封禁",
+		slog.Int64("admin_id", identity.UserID),
+		slog.Int64("user_id", userID),
+	)
+	return s.adminUserResponse(ctx, userID)
+}
+
+// SetUserRole 修改用户角色。提升权限在用户下次刷新令牌时生效；
+// 降低权限时撤销用户的全部会话，避免旧令牌继续携带更高的角色
+func (s *userService) SetUserRole(ctx context.Context, identity auth.Identity, userID int64, role string) (*dto.AdminUserResponse, error) {
+	logger := log.FromContext(ctx)
+
+	newRank := slices.Index(validRoles, role)
+	if newRank < 0 {
+		return nil, ErrInvalidRole
+	}
+	if userID == identity.UserID {
+		return nil, ErrCannotModifySelf
+	}
+	user, err := s.getTargetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	oldRole := userRole(user)
+	if oldRole == role {
+		return dto.NewAdminUserResponse(user, time.Now()), nil
+	}
+
+	if err := s.userStore.SetUserRole(ctx, userID, role); err != nil {
+		logger.Error("修改用户角色失败",
+			slog.Int64("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	if newRank < slices.Index(validRoles, oldRole) {
+		if _, err := s.revokeUserSessions(ctx, userID, ""); err != nil {
+			logger.Error("降低用户角色后撤销会话失败",
+				slog.Int64("user_id", userID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	logger.Info("管理员修改了用户角色",
+		slog.Int64("admin_id", identity.UserID),
+		slog.Int64("user_id", userID),
+		slog.String("old_role", oldRole),
+		slog.String("new_role", role),
+	)
+	return s.adminUserResponse(ctx, userID)
+}
+
+// ForceLogout 撤销用户的全部登录会话，返回被撤销的会话数量
+func (s *userService) ForceLogout(ctx context.Context, identity auth.Identity, userID int64) (int, error) {
+	logger := log.FromContext(ctx)
+
+	if _, err := s.getTargetUser(ctx, userID); err != nil {
+		return 0, err
+	}
+	revoked, err := s.revokeUserSessions(ctx, userID, "")
+	if err != nil {
+		return revoked, err
+	}
+
+	logger.Info("管理员强制用户下线",
+		slog.Int64("admin_id", identity.UserID),
+		slog.Int64("user_id", userID),
+		slog.Int("revoked_sessions", revoked),
+	)
+	return revoked, nil
+}
+
+// getTargetUser 获取管理、关注等操作的目标用户，用户不存在时返回 ErrUserNotFound
+func (s *userService) getTargetUser(ctx context.Context, userID int64) (*model.User, error) {
+	user, err := s.userStore.GetUserByID(ctx, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+// adminUserResponse 重新读取用户，返回修改后的状态
+func (s *userService) adminUserResponse(ctx context.Context, userID int64) (*dto.AdminUserResponse, error) {
+	user, err := s.userStore.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewAdminUserResponse(user, time.Now()), nil
+}
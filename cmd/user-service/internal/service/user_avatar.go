@@ -0,0 +1,183 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/blob"
+	"qahub/pkg/config"
+	"qahub/pkg/log"
+	"qahub/user-service/internal/dto"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+const (
+	defaultAvatarMaxBytes     = 5 << 20
+	defaultAvatarMaxDimension = 4096
+	// minAvatarDimension 是头像宽高的下限，小于最小缩略图的图片放大后会明显模糊
+	minAvatarDimension = blob.AvatarSizeSmall
+)
+
+var (
+	// ErrAvatarTooLarge 表示上传的头像文件超过大小限制
+	ErrAvatarTooLarge = errors.New("头像文件过大")
+	// ErrUnsupportedAvatarType 表示头像不是 PNG、JPEG 或 WebP 图片
+	ErrUnsupportedAvatarType = errors.New("不支持的头像格式，仅支持 PNG、JPEG 和 WebP")
+	// ErrInvalidAvatarDimensions 表示头像的宽高超出允许范围
+	ErrInvalidAvatarDimensions = errors.New("头像尺寸不符合要求")
+	// ErrInvalidAvatar 表示头像文件已损坏，无法解码
+	ErrInvalidAvatar = errors.New("头像文件已损坏或无法解析")
+)
+
+// avatarCodec 描述一种允许上传的图片格式
+type avatarCodec struct {
+	decodeConfig func(io.Reader) (image.Config, error)
+	decode       func(io.Reader) (image.Image, error)
+}
+
+// avatarCodecs 以 http.DetectContentType 嗅探出的类型为键，不信任客户端声明的文件类型
+var avatarCodecs = map[string]avatarCodec{
+	"image/png":  {decodeConfig: png.DecodeConfig, decode: png.Decode},
+	"image/jpeg": {decodeConfig: jpeg.DecodeConfig, decode: jpeg.Decode},
+	"image/webp": {decodeConfig: webp.DecodeConfig, decode: webp.Decode},
+}
+
+// UploadAvatar 读取上传的图片，校验后生成固定尺寸的缩略图并替换用户当前的头像
+func (s *userService) UploadAvatar(ctx context.Context, identity auth.Identity, r io.Reader) (*dto.UserResponse, error) {
+	logger := log.FromContext(ctx)
+
+	maxBytes := avatarMaxBytes()
+	data, err := io.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("%w，不能超过 %d KB", ErrAvatarTooLarge, maxBytes/1024)
+	}
+
+	thumbnails, err := makeAvatarThumbnails(data)
+	if err != nil {
+		logger.Warn("头像校验失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.Int("size", len(data)),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	// 目录名取原图摘要，重复上传同一张图片不会产生新目录，客户端也可以长期缓存头像地址
+	sum := sha256.Sum256(data)
+	prefix := fmt.Sprintf("avatars/%d/%s", identity.UserID, hex.EncodeToString(sum[:8]))
+	for _, size := range blob.AvatarSizes {
+		if err := s.blobs.Put(ctx, blob.AvatarKey(prefix, size), thumbnails[size], "image/png"); err != nil {
+			logger.Error("保存头像失败",
+				slog.Int64("user_id", identity.UserID),
+				slog.String("avatar_key", prefix),
+				slog.String("error", err.Error()),
+			)
+			s.deleteAvatar(ctx, prefix)
+			return nil, err
+		}
+	}
+
+	previous, err := s.userStore.UpdateAvatar(ctx, identity.UserID, prefix)
+	if err != nil {
+		logger.Error("更新用户头像失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		s.deleteAvatar(ctx, prefix)
+		return nil, err
+	}
+	if previous != "" && previous != prefix {
+		s.deleteAvatar(ctx, previous)
+	}
+
+	logger.Info("用户更新了头像",
+		slog.Int64("user_id", identity.UserID),
+		slog.String("avatar_key", prefix),
+	)
+	return s.GetUserProfile(ctx, identity.UserID)
+}
+
+// deleteAvatar 尽力删除头像目录，失败只记录日志，残留的文件不会被引用
+func (s *userService) deleteAvatar(ctx context.Context, prefix string) {
+	if err := s.blobs.Delete(ctx, prefix); err != nil {
+		log.FromContext(ctx).Warn("删除头像文件失败",
+			slog.String("avatar_key", prefix),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// makeAvatarThumbnails 校验图片的格式和尺寸，并为 blob.AvatarSizes 中的每个尺寸生成正方形 PNG 缩略图
+func makeAvatarThumbnails(data []byte) (map[int][]byte, error) {
+	codec, ok := avatarCodecs[http.DetectContentType(data)]
+	if !ok {
+		return nil, ErrUnsupportedAvatarType
+	}
+
+	// 先只解析文件头中的宽高，避免为超大图片分配内存
+	cfg, err := codec.decodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidAvatar
+	}
+	maxDimension := avatarMaxDimension()
+	if cfg.Width < minAvatarDimension || cfg.Height < minAvatarDimension || cfg.Width > maxDimension || cfg.Height > maxDimension {
+		return nil, fmt.Errorf("%w，宽高需在 %d 到 %d 像素之间", ErrInvalidAvatarDimensions, minAvatarDimension, maxDimension)
+	}
+
+	img, err := codec.decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidAvatar
+	}
+
+	// 居中裁剪为正方形
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	x0 := bounds.Min.X + (bounds.Dx()-side)/2
+	y0 := bounds.Min.Y + (bounds.Dy()-side)/2
+	square := image.Rect(x0, y0, x0+side, y0+side)
+
+	thumbnails := make(map[int][]byte, len(blob.AvatarSizes))
+	for _, size := range blob.AvatarSizes {
+		dst := image.NewNRGBA(image.Rect(0, 0, size, size))
+		draw.CatmullRom.Scale(dst, dst.Bounds(), img, square, draw.Src, nil)
+
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, dst); err != nil {
+			return nil, err
+		}
+		thumbnails[size] = buf.Bytes()
+	}
+	return thumbnails, nil
+}
+
+// avatarMaxBytes 返回头像文件的大小上限，未配置时使用默认值
+func avatarMaxBytes() int64 {
+	if limit := config.Conf.Services.UserService.AvatarMaxBytes; limit > 0 {
+		return limit
+	}
+	return defaultAvatarMaxBytes
+}
+
+// avatarMaxDimension 返回头像宽高的上限，未配置时使用默认值
+func avatarMaxDimension() int {
+	if limit := config.Conf.Services.UserService.AvatarMaxDimension; limit > 0 {
+		return limit
+	}
+	return defaultAvatarMaxDimension
+}
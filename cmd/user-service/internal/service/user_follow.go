@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/log"
+	"qahub/pkg/pagination"
+	"qahub/user-service/internal/dto"
+	"qahub/user-service/internal/model"
+	"qahub/user-service/internal/store"
+)
+
+// ErrCannotFollowSelf 表示用户尝试关注自己
+var ErrCannotFollowSelf = errors.New("不能关注自己")
+
+// FollowUser 关注用户，重复关注不会报错。关注之前发布的内容不会补发到关注动态中
+func (s *userService) FollowUser(ctx context.Context, identity auth.Identity, userID int64) error {
+	logger := log.FromContext(ctx)
+
+	if userID == identity.UserID {
+		return ErrCannotFollowSelf
+	}
+	if _, err := s.getTargetUser(ctx, userID); err != nil {
+		return err
+	}
+
+	created, err := s.userStore.FollowUser(ctx, identity.UserID, userID)
+	if err != nil {
+		logger.Error("关注用户失败",
+			slog.Int64("follower_id", identity.UserID),
+			slog.Int64("followee_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+	if created {
+		logger.Info("用户关注了其他用户",
+			slog.Int64("follower_id", identity.UserID),
+			slog.Int64("followee_id", userID),
+		)
+	}
+	return nil
+}
+
+// UnfollowUser 取消关注，并从关注动态中移除该用户的内容。没有关注时不会报错
+func (s *userService) UnfollowUser(ctx context.Context, identity auth.Identity, userID int64) error {
+	logger := log.FromContext(ctx)
+
+	removed, err := s.userStore.UnfollowUser(ctx, identity.UserID, userID)
+	if err != nil {
+		logger.Error("取消关注失败",
+			slog.Int64("follower_id", identity.UserID),
+			slog.Int64("followee_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+	if !removed {
+		return nil
+	}
+
+	if feeds, ok := s.userStore.(store.FeedStore); ok {
+		// 关注关系已经删除，之后的新动态不会再写入，清理失败只影响时间线中的旧内容
+		if err := feeds.RemoveAuthorFromFeed(ctx, identity.UserID, userID); err != nil {
+			logger.Warn("从关注动态中移除内容失败",
+				slog.Int64("user_id", identity.UserID),
+				slog.Int64("author_id", userID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
+	logger.Info("用户取消了关注",
+		slog.Int64("follower_id", identity.UserID),
+		slog.Int64("followee_id", userID),
+	)
+	return nil
+}
+
+// ListFollowers 分页返回关注了 userID 的用户
+func (s *userService) ListFollowers(ctx context.Context, userID int64, page int64, pageSize int32) ([]*dto.UserResponse, int64, error) {
+	limit, offset := pagination.CalculateOffset(page, pageSize)
+	users, total, err := s.userStore.ListFollowers(ctx, userID, int(limit), int(offset))
+	if err != nil {
+		return nil, 0, err
+	}
+	return newUserResponses(users), total, nil
+}
+
+// ListFollowing 分页返回 userID 关注的用户
+func (s *userService) ListFollowing(ctx context.Context, userID int64, page int64, pageSize int32) ([]*dto.UserResponse, int64, error) {
+	limit, offset := pagination.CalculateOffset(page, pageSize)
+	users, total, err := s.userStore.ListFollowing(ctx, userID, int(limit), int(offset))
+	if err != nil {
+		return nil, 0, err
+	}
+	return newUserResponses(users), total, nil
+}
+
+// GetFeed 分页读取当前用户的关注动态。时间线由 FeedService 在内容发布时写入，只保留最新的若干条
+func (s *userService) GetFeed(ctx context.Context, identity auth.Identity, page int64, pageSize int32) ([]*model.FeedItem, int64, error) {
+	feeds, ok := s.userStore.(store.FeedStore)
+	if !ok {
+		log.FromContext(ctx).Warn("Store 不支持关注动态")
+		return []*model.FeedItem{}, 0, nil
+	}
+
+	limit, offset := pagination.CalculateOffset(page, pageSize)
+	items, total, err := feeds.GetFeed(ctx, identity.UserID, int(offset), int(limit))
+	if err != nil {
+		log.FromContext(ctx).Error("读取关注动态失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// newUserResponses 将用户列表转换为 UserResponse 列表
+func newUserResponses(users []*model.User) []*dto.UserResponse {
+	resp := make([]*dto.UserResponse, 0, len(users))
+	for _, user := range users {
+		resp = append(resp, dto.NewUserResponse(user))
+	}
+	return resp
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/i18n"
+	"qahub/pkg/log"
+	"qahub/user-service/internal/dto"
+	"qahub/user-service/internal/model"
+	"qahub/user-service/internal/store"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// 导入的用户与注册时使用相同的长度限制
+	minImportedUsernameLength = 3
+	maxImportedUsernameLength = 30
+	maxImportedBioLength      = 500
+
+	// maxImportUsernameAttempts 是用户名冲突时最多尝试的候选用户名数量
+	maxImportUsernameAttempts = 20
+)
+
+// ErrInvalidImportUser 表示导入的用户数据不符合注册时的校验规则
+var ErrInvalidImportUser = errors.New("导入的用户数据无效")
+
+// ImportUser 创建从其他实例导出的用户，由管理员在导入问答内容时调用。
+// 邮箱已存在时直接复用该用户；用户名与已有用户冲突（不区分大小写）时依次尝试追加 _2、_3 等后缀，
+// 返回实际使用的用户名。没有密码哈希的用户得到一个随机密码，需要通过找回密码设置新密码后才能登录。
+func (s *userService) ImportUser(ctx context.Context, identity auth.Identity, req dto.ImportUserRequest) (*dto.ImportUserResult, error) {
+	logger := log.FromContext(ctx)
+
+	user, err := newImportedUser(req)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.userStore.GetUserByEmail(ctx, user.Email)
+	if err == nil {
+		return &dto.ImportUserResult{UserID: existing.ID, Username: existing.Username, Reused: true}, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if user.Password == "" {
+		// 随机密码不会告知任何人，用户只能通过找回密码设置新密码
+		password, err := randomToken(32)
+		if err != nil {
+			return nil, err
+		}
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+		user.Password = string(hashedPassword)
+	}
+
+	// 用户名由唯一索引保证不重复（不区分大小写），冲突时换下一个候选用户名
+	base := user.Username
+	for attempt := 1; attempt <= maxImportUsernameAttempts; attempt++ {
+		user.Username = importUsername(base, attempt)
+		id, err := s.userStore.ImportUser(ctx, user)
+		if errors.Is(err, store.ErrDuplicateUsername) {
+			continue
+		}
+		if err != nil {
+			logger.Error("导入用户失败",
+				slog.String("username", user.Username),
+				slog.String("error", err.Error()),
+			)
+			return nil, err
+		}
+
+		logger.Info("管理员导入了用户",
+			slog.Int64("admin_id", identity.UserID),
+			slog.Int64("user_id", id),
+			slog.String("username", user.Username),
+			slog.String("original_username", base),
+		)
+		return &dto.ImportUserResult{UserID: id, Username: user.Username}, nil
+	}
+	return nil, ErrUsernameTaken
+}
+
+// newImportedUser 校验导入的用户数据，并为缺省的字段填充与注册时相同的默认值
+func newImportedUser(req dto.ImportUserRequest) (*model.User, error) {
+	username := strings.TrimSpace(req.Username)
+	if n := utf8.RuneCountInString(username); n < minImportedUsernameLength || n > maxImportedUsernameLength {
+		return nil, fmt.Errorf("%w: 用户名长度必须在 %d 到 %d 个字符之间", ErrInvalidImportUser, minImportedUsernameLength, maxImportedUsernameLength)
+	}
+	if addr, err := mail.ParseAddress(req.Email); err != nil || addr.Address != req.Email {
+		return nil, fmt.Errorf("%w: 邮箱格式不正确", ErrInvalidImportUser)
+	}
+	if utf8.RuneCountInString(req.Bio) > maxImportedBioLength {
+		return nil, fmt.Errorf("%w: 个人简介不能超过 %d 个字符", ErrInvalidImportUser, maxImportedBioLength)
+	}
+	if req.PasswordHash != "" {
+		if _, err := bcrypt.Cost([]byte(req.PasswordHash)); err != nil {
+			return nil, fmt.Errorf("%w: 密码哈希不是有效的 bcrypt 哈希", ErrInvalidImportUser)
+		}
+	}
+
+	role := req.Role
+	if role == "" {
+		role = auth.RoleUser
+	}
+	if !slices.Contains(validRoles, role) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImportUser, ErrInvalidRole)
+	}
+	userType := req.UserType
+	if userType == "" {
+		userType = auth.UserTypeHuman
+	}
+	if userType != auth.UserTypeHuman && userType != auth.UserTypeBot {
+		return nil, fmt.Errorf("%w: 无效的用户类型 %q", ErrInvalidImportUser, userType)
+	}
+	language := req.Language
+	if language == "" {
+		language = i18n.DefaultLanguage
+	}
+	if !i18n.IsSupported(language) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidImportUser, ErrUnsupportedLanguage)
+	}
+	createdAt := req.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	return &model.User{
+		Username:        username,
+		Email:           req.Email,
+		Bio:             req.Bio,
+		AvatarKey:       req.AvatarKey,
+		Password:        req.PasswordHash,
+		Role:            role,
+		UserType:        userType,
+		Language:        i18n.Normalize(language),
+		EmailVerifiedAt: req.EmailVerifiedAt,
+		CreatedAt:       createdAt,
+	}, nil
+}
+
+// importUsername 返回第 attempt 次尝试使用的用户名：第一次使用原用户名，
+// 之后追加 _2、_3 等后缀，必要时截短原用户名以保证不超过长度上限
+func importUsername(base string, attempt int) string {
+	if attempt == 1 {
+		return base
+	}
+	suffix := fmt.Sprintf("_%d", attempt)
+	runes := []rune(base)
+	if keep := maxImportedUsernameLength - len(suffix); len(runes) > keep {
+		runes = runes[:keep]
+	}
+	return string(runes) + suffix
+}
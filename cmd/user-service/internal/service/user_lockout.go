@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"qahub/pkg/config"
+	"qahub/pkg/i18n"
+	"qahub/pkg/log"
+	"qahub/user-service/internal/model"
+	"qahub/user-service/internal/store"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	defaultLoginMaxFailures   = 5
+	defaultLoginIPMaxFailures = 20
+	defaultLoginFailureWindow = time.Hour
+	defaultLoginLockout       = time.Minute
+	defaultLoginMaxLockout    = time.Hour
+)
+
+var (
+	// ErrInvalidCredentials 表示用户名或密码错误，用户不存在时返回同样的错误，避免枚举用户名
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	// ErrLoginLocked 表示登录失败次数过多，用户名或 IP 被临时锁定
+	ErrLoginLocked = errors.New("登录失败次数过多，账户已被临时锁定")
+)
+
+// loginLockedMails 是账户被临时锁定时发给用户的安全提醒，参数依次为用户名、失败次数、锁定分钟数、来源 IP
+var loginLockedMails = map[string]mailTemplate{
+	i18n.LanguageZH: {
+		subject: "QAHub 安全提醒：账户已被临时锁定",
+		body: "%s，你好：\n\n你的 QAHub 账户连续 %d 次登录失败，为保护账户安全，登录已被锁定 %d 分钟。最近一次失败的登录来自 IP %s。\n\n" +
+			"如果这不是你本人的操作，建议在锁定解除后尽快修改密码，或通过「忘记密码」重置密码。\n",
+	},
+	i18n.LanguageEN: {
+		subject: "QAHub security alert: your account has been temporarily locked",
+		body: "Hi %s,\n\nThere were %d failed sign-in attempts on your QAHub account in a row, so sign-in has been locked for %d minutes " +
+			"to protect your account. The latest failed attempt came from IP %s.\n\n" +
+			"If this wasn't you, we recommend changing your password once the lock expires, or resetting it via \"Forgot password\".\n",
+	},
+}
+
+// dummyPasswordHash 在用户不存在时参与一次 bcrypt 比较，使响应耗时与密码错误时一致
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("qahub-dummy-password"), bcrypt.DefaultCost)
+	return hash
+})
+
+// loginAttemptKeys 是一次登录请求对应的失败计数键
+type loginAttemptKeys struct {
+	username string
+	ip       string // 无法获取客户端 IP 时为空，此时只按用户名计数
+	clientIP string // 原始的客户端 IP，用于安全提醒邮件
+}
+
+func newLoginAttemptKeys(username, ip string) loginAttemptKeys {
+	keys := loginAttemptKeys{username: usernameAttemptKey(username), clientIP: ip}
+	if ip != "" {
+		keys.ip = "ip:" + ip
+	}
+	return keys
+}
+
+// usernameAttemptKey 返回用户名的失败计数键，不区分大小写，避免通过变换大小写绕过限制
+func usernameAttemptKey(username string) string {
+	return "user:" + strings.ToLower(strings.TrimSpace(username))
+}
+
+// checkLoginLock 检查用户名和 IP 是否处于锁定状态。锁定与用户是否存在无关，不会泄露用户名是否注册。
+func (s *userService) checkLoginLock(ctx context.Context, keys loginAttemptKeys) error {
+	attempts, ok := s.userStore.(store.LoginAttemptStore)
+	if !ok {
+		return nil
+	}
+
+	for _, key := range []string{keys.username, keys.ip} {
+		if key == "" {
+			continue
+		}
+		remaining, err := attempts.LoginLockRemaining(ctx, key)
+		if err != nil {
+			// Redis 故障时放行，登录本身仍需校验密码
+			log.FromContext(ctx).Error("读取登录锁定状态失败",
+				slog.String("key", key),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if remaining > 0 {
+			return fmt.Errorf("%w，请在 %d 分钟后重试", ErrLoginLocked, int(math.Ceil(remaining.Minutes())))
+		}
+	}
+	return nil
+}
+
+// recordLoginFailure 为用户名和 IP 累加失败次数，达到阈值后按指数退避锁定。
+// 用户名被锁定且用户存在时，向账户所有者发送安全提醒。
+func (s *userService) recordLoginFailure(ctx context.Context, keys loginAttemptKeys, user *model.User) {
+	logger := log.FromContext(ctx)
+
+	attempts, ok := s.userStore.(store.LoginAttemptStore)
+	if !ok {
+		return
+	}
+
+	thresholds := map[string]int{keys.username: loginMaxFailures()}
+	if keys.ip != "" {
+		thresholds[keys.ip] = loginIPMaxFailures()
+	}
+	for key, threshold := range thresholds {
+		count, err := attempts.IncrLoginFailures(ctx, key, loginFailureWindow())
+		if err != nil {
+			logger.Error("登录失败计数失败",
+				slog.String("key", key),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if count < int64(threshold) {
+			continue
+		}
+
+		lockout := loginLockoutDuration(count - int64(threshold))
+		if err := attempts.LockLogin(ctx, key, lockout); err != nil {
+			logger.Error("锁定登录失败",
+				slog.String("key", key),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		logger.Warn("登录失败次数过多，已临时锁定",
+			slog.String("key", key),
+			slog.Int64("failures", count),
+			slog.Duration("lockout", lockout),
+		)
+
+		if key == keys.username && user != nil {
+			go s.sendMail(context.WithoutCancel(ctx), user.ID, user.Email, user.Language, loginLockedMails,
+				user.Username, count, int(math.Ceil(lockout.Minutes())), keys.clientIP)
+		}
+	}
+}
+
+// clearLoginFailures 登录成功后清除用户名的失败计数。IP 的计数不清除，
+// 否则攻击者可以穿插登录自己的账户来重置同一 IP 上的计数。
+func (s *userService) clearLoginFailures(ctx context.Context, keys loginAttemptKeys) {
+	attempts, ok := s.userStore.(store.LoginAttemptStore)
+	if !ok {
+		return
+	}
+	if err := attempts.ClearLoginFailures(ctx, keys.username); err != nil {
+		log.FromContext(ctx).Error("清除登录失败计数失败",
+			slog.String("key", keys.username),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// UnlockUser 解除用户的登录锁定并清零失败计数，供管理员使用
+func (s *userService) UnlockUser(ctx context.Context, userID int64) error {
+	logger := log.FromContext(ctx)
+
+	user, err := s.userStore.GetUserByID(ctx, userID)
+	if err != nil {
+		logger.Error("解锁用户失败：获取用户失败",
+			slog.Int64("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	attempts, ok := s.userStore.(store.LoginAttemptStore)
+	if !ok {
+		logger.Warn("Store 不支持登录失败计数，无需解锁")
+		return nil
+	}
+	if err := attempts.ClearLoginFailures(ctx, usernameAttemptKey(user.Username)); err != nil {
+		logger.Error("解锁用户失败",
+			slog.Int64("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	logger.Info("用户登录锁定已解除",
+		slog.Int64("user_id", userID),
+	)
+	return nil
+}
+
+// loginLockoutDuration 返回第 n 次超出阈值时的锁定时长，从首次锁定时长开始每次翻倍，不超过上限
+func loginLockoutDuration(n int64) time.Duration {
+	lockout, maxLockout := loginLockout(), loginMaxLockout()
+	for ; n > 0 && lockout < maxLockout; n-- {
+		lockout *= 2
+	}
+	return min(lockout, maxLockout)
+}
+
+// loginMaxFailures 返回同一用户名允许的连续失败次数，未配置时使用默认值
+func loginMaxFailures() int {
+	if limit := config.Conf.Services.UserService.LoginMaxFailures; limit > 0 {
+		return limit
+	}
+	return defaultLoginMaxFailures
+}
+
+// loginIPMaxFailures 返回同一 IP 允许的失败次数，未配置时使用默认值
+func loginIPMaxFailures() int {
+	if limit := config.Conf.Services.UserService.LoginIPMaxFailures; limit > 0 {
+		return limit
+	}
+	return defaultLoginIPMaxFailures
+}
+
+// loginFailureWindow 返回失败计数的保留时间，未配置时使用默认值
+func loginFailureWindow() time.Duration {
+	if window := config.Conf.Services.UserService.LoginFailureWindow; window > 0 {
+		return window
+	}
+	return defaultLoginFailureWindow
+}
+
+// loginLockout 返回首次锁定的时长，未配置时使用默认值
+func loginLockout() time.Duration {
+	if lockout := config.Conf.Services.UserService.LoginLockout; lockout > 0 {
+		return lockout
+	}
+	return defaultLoginLockout
+}
+
+// loginMaxLockout 返回锁定时长的上限，未配置时使用默认值
+func loginMaxLockout() time.Duration {
+	if lockout := config.Conf.Services.UserService.LoginMaxLockout; lockout > 0 {
+		return lockout
+	}
+	return defaultLoginMaxLockout
+}
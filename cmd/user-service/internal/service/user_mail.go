@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"qahub/pkg/i18n"
+	"qahub/pkg/log"
+	"qahub/pkg/mail"
+)
+
+// mailTemplate 是发给用户的邮件模板，按收件人的语言偏好选择
+type mailTemplate struct {
+	subject string
+	body    string // fmt 格式串，参数由具体的邮件决定
+}
+
+// sendMail 按收件人的语言渲染模板并发送，失败只记录日志。
+// 调用方通常在独立的 goroutine 中调用，避免邮件服务的耗时影响接口响应。
+func (s *userService) sendMail(ctx context.Context, userID int64, to, language string, templates map[string]mailTemplate, args ...any) {
+	if s.mailer == nil {
+		return
+	}
+
+	tmpl, ok := templates[i18n.Normalize(language)]
+	if !ok {
+		tmpl = templates[i18n.DefaultLanguage]
+	}
+	err := s.mailer.Send(ctx, mail.Message{
+		To:      to,
+		Subject: tmpl.subject,
+		Body:    fmt.Sprintf(tmpl.body, args...),
+	})
+	if err != nil {
+		log.FromContext(ctx).Error("发送邮件失败",
+			slog.Int64("user_id", userID),
+			slog.String("subject", tmpl.subject),
+			slog.String("error", err.Error()),
+		)
+	}
+}
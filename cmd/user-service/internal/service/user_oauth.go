@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"qahub/pkg/config"
+	"qahub/pkg/log"
+	"qahub/pkg/oidc"
+	"qahub/user-service/internal/dto"
+	"qahub/user-service/internal/model"
+	"qahub/user-service/internal/store"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	defaultOAuthStateTTL = 10 * time.Minute
+	oauthHTTPTimeout     = 10 * time.Second
+	maxUsernameLength    = 32
+)
+
+var (
+	// ErrUnknownOAuthProvider 表示登录地址中的身份提供方没有配置
+	ErrUnknownOAuthProvider = errors.New("不支持的单点登录方式")
+	// ErrInvalidOAuthState 表示回调中的 state 不存在、已过期或已被使用
+	ErrInvalidOAuthState = errors.New("单点登录请求无效或已过期，请重新登录")
+	// ErrOAuthFailed 表示授权码换取令牌或 ID Token 校验失败
+	ErrOAuthFailed = errors.New("单点登录失败，请重新登录")
+	// ErrOAuthEmailRequired 表示身份提供方没有返回邮箱，无法创建用户
+	ErrOAuthEmailRequired = errors.New("身份提供方没有返回邮箱，无法完成登录")
+	// ErrOAuthAccountConflict 表示邮箱已被本地用户使用，但无法确认双方是同一个人
+	ErrOAuthAccountConflict = errors.New("该邮箱已被其他账户使用，请先使用密码登录并验证邮箱后再使用单点登录")
+)
+
+// usernameDisallowed 匹配生成用户名时需要替换掉的字符
+var usernameDisallowed = regexp.MustCompile(`[^\p{L}\p{N}_.-]+`)
+
+// oauthProviders 缓存服务发现的结果，按 issuer 索引。发现失败时不缓存，下次登录时重试。
+type oauthProviders struct {
+	client *http.Client
+
+	mu        sync.Mutex
+	providers map[string]*oidc.Provider
+}
+
+func newOAuthProviders() *oauthProviders {
+	return &oauthProviders{
+		client:    &http.Client{Timeout: oauthHTTPTimeout},
+		providers: make(map[string]*oidc.Provider),
+	}
+}
+
+func (p *oauthProviders) get(ctx context.Context, issuer string) (*oidc.Provider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if provider, ok := p.providers[issuer]; ok {
+		return provider, nil
+	}
+	provider, err := oidc.Discover(ctx, issuer, p.client)
+	if err != nil {
+		return nil, err
+	}
+	p.providers[issuer] = provider
+	return provider, nil
+}
+
+// BeginOAuthLogin 生成 state、nonce 和 PKCE 校验码，返回身份提供方的授权地址
+func (s *userService) BeginOAuthLogin(ctx context.Context, providerName string) (*dto.OAuthLoginStart, error) {
+	logger := log.FromContext(ctx)
+
+	cfg, provider, err := s.oauthProvider(ctx, providerName)
+	if err != nil {
+		return nil, err
+	}
+
+	states, ok := s.userStore.(store.OAuthStateStore)
+	if !ok {
+		logger.Error("Store 不支持单点登录")
+		return nil, errors.New("单点登录暂不可用，请稍后再试")
+	}
+
+	state, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := oidc.NewVerifier()
+	if err != nil {
+		return nil, err
+	}
+
+	record := &model.OAuthState{Provider: providerName, Nonce: nonce, Verifier: verifier}
+	if err := states.SaveOAuthState(ctx, hashToken(state), record, oauthStateTTL()); err != nil {
+		logger.Error("保存单点登录请求失败",
+			slog.String("provider", providerName),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return &dto.OAuthLoginStart{
+		AuthorizationURL: provider.AuthCodeURL(oauthConfig(cfg), state, nonce, verifier),
+		State:            state,
+	}, nil
+}
+
+// CompleteOAuthLogin 校验 state 后用授权码换取 ID Token，找到或创建对应的用户并签发令牌。
+// 用户启用了两步验证时与密码登录一样只返回挑战令牌。
+func (s *userService) CompleteOAuthLogin(ctx context.Context, providerName, code, state string) (*dto.TokenResponse, error) {
+	logger := log.FromContext(ctx)
+
+	states, ok := s.userStore.(store.OAuthStateStore)
+	if !ok {
+		return nil, ErrInvalidOAuthState
+	}
+	record, err := states.ConsumeOAuthState(ctx, hashToken(state))
+	if err != nil {
+		logger.Error("读取单点登录请求失败",
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	// state 只能在发起登录时的身份提供方使用，防止混用不同身份提供方的回调
+	if record == nil || record.Provider != providerName {
+		return nil, ErrInvalidOAuthState
+	}
+
+	cfg, provider, err := s.oauthProvider(ctx, providerName)
+	if err != nil {
+		return nil, err
+	}
+	token, err := provider.Exchange(ctx, oauthConfig(cfg), code, record.Verifier)
+	if err != nil {
+		logger.Warn("单点登录失败：换取令牌失败",
+			slog.String("provider", providerName),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("%w: %v", ErrOAuthFailed, err)
+	}
+	claims, err := provider.VerifyIDToken(ctx, cfg.ClientID, token.IDToken, record.Nonce)
+	if err != nil {
+		logger.Warn("单点登录失败：ID Token 校验失败",
+			slog.String("provider", providerName),
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("%w: %v", ErrOAuthFailed, err)
+	}
+
+	user, err := s.resolveOAuthUser(ctx, providerName, provider.Issuer, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := s.userStore.GetTwoFactor(ctx, user.ID)
+	if err != nil {
+		logger.Error("单点登录失败：获取两步验证状态失败",
+			slog.Int64("user_id", user.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	if twoFactor.Enabled() {
+		return s.startLoginChallenge(ctx, user)
+	}
+
+	logger.Info("单点登录成功",
+		slog.String("provider", providerName),
+		slog.Int64("user_id", user.ID),
+	)
+	return s.issueTokens(ctx, user, nil)
+}
+
+// resolveOAuthUser 返回外部身份对应的用户。外部身份尚未关联时，关联到邮箱相同的已有用户，
+// 或者创建一个新用户。
+func (s *userService) resolveOAuthUser(ctx context.Context, providerName, issuer string, claims *oidc.Claims) (*model.User, error) {
+	logger := log.FromContext(ctx)
+
+	identity, err := s.userStore.GetUserIdentity(ctx, issuer, claims.Subject)
+	if err != nil {
+		logger.Error("查询外部身份失败",
+			slog.String("provider", providerName),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	if identity != nil {
+		return s.userStore.GetUserByID(ctx, identity.UserID)
+	}
+
+	if claims.Email == "" {
+		return nil, ErrOAuthEmailRequired
+	}
+	identity = &model.UserIdentity{Provider: providerName, Issuer: issuer, Subject: claims.Subject, Email: claims.Email}
+
+	if existing, _ := s.userStore.GetUserByEmail(ctx, claims.Email); existing != nil {
+		// 只有双方都确认过邮箱归属时才自动关联，否则能在身份提供方使用该邮箱的人就可以接管本地账户
+		if !claims.EmailVerified || existing.EmailVerifiedAt == nil {
+			logger.Warn("单点登录失败：邮箱已被未验证的账户使用",
+				slog.String("provider", providerName),
+				slog.Int64("user_id", existing.ID),
+			)
+			return nil, ErrOAuthAccountConflict
+		}
+
+		identity.UserID = existing.ID
+		if err := s.userStore.LinkUserIdentity(ctx, identity); err != nil {
+			logger.Error("关联外部身份失败",
+				slog.Int64("user_id", existing.ID),
+				slog.String("error", err.Error()),
+			)
+			return nil, err
+		}
+		logger.Info("外部身份已关联到已有用户",
+			slog.String("provider", providerName),
+			slog.Int64("user_id", existing.ID),
+		)
+		return existing, nil
+	}
+
+	return s.provisionOAuthUser(ctx, identity, claims)
+}
+
+// provisionOAuthUser 为首次单点登录的外部身份创建用户。新用户没有可用的本地密码，
+// 需要时可以通过「忘记密码」设置。
+func (s *userService) provisionOAuthUser(ctx context.Context, identity *model.UserIdentity, claims *oidc.Claims) (*model.User, error) {
+	logger := log.FromContext(ctx)
+
+	username, err := s.oauthUsername(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	password, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &model.User{Username: username, Email: claims.Email, Password: string(hashedPassword)}
+	if claims.EmailVerified {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
+	user.ID, err = s.userStore.CreateUserWithIdentity(ctx, user, identity)
+	if err != nil {
+		// 同一外部账户并发的首次登录只有一个能创建成功，其余的使用已创建的用户
+		if existing, _ := s.userStore.GetUserIdentity(ctx, identity.Issuer, identity.Subject); existing != nil {
+			return s.userStore.GetUserByID(ctx, existing.UserID)
+		}
+		logger.Error("单点登录创建用户失败",
+			slog.String("provider", identity.Provider),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	logger.Info("单点登录自动创建用户",
+		slog.String("provider", identity.Provider),
+		slog.Int64("user_id", user.ID),
+		slog.String("username", user.Username),
+	)
+
+	if user.EmailVerifiedAt == nil {
+		if err := s.startEmailVerification(ctx, user); err != nil {
+			logger.Warn("签发邮箱验证码失败",
+				slog.Int64("user_id", user.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+	return user, nil
+}
+
+// oauthUsername 根据 ID Token 中的声明生成一个未被使用的用户名，重名时追加随机后缀
+func (s *userService) oauthUsername(ctx context.Context, claims *oidc.Claims) (string, error) {
+	base := "user"
+	emailName, _, _ := strings.Cut(claims.Email, "@")
+	for _, candidate := range []string{claims.PreferredUsername, emailName, claims.Name} {
+		if cleaned := strings.Trim(usernameDisallowed.ReplaceAllString(candidate, "_"), "_.-"); cleaned != "" {
+			base = cleaned
+			break
+		}
+	}
+	if runes := []rune(base); len(runes) > maxUsernameLength {
+		base = string(runes[:maxUsernameLength])
+	}
+
+	for i := range 5 {
+		username := base
+		if i > 0 {
+			username = fmt.Sprintf("%s_%04d", base, rand.IntN(10000))
+		}
+		if existing, _ := s.userStore.GetUserByUsername(ctx, username); existing == nil {
+			return username, nil
+		}
+	}
+	return "", errors.New("无法生成可用的用户名，请稍后再试")
+}
+
+// oauthProvider 返回身份提供方的配置和服务发现的结果
+func (s *userService) oauthProvider(ctx context.Context, name string) (config.OAuthProvider, *oidc.Provider, error) {
+	cfg, ok := config.Conf.Services.UserService.OAuthProviders[strings.ToLower(name)]
+	if !ok || cfg.Issuer == "" {
+		return cfg, nil, ErrUnknownOAuthProvider
+	}
+
+	provider, err := s.oauth.get(ctx, cfg.Issuer)
+	if err != nil {
+		log.FromContext(ctx).Error("身份提供方服务发现失败",
+			slog.String("provider", name),
+			slog.String("issuer", cfg.Issuer),
+			slog.String("error", err.Error()),
+		)
+		return cfg, nil, fmt.Errorf("%w: %v", ErrOAuthFailed, err)
+	}
+	return cfg, provider, nil
+}
+
+func oauthConfig(cfg config.OAuthProvider) oidc.Config {
+	return oidc.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		RedirectURL:  cfg.RedirectURL,
+		Scopes:       cfg.Scopes,
+	}
+}
+
+// oauthStateTTL 返回单点登录请求的有效期，未配置时使用默认值
+func oauthStateTTL() time.Duration {
+	if ttl := config.Conf.Services.UserService.OAuthStateTTL; ttl > 0 {
+		return ttl
+	}
+	return defaultOAuthStateTTL
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/config"
+	"qahub/pkg/i18n"
+	"qahub/pkg/log"
+	"qahub/user-service/internal/store"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	minPasswordLength = 6
+	maxPasswordLength = 72 // bcrypt 只使用密码的前 72 个字节
+
+	defaultPasswordResetTTL   = 30 * time.Minute
+	defaultPasswordResetLimit = 5
+	passwordResetWindow       = time.Hour
+)
+
+var (
+	// ErrIncorrectPassword 表示修改密码时提供的当前密码不正确
+	ErrIncorrectPassword = errors.New("当前密码不正确")
+	// ErrInvalidPassword 表示新密码不满足长度要求
+	ErrInvalidPassword = fmt.Errorf("密码长度需要在 %d 到 %d 个字节之间", minPasswordLength, maxPasswordLength)
+	// ErrInvalidResetToken 表示重置码不存在、已过期或已被使用
+	ErrInvalidResetToken = errors.New("重置码无效或已过期")
+	// ErrTooManyRequests 表示密码重置请求过于频繁
+	ErrTooManyRequests = errors.New("请求过于频繁，请稍后再试")
+)
+
+// passwordResetMails 是密码重置邮件的模板，参数依次为用户名、有效分钟数、重置码
+var passwordResetMails = map[string]mailTemplate{
+	i18n.LanguageZH: {
+		subject: "重置你的 QAHub 密码",
+		body: "%s，你好：\n\n我们收到了重置你的 QAHub 账户密码的请求。请在客户端的「忘记密码」页面输入以下重置码，" +
+			"重置码 %d 分钟内有效，且只能使用一次：\n\n%s\n\n如果这不是你本人的操作，请忽略这封邮件，你的密码不会改变。\n",
+	},
+	i18n.LanguageEN: {
+		subject: "Reset your QAHub password",
+		body: "Hi %s,\n\nWe received a request to reset the password of your QAHub account. Enter the following code on the " +
+			"\"Forgot password\" page of the client. It is valid for %d minutes and can only be used once:\n\n%s\n\n" +
+			"If you did not request this, you can ignore this email and your password will stay the same.\n",
+	},
+}
+
+// ChangePassword 校验当前密码后修改密码，并让其他设备上的会话全部下线
+func (s *userService) ChangePassword(ctx context.Context, identity auth.Identity, currentPassword, newPassword string) error {
+	logger := log.FromContext(ctx)
+
+	if err := validatePassword(newPassword); err != nil {
+		return err
+	}
+
+	user, err := s.userStore.GetUserByID(ctx, identity.UserID)
+	if err != nil {
+		logger.Error("修改密码失败：获取用户失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)) != nil {
+		logger.Warn("修改密码失败：当前密码错误",
+			slog.Int64("user_id", identity.UserID),
+		)
+		return ErrIncorrectPassword
+	}
+
+	if err := s.setPassword(ctx, user.ID, newPassword); err != nil {
+		return err
+	}
+
+	currentID, _ := identity.GetStringClaim(claimSessionID)
+	revoked, err := s.revokeUserSessions(ctx, user.ID, currentID)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("用户已修改密码",
+		slog.Int64("user_id", user.ID),
+		slog.Int("revoked_sessions", revoked),
+	)
+	return nil
+}
+
+// RequestPasswordReset 为邮箱对应的用户签发重置码并发送邮件。
+// 无论邮箱是否注册都返回相同的结果，邮件在后台发送，避免通过响应内容或耗时探测邮箱是否存在。
+func (s *userService) RequestPasswordReset(ctx context.Context, email string) error {
+	logger := log.FromContext(ctx)
+
+	resetStore, ok := s.userStore.(store.PasswordResetStore)
+	if !ok {
+		logger.Warn("Store 不支持密码重置")
+		return nil
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	_, ip, _ := sessionClientInfo(ctx)
+
+	// 限流对已注册和未注册的邮箱一视同仁，同样不会泄露邮箱是否存在
+	limitKeys := []string{"password_reset:email:" + email}
+	if ip != "" {
+		limitKeys = append(limitKeys, "password_reset:ip:"+ip)
+	}
+	for _, key := range limitKeys {
+		count, err := resetStore.IncrRateLimit(ctx, key, passwordResetWindow)
+		if err != nil {
+			logger.Error("密码重置限流计数失败",
+				slog.String("error", err.Error()),
+			)
+			return err
+		}
+		if count > int64(passwordResetLimit()) {
+			logger.Warn("密码重置请求过于频繁",
+				slog.String("key", key),
+			)
+			return ErrTooManyRequests
+		}
+	}
+
+	user, err := s.userStore.GetUserByEmail(ctx, email)
+	if err != nil || user == nil {
+		logger.Info("密码重置请求的邮箱未注册或查询失败",
+			slog.Any("error", err),
+		)
+		return nil
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return err
+	}
+	ttl := passwordResetTTL()
+	if err := resetStore.SavePasswordResetToken(ctx, hashToken(token), user.ID, ttl); err != nil {
+		logger.Error("保存密码重置令牌失败",
+			slog.Int64("user_id", user.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil
+	}
+
+	go s.sendMail(context.WithoutCancel(ctx), user.ID, user.Email, user.Language, passwordResetMails,
+		user.Username, int(ttl.Minutes()), token)
+	return nil
+}
+
+// ConfirmPasswordReset 使用重置码设置新密码，成功后该用户的所有会话都会下线
+func (s *userService) ConfirmPasswordReset(ctx context.Context, token, newPassword string) error {
+	logger := log.FromContext(ctx)
+
+	// 先校验新密码，避免因密码格式错误白白消耗一次性的重置码
+	if err := validatePassword(newPassword); err != nil {
+		return err
+	}
+
+	resetStore, ok := s.userStore.(store.PasswordResetStore)
+	if !ok {
+		return ErrInvalidResetToken
+	}
+
+	userID, err := resetStore.ConsumePasswordResetToken(ctx, hashToken(strings.TrimSpace(token)))
+	if err != nil {
+		logger.Error("读取密码重置令牌失败",
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+	if userID == 0 {
+		return ErrInvalidResetToken
+	}
+
+	if err := s.setPassword(ctx, userID, newPassword); err != nil {
+		return err
+	}
+
+	revoked, err := s.revokeUserSessions(ctx, userID, "")
+	if err != nil {
+		return err
+	}
+
+	logger.Info("用户已通过重置码重置密码",
+		slog.Int64("user_id", userID),
+		slog.Int("revoked_sessions", revoked),
+	)
+	return nil
+}
+
+// setPassword 哈希并保存新密码
+func (s *userService) setPassword(ctx context.Context, userID int64, password string) error {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err := s.userStore.UpdatePassword(ctx, userID, string(hashed)); err != nil {
+		log.FromContext(ctx).Error("更新密码失败",
+			slog.Int64("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+	return nil
+}
+
+// validatePassword 检查密码长度是否符合要求
+func validatePassword(password string) error {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return ErrInvalidPassword
+	}
+	return nil
+}
+
+// passwordResetTTL 返回重置码的有效期，未配置时使用默认值
+func passwordResetTTL() time.Duration {
+	if ttl := config.Conf.Services.UserService.PasswordResetTTL; ttl > 0 {
+		return ttl
+	}
+	return defaultPasswordResetTTL
+}
+
+// passwordResetLimit 返回每小时允许的重置请求数，未配置时使用默认值
+func passwordResetLimit() int {
+	if limit := config.Conf.Services.UserService.PasswordResetLimit; limit > 0 {
+		return limit
+	}
+	return defaultPasswordResetLimit
+}
@@ -5,19 +5,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"slices"
 	"strings"
 	"time"
 
 	"qahub/pkg/auth"
-	"qahub/pkg/config"
+	"qahub/pkg/blob"
+	"qahub/pkg/i18n"
 	"qahub/pkg/log"
+	"qahub/pkg/mail"
+	"qahub/pkg/messaging"
 	"qahub/user-service/internal/dto"
 	"qahub/user-service/internal/model"
 	"qahub/user-service/internal/store"
 
-	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -25,23 +28,68 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// ErrUnsupportedLanguage 表示用户设置的语言偏好不受支持
+var ErrUnsupportedLanguage = fmt.Errorf("不支持的语言，可选值为 %s", strings.Join(i18n.SupportedLanguages, "、"))
+
 type UserService interface {
 	Register(ctx context.Context, req dto.RegisterRequest) (*dto.UserResponse, error)
-	Login(ctx context.Context, username, password string) (string, error)
-	Logout(ctx context.Context, tokenString string, claims jwt.MapClaims) error
+	Login(ctx context.Context, username, password string) (*dto.TokenResponse, error)
+	Verify2FA(ctx context.Context, challengeToken, code string) (*dto.TokenResponse, error)
+	Enroll2FA(ctx context.Context, identity auth.Identity) (*dto.TwoFactorEnrollment, error)
+	Confirm2FA(ctx context.Context, identity auth.Identity, code string) ([]string, error)
+	Disable2FA(ctx context.Context, identity auth.Identity, code string) error
+	TwoFactorEnabled(ctx context.Context, userID int64) (bool, error)
+	BeginOAuthLogin(ctx context.Context, provider string) (*dto.OAuthLoginStart, error)
+	CompleteOAuthLogin(ctx context.Context, provider, code, state string) (*dto.TokenResponse, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*dto.TokenResponse, error)
+	Logout(ctx context.Context, identity auth.Identity) error
 	ValidateToken(ctx context.Context, tokenString string) (auth.Identity, error)
+	GetJWKS(ctx context.Context) auth.JWKS
+	CreateBot(ctx context.Context, req dto.CreateBotRequest) (*dto.UserResponse, error)
+	CreateAccessToken(ctx context.Context, identity auth.Identity, req dto.CreateAccessTokenRequest) (*dto.AccessTokenCreated, error)
+	ListAccessTokens(ctx context.Context, identity auth.Identity, userID int64) ([]*dto.AccessTokenResponse, error)
+	RevokeAccessToken(ctx context.Context, identity auth.Identity, userID, tokenID int64) error
+	UnlockUser(ctx context.Context, userID int64) error
+	ListUsers(ctx context.Context, filter model.UserFilter, page int64, pageSize int32) ([]*dto.AdminUserResponse, int64, error)
+	SuspendUser(ctx context.Context, identity auth.Identity, req dto.SuspendUserRequest) (*dto.AdminUserResponse, error)
+	UnsuspendUser(ctx context.Context, identity auth.Identity, userID int64) (*dto.AdminUserResponse, error)
+	SetUserRole(ctx context.Context, identity auth.Identity, userID int64, role string) (*dto.AdminUserResponse, error)
+	ForceLogout(ctx context.Context, identity auth.Identity, userID int64) (int, error)
+	ImportUser(ctx context.Context, identity auth.Identity, req dto.ImportUserRequest) (*dto.ImportUserResult, error)
+	ListSessions(ctx context.Context, identity auth.Identity) ([]*dto.SessionResponse, error)
+	RevokeSession(ctx context.Context, identity auth.Identity, sessionID string) error
+	RevokeAllOtherSessions(ctx context.Context, identity auth.Identity) (int, error)
+	ChangePassword(ctx context.Context, identity auth.Identity, currentPassword, newPassword string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ConfirmPasswordReset(ctx context.Context, token, newPassword string) error
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, identity auth.Identity) error
 	AuthUnaryServerInterceptor(publicMethods ...string) grpc.UnaryServerInterceptor
+	AuthStreamServerInterceptor(publicMethods ...string) grpc.StreamServerInterceptor
 	GetUserProfile(ctx context.Context, userID int64) (*dto.UserResponse, error)
+	GetUserByUsername(ctx context.Context, username string) (*dto.UserResponse, error)
+	ChangeUsername(ctx context.Context, userID int64, newUsername string) (*dto.UserResponse, error)
+	UploadAvatar(ctx context.Context, identity auth.Identity, r io.Reader) (*dto.UserResponse, error)
+	FollowUser(ctx context.Context, identity auth.Identity, userID int64) error
+	UnfollowUser(ctx context.Context, identity auth.Identity, userID int64) error
+	ListFollowers(ctx context.Context, userID int64, page int64, pageSize int32) ([]*dto.UserResponse, int64, error)
+	ListFollowing(ctx context.Context, userID int64, page int64, pageSize int32) ([]*dto.UserResponse, int64, error)
+	GetFeed(ctx context.Context, identity auth.Identity, page int64, pageSize int32) ([]*model.FeedItem, int64, error)
 	UpdateUserProfile(ctx context.Context, user *model.User) error
 	DeleteUser(ctx context.Context, userID int64) error
 }
 
 type userService struct {
 	userStore store.UserStore
+	mailer    mail.Mailer        // 发送邮箱验证、密码重置等邮件
+	oauth     *oauthProviders    // 单点登录身份提供方的服务发现缓存
+	signer    *auth.Signer       // 签发和校验访问令牌，公钥通过 GetJWKS 发布
+	producer  messaging.Producer // 发布用户资料更新事件
+	blobs     blob.Store         // 保存头像缩略图等上传文件
 }
 
-func NewUserService(store store.UserStore) UserService {
-	return &userService{userStore: store}
+func NewUserService(store store.UserStore, mailer mail.Mailer, signer *auth.Signer, producer messaging.Producer, blobs blob.Store) UserService {
+	return &userService{userStore: store, mailer: mailer, oauth: newOAuthProviders(), signer: signer, producer: producer, blobs: blobs}
 }
 
 func (s *userService) Register(ctx context.Context, req dto.RegisterRequest) (*dto.UserResponse, error) {
@@ -67,7 +115,11 @@ func (s *userService) Register(ctx context.Context, req dto.RegisterRequest) (*d
 	// 使用 ToUser 方法转换
 	newUser := req.ToUser(string(hashedPassword))
 
+	// 用户名由唯一索引保证不重复（不区分大小写）
 	newID, err := s.userStore.CreateUser(ctx, newUser)
+	if errors.Is(err, store.ErrDuplicateUsername) {
+		return nil, ErrUsernameTaken
+	}
 	if err != nil {
 		logger.Error("创建用户失败",
 			slog.String("username", req.Username),
@@ -84,19 +136,40 @@ func (s *userService) Register(ctx context.Context, req dto.RegisterRequest) (*d
 		slog.String("username", req.Username),
 	)
 
+	// 验证邮件发送失败不影响注册，用户之后可以重新发送
+	if err := s.startEmailVerification(ctx, newUser); err != nil {
+		logger.Warn("签发邮箱验证码失败",
+			slog.Int64("user_id", newID),
+			slog.String("error", err.Error()),
+		)
+	}
+
 	// 使用 NewUserResponse 方法转换
 	return dto.NewUserResponse(newUser), nil
 }
 
-func (s *userService) Login(ctx context.Context, username, password string) (string, error) {
+func (s *userService) Login(ctx context.Context, username, password string) (*dto.TokenResponse, error) {
 	logger := log.FromContext(ctx)
 
+	_, ip, _ := sessionClientInfo(ctx)
+	keys := newLoginAttemptKeys(username, ip)
+	if err := s.checkLoginLock(ctx, keys); err != nil {
+		logger.Warn("登录失败：已被临时锁定",
+			slog.String("username", username),
+			slog.String("ip", ip),
+		)
+		return nil, err
+	}
+
 	user, err := s.userStore.GetUserByUsername(ctx, username)
 	if err != nil {
+		// 用户不存在时同样执行一次 bcrypt 比较，响应耗时和错误与密码错误时一致
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
 		logger.Warn("登录失败：用户不存在",
 			slog.String("username", username),
 		)
-		return "", errors.New("invalid username or password")
+		s.recordLoginFailure(ctx, keys, nil)
+		return nil, ErrInvalidCredentials
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
@@ -104,70 +177,41 @@ func (s *userService) Login(ctx context.Context, username, password string) (str
 		logger.Warn("登录失败：密码错误",
 			slog.String("username", username),
 		)
-		return "", errors.New("invalid username or password")
+		s.recordLoginFailure(ctx, keys, user)
+		return nil, ErrInvalidCredentials
 	}
 
-	claims := jwt.MapClaims{
-		"user_id":  user.ID,
-		"username": user.Username,
-		"exp":      time.Now().Add(time.Hour * time.Duration(config.Conf.Services.UserService.TokenExpireHours)).Unix(), // token于72小时后过期
-		"iat":      time.Now().Unix(),                                                                                   // token的签发时间
+	// 密码正确后才提示封禁，避免通过错误信息探测账户状态
+	if err := checkSuspended(user, time.Now()); err != nil {
+		logger.Warn("登录失败：账户已被封禁",
+			slog.Int64("user_id", user.ID),
+		)
+		return nil, err
 	}
 
-	var jwtSecret = []byte(config.Conf.Services.UserService.JWTSecret)
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtSecret)
+	twoFactor, err := s.userStore.GetTwoFactor(ctx, user.ID)
 	if err != nil {
-		logger := log.FromContext(ctx)
-		logger.Error("生成 Token 失败",
+		logger.Error("登录失败：获取两步验证状态失败",
+			slog.Int64("user_id", user.ID),
 			slog.String("error", err.Error()),
 		)
-		return "", err
-	}
-
-	return tokenString, nil
-}
-
-// Logout 将 token 加入黑名单
-func (s *userService) Logout(ctx context.Context, tokenString string, claims jwt.MapClaims) error {
-	logger := log.FromContext(ctx)
-
-	blacklister, ok := s.userStore.(store.TokenBlacklister)
-	if !ok {
-		logger.Warn("Store 不支持 Token 黑名单")
-		return nil
-	}
-	exp, ok := claims["exp"].(float64)
-	if !ok {
-		logger.Error("无效的 Token 过期时间")
-		return errors.New("invalid token expiration")
+		return nil, err
 	}
-
-	remaining := time.Until(time.Unix(int64(exp), 0))
-	if remaining <= 0 {
-		return nil
+	if twoFactor.Enabled() {
+		// 失败计数在第二步验证通过后才清除
+		return s.startLoginChallenge(ctx, user)
 	}
 
-	return blacklister.AddToBlacklist(ctx, tokenString, remaining)
+	s.clearLoginFailures(ctx, keys)
+	return s.issueTokens(ctx, user, nil)
 }
 
-func (s *userService) ValidateToken(ctx context.Context, tokenString string) (auth.Identity, error) {
-	blacklister, hasBlacklist := s.userStore.(store.TokenBlacklister)
-	if hasBlacklist {
-		isBlacklisted, err := blacklister.IsBlacklisted(ctx, tokenString)
-		if err != nil {
-			return auth.Identity{}, fmt.Errorf("failed to check blacklist: %w", err)
-		}
-		if isBlacklisted {
-			return auth.Identity{}, errors.New("token is blacklisted")
-		}
+// userRole 返回写入 token 的角色，缓存中的旧用户数据可能没有角色字段
+func userRole(user *model.User) string {
+	if user.Role == "" {
+		return auth.RoleUser
 	}
-	identity, err := auth.ParseToken(tokenString, []byte(config.Conf.Services.UserService.JWTSecret))
-	if err != nil {
-		return auth.Identity{}, fmt.Errorf("token parsing error: %w", err)
-	}
-
-	return identity, nil
+	return user.Role
 }
 
 func (s *userService) GetUserProfile(ctx context.Context, userID int64) (*dto.UserResponse, error) {
@@ -194,7 +238,24 @@ func (s *userService) GetUserProfile(ctx context.Context, userID int64) (*dto.Us
 func (s *userService) UpdateUserProfile(ctx context.Context, user *model.User) error {
 	logger := log.FromContext(ctx)
 
-	err := s.userStore.UpdateUser(ctx, user)
+	if user.Language != "" {
+		if !i18n.IsSupported(user.Language) {
+			return ErrUnsupportedLanguage
+		}
+		user.Language = i18n.Normalize(user.Language)
+	}
+
+	current, err := s.userStore.GetUserByID(ctx, user.ID)
+	if err != nil {
+		logger.Error("更新用户资料失败：获取用户失败",
+			slog.Int64("user_id", user.ID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	// store 在邮箱变化时会清除验证状态
+	err = s.userStore.UpdateUser(ctx, user)
 	if err != nil {
 		logger.Error("更新用户资料失败",
 			slog.Int64("user_id", user.ID),
@@ -203,6 +264,22 @@ func (s *userService) UpdateUserProfile(ctx context.Context, user *model.User) e
 		return err
 	}
 
+	if user.Email != "" && !strings.EqualFold(user.Email, current.Email) {
+		logger.Info("用户修改了邮箱，需要重新验证",
+			slog.Int64("user_id", user.ID),
+		)
+		updated := *user
+		if updated.Language == "" {
+			updated.Language = current.Language
+		}
+		if err := s.startEmailVerification(ctx, &updated); err != nil {
+			logger.Warn("签发邮箱验证码失败",
+				slog.Int64("user_id", user.ID),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+
 	logger.Debug("用户资料更新成功",
 		slog.Int64("user_id", user.ID),
 		slog.String("username", user.Username),
@@ -238,32 +315,65 @@ func (s *userService) AuthUnaryServerInterceptor(publicMethods ...string) grpc.U
 			return handler(ctx, req)
 		}
 
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Errorf(codes.Unauthenticated, "缺少认证信息 (metadata)")
+		newCtx, err := s.authenticate(ctx)
+		if err != nil {
+			return nil, err
 		}
 
-		authHeaders := md.Get("authorization")
-		if len(authHeaders) == 0 {
-			return nil, status.Errorf(codes.Unauthenticated, "请求未包含授权标头")
-		}
+		// 继续处理请求
+		return handler(newCtx, req)
+	}
+}
 
-		authHeader := authHeaders[0]
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		if tokenString == authHeader { // 如果没有 "Bearer " 前缀，TrimPrefix 不会改变字符串
-			return nil, status.Errorf(codes.Unauthenticated, "授权标头格式不正确，需要 'Bearer ' 前缀")
+// AuthStreamServerInterceptor 是 AuthUnaryServerInterceptor 的流式版本，用于上传头像等客户端流式调用
+func (s *userService) AuthStreamServerInterceptor(publicMethods ...string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if slices.Contains(publicMethods, info.FullMethod) {
+			return handler(srv, ss)
 		}
 
-		// 调用 user-service 的 ValidateToken 方法验证 token
-		validateResp, err := s.ValidateToken(ctx, tokenString)
+		newCtx, err := s.authenticate(ss.Context())
 		if err != nil {
-			return nil, status.Errorf(codes.Unauthenticated, "token 验证失败: %v", err)
+			return err
 		}
+		return handler(srv, &authServerStream{ServerStream: ss, ctx: newCtx})
+	}
+}
 
-		// 验证成功，将用户信息注入到 context 中
-		newCtx := auth.WithIdentity(ctx, validateResp)
+// authServerStream 包装 grpc.ServerStream，使处理函数能从上下文中取得调用者身份
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
 
-		// 继续处理请求
-		return handler(newCtx, req)
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate 从 metadata 中取出 Bearer token 并校验，成功时返回注入了调用者身份的上下文
+func (s *userService) authenticate(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "缺少认证信息 (metadata)")
 	}
+
+	authHeaders := md.Get("authorization")
+	if len(authHeaders) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "请求未包含授权标头")
+	}
+
+	authHeader := authHeaders[0]
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+	if tokenString == authHeader { // 如果没有 "Bearer " 前缀，TrimPrefix 不会改变字符串
+		return nil, status.Errorf(codes.Unauthenticated, "授权标头格式不正确，需要 'Bearer ' 前缀")
+	}
+
+	// 调用 user-service 的 ValidateToken 方法验证 token
+	validateResp, err := s.ValidateToken(ctx, tokenString)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "token 验证失败: %v", err)
+	}
+
+	// 验证成功，将用户信息注入到 context 中
+	return auth.WithIdentity(ctx, validateResp), nil
 }
@@ -1,25 +1,73 @@
 package service_test
 
 import (
+	"bytes"
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
-
+	"time"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/blob"
+	"qahub/pkg/config"
+	"qahub/pkg/mail"
+	"qahub/pkg/messaging"
+	"qahub/pkg/oidc/oidctest"
+	"qahub/pkg/totp"
 	"qahub/user-service/internal/dto"
 	"qahub/user-service/internal/model"
 	"qahub/user-service/internal/service"
+	"qahub/user-service/internal/store"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
+// testSigner 签发和校验测试中的访问令牌
+var testSigner = func() *auth.Signer {
+	key, err := auth.GenerateSigningKey()
+	if err != nil {
+		panic(err)
+	}
+	signer, err := auth.NewSigner(key.ID, key)
+	if err != nil {
+		panic(err)
+	}
+	return signer
+}()
+
+// testProducer 没有配置 broker，发布事件会失败，只记录日志
+var testProducer = messaging.NewKafkaProducer(config.Conf.Kafka)
+
+// testBlobs 把上传的文件写到系统临时目录，需要检查文件内容的测试应使用 t.TempDir() 创建自己的 Store
+var testBlobs = func() blob.Store {
+	blobs, err := blob.NewLocalStore(filepath.Join(os.TempDir(), "qahub-test-blobs"), "http://localhost/blobs")
+	if err != nil {
+		panic(err)
+	}
+	return blobs
+}()
+
 func TestRegister(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockStore := service.NewMockUserStore(ctrl)
-	userService := service.NewUserService(mockStore)
+	userService := service.NewUserService(mockStore, mail.NewFileMailer(""), testSigner, testProducer, testBlobs)
 	ctx := context.Background()
 
 	t.Run("成功注册新用户", func(t *testing.T) {
@@ -127,7 +175,7 @@ func TestLogin(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockStore := service.NewMockUserStore(ctrl)
-	userService := service.NewUserService(mockStore)
+	userService := service.NewUserService(mockStore, mail.NewFileMailer(""), testSigner, testProducer, testBlobs)
 	ctx := context.Background()
 
 	// 准备测试数据：哈希密码
@@ -149,13 +197,19 @@ func TestLogin(t *testing.T) {
 			GetUserByUsername(ctx, username).
 			Return(user, nil).
 			Times(1)
+		// Mock: 用户没有启用两步验证
+		mockStore.EXPECT().
+			GetTwoFactor(ctx, user.ID).
+			Return(nil, nil).
+			Times(1)
 
 		// 执行测试
-		token, err := userService.Login(ctx, username, password)
+		tokens, err := userService.Login(ctx, username, password)
 
 		// 验证结果
 		assert.NoError(t, err)
-		assert.NotEmpty(t, token)
+		assert.NotEmpty(t, tokens.AccessToken)
+		assert.Empty(t, tokens.RefreshToken, "store 不支持刷新令牌时只签发访问令牌")
 	})
 
 	t.Run("用户名不存在", func(t *testing.T) {
@@ -169,11 +223,11 @@ func TestLogin(t *testing.T) {
 			Times(1)
 
 		// 执行测试
-		token, err := userService.Login(ctx, username, password)
+		tokens, err := userService.Login(ctx, username, password)
 
 		// 验证结果
 		assert.Error(t, err)
-		assert.Empty(t, token)
+		assert.Nil(t, tokens)
 		assert.Equal(t, "invalid username or password", err.Error())
 	})
 
@@ -195,147 +249,1427 @@ func TestLogin(t *testing.T) {
 			Times(1)
 
 		// 执行测试
-		token, err := userService.Login(ctx, username, password)
+		tokens, err := userService.Login(ctx, username, password)
 
 		// 验证结果
 		assert.Error(t, err)
-		assert.Empty(t, token)
+		assert.Nil(t, tokens)
 		assert.Equal(t, "invalid username or password", err.Error())
 	})
 }
 
-func TestGetUserProfile(t *testing.T) {
+// requestFrom 模拟从 remoteAddr 建立的连接上收到的请求，kv 是请求携带的 metadata
+func requestFrom(ctx context.Context, remoteAddr string, kv ...string) context.Context {
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: net.TCPAddrFromAddrPort(netip.MustParseAddrPort(remoteAddr))})
+	return metadata.NewIncomingContext(ctx, metadata.Pairs(kv...))
+}
+
+func TestLoginLockout(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockStore := service.NewMockUserStore(ctrl)
-	userService := service.NewUserService(mockStore)
+	config.Conf.Services.UserService.LoginMaxFailures = 3
+	config.Conf.Services.UserService.LoginIPMaxFailures = 5
+	config.Conf.Services.UserService.LoginLockout = time.Minute
+	config.Conf.Services.UserService.LoginMaxLockout = 3 * time.Minute
+	config.Conf.Services.UserService.TrustedProxies = []string{"10.0.0.0/8"}
+	defer func() { config.Conf.Services.UserService.TrustedProxies = nil }()
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	user := &model.User{ID: 1, Username: "testuser", Email: "test@example.com", Password: string(hashedPassword)}
+
+	setup := func() (*sessionStore, service.UserService, *captureMailer) {
+		mockStore := newSessionStore(ctrl)
+		mockStore.EXPECT().GetUserByUsername(gomock.Any(), user.Username).Return(user, nil).AnyTimes()
+		mockStore.EXPECT().GetUserByUsername(gomock.Any(), gomock.Not(user.Username)).Return(nil, errors.New("user not found")).AnyTimes()
+		mockStore.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil).AnyTimes()
+		mailer := &captureMailer{sent: make(chan mail.Message, 10)}
+		return mockStore, service.NewUserService(mockStore, mailer, testSigner, testProducer, testBlobs), mailer
+	}
 	ctx := context.Background()
 
-	t.Run("成功获取用户信息", func(t *testing.T) {
-		userID := int64(1)
-		user := &model.User{
-			ID:       userID,
-			Username: "testuser",
-			Email:    "test@example.com",
-			Bio:      "Test bio",
-			Password: "hashedpassword",
+	t.Run("连续失败后锁定并发送安全提醒", func(t *testing.T) {
+		_, userService, mailer := setup()
+
+		for i := 0; i < 3; i++ {
+			_, err := userService.Login(ctx, user.Username, "wrongpassword")
+			assert.ErrorIs(t, err, service.ErrInvalidCredentials)
 		}
+		msg := <-mailer.sent
+		assert.Equal(t, user.Email, msg.To)
+		assert.Equal(t, "QAHub 安全提醒：账户已被临时锁定", msg.Subject)
 
-		// Mock: 根据ID查找用户
-		mockStore.EXPECT().
-			GetUserByID(ctx, userID).
-			Return(user, nil).
-			Times(1)
+		_, err := userService.Login(ctx, user.Username, "password123")
+		assert.ErrorIs(t, err, service.ErrLoginLocked, "锁定期间密码正确也不能登录")
+	})
 
-		// 执行测试
-		resp, err := userService.GetUserProfile(ctx, userID)
+	t.Run("不存在的用户名返回相同的错误并同样被锁定", func(t *testing.T) {
+		_, userService, mailer := setup()
 
-		// 验证结果
+		for i := 0; i < 3; i++ {
+			_, err := userService.Login(ctx, "nobody", "wrongpassword")
+			assert.ErrorIs(t, err, service.ErrInvalidCredentials)
+		}
+		_, err := userService.Login(ctx, "nobody", "wrongpassword")
+		assert.ErrorIs(t, err, service.ErrLoginLocked)
+		select {
+		case msg := <-mailer.sent:
+			t.Fatalf("不应发送邮件: %+v", msg)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("锁定到期后再次失败，锁定时长翻倍且不超过上限", func(t *testing.T) {
+		mockStore, userService, _ := setup()
+
+		for i := 0; i < 3; i++ {
+			_, _ = userService.Login(ctx, user.Username, "wrongpassword")
+		}
+		assert.Equal(t, time.Minute, mockStore.locks["user:testuser"])
+
+		for _, want := range []time.Duration{2 * time.Minute, 3 * time.Minute, 3 * time.Minute} {
+			delete(mockStore.locks, "user:testuser") // 模拟锁定到期
+			_, _ = userService.Login(ctx, user.Username, "wrongpassword")
+			assert.Equal(t, want, mockStore.locks["user:testuser"])
+		}
+	})
+
+	t.Run("用户名的大小写变化不能绕过计数", func(t *testing.T) {
+		mockStore, userService, _ := setup()
+
+		for _, name := range []string{"TestUser", "TESTUSER", "testuser"} {
+			_, _ = userService.Login(ctx, name, "wrongpassword")
+		}
+		assert.Equal(t, int64(3), mockStore.failures["user:testuser"])
+	})
+
+	t.Run("同一 IP 尝试多个用户名后锁定该 IP", func(t *testing.T) {
+		_, userService, _ := setup()
+		ipCtx := requestFrom(ctx, "10.0.0.2:40000", "x-forwarded-for", "203.0.113.7")
+
+		for i := 0; i < 5; i++ {
+			_, err := userService.Login(ipCtx, fmt.Sprintf("user%d", i), "wrongpassword")
+			assert.ErrorIs(t, err, service.ErrInvalidCredentials)
+		}
+		_, err := userService.Login(ipCtx, user.Username, "password123")
+		assert.ErrorIs(t, err, service.ErrLoginLocked)
+
+		// 其他 IP 不受影响
+		_, err = userService.Login(requestFrom(ctx, "10.0.0.2:40000", "x-forwarded-for", "203.0.113.8"), user.Username, "password123")
 		assert.NoError(t, err)
-		assert.NotNil(t, resp)
-		assert.Equal(t, userID, resp.ID)
-		assert.Equal(t, "testuser", resp.Username)
-		assert.Equal(t, "test@example.com", resp.Email)
-		assert.Equal(t, "Test bio", resp.Bio)
 	})
 
-	t.Run("用户不存在", func(t *testing.T) {
-		userID := int64(999)
+	t.Run("不受信任的连接伪造 X-Forwarded-For 不能绕过 IP 锁定", func(t *testing.T) {
+		_, userService, _ := setup()
 
-		// Mock: 用户不存在
-		mockStore.EXPECT().
-			GetUserByID(ctx, userID).
-			Return(nil, errors.New("user not found")).
-			Times(1)
+		for i := 0; i < 5; i++ {
+			spoofed := requestFrom(ctx, "198.51.100.9:40000", "x-forwarded-for", fmt.Sprintf("203.0.113.%d", i))
+			_, err := userService.Login(spoofed, fmt.Sprintf("user%d", i), "wrongpassword")
+			assert.ErrorIs(t, err, service.ErrInvalidCredentials)
+		}
+		_, err := userService.Login(requestFrom(ctx, "198.51.100.9:40000", "x-forwarded-for", "203.0.113.99"), user.Username, "password123")
+		assert.ErrorIs(t, err, service.ErrLoginLocked)
+	})
 
-		// 执行测试
-		resp, err := userService.GetUserProfile(ctx, userID)
+	t.Run("代理转发时客户端自己填写的 X-Forwarded-For 不被采信", func(t *testing.T) {
+		_, userService, _ := setup()
 
-		// 验证结果
+		for i := 0; i < 5; i++ {
+			// 代理把真实的客户端地址追加在末尾，前面的部分由客户端填写
+			spoofed := requestFrom(ctx, "10.0.0.2:40000", "x-forwarded-for", fmt.Sprintf("203.0.113.%d, 198.51.100.9", i))
+			_, err := userService.Login(spoofed, fmt.Sprintf("user%d", i), "wrongpassword")
+			assert.ErrorIs(t, err, service.ErrInvalidCredentials)
+		}
+		_, err := userService.Login(requestFrom(ctx, "10.0.0.2:40000", "x-forwarded-for", "198.51.100.9"), user.Username, "password123")
+		assert.ErrorIs(t, err, service.ErrLoginLocked)
+	})
+
+	t.Run("管理员解锁后可以登录，登录成功清零计数", func(t *testing.T) {
+		mockStore, userService, _ := setup()
+
+		for i := 0; i < 3; i++ {
+			_, _ = userService.Login(ctx, user.Username, "wrongpassword")
+		}
+		assert.NoError(t, userService.UnlockUser(ctx, user.ID))
+
+		_, err := userService.Login(ctx, user.Username, "password123")
+		assert.NoError(t, err)
+		assert.Zero(t, mockStore.failures["user:testuser"])
+	})
+}
+
+// sessionStore 在 MockUserStore 的基础上提供内存版的会话和刷新令牌存储
+type sessionStore struct {
+	*service.MockUserStore
+	sessions map[string]*model.Session
+	tokens   map[string]*model.RefreshToken
+	used     map[string]bool
+	revoked  map[string]bool
+	resets   map[string]int64
+	limits   map[string]int64
+	verifies map[string]*model.EmailVerificationToken
+	failures map[string]int64
+	locks    map[string]time.Duration
+
+	twoFactors    map[int64]*model.TwoFactor
+	recoveryCodes map[int64]map[string]bool // 恢复码哈希 -> 是否已使用
+	challenges    map[string]int64
+	usedSteps     map[string]bool
+
+	oauthStates map[string]*model.OAuthState
+
+	accessTokens map[int64]*model.AccessToken
+}
+
+func newSessionStore(ctrl *gomock.Controller) *sessionStore {
+	return &sessionStore{
+		MockUserStore: service.NewMockUserStore(ctrl),
+		sessions:      make(map[string]*model.Session),
+		tokens:        make(map[string]*model.RefreshToken),
+		used:          make(map[string]bool),
+		revoked:       make(map[string]bool),
+		resets:        make(map[string]int64),
+		limits:        make(map[string]int64),
+		verifies:      make(map[string]*model.EmailVerificationToken),
+		failures:      make(map[string]int64),
+		locks:         make(map[string]time.Duration),
+		twoFactors:    make(map[int64]*model.TwoFactor),
+		recoveryCodes: make(map[int64]map[string]bool),
+		challenges:    make(map[string]int64),
+		usedSteps:     make(map[string]bool),
+		oauthStates:   make(map[string]*model.OAuthState),
+		accessTokens:  make(map[int64]*model.AccessToken),
+	}
+}
+
+func (s *sessionStore) CreateAccessToken(ctx context.Context, token *model.AccessToken) (int64, error) {
+	stored := *token
+	stored.ID = int64(len(s.accessTokens) + 1)
+	s.accessTokens[stored.ID] = &stored
+	return stored.ID, nil
+}
+
+func (s *sessionStore) GetAccessTokenByHash(ctx context.Context, tokenHash string) (*model.AccessToken, error) {
+	for _, token := range s.accessTokens {
+		if token.TokenHash == tokenHash {
+			copied := *token
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *sessionStore) ListAccessTokens(ctx context.Context, userID int64) ([]*model.AccessToken, error) {
+	var result []*model.AccessToken
+	for id := int64(1); id <= int64(len(s.accessTokens)); id++ {
+		if token := s.accessTokens[id]; token.UserID == userID && token.RevokedAt == nil {
+			result = append(result, token)
+		}
+	}
+	return result, nil
+}
+
+func (s *sessionStore) RevokeAccessToken(ctx context.Context, userID, tokenID int64) (bool, error) {
+	token, ok := s.accessTokens[tokenID]
+	if !ok || token.UserID != userID || token.RevokedAt != nil {
+		return false, nil
+	}
+	now := time.Now()
+	token.RevokedAt = &now
+	return true, nil
+}
+
+func (s *sessionStore) TouchAccessToken(ctx context.Context, tokenID int64, usedAt time.Time) error {
+	s.accessTokens[tokenID].LastUsedAt = &usedAt
+	return nil
+}
+
+func (s *sessionStore) SaveOAuthState(ctx context.Context, stateHash string, state *model.OAuthState, expiration time.Duration) error {
+	s.oauthStates[stateHash] = state
+	return nil
+}
+
+func (s *sessionStore) ConsumeOAuthState(ctx context.Context, stateHash string) (*model.OAuthState, error) {
+	state := s.oauthStates[stateHash]
+	delete(s.oauthStates, stateHash)
+	return state, nil
+}
+
+func (s *sessionStore) GetTwoFactor(ctx context.Context, userID int64) (*model.TwoFactor, error) {
+	return s.twoFactors[userID], nil
+}
+
+func (s *sessionStore) SaveTwoFactorSecret(ctx context.Context, userID int64, secret string) error {
+	if !s.twoFactors[userID].Enabled() {
+		s.twoFactors[userID] = &model.TwoFactor{UserID: userID, Secret: secret}
+	}
+	return nil
+}
+
+func (s *sessionStore) EnableTwoFactor(ctx context.Context, userID int64, codeHashes []string) error {
+	now := time.Now()
+	s.twoFactors[userID].EnabledAt = &now
+	s.recoveryCodes[userID] = make(map[string]bool)
+	for _, hash := range codeHashes {
+		s.recoveryCodes[userID][hash] = false
+	}
+	return nil
+}
+
+func (s *sessionStore) DeleteTwoFactor(ctx context.Context, userID int64) error {
+	delete(s.twoFactors, userID)
+	delete(s.recoveryCodes, userID)
+	return nil
+}
+
+func (s *sessionStore) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	used, ok := s.recoveryCodes[userID][codeHash]
+	if !ok || used {
+		return false, nil
+	}
+	s.recoveryCodes[userID][codeHash] = true
+	return true, nil
+}
+
+func (s *sessionStore) SaveLoginChallenge(ctx context.Context, tokenHash string, userID int64, expiration time.Duration) error {
+	s.challenges[tokenHash] = userID
+	return nil
+}
+
+func (s *sessionStore) GetLoginChallenge(ctx context.Context, tokenHash string) (int64, error) {
+	return s.challenges[tokenHash], nil
+}
+
+func (s *sessionStore) DeleteLoginChallenge(ctx context.Context, tokenHash string) (bool, error) {
+	_, ok := s.challenges[tokenHash]
+	delete(s.challenges, tokenHash)
+	return ok, nil
+}
+
+func (s *sessionStore) MarkTOTPStepUsed(ctx context.Context, userID int64, step int64, expiration time.Duration) (bool, error) {
+	key := fmt.Sprintf("%d:%d", userID, step)
+	if s.usedSteps[key] {
+		return false, nil
+	}
+	s.usedSteps[key] = true
+	return true, nil
+}
+
+func (s *sessionStore) IncrLoginFailures(ctx context.Context, key string, window time.Duration) (int64, error) {
+	s.failures[key]++
+	return s.failures[key], nil
+}
+
+func (s *sessionStore) LockLogin(ctx context.Context, key string, duration time.Duration) error {
+	s.locks[key] = duration
+	return nil
+}
+
+// LoginLockRemaining 返回锁定时设置的时长，测试中删除 locks 中的键来模拟锁定到期
+func (s *sessionStore) LoginLockRemaining(ctx context.Context, key string) (time.Duration, error) {
+	return s.locks[key], nil
+}
+
+func (s *sessionStore) ClearLoginFailures(ctx context.Context, key string) error {
+	delete(s.failures, key)
+	delete(s.locks, key)
+	return nil
+}
+
+func (s *sessionStore) SaveEmailVerificationToken(ctx context.Context, tokenHash string, token *model.EmailVerificationToken, expiration time.Duration) error {
+	s.verifies[tokenHash] = token
+	return nil
+}
+
+func (s *sessionStore) ConsumeEmailVerificationToken(ctx context.Context, tokenHash string) (*model.EmailVerificationToken, error) {
+	token := s.verifies[tokenHash]
+	delete(s.verifies, tokenHash)
+	return token, nil
+}
+
+func (s *sessionStore) SavePasswordResetToken(ctx context.Context, tokenHash string, userID int64, expiration time.Duration) error {
+	s.resets[tokenHash] = userID
+	return nil
+}
+
+func (s *sessionStore) ConsumePasswordResetToken(ctx context.Context, tokenHash string) (int64, error) {
+	userID := s.resets[tokenHash]
+	delete(s.resets, tokenHash)
+	return userID, nil
+}
+
+func (s *sessionStore) IncrRateLimit(ctx context.Context, key string, window time.Duration) (int64, error) {
+	s.limits[key]++
+	return s.limits[key], nil
+}
+
+func (s *sessionStore) SaveSession(ctx context.Context, session *model.Session, expiration time.Duration) error {
+	copied := *session
+	s.sessions[session.ID] = &copied
+	return nil
+}
+
+func (s *sessionStore) GetSession(ctx context.Context, sessionID string) (*model.Session, error) {
+	session, ok := s.sessions[sessionID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (s *sessionStore) ListSessions(ctx context.Context, userID int64) ([]*model.Session, error) {
+	var list []*model.Session
+	for _, session := range s.sessions {
+		if session.UserID == userID {
+			copied := *session
+			list = append(list, &copied)
+		}
+	}
+	return list, nil
+}
+
+func (s *sessionStore) RevokeSession(ctx context.Context, userID int64, sessionID string, expiration time.Duration) error {
+	s.revoked[sessionID] = true
+	delete(s.sessions, sessionID)
+	return nil
+}
+
+func (s *sessionStore) IsSessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	return s.revoked[sessionID], nil
+}
+
+func (s *sessionStore) SaveRefreshToken(ctx context.Context, token *model.RefreshToken, expiration time.Duration) error {
+	s.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (s *sessionStore) GetRefreshToken(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	return s.tokens[tokenHash], nil
+}
+
+func (s *sessionStore) MarkRefreshTokenUsed(ctx context.Context, tokenHash string, expiration time.Duration) (bool, error) {
+	if s.used[tokenHash] {
+		return false, nil
+	}
+	s.used[tokenHash] = true
+	return true, nil
+}
+
+func TestRefreshToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correctpassword"), bcrypt.DefaultCost)
+	user := &model.User{
+		ID:       1,
+		Username: "testuser",
+		Password: string(hashedPassword),
+	}
+
+	// 每个子测试使用独立的 store，避免令牌族状态互相影响
+	setup := func() (service.UserService, context.Context) {
+		mockStore := newSessionStore(ctrl)
+		mockStore.EXPECT().GetUserByUsername(gomock.Any(), user.Username).Return(user, nil).AnyTimes()
+		mockStore.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil).AnyTimes()
+		return service.NewUserService(mockStore, mail.NewFileMailer(""), testSigner, testProducer, testBlobs), context.Background()
+	}
+
+	t.Run("每次刷新都会轮换刷新令牌", func(t *testing.T) {
+		userService, ctx := setup()
+
+		login, err := userService.Login(ctx, user.Username, "correctpassword")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, login.RefreshToken)
+		assert.True(t, login.ExpiresAt.After(time.Now()))
+
+		refreshed, err := userService.RefreshToken(ctx, login.RefreshToken)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, refreshed.AccessToken)
+		assert.NotEqual(t, login.RefreshToken, refreshed.RefreshToken)
+
+		// 新旧访问令牌属于同一个会话
+		oldIdentity, err := userService.ValidateToken(ctx, login.AccessToken)
+		assert.NoError(t, err)
+		newIdentity, err := userService.ValidateToken(ctx, refreshed.AccessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, oldIdentity.Claims["sid"], newIdentity.Claims["sid"])
+		assert.NotEqual(t, oldIdentity.Claims["jti"], newIdentity.Claims["jti"])
+		assert.Equal(t, user.ID, newIdentity.UserID)
+	})
+
+	t.Run("重用已轮换的刷新令牌会撤销整个会话", func(t *testing.T) {
+		userService, ctx := setup()
+
+		login, err := userService.Login(ctx, user.Username, "correctpassword")
+		assert.NoError(t, err)
+		refreshed, err := userService.RefreshToken(ctx, login.RefreshToken)
+		assert.NoError(t, err)
+
+		// 旧令牌再次出现，视为泄露
+		_, err = userService.RefreshToken(ctx, login.RefreshToken)
+		assert.ErrorIs(t, err, service.ErrRefreshTokenReused)
+
+		// 合法持有者手中的最新令牌也一并失效
+		_, err = userService.RefreshToken(ctx, refreshed.RefreshToken)
+		assert.ErrorIs(t, err, service.ErrInvalidRefreshToken)
+		_, err = userService.ValidateToken(ctx, refreshed.AccessToken)
 		assert.Error(t, err)
-		assert.Nil(t, resp)
-		assert.Equal(t, "user not found", err.Error())
 	})
 
-	t.Run("数据库错误", func(t *testing.T) {
-		userID := int64(1)
+	t.Run("未知的刷新令牌", func(t *testing.T) {
+		userService, ctx := setup()
 
-		// Mock: 数据库错误
-		mockStore.EXPECT().
-			GetUserByID(ctx, userID).
-			Return(nil, errors.New("database connection error")).
-			Times(1)
+		tokens, err := userService.RefreshToken(ctx, "not-a-real-token")
+		assert.ErrorIs(t, err, service.ErrInvalidRefreshToken)
+		assert.Nil(t, tokens)
+	})
 
-		// 执行测试
-		resp, err := userService.GetUserProfile(ctx, userID)
+	t.Run("登出撤销当前会话", func(t *testing.T) {
+		userService, ctx := setup()
 
-		// 验证结果
+		login, err := userService.Login(ctx, user.Username, "correctpassword")
+		assert.NoError(t, err)
+		identity, err := userService.ValidateToken(ctx, login.AccessToken)
+		assert.NoError(t, err)
+
+		err = userService.Logout(ctx, identity)
+		assert.NoError(t, err)
+
+		_, err = userService.RefreshToken(ctx, login.RefreshToken)
+		assert.ErrorIs(t, err, service.ErrInvalidRefreshToken)
+		_, err = userService.ValidateToken(ctx, login.AccessToken)
 		assert.Error(t, err)
-		assert.Nil(t, resp)
 	})
 }
 
-func TestUpdateUserProfile(t *testing.T) {
+func TestSessions(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockStore := service.NewMockUserStore(ctrl)
-	userService := service.NewUserService(mockStore)
+	config.Conf.Services.UserService.TrustedProxies = []string{"10.0.0.0/8"}
+	defer func() { config.Conf.Services.UserService.TrustedProxies = nil }()
+
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correctpassword"), bcrypt.DefaultCost)
+	user := &model.User{ID: 1, Username: "testuser", Password: string(hashedPassword)}
+	other := &model.User{ID: 2, Username: "other", Password: string(hashedPassword)}
+
+	mockStore := newSessionStore(ctrl)
+	mockStore.EXPECT().GetUserByUsername(gomock.Any(), user.Username).Return(user, nil).AnyTimes()
+	mockStore.EXPECT().GetUserByUsername(gomock.Any(), other.Username).Return(other, nil).AnyTimes()
+	userService := service.NewUserService(mockStore, mail.NewFileMailer(""), testSigner, testProducer, testBlobs)
+
+	// 模拟从不同设备登录，设备信息通过 gRPC metadata 传入
+	loginFrom := func(u *model.User, device string) auth.Identity {
+		ctx := requestFrom(context.Background(), "10.0.0.2:40000",
+			"x-device-name", device,
+			"user-agent", "qahub-test",
+			"x-forwarded-for", "203.0.113.7, 10.0.0.1",
+		)
+		tokens, err := userService.Login(ctx, u.Username, "correctpassword")
+		assert.NoError(t, err)
+		identity, err := userService.ValidateToken(ctx, tokens.AccessToken)
+		assert.NoError(t, err)
+		return identity
+	}
+
 	ctx := context.Background()
+	laptop := loginFrom(user, "laptop")
+	phone := loginFrom(user, "phone")
+	tablet := loginFrom(user, "tablet")
+	otherIdentity := loginFrom(other, "other-laptop")
 
-	t.Run("成功更新用户信息", func(t *testing.T) {
-		user := &model.User{
-			ID:       1,
-			Username: "testuser",
-			Email:    "updated@example.com",
-			Bio:      "Updated bio",
+	t.Run("列出会话并标记当前会话", func(t *testing.T) {
+		sessions, err := userService.ListSessions(ctx, laptop)
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 3)
+
+		var current int
+		for _, session := range sessions {
+			assert.Equal(t, "203.0.113.7", session.IP)
+			assert.Equal(t, "qahub-test", session.UserAgent)
+			if session.Current {
+				current++
+				assert.Equal(t, "laptop", session.Device)
+			}
 		}
+		assert.Equal(t, 1, current)
+	})
 
-		// Mock: 更新用户成功
-		mockStore.EXPECT().
-			UpdateUser(ctx, user).
-			Return(nil).
-			Times(1)
-
-		// 执行测试
-		err := userService.UpdateUserProfile(ctx, user)
+	t.Run("不能撤销其他用户的会话", func(t *testing.T) {
+		sid, _ := otherIdentity.GetStringClaim("sid")
+		err := userService.RevokeSession(ctx, laptop, sid)
+		assert.ErrorIs(t, err, service.ErrSessionNotFound)
 
-		// 验证结果
+		_, err = userService.ValidateToken(ctx, otherIdentity.Token)
 		assert.NoError(t, err)
 	})
 
-	t.Run("更新用户失败", func(t *testing.T) {
-		user := &model.User{
-			ID:       1,
-			Username: "testuser",
-			Email:    "updated@example.com",
-		}
+	t.Run("撤销指定会话后该会话的访问令牌失效", func(t *testing.T) {
+		sid, _ := phone.GetStringClaim("sid")
+		err := userService.RevokeSession(ctx, laptop, sid)
+		assert.NoError(t, err)
 
-		// Mock: 更新失败
-		mockStore.EXPECT().
-			UpdateUser(ctx, user).
-			Return(errors.New("update failed")).
-			Times(1)
+		_, err = userService.ValidateToken(ctx, phone.Token)
+		assert.Error(t, err)
+		_, err = userService.ValidateToken(ctx, laptop.Token)
+		assert.NoError(t, err)
+	})
 
-		// 执行测试
-		err := userService.UpdateUserProfile(ctx, user)
+	t.Run("撤销其他所有会话只保留当前会话", func(t *testing.T) {
+		count, err := userService.RevokeAllOtherSessions(ctx, laptop)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, count)
 
-		// 验证结果
+		_, err = userService.ValidateToken(ctx, tablet.Token)
 		assert.Error(t, err)
-		assert.Equal(t, "update failed", err.Error())
+		_, err = userService.ValidateToken(ctx, laptop.Token)
+		assert.NoError(t, err)
+
+		sessions, err := userService.ListSessions(ctx, laptop)
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 1)
+		assert.True(t, sessions[0].Current)
 	})
+}
 
-	t.Run("数据库连接错误", func(t *testing.T) {
-		user := &model.User{
-			ID:       1,
-			Username: "testuser",
-		}
+// captureMailer 记录发送的邮件，邮件在后台发送，因此使用 channel 等待
+type captureMailer struct {
+	sent chan mail.Message
+}
+
+func (m *captureMailer) Send(ctx context.Context, msg mail.Message) error {
+	m.sent <- msg
+	return nil
+}
+
+func TestChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("oldpassword"), bcrypt.DefaultCost)
+	user := &model.User{ID: 1, Username: "testuser", Password: string(hashedPassword)}
+
+	mockStore := newSessionStore(ctrl)
+	mockStore.EXPECT().GetUserByUsername(gomock.Any(), user.Username).Return(user, nil).AnyTimes()
+	mockStore.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil).AnyTimes()
+	userService := service.NewUserService(mockStore, mail.NewFileMailer(""), testSigner, testProducer, testBlobs)
+	ctx := context.Background()
+
+	login := func() auth.Identity {
+		tokens, err := userService.Login(ctx, user.Username, "oldpassword")
+		assert.NoError(t, err)
+		identity, err := userService.ValidateToken(ctx, tokens.AccessToken)
+		assert.NoError(t, err)
+		return identity
+	}
+
+	t.Run("新密码太短", func(t *testing.T) {
+		err := userService.ChangePassword(ctx, login(), "oldpassword", "123")
+		assert.ErrorIs(t, err, service.ErrInvalidPassword)
+	})
+
+	t.Run("当前密码错误", func(t *testing.T) {
+		err := userService.ChangePassword(ctx, login(), "wrongpassword", "newpassword")
+		assert.ErrorIs(t, err, service.ErrIncorrectPassword)
+	})
+
+	t.Run("修改成功后其他设备下线", func(t *testing.T) {
+		current := login()
+		other := login()
 
-		// Mock: 数据库连接错误
 		mockStore.EXPECT().
-			UpdateUser(ctx, user).
-			Return(errors.New("database connection error")).
+			UpdatePassword(gomock.Any(), user.ID, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, id int64, hashed string) error {
+				assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(hashed), []byte("newpassword")))
+				return nil
+			}).
+			Times(1)
+
+		err := userService.ChangePassword(ctx, current, "oldpassword", "newpassword")
+		assert.NoError(t, err)
+
+		_, err = userService.ValidateToken(ctx, current.Token)
+		assert.NoError(t, err)
+		_, err = userService.ValidateToken(ctx, other.Token)
+		assert.Error(t, err)
+	})
+}
+
+func TestPasswordReset(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config.Conf.Services.UserService.PasswordResetLimit = 3
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("oldpassword"), bcrypt.DefaultCost)
+	user := &model.User{ID: 1, Username: "testuser", Email: "test@example.com", Password: string(hashedPassword), Language: "en"}
+	tokenPattern := regexp.MustCompile(`[A-Za-z0-9_-]{43}`)
+
+	setup := func() (*sessionStore, service.UserService, *captureMailer) {
+		mockStore := newSessionStore(ctrl)
+		mockStore.EXPECT().GetUserByEmail(gomock.Any(), user.Email).Return(user, nil).AnyTimes()
+		mockStore.EXPECT().GetUserByEmail(gomock.Any(), gomock.Not(user.Email)).Return(nil, errors.New("user not found")).AnyTimes()
+		mockStore.EXPECT().GetUserByUsername(gomock.Any(), user.Username).Return(user, nil).AnyTimes()
+		mailer := &captureMailer{sent: make(chan mail.Message, 10)}
+		return mockStore, service.NewUserService(mockStore, mailer, testSigner, testProducer, testBlobs), mailer
+	}
+	ctx := context.Background()
+
+	t.Run("未注册的邮箱同样返回成功且不发送邮件", func(t *testing.T) {
+		_, userService, mailer := setup()
+
+		err := userService.RequestPasswordReset(ctx, "nobody@example.com")
+		assert.NoError(t, err)
+		select {
+		case msg := <-mailer.sent:
+			t.Fatalf("不应发送邮件: %+v", msg)
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	t.Run("重置码只能使用一次且会下线所有会话", func(t *testing.T) {
+		mockStore, userService, mailer := setup()
+
+		tokens, err := userService.Login(ctx, user.Username, "oldpassword")
+		assert.NoError(t, err)
+
+		err = userService.RequestPasswordReset(ctx, " Test@Example.com ")
+		assert.NoError(t, err)
+		msg := <-mailer.sent
+		assert.Equal(t, user.Email, msg.To)
+		assert.Equal(t, "Reset your QAHub password", msg.Subject, "邮件应使用用户的语言偏好")
+		code := tokenPattern.FindString(msg.Body)
+		assert.NotEmpty(t, code)
+
+		// 新密码不合法时不消耗重置码
+		err = userService.ConfirmPasswordReset(ctx, code, "123")
+		assert.ErrorIs(t, err, service.ErrInvalidPassword)
+
+		mockStore.EXPECT().UpdatePassword(gomock.Any(), user.ID, gomock.Any()).Return(nil).Times(1)
+		err = userService.ConfirmPasswordReset(ctx, code, "newpassword")
+		assert.NoError(t, err)
+
+		_, err = userService.ValidateToken(ctx, tokens.AccessToken)
+		assert.Error(t, err, "重置密码后所有会话都应下线")
+
+		err = userService.ConfirmPasswordReset(ctx, code, "anotherpassword")
+		assert.ErrorIs(t, err, service.ErrInvalidResetToken)
+	})
+
+	t.Run("超过频率限制", func(t *testing.T) {
+		_, userService, _ := setup()
+
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, userService.RequestPasswordReset(ctx, "nobody@example.com"))
+		}
+		err := userService.RequestPasswordReset(ctx, "nobody@example.com")
+		assert.ErrorIs(t, err, service.ErrTooManyRequests)
+	})
+}
+
+func TestEmailVerification(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config.Conf.Services.UserService.EmailVerificationLimit = 2
+
+	tokenPattern := regexp.MustCompile(`[A-Za-z0-9_-]{43}`)
+	setup := func() (*sessionStore, service.UserService, *captureMailer) {
+		mockStore := newSessionStore(ctrl)
+		mailer := &captureMailer{sent: make(chan mail.Message, 10)}
+		return mockStore, service.NewUserService(mockStore, mailer, testSigner, testProducer, testBlobs), mailer
+	}
+	ctx := context.Background()
+
+	t.Run("注册后发送验证码，验证码只能使用一次", func(t *testing.T) {
+		mockStore, userService, mailer := setup()
+		mockStore.EXPECT().GetUserByEmail(ctx, "new@example.com").Return(nil, errors.New("user not found"))
+		mockStore.EXPECT().CreateUser(ctx, gomock.Any()).Return(int64(1), nil)
+
+		resp, err := userService.Register(ctx, dto.RegisterRequest{Username: "newuser", Email: "new@example.com", Password: "password123"})
+		assert.NoError(t, err)
+		assert.False(t, resp.EmailVerified)
+
+		msg := <-mailer.sent
+		assert.Equal(t, "new@example.com", msg.To)
+		assert.Equal(t, "验证你的 QAHub 邮箱", msg.Subject, "没有语言偏好时使用默认语言")
+		code := tokenPattern.FindString(msg.Body)
+		assert.NotEmpty(t, code)
+
+		mockStore.EXPECT().MarkEmailVerified(gomock.Any(), int64(1), "new@example.com").Return(true, nil).Times(1)
+		assert.NoError(t, userService.VerifyEmail(ctx, code))
+		assert.ErrorIs(t, userService.VerifyEmail(ctx, code), service.ErrInvalidVerificationToken)
+	})
+
+	t.Run("验证前修改了邮箱，旧验证码失效", func(t *testing.T) {
+		mockStore, userService, mailer := setup()
+		user := &model.User{ID: 1, Username: "testuser", Email: "old@example.com"}
+		mockStore.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil)
+
+		assert.NoError(t, userService.ResendVerification(ctx, auth.Identity{UserID: user.ID}))
+		code := tokenPattern.FindString((<-mailer.sent).Body)
+
+		mockStore.EXPECT().MarkEmailVerified(gomock.Any(), user.ID, "old@example.com").Return(false, nil)
+		assert.ErrorIs(t, userService.VerifyEmail(ctx, code), service.ErrInvalidVerificationToken)
+	})
+
+	t.Run("修改邮箱后向新邮箱发送验证码", func(t *testing.T) {
+		mockStore, userService, mailer := setup()
+		verifiedAt := time.Now()
+		current := &model.User{ID: 1, Username: "testuser", Email: "old@example.com", Language: "en", EmailVerifiedAt: &verifiedAt}
+		updated := &model.User{ID: 1, Username: "testuser", Email: "new@example.com"}
+		mockStore.EXPECT().GetUserByID(gomock.Any(), current.ID).Return(current, nil)
+		mockStore.EXPECT().UpdateUser(gomock.Any(), updated).Return(nil)
+
+		assert.NoError(t, userService.UpdateUserProfile(ctx, updated))
+		msg := <-mailer.sent
+		assert.Equal(t, "new@example.com", msg.To)
+		assert.Equal(t, "Verify your QAHub email", msg.Subject, "未修改语言时沿用原有的语言偏好")
+	})
+
+	t.Run("已验证的邮箱不能重发验证码", func(t *testing.T) {
+		mockStore, userService, _ := setup()
+		verifiedAt := time.Now()
+		mockStore.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(&model.User{ID: 1, EmailVerifiedAt: &verifiedAt}, nil)
+
+		err := userService.ResendVerification(ctx, auth.Identity{UserID: 1})
+		assert.ErrorIs(t, err, service.ErrEmailAlreadyVerified)
+	})
+
+	t.Run("重发验证码超过频率限制", func(t *testing.T) {
+		mockStore, userService, _ := setup()
+		mockStore.EXPECT().GetUserByID(gomock.Any(), int64(1)).Return(&model.User{ID: 1, Email: "test@example.com"}, nil).AnyTimes()
+
+		for i := 0; i < 2; i++ {
+			assert.NoError(t, userService.ResendVerification(ctx, auth.Identity{UserID: 1}))
+		}
+		err := userService.ResendVerification(ctx, auth.Identity{UserID: 1})
+		assert.ErrorIs(t, err, service.ErrTooManyRequests)
+	})
+
+	t.Run("访问令牌携带邮箱验证状态", func(t *testing.T) {
+		mockStore, userService, _ := setup()
+		hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+		mockStore.EXPECT().GetUserByUsername(gomock.Any(), "testuser").
+			Return(&model.User{ID: 1, Username: "testuser", Password: string(hashedPassword)}, nil)
+
+		tokens, err := userService.Login(ctx, "testuser", "password123")
+		assert.NoError(t, err)
+		identity, err := userService.ValidateToken(ctx, tokens.AccessToken)
+		assert.NoError(t, err)
+		assert.False(t, identity.EmailVerified())
+	})
+}
+
+func TestTwoFactor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config.Conf.Services.UserService.TwoFactorRequiredRoles = []string{auth.RoleModerator, auth.RoleAdmin}
+	defer func() { config.Conf.Services.UserService.TwoFactorRequiredRoles = nil }()
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correctpassword"), bcrypt.DefaultCost)
+	setup := func(role string) (*sessionStore, service.UserService, *model.User) {
+		user := &model.User{ID: 1, Username: "testuser", Password: string(hashedPassword), Role: role}
+		mockStore := newSessionStore(ctrl)
+		mockStore.EXPECT().GetUserByUsername(gomock.Any(), user.Username).Return(user, nil).AnyTimes()
+		mockStore.EXPECT().GetUserByID(gomock.Any(), user.ID).Return(user, nil).AnyTimes()
+		return mockStore, service.NewUserService(mockStore, mail.NewFileMailer(""), testSigner, testProducer, testBlobs), user
+	}
+	totpCode := func(t *testing.T, secret string, at time.Time) string {
+		code, err := totp.Code(secret, at)
+		assert.NoError(t, err)
+		return code
+	}
+	// enroll 为用户完成绑定，返回密钥和恢复码。绑定时使用了当前时间步的验证码。
+	enroll := func(t *testing.T, userService service.UserService, user *model.User) (string, []string) {
+		identity := auth.Identity{UserID: user.ID, Username: user.Username}
+		enrollment, err := userService.Enroll2FA(context.Background(), identity)
+		assert.NoError(t, err)
+		assert.Contains(t, enrollment.OTPAuthURI, "otpauth://totp/")
+		assert.Contains(t, enrollment.OTPAuthURI, enrollment.Secret)
+
+		_, err = userService.Confirm2FA(context.Background(), identity, "000000")
+		assert.ErrorIs(t, err, service.ErrInvalidTwoFactorCode)
+
+		codes, err := userService.Confirm2FA(context.Background(), identity, totpCode(t, enrollment.Secret, time.Now()))
+		assert.NoError(t, err)
+		assert.Len(t, codes, 10)
+		return enrollment.Secret, codes
+	}
+	parseRole := func(t *testing.T, accessToken string) string {
+		identity, err := testSigner.Verify(accessToken)
+		assert.NoError(t, err)
+		return identity.Role()
+	}
+	ctx := context.Background()
+
+	t.Run("启用后登录需要两步验证，挑战只能使用一次", func(t *testing.T) {
+		_, userService, user := setup(auth.RoleUser)
+		secret, _ := enroll(t, userService, user)
+
+		login, err := userService.Login(ctx, user.Username, "correctpassword")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, login.ChallengeToken)
+		assert.Empty(t, login.AccessToken, "通过第二步验证前不签发令牌")
+
+		_, err = userService.Verify2FA(ctx, login.ChallengeToken, "000000")
+		assert.ErrorIs(t, err, service.ErrInvalidTwoFactorCode)
+
+		// 绑定时已经用过当前时间步的验证码，这里使用下一个时间步的验证码
+		code := totpCode(t, secret, time.Now().Add(totp.Period))
+		tokens, err := userService.Verify2FA(ctx, login.ChallengeToken, code)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, tokens.AccessToken)
+		assert.NotEmpty(t, tokens.RefreshToken)
+
+		_, err = userService.Verify2FA(ctx, login.ChallengeToken, code)
+		assert.ErrorIs(t, err, service.ErrInvalidLoginChallenge)
+	})
+
+	t.Run("验证码不能重放，恢复码只能使用一次", func(t *testing.T) {
+		_, userService, user := setup(auth.RoleUser)
+		secret, codes := enroll(t, userService, user)
+
+		login, err := userService.Login(ctx, user.Username, "correctpassword")
+		assert.NoError(t, err)
+		_, err = userService.Verify2FA(ctx, login.ChallengeToken, totpCode(t, secret, time.Now()))
+		assert.ErrorIs(t, err, service.ErrInvalidTwoFactorCode, "绑定时用过的验证码不能再次使用")
+
+		// 恢复码不区分大小写，也可以省略连字符
+		_, err = userService.Verify2FA(ctx, login.ChallengeToken, strings.ToUpper(strings.ReplaceAll(codes[0], "-", "")))
+		assert.NoError(t, err)
+
+		login, err = userService.Login(ctx, user.Username, "correctpassword")
+		assert.NoError(t, err)
+		_, err = userService.Verify2FA(ctx, login.ChallengeToken, codes[0])
+		assert.ErrorIs(t, err, service.ErrInvalidTwoFactorCode)
+	})
+
+	t.Run("版主未启用两步验证时只获得普通用户权限", func(t *testing.T) {
+		_, userService, user := setup(auth.RoleModerator)
+
+		login, err := userService.Login(ctx, user.Username, "correctpassword")
+		assert.NoError(t, err)
+		assert.True(t, login.TwoFactorSetupRequired)
+		assert.Equal(t, auth.RoleUser, parseRole(t, login.AccessToken))
+
+		secret, _ := enroll(t, userService, user)
+		login, err = userService.Login(ctx, user.Username, "correctpassword")
+		assert.NoError(t, err)
+		tokens, err := userService.Verify2FA(ctx, login.ChallengeToken, totpCode(t, secret, time.Now().Add(totp.Period)))
+		assert.NoError(t, err)
+		assert.False(t, tokens.TwoFactorSetupRequired)
+		assert.Equal(t, auth.RoleModerator, parseRole(t, tokens.AccessToken))
+	})
+
+	t.Run("停用后恢复为单步登录", func(t *testing.T) {
+		_, userService, user := setup(auth.RoleUser)
+		_, codes := enroll(t, userService, user)
+		identity := auth.Identity{UserID: user.ID}
+
+		assert.ErrorIs(t, userService.Disable2FA(ctx, identity, "wrong-code"), service.ErrInvalidTwoFactorCode)
+		assert.NoError(t, userService.Disable2FA(ctx, identity, codes[1]))
+		assert.ErrorIs(t, userService.Disable2FA(ctx, identity, codes[2]), service.ErrTwoFactorNotEnabled)
+
+		login, err := userService.Login(ctx, user.Username, "correctpassword")
+		assert.NoError(t, err)
+		assert.Empty(t, login.ChallengeToken)
+		assert.NotEmpty(t, login.AccessToken)
+	})
+}
+
+func TestAccessTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correctpassword"), bcrypt.DefaultCost)
+	user := &model.User{ID: 1, Username: "testuser", Password: string(hashedPassword), Role: auth.RoleUser}
+	other := &model.User{ID: 2, Username: "otheruser", Role: auth.RoleUser}
+	admin := &model.User{ID: 3, Username: "admin", Role: auth.RoleAdmin}
+	bot := &model.User{ID: 4, Username: "helper-bot", Role: auth.RoleModerator, UserType: auth.UserTypeBot}
+
+	setup := func() (*sessionStore, service.UserService) {
+		mockStore := newSessionStore(ctrl)
+		for _, u := range []*model.User{user, other, admin, bot} {
+			mockStore.EXPECT().GetUserByID(gomock.Any(), u.ID).Return(u, nil).AnyTimes()
+			mockStore.EXPECT().GetUserByUsername(gomock.Any(), u.Username).Return(u, nil).AnyTimes()
+		}
+		return mockStore, service.NewUserService(mockStore, mail.NewFileMailer(""), testSigner, testProducer, testBlobs)
+	}
+	ctx := context.Background()
+	userIdentity := auth.Identity{UserID: user.ID, Username: user.Username, Claims: map[string]any{"role": auth.RoleUser}}
+	adminIdentity := auth.Identity{UserID: admin.ID, Username: admin.Username, Claims: map[string]any{"role": auth.RoleAdmin}}
+
+	t.Run("创建的令牌可以用于认证，撤销后立即失效", func(t *testing.T) {
+		mockStore, userService := setup()
+
+		created, err := userService.CreateAccessToken(ctx, userIdentity, dto.CreateAccessTokenRequest{
+			Name:   "ci",
+			Scopes: []string{"write:qa", "read", "read"},
+		})
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(created.Token, auth.AccessTokenPrefix))
+		assert.Equal(t, []string{auth.ScopeRead, auth.ScopeWriteQA}, created.Scopes)
+		assert.Equal(t, created.Token[len(created.Token)-4:], created.TokenHint)
+		assert.Nil(t, created.ExpiresAt)
+		assert.NotContains(t, mockStore.accessTokens[created.ID].TokenHash, created.Token, "只保存令牌的哈希")
+
+		identity, err := userService.ValidateToken(ctx, created.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, identity.UserID)
+		assert.True(t, identity.IsAccessToken())
+		assert.True(t, identity.HasScope(auth.ScopeWriteQA))
+		assert.False(t, identity.HasScope(auth.ScopeAdmin))
+		assert.NotNil(t, mockStore.accessTokens[created.ID].LastUsedAt)
+
+		tokens, err := userService.ListAccessTokens(ctx, userIdentity, 0)
+		assert.NoError(t, err)
+		assert.Len(t, tokens, 1)
+
+		assert.NoError(t, userService.RevokeAccessToken(ctx, userIdentity, 0, created.ID))
+		assert.ErrorIs(t, userService.RevokeAccessToken(ctx, userIdentity, 0, created.ID), service.ErrAccessTokenNotFound)
+		_, err = userService.ValidateToken(ctx, created.Token)
+		assert.ErrorIs(t, err, service.ErrInvalidAccessToken)
+
+		_, err = userService.ValidateToken(ctx, auth.AccessTokenPrefix+"unknown")
+		assert.ErrorIs(t, err, service.ErrInvalidAccessToken)
+	})
+
+	t.Run("过期的令牌不能使用", func(t *testing.T) {
+		mockStore, userService := setup()
+
+		created, err := userService.CreateAccessToken(ctx, userIdentity, dto.CreateAccessTokenRequest{
+			Name:      "short",
+			Scopes:    []string{auth.ScopeRead},
+			ExpiresIn: time.Hour,
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, created.ExpiresAt)
+
+		expired := time.Now().Add(-time.Second)
+		mockStore.accessTokens[created.ID].ExpiresAt = &expired
+		_, err = userService.ValidateToken(ctx, created.Token)
+		assert.ErrorIs(t, err, service.ErrInvalidAccessToken)
+	})
+
+	t.Run("参数校验", func(t *testing.T) {
+		_, userService := setup()
+
+		_, err := userService.CreateAccessToken(ctx, userIdentity, dto.CreateAccessTokenRequest{Name: " ", Scopes: []string{auth.ScopeRead}})
+		assert.ErrorIs(t, err, service.ErrInvalidAccessTokenName)
+		_, err = userService.CreateAccessToken(ctx, userIdentity, dto.CreateAccessTokenRequest{Name: "ci"})
+		assert.ErrorIs(t, err, service.ErrInvalidScope)
+		_, err = userService.CreateAccessToken(ctx, userIdentity, dto.CreateAccessTokenRequest{Name: "ci", Scopes: []string{"write"}})
+		assert.ErrorIs(t, err, service.ErrInvalidScope)
+		_, err = userService.CreateAccessToken(ctx, userIdentity, dto.CreateAccessTokenRequest{Name: "ci", Scopes: []string{auth.ScopeRead}, ExpiresIn: -time.Hour})
+		assert.ErrorIs(t, err, service.ErrInvalidExpiry)
+	})
+
+	t.Run("admin 范围只对有管理权限的用户开放，否则令牌中只有普通用户角色", func(t *testing.T) {
+		_, userService := setup()
+
+		_, err := userService.CreateAccessToken(ctx, userIdentity, dto.CreateAccessTokenRequest{Name: "ci", Scopes: []string{auth.ScopeAdmin}})
+		assert.ErrorIs(t, err, service.ErrAdminScopeNotAllowed)
+
+		created, err := userService.CreateAccessToken(ctx, adminIdentity, dto.CreateAccessTokenRequest{Name: "ops", Scopes: []string{auth.ScopeAdmin}})
+		assert.NoError(t, err)
+		identity, err := userService.ValidateToken(ctx, created.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, auth.RoleAdmin, identity.Role())
+
+		created, err = userService.CreateAccessToken(ctx, adminIdentity, dto.CreateAccessTokenRequest{Name: "readonly", Scopes: []string{auth.ScopeRead}})
+		assert.NoError(t, err)
+		identity, err = userService.ValidateToken(ctx, created.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, auth.RoleUser, identity.Role(), "没有 admin 范围的令牌不携带管理员角色")
+	})
+
+	t.Run("管理员可以管理机器人账户的令牌，但不能管理其他用户的令牌", func(t *testing.T) {
+		_, userService := setup()
+
+		_, err := userService.CreateAccessToken(ctx, userIdentity, dto.CreateAccessTokenRequest{UserID: bot.ID, Name: "bot", Scopes: []string{auth.ScopeRead}})
+		assert.ErrorIs(t, err, service.ErrAccessTokenForbidden)
+		_, err = userService.CreateAccessToken(ctx, adminIdentity, dto.CreateAccessTokenRequest{UserID: other.ID, Name: "other", Scopes: []string{auth.ScopeRead}})
+		assert.ErrorIs(t, err, service.ErrAccessTokenForbidden)
+
+		created, err := userService.CreateAccessToken(ctx, adminIdentity, dto.CreateAccessTokenRequest{UserID: bot.ID, Name: "bot", Scopes: []string{auth.ScopeWriteQA, auth.ScopeAdmin}})
+		assert.NoError(t, err)
+		identity, err := userService.ValidateToken(ctx, created.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, bot.ID, identity.UserID)
+		assert.True(t, identity.IsBot())
+		assert.Equal(t, auth.RoleModerator, identity.Role())
+
+		tokens, err := userService.ListAccessTokens(ctx, adminIdentity, bot.ID)
+		assert.NoError(t, err)
+		assert.Len(t, tokens, 1)
+		_, err = userService.ListAccessTokens(ctx, userIdentity, bot.ID)
+		assert.ErrorIs(t, err, service.ErrAccessTokenForbidden)
+		assert.ErrorIs(t, userService.RevokeAccessToken(ctx, userIdentity, bot.ID, created.ID), service.ErrAccessTokenForbidden)
+		assert.ErrorIs(t, userService.RevokeAccessToken(ctx, userIdentity, 0, created.ID), service.ErrAccessTokenNotFound, "不能撤销其他用户的令牌")
+	})
+
+	t.Run("机器人账户不能登录", func(t *testing.T) {
+		mockStore, userService := setup()
+		mockStore.EXPECT().GetUserByUsername(gomock.Any(), "new-bot").Return(nil, errors.New("user not found"))
+		mockStore.EXPECT().GetUserByEmail(gomock.Any(), "new-bot@bots.qahub.invalid").Return(nil, errors.New("user not found"))
+		var created *model.User
+		mockStore.EXPECT().CreateUser(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, u *model.User) (int64, error) {
+			created = u
+			return 5, nil
+		})
+
+		resp, err := userService.CreateBot(ctx, dto.CreateBotRequest{Username: "new-bot"})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), resp.ID)
+		assert.Equal(t, auth.UserTypeBot, resp.UserType)
+		assert.Equal(t, auth.UserTypeBot, created.UserType)
+		assert.NotNil(t, created.EmailVerifiedAt)
+
+		_, err = userService.CreateBot(ctx, dto.CreateBotRequest{Username: user.Username})
+		assert.ErrorIs(t, err, service.ErrUsernameTaken)
+
+		hashed, _ := bcrypt.GenerateFromPassword([]byte("botpassword"), bcrypt.DefaultCost)
+		loginBot := &model.User{ID: 6, Username: "login-bot", Password: string(hashed), UserType: auth.UserTypeBot}
+		mockStore.EXPECT().GetUserByUsername(gomock.Any(), loginBot.Username).Return(loginBot, nil)
+		_, err = userService.Login(ctx, loginBot.Username, "botpassword")
+		assert.ErrorIs(t, err, service.ErrBotLogin)
+	})
+}
+
+func TestOAuthLogin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idp := oidctest.NewServer("qahub", "client-secret")
+	defer idp.Close()
+
+	config.Conf.Services.UserService.OAuthProviders = map[string]config.OAuthProvider{
+		"company": {
+			Issuer:       idp.Issuer(),
+			ClientID:     "qahub",
+			ClientSecret: "client-secret",
+			RedirectURL:  "http://localhost:8080/api/v1/auth/oauth/company/callback",
+		},
+		"partner": {Issuer: idp.Issuer(), ClientID: "qahub", ClientSecret: "client-secret"},
+	}
+	defer func() { config.Conf.Services.UserService.OAuthProviders = nil }()
+
+	alice := oidctest.User{Subject: "sub-alice", Email: "alice@example.com", EmailVerified: true, PreferredUsername: "alice"}
+	setup := func() (*sessionStore, service.UserService) {
+		mockStore := newSessionStore(ctrl)
+		mockStore.EXPECT().GetTwoFactor(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+		return mockStore, service.NewUserService(mockStore, mail.NewFileMailer(""), testSigner, testProducer, testBlobs)
+	}
+	// authorize 发起单点登录并模拟用户在身份提供方登录，返回回调中的授权码和 state
+	authorize := func(t *testing.T, userService service.UserService, user oidctest.User) (string, string) {
+		start, err := userService.BeginOAuthLogin(context.Background(), "company")
+		assert.NoError(t, err)
+		code, state, err := idp.Authorize(start.AuthorizationURL, user)
+		assert.NoError(t, err)
+		assert.Equal(t, start.State, state)
+		return code, state
+	}
+	ctx := context.Background()
+
+	t.Run("首次登录自动创建用户，state 只能使用一次", func(t *testing.T) {
+		mockStore, userService := setup()
+		code, state := authorize(t, userService, alice)
+
+		mockStore.EXPECT().GetUserIdentity(gomock.Any(), idp.Issuer(), "sub-alice").Return(nil, nil)
+		mockStore.EXPECT().GetUserByEmail(gomock.Any(), "alice@example.com").Return(nil, errors.New("user not found"))
+		mockStore.EXPECT().GetUserByUsername(gomock.Any(), "alice").Return(nil, errors.New("user not found"))
+		mockStore.EXPECT().CreateUserWithIdentity(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, user *model.User, identity *model.UserIdentity) (int64, error) {
+				assert.Equal(t, "alice", user.Username)
+				assert.NotNil(t, user.EmailVerifiedAt, "身份提供方确认过的邮箱视为已验证")
+				assert.NotEmpty(t, user.Password, "自动创建的用户也有一个无法使用的密码哈希")
+				assert.Equal(t, &model.UserIdentity{Provider: "company", Issuer: idp.Issuer(), Subject: "sub-alice", Email: "alice@example.com"}, identity)
+				return 7, nil
+			})
+
+		tokens, err := userService.CompleteOAuthLogin(ctx, "company", code, state)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, tokens.AccessToken)
+
+		identity, err := userService.ValidateToken(ctx, tokens.AccessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), identity.UserID)
+
+		_, err = userService.CompleteOAuthLogin(ctx, "company", code, state)
+		assert.ErrorIs(t, err, service.ErrInvalidOAuthState)
+	})
+
+	t.Run("已关联的外部身份直接登录", func(t *testing.T) {
+		mockStore, userService := setup()
+		code, state := authorize(t, userService, alice)
+		user := &model.User{ID: 3, Username: "alice"}
+
+		mockStore.EXPECT().GetUserIdentity(gomock.Any(), idp.Issuer(), "sub-alice").Return(&model.UserIdentity{UserID: 3}, nil)
+		mockStore.EXPECT().GetUserByID(gomock.Any(), int64(3)).Return(user, nil).AnyTimes()
+
+		tokens, err := userService.CompleteOAuthLogin(ctx, "company", code, state)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, tokens.AccessToken)
+	})
+
+	t.Run("邮箱均已验证时关联到已有用户", func(t *testing.T) {
+		mockStore, userService := setup()
+		code, state := authorize(t, userService, alice)
+		verifiedAt := time.Now()
+		user := &model.User{ID: 3, Username: "alice", Email: "alice@example.com", EmailVerifiedAt: &verifiedAt}
+
+		mockStore.EXPECT().GetUserIdentity(gomock.Any(), idp.Issuer(), "sub-alice").Return(nil, nil)
+		mockStore.EXPECT().GetUserByEmail(gomock.Any(), "alice@example.com").Return(user, nil)
+		mockStore.EXPECT().LinkUserIdentity(gomock.Any(), &model.UserIdentity{
+			UserID: 3, Provider: "company", Issuer: idp.Issuer(), Subject: "sub-alice", Email: "alice@example.com",
+		}).Return(nil)
+		mockStore.EXPECT().GetUserByID(gomock.Any(), int64(3)).Return(user, nil).AnyTimes()
+
+		_, err := userService.CompleteOAuthLogin(ctx, "company", code, state)
+		assert.NoError(t, err)
+	})
+
+	t.Run("本地邮箱未验证时拒绝关联", func(t *testing.T) {
+		mockStore, userService := setup()
+		code, state := authorize(t, userService, alice)
+
+		mockStore.EXPECT().GetUserIdentity(gomock.Any(), idp.Issuer(), "sub-alice").Return(nil, nil)
+		mockStore.EXPECT().GetUserByEmail(gomock.Any(), "alice@example.com").Return(&model.User{ID: 3}, nil)
+
+		_, err := userService.CompleteOAuthLogin(ctx, "company", code, state)
+		assert.ErrorIs(t, err, service.ErrOAuthAccountConflict)
+	})
+
+	t.Run("用户名重复时追加后缀", func(t *testing.T) {
+		mockStore, userService := setup()
+		code, state := authorize(t, userService, oidctest.User{Subject: "sub-bob", Email: "bob@example.com"})
+
+		mockStore.EXPECT().GetUserIdentity(gomock.Any(), idp.Issuer(), "sub-bob").Return(nil, nil)
+		mockStore.EXPECT().GetUserByEmail(gomock.Any(), "bob@example.com").Return(nil, errors.New("user not found"))
+		mockStore.EXPECT().GetUserByUsername(gomock.Any(), "bob").Return(&model.User{ID: 1}, nil)
+		mockStore.EXPECT().GetUserByUsername(gomock.Any(), gomock.Any()).Return(nil, errors.New("user not found"))
+		mockStore.EXPECT().CreateUserWithIdentity(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, user *model.User, _ *model.UserIdentity) (int64, error) {
+				assert.Regexp(t, `^bob_\d{4}$`, user.Username)
+				assert.Nil(t, user.EmailVerifiedAt, "身份提供方未确认的邮箱需要重新验证")
+				return 8, nil
+			})
+
+		_, err := userService.CompleteOAuthLogin(ctx, "company", code, state)
+		assert.NoError(t, err)
+	})
+
+	t.Run("state 不能用于其他身份提供方", func(t *testing.T) {
+		_, userService := setup()
+		code, state := authorize(t, userService, alice)
+
+		_, err := userService.CompleteOAuthLogin(ctx, "partner", code, state)
+		assert.ErrorIs(t, err, service.ErrInvalidOAuthState)
+	})
+
+	t.Run("授权码无效时登录失败", func(t *testing.T) {
+		_, userService := setup()
+		_, state := authorize(t, userService, alice)
+
+		_, err := userService.CompleteOAuthLogin(ctx, "company", "forged-code", state)
+		assert.ErrorIs(t, err, service.ErrOAuthFailed)
+	})
+
+	t.Run("未配置的身份提供方", func(t *testing.T) {
+		_, userService := setup()
+		_, err := userService.BeginOAuthLogin(ctx, "unknown")
+		assert.ErrorIs(t, err, service.ErrUnknownOAuthProvider)
+	})
+}
+
+func TestGetUserProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockUserStore(ctrl)
+	userService := service.NewUserService(mockStore, mail.NewFileMailer(""), testSigner, testProducer, testBlobs)
+	ctx := context.Background()
+
+	t.Run("成功获取用户信息", func(t *testing.T) {
+		userID := int64(1)
+		user := &model.User{
+			ID:       userID,
+			Username: "testuser",
+			Email:    "test@example.com",
+			Bio:      "Test bio",
+			Password: "hashedpassword",
+		}
+
+		// Mock: 根据ID查找用户
+		mockStore.EXPECT().
+			GetUserByID(ctx, userID).
+			Return(user, nil).
+			Times(1)
+
+		// 执行测试
+		resp, err := userService.GetUserProfile(ctx, userID)
+
+		// 验证结果
+		assert.NoError(t, err)
+		assert.NotNil(t, resp)
+		assert.Equal(t, userID, resp.ID)
+		assert.Equal(t, "testuser", resp.Username)
+		assert.Equal(t, "test@example.com", resp.Email)
+		assert.Equal(t, "Test bio", resp.Bio)
+	})
+
+	t.Run("用户不存在", func(t *testing.T) {
+		userID := int64(999)
+
+		// Mock: 用户不存在
+		mockStore.EXPECT().
+			GetUserByID(ctx, userID).
+			Return(nil, errors.New("user not found")).
+			Times(1)
+
+		// 执行测试
+		resp, err := userService.GetUserProfile(ctx, userID)
+
+		// 验证结果
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+		assert.Equal(t, "user not found", err.Error())
+	})
+
+	t.Run("数据库错误", func(t *testing.T) {
+		userID := int64(1)
+
+		// Mock: 数据库错误
+		mockStore.EXPECT().
+			GetUserByID(ctx, userID).
+			Return(nil, errors.New("database connection error")).
+			Times(1)
+
+		// 执行测试
+		resp, err := userService.GetUserProfile(ctx, userID)
+
+		// 验证结果
+		assert.Error(t, err)
+		assert.Nil(t, resp)
+	})
+}
+
+func TestUpdateUserProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockUserStore(ctrl)
+	userService := service.NewUserService(mockStore, mail.NewFileMailer(""), testSigner, testProducer, testBlobs)
+	ctx := context.Background()
+
+	t.Run("成功更新用户信息", func(t *testing.T) {
+		user := &model.User{
+			ID:       1,
+			Username: "testuser",
+			Email:    "updated@example.com",
+			Bio:      "Updated bio",
+		}
+
+		// Mock: 邮箱没有变化，不需要重新验证
+		mockStore.EXPECT().
+			GetUserByID(ctx, int64(1)).
+			Return(&model.User{ID: 1, Email: "updated@example.com"}, nil).
+			Times(1)
+		// Mock: 更新用户成功
+		mockStore.EXPECT().
+			UpdateUser(ctx, user).
+			Return(nil).
+			Times(1)
+
+		// 执行测试
+		err := userService.UpdateUserProfile(ctx, user)
+
+		// 验证结果
+		assert.NoError(t, err)
+	})
+
+	t.Run("更新用户失败", func(t *testing.T) {
+		user := &model.User{
+			ID:       1,
+			Username: "testuser",
+			Email:    "updated@example.com",
+		}
+
+		mockStore.EXPECT().
+			GetUserByID(ctx, int64(1)).
+			Return(&model.User{ID: 1, Email: "updated@example.com"}, nil).
+			Times(1)
+		// Mock: 更新失败
+		mockStore.EXPECT().
+			UpdateUser(ctx, user).
+			Return(errors.New("update failed")).
+			Times(1)
+
+		// 执行测试
+		err := userService.UpdateUserProfile(ctx, user)
+
+		// 验证结果
+		assert.Error(t, err)
+		assert.Equal(t, "update failed", err.Error())
+	})
+
+	t.Run("语言偏好归一化后保存", func(t *testing.T) {
+		user := &model.User{
+			ID:       1,
+			Username: "testuser",
+			Language: "en-US",
+		}
+
+		mockStore.EXPECT().
+			GetUserByID(ctx, int64(1)).
+			Return(&model.User{ID: 1}, nil).
+			Times(1)
+		mockStore.EXPECT().
+			UpdateUser(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, u *model.User) error {
+				assert.Equal(t, "en", u.Language)
+				return nil
+			}).
+			Times(1)
+
+		err := userService.UpdateUserProfile(ctx, user)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("不支持的语言", func(t *testing.T) {
+		user := &model.User{
+			ID:       1,
+			Username: "testuser",
+			Language: "fr",
+		}
+
+		// 校验失败时不应写入数据库
+		err := userService.UpdateUserProfile(ctx, user)
+
+		assert.ErrorIs(t, err, service.ErrUnsupportedLanguage)
+	})
+
+	t.Run("数据库连接错误", func(t *testing.T) {
+		user := &model.User{
+			ID:       1,
+			Username: "testuser",
+		}
+
+		mockStore.EXPECT().
+			GetUserByID(ctx, int64(1)).
+			Return(&model.User{ID: 1}, nil).
+			Times(1)
+		// Mock: 数据库连接错误
+		mockStore.EXPECT().
+			UpdateUser(ctx, user).
+			Return(errors.New("database connection error")).
 			Times(1)
 
 		// 执行测试
@@ -346,12 +1680,468 @@ func TestUpdateUserProfile(t *testing.T) {
 	})
 }
 
+func TestChangeUsername(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockUserStore(ctrl)
+	userService := service.NewUserService(mockStore, mail.NewFileMailer(""), testSigner, testProducer, testBlobs)
+	ctx := context.Background()
+	user := func() *model.User { return &model.User{ID: 1, Username: "alice"} }
+
+	t.Run("成功修改用户名并记录旧用户名", func(t *testing.T) {
+		mockStore.EXPECT().GetUserByID(ctx, int64(1)).Return(user(), nil)
+		mockStore.EXPECT().GetUserByUsername(ctx, "alice2").Return(nil, sql.ErrNoRows)
+		mockStore.EXPECT().GetLatestUsernameChange(ctx, int64(1)).Return(nil, nil)
+		mockStore.EXPECT().ChangeUsername(ctx, int64(1), "alice", "alice2").Return(nil)
+
+		updated, err := userService.ChangeUsername(ctx, 1, "alice2")
+		assert.NoError(t, err)
+		assert.Equal(t, "alice2", updated.Username)
+	})
+
+	t.Run("只修改大小写", func(t *testing.T) {
+		mockStore.EXPECT().GetUserByID(ctx, int64(1)).Return(user(), nil)
+		// 用户名不区分大小写，查到的是自己
+		mockStore.EXPECT().GetUserByUsername(ctx, "Alice").Return(user(), nil)
+		mockStore.EXPECT().GetLatestUsernameChange(ctx, int64(1)).Return(nil, nil)
+		mockStore.EXPECT().ChangeUsername(ctx, int64(1), "alice", "Alice").Return(nil)
+
+		_, err := userService.ChangeUsername(ctx, 1, "Alice")
+		assert.NoError(t, err)
+	})
+
+	t.Run("用户名没有变化", func(t *testing.T) {
+		mockStore.EXPECT().GetUserByID(ctx, int64(1)).Return(user(), nil)
+
+		_, err := userService.ChangeUsername(ctx, 1, "alice")
+		assert.ErrorIs(t, err, service.ErrUsernameUnchanged)
+	})
+
+	t.Run("用户名已被其他用户使用", func(t *testing.T) {
+		mockStore.EXPECT().GetUserByID(ctx, int64(1)).Return(user(), nil)
+		mockStore.EXPECT().GetUserByUsername(ctx, "BOB").Return(&model.User{ID: 2, Username: "bob"}, nil)
+
+		_, err := userService.ChangeUsername(ctx, 1, "BOB")
+		assert.ErrorIs(t, err, service.ErrUsernameTaken)
+	})
+
+	t.Run("并发修改时由唯一索引拒绝", func(t *testing.T) {
+		mockStore.EXPECT().GetUserByID(ctx, int64(1)).Return(user(), nil)
+		mockStore.EXPECT().GetUserByUsername(ctx, "carol").Return(nil, sql.ErrNoRows)
+		mockStore.EXPECT().GetLatestUsernameChange(ctx, int64(1)).Return(nil, nil)
+		mockStore.EXPECT().ChangeUsername(ctx, int64(1), "alice", "carol").Return(store.ErrDuplicateUsername)
+
+		_, err := userService.ChangeUsername(ctx, 1, "carol")
+		assert.ErrorIs(t, err, service.ErrUsernameTaken)
+	})
+
+	t.Run("冷却期内不能再次修改", func(t *testing.T) {
+		mockStore.EXPECT().GetUserByID(ctx, int64(1)).Return(user(), nil)
+		mockStore.EXPECT().GetUserByUsername(ctx, "alice3").Return(nil, sql.ErrNoRows)
+		mockStore.EXPECT().GetLatestUsernameChange(ctx, int64(1)).
+			Return(&model.UsernameChange{UserID: 1, Username: "alice0", ChangedAt: time.Now().Add(-24 * time.Hour)}, nil)
+
+		_, err := userService.ChangeUsername(ctx, 1, "alice3")
+		assert.ErrorIs(t, err, service.ErrUsernameChangeTooSoon)
+	})
+
+	t.Run("冷却期过后可以再次修改", func(t *testing.T) {
+		mockStore.EXPECT().GetUserByID(ctx, int64(1)).Return(user(), nil)
+		mockStore.EXPECT().GetUserByUsername(ctx, "alice3").Return(nil, sql.ErrNoRows)
+		mockStore.EXPECT().GetLatestUsernameChange(ctx, int64(1)).
+			Return(&model.UsernameChange{UserID: 1, Username: "alice0", ChangedAt: time.Now().Add(-31 * 24 * time.Hour)}, nil)
+		mockStore.EXPECT().ChangeUsername(ctx, int64(1), "alice", "alice3").Return(nil)
+
+		_, err := userService.ChangeUsername(ctx, 1, "alice3")
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetUserByUsername(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockUserStore(ctrl)
+	userService := service.NewUserService(mockStore, mail.NewFileMailer(""), testSigner, testProducer, testBlobs)
+	ctx := context.Background()
+
+	t.Run("按当前用户名查找", func(t *testing.T) {
+		mockStore.EXPECT().GetUserByUsername(ctx, "alice").Return(&model.User{ID: 1, Username: "alice"}, nil)
+
+		user, err := userService.GetUserByUsername(ctx, "alice")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), user.ID)
+	})
+
+	t.Run("按曾用名查找", func(t *testing.T) {
+		mockStore.EXPECT().GetUserByUsername(ctx, "old_alice").Return(nil, sql.ErrNoRows)
+		mockStore.EXPECT().GetUserByPreviousUsername(ctx, "old_alice").Return(&model.User{ID: 1, Username: "alice"}, nil)
+
+		user, err := userService.GetUserByUsername(ctx, "old_alice")
+		assert.NoError(t, err)
+		assert.Equal(t, "alice", user.Username)
+	})
+
+	t.Run("用户不存在", func(t *testing.T) {
+		mockStore.EXPECT().GetUserByUsername(ctx, "nobody").Return(nil, sql.ErrNoRows)
+		mockStore.EXPECT().GetUserByPreviousUsername(ctx, "nobody").Return(nil, sql.ErrNoRows)
+
+		_, err := userService.GetUserByUsername(ctx, "nobody")
+		assert.ErrorIs(t, err, service.ErrUserNotFound)
+	})
+}
+
+func TestUploadAvatar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockUserStore(ctrl)
+	dir := t.TempDir()
+	blobs, err := blob.NewLocalStore(dir, "http://localhost/blobs")
+	assert.NoError(t, err)
+	userService := service.NewUserService(mockStore, mail.NewFileMailer(""), testSigner, testProducer, blobs)
+	ctx := context.Background()
+	identity := auth.Identity{UserID: 1, Username: "alice"}
+
+	encodePNG := func(width, height int) []byte {
+		img := image.NewNRGBA(image.Rect(0, 0, width, height))
+		for x := 0; x < width; x++ {
+			img.Set(x, height/2, color.NRGBA{R: 255, A: 255})
+		}
+		var buf bytes.Buffer
+		assert.NoError(t, png.Encode(&buf, img))
+		return buf.Bytes()
+	}
+
+	t.Run("生成固定尺寸的缩略图并删除旧头像", func(t *testing.T) {
+		assert.NoError(t, blobs.Put(ctx, "avatars/1/old/64.png", []byte("old"), "image/png"))
+
+		var avatarKey string
+		mockStore.EXPECT().UpdateAvatar(ctx, int64(1), gomock.Any()).DoAndReturn(func(_ context.Context, _ int64, key string) (string, error) {
+			avatarKey = key
+			return "avatars/1/old", nil
+		})
+		mockStore.EXPECT().GetUserByID(ctx, int64(1)).DoAndReturn(func(context.Context, int64) (*model.User, error) {
+			return &model.User{ID: 1, Username: "alice", AvatarKey: avatarKey}, nil
+		})
+
+		user, err := userService.UploadAvatar(ctx, identity, bytes.NewReader(encodePNG(400, 300)))
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(avatarKey, "avatars/1/"))
+		assert.True(t, strings.HasSuffix(user.AvatarURL, avatarKey+"/256.png"))
+
+		for _, size := range blob.AvatarSizes {
+			f, err := os.Open(filepath.Join(dir, filepath.FromSlash(blob.AvatarKey(avatarKey, size))))
+			if !assert.NoError(t, err) {
+				continue
+			}
+			cfg, err := png.DecodeConfig(f)
+			f.Close()
+			assert.NoError(t, err)
+			assert.Equal(t, size, cfg.Width)
+			assert.Equal(t, size, cfg.Height)
+		}
+		_, err = os.Stat(filepath.Join(dir, "avatars", "1", "old"))
+		assert.True(t, os.IsNotExist(err), "旧头像应被删除")
+	})
+
+	t.Run("拒绝非图片文件", func(t *testing.T) {
+		_, err := userService.UploadAvatar(ctx, identity, strings.NewReader("GIF89a not really an avatar"))
+		assert.ErrorIs(t, err, service.ErrUnsupportedAvatarType)
+	})
+
+	t.Run("拒绝超过大小限制的文件", func(t *testing.T) {
+		_, err := userService.UploadAvatar(ctx, identity, bytes.NewReader(make([]byte, 6<<20)))
+		assert.ErrorIs(t, err, service.ErrAvatarTooLarge)
+	})
+
+	t.Run("拒绝尺寸过小或过大的图片", func(t *testing.T) {
+		_, err := userService.UploadAvatar(ctx, identity, bytes.NewReader(encodePNG(32, 32)))
+		assert.ErrorIs(t, err, service.ErrInvalidAvatarDimensions)
+
+		_, err = userService.UploadAvatar(ctx, identity, bytes.NewReader(encodePNG(5000, 64)))
+		assert.ErrorIs(t, err, service.ErrInvalidAvatarDimensions)
+	})
+
+	t.Run("拒绝损坏的图片", func(t *testing.T) {
+		data := encodePNG(128, 128)
+		_, err := userService.UploadAvatar(ctx, identity, bytes.NewReader(data[:len(data)/2]))
+		assert.ErrorIs(t, err, service.ErrInvalidAvatar)
+	})
+}
+
+func TestAdminUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("correctpassword"), bcrypt.DefaultCost)
+	admin := &model.User{ID: 3, Username: "admin", Role: auth.RoleAdmin}
+	adminIdentity := auth.Identity{UserID: admin.ID, Username: admin.Username, Claims: map[string]any{"role": auth.RoleAdmin}}
+	ctx := context.Background()
+
+	// 每个子测试使用独立的用户和 store，封禁和角色修改直接作用在 user 上
+	setup := func() (*model.User, *sessionStore, service.UserService) {
+		user := &model.User{ID: 1, Username: "testuser", Password: string(hashedPassword), Role: auth.RoleModerator}
+		mockStore := newSessionStore(ctrl)
+		for _, u := range []*model.User{user, admin} {
+			mockStore.EXPECT().GetUserByID(gomock.Any(), u.ID).Return(u, nil).AnyTimes()
+			mockStore.EXPECT().GetUserByUsername(gomock.Any(), u.Username).Return(u, nil).AnyTimes()
+		}
+		mockStore.EXPECT().GetUserByID(gomock.Any(), int64(404)).Return(nil, sql.ErrNoRows).AnyTimes()
+		mockStore.EXPECT().SuspendUser(gomock.Any(), user.ID, gomock.Any(), gomock.Any(), admin.ID).
+			DoAndReturn(func(ctx context.Context, userID int64, until *time.Time, reason string, adminID int64) error {
+				now := time.Now()
+				user.SuspendedAt, user.SuspendedUntil, user.SuspensionReason, user.SuspendedBy = &now, until, reason, &adminID
+				return nil
+			}).AnyTimes()
+		mockStore.EXPECT().UnsuspendUser(gomock.Any(), user.ID).
+			DoAndReturn(func(ctx context.Context, userID int64) error {
+				user.SuspendedAt, user.SuspendedUntil, user.SuspensionReason, user.SuspendedBy = nil, nil, "", nil
+				return nil
+			}).AnyTimes()
+		mockStore.EXPECT().SetUserRole(gomock.Any(), user.ID, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, userID int64, role string) error {
+				user.Role = role
+				return nil
+			}).AnyTimes()
+		return user, mockStore, service.NewUserService(mockStore, mail.NewFileMailer(""), testSigner, testProducer, testBlobs)
+	}
+
+	t.Run("封禁后会话失效，不能登录和刷新，解除封禁后可以重新登录", func(t *testing.T) {
+		user, _, userService := setup()
+
+		login, err := userService.Login(ctx, user.Username, "correctpassword")
+		assert.NoError(t, err)
+
+		until := time.Now().Add(24 * time.Hour)
+		resp, err := userService.SuspendUser(ctx, adminIdentity, dto.SuspendUserRequest{UserID: user.ID, Reason: " 发布垃圾广告 ", Until: &until})
+		assert.NoError(t, err)
+		assert.True(t, resp.Suspended)
+		assert.Equal(t, "发布垃圾广告", resp.SuspensionReason)
+		assert.Equal(t, admin.ID, resp.SuspendedBy)
+
+		_, err = userService.ValidateToken(ctx, login.AccessToken)
+		assert.Error(t, err, "已签发的访问令牌随会话一起失效")
+		_, err = userService.RefreshToken(ctx, login.RefreshToken)
+		assert.Error(t, err)
+		_, err = userService.Login(ctx, user.Username, "correctpassword")
+		assert.ErrorIs(t, err, service.ErrUserSuspended)
+		assert.Contains(t, err.Error(), "发布垃圾广告")
+		_, err = userService.Login(ctx, user.Username, "wrongpassword")
+		assert.ErrorIs(t, err, service.ErrInvalidCredentials, "密码错误时不提示封禁状态")
+
+		resp, err = userService.UnsuspendUser(ctx, adminIdentity, user.ID)
+		assert.NoError(t, err)
+		assert.False(t, resp.Suspended)
+		_, err = userService.Login(ctx, user.Username, "correctpassword")
+		assert.NoError(t, err)
+	})
+
+	t.Run("封禁期间个人访问令牌不可用", func(t *testing.T) {
+		user, _, userService := setup()
+		userIdentity := auth.Identity{UserID: user.ID, Username: user.Username}
+
+		created, err := userService.CreateAccessToken(ctx, userIdentity, dto.CreateAccessTokenRequest{Name: "ci", Scopes: []string{auth.ScopeRead}})
+		assert.NoError(t, err)
+
+		_, err = userService.SuspendUser(ctx, adminIdentity, dto.SuspendUserRequest{UserID: user.ID, Reason: "滥用接口"})
+		assert.NoError(t, err)
+		_, err = userService.ValidateToken(ctx, created.Token)
+		assert.ErrorIs(t, err, service.ErrUserSuspended)
+	})
+
+	t.Run("封禁到期后自动失效", func(t *testing.T) {
+		user, _, userService := setup()
+		suspendedAt, until := time.Now().Add(-48*time.Hour), time.Now().Add(-time.Hour)
+		user.SuspendedAt, user.SuspendedUntil, user.SuspensionReason = &suspendedAt, &until, "已过期"
+
+		_, err := userService.Login(ctx, user.Username, "correctpassword")
+		assert.NoError(t, err)
+	})
+
+	t.Run("封禁参数校验", func(t *testing.T) {
+		user, _, userService := setup()
+		past := time.Now().Add(-time.Minute)
+
+		_, err := userService.SuspendUser(ctx, adminIdentity, dto.SuspendUserRequest{UserID: admin.ID, Reason: "测试"})
+		assert.ErrorIs(t, err, service.ErrCannotModifySelf)
+		_, err = userService.SuspendUser(ctx, adminIdentity, dto.SuspendUserRequest{UserID: user.ID, Reason: "  "})
+		assert.ErrorIs(t, err, service.ErrSuspensionReasonRequired)
+		_, err = userService.SuspendUser(ctx, adminIdentity, dto.SuspendUserRequest{UserID: user.ID, Reason: "测试", Until: &past})
+		assert.ErrorIs(t, err, service.ErrInvalidSuspensionExpiry)
+		_, err = userService.SuspendUser(ctx, adminIdentity, dto.SuspendUserRequest{UserID: 404, Reason: "测试"})
+		assert.ErrorIs(t, err, service.ErrUserNotFound)
+		assert.Nil(t, user.SuspendedAt)
+	})
+
+	t.Run("降低角色时撤销会话，提升角色时保留会话", func(t *testing.T) {
+		user, _, userService := setup()
+
+		_, err := userService.SetUserRole(ctx, adminIdentity, user.ID, "superuser")
+		assert.ErrorIs(t, err, service.ErrInvalidRole)
+		_, err = userService.SetUserRole(ctx, adminIdentity, admin.ID, auth.RoleUser)
+		assert.ErrorIs(t, err, service.ErrCannotModifySelf)
+
+		login, err := userService.Login(ctx, user.Username, "correctpassword")
+		assert.NoError(t, err)
+		resp, err := userService.SetUserRole(ctx, adminIdentity, user.ID, auth.RoleAdmin)
+		assert.NoError(t, err)
+		assert.Equal(t, auth.RoleAdmin, resp.Role)
+		_, err = userService.ValidateToken(ctx, login.AccessToken)
+		assert.NoError(t, err)
+
+		resp, err = userService.SetUserRole(ctx, adminIdentity, user.ID, auth.RoleUser)
+		assert.NoError(t, err)
+		assert.Equal(t, auth.RoleUser, resp.Role)
+		_, err = userService.ValidateToken(ctx, login.AccessToken)
+		assert.Error(t, err)
+	})
+
+	t.Run("强制下线撤销全部会话", func(t *testing.T) {
+		user, _, userService := setup()
+
+		phone, err := userService.Login(ctx, user.Username, "correctpassword")
+		assert.NoError(t, err)
+		laptop, err := userService.Login(ctx, user.Username, "correctpassword")
+		assert.NoError(t, err)
+
+		revoked, err := userService.ForceLogout(ctx, adminIdentity, user.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, revoked)
+		_, err = userService.ValidateToken(ctx, phone.AccessToken)
+		assert.Error(t, err)
+		_, err = userService.RefreshToken(ctx, laptop.RefreshToken)
+		assert.ErrorIs(t, err, service.ErrInvalidRefreshToken)
+
+		_, err = userService.ForceLogout(ctx, adminIdentity, 404)
+		assert.ErrorIs(t, err, service.ErrUserNotFound)
+	})
+
+	t.Run("分页查询用户列表", func(t *testing.T) {
+		user, mockStore, userService := setup()
+		suspended := true
+		filter := model.UserFilter{Query: "test", Suspended: &suspended}
+		suspendedAt := time.Now()
+		user.SuspendedAt = &suspendedAt
+		mockStore.EXPECT().ListUsers(gomock.Any(), filter, 20, 20).Return([]*model.User{user}, int64(21), nil)
+
+		users, total, err := userService.ListUsers(ctx, filter, 2, 20)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(21), total)
+		assert.Len(t, users, 1)
+		assert.Equal(t, auth.RoleModerator, users[0].Role)
+		assert.True(t, users[0].Suspended)
+	})
+}
+
+func TestImportUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := service.NewMockUserStore(ctrl)
+	userService := service.NewUserService(mockStore, mail.NewFileMailer(""), testSigner, testProducer, testBlobs)
+	adminIdentity := auth.Identity{UserID: 3, Username: "admin", Claims: map[string]any{"role": auth.RoleAdmin}}
+	ctx := context.Background()
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("oldpassword"), bcrypt.MinCost)
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("保留导入的字段和密码哈希", func(t *testing.T) {
+		mockStore.EXPECT().GetUserByEmail(ctx, "alice@example.com").Return(nil, sql.ErrNoRows).Times(1)
+		mockStore.EXPECT().
+			ImportUser(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, u *model.User) (int64, error) {
+				assert.Equal(t, "Alice", u.Username)
+				assert.Equal(t, string(hashedPassword), u.Password)
+				assert.Equal(t, auth.RoleModerator, u.Role)
+				assert.Equal(t, auth.UserTypeHuman, u.UserType)
+				assert.Equal(t, "en", u.Language)
+				assert.Equal(t, createdAt, u.CreatedAt)
+				return 10, nil
+			}).
+			Times(1)
+
+		result, err := userService.ImportUser(ctx, adminIdentity, dto.ImportUserRequest{
+			Username:     "Alice",
+			Email:        "alice@example.com",
+			PasswordHash: string(hashedPassword),
+			Role:         auth.RoleModerator,
+			Language:     "en-US",
+			CreatedAt:    createdAt,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &dto.ImportUserResult{UserID: 10, Username: "Alice"}, result)
+	})
+
+	t.Run("用户名只有大小写不同也视为冲突，追加后缀后导入", func(t *testing.T) {
+		mockStore.EXPECT().GetUserByEmail(ctx, "alice2@example.com").Return(nil, sql.ErrNoRows).Times(1)
+		gomock.InOrder(
+			mockStore.EXPECT().ImportUser(ctx, gomock.Any()).Return(int64(0), store.ErrDuplicateUsername).Times(1),
+			mockStore.EXPECT().
+				ImportUser(ctx, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, u *model.User) (int64, error) {
+					assert.Equal(t, "alice_2", u.Username)
+					return 11, nil
+				}).
+				Times(1),
+		)
+
+		result, err := userService.ImportUser(ctx, adminIdentity, dto.ImportUserRequest{Username: "alice", Email: "alice2@example.com"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "alice_2", result.Username)
+	})
+
+	t.Run("没有密码哈希时生成随机密码", func(t *testing.T) {
+		mockStore.EXPECT().GetUserByEmail(ctx, "bob@example.com").Return(nil, sql.ErrNoRows).Times(1)
+		mockStore.EXPECT().
+			ImportUser(ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, u *model.User) (int64, error) {
+				_, err := bcrypt.Cost([]byte(u.Password))
+				assert.NoError(t, err)
+				return 12, nil
+			}).
+			Times(1)
+
+		_, err := userService.ImportUser(ctx, adminIdentity, dto.ImportUserRequest{Username: "bob", Email: "bob@example.com"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("邮箱已存在时复用已有用户", func(t *testing.T) {
+		mockStore.EXPECT().
+			GetUserByEmail(ctx, "carol@example.com").
+			Return(&model.User{ID: 5, Username: "carol"}, nil).
+			Times(1)
+
+		result, err := userService.ImportUser(ctx, adminIdentity, dto.ImportUserRequest{Username: "Carol", Email: "carol@example.com"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &dto.ImportUserResult{UserID: 5, Username: "carol", Reused: true}, result)
+	})
+
+	t.Run("不符合注册规则的用户被拒绝", func(t *testing.T) {
+		for _, req := range []dto.ImportUserRequest{
+			{Username: "ab", Email: "ab@example.com"},
+			{Username: "dave", Email: "not-an-email"},
+			{Username: "dave", Email: "dave@example.com", PasswordHash: "plaintext"},
+			{Username: "dave", Email: "dave@example.com", Role: "root"},
+			{Username: "dave", Email: "dave@example.com", Language: "fr"},
+		} {
+			_, err := userService.ImportUser(ctx, adminIdentity, req)
+			assert.ErrorIs(t, err, service.ErrInvalidImportUser)
+		}
+	})
+}
+
 func TestDeleteUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockStore := service.NewMockUserStore(ctrl)
-	userService := service.NewUserService(mockStore)
+	userService := service.NewUserService(mockStore, mail.NewFileMailer(""), testSigner, testProducer, testBlobs)
 	ctx := context.Background()
 
 	t.Run("成功删除用户", func(t *testing.T) {
@@ -0,0 +1,501 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+	"time"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/config"
+	"qahub/pkg/log"
+	"qahub/user-service/internal/dto"
+	"qahub/user-service/internal/model"
+	"qahub/user-service/internal/store"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+var (
+	// ErrInvalidRefreshToken 表示刷新令牌不存在、已过期或所在的会话已被撤销
+	ErrInvalidRefreshToken = errors.New("刷新令牌无效或已过期")
+	// ErrRefreshTokenReused 表示已轮换的刷新令牌被再次使用，所在会话已被撤销
+	ErrRefreshTokenReused = errors.New("刷新令牌已被使用，请重新登录")
+	// ErrSessionNotFound 表示会话不存在或不属于当前用户
+	ErrSessionNotFound = errors.New("会话不存在")
+)
+
+const (
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+	// claimSessionID 是访问令牌中记录会话ID的声明，其他服务据此检查会话是否已被撤销
+	claimSessionID = auth.ClaimSessionID
+)
+
+// RefreshToken 校验并轮换刷新令牌。已轮换过的令牌再次出现说明令牌可能已泄露，
+// 此时撤销整个会话，持有任一令牌的一方都需要重新登录。
+func (s *userService) RefreshToken(ctx context.Context, refreshToken string) (*dto.TokenResponse, error) {
+	logger := log.FromContext(ctx)
+
+	sessions, ok := s.userStore.(store.SessionStore)
+	if !ok {
+		logger.Warn("Store 不支持会话管理")
+		return nil, ErrInvalidRefreshToken
+	}
+
+	tokenHash := hashToken(refreshToken)
+	record, err := sessions.GetRefreshToken(ctx, tokenHash)
+	if err != nil {
+		logger.Error("读取刷新令牌失败",
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	if record == nil || time.Now().After(record.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	revoked, err := sessions.IsSessionRevoked(ctx, record.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check session revocation: %w", err)
+	}
+	if revoked {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	firstUse, err := sessions.MarkRefreshTokenUsed(ctx, tokenHash, time.Until(record.ExpiresAt))
+	if err != nil {
+		logger.Error("标记刷新令牌失败",
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	if !firstUse {
+		logger.Warn("检测到刷新令牌重用，撤销整个会话",
+			slog.Int64("user_id", record.UserID),
+			slog.String("session_id", record.SessionID),
+		)
+		if err := sessions.RevokeSession(ctx, record.UserID, record.SessionID, refreshTokenTTL()); err != nil {
+			logger.Error("撤销会话失败",
+				slog.String("session_id", record.SessionID),
+				slog.String("error", err.Error()),
+			)
+		}
+		return nil, ErrRefreshTokenReused
+	}
+
+	session, err := sessions.GetSession(ctx, record.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	// 重新读取用户，使角色等变更在下一个访问令牌中生效
+	user, err := s.userStore.GetUserByID(ctx, record.UserID)
+	if err != nil {
+		logger.Warn("刷新令牌失败：用户不存在",
+			slog.Int64("user_id", record.UserID),
+		)
+		return nil, ErrInvalidRefreshToken
+	}
+
+	return s.issueTokens(ctx, user, session)
+}
+
+// issueTokens 签发访问令牌和刷新令牌，session 为空时为本次登录创建新会话
+func (s *userService) issueTokens(ctx context.Context, user *model.User, session *model.Session) (*dto.TokenResponse, error) {
+	logger := log.FromContext(ctx)
+
+	if dto.UserType(user) == auth.UserTypeBot {
+		logger.Warn("拒绝为机器人账户签发会话",
+			slog.Int64("user_id", user.ID),
+		)
+		return nil, ErrBotLogin
+	}
+
+	now := time.Now()
+	// 刷新令牌、两步验证和单点登录都经过这里，封禁期间不再签发新令牌
+	if err := checkSuspended(user, now); err != nil {
+		logger.Warn("拒绝为封禁中的用户签发令牌",
+			slog.Int64("user_id", user.ID),
+		)
+		return nil, err
+	}
+	expiresAt := now.Add(accessTokenTTL())
+	jti, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	role, setupRequired, err := s.tokenRole(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	claims := jwt.MapClaims{
+		"user_id":  user.ID,
+		"username": user.Username,
+		"role":     role,
+		"jti":      jti,              // 每个访问令牌唯一的ID
+		"exp":      expiresAt.Unix(), // 访问令牌的过期时间
+		"iat":      now.Unix(),       // token的签发时间
+	}
+	// 邮箱验证状态随令牌下发，其他服务据此限制未验证用户的写操作
+	claims[auth.ClaimEmailVerified] = user.EmailVerifiedAt != nil
+	claims[auth.ClaimUserType] = dto.UserType(user)
+
+	sessions, hasSessions := s.userStore.(store.SessionStore)
+	if hasSessions {
+		if session, err = touchSession(ctx, user.ID, session, now); err != nil {
+			return nil, err
+		}
+		// 会话被撤销后，携带该会话ID的访问令牌随之失效
+		claims[claimSessionID] = session.ID
+	}
+
+	tokenString, err := s.signer.Sign(claims)
+	if err != nil {
+		logger.Error("生成 Token 失败",
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	resp := &dto.TokenResponse{AccessToken: tokenString, ExpiresAt: expiresAt, TwoFactorSetupRequired: setupRequired}
+	if !hasSessions {
+		logger.Warn("Store 不支持会话管理，仅签发访问令牌")
+		return resp, nil
+	}
+
+	refreshToken, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	ttl := refreshTokenTTL()
+	record := &model.RefreshToken{
+		TokenHash: hashToken(refreshToken),
+		UserID:    user.ID,
+		SessionID: session.ID,
+		ExpiresAt: now.Add(ttl),
+	}
+	if err := sessions.SaveSession(ctx, session, ttl); err != nil {
+		logger.Error("保存会话失败",
+			slog.Int64("user_id", user.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	if err := sessions.SaveRefreshToken(ctx, record, ttl); err != nil {
+		logger.Error("保存刷新令牌失败",
+			slog.Int64("user_id", user.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	resp.RefreshToken = refreshToken
+
+	return resp, nil
+}
+
+// touchSession 用本次请求的客户端信息更新会话，session 为空时创建新会话
+func touchSession(ctx context.Context, userID int64, session *model.Session, now time.Time) (*model.Session, error) {
+	if session == nil {
+		id, err := randomToken(16)
+		if err != nil {
+			return nil, err
+		}
+		session = &model.Session{ID: id, UserID: userID, CreatedAt: now}
+	}
+
+	device, ip, userAgent := sessionClientInfo(ctx)
+	if device != "" {
+		session.Device = device
+	}
+	if ip != "" {
+		session.IP = ip
+	}
+	if userAgent != "" {
+		session.UserAgent = userAgent
+	}
+	session.LastSeenAt = now
+	return session, nil
+}
+
+// sessionClientInfo 从 gRPC metadata 和连接信息中提取设备名称、客户端 IP 和 User-Agent
+func sessionClientInfo(ctx context.Context) (device, ip, userAgent string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(keys ...string) string {
+		for _, key := range keys {
+			if values := md.Get(key); len(values) > 0 && values[0] != "" {
+				return values[0]
+			}
+		}
+		return ""
+	}
+
+	device = first("x-device-name")
+	// 经过 grpc-gateway 的请求，原始 User-Agent 会以 grpcgateway- 前缀转发
+	userAgent = first("grpcgateway-user-agent", "user-agent")
+
+	ip = clientIP(ctx, md)
+	return device, ip, userAgent
+}
+
+// clientIP 返回请求的客户端 IP，用于会话记录、登录锁定和限流。
+// 转发头可以由客户端随意填写，只有直连的对端是受信任的代理时才采信；
+// 代理会把对端地址追加到 X-Forwarded-For 末尾，因此从右向左跳过受信任的代理，第一个不受信任的地址就是客户端
+func clientIP(ctx context.Context, md metadata.MD) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	ip := p.Addr.String()
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	if !trustedProxy(ip) {
+		return ip
+	}
+
+	var hops []string
+	for _, value := range md.Get("x-forwarded-for") {
+		for _, hop := range strings.Split(value, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		if !trustedProxy(hops[i]) {
+			return hops[i]
+		}
+	}
+	if len(hops) > 0 {
+		return hops[0]
+	}
+	if values := md.Get("x-real-ip"); len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+	return ip
+}
+
+// trustedProxy 判断地址是否属于配置的受信任代理
+func trustedProxy(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, proxy := range config.Conf.Services.UserService.TrustedProxies {
+		if prefix, err := netip.ParsePrefix(proxy); err == nil {
+			if prefix.Contains(addr) {
+				return true
+			}
+		} else if proxyAddr, err := netip.ParseAddr(proxy); err == nil && proxyAddr.Unmap() == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// Logout 撤销当前会话，会话内的刷新令牌和访问令牌全部失效
+func (s *userService) Logout(ctx context.Context, identity auth.Identity) error {
+	logger := log.FromContext(ctx)
+
+	sessionID, ok := identity.GetStringClaim(claimSessionID)
+	if !ok || sessionID == "" {
+		// 不带会话ID的旧令牌无法单独撤销，只能等待其自然过期
+		logger.Warn("Token 中没有会话ID，无法撤销",
+			slog.Int64("user_id", identity.UserID),
+		)
+		return nil
+	}
+
+	sessions, ok := s.userStore.(store.SessionStore)
+	if !ok {
+		logger.Warn("Store 不支持会话管理")
+		return nil
+	}
+	return sessions.RevokeSession(ctx, identity.UserID, sessionID, refreshTokenTTL())
+}
+
+// ValidateToken 校验 JWT 访问令牌或个人访问令牌，返回令牌所属用户的身份
+func (s *userService) ValidateToken(ctx context.Context, tokenString string) (auth.Identity, error) {
+	if strings.HasPrefix(tokenString, auth.AccessTokenPrefix) {
+		return s.validateAccessToken(ctx, tokenString)
+	}
+
+	identity, err := s.signer.Verify(tokenString)
+	if err != nil {
+		return auth.Identity{}, fmt.Errorf("token parsing error: %w", err)
+	}
+
+	// 会话被撤销 (登出、被其他设备踢下线或检测到刷新令牌重用) 后，尚未过期的访问令牌同样失效
+	if sessionID, ok := identity.GetStringClaim(claimSessionID); ok && sessionID != "" {
+		if sessions, ok := s.userStore.(store.SessionStore); ok {
+			revoked, err := sessions.IsSessionRevoked(ctx, sessionID)
+			if err != nil {
+				return auth.Identity{}, fmt.Errorf("failed to check session revocation: %w", err)
+			}
+			if revoked {
+				return auth.Identity{}, errors.New("session is revoked")
+			}
+		}
+	}
+
+	return identity, nil
+}
+
+// GetJWKS 返回全部签名密钥的公钥，包括轮换中尚未启用和即将移除的密钥
+func (s *userService) GetJWKS(ctx context.Context) auth.JWKS {
+	return s.signer.JWKS()
+}
+
+// ListSessions 返回当前用户所有有效的登录会话，按最近活跃时间倒序排列
+func (s *userService) ListSessions(ctx context.Context, identity auth.Identity) ([]*dto.SessionResponse, error) {
+	logger := log.FromContext(ctx)
+
+	sessions, ok := s.userStore.(store.SessionStore)
+	if !ok {
+		logger.Warn("Store 不支持会话管理")
+		return []*dto.SessionResponse{}, nil
+	}
+
+	list, err := sessions.ListSessions(ctx, identity.UserID)
+	if err != nil {
+		logger.Error("获取会话列表失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].LastSeenAt.After(list[j].LastSeenAt)
+	})
+
+	currentID, _ := identity.GetStringClaim(claimSessionID)
+	resp := make([]*dto.SessionResponse, 0, len(list))
+	for _, session := range list {
+		resp = append(resp, dto.NewSessionResponse(session, session.ID == currentID))
+	}
+	return resp, nil
+}
+
+// RevokeSession 撤销当前用户的指定会话，撤销当前会话等同于登出
+func (s *userService) RevokeSession(ctx context.Context, identity auth.Identity, sessionID string) error {
+	logger := log.FromContext(ctx)
+
+	sessions, ok := s.userStore.(store.SessionStore)
+	if !ok {
+		return ErrSessionNotFound
+	}
+
+	session, err := sessions.GetSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != identity.UserID {
+		return ErrSessionNotFound
+	}
+
+	if err := sessions.RevokeSession(ctx, identity.UserID, sessionID, refreshTokenTTL()); err != nil {
+		logger.Error("撤销会话失败",
+			slog.String("session_id", sessionID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	logger.Info("会话已撤销",
+		slog.Int64("user_id", identity.UserID),
+		slog.String("session_id", sessionID),
+	)
+	return nil
+}
+
+// RevokeAllOtherSessions 撤销除当前会话以外的所有会话，返回撤销的数量
+func (s *userService) RevokeAllOtherSessions(ctx context.Context, identity auth.Identity) (int, error) {
+	currentID, _ := identity.GetStringClaim(claimSessionID)
+	revoked, err := s.revokeUserSessions(ctx, identity.UserID, currentID)
+	if err != nil {
+		return revoked, err
+	}
+
+	log.FromContext(ctx).Info("已撤销其他会话",
+		slog.Int64("user_id", identity.UserID),
+		slog.Int("count", revoked),
+	)
+	return revoked, nil
+}
+
+// revokeUserSessions 撤销用户除 exceptID 以外的所有会话，exceptID 为空时全部撤销
+func (s *userService) revokeUserSessions(ctx context.Context, userID int64, exceptID string) (int, error) {
+	sessions, ok := s.userStore.(store.SessionStore)
+	if !ok {
+		return 0, nil
+	}
+
+	list, err := sessions.ListSessions(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	revoked := 0
+	for _, session := range list {
+		if session.ID == exceptID {
+			continue
+		}
+		if err := sessions.RevokeSession(ctx, userID, session.ID, refreshTokenTTL()); err != nil {
+			log.FromContext(ctx).Error("撤销会话失败",
+				slog.String("session_id", session.ID),
+				slog.String("error", err.Error()),
+			)
+			return revoked, err
+		}
+		revoked++
+	}
+	return revoked, nil
+}
+
+// accessTokenTTL 返回访问令牌的有效期，未配置时使用默认值
+func accessTokenTTL() time.Duration {
+	if ttl := config.Conf.Services.UserService.AccessTokenTTL; ttl > 0 {
+		return ttl
+	}
+	return defaultAccessTokenTTL
+}
+
+// refreshTokenTTL 返回刷新令牌的有效期，未配置时使用默认值
+func refreshTokenTTL() time.Duration {
+	if ttl := config.Conf.Services.UserService.RefreshTokenTTL; ttl > 0 {
+		return ttl
+	}
+	return defaultRefreshTokenTTL
+}
+
+// randomToken 生成 n 字节的随机令牌，使用 URL 安全的 base64 编码
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken 计算刷新令牌、重置码等一次性令牌的 SHA-256 哈希，存储中不保存令牌原文
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/config"
+)
+
+// NewSigner 根据配置加载签发访问令牌的密钥。未配置密钥时生成临时密钥，
+// 服务重启或存在多个实例时已签发的访问令牌会失效，只适合本地开发。
+func NewSigner(cfg config.UserService) (*auth.Signer, error) {
+	if len(cfg.SigningKeys) == 0 {
+		key, err := auth.GenerateSigningKey()
+		if err != nil {
+			return nil, err
+		}
+		slog.Warn("未配置访问令牌签名密钥，使用临时生成的密钥",
+			slog.String("kid", key.ID),
+		)
+		return auth.NewSigner(key.ID, key)
+	}
+
+	keys := make([]auth.SigningKey, 0, len(cfg.SigningKeys))
+	for _, keyCfg := range cfg.SigningKeys {
+		data, err := os.ReadFile(keyCfg.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("读取签名密钥 %q 失败: %w", keyCfg.ID, err)
+		}
+		key, err := auth.ParsePrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("签名密钥 %q: %w", keyCfg.ID, err)
+		}
+		keys = append(keys, auth.SigningKey{ID: keyCfg.ID, Key: key})
+	}
+	return auth.NewSigner(cfg.ActiveSigningKey, keys...)
+}
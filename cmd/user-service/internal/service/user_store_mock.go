@@ -18,81 +18,57 @@ import (
 	gomock "go.uber.org/mock/gomock"
 )
 
-// MockTokenBlacklister is a mock of TokenBlacklister interface.
-type MockTokenBlacklister struct {
+// MockUserStore is a mock of UserStore interface.
+type MockUserStore struct {
 	ctrl     *gomock.Controller
-	recorder *MockTokenBlacklisterMockRecorder
+	recorder *MockUserStoreMockRecorder
 	isgomock struct{}
 }
 
-// MockTokenBlacklisterMockRecorder is the mock recorder for MockTokenBlacklister.
-type MockTokenBlacklisterMockRecorder struct {
-	mock *MockTokenBlacklister
+// MockUserStoreMockRecorder is the mock recorder for MockUserStore.
+type MockUserStoreMockRecorder struct {
+	mock *MockUserStore
 }
 
-// NewMockTokenBlacklister creates a new mock instance.
-func NewMockTokenBlacklister(ctrl *gomock.Controller) *MockTokenBlacklister {
-	mock := &MockTokenBlacklister{ctrl: ctrl}
-	mock.recorder = &MockTokenBlacklisterMockRecorder{mock}
+// NewMockUserStore creates a new mock instance.
+func NewMockUserStore(ctrl *gomock.Controller) *MockUserStore {
+	mock := &MockUserStore{ctrl: ctrl}
+	mock.recorder = &MockUserStoreMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockTokenBlacklister) EXPECT() *MockTokenBlacklisterMockRecorder {
+func (m *MockUserStore) EXPECT() *MockUserStoreMockRecorder {
 	return m.recorder
 }
 
-// AddToBlacklist mocks base method.
-func (m *MockTokenBlacklister) AddToBlacklist(ctx context.Context, token string, expiration time.Duration) error {
+// ChangeUsername mocks base method.
+func (m *MockUserStore) ChangeUsername(ctx context.Context, userID int64, oldUsername, newUsername string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddToBlacklist", ctx, token, expiration)
+	ret := m.ctrl.Call(m, "ChangeUsername", ctx, userID, oldUsername, newUsername)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// AddToBlacklist indicates an expected call of AddToBlacklist.
-func (mr *MockTokenBlacklisterMockRecorder) AddToBlacklist(ctx, token, expiration any) *gomock.Call {
+// ChangeUsername indicates an expected call of ChangeUsername.
+func (mr *MockUserStoreMockRecorder) ChangeUsername(ctx, userID, oldUsername, newUsername any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToBlacklist", reflect.TypeOf((*MockTokenBlacklister)(nil).AddToBlacklist), ctx, token, expiration)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeUsername", reflect.TypeOf((*MockUserStore)(nil).ChangeUsername), ctx, userID, oldUsername, newUsername)
 }
 
-// IsBlacklisted mocks base method.
-func (m *MockTokenBlacklister) IsBlacklisted(ctx context.Context, token string) (bool, error) {
+// CreateAccessToken mocks base method.
+func (m *MockUserStore) CreateAccessToken(ctx context.Context, token *model.AccessToken) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsBlacklisted", ctx, token)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "CreateAccessToken", ctx, token)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IsBlacklisted indicates an expected call of IsBlacklisted.
-func (mr *MockTokenBlacklisterMockRecorder) IsBlacklisted(ctx, token any) *gomock.Call {
+// CreateAccessToken indicates an expected call of CreateAccessToken.
+func (mr *MockUserStoreMockRecorder) CreateAccessToken(ctx, token any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlacklisted", reflect.TypeOf((*MockTokenBlacklister)(nil).IsBlacklisted), ctx, token)
-}
-
-// MockUserStore is a mock of UserStore interface.
-type MockUserStore struct {
-	ctrl     *gomock.Controller
-	recorder *MockUserStoreMockRecorder
-	isgomock struct{}
-}
-
-// MockUserStoreMockRecorder is the mock recorder for MockUserStore.
-type MockUserStoreMockRecorder struct {
-	mock *MockUserStore
-}
-
-// NewMockUserStore creates a new mock instance.
-func NewMockUserStore(ctrl *gomock.Controller) *MockUserStore {
-	mock := &MockUserStore{ctrl: ctrl}
-	mock.recorder = &MockUserStoreMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockUserStore) EXPECT() *MockUserStoreMockRecorder {
-	return m.recorder
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccessToken", reflect.TypeOf((*MockUserStore)(nil).CreateAccessToken), ctx, token)
 }
 
 // CreateUser mocks base method.
@@ -110,6 +86,35 @@ func (mr *MockUserStoreMockRecorder) CreateUser(ctx, user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserStore)(nil).CreateUser), ctx, user)
 }
 
+// CreateUserWithIdentity mocks base method.
+func (m *MockUserStore) CreateUserWithIdentity(ctx context.Context, user *model.User, identity *model.UserIdentity) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserWithIdentity", ctx, user, identity)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserWithIdentity indicates an expected call of CreateUserWithIdentity.
+func (mr *MockUserStoreMockRecorder) CreateUserWithIdentity(ctx, user, identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserWithIdentity", reflect.TypeOf((*MockUserStore)(nil).CreateUserWithIdentity), ctx, user, identity)
+}
+
+// DeleteTwoFactor mocks base method.
+func (m *MockUserStore) DeleteTwoFactor(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTwoFactor", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTwoFactor indicates an expected call of DeleteTwoFactor.
+func (mr *MockUserStoreMockRecorder) DeleteTwoFactor(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTwoFactor", reflect.TypeOf((*MockUserStore)(nil).DeleteTwoFactor), ctx, userID)
+}
+
 // DeleteUser mocks base method.
 func (m *MockUserStore) DeleteUser(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
@@ -124,6 +129,80 @@ func (mr *MockUserStoreMockRecorder) DeleteUser(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserStore)(nil).DeleteUser), ctx, id)
 }
 
+// EnableTwoFactor mocks base method.
+func (m *MockUserStore) EnableTwoFactor(ctx context.Context, userID int64, codeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableTwoFactor", ctx, userID, codeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableTwoFactor indicates an expected call of EnableTwoFactor.
+func (mr *MockUserStoreMockRecorder) EnableTwoFactor(ctx, userID, codeHashes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableTwoFactor", reflect.TypeOf((*MockUserStore)(nil).EnableTwoFactor), ctx, userID, codeHashes)
+}
+
+// FollowUser mocks base method.
+func (m *MockUserStore) FollowUser(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FollowUser", ctx, followerID, followeeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FollowUser indicates an expected call of FollowUser.
+func (mr *MockUserStoreMockRecorder) FollowUser(ctx, followerID, followeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FollowUser", reflect.TypeOf((*MockUserStore)(nil).FollowUser), ctx, followerID, followeeID)
+}
+
+// GetAccessTokenByHash mocks base method.
+func (m *MockUserStore) GetAccessTokenByHash(ctx context.Context, tokenHash string) (*model.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccessTokenByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*model.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccessTokenByHash indicates an expected call of GetAccessTokenByHash.
+func (mr *MockUserStoreMockRecorder) GetAccessTokenByHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccessTokenByHash", reflect.TypeOf((*MockUserStore)(nil).GetAccessTokenByHash), ctx, tokenHash)
+}
+
+// GetLatestUsernameChange mocks base method.
+func (m *MockUserStore) GetLatestUsernameChange(ctx context.Context, userID int64) (*model.UsernameChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestUsernameChange", ctx, userID)
+	ret0, _ := ret[0].(*model.UsernameChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestUsernameChange indicates an expected call of GetLatestUsernameChange.
+func (mr *MockUserStoreMockRecorder) GetLatestUsernameChange(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestUsernameChange", reflect.TypeOf((*MockUserStore)(nil).GetLatestUsernameChange), ctx, userID)
+}
+
+// GetTwoFactor mocks base method.
+func (m *MockUserStore) GetTwoFactor(ctx context.Context, userID int64) (*model.TwoFactor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTwoFactor", ctx, userID)
+	ret0, _ := ret[0].(*model.TwoFactor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTwoFactor indicates an expected call of GetTwoFactor.
+func (mr *MockUserStoreMockRecorder) GetTwoFactor(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTwoFactor", reflect.TypeOf((*MockUserStore)(nil).GetTwoFactor), ctx, userID)
+}
+
 // GetUserByEmail mocks base method.
 func (m *MockUserStore) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +233,21 @@ func (mr *MockUserStoreMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockUserStore)(nil).GetUserByID), ctx, id)
 }
 
+// GetUserByPreviousUsername mocks base method.
+func (m *MockUserStore) GetUserByPreviousUsername(ctx context.Context, username string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByPreviousUsername", ctx, username)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByPreviousUsername indicates an expected call of GetUserByPreviousUsername.
+func (mr *MockUserStoreMockRecorder) GetUserByPreviousUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByPreviousUsername", reflect.TypeOf((*MockUserStore)(nil).GetUserByPreviousUsername), ctx, username)
+}
+
 // GetUserByUsername mocks base method.
 func (m *MockUserStore) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
 	m.ctrl.T.Helper()
@@ -169,6 +263,316 @@ func (mr *MockUserStoreMockRecorder) GetUserByUsername(ctx, username any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockUserStore)(nil).GetUserByUsername), ctx, username)
 }
 
+// GetUserIdentity mocks base method.
+func (m *MockUserStore) GetUserIdentity(ctx context.Context, issuer, subject string) (*model.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIdentity", ctx, issuer, subject)
+	ret0, _ := ret[0].(*model.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIdentity indicates an expected call of GetUserIdentity.
+func (mr *MockUserStoreMockRecorder) GetUserIdentity(ctx, issuer, subject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIdentity", reflect.TypeOf((*MockUserStore)(nil).GetUserIdentity), ctx, issuer, subject)
+}
+
+// ImportUser mocks base method.
+func (m *MockUserStore) ImportUser(ctx context.Context, user *model.User) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportUser", ctx, user)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportUser indicates an expected call of ImportUser.
+func (mr *MockUserStoreMockRecorder) ImportUser(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportUser", reflect.TypeOf((*MockUserStore)(nil).ImportUser), ctx, user)
+}
+
+// LinkUserIdentity mocks base method.
+func (m *MockUserStore) LinkUserIdentity(ctx context.Context, identity *model.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkUserIdentity", ctx, identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkUserIdentity indicates an expected call of LinkUserIdentity.
+func (mr *MockUserStoreMockRecorder) LinkUserIdentity(ctx, identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkUserIdentity", reflect.TypeOf((*MockUserStore)(nil).LinkUserIdentity), ctx, identity)
+}
+
+// ListAccessTokens mocks base method.
+func (m *MockUserStore) ListAccessTokens(ctx context.Context, userID int64) ([]*model.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccessTokens", ctx, userID)
+	ret0, _ := ret[0].([]*model.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccessTokens indicates an expected call of ListAccessTokens.
+func (mr *MockUserStoreMockRecorder) ListAccessTokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccessTokens", reflect.TypeOf((*MockUserStore)(nil).ListAccessTokens), ctx, userID)
+}
+
+// ListFollowerIDs mocks base method.
+func (m *MockUserStore) ListFollowerIDs(ctx context.Context, userID int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowerIDs", ctx, userID)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowerIDs indicates an expected call of ListFollowerIDs.
+func (mr *MockUserStoreMockRecorder) ListFollowerIDs(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowerIDs", reflect.TypeOf((*MockUserStore)(nil).ListFollowerIDs), ctx, userID)
+}
+
+// ListFollowers mocks base method.
+func (m *MockUserStore) ListFollowers(ctx context.Context, userID int64, limit, offset int) ([]*model.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowers", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*model.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListFollowers indicates an expected call of ListFollowers.
+func (mr *MockUserStoreMockRecorder) ListFollowers(ctx, userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowers", reflect.TypeOf((*MockUserStore)(nil).ListFollowers), ctx, userID, limit, offset)
+}
+
+// ListFollowing mocks base method.
+func (m *MockUserStore) ListFollowing(ctx context.Context, userID int64, limit, offset int) ([]*model.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowing", ctx, userID, limit, offset)
+	ret0, _ := ret[0].([]*model.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListFollowing indicates an expected call of ListFollowing.
+func (mr *MockUserStoreMockRecorder) ListFollowing(ctx, userID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowing", reflect.TypeOf((*MockUserStore)(nil).ListFollowing), ctx, userID, limit, offset)
+}
+
+// ListReputationTotalsByAnswer mocks base method.
+func (m *MockUserStore) ListReputationTotalsByAnswer(ctx context.Context, answerID int64) ([]*model.ReputationTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReputationTotalsByAnswer", ctx, answerID)
+	ret0, _ := ret[0].([]*model.ReputationTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReputationTotalsByAnswer indicates an expected call of ListReputationTotalsByAnswer.
+func (mr *MockUserStoreMockRecorder) ListReputationTotalsByAnswer(ctx, answerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReputationTotalsByAnswer", reflect.TypeOf((*MockUserStore)(nil).ListReputationTotalsByAnswer), ctx, answerID)
+}
+
+// ListReputationTotalsByQuestion mocks base method.
+func (m *MockUserStore) ListReputationTotalsByQuestion(ctx context.Context, questionID int64) ([]*model.ReputationTotal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReputationTotalsByQuestion", ctx, questionID)
+	ret0, _ := ret[0].([]*model.ReputationTotal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReputationTotalsByQuestion indicates an expected call of ListReputationTotalsByQuestion.
+func (mr *MockUserStoreMockRecorder) ListReputationTotalsByQuestion(ctx, questionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReputationTotalsByQuestion", reflect.TypeOf((*MockUserStore)(nil).ListReputationTotalsByQuestion), ctx, questionID)
+}
+
+// ListUsers mocks base method.
+func (m *MockUserStore) ListUsers(ctx context.Context, filter model.UserFilter, limit, offset int) ([]*model.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, filter, limit, offset)
+	ret0, _ := ret[0].([]*model.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockUserStoreMockRecorder) ListUsers(ctx, filter, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockUserStore)(nil).ListUsers), ctx, filter, limit, offset)
+}
+
+// MarkEmailVerified mocks base method.
+func (m *MockUserStore) MarkEmailVerified(ctx context.Context, id int64, email string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailVerified", ctx, id, email)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkEmailVerified indicates an expected call of MarkEmailVerified.
+func (mr *MockUserStoreMockRecorder) MarkEmailVerified(ctx, id, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerified", reflect.TypeOf((*MockUserStore)(nil).MarkEmailVerified), ctx, id, email)
+}
+
+// RecordReputationEvents mocks base method.
+func (m *MockUserStore) RecordReputationEvents(ctx context.Context, events []*model.ReputationEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordReputationEvents", ctx, events)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordReputationEvents indicates an expected call of RecordReputationEvents.
+func (mr *MockUserStoreMockRecorder) RecordReputationEvents(ctx, events any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordReputationEvents", reflect.TypeOf((*MockUserStore)(nil).RecordReputationEvents), ctx, events)
+}
+
+// RevokeAccessToken mocks base method.
+func (m *MockUserStore) RevokeAccessToken(ctx context.Context, userID, tokenID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAccessToken", ctx, userID, tokenID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAccessToken indicates an expected call of RevokeAccessToken.
+func (mr *MockUserStoreMockRecorder) RevokeAccessToken(ctx, userID, tokenID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAccessToken", reflect.TypeOf((*MockUserStore)(nil).RevokeAccessToken), ctx, userID, tokenID)
+}
+
+// SaveTwoFactorSecret mocks base method.
+func (m *MockUserStore) SaveTwoFactorSecret(ctx context.Context, userID int64, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTwoFactorSecret", ctx, userID, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTwoFactorSecret indicates an expected call of SaveTwoFactorSecret.
+func (mr *MockUserStoreMockRecorder) SaveTwoFactorSecret(ctx, userID, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTwoFactorSecret", reflect.TypeOf((*MockUserStore)(nil).SaveTwoFactorSecret), ctx, userID, secret)
+}
+
+// SetUserRole mocks base method.
+func (m *MockUserStore) SetUserRole(ctx context.Context, userID int64, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRole", ctx, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockUserStoreMockRecorder) SetUserRole(ctx, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockUserStore)(nil).SetUserRole), ctx, userID, role)
+}
+
+// SuspendUser mocks base method.
+func (m *MockUserStore) SuspendUser(ctx context.Context, userID int64, until *time.Time, reason string, adminID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SuspendUser", ctx, userID, until, reason, adminID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SuspendUser indicates an expected call of SuspendUser.
+func (mr *MockUserStoreMockRecorder) SuspendUser(ctx, userID, until, reason, adminID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SuspendUser", reflect.TypeOf((*MockUserStore)(nil).SuspendUser), ctx, userID, until, reason, adminID)
+}
+
+// TouchAccessToken mocks base method.
+func (m *MockUserStore) TouchAccessToken(ctx context.Context, tokenID int64, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAccessToken", ctx, tokenID, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAccessToken indicates an expected call of TouchAccessToken.
+func (mr *MockUserStoreMockRecorder) TouchAccessToken(ctx, tokenID, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAccessToken", reflect.TypeOf((*MockUserStore)(nil).TouchAccessToken), ctx, tokenID, usedAt)
+}
+
+// UnfollowUser mocks base method.
+func (m *MockUserStore) UnfollowUser(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnfollowUser", ctx, followerID, followeeID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnfollowUser indicates an expected call of UnfollowUser.
+func (mr *MockUserStoreMockRecorder) UnfollowUser(ctx, followerID, followeeID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnfollowUser", reflect.TypeOf((*MockUserStore)(nil).UnfollowUser), ctx, followerID, followeeID)
+}
+
+// UnsuspendUser mocks base method.
+func (m *MockUserStore) UnsuspendUser(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnsuspendUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnsuspendUser indicates an expected call of UnsuspendUser.
+func (mr *MockUserStoreMockRecorder) UnsuspendUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnsuspendUser", reflect.TypeOf((*MockUserStore)(nil).UnsuspendUser), ctx, userID)
+}
+
+// UpdateAvatar mocks base method.
+func (m *MockUserStore) UpdateAvatar(ctx context.Context, id int64, avatarKey string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAvatar", ctx, id, avatarKey)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAvatar indicates an expected call of UpdateAvatar.
+func (mr *MockUserStoreMockRecorder) UpdateAvatar(ctx, id, avatarKey any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAvatar", reflect.TypeOf((*MockUserStore)(nil).UpdateAvatar), ctx, id, avatarKey)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockUserStore) UpdatePassword(ctx context.Context, id int64, hashedPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, id, hashedPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockUserStoreMockRecorder) UpdatePassword(ctx, id, hashedPassword any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockUserStore)(nil).UpdatePassword), ctx, id, hashedPassword)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserStore) UpdateUser(ctx context.Context, user *model.User) error {
 	m.ctrl.T.Helper()
@@ -182,3 +586,18 @@ func (mr *MockUserStoreMockRecorder) UpdateUser(ctx, user any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserStore)(nil).UpdateUser), ctx, user)
 }
+
+// UseRecoveryCode mocks base method.
+func (m *MockUserStore) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", ctx, userID, codeHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockUserStoreMockRecorder) UseRecoveryCode(ctx, userID, codeHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockUserStore)(nil).UseRecoveryCode), ctx, userID, codeHash)
+}
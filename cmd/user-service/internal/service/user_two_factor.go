@@ -0,0 +1,385 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/config"
+	"qahub/pkg/log"
+	"qahub/pkg/totp"
+	"qahub/user-service/internal/dto"
+	"qahub/user-service/internal/model"
+	"qahub/user-service/internal/store"
+)
+
+const (
+	twoFactorIssuer              = "QAHub" // 显示在身份验证器中的服务名
+	recoveryCodeCount            = 10
+	defaultTwoFactorChallengeTTL = 5 * time.Minute
+)
+
+var (
+	// ErrTwoFactorAlreadyEnabled 表示两步验证已经启用，不能重新生成密钥
+	ErrTwoFactorAlreadyEnabled = errors.New("两步验证已启用")
+	// ErrTwoFactorNotEnabled 表示用户没有启用两步验证
+	ErrTwoFactorNotEnabled = errors.New("两步验证未启用")
+	// ErrTwoFactorNotEnrolled 表示用户还没有生成待确认的密钥
+	ErrTwoFactorNotEnrolled = errors.New("请先生成两步验证密钥")
+	// ErrInvalidTwoFactorCode 表示验证码或恢复码错误，或验证码已被使用过
+	ErrInvalidTwoFactorCode = errors.New("验证码错误")
+	// ErrInvalidLoginChallenge 表示登录挑战不存在或已过期
+	ErrInvalidLoginChallenge = errors.New("登录已过期，请重新输入用户名和密码")
+)
+
+// recoveryCodeEncoding 生成不含填充的小写恢复码
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Enroll2FA 为用户生成待确认的 TOTP 密钥，重复调用会替换之前未确认的密钥
+func (s *userService) Enroll2FA(ctx context.Context, identity auth.Identity) (*dto.TwoFactorEnrollment, error) {
+	logger := log.FromContext(ctx)
+
+	twoFactor, err := s.userStore.GetTwoFactor(ctx, identity.UserID)
+	if err != nil {
+		logger.Error("获取两步验证状态失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	if twoFactor.Enabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.userStore.SaveTwoFactorSecret(ctx, identity.UserID, secret); err != nil {
+		logger.Error("保存两步验证密钥失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	return &dto.TwoFactorEnrollment{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(twoFactorIssuer, identity.Username, secret),
+	}, nil
+}
+
+// Confirm2FA 校验身份验证器生成的验证码后启用两步验证，返回只展示这一次的恢复码
+func (s *userService) Confirm2FA(ctx context.Context, identity auth.Identity, code string) ([]string, error) {
+	logger := log.FromContext(ctx)
+
+	twoFactor, err := s.userStore.GetTwoFactor(ctx, identity.UserID)
+	if err != nil {
+		logger.Error("获取两步验证状态失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	if twoFactor == nil {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+	if twoFactor.Enabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	// 绑定时只接受 TOTP 验证码，证明用户的身份验证器已经正确导入密钥
+	if ok, err := s.checkTOTP(ctx, twoFactor, code); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		if codes[i], err = newRecoveryCode(); err != nil {
+			return nil, err
+		}
+		hashes[i] = hashToken(normalizeRecoveryCode(codes[i]))
+	}
+	if err := s.userStore.EnableTwoFactor(ctx, identity.UserID, hashes); err != nil {
+		logger.Error("启用两步验证失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	logger.Info("用户已启用两步验证",
+		slog.Int64("user_id", identity.UserID),
+	)
+	return codes, nil
+}
+
+// Disable2FA 校验验证码或恢复码后停用两步验证
+func (s *userService) Disable2FA(ctx context.Context, identity auth.Identity, code string) error {
+	logger := log.FromContext(ctx)
+
+	twoFactor, err := s.userStore.GetTwoFactor(ctx, identity.UserID)
+	if err != nil {
+		logger.Error("获取两步验证状态失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+	if !twoFactor.Enabled() {
+		return ErrTwoFactorNotEnabled
+	}
+
+	if ok, err := s.checkSecondFactor(ctx, twoFactor, code); err != nil {
+		return err
+	} else if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	if err := s.userStore.DeleteTwoFactor(ctx, identity.UserID); err != nil {
+		logger.Error("停用两步验证失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	logger.Info("用户已停用两步验证",
+		slog.Int64("user_id", identity.UserID),
+	)
+	return nil
+}
+
+// TwoFactorEnabled 查询用户是否启用了两步验证
+func (s *userService) TwoFactorEnabled(ctx context.Context, userID int64) (bool, error) {
+	twoFactor, err := s.userStore.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return twoFactor.Enabled(), nil
+}
+
+// startLoginChallenge 在密码校验通过后为启用了两步验证的用户签发登录挑战
+func (s *userService) startLoginChallenge(ctx context.Context, user *model.User) (*dto.TokenResponse, error) {
+	logger := log.FromContext(ctx)
+
+	challenges, ok := s.userStore.(store.TwoFactorStore)
+	if !ok {
+		// 无法签发挑战时拒绝登录，不能因此跳过第二步验证
+		logger.Error("Store 不支持两步验证登录",
+			slog.Int64("user_id", user.ID),
+		)
+		return nil, errors.New("两步验证暂不可用，请稍后再试")
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	if err := challenges.SaveLoginChallenge(ctx, hashToken(token), user.ID, twoFactorChallengeTTL()); err != nil {
+		logger.Error("保存登录挑战失败",
+			slog.Int64("user_id", user.ID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	logger.Info("密码校验通过，等待两步验证",
+		slog.Int64("user_id", user.ID),
+	)
+	return &dto.TokenResponse{ChallengeToken: token}, nil
+}
+
+// Verify2FA 校验登录挑战对应用户的验证码或恢复码，通过后签发正式的令牌。
+// 验证码错误与密码错误一样计入登录失败次数，达到阈值后同样会被锁定。
+func (s *userService) Verify2FA(ctx context.Context, challengeToken, code string) (*dto.TokenResponse, error) {
+	logger := log.FromContext(ctx)
+
+	challenges, ok := s.userStore.(store.TwoFactorStore)
+	if !ok {
+		return nil, ErrInvalidLoginChallenge
+	}
+	challengeHash := hashToken(strings.TrimSpace(challengeToken))
+	userID, err := challenges.GetLoginChallenge(ctx, challengeHash)
+	if err != nil {
+		logger.Error("读取登录挑战失败",
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+	if userID == 0 {
+		return nil, ErrInvalidLoginChallenge
+	}
+
+	user, err := s.userStore.GetUserByID(ctx, userID)
+	if err != nil {
+		logger.Error("两步验证失败：获取用户失败",
+			slog.Int64("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	_, ip, _ := sessionClientInfo(ctx)
+	keys := newLoginAttemptKeys(user.Username, ip)
+	if err := s.checkLoginLock(ctx, keys); err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := s.userStore.GetTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !twoFactor.Enabled() {
+		// 签发挑战后两步验证被停用，要求重新登录
+		return nil, ErrInvalidLoginChallenge
+	}
+
+	ok, err = s.checkSecondFactor(ctx, twoFactor, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		logger.Warn("两步验证失败：验证码错误",
+			slog.Int64("user_id", userID),
+		)
+		s.recordLoginFailure(ctx, keys, user)
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	// 并发的请求中只有一个能删除挑战，保证一个挑战只换取一次令牌
+	if deleted, err := challenges.DeleteLoginChallenge(ctx, challengeHash); err != nil {
+		return nil, err
+	} else if !deleted {
+		return nil, ErrInvalidLoginChallenge
+	}
+
+	s.clearLoginFailures(ctx, keys)
+	return s.issueTokens(ctx, user, nil)
+}
+
+// checkSecondFactor 校验 TOTP 验证码或恢复码，6 位数字按验证码处理，其余按恢复码处理
+func (s *userService) checkSecondFactor(ctx context.Context, twoFactor *model.TwoFactor, code string) (bool, error) {
+	if isTOTPCode(code) {
+		return s.checkTOTP(ctx, twoFactor, code)
+	}
+
+	normalized := normalizeRecoveryCode(code)
+	if normalized == "" {
+		return false, nil
+	}
+	used, err := s.userStore.UseRecoveryCode(ctx, twoFactor.UserID, hashToken(normalized))
+	if err != nil {
+		log.FromContext(ctx).Error("核销恢复码失败",
+			slog.Int64("user_id", twoFactor.UserID),
+			slog.String("error", err.Error()),
+		)
+		return false, err
+	}
+	if used {
+		log.FromContext(ctx).Warn("用户使用了恢复码",
+			slog.Int64("user_id", twoFactor.UserID),
+		)
+	}
+	return used, nil
+}
+
+// checkTOTP 校验 TOTP 验证码，同一个验证码在有效期内只能使用一次
+func (s *userService) checkTOTP(ctx context.Context, twoFactor *model.TwoFactor, code string) (bool, error) {
+	step, ok := totp.Validate(twoFactor.Secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	steps, ok := s.userStore.(store.TwoFactorStore)
+	if !ok {
+		return true, nil
+	}
+	// 时间步在允许的误差范围过去之后才会失效，标记需要保留到那时
+	first, err := steps.MarkTOTPStepUsed(ctx, twoFactor.UserID, step, (2*totp.Skew+1)*totp.Period)
+	if err != nil {
+		log.FromContext(ctx).Error("记录 TOTP 时间步失败",
+			slog.Int64("user_id", twoFactor.UserID),
+			slog.String("error", err.Error()),
+		)
+		return false, err
+	}
+	return first, nil
+}
+
+// tokenRole 返回写入令牌的角色。角色要求两步验证但用户尚未启用时，只授予普通用户权限。
+func (s *userService) tokenRole(ctx context.Context, user *model.User) (role string, setupRequired bool, err error) {
+	role = userRole(user)
+	if !slices.Contains(config.Conf.Services.UserService.TwoFactorRequiredRoles, role) {
+		return role, false, nil
+	}
+
+	twoFactor, err := s.userStore.GetTwoFactor(ctx, user.ID)
+	if err != nil {
+		log.FromContext(ctx).Error("获取两步验证状态失败",
+			slog.Int64("user_id", user.ID),
+			slog.String("error", err.Error()),
+		)
+		return "", false, err
+	}
+	if !twoFactor.Enabled() {
+		log.FromContext(ctx).Warn("角色要求两步验证但用户尚未启用，降级为普通用户权限",
+			slog.Int64("user_id", user.ID),
+			slog.String("role", role),
+		)
+		return auth.RoleUser, true, nil
+	}
+	return role, false, nil
+}
+
+// newRecoveryCode 生成形如 abcde-fghij 的恢复码
+func newRecoveryCode() (string, error) {
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(recoveryCodeEncoding.EncodeToString(buf))
+	return code[:5] + "-" + code[5:], nil
+}
+
+// normalizeRecoveryCode 去掉恢复码中的分隔符和空白并转为小写，便于用户手动输入
+func normalizeRecoveryCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, code)
+}
+
+// isTOTPCode 判断输入是否为 6 位数字的 TOTP 验证码
+func isTOTPCode(code string) bool {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// twoFactorChallengeTTL 返回登录挑战的有效期，未配置时使用默认值
+func twoFactorChallengeTTL() time.Duration {
+	if ttl := config.Conf.Services.UserService.TwoFactorChallengeTTL; ttl > 0 {
+		return ttl
+	}
+	return defaultTwoFactorChallengeTTL
+}
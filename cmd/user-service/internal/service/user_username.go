@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"qahub/pkg/config"
+	"qahub/pkg/log"
+	"qahub/pkg/messaging"
+	"qahub/user-service/internal/dto"
+	"qahub/user-service/internal/store"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultUsernameChangeCooldown = 30 * 24 * time.Hour
+	defaultUserEventsTopic        = "user_events"
+)
+
+var (
+	// ErrUsernameUnchanged 表示新用户名与当前用户名完全相同
+	ErrUsernameUnchanged = errors.New("新用户名与当前用户名相同")
+	// ErrUsernameChangeTooSoon 表示距离上次修改用户名的时间还不到冷却期
+	ErrUsernameChangeTooSoon = errors.New("修改用户名过于频繁")
+	// ErrUserNotFound 表示按用户名（包括曾用名）找不到用户
+	ErrUserNotFound = errors.New("用户不存在")
+)
+
+// ChangeUsername 修改当前用户的用户名。用户名不区分大小写，只修改大小写同样算一次修改；
+// 旧用户名记录在修改历史中，在没有被其他用户占用之前仍然可以找到该用户。
+func (s *userService) ChangeUsername(ctx context.Context, userID int64, newUsername string) (*dto.UserResponse, error) {
+	logger := log.FromContext(ctx)
+
+	user, err := s.userStore.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Username == newUsername {
+		return nil, ErrUsernameUnchanged
+	}
+	if existing, _ := s.userStore.GetUserByUsername(ctx, newUsername); existing != nil && existing.ID != userID {
+		return nil, ErrUsernameTaken
+	}
+
+	last, err := s.userStore.GetLatestUsernameChange(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if last != nil {
+		if next := last.ChangedAt.Add(usernameChangeCooldown()); time.Now().Before(next) {
+			return nil, fmt.Errorf("%w，请在 %s 之后再试", ErrUsernameChangeTooSoon, next.Format(time.DateTime))
+		}
+	}
+
+	if err := s.userStore.ChangeUsername(ctx, userID, user.Username, newUsername); err != nil {
+		if errors.Is(err, store.ErrDuplicateUsername) {
+			return nil, ErrUsernameTaken
+		}
+		logger.Error("修改用户名失败",
+			slog.Int64("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	logger.Info("用户修改了用户名",
+		slog.Int64("user_id", userID),
+		slog.String("old_username", user.Username),
+		slog.String("new_username", newUsername),
+	)
+	s.publishUserUpdated(ctx, userID, newUsername, user.Username)
+
+	user.Username = newUsername
+	return dto.NewUserResponse(user), nil
+}
+
+// GetUserByUsername 按用户名查找用户，找不到时再按曾用名查找，使旧的 @ 链接仍然有效
+func (s *userService) GetUserByUsername(ctx context.Context, username string) (*dto.UserResponse, error) {
+	user, err := s.userStore.GetUserByUsername(ctx, username)
+	if errors.Is(err, sql.ErrNoRows) {
+		user, err = s.userStore.GetUserByPreviousUsername(ctx, username)
+	}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return dto.NewUserResponse(user), nil
+}
+
+// publishUserUpdated 发布用户资料更新事件，发布失败不影响修改结果
+func (s *userService) publishUserUpdated(ctx context.Context, userID int64, username, previousUsername string) {
+	event := messaging.UserUpdatedEvent{
+		Header: messaging.EventHeader{
+			ID:        uuid.New().String(),
+			Type:      messaging.EventUserUpdated,
+			Source:    "user-service",
+			Timestamp: time.Now(),
+		},
+		Payload: messaging.UserPayload{
+			ID:               userID,
+			Username:         username,
+			PreviousUsername: previousUsername,
+			UpdatedAt:        time.Now(),
+		},
+	}
+	if err := s.producer.SendMessage(ctx, userEventsTopic(), event); err != nil {
+		log.FromContext(ctx).Warn("发布用户更新事件失败",
+			slog.Int64("user_id", userID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// usernameChangeCooldown 返回两次修改用户名之间的最短间隔，未配置时使用默认值
+func usernameChangeCooldown() time.Duration {
+	if cooldown := config.Conf.Services.UserService.UsernameChangeCooldown; cooldown > 0 {
+		return cooldown
+	}
+	return defaultUsernameChangeCooldown
+}
+
+// userEventsTopic 返回用户事件的主题，未配置时使用默认值
+func userEventsTopic() string {
+	if topic := strings.TrimSpace(config.Conf.UserEventsDestination()); topic != "" {
+		return topic
+	}
+	return defaultUserEventsTopic
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"qahub/pkg/auth"
+	"qahub/pkg/config"
+	"qahub/pkg/i18n"
+	"qahub/pkg/log"
+	"qahub/user-service/internal/model"
+	"qahub/user-service/internal/store"
+)
+
+const (
+	defaultEmailVerificationTTL   = 24 * time.Hour
+	defaultEmailVerificationLimit = 3
+	emailVerificationWindow       = time.Hour
+)
+
+var (
+	// ErrInvalidVerificationToken 表示验证码不存在、已过期、已被使用，或邮箱在验证前已被修改
+	ErrInvalidVerificationToken = errors.New("验证码无效或已过期")
+	// ErrEmailAlreadyVerified 表示邮箱已经验证过，无需重新发送验证码
+	ErrEmailAlreadyVerified = errors.New("邮箱已验证")
+)
+
+// emailVerificationMails 是邮箱验证邮件的模板，参数依次为用户名、有效小时数、验证码
+var emailVerificationMails = map[string]mailTemplate{
+	i18n.LanguageZH: {
+		subject: "验证你的 QAHub 邮箱",
+		body: "%s，你好：\n\n请在客户端的「个人资料」页面输入以下验证码完成邮箱验证。验证完成前你可以浏览内容，但不能提问、回答或评论。" +
+			"验证码 %d 小时内有效，且只能使用一次：\n\n%s\n\n如果这不是你本人的操作，请忽略这封邮件。\n",
+	},
+	i18n.LanguageEN: {
+		subject: "Verify your QAHub email",
+		body: "Hi %s,\n\nEnter the following code on the \"Profile\" page of the client to verify your email. Until then you can " +
+			"browse, but not ask, answer or comment. The code is valid for %d hours and can only be used once:\n\n%s\n\n" +
+			"If you did not sign up for QAHub, you can ignore this email.\n",
+	},
+}
+
+// VerifyEmail 使用验证码完成邮箱验证。验证码绑定签发时的邮箱，邮箱已被修改时验证码无效。
+func (s *userService) VerifyEmail(ctx context.Context, token string) error {
+	logger := log.FromContext(ctx)
+
+	verificationStore, ok := s.userStore.(store.EmailVerificationStore)
+	if !ok {
+		return ErrInvalidVerificationToken
+	}
+
+	record, err := verificationStore.ConsumeEmailVerificationToken(ctx, hashToken(strings.TrimSpace(token)))
+	if err != nil {
+		logger.Error("读取邮箱验证令牌失败",
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+	if record == nil {
+		return ErrInvalidVerificationToken
+	}
+
+	matched, err := s.userStore.MarkEmailVerified(ctx, record.UserID, record.Email)
+	if err != nil {
+		logger.Error("标记邮箱已验证失败",
+			slog.Int64("user_id", record.UserID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+	if !matched {
+		logger.Warn("邮箱验证失败：邮箱已在验证前被修改",
+			slog.Int64("user_id", record.UserID),
+		)
+		return ErrInvalidVerificationToken
+	}
+
+	logger.Info("用户已验证邮箱",
+		slog.Int64("user_id", record.UserID),
+	)
+	return nil
+}
+
+// ResendVerification 向当前用户的邮箱重新发送验证码，之前发送的验证码随之失效
+func (s *userService) ResendVerification(ctx context.Context, identity auth.Identity) error {
+	logger := log.FromContext(ctx)
+
+	user, err := s.userStore.GetUserByID(ctx, identity.UserID)
+	if err != nil {
+		logger.Error("重发验证邮件失败：获取用户失败",
+			slog.Int64("user_id", identity.UserID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+	if user.EmailVerifiedAt != nil {
+		return ErrEmailAlreadyVerified
+	}
+
+	if limiter, ok := s.userStore.(store.PasswordResetStore); ok {
+		count, err := limiter.IncrRateLimit(ctx, fmt.Sprintf("email_verification:user:%d", user.ID), emailVerificationWindow)
+		if err != nil {
+			logger.Error("验证邮件限流计数失败",
+				slog.String("error", err.Error()),
+			)
+			return err
+		}
+		if count > int64(emailVerificationLimit()) {
+			logger.Warn("验证邮件重发过于频繁",
+				slog.Int64("user_id", user.ID),
+			)
+			return ErrTooManyRequests
+		}
+	}
+
+	return s.startEmailVerification(ctx, user)
+}
+
+// startEmailVerification 为用户当前的邮箱签发验证码，并在后台发送验证邮件
+func (s *userService) startEmailVerification(ctx context.Context, user *model.User) error {
+	logger := log.FromContext(ctx)
+
+	verificationStore, ok := s.userStore.(store.EmailVerificationStore)
+	if !ok {
+		logger.Warn("Store 不支持邮箱验证")
+		return nil
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return err
+	}
+	ttl := emailVerificationTTL()
+	record := &model.EmailVerificationToken{UserID: user.ID, Email: user.Email}
+	if err := verificationStore.SaveEmailVerificationToken(ctx, hashToken(token), record, ttl); err != nil {
+		logger.Error("保存邮箱验证令牌失败",
+			slog.Int64("user_id", user.ID),
+			slog.String("error", err.Error()),
+		)
+		return err
+	}
+
+	go s.sendMail(context.WithoutCancel(ctx), user.ID, user.Email, user.Language, emailVerificationMails,
+		user.Username, int(ttl.Hours()), token)
+	return nil
+}
+
+// emailVerificationTTL 返回验证码的有效期，未配置时使用默认值
+func emailVerificationTTL() time.Duration {
+	if ttl := config.Conf.Services.UserService.EmailVerificationTTL; ttl > 0 {
+		return ttl
+	}
+	return defaultEmailVerificationTTL
+}
+
+// emailVerificationLimit 返回每小时允许重发验证邮件的次数，未配置时使用默认值
+func emailVerificationLimit() int {
+	if limit := config.Conf.Services.UserService.EmailVerificationLimit; limit > 0 {
+		return limit
+	}
+	return defaultEmailVerificationLimit
+}
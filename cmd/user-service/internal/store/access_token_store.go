@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"qahub/user-service/internal/model"
+)
+
+const accessTokenColumns = "id, user_id, name, token_hash, token_hint, scopes, expires_at, last_used_at, revoked_at, created_at"
+
+// CreateAccessToken 保存新的个人访问令牌
+func (s *mySQLUserStore) CreateAccessToken(ctx context.Context, token *model.AccessToken) (int64, error) {
+	query := "INSERT INTO access_tokens (user_id, name, token_hash, token_hint, scopes, expires_at) VALUES (?, ?, ?, ?, ?, ?)"
+	result, err := s.db.ExecContext(ctx, query, token.UserID, token.Name, token.TokenHash, token.TokenHint, token.Scopes, token.ExpiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// GetAccessTokenByHash 根据哈希查找令牌，包括已撤销和已过期的令牌，不存在时返回 nil, nil
+func (s *mySQLUserStore) GetAccessTokenByHash(ctx context.Context, tokenHash string) (*model.AccessToken, error) {
+	var token model.AccessToken
+	query := "SELECT " + accessTokenColumns + " FROM access_tokens WHERE token_hash = ?"
+	if err := s.db.GetContext(ctx, &token, query, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// ListAccessTokens 返回用户尚未撤销的令牌，按创建时间倒序排列
+func (s *mySQLUserStore) ListAccessTokens(ctx context.Context, userID int64) ([]*model.AccessToken, error) {
+	tokens := []*model.AccessToken{}
+	query := "SELECT " + accessTokenColumns + " FROM access_tokens WHERE user_id = ? AND revoked_at IS NULL ORDER BY created_at DESC, id DESC"
+	if err := s.db.SelectContext(ctx, &tokens, query, userID); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// RevokeAccessToken 撤销属于 userID 的令牌，返回是否有令牌被撤销
+func (s *mySQLUserStore) RevokeAccessToken(ctx context.Context, userID, tokenID int64) (bool, error) {
+	query := "UPDATE access_tokens SET revoked_at = NOW() WHERE id = ? AND user_id = ? AND revoked_at IS NULL"
+	result, err := s.db.ExecContext(ctx, query, tokenID, userID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// TouchAccessToken 记录令牌最近一次的使用时间
+func (s *mySQLUserStore) TouchAccessToken(ctx context.Context, tokenID int64, usedAt time.Time) error {
+	_, err := s.db.ExecContext(ctx, "UPDATE access_tokens SET last_used_at = ? WHERE id = ?", usedAt, tokenID)
+	return err
+}
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"qahub/user-service/internal/model"
+)
+
+// adminUserColumns 是管理员用户列表返回的列，不包含密码哈希
+const adminUserColumns = "id, username, email, email_verified_at, bio, avatar_key, role, user_type, suspended_at, suspended_until, suspension_reason, suspended_by, language, reputation, question_count, answer_count, accepted_answer_count, created_at, updated_at"
+
+// suspendedCondition 判断用户当前是否处于封禁状态，与 model.User.Suspended 保持一致
+const suspendedCondition = "(suspended_at IS NOT NULL AND (suspended_until IS NULL OR suspended_until > NOW()))"
+
+// ListUsers 按筛选条件分页查询用户，按注册时间倒序排列，同时返回符合条件的总数
+func (s *mySQLUserStore) ListUsers(ctx context.Context, filter model.UserFilter, limit, offset int) ([]*model.User, int64, error) {
+	var conditions []string
+	var args []any
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(filter.Query) + "%"
+		conditions = append(conditions, "(username LIKE ? OR email LIKE ?)")
+		args = append(args, pattern, pattern)
+	}
+	if filter.Role != "" {
+		conditions = append(conditions, "role = ?")
+		args = append(args, filter.Role)
+	}
+	if filter.UserType != "" {
+		conditions = append(conditions, "user_type = ?")
+		args = append(args, filter.UserType)
+	}
+	if filter.Suspended != nil {
+		if *filter.Suspended {
+			conditions = append(conditions, suspendedCondition)
+		} else {
+			conditions = append(conditions, "NOT "+suspendedCondition)
+		}
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int64
+	if err := s.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM users"+where, args...); err != nil {
+		return nil, 0, err
+	}
+
+	users := []*model.User{}
+	query := "SELECT " + adminUserColumns + " FROM users" + where + " ORDER BY id DESC LIMIT ? OFFSET ?"
+	if err := s.db.SelectContext(ctx, &users, query, append(args, limit, offset)...); err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// SuspendUser 封禁用户，until 为 nil 表示永久封禁。重复封禁会覆盖之前的期限和原因
+func (s *mySQLUserStore) SuspendUser(ctx context.Context, userID int64, until *time.Time, reason string, adminID int64) error {
+	query := "UPDATE users SET suspended_at = NOW(), suspended_until = ?, suspension_reason = ?, suspended_by = ? WHERE id = ?"
+	_, err := s.db.ExecContext(ctx, query, until, reason, adminID, userID)
+	return err
+}
+
+// UnsuspendUser 解除封禁，清除全部封禁字段
+func (s *mySQLUserStore) UnsuspendUser(ctx context.Context, userID int64) error {
+	query := "UPDATE users SET suspended_at = NULL, suspended_until = NULL, suspension_reason = '', suspended_by = NULL WHERE id = ?"
+	_, err := s.db.ExecContext(ctx, query, userID)
+	return err
+}
+
+// SetUserRole 修改用户角色
+func (s *mySQLUserStore) SetUserRole(ctx context.Context, userID int64, role string) error {
+	query := "UPDATE users SET role = ? WHERE id = ?"
+	_, err := s.db.ExecContext(ctx, query, role, userID)
+	return err
+}
+
+// ImportUser 写入从其他实例导入的用户，保留角色、语言、头像和注册时间。
+// 用户名与已有用户冲突（不区分大小写）时返回 ErrDuplicateUsername
+func (s *mySQLUserStore) ImportUser(ctx context.Context, user *model.User) (int64, error) {
+	query := `INSERT INTO users (username, email, email_verified_at, bio, avatar_key, password, role, user_type, language, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	result, err := s.db.ExecContext(ctx, query, user.Username, user.Email, user.EmailVerifiedAt, user.Bio, user.AvatarKey,
+		user.Password, user.Role, user.UserType, user.Language, user.CreatedAt)
+	if err != nil {
+		return 0, usernameConflict(err)
+	}
+	return result.LastInsertId()
+}
+
+// escapeLike 转义 LIKE 模式中的通配符，使搜索词按字面匹配
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,168 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"qahub/user-service/internal/model"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// FeedStore 定义了个人动态时间线所需的方法。动态内容只保存一份，
+// 每个用户的时间线是按发布时间排序的内容标识，内容被删除后时间线中的引用在读取时清理
+type FeedStore interface {
+	// AddFeedItem 保存动态内容并写入每个接收者的时间线，时间线只保留最新的 maxItems 条
+	AddFeedItem(ctx context.Context, item *model.FeedItem, recipientIDs []int64, maxItems int, ttl time.Duration) error
+	// DeleteFeedItem 删除一条动态内容
+	DeleteFeedItem(ctx context.Context, key string) error
+	// DeleteQuestionFeedItems 删除问题及其下全部回答的动态内容
+	DeleteQuestionFeedItems(ctx context.Context, questionID int64) error
+	// GetFeed 按时间倒序分页读取用户的时间线，同时返回时间线的总条数
+	GetFeed(ctx context.Context, userID int64, offset, limit int) ([]*model.FeedItem, int64, error)
+	// RemoveAuthorFromFeed 从用户的时间线中移除某个作者的全部动态，在取消关注时调用
+	RemoveAuthorFromFeed(ctx context.Context, userID, authorID int64) error
+}
+
+// feedKey 生成用户时间线的键
+func feedKey(userID int64) string {
+	return fmt.Sprintf("feed:%d", userID)
+}
+
+// feedItemKey 生成动态内容的键
+func feedItemKey(key string) string {
+	return fmt.Sprintf("feed:item:%s", key)
+}
+
+// feedQuestionAnswersKey 生成问题下回答动态的索引键，删除问题时据此删除其下的回答
+func feedQuestionAnswersKey(questionID int64) string {
+	return fmt.Sprintf("feed:question:%d:answers", questionID)
+}
+
+// AddFeedItem 保存动态内容，并把它加入每个接收者的时间线，时间线的过期时间随最新的动态顺延
+func (s *userCacheStore) AddFeedItem(ctx context.Context, item *model.FeedItem, recipientIDs []int64, maxItems int, ttl time.Duration) error {
+	jsonData, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	key := item.Key()
+	pipe := s.redisClient.Pipeline()
+	pipe.Set(ctx, feedItemKey(key), jsonData, ttl)
+	if item.Type == model.FeedItemAnswer {
+		indexKey := feedQuestionAnswersKey(item.QuestionID)
+		pipe.SAdd(ctx, indexKey, key)
+		pipe.Expire(ctx, indexKey, ttl)
+	}
+	score := float64(item.CreatedAt.UnixMilli())
+	for _, userID := range recipientIDs {
+		timeline := feedKey(userID)
+		pipe.ZAdd(ctx, timeline, redis.Z{Score: score, Member: key})
+		// 按分数从低到高删除超出上限的旧动态
+		pipe.ZRemRangeByRank(ctx, timeline, 0, int64(-maxItems-1))
+		pipe.Expire(ctx, timeline, ttl)
+	}
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// DeleteFeedItem 删除动态内容，时间线中的引用在读取时清理
+func (s *userCacheStore) DeleteFeedItem(ctx context.Context, key string) error {
+	return s.redisClient.Del(ctx, feedItemKey(key)).Err()
+}
+
+// DeleteQuestionFeedItems 删除问题及其下全部回答的动态内容，问题删除时回答被级联删除
+func (s *userCacheStore) DeleteQuestionFeedItems(ctx context.Context, questionID int64) error {
+	indexKey := feedQuestionAnswersKey(questionID)
+	answers, err := s.redisClient.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := []string{feedItemKey(model.FeedItemKey(model.FeedItemQuestion, questionID)), indexKey}
+	for _, key := range answers {
+		keys = append(keys, feedItemKey(key))
+	}
+	return s.redisClient.Del(ctx, keys...).Err()
+}
+
+// GetFeed 分页读取时间线，顺带清理已被删除或过期的动态
+func (s *userCacheStore) GetFeed(ctx context.Context, userID int64, offset, limit int) ([]*model.FeedItem, int64, error) {
+	timeline := feedKey(userID)
+	total, err := s.redisClient.ZCard(ctx, timeline).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+	members, err := s.redisClient.ZRevRange(ctx, timeline, int64(offset), int64(offset+limit-1)).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	items, missing, err := s.loadFeedItems(ctx, members)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(missing) > 0 {
+		s.redisClient.ZRem(ctx, timeline, missing...)
+		total -= int64(len(missing))
+	}
+	return items, total, nil
+}
+
+// RemoveAuthorFromFeed 从时间线中移除某个作者的动态，时间线有长度上限，可以整体读取
+func (s *userCacheStore) RemoveAuthorFromFeed(ctx context.Context, userID, authorID int64) error {
+	timeline := feedKey(userID)
+	members, err := s.redisClient.ZRange(ctx, timeline, 0, -1).Result()
+	if err != nil {
+		return err
+	}
+
+	items, removed, err := s.loadFeedItems(ctx, members)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if item.AuthorID == authorID {
+			removed = append(removed, item.Key())
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	return s.redisClient.ZRem(ctx, timeline, removed...).Err()
+}
+
+// loadFeedItems 按顺序读取动态内容，同时返回已不存在的内容标识
+func (s *userCacheStore) loadFeedItems(ctx context.Context, members []string) ([]*model.FeedItem, []any, error) {
+	items := make([]*model.FeedItem, 0, len(members))
+	if len(members) == 0 {
+		return items, nil, nil
+	}
+
+	keys := make([]string, len(members))
+	for i, member := range members {
+		keys[i] = feedItemKey(member)
+	}
+	vals, err := s.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var missing []any
+	for i, val := range vals {
+		str, ok := val.(string)
+		if !ok {
+			missing = append(missing, members[i])
+			continue
+		}
+		var item model.FeedItem
+		if json.Unmarshal([]byte(str), &item) != nil {
+			missing = append(missing, members[i])
+			continue
+		}
+		items = append(items, &item)
+	}
+	return items, missing, nil
+}
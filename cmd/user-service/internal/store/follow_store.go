@@ -0,0 +1,77 @@
+package store
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+
+	"qahub/user-service/internal/model"
+)
+
+// followUserColumns 是关注列表返回的用户列，不包含密码哈希
+const followUserColumns = "u.id, u.username, u.email, u.email_verified_at, u.bio, u.avatar_key, u.role, u.user_type, u.language, u.reputation, u.question_count, u.answer_count, u.accepted_answer_count, u.created_at, u.updated_at"
+
+// FollowUser 记录关注关系，已经关注时返回 false
+func (s *mySQLUserStore) FollowUser(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	query := "INSERT INTO user_follows (follower_id, followee_id) VALUES (?, ?)"
+	if _, err := s.db.ExecContext(ctx, query, followerID, followeeID); err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// UnfollowUser 删除关注关系，没有关注时返回 false
+func (s *mySQLUserStore) UnfollowUser(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	query := "DELETE FROM user_follows WHERE follower_id = ? AND followee_id = ?"
+	result, err := s.db.ExecContext(ctx, query, followerID, followeeID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// ListFollowers 分页返回关注了 userID 的用户，按关注时间倒序排列，同时返回总数
+func (s *mySQLUserStore) ListFollowers(ctx context.Context, userID int64, limit, offset int) ([]*model.User, int64, error) {
+	var total int64
+	if err := s.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM user_follows WHERE followee_id = ?", userID); err != nil {
+		return nil, 0, err
+	}
+
+	users := []*model.User{}
+	query := "SELECT " + followUserColumns + " FROM user_follows f JOIN users u ON u.id = f.follower_id WHERE f.followee_id = ? ORDER BY f.created_at DESC, f.follower_id DESC LIMIT ? OFFSET ?"
+	if err := s.db.SelectContext(ctx, &users, query, userID, limit, offset); err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// ListFollowing 分页返回 userID 关注的用户，按关注时间倒序排列，同时返回总数
+func (s *mySQLUserStore) ListFollowing(ctx context.Context, userID int64, limit, offset int) ([]*model.User, int64, error) {
+	var total int64
+	if err := s.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM user_follows WHERE follower_id = ?", userID); err != nil {
+		return nil, 0, err
+	}
+
+	users := []*model.User{}
+	query := "SELECT " + followUserColumns + " FROM user_follows f JOIN users u ON u.id = f.followee_id WHERE f.follower_id = ? ORDER BY f.created_at DESC, f.followee_id DESC LIMIT ? OFFSET ?"
+	if err := s.db.SelectContext(ctx, &users, query, userID, limit, offset); err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+// ListFollowerIDs 返回关注了 userID 的全部用户ID，用于把动态分发到关注者的时间线
+func (s *mySQLUserStore) ListFollowerIDs(ctx context.Context, userID int64) ([]int64, error) {
+	ids := []int64{}
+	query := "SELECT follower_id FROM user_follows WHERE followee_id = ?"
+	if err := s.db.SelectContext(ctx, &ids, query, userID); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
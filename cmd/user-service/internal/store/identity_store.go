@@ -0,0 +1,63 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"qahub/user-service/internal/model"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// GetUserIdentity 根据 issuer 和 sub 查找外部身份，尚未关联时返回 nil, nil
+func (s *mySQLUserStore) GetUserIdentity(ctx context.Context, issuer, subject string) (*model.UserIdentity, error) {
+	var identity model.UserIdentity
+	query := "SELECT id, user_id, provider, issuer, subject, email, created_at FROM user_identities WHERE issuer = ? AND subject = ?"
+	if err := s.db.GetContext(ctx, &identity, query, issuer, subject); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// CreateUserWithIdentity 在一个事务中创建用户并关联外部身份，用于首次单点登录时自动开通账户
+func (s *mySQLUserStore) CreateUserWithIdentity(ctx context.Context, user *model.User, identity *model.UserIdentity) (int64, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := "INSERT INTO users (username, email, bio, password, email_verified_at) VALUES (?, ?, ?, ?, ?)"
+	result, err := tx.ExecContext(ctx, query, user.Username, user.Email, user.Bio, user.Password, user.EmailVerifiedAt)
+	if err != nil {
+		return 0, usernameConflict(err)
+	}
+	userID, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := insertUserIdentity(ctx, tx, userID, identity); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return userID, nil
+}
+
+// LinkUserIdentity 将外部身份关联到已有的用户
+func (s *mySQLUserStore) LinkUserIdentity(ctx context.Context, identity *model.UserIdentity) error {
+	return insertUserIdentity(ctx, s.db, identity.UserID, identity)
+}
+
+// insertUserIdentity 插入一条外部身份记录，db 可以是连接或事务
+func insertUserIdentity(ctx context.Context, db sqlx.ExecerContext, userID int64, identity *model.UserIdentity) error {
+	query := "INSERT INTO user_identities (user_id, provider, issuer, subject, email) VALUES (?, ?, ?, ?, ?)"
+	_, err := db.ExecContext(ctx, query, userID, identity.Provider, identity.Issuer, identity.Subject, identity.Email)
+	return err
+}
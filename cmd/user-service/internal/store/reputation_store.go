@@ -0,0 +1,73 @@
+package store
+
+import (
+	"context"
+
+	"qahub/user-service/internal/model"
+)
+
+// refreshUserStatsQuery 根据声望流水重新汇总用户的声望与统计字段。
+// 统计数只计算尚未被 content_removed 冲销的内容，保证它们总能由流水完整推导出来。
+const refreshUserStatsQuery = `
+UPDATE users SET
+	reputation = (SELECT COALESCE(SUM(points), 0) FROM reputation_events WHERE user_id = ?),
+	question_count = (SELECT COUNT(*) FROM reputation_events e WHERE e.user_id = ? AND e.reason = 'question_asked' AND NOT EXISTS (
+		SELECT 1 FROM reputation_events r WHERE r.user_id = e.user_id AND r.reason = 'content_removed' AND r.source_type = e.source_type AND r.source_id = e.source_id)),
+	answer_count = (SELECT COUNT(*) FROM reputation_events e WHERE e.user_id = ? AND e.reason = 'answer_posted' AND NOT EXISTS (
+		SELECT 1 FROM reputation_events r WHERE r.user_id = e.user_id AND r.reason = 'content_removed' AND r.source_type = e.source_type AND r.source_id = e.source_id)),
+	accepted_answer_count = (SELECT COUNT(*) FROM reputation_events e WHERE e.user_id = ? AND e.reason = 'answer_accepted' AND NOT EXISTS (
+		SELECT 1 FROM reputation_events r WHERE r.user_id = e.user_id AND r.reason = 'content_removed' AND r.source_type = e.source_type AND r.source_id = e.source_id))
+WHERE id = ?`
+
+// RecordReputationEvents 在一个事务中写入声望流水并刷新受影响用户的统计字段。
+// 同一事件重复投递时，唯一键 (event_id, user_id, source_type, source_id) 保证流水不会重复记账。
+func (s *mySQLUserStore) RecordReputationEvents(ctx context.Context, events []*model.ReputationEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	insert := `INSERT IGNORE INTO reputation_events (user_id, event_id, reason, points, source_type, source_id, question_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+	userIDs := make(map[int64]struct{})
+	for _, e := range events {
+		if _, err := tx.ExecContext(ctx, insert, e.UserID, e.EventID, e.Reason, e.Points, e.SourceType, e.SourceID, e.QuestionID); err != nil {
+			return err
+		}
+		userIDs[e.UserID] = struct{}{}
+	}
+
+	for id := range userIDs {
+		if _, err := tx.ExecContext(ctx, refreshUserStatsQuery, id, id, id, id, id); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ListReputationTotalsByQuestion 按用户和内容汇总某个问题（含其下所有回答）产生的积分
+func (s *mySQLUserStore) ListReputationTotalsByQuestion(ctx context.Context, questionID int64) ([]*model.ReputationTotal, error) {
+	var totals []*model.ReputationTotal
+	query := `SELECT user_id, source_type, source_id, SUM(points) AS points FROM reputation_events
+		WHERE question_id = ? GROUP BY user_id, source_type, source_id`
+	if err := s.db.SelectContext(ctx, &totals, query, questionID); err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// ListReputationTotalsByAnswer 按用户汇总某个回答产生的积分
+func (s *mySQLUserStore) ListReputationTotalsByAnswer(ctx context.Context, answerID int64) ([]*model.ReputationTotal, error) {
+	var totals []*model.ReputationTotal
+	query := `SELECT user_id, source_type, source_id, SUM(points) AS points FROM reputation_events
+		WHERE source_type = 'answer' AND source_id = ? GROUP BY user_id, source_type, source_id`
+	if err := s.db.SelectContext(ctx, &totals, query, answerID); err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
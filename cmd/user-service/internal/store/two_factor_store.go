@@ -0,0 +1,83 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"qahub/user-service/internal/model"
+)
+
+// GetTwoFactor 获取用户的两步验证密钥，用户从未绑定过时返回 nil, nil
+func (s *mySQLUserStore) GetTwoFactor(ctx context.Context, userID int64) (*model.TwoFactor, error) {
+	var twoFactor model.TwoFactor
+	query := "SELECT user_id, secret, enabled_at, created_at FROM user_two_factor WHERE user_id = ?"
+	if err := s.db.GetContext(ctx, &twoFactor, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &twoFactor, nil
+}
+
+// SaveTwoFactorSecret 保存待确认的密钥，已启用的两步验证不会被覆盖
+func (s *mySQLUserStore) SaveTwoFactorSecret(ctx context.Context, userID int64, secret string) error {
+	query := `INSERT INTO user_two_factor (user_id, secret) VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE secret = IF(enabled_at IS NULL, VALUES(secret), secret)`
+	_, err := s.db.ExecContext(ctx, query, userID, secret)
+	return err
+}
+
+// EnableTwoFactor 在一个事务中启用两步验证，并用新的恢复码替换旧的恢复码
+func (s *mySQLUserStore) EnableTwoFactor(ctx context.Context, userID int64, codeHashes []string) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "UPDATE user_two_factor SET enabled_at = NOW() WHERE user_id = ?", userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM user_recovery_codes WHERE user_id = ?", userID); err != nil {
+		return err
+	}
+	for _, hash := range codeHashes {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO user_recovery_codes (user_id, code_hash) VALUES (?, ?)", userID, hash); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// DeleteTwoFactor 在一个事务中删除密钥和全部恢复码
+func (s *mySQLUserStore) DeleteTwoFactor(ctx context.Context, userID int64) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM user_recovery_codes WHERE user_id = ?", userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM user_two_factor WHERE user_id = ?", userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// UseRecoveryCode 将未使用的恢复码标记为已使用，恢复码不存在或已被使用时返回 false
+func (s *mySQLUserStore) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	query := "UPDATE user_recovery_codes SET used_at = NOW() WHERE user_id = ? AND code_hash = ? AND used_at IS NULL"
+	result, err := s.db.ExecContext(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
@@ -4,18 +4,79 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
+	"qahub/pkg/auth"
 	"qahub/pkg/health"
 	"qahub/user-service/internal/model"
 
 	"github.com/redis/go-redis/v9"
 )
 
-// TokenBlacklister 定义了 JWT 黑名单所需的方法
-type TokenBlacklister interface {
-	AddToBlacklist(ctx context.Context, token string, expiration time.Duration) error
-	IsBlacklisted(ctx context.Context, token string) (bool, error)
+// SessionStore 定义了登录会话登记与刷新令牌轮换所需的方法
+type SessionStore interface {
+	// SaveSession 创建或更新会话，并将其加入用户的会话索引
+	SaveSession(ctx context.Context, session *model.Session, expiration time.Duration) error
+	// GetSession 在会话不存在或已过期时返回 nil, nil
+	GetSession(ctx context.Context, sessionID string) (*model.Session, error)
+	ListSessions(ctx context.Context, userID int64) ([]*model.Session, error)
+	// RevokeSession 删除会话并留下撤销标记，标记需要保留到族内最后一个令牌过期为止
+	RevokeSession(ctx context.Context, userID int64, sessionID string, expiration time.Duration) error
+	IsSessionRevoked(ctx context.Context, sessionID string) (bool, error)
+
+	SaveRefreshToken(ctx context.Context, token *model.RefreshToken, expiration time.Duration) error
+	// GetRefreshToken 在令牌不存在或已过期时返回 nil, nil
+	GetRefreshToken(ctx context.Context, tokenHash string) (*model.RefreshToken, error)
+	// MarkRefreshTokenUsed 原子地将令牌标记为已使用，令牌此前已被使用过时返回 false
+	MarkRefreshTokenUsed(ctx context.Context, tokenHash string, expiration time.Duration) (bool, error)
+}
+
+// PasswordResetStore 定义了密码重置令牌与请求限流所需的方法
+type PasswordResetStore interface {
+	// SavePasswordResetToken 保存重置令牌，同一用户之前未使用的令牌随之作废
+	SavePasswordResetToken(ctx context.Context, tokenHash string, userID int64, expiration time.Duration) error
+	// ConsumePasswordResetToken 原子地取出并删除令牌，令牌不存在或已过期时返回 0
+	ConsumePasswordResetToken(ctx context.Context, tokenHash string) (int64, error)
+	// IncrRateLimit 对限流键计数并返回窗口内的累计次数，窗口从第一次计数开始
+	IncrRateLimit(ctx context.Context, key string, window time.Duration) (int64, error)
+}
+
+// EmailVerificationStore 定义了邮箱验证令牌所需的方法，重发限流复用 PasswordResetStore.IncrRateLimit
+type EmailVerificationStore interface {
+	// SaveEmailVerificationToken 保存验证令牌，同一用户之前未使用的令牌随之作废
+	SaveEmailVerificationToken(ctx context.Context, tokenHash string, token *model.EmailVerificationToken, expiration time.Duration) error
+	// ConsumeEmailVerificationToken 原子地取出并删除令牌，令牌不存在或已过期时返回 nil, nil
+	ConsumeEmailVerificationToken(ctx context.Context, tokenHash string) (*model.EmailVerificationToken, error)
+}
+
+// LoginAttemptStore 定义了登录失败计数与临时锁定所需的方法，键由调用方区分用户名和 IP
+type LoginAttemptStore interface {
+	// IncrLoginFailures 增加失败计数并返回累计次数，计数在最后一次失败 window 之后清零
+	IncrLoginFailures(ctx context.Context, key string, window time.Duration) (int64, error)
+	LockLogin(ctx context.Context, key string, duration time.Duration) error
+	// LoginLockRemaining 返回剩余的锁定时间，未锁定时返回 0
+	LoginLockRemaining(ctx context.Context, key string) (time.Duration, error)
+	// ClearLoginFailures 清除失败计数并解除锁定
+	ClearLoginFailures(ctx context.Context, key string) error
+}
+
+// TwoFactorStore 定义了两步验证登录挑战与防重放所需的方法，验证码错误计入 LoginAttemptStore 的失败次数
+type TwoFactorStore interface {
+	SaveLoginChallenge(ctx context.Context, tokenHash string, userID int64, expiration time.Duration) error
+	// GetLoginChallenge 在挑战不存在或已过期时返回 0
+	GetLoginChallenge(ctx context.Context, tokenHash string) (int64, error)
+	// DeleteLoginChallenge 删除挑战，挑战已被并发的请求删除时返回 false，保证一个挑战只换取一次令牌
+	DeleteLoginChallenge(ctx context.Context, tokenHash string) (bool, error)
+	// MarkTOTPStepUsed 原子地记录用户已使用的时间步，该时间步此前已被使用过时返回 false
+	MarkTOTPStepUsed(ctx context.Context, userID int64, step int64, expiration time.Duration) (bool, error)
+}
+
+// OAuthStateStore 定义了单点登录请求所需的方法
+type OAuthStateStore interface {
+	SaveOAuthState(ctx context.Context, stateHash string, state *model.OAuthState, expiration time.Duration) error
+	// ConsumeOAuthState 原子地取出并删除请求，不存在或已过期时返回 nil, nil
+	ConsumeOAuthState(ctx context.Context, stateHash string) (*model.OAuthState, error)
 }
 
 // userCacheStore 是一个为 UserStore 实现的装饰器，它使用 Redis 增加了缓存层。
@@ -64,9 +125,9 @@ func userKey(id int64) string {
 	return fmt.Sprintf("user:%d", id)
 }
 
-// usernameKey 根据用户名生成缓存键
+// usernameKey 根据用户名生成缓存键，用户名不区分大小写
 func usernameKey(username string) string {
-	return fmt.Sprintf("user:username:%s", username)
+	return fmt.Sprintf("user:username:%s", strings.ToLower(username))
 }
 
 // --- 写操作与缓存失效方法 ---
@@ -84,13 +145,143 @@ func (s *userCacheStore) UpdateUser(ctx context.Context, user *model.User) error
 		return err
 	}
 
-	// 2. 如果数据库更新成功，则删除对应的缓存以保证数据一致性
+	// 2. 如果数据库更新成功，则删除对应的缓存以保证数据一致性。
+	// UpdateUser 不修改用户名，传入的用户名可能为空，以数据库中的为准
 	s.redisClient.Del(ctx, userKey(user.ID))
-	s.redisClient.Del(ctx, usernameKey(user.Username))
+	if current, err := s.next.GetUserByID(ctx, user.ID); err == nil {
+		s.redisClient.Del(ctx, usernameKey(current.Username))
+	}
+
+	return nil
+}
+
+// ChangeUsername 修改用户名后删除以用户ID和新旧用户名为键的缓存
+func (s *userCacheStore) ChangeUsername(ctx context.Context, userID int64, oldUsername, newUsername string) error {
+	if err := s.next.ChangeUsername(ctx, userID, oldUsername, newUsername); err != nil {
+		return err
+	}
+
+	s.redisClient.Del(ctx, userKey(userID), usernameKey(oldUsername), usernameKey(newUsername))
+	return nil
+}
+
+// GetLatestUsernameChange 直接调用下一层，只在修改用户名时使用，不需要缓存
+func (s *userCacheStore) GetLatestUsernameChange(ctx context.Context, userID int64) (*model.UsernameChange, error) {
+	return s.next.GetLatestUsernameChange(ctx, userID)
+}
+
+// GetUserByPreviousUsername 直接调用下一层，旧用户名随时可能被其他用户占用，不缓存
+func (s *userCacheStore) GetUserByPreviousUsername(ctx context.Context, username string) (*model.User, error) {
+	return s.next.GetUserByPreviousUsername(ctx, username)
+}
+
+// ListUsers 直接调用下一层，管理员查询需要看到最新的数据，不缓存
+func (s *userCacheStore) ListUsers(ctx context.Context, filter model.UserFilter, limit, offset int) ([]*model.User, int64, error) {
+	return s.next.ListUsers(ctx, filter, limit, offset)
+}
+
+// SuspendUser 封禁用户后删除缓存，登录和令牌校验需要立即读到封禁状态
+func (s *userCacheStore) SuspendUser(ctx context.Context, userID int64, until *time.Time, reason string, adminID int64) error {
+	if err := s.next.SuspendUser(ctx, userID, until, reason, adminID); err != nil {
+		return err
+	}
+	s.invalidateUser(ctx, userID)
+	return nil
+}
+
+// UnsuspendUser 解除封禁后删除缓存
+func (s *userCacheStore) UnsuspendUser(ctx context.Context, userID int64) error {
+	if err := s.next.UnsuspendUser(ctx, userID); err != nil {
+		return err
+	}
+	s.invalidateUser(ctx, userID)
+	return nil
+}
 
+// SetUserRole 修改角色后删除缓存，让之后签发的 token 带上新角色
+func (s *userCacheStore) SetUserRole(ctx context.Context, userID int64, role string) error {
+	if err := s.next.SetUserRole(ctx, userID, role); err != nil {
+		return err
+	}
+	s.invalidateUser(ctx, userID)
 	return nil
 }
 
+// ImportUser 直接穿透到下一层，新用户尚未被缓存。
+func (s *userCacheStore) ImportUser(ctx context.Context, user *model.User) (int64, error) {
+	return s.next.ImportUser(ctx, user)
+}
+
+// invalidateUser 删除以用户ID和用户名为键的缓存
+func (s *userCacheStore) invalidateUser(ctx context.Context, userID int64) {
+	s.redisClient.Del(ctx, userKey(userID))
+	if user, err := s.next.GetUserByID(ctx, userID); err == nil {
+		s.redisClient.Del(ctx, usernameKey(user.Username))
+	}
+}
+
+// FollowUser 直接穿透到下一层，关注关系不缓存
+func (s *userCacheStore) FollowUser(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	return s.next.FollowUser(ctx, followerID, followeeID)
+}
+
+// UnfollowUser 直接穿透到下一层。
+func (s *userCacheStore) UnfollowUser(ctx context.Context, followerID, followeeID int64) (bool, error) {
+	return s.next.UnfollowUser(ctx, followerID, followeeID)
+}
+
+// ListFollowers 直接穿透到下一层。
+func (s *userCacheStore) ListFollowers(ctx context.Context, userID int64, limit, offset int) ([]*model.User, int64, error) {
+	return s.next.ListFollowers(ctx, userID, limit, offset)
+}
+
+// ListFollowing 直接穿透到下一层。
+func (s *userCacheStore) ListFollowing(ctx context.Context, userID int64, limit, offset int) ([]*model.User, int64, error) {
+	return s.next.ListFollowing(ctx, userID, limit, offset)
+}
+
+// ListFollowerIDs 直接穿透到下一层。
+func (s *userCacheStore) ListFollowerIDs(ctx context.Context, userID int64) ([]int64, error) {
+	return s.next.ListFollowerIDs(ctx, userID)
+}
+
+// UpdatePassword 更新密码后删除缓存，缓存中的用户数据包含密码哈希
+func (s *userCacheStore) UpdatePassword(ctx context.Context, id int64, hashedPassword string) error {
+	if err := s.next.UpdatePassword(ctx, id, hashedPassword); err != nil {
+		return err
+	}
+
+	s.redisClient.Del(ctx, userKey(id))
+	if user, err := s.next.GetUserByID(ctx, id); err == nil {
+		s.redisClient.Del(ctx, usernameKey(user.Username))
+	}
+	return nil
+}
+
+// UpdateAvatar 更新头像后删除缓存
+func (s *userCacheStore) UpdateAvatar(ctx context.Context, id int64, avatarKey string) (string, error) {
+	previous, err := s.next.UpdateAvatar(ctx, id, avatarKey)
+	if err != nil {
+		return "", err
+	}
+	s.invalidateUser(ctx, id)
+	return previous, nil
+}
+
+// MarkEmailVerified 标记成功后删除缓存，让后续签发的 token 读到最新的验证状态
+func (s *userCacheStore) MarkEmailVerified(ctx context.Context, id int64, email string) (bool, error) {
+	ok, err := s.next.MarkEmailVerified(ctx, id, email)
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	s.redisClient.Del(ctx, userKey(id))
+	if user, err := s.next.GetUserByID(ctx, id); err == nil {
+		s.redisClient.Del(ctx, usernameKey(user.Username))
+	}
+	return true, nil
+}
+
 // DeleteUser 首先从数据库删除，如果成功，则使缓存失效。
 func (s *userCacheStore) DeleteUser(ctx context.Context, id int64) error {
 	// 为了让 username 相关的缓存也失效，我们需要先获取用户信息
@@ -115,6 +306,102 @@ func (s *userCacheStore) DeleteUser(ctx context.Context, id int64) error {
 	return nil
 }
 
+// RecordReputationEvents 写入声望流水后，使受影响用户的缓存失效。
+func (s *userCacheStore) RecordReputationEvents(ctx context.Context, events []*model.ReputationEvent) error {
+	if err := s.next.RecordReputationEvents(ctx, events); err != nil {
+		return err
+	}
+
+	invalidated := make(map[int64]struct{})
+	for _, e := range events {
+		if _, ok := invalidated[e.UserID]; ok {
+			continue
+		}
+		invalidated[e.UserID] = struct{}{}
+		s.redisClient.Del(ctx, userKey(e.UserID))
+		// username 缓存中同样保存了统计字段，需要一并删除
+		if user, err := s.next.GetUserByID(ctx, e.UserID); err == nil {
+			s.redisClient.Del(ctx, usernameKey(user.Username))
+		}
+	}
+	return nil
+}
+
+// ListReputationTotalsByQuestion 直接穿透到下一层。
+func (s *userCacheStore) ListReputationTotalsByQuestion(ctx context.Context, questionID int64) ([]*model.ReputationTotal, error) {
+	return s.next.ListReputationTotalsByQuestion(ctx, questionID)
+}
+
+// ListReputationTotalsByAnswer 直接穿透到下一层。
+func (s *userCacheStore) ListReputationTotalsByAnswer(ctx context.Context, answerID int64) ([]*model.ReputationTotal, error) {
+	return s.next.ListReputationTotalsByAnswer(ctx, answerID)
+}
+
+// GetTwoFactor 直接穿透到下一层，两步验证状态变化时不需要维护缓存。
+func (s *userCacheStore) GetTwoFactor(ctx context.Context, userID int64) (*model.TwoFactor, error) {
+	return s.next.GetTwoFactor(ctx, userID)
+}
+
+// SaveTwoFactorSecret 直接穿透到下一层。
+func (s *userCacheStore) SaveTwoFactorSecret(ctx context.Context, userID int64, secret string) error {
+	return s.next.SaveTwoFactorSecret(ctx, userID, secret)
+}
+
+// EnableTwoFactor 直接穿透到下一层。
+func (s *userCacheStore) EnableTwoFactor(ctx context.Context, userID int64, codeHashes []string) error {
+	return s.next.EnableTwoFactor(ctx, userID, codeHashes)
+}
+
+// DeleteTwoFactor 直接穿透到下一层。
+func (s *userCacheStore) DeleteTwoFactor(ctx context.Context, userID int64) error {
+	return s.next.DeleteTwoFactor(ctx, userID)
+}
+
+// UseRecoveryCode 直接穿透到下一层。
+func (s *userCacheStore) UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error) {
+	return s.next.UseRecoveryCode(ctx, userID, codeHash)
+}
+
+// GetUserIdentity 直接穿透到下一层。
+func (s *userCacheStore) GetUserIdentity(ctx context.Context, issuer, subject string) (*model.UserIdentity, error) {
+	return s.next.GetUserIdentity(ctx, issuer, subject)
+}
+
+// CreateUserWithIdentity 直接穿透到下一层，新用户尚未被缓存。
+func (s *userCacheStore) CreateUserWithIdentity(ctx context.Context, user *model.User, identity *model.UserIdentity) (int64, error) {
+	return s.next.CreateUserWithIdentity(ctx, user, identity)
+}
+
+// LinkUserIdentity 直接穿透到下一层，用户信息本身没有变化。
+func (s *userCacheStore) LinkUserIdentity(ctx context.Context, identity *model.UserIdentity) error {
+	return s.next.LinkUserIdentity(ctx, identity)
+}
+
+// CreateAccessToken 直接穿透到下一层。
+func (s *userCacheStore) CreateAccessToken(ctx context.Context, token *model.AccessToken) (int64, error) {
+	return s.next.CreateAccessToken(ctx, token)
+}
+
+// GetAccessTokenByHash 直接穿透到下一层，令牌被撤销后需要立即失效，因此不缓存。
+func (s *userCacheStore) GetAccessTokenByHash(ctx context.Context, tokenHash string) (*model.AccessToken, error) {
+	return s.next.GetAccessTokenByHash(ctx, tokenHash)
+}
+
+// ListAccessTokens 直接穿透到下一层。
+func (s *userCacheStore) ListAccessTokens(ctx context.Context, userID int64) ([]*model.AccessToken, error) {
+	return s.next.ListAccessTokens(ctx, userID)
+}
+
+// RevokeAccessToken 直接穿透到下一层。
+func (s *userCacheStore) RevokeAccessToken(ctx context.Context, userID, tokenID int64) (bool, error) {
+	return s.next.RevokeAccessToken(ctx, userID, tokenID)
+}
+
+// TouchAccessToken 直接穿透到下一层。
+func (s *userCacheStore) TouchAccessToken(ctx context.Context, tokenID int64, usedAt time.Time) error {
+	return s.next.TouchAccessToken(ctx, tokenID, usedAt)
+}
+
 // --- 读穿透缓存方法 ---
 
 // GetUserByID 实现了“读穿透”缓存逻辑。
@@ -182,27 +469,373 @@ func (s *userCacheStore) GetUserByEmail(ctx context.Context, email string) (*mod
 	return s.next.GetUserByEmail(ctx, email)
 }
 
-// --- JWT 黑名单方法 ---
+// --- 会话方法 ---
 
-// jwtBlacklistKey 生成黑名单的键
-func jwtBlacklistKey(token string) string {
-	return fmt.Sprintf("blacklist:jwt:%s", token)
+// sessionKey 生成会话记录的键
+func sessionKey(sessionID string) string {
+	return fmt.Sprintf("session:%s", sessionID)
 }
 
-// AddToBlacklist 将 JWT 加入黑名单，并设置其过期时间
-func (s *userCacheStore) AddToBlacklist(ctx context.Context, token string, expiration time.Duration) error {
-	key := jwtBlacklistKey(token)
-	return s.redisClient.Set(ctx, key, "true", expiration).Err()
+// userSessionsKey 生成用户会话索引的键
+func userSessionsKey(userID int64) string {
+	return fmt.Sprintf("user:sessions:%d", userID)
 }
 
-// IsBlacklisted 检查 JWT 是否存在于黑名单中
-func (s *userCacheStore) IsBlacklisted(ctx context.Context, token string) (bool, error) {
-	key := jwtBlacklistKey(token)
-	val, err := s.redisClient.Get(ctx, key).Result()
+// SaveSession 保存会话记录并加入用户的会话索引，索引的过期时间随最新的会话顺延
+func (s *userCacheStore) SaveSession(ctx context.Context, session *model.Session, expiration time.Duration) error {
+	jsonData, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	indexKey := userSessionsKey(session.UserID)
+	pipe := s.redisClient.TxPipeline()
+	pipe.Set(ctx, sessionKey(session.ID), jsonData, expiration)
+	pipe.SAdd(ctx, indexKey, session.ID)
+	pipe.Expire(ctx, indexKey, expiration)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetSession 根据会话ID读取会话记录
+func (s *userCacheStore) GetSession(ctx context.Context, sessionID string) (*model.Session, error) {
+	val, err := s.redisClient.Get(ctx, sessionKey(sessionID)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var session model.Session
+	if err := json.Unmarshal([]byte(val), &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListSessions 返回用户所有未过期的会话，顺带清理索引中已过期的会话ID
+func (s *userCacheStore) ListSessions(ctx context.Context, userID int64) ([]*model.Session, error) {
+	indexKey := userSessionsKey(userID)
+	ids, err := s.redisClient.SMembers(ctx, indexKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []*model.Session{}, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = sessionKey(id)
+	}
+	vals, err := s.redisClient.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*model.Session, 0, len(ids))
+	var expired []any
+	for i, val := range vals {
+		str, ok := val.(string)
+		if !ok {
+			expired = append(expired, ids[i])
+			continue
+		}
+		var session model.Session
+		if json.Unmarshal([]byte(str), &session) != nil {
+			continue
+		}
+		sessions = append(sessions, &session)
+	}
+	if len(expired) > 0 {
+		s.redisClient.SRem(ctx, indexKey, expired...)
+	}
+	return sessions, nil
+}
+
+// RevokeSession 删除会话记录并写入撤销标记
+func (s *userCacheStore) RevokeSession(ctx context.Context, userID int64, sessionID string, expiration time.Duration) error {
+	pipe := s.redisClient.TxPipeline()
+	pipe.Set(ctx, auth.SessionRevokedKey(sessionID), "true", expiration)
+	pipe.Del(ctx, sessionKey(sessionID))
+	pipe.SRem(ctx, userSessionsKey(userID), sessionID)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// IsSessionRevoked 检查会话是否已被撤销
+func (s *userCacheStore) IsSessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	val, err := s.redisClient.Get(ctx, auth.SessionRevokedKey(sessionID)).Result()
 	if err == redis.Nil {
-		return false, nil // 不在黑名单中
+		return false, nil
 	} else if err != nil {
-		return false, err // Redis查询出错
+		return false, err
 	}
 	return val == "true", nil
 }
+
+// --- 刷新令牌方法 ---
+
+// refreshTokenKey 生成刷新令牌记录的键
+func refreshTokenKey(tokenHash string) string {
+	return fmt.Sprintf("refresh:token:%s", tokenHash)
+}
+
+// refreshTokenUsedKey 生成刷新令牌已使用标记的键
+func refreshTokenUsedKey(tokenHash string) string {
+	return fmt.Sprintf("refresh:used:%s", tokenHash)
+}
+
+// SaveRefreshToken 保存刷新令牌记录，过期后由 Redis 自动清理
+func (s *userCacheStore) SaveRefreshToken(ctx context.Context, token *model.RefreshToken, expiration time.Duration) error {
+	jsonData, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	return s.redisClient.Set(ctx, refreshTokenKey(token.TokenHash), jsonData, expiration).Err()
+}
+
+// GetRefreshToken 根据令牌哈希读取刷新令牌记录
+func (s *userCacheStore) GetRefreshToken(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	val, err := s.redisClient.Get(ctx, refreshTokenKey(tokenHash)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var token model.RefreshToken
+	if err := json.Unmarshal([]byte(val), &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkRefreshTokenUsed 使用 SETNX 保证同一个刷新令牌只能被成功使用一次
+func (s *userCacheStore) MarkRefreshTokenUsed(ctx context.Context, tokenHash string, expiration time.Duration) (bool, error) {
+	return s.redisClient.SetNX(ctx, refreshTokenUsedKey(tokenHash), "true", expiration).Result()
+}
+
+// --- 密码重置方法 ---
+
+// passwordResetKey 生成密码重置令牌的键
+func passwordResetKey(tokenHash string) string {
+	return fmt.Sprintf("password_reset:%s", tokenHash)
+}
+
+// userPasswordResetKey 生成用户当前有效重置令牌的键
+func userPasswordResetKey(userID int64) string {
+	return fmt.Sprintf("password_reset:user:%d", userID)
+}
+
+// rateLimitKey 生成限流计数器的键
+func rateLimitKey(key string) string {
+	return fmt.Sprintf("ratelimit:%s", key)
+}
+
+// SavePasswordResetToken 保存重置令牌，并删除该用户之前签发的令牌
+func (s *userCacheStore) SavePasswordResetToken(ctx context.Context, tokenHash string, userID int64, expiration time.Duration) error {
+	indexKey := userPasswordResetKey(userID)
+	previous, err := s.redisClient.GetSet(ctx, indexKey, tokenHash).Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	pipe := s.redisClient.TxPipeline()
+	if previous != "" {
+		pipe.Del(ctx, passwordResetKey(previous))
+	}
+	pipe.Expire(ctx, indexKey, expiration)
+	pipe.Set(ctx, passwordResetKey(tokenHash), userID, expiration)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// ConsumePasswordResetToken 使用 GETDEL 保证令牌只能被使用一次
+func (s *userCacheStore) ConsumePasswordResetToken(ctx context.Context, tokenHash string) (int64, error) {
+	userID, err := s.redisClient.GetDel(ctx, passwordResetKey(tokenHash)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	s.redisClient.Del(ctx, userPasswordResetKey(userID))
+	return userID, nil
+}
+
+// IncrRateLimit 使用固定窗口计数，第一次计数时设置窗口的过期时间
+func (s *userCacheStore) IncrRateLimit(ctx context.Context, key string, window time.Duration) (int64, error) {
+	redisKey := rateLimitKey(key)
+	count, err := s.redisClient.Incr(ctx, redisKey).Result()
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 {
+		s.redisClient.Expire(ctx, redisKey, window)
+	}
+	return count, nil
+}
+
+// --- 邮箱验证方法 ---
+
+// emailVerificationKey 生成邮箱验证令牌的键
+func emailVerificationKey(tokenHash string) string {
+	return fmt.Sprintf("email_verification:%s", tokenHash)
+}
+
+// userEmailVerificationKey 生成用户当前有效验证令牌的键
+func userEmailVerificationKey(userID int64) string {
+	return fmt.Sprintf("email_verification:user:%d", userID)
+}
+
+// SaveEmailVerificationToken 保存验证令牌，并删除该用户之前签发的令牌
+func (s *userCacheStore) SaveEmailVerificationToken(ctx context.Context, tokenHash string, token *model.EmailVerificationToken, expiration time.Duration) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	indexKey := userEmailVerificationKey(token.UserID)
+	previous, err := s.redisClient.GetSet(ctx, indexKey, tokenHash).Result()
+	if err != nil && err != redis.Nil {
+		return err
+	}
+
+	pipe := s.redisClient.TxPipeline()
+	if previous != "" {
+		pipe.Del(ctx, emailVerificationKey(previous))
+	}
+	pipe.Expire(ctx, indexKey, expiration)
+	pipe.Set(ctx, emailVerificationKey(tokenHash), data, expiration)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// ConsumeEmailVerificationToken 使用 GETDEL 保证令牌只能被使用一次
+func (s *userCacheStore) ConsumeEmailVerificationToken(ctx context.Context, tokenHash string) (*model.EmailVerificationToken, error) {
+	data, err := s.redisClient.GetDel(ctx, emailVerificationKey(tokenHash)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var token model.EmailVerificationToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, err
+	}
+	s.redisClient.Del(ctx, userEmailVerificationKey(token.UserID))
+	return &token, nil
+}
+
+// --- 登录失败计数方法 ---
+
+// loginFailuresKey 生成登录失败计数器的键
+func loginFailuresKey(key string) string {
+	return fmt.Sprintf("login:failures:%s", key)
+}
+
+// loginLockKey 生成登录锁定标记的键
+func loginLockKey(key string) string {
+	return fmt.Sprintf("login:lock:%s", key)
+}
+
+// IncrLoginFailures 每次失败都会刷新过期时间，连续失败时计数持续累加
+func (s *userCacheStore) IncrLoginFailures(ctx context.Context, key string, window time.Duration) (int64, error) {
+	redisKey := loginFailuresKey(key)
+	pipe := s.redisClient.TxPipeline()
+	incr := pipe.Incr(ctx, redisKey)
+	pipe.Expire(ctx, redisKey, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+// LockLogin 设置带过期时间的锁定标记
+func (s *userCacheStore) LockLogin(ctx context.Context, key string, duration time.Duration) error {
+	return s.redisClient.Set(ctx, loginLockKey(key), "1", duration).Err()
+}
+
+// LoginLockRemaining 通过锁定标记的剩余生存时间计算剩余的锁定时间
+func (s *userCacheStore) LoginLockRemaining(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := s.redisClient.PTTL(ctx, loginLockKey(key)).Result()
+	if err != nil {
+		return 0, err
+	}
+	// 键不存在时 PTTL 返回负值
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// ClearLoginFailures 删除失败计数和锁定标记
+func (s *userCacheStore) ClearLoginFailures(ctx context.Context, key string) error {
+	return s.redisClient.Del(ctx, loginFailuresKey(key), loginLockKey(key)).Err()
+}
+
+// --- 两步验证方法 ---
+
+// loginChallengeKey 生成两步验证登录挑战的键
+func loginChallengeKey(tokenHash string) string {
+	return fmt.Sprintf("login:challenge:%s", tokenHash)
+}
+
+// totpStepKey 生成用户已使用的 TOTP 时间步的键
+func totpStepKey(userID int64, step int64) string {
+	return fmt.Sprintf("totp:used:%d:%d", userID, step)
+}
+
+func (s *userCacheStore) SaveLoginChallenge(ctx context.Context, tokenHash string, userID int64, expiration time.Duration) error {
+	return s.redisClient.Set(ctx, loginChallengeKey(tokenHash), userID, expiration).Err()
+}
+
+func (s *userCacheStore) GetLoginChallenge(ctx context.Context, tokenHash string) (int64, error) {
+	userID, err := s.redisClient.Get(ctx, loginChallengeKey(tokenHash)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return userID, err
+}
+
+func (s *userCacheStore) DeleteLoginChallenge(ctx context.Context, tokenHash string) (bool, error) {
+	deleted, err := s.redisClient.Del(ctx, loginChallengeKey(tokenHash)).Result()
+	return deleted > 0, err
+}
+
+// MarkTOTPStepUsed 使用 SETNX 保证同一个验证码只能使用一次
+func (s *userCacheStore) MarkTOTPStepUsed(ctx context.Context, userID int64, step int64, expiration time.Duration) (bool, error) {
+	return s.redisClient.SetNX(ctx, totpStepKey(userID, step), "true", expiration).Result()
+}
+
+// --- 单点登录方法 ---
+
+// oauthStateKey 生成单点登录请求的键
+func oauthStateKey(stateHash string) string {
+	return fmt.Sprintf("oauth:state:%s", stateHash)
+}
+
+func (s *userCacheStore) SaveOAuthState(ctx context.Context, stateHash string, state *model.OAuthState, expiration time.Duration) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.redisClient.Set(ctx, oauthStateKey(stateHash), data, expiration).Err()
+}
+
+// ConsumeOAuthState 使用 GETDEL 保证每个 state 只能回调一次
+func (s *userCacheStore) ConsumeOAuthState(ctx context.Context, stateHash string) (*model.OAuthState, error) {
+	data, err := s.redisClient.GetDel(ctx, oauthStateKey(stateHash)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var state model.OAuthState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
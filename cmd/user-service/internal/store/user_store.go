@@ -8,6 +8,7 @@ import (
 
 	_ "github.com/go-sql-driver/mysql" // MySQL driver
 
+	"qahub/pkg/auth"
 	"qahub/pkg/health"
 	"qahub/user-service/internal/model"
 )
@@ -18,7 +19,55 @@ type UserStore interface {
 	GetUserByUsername(ctx context.Context, username string) (*model.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
 	UpdateUser(ctx context.Context, user *model.User) error
+	UpdatePassword(ctx context.Context, id int64, hashedPassword string) error
+	// UpdateAvatar 修改用户的头像目录，返回修改前的目录，用于删除旧头像
+	UpdateAvatar(ctx context.Context, id int64, avatarKey string) (string, error)
+	// MarkEmailVerified 仅在用户当前邮箱仍为 email 时标记为已验证，返回是否有记录被更新
+	MarkEmailVerified(ctx context.Context, id int64, email string) (bool, error)
 	DeleteUser(ctx context.Context, id int64) error
+
+	// --- 用户名相关 (Username) ---
+	ChangeUsername(ctx context.Context, userID int64, oldUsername, newUsername string) error
+	GetLatestUsernameChange(ctx context.Context, userID int64) (*model.UsernameChange, error)
+	GetUserByPreviousUsername(ctx context.Context, username string) (*model.User, error)
+
+	// --- 用户管理相关 (Admin) ---
+	ListUsers(ctx context.Context, filter model.UserFilter, limit, offset int) ([]*model.User, int64, error)
+	SuspendUser(ctx context.Context, userID int64, until *time.Time, reason string, adminID int64) error
+	UnsuspendUser(ctx context.Context, userID int64) error
+	SetUserRole(ctx context.Context, userID int64, role string) error
+	ImportUser(ctx context.Context, user *model.User) (int64, error)
+
+	// --- 关注相关 (Follows) ---
+	FollowUser(ctx context.Context, followerID, followeeID int64) (bool, error)
+	UnfollowUser(ctx context.Context, followerID, followeeID int64) (bool, error)
+	ListFollowers(ctx context.Context, userID int64, limit, offset int) ([]*model.User, int64, error)
+	ListFollowing(ctx context.Context, userID int64, limit, offset int) ([]*model.User, int64, error)
+	ListFollowerIDs(ctx context.Context, userID int64) ([]int64, error)
+
+	// --- 两步验证相关 (Two-factor) ---
+	GetTwoFactor(ctx context.Context, userID int64) (*model.TwoFactor, error)
+	SaveTwoFactorSecret(ctx context.Context, userID int64, secret string) error
+	EnableTwoFactor(ctx context.Context, userID int64, codeHashes []string) error
+	DeleteTwoFactor(ctx context.Context, userID int64) error
+	UseRecoveryCode(ctx context.Context, userID int64, codeHash string) (bool, error)
+
+	// --- 外部身份相关 (Identity federation) ---
+	GetUserIdentity(ctx context.Context, issuer, subject string) (*model.UserIdentity, error)
+	CreateUserWithIdentity(ctx context.Context, user *model.User, identity *model.UserIdentity) (int64, error)
+	LinkUserIdentity(ctx context.Context, identity *model.UserIdentity) error
+
+	// --- 个人访问令牌相关 (Access tokens) ---
+	CreateAccessToken(ctx context.Context, token *model.AccessToken) (int64, error)
+	GetAccessTokenByHash(ctx context.Context, tokenHash string) (*model.AccessToken, error)
+	ListAccessTokens(ctx context.Context, userID int64) ([]*model.AccessToken, error)
+	RevokeAccessToken(ctx context.Context, userID, tokenID int64) (bool, error)
+	TouchAccessToken(ctx context.Context, tokenID int64, usedAt time.Time) error
+
+	// --- 声望相关 (Reputation) ---
+	RecordReputationEvents(ctx context.Context, events []*model.ReputationEvent) error
+	ListReputationTotalsByQuestion(ctx context.Context, questionID int64) ([]*model.ReputationTotal, error)
+	ListReputationTotalsByAnswer(ctx context.Context, answerID int64) ([]*model.ReputationTotal, error)
 }
 
 type mySQLUserStore struct {
@@ -31,10 +80,14 @@ func NewMySQLUserStore(db *sqlx.DB) *mySQLUserStore {
 }
 
 func (s *mySQLUserStore) CreateUser(ctx context.Context, user *model.User) (int64, error) {
-	query := "INSERT INTO users (username, email,bio, password) VALUES (?, ?,?, ?)"
-	result, err := s.db.ExecContext(ctx, query, user.Username, user.Email, user.Bio, user.Password)
+	userType := user.UserType
+	if userType == "" {
+		userType = auth.UserTypeHuman
+	}
+	query := "INSERT INTO users (username, email,bio, password, user_type, email_verified_at) VALUES (?, ?,?, ?, ?, ?)"
+	result, err := s.db.ExecContext(ctx, query, user.Username, user.Email, user.Bio, user.Password, userType, user.EmailVerifiedAt)
 	if err != nil {
-		return 0, err
+		return 0, usernameConflict(err)
 	}
 	id, err := result.LastInsertId()
 	if err != nil {
@@ -46,7 +99,7 @@ func (s *mySQLUserStore) CreateUser(ctx context.Context, user *model.User) (int6
 func (s *mySQLUserStore) GetUserByID(ctx context.Context, id int64) (*model.User, error) {
 	var user model.User
 
-	query := "SELECT id, username, email, bio, password, created_at, updated_at FROM users WHERE id = ?"
+	query := "SELECT id, username, email, email_verified_at, bio, avatar_key, password, role, user_type, suspended_at, suspended_until, suspension_reason, suspended_by, language, reputation, question_count, answer_count, accepted_answer_count, created_at, updated_at FROM users WHERE id = ?"
 	err := s.db.GetContext(ctx, &user, query, id)
 	if err != nil {
 		return nil, err
@@ -57,7 +110,7 @@ func (s *mySQLUserStore) GetUserByID(ctx context.Context, id int64) (*model.User
 func (s *mySQLUserStore) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
 	var user model.User
 
-	query := "SELECT id, username, email, bio, password, created_at, updated_at FROM users WHERE username = ?"
+	query := "SELECT id, username, email, email_verified_at, bio, avatar_key, password, role, user_type, suspended_at, suspended_until, suspension_reason, suspended_by, language, reputation, question_count, answer_count, accepted_answer_count, created_at, updated_at FROM users WHERE username = ?"
 	err := s.db.GetContext(ctx, &user, query, username)
 	if err != nil {
 		return nil, err
@@ -69,7 +122,7 @@ func (s *mySQLUserStore) GetUserByUsername(ctx context.Context, username string)
 func (s *mySQLUserStore) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
 	var user model.User
 
-	query := "SELECT id, username, email,bio, password FROM users WHERE email = ?"
+	query := "SELECT id, username, email, email_verified_at, bio, avatar_key, password, role, user_type, suspended_at, suspended_until, suspension_reason, suspended_by, language FROM users WHERE email = ?"
 	err := s.db.GetContext(ctx, &user, query, email)
 	if err != nil {
 		return nil, err
@@ -79,14 +132,61 @@ func (s *mySQLUserStore) GetUserByEmail(ctx context.Context, email string) (*mod
 }
 
 func (s *mySQLUserStore) UpdateUser(ctx context.Context, user *model.User) error {
-	query := "UPDATE users SET username = ?, email = ?, bio = ? WHERE id = ?"
-	_, err := s.db.ExecContext(ctx, query, user.Username, user.Email, user.Bio, user.ID)
+	// 未指定语言时保留原有的语言偏好；邮箱变化时清除验证状态；用户名只能通过 ChangeUsername 修改。
+	// MySQL 按从左到右的顺序执行 SET，email_verified_at 必须在 email 之前赋值才能与旧邮箱比较
+	query := "UPDATE users SET email_verified_at = IF(email = ?, email_verified_at, NULL), email = ?, bio = ?, language = COALESCE(NULLIF(?, ''), language) WHERE id = ?"
+	_, err := s.db.ExecContext(ctx, query, user.Email, user.Email, user.Bio, user.Language, user.ID)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+func (s *mySQLUserStore) UpdatePassword(ctx context.Context, id int64, hashedPassword string) error {
+	query := "UPDATE users SET password = ? WHERE id = ?"
+	_, err := s.db.ExecContext(ctx, query, hashedPassword, id)
+	return err
+}
+
+func (s *mySQLUserStore) UpdateAvatar(ctx context.Context, id int64, avatarKey string) (string, error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	// 锁定用户行，并发上传时每次都能拿到被替换的旧头像
+	var previous string
+	if err := tx.GetContext(ctx, &previous, "SELECT avatar_key FROM users WHERE id = ? FOR UPDATE", id); err != nil {
+		return "", err
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE users SET avatar_key = ? WHERE id = ?", avatarKey, id); err != nil {
+		return "", err
+	}
+	return previous, tx.Commit()
+}
+
+func (s *mySQLUserStore) MarkEmailVerified(ctx context.Context, id int64, email string) (bool, error) {
+	query := "UPDATE users SET email_verified_at = NOW() WHERE id = ? AND email = ? AND email_verified_at IS NULL"
+	result, err := s.db.ExecContext(ctx, query, id, email)
+	if err != nil {
+		return false, err
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return false, err
+	} else if affected > 0 {
+		return true, nil
+	}
+
+	// 没有记录被更新时，邮箱可能已经验证过，也可能已被修改
+	var count int
+	err = s.db.GetContext(ctx, &count, "SELECT COUNT(*) FROM users WHERE id = ? AND email = ?", id, email)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
 func (s *mySQLUserStore) DeleteUser(ctx context.Context, id int64) error {
 	query := "DELETE FROM users WHERE id = ?"
 	_, err := s.db.ExecContext(ctx, query, id)
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+
+	"qahub/user-service/internal/model"
+)
+
+// ErrDuplicateUsername 表示用户名与已有用户冲突（不区分大小写）
+var ErrDuplicateUsername = errors.New("duplicate username")
+
+// mysqlErrDuplicateEntry 是违反唯一索引时 MySQL 返回的错误码
+const mysqlErrDuplicateEntry = 1062
+
+// usernameConflict 将违反 uk_username 唯一索引的错误转换为 ErrDuplicateUsername
+func usernameConflict(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry && strings.Contains(mysqlErr.Message, "uk_username") {
+		return ErrDuplicateUsername
+	}
+	return err
+}
+
+// ChangeUsername 在一个事务中修改用户名并记录旧用户名，新用户名已被使用时返回 ErrDuplicateUsername
+func (s *mySQLUserStore) ChangeUsername(ctx context.Context, userID int64, oldUsername, newUsername string) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// 旧用户名按字节比较，防止并发修改时记录错误的历史
+	result, err := tx.ExecContext(ctx, "UPDATE users SET username = ? WHERE id = ? AND BINARY username = ?", newUsername, userID, oldUsername)
+	if err != nil {
+		return usernameConflict(err)
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return err
+	} else if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	query := "INSERT INTO username_history (user_id, username) VALUES (?, ?)"
+	if _, err := tx.ExecContext(ctx, query, userID, oldUsername); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetLatestUsernameChange 返回用户最近一次修改用户名的记录，从未修改过时返回 nil, nil
+func (s *mySQLUserStore) GetLatestUsernameChange(ctx context.Context, userID int64) (*model.UsernameChange, error) {
+	var change model.UsernameChange
+	query := "SELECT id, user_id, username, changed_at FROM username_history WHERE user_id = ? ORDER BY changed_at DESC, id DESC LIMIT 1"
+	if err := s.db.GetContext(ctx, &change, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &change, nil
+}
+
+// GetUserByPreviousUsername 根据旧用户名查找用户，多个用户曾使用过该用户名时返回最近改名的用户。
+// 调用方应先按当前用户名查找，旧用户名被其他用户占用后不再指向原来的用户。
+func (s *mySQLUserStore) GetUserByPreviousUsername(ctx context.Context, username string) (*model.User, error) {
+	var change model.UsernameChange
+	query := "SELECT id, user_id, username, changed_at FROM username_history WHERE username = ? ORDER BY changed_at DESC, id DESC LIMIT 1"
+	if err := s.db.GetContext(ctx, &change, query, username); err != nil {
+		return nil, err
+	}
+	return s.GetUserByID(ctx, change.UserID)
+}
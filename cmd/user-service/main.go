@@ -2,13 +2,18 @@
 package main
 
 import (
+	"context"
 	"log"
 	"log/slog"
+	"net/http"
+	"qahub/pkg/blob"
 	"qahub/pkg/config"
 	"qahub/pkg/database"
 	"qahub/pkg/health"
 	"qahub/pkg/interceptor"
 	logpkg "qahub/pkg/log"
+	"qahub/pkg/mail"
+	"qahub/pkg/messaging"
 	"qahub/pkg/redis"
 	"qahub/pkg/server"
 	"qahub/pkg/util"
@@ -60,9 +65,45 @@ func main() {
 	defer util.Cleanup("Redis client", redisClient.Close)
 	logger.Info("Redis 连接成功")
 
+	mailer, err := mail.New(config.Conf.Mail)
+	if err != nil {
+		log.Fatalf("Mailer initialization failed: %v", err)
+	}
+
+	signer, err := service.NewSigner(cfg)
+	if err != nil {
+		log.Fatalf("Signing key initialization failed: %v", err)
+	}
+
+	// 初始化文件存储，保存用户上传的头像
+	blobs, err := blob.New(config.Conf.Blob)
+	if err != nil {
+		log.Fatalf("Blob store initialization failed: %v", err)
+	}
+
+	// 初始化 Kafka 生产者，发布用户资料更新事件
+	kafkaProducer := messaging.NewKafkaProducer(config.Conf.Kafka)
+	defer util.Cleanup("Kafka producer", kafkaProducer.Close)
+
 	userStore := store.NewUserCacheStore(redisClient, store.NewMySQLUserStore(db))
-	userService := service.NewUserService(userStore)
+	userService := service.NewUserService(userStore, mailer, signer, kafkaProducer, blobs)
 	userHandler := handler.NewUserGrpcServer(userService)
+	adminHandler := handler.NewAdminUserGrpcServer(userService)
+
+	// 初始化 Kafka 消费者，根据问答事件维护用户声望
+	reputationService := service.NewReputationService(userStore)
+	consumer := messaging.NewKafkaConsumer(config.Conf.Kafka, service.TopicQAEvents, service.ReputationGroupID, nil)
+	consumer.SetHandlers(reputationService.RegisterHandlers())
+	defer util.Cleanup("Kafka consumer", consumer.Close)
+
+	// 初始化关注动态的消费者，把新发布的问答写入关注者的时间线
+	feedService, err := service.NewFeedService(userStore)
+	if err != nil {
+		log.Fatalf("Feed service initialization failed: %v", err)
+	}
+	feedConsumer := messaging.NewKafkaConsumer(config.Conf.Kafka, service.TopicQAEvents, service.FeedGroupID, nil)
+	feedConsumer.SetHandlers(feedService.RegisterHandlers())
+	defer util.Cleanup("Kafka feed consumer", feedConsumer.Close)
 
 	logger.Info("初始化 gRPC 服务器...",
 		slog.String("service_name", serviceName),
@@ -73,6 +114,12 @@ func main() {
 		grpc.ChainUnaryInterceptor(
 			interceptor.LogUnaryServerInterceptor(),
 			userService.AuthUnaryServerInterceptor(config.Conf.Services.UserService.PublicMethods...),
+			interceptor.AccessTokenScopeUnaryServerInterceptor(config.Conf.Services.UserService.AccessTokenScopes),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptor.LogStreamServerInterceptor(),
+			userService.AuthStreamServerInterceptor(config.Conf.Services.UserService.PublicMethods...),
+			interceptor.AccessTokenScopeStreamServerInterceptor(config.Conf.Services.UserService.AccessTokenScopes),
 		),
 	}
 
@@ -84,7 +131,26 @@ func main() {
 	health.SetHealthChecks(
 		healthUpdater,
 		serviceName,
-		userStore)
+		userStore,
+		kafkaProducer,
+		consumer,
+		feedConsumer)
+
+	// 本地文件存储需要由服务自己提供下载，对象存储则由客户端直接访问
+	if handler, ok := blobs.(http.Handler); ok && cfg.HttpPort != "" {
+		httpSrv := &http.Server{Addr: ":" + cfg.HttpPort, Handler: handler}
+		go func() {
+			if err := httpSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error("文件下载服务异常退出",
+					slog.String("error", err.Error()),
+				)
+			}
+		}()
+		defer util.Cleanup("Blob HTTP server", func() error { return httpSrv.Shutdown(context.Background()) })
+	}
+
+	go consumer.Start(context.Background())
+	go feedConsumer.Start(context.Background())
 
 	logger.Info("用户服务准备就绪，开始监听请求",
 		slog.String("grpc_port", cfg.GrpcPort),
@@ -93,5 +159,6 @@ func main() {
 	// 运行服务器，并传入业务注册的逻辑
 	grpcSrv.Run(func(s *grpc.Server) {
 		userHandler.RegisterServer(s)
+		adminHandler.RegisterServer(s)
 	})
 }
@@ -2,6 +2,8 @@ package auth
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
@@ -127,3 +129,102 @@ func (i Identity) HasClaim(key string) bool {
 	_, ok := i.GetClaim(key)
 	return ok
 }
+
+// 用户角色，由 user-service 写入 JWT 的 role 声明。
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// Role 返回身份的角色，没有 role 声明的旧 token 视为普通用户。
+func (i Identity) Role() string {
+	if role, ok := i.GetStringClaim("role"); ok && role != "" {
+		return role
+	}
+	return RoleUser
+}
+
+// IsAdmin 检查身份是否为管理员。
+func (i Identity) IsAdmin() bool {
+	return i.Role() == RoleAdmin
+}
+
+// IsModerator 检查身份是否具有版主权限，管理员同样拥有版主权限。
+func (i Identity) IsModerator() bool {
+	role := i.Role()
+	return role == RoleModerator || role == RoleAdmin
+}
+
+// ClaimEmailVerified 是 user-service 写入 JWT 的邮箱验证状态声明。
+const ClaimEmailVerified = "email_verified"
+
+// EmailVerified 检查身份的邮箱是否已验证，没有该声明的旧 token 视为已验证。
+func (i Identity) EmailVerified() bool {
+	value, ok := i.GetClaim(ClaimEmailVerified)
+	if !ok {
+		return true
+	}
+	verified, ok := value.(bool)
+	return !ok || verified
+}
+
+// 用户类型，由 user-service 写入 JWT 的 user_type 声明。机器人账户只能通过个人访问令牌调用接口。
+const (
+	UserTypeHuman = "human"
+	UserTypeBot   = "bot"
+)
+
+// ClaimUserType 是 user-service 写入 JWT 的用户类型声明。
+const ClaimUserType = "user_type"
+
+// IsBot 检查身份是否属于机器人账户，没有该声明的旧 token 视为普通用户。
+func (i Identity) IsBot() bool {
+	userType, _ := i.GetStringClaim(ClaimUserType)
+	return userType == UserTypeBot
+}
+
+// AccessTokenPrefix 是个人访问令牌的前缀，用于与 JWT 区分。
+const AccessTokenPrefix = "pat_"
+
+// 个人访问令牌的权限范围。范围逐级包含：admin 包含 write:qa，write:qa 包含 read。
+const (
+	ScopeRead    = "read"
+	ScopeWriteQA = "write:qa"
+	ScopeAdmin   = "admin"
+)
+
+// Scopes 是所有可用的权限范围，按从低到高排列。
+var Scopes = []string{ScopeRead, ScopeWriteQA, ScopeAdmin}
+
+// ClaimScope 是个人访问令牌的权限范围声明，多个范围以空格分隔。通过 JWT 登录的身份没有该声明。
+const ClaimScope = "scope"
+
+// IsAccessToken 检查身份是否来自个人访问令牌。
+func (i Identity) IsAccessToken() bool {
+	return i.HasClaim(ClaimScope)
+}
+
+// HasScope 检查身份是否拥有指定的权限范围。通过 JWT 登录的身份拥有全部范围。
+func (i Identity) HasScope(scope string) bool {
+	value, ok := i.GetClaim(ClaimScope)
+	if !ok {
+		return true
+	}
+	granted, _ := value.(string)
+	required := scopeLevel(scope)
+	if required < 0 {
+		return false
+	}
+	for _, s := range strings.Fields(granted) {
+		if scopeLevel(s) >= required {
+			return true
+		}
+	}
+	return false
+}
+
+// scopeLevel 返回权限范围在 Scopes 中的位置，未知的范围返回 -1。
+func scopeLevel(scope string) int {
+	return slices.Index(Scopes, scope)
+}
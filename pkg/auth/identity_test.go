@@ -150,3 +150,100 @@ func TestIdentityClaims(t *testing.T) {
 		t.Error("在 nil claims 上调用 HasClaim 应为 false")
 	}
 }
+
+// TestIdentityRole 测试基于 role 声明的角色判断。
+func TestIdentityRole(t *testing.T) {
+	cases := []struct {
+		name          string
+		claims        jwt.MapClaims
+		wantRole      string
+		wantAdmin     bool
+		wantModerator bool
+	}{
+		{"没有 role 声明", nil, RoleUser, false, false},
+		{"普通用户", jwt.MapClaims{"role": RoleUser}, RoleUser, false, false},
+		{"版主", jwt.MapClaims{"role": RoleModerator}, RoleModerator, false, true},
+		{"管理员", jwt.MapClaims{"role": RoleAdmin}, RoleAdmin, true, true},
+	}
+
+	for _, tc := range cases {
+		identity := Identity{Claims: tc.claims}
+		if got := identity.Role(); got != tc.wantRole {
+			t.Errorf("%s: Role() = '%s', 期望 '%s'", tc.name, got, tc.wantRole)
+		}
+		if got := identity.IsAdmin(); got != tc.wantAdmin {
+			t.Errorf("%s: IsAdmin() = %v, 期望 %v", tc.name, got, tc.wantAdmin)
+		}
+		if got := identity.IsModerator(); got != tc.wantModerator {
+			t.Errorf("%s: IsModerator() = %v, 期望 %v", tc.name, got, tc.wantModerator)
+		}
+	}
+}
+
+// TestIdentityEmailVerified 测试基于 email_verified 声明的邮箱验证状态判断。
+func TestIdentityEmailVerified(t *testing.T) {
+	cases := []struct {
+		name   string
+		claims jwt.MapClaims
+		want   bool
+	}{
+		{"没有 email_verified 声明的旧 token", nil, true},
+		{"已验证", jwt.MapClaims{ClaimEmailVerified: true}, true},
+		{"未验证", jwt.MapClaims{ClaimEmailVerified: false}, false},
+	}
+
+	for _, tc := range cases {
+		identity := Identity{Claims: tc.claims}
+		if got := identity.EmailVerified(); got != tc.want {
+			t.Errorf("%s: EmailVerified() = %v, 期望 %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+// TestIdentityScopes 测试个人访问令牌的权限范围判断。
+func TestIdentityScopes(t *testing.T) {
+	cases := []struct {
+		name            string
+		claims          jwt.MapClaims
+		wantAccessToken bool
+		want            map[string]bool
+	}{
+		{"JWT 登录拥有全部范围", jwt.MapClaims{"role": RoleUser}, false,
+			map[string]bool{ScopeRead: true, ScopeWriteQA: true, ScopeAdmin: true}},
+		{"只读令牌", jwt.MapClaims{ClaimScope: ScopeRead}, true,
+			map[string]bool{ScopeRead: true, ScopeWriteQA: false, ScopeAdmin: false}},
+		{"写入令牌包含只读", jwt.MapClaims{ClaimScope: ScopeWriteQA}, true,
+			map[string]bool{ScopeRead: true, ScopeWriteQA: true, ScopeAdmin: false}},
+		{"管理令牌包含全部范围", jwt.MapClaims{ClaimScope: ScopeRead + " " + ScopeAdmin}, true,
+			map[string]bool{ScopeRead: true, ScopeWriteQA: true, ScopeAdmin: true}},
+		{"空范围", jwt.MapClaims{ClaimScope: ""}, true,
+			map[string]bool{ScopeRead: false, ScopeWriteQA: false, ScopeAdmin: false}},
+		{"未知范围", jwt.MapClaims{ClaimScope: "write:all"}, true,
+			map[string]bool{ScopeRead: false, "write:all": false}},
+	}
+
+	for _, tc := range cases {
+		identity := Identity{Claims: tc.claims}
+		if got := identity.IsAccessToken(); got != tc.wantAccessToken {
+			t.Errorf("%s: IsAccessToken() = %v, 期望 %v", tc.name, got, tc.wantAccessToken)
+		}
+		for scope, want := range tc.want {
+			if got := identity.HasScope(scope); got != want {
+				t.Errorf("%s: HasScope(%q) = %v, 期望 %v", tc.name, scope, got, want)
+			}
+		}
+	}
+}
+
+// TestIdentityIsBot 测试基于 user_type 声明的机器人账户判断。
+func TestIdentityIsBot(t *testing.T) {
+	if (Identity{}).IsBot() {
+		t.Error("没有 user_type 声明的旧 token 不应视为机器人")
+	}
+	if (Identity{Claims: jwt.MapClaims{ClaimUserType: UserTypeHuman}}).IsBot() {
+		t.Error("普通用户不应视为机器人")
+	}
+	if !(Identity{Claims: jwt.MapClaims{ClaimUserType: UserTypeBot}}).IsBot() {
+		t.Error("机器人账户应视为机器人")
+	}
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	// AlgRS256 是 RSA 签名密钥使用的算法
+	AlgRS256 = "RS256"
+	// AlgEdDSA 是 Ed25519 签名密钥使用的算法
+	AlgEdDSA = "EdDSA"
+)
+
+// JSONWebKey 是 JWKS 中的一个公钥 (RFC 7517)，只包含 RSA 和 Ed25519 需要的字段
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// OKP
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS 是 user-service 发布的公钥集合，其他服务用它在本地校验访问令牌的签名
+type JWKS struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// NewJSONWebKey 将 RSA 或 Ed25519 公钥编码为 JWK
+func NewJSONWebKey(kid string, pub crypto.PublicKey) (JSONWebKey, error) {
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		return JSONWebKey{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			Alg: AlgRS256,
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}, nil
+	case ed25519.PublicKey:
+		return JSONWebKey{
+			Kty: "OKP",
+			Kid: kid,
+			Use: "sig",
+			Alg: AlgEdDSA,
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key),
+		}, nil
+	default:
+		return JSONWebKey{}, fmt.Errorf("不支持的公钥类型 %T", pub)
+	}
+}
+
+// PublicKey 将 JWK 解码为 jwt 库校验签名所需的公钥类型，并返回对应的签名算法
+func (k JSONWebKey) PublicKey() (crypto.PublicKey, string, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, "", err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, "", err
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, "", errors.New("RSA 公钥指数无效")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, AlgRS256, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, "", fmt.Errorf("不支持的曲线 %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, "", errors.New("Ed25519 公钥长度无效")
+		}
+		return ed25519.PublicKey(x), AlgEdDSA, nil
+
+	default:
+		return nil, "", fmt.Errorf("不支持的密钥类型 %q", k.Kty)
+	}
+}
+
+// decodeBigInt 解码 base64url 编码的大整数
+func decodeBigInt(s string) (*big.Int, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) == 0 {
+		return nil, errors.New("空的整数")
+	}
+	return new(big.Int).SetBytes(buf), nil
+}
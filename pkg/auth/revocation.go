@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ClaimSessionID 是访问令牌中记录会话ID的声明
+const ClaimSessionID = "sid"
+
+// SessionRevokedKey 返回会话撤销标记的 Redis 键。user-service 在登出、下线设备或检测到
+// 刷新令牌重用时写入该键，有效期覆盖会话内访问令牌的剩余有效期。
+func SessionRevokedKey(sessionID string) string {
+	return fmt.Sprintf("session:revoked:%s", sessionID)
+}
+
+// RedisRevocationList 通过 user-service 写入 Redis 的会话撤销标记判断令牌是否已被撤销
+type RedisRevocationList struct {
+	client redis.Cmdable
+}
+
+// NewRedisRevocationList 创建基于 Redis 的撤销列表，client 需要连接到 user-service 使用的 Redis
+func NewRedisRevocationList(client redis.Cmdable) *RedisRevocationList {
+	return &RedisRevocationList{client: client}
+}
+
+// IsRevoked 判断令牌所在的会话是否已被撤销，不带会话ID的令牌只能等待其自然过期
+func (r *RedisRevocationList) IsRevoked(ctx context.Context, identity Identity) (bool, error) {
+	sessionID, ok := identity.GetStringClaim(ClaimSessionID)
+	if !ok || sessionID == "" {
+		return false, nil
+	}
+
+	val, err := r.client.Get(ctx, SessionRevokedKey(sessionID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return val == "true", nil
+}
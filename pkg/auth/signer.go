@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSAKeyBits 是 RSA 签名密钥的最小长度
+const minRSAKeyBits = 2048
+
+// SigningKey 是签发访问令牌的私钥，ID 作为 JWT 头部的 kid，并随公钥一起发布在 JWKS 中
+type SigningKey struct {
+	ID  string
+	Key crypto.Signer // *rsa.PrivateKey 或 ed25519.PrivateKey
+}
+
+// Signer 使用当前密钥签发访问令牌，并用全部密钥校验令牌。
+// 轮换密钥时先加入新密钥 (此时只发布公钥)，再将其设为当前密钥，
+// 等旧密钥签发的令牌全部过期后再移除旧密钥。
+type Signer struct {
+	active SigningKey
+	keys   map[string]SigningKey
+	jwks   JWKS
+}
+
+// NewSigner 创建签名器，activeID 为空时使用第一个密钥签发令牌
+func NewSigner(activeID string, keys ...SigningKey) (*Signer, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("auth: 至少需要一个签名密钥")
+	}
+	if activeID == "" {
+		activeID = keys[0].ID
+	}
+
+	s := &Signer{keys: make(map[string]SigningKey, len(keys)), jwks: JWKS{Keys: make([]JSONWebKey, 0, len(keys))}}
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, errors.New("auth: 签名密钥缺少 kid")
+		}
+		if _, ok := s.keys[key.ID]; ok {
+			return nil, fmt.Errorf("auth: 重复的签名密钥 %q", key.ID)
+		}
+		if rsaKey, ok := key.Key.(*rsa.PrivateKey); ok && rsaKey.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("auth: 签名密钥 %q 的长度不能少于 %d 位", key.ID, minRSAKeyBits)
+		}
+		jwk, err := NewJSONWebKey(key.ID, key.Key.Public())
+		if err != nil {
+			return nil, fmt.Errorf("auth: 签名密钥 %q: %w", key.ID, err)
+		}
+		s.keys[key.ID] = key
+		s.jwks.Keys = append(s.jwks.Keys, jwk)
+	}
+
+	active, ok := s.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("auth: 当前签名密钥 %q 不存在", activeID)
+	}
+	s.active = active
+	return s, nil
+}
+
+// GenerateSigningKey 生成一个随机 kid 的 Ed25519 签名密钥
+func GenerateSigningKey() (SigningKey, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return SigningKey{}, err
+	}
+	return SigningKey{ID: rand.Text()[:16], Key: key}, nil
+}
+
+// ParsePrivateKey 解析 PEM 格式 (PKCS#8 或 PKCS#1) 的 RSA 或 Ed25519 私钥
+func ParsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("auth: 不是有效的 PEM 文件")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("auth: 不支持的 PEM 类型 %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("auth: 解析私钥失败: %w", err)
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("auth: 不支持的私钥类型 %T，只支持 RSA 和 Ed25519", key)
+	}
+}
+
+// Sign 使用当前密钥签发令牌
+func (s *Signer) Sign(claims jwt.MapClaims) (string, error) {
+	method := signingMethod(s.active.Key)
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = s.active.ID
+	return token.SignedString(s.active.Key)
+}
+
+// Verify 使用签名器自身的密钥校验令牌，不需要获取 JWKS
+func (s *Signer) Verify(tokenString string) (Identity, error) {
+	return ParseToken(tokenString, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := s.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("未知的签名密钥 %q", kid)
+		}
+		if signingMethod(key.Key).Alg() != token.Method.Alg() {
+			return nil, fmt.Errorf("签名密钥 %q 不能用于 %s", kid, token.Method.Alg())
+		}
+		return key.Key.Public(), nil
+	})
+}
+
+// JWKS 返回全部签名密钥的公钥
+func (s *Signer) JWKS() JWKS {
+	return s.jwks
+}
+
+// signingMethod 返回私钥对应的签名算法
+func signingMethod(key crypto.Signer) jwt.SigningMethod {
+	if _, ok := key.(*rsa.PrivateKey); ok {
+		return jwt.SigningMethodRS256
+	}
+	return jwt.SigningMethodEdDSA
+}
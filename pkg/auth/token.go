@@ -14,18 +14,17 @@ var (
 	ErrMissingUserID = errors.New("token missing user_id claim")
 )
 
-// ParseToken 解析 JWT 并返回 Identity。
-func ParseToken(tokenString string, secret []byte) (Identity, error) {
+// ParseToken 解析 JWT 并返回 Identity。keyFunc 根据令牌头部的 kid 返回校验签名的公钥，
+// 只接受 RS256 和 EdDSA 签名，且令牌必须带有过期时间。
+func ParseToken(tokenString string, keyFunc jwt.Keyfunc) (Identity, error) {
 	if tokenString == "" {
 		return Identity{}, ErrInvalidToken
 	}
 
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return secret, nil
-	})
+	token, err := jwt.Parse(tokenString, keyFunc,
+		jwt.WithValidMethods([]string{AlgRS256, AlgEdDSA}),
+		jwt.WithExpirationRequired(),
+	)
 	if err != nil {
 		return Identity{}, fmt.Errorf("parse token: %w", err)
 	}
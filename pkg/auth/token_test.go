@@ -1,26 +1,40 @@
 package auth
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+func newTestSigner(t *testing.T) *Signer {
+	t.Helper()
+	key, err := GenerateSigningKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	signer, err := NewSigner("", key)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	return signer
+}
+
 func TestParseToken(t *testing.T) {
-	secret := []byte("secret")
+	signer := newTestSigner(t)
 	claims := jwt.MapClaims{
 		"user_id":  int64(42),
 		"username": "alice",
 		"exp":      time.Now().Add(time.Hour).Unix(),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(secret)
+	tokenString, err := signer.Sign(claims)
 	if err != nil {
 		t.Fatalf("failed to sign token: %v", err)
 	}
 
-	identity, err := ParseToken(tokenString, secret)
+	identity, err := signer.Verify(tokenString)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -61,17 +75,103 @@ func TestParseToken(t *testing.T) {
 }
 
 func TestParseTokenMissingUserID(t *testing.T) {
-	secret := []byte("secret")
+	signer := newTestSigner(t)
 	claims := jwt.MapClaims{
 		"exp": time.Now().Add(time.Hour).Unix(),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(secret)
+	tokenString, err := signer.Sign(claims)
 	if err != nil {
 		t.Fatalf("failed to sign token: %v", err)
 	}
 
-	if _, err := ParseToken(tokenString, secret); err == nil {
+	if _, err := signer.Verify(tokenString); err == nil {
 		t.Fatalf("expected error when user_id is missing")
 	}
 }
+
+func TestParseTokenRejectsUnsafeTokens(t *testing.T) {
+	signer := newTestSigner(t)
+
+	// 以公钥作为 HMAC 密钥伪造的令牌不能通过校验
+	hmac := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"user_id": 1, "exp": time.Now().Add(time.Hour).Unix()})
+	hmac.Header["kid"] = signer.active.ID
+	forged, err := hmac.SignedString([]byte(signer.JWKS().Keys[0].X))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	if _, err := signer.Verify(forged); err == nil {
+		t.Fatalf("expected HS256 token to be rejected")
+	}
+
+	noExpiry, err := signer.Sign(jwt.MapClaims{"user_id": 1})
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	if _, err := signer.Verify(noExpiry); err == nil {
+		t.Fatalf("expected token without exp to be rejected")
+	}
+
+	other := newTestSigner(t)
+	foreign, err := other.Sign(jwt.MapClaims{"user_id": 1, "exp": time.Now().Add(time.Hour).Unix()})
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	if _, err := signer.Verify(foreign); err == nil {
+		t.Fatalf("expected token signed by another key to be rejected")
+	}
+}
+
+func TestSignerRotation(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	edKey, err := GenerateSigningKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	oldKey := SigningKey{ID: "old", Key: rsaKey}
+	newKey := SigningKey{ID: "new", Key: edKey.Key}
+
+	before, err := NewSigner("old", oldKey, newKey)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	if len(before.JWKS().Keys) != 2 {
+		t.Fatalf("expected both keys to be published, got %d", len(before.JWKS().Keys))
+	}
+	oldToken, err := before.Sign(jwt.MapClaims{"user_id": 1, "exp": time.Now().Add(time.Hour).Unix()})
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	// 切换当前密钥后，旧密钥签发的令牌在其移除前仍然有效
+	after, err := NewSigner("new", oldKey, newKey)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	newToken, err := after.Sign(jwt.MapClaims{"user_id": 1, "exp": time.Now().Add(time.Hour).Unix()})
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	for _, token := range []string{oldToken, newToken} {
+		if _, err := after.Verify(token); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	removed, err := NewSigner("", newKey)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	if _, err := removed.Verify(oldToken); err == nil {
+		t.Fatalf("expected token signed by removed key to be rejected")
+	}
+
+	if _, err := NewSigner("missing", oldKey); err == nil {
+		t.Fatalf("expected error when active key does not exist")
+	}
+	if _, err := NewSigner("", oldKey, oldKey); err == nil {
+		t.Fatalf("expected error for duplicate kid")
+	}
+}
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultJWKSCacheTTL 是未配置时公钥的缓存时间
+	DefaultJWKSCacheTTL = time.Hour
+	// minJWKSRefreshInterval 是两次获取 JWKS 之间的最短间隔，防止伪造的 kid 或签发方故障引发大量请求
+	minJWKSRefreshInterval = 10 * time.Second
+	// jwksFetchTimeout 是一次获取 JWKS 的超时时间，获取不随触发它的请求取消
+	jwksFetchTimeout = 10 * time.Second
+)
+
+// ErrTokenRevoked 表示令牌所在的会话已被撤销
+var ErrTokenRevoked = errors.New("token is revoked")
+
+// KeySource 获取签发方当前发布的 JWKS
+type KeySource func(ctx context.Context) (*JWKS, error)
+
+// RevocationList 判断签名有效的令牌是否已被撤销
+type RevocationList interface {
+	IsRevoked(ctx context.Context, identity Identity) (bool, error)
+}
+
+// verificationKey 是解析后的公钥
+type verificationKey struct {
+	alg string
+	key crypto.PublicKey
+}
+
+// Verifier 在本地校验访问令牌的签名，不需要每个请求都调用 user-service。
+// 公钥缓存 cacheTTL 后重新获取；遇到未知的 kid 时立即重新获取，签发方轮换密钥后无需重启服务。
+// 获取失败时继续使用缓存中的公钥，签发方短暂不可用不影响已签发令牌的校验。
+// 获取 JWKS 时不持有锁，签发方响应慢只影响等待新 kid 的请求，缓存中的公钥照常可用。
+type Verifier struct {
+	source      KeySource
+	cacheTTL    time.Duration
+	revocations RevocationList
+	fetches     singleflight.Group // 并发触发的获取合并为一次
+
+	mu          sync.RWMutex
+	keys        map[string]verificationKey
+	fetchedAt   time.Time // 最近一次成功获取的时间
+	attemptedAt time.Time // 最近一次尝试获取的时间
+}
+
+// NewVerifier 创建校验器，cacheTTL 为 0 时使用 DefaultJWKSCacheTTL，revocations 为 nil 时不检查撤销
+func NewVerifier(source KeySource, cacheTTL time.Duration, revocations RevocationList) *Verifier {
+	if cacheTTL <= 0 {
+		cacheTTL = DefaultJWKSCacheTTL
+	}
+	return &Verifier{source: source, cacheTTL: cacheTTL, revocations: revocations}
+}
+
+// Verify 校验令牌的签名和有效期，并检查令牌是否已被撤销
+func (v *Verifier) Verify(ctx context.Context, tokenString string) (Identity, error) {
+	identity, err := ParseToken(tokenString, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.key(ctx, kid, token.Method.Alg())
+	})
+	if err != nil {
+		return Identity{}, err
+	}
+
+	if v.revocations != nil {
+		revoked, err := v.revocations.IsRevoked(ctx, identity)
+		if err != nil {
+			return Identity{}, fmt.Errorf("failed to check token revocation: %w", err)
+		}
+		if revoked {
+			return Identity{}, ErrTokenRevoked
+		}
+	}
+	return identity, nil
+}
+
+// key 返回 kid 对应、可用于 alg 的公钥
+func (v *Verifier) key(ctx context.Context, kid, alg string) (any, error) {
+	if kid == "" {
+		return nil, errors.New("令牌缺少 kid")
+	}
+
+	v.mu.RLock()
+	key, ok := v.keys[kid]
+	now := time.Now()
+	stale := now.Sub(v.fetchedAt) >= v.cacheTTL
+	due := now.Sub(v.attemptedAt) >= minJWKSRefreshInterval
+	v.mu.RUnlock()
+
+	if (!ok || stale) && due {
+		done := v.fetches.DoChan("jwks", func() (any, error) {
+			return nil, v.refresh(context.WithoutCancel(ctx))
+		})
+		if ok {
+			// 缓存过期但公钥仍在，先用缓存中的公钥，在后台刷新
+			return v.checkKey(kid, alg, key)
+		}
+		select {
+		case res := <-done:
+			if res.Err != nil {
+				return nil, res.Err
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		v.mu.RLock()
+		key, ok = v.keys[kid]
+		v.mu.RUnlock()
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("未知的签名密钥 %q", kid)
+	}
+	return v.checkKey(kid, alg, key)
+}
+
+// checkKey 检查公钥能否用于令牌声明的算法
+func (v *Verifier) checkKey(kid, alg string, key verificationKey) (any, error) {
+	if key.alg != alg {
+		return nil, fmt.Errorf("签名密钥 %q 不能用于 %s", kid, alg)
+	}
+	return key.key, nil
+}
+
+// refresh 重新获取 JWKS，跳过不用于签名和不支持的密钥。只在替换公钥时短暂持有锁
+func (v *Verifier) refresh(ctx context.Context) error {
+	v.mu.Lock()
+	if time.Since(v.attemptedAt) < minJWKSRefreshInterval {
+		v.mu.Unlock()
+		return nil
+	}
+	v.attemptedAt = time.Now()
+	v.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, jwksFetchTimeout)
+	defer cancel()
+	jwks, err := v.source(ctx)
+	if err != nil {
+		return fmt.Errorf("获取 JWKS 失败: %w", err)
+	}
+
+	keys := make(map[string]verificationKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, alg, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		if jwk.Alg != "" && jwk.Alg != alg {
+			continue
+		}
+		keys[jwk.Kid] = verificationKey{alg: alg, key: key}
+	}
+	v.mu.Lock()
+	v.keys, v.fetchedAt = keys, time.Now()
+	v.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// fakeKeySource 模拟 user-service 的 GetJWKS，经过一次 JSON 编解码
+type fakeKeySource struct {
+	signer *Signer
+	err    error
+	calls  atomic.Int32
+}
+
+func (s *fakeKeySource) fetch(ctx context.Context) (*JWKS, error) {
+	s.calls.Add(1)
+	if s.err != nil {
+		return nil, s.err
+	}
+	data, err := json.Marshal(s.signer.JWKS())
+	if err != nil {
+		return nil, err
+	}
+	var jwks JWKS
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, err
+	}
+	return &jwks, nil
+}
+
+type fakeRevocationList map[string]bool
+
+func (l fakeRevocationList) IsRevoked(ctx context.Context, identity Identity) (bool, error) {
+	sessionID, _ := identity.GetStringClaim(ClaimSessionID)
+	return l[sessionID], nil
+}
+
+func signTestToken(t *testing.T, signer *Signer, claims jwt.MapClaims) string {
+	t.Helper()
+	claims["exp"] = time.Now().Add(time.Hour).Unix()
+	token, err := signer.Sign(claims)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestVerifierCachesKeys(t *testing.T) {
+	ctx := context.Background()
+	source := &fakeKeySource{signer: newTestSigner(t)}
+	verifier := NewVerifier(source.fetch, time.Hour, nil)
+
+	token := signTestToken(t, source.signer, jwt.MapClaims{"user_id": 7, "username": "bob"})
+	for range 3 {
+		identity, err := verifier.Verify(ctx, token)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if identity.UserID != 7 || identity.Username != "bob" {
+			t.Fatalf("unexpected identity: %+v", identity)
+		}
+	}
+	if calls := source.calls.Load(); calls != 1 {
+		t.Fatalf("expected JWKS to be fetched once, got %d", calls)
+	}
+
+	// user-service 不可用时继续使用缓存中的公钥
+	source.err = errors.New("unavailable")
+	verifier.fetchedAt = time.Now().Add(-2 * time.Hour)
+	verifier.attemptedAt = verifier.fetchedAt
+	if _, err := verifier.Verify(ctx, token); err != nil {
+		t.Fatalf("expected cached key to be used, got %v", err)
+	}
+	// 缓存过期时在后台刷新
+	deadline := time.Now().Add(time.Second)
+	for source.calls.Load() != 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if calls := source.calls.Load(); calls != 2 {
+		t.Fatalf("expected stale cache to trigger a refresh, got %d calls", calls)
+	}
+}
+
+func TestVerifierKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	source := &fakeKeySource{signer: newTestSigner(t)}
+	verifier := NewVerifier(source.fetch, time.Hour, nil)
+
+	if _, err := verifier.Verify(ctx, signTestToken(t, source.signer, jwt.MapClaims{"user_id": 1})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 轮换后遇到未知的 kid 立即重新获取
+	rotated := newTestSigner(t)
+	source.signer = rotated
+	verifier.attemptedAt = time.Time{}
+	if _, err := verifier.Verify(ctx, signTestToken(t, rotated, jwt.MapClaims{"user_id": 1})); err != nil {
+		t.Fatalf("expected rotated key to be fetched, got %v", err)
+	}
+	if calls := source.calls.Load(); calls != 2 {
+		t.Fatalf("expected 2 fetches, got %d", calls)
+	}
+
+	// 伪造的 kid 不会在短时间内反复触发获取
+	forged := signTestToken(t, newTestSigner(t), jwt.MapClaims{"user_id": 1})
+	for range 3 {
+		if _, err := verifier.Verify(ctx, forged); err == nil {
+			t.Fatalf("expected unknown key to be rejected")
+		}
+	}
+	if calls := source.calls.Load(); calls != 2 {
+		t.Fatalf("expected no refetch within the refresh interval, got %d", calls)
+	}
+}
+
+func TestVerifierSlowSourceDoesNotBlockCachedKeys(t *testing.T) {
+	ctx := context.Background()
+	source := &fakeKeySource{signer: newTestSigner(t)}
+	entered, release := make(chan struct{}, 1), make(chan struct{})
+	var blocking atomic.Bool
+	verifier := NewVerifier(func(ctx context.Context) (*JWKS, error) {
+		if blocking.Load() {
+			entered <- struct{}{}
+			<-release
+		}
+		return source.fetch(ctx)
+	}, time.Hour, nil)
+	defer close(release)
+
+	cached := signTestToken(t, source.signer, jwt.MapClaims{"user_id": 1})
+	if _, err := verifier.Verify(ctx, cached); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 未知的 kid 触发一次卡住的获取
+	blocking.Store(true)
+	verifier.attemptedAt = time.Time{}
+	unknown := signTestToken(t, newTestSigner(t), jwt.MapClaims{"user_id": 2})
+	pending := make(chan error, 1)
+	go func() {
+		_, err := verifier.Verify(ctx, unknown)
+		pending <- err
+	}()
+	select {
+	case <-entered:
+	case <-time.After(time.Second):
+		t.Fatalf("expected the unknown kid to trigger a fetch")
+	}
+
+	// 获取进行中时缓存中的 kid 仍能立即校验，缓存过期也不等待获取结果
+	for _, fetchedAt := range []time.Time{time.Now(), time.Now().Add(-2 * time.Hour)} {
+		verifier.mu.Lock()
+		verifier.fetchedAt = fetchedAt
+		verifier.mu.Unlock()
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := verifier.Verify(ctx, cached)
+			done <- err
+		}()
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("verification of a cached kid blocked on the slow key source")
+		}
+	}
+
+	select {
+	case <-pending:
+		t.Fatalf("expected the unknown kid to wait for the slow key source")
+	default:
+	}
+}
+
+func TestVerifierRevocation(t *testing.T) {
+	ctx := context.Background()
+	source := &fakeKeySource{signer: newTestSigner(t)}
+	verifier := NewVerifier(source.fetch, 0, fakeRevocationList{"revoked": true})
+
+	if _, err := verifier.Verify(ctx, signTestToken(t, source.signer, jwt.MapClaims{"user_id": 1, ClaimSessionID: "active"})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err := verifier.Verify(ctx, signTestToken(t, source.signer, jwt.MapClaims{"user_id": 1, ClaimSessionID: "revoked"}))
+	if !errors.Is(err, ErrTokenRevoked) {
+		t.Fatalf("expected ErrTokenRevoked, got %v", err)
+	}
+}
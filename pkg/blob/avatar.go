@@ -0,0 +1,25 @@
+package blob
+
+import "fmt"
+
+// 头像缩略图的固定尺寸 (像素)，上传时每个尺寸各生成一张正方形 PNG
+const (
+	AvatarSizeLarge = 256 // 个人资料页
+	AvatarSizeSmall = 64  // 问题、回答、评论的作者
+)
+
+// AvatarSizes 是上传头像时生成的全部缩略图尺寸
+var AvatarSizes = []int{AvatarSizeLarge, AvatarSizeSmall}
+
+// AvatarKey 返回头像某个尺寸缩略图的对象键，prefix 是 users.avatar_key 中保存的头像目录
+func AvatarKey(prefix string, size int) string {
+	return fmt.Sprintf("%s/%d.png", prefix, size)
+}
+
+// AvatarURL 返回头像缩略图的地址，prefix 为空 (用户没有上传头像) 时返回空字符串
+func AvatarURL(baseURL, prefix string, size int) string {
+	if prefix == "" {
+		return ""
+	}
+	return PublicURL(baseURL, AvatarKey(prefix, size))
+}
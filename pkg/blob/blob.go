@@ -0,0 +1,52 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"qahub/pkg/config"
+)
+
+// ErrInvalidKey 表示对象键为空、是绝对路径或包含 ".." 等路径穿越片段
+var ErrInvalidKey = errors.New("无效的对象键")
+
+// Store 定义了保存上传文件所需的方法，便于在本地目录与对象存储之间切换。
+// 对象键是以 "/" 分隔的相对路径，例如 "avatars/1/3f2a.../256.png"
+type Store interface {
+	// Put 写入对象，已存在时覆盖
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	// Delete 删除以 prefix 开头的全部对象，对象不存在时不报错
+	Delete(ctx context.Context, prefix string) error
+	// URL 返回客户端访问对象的地址
+	URL(key string) string
+}
+
+// New 根据配置中的 driver 创建对应的 Store
+func New(cfg config.Blob) (Store, error) {
+	switch cfg.Driver {
+	case "local", "":
+		return NewLocalStore(cfg.Dir, cfg.BaseURL)
+	default:
+		return nil, fmt.Errorf("不支持的文件存储方式: %s", cfg.Driver)
+	}
+}
+
+// PublicURL 拼接访问对象的地址，供只需要生成地址、不读写文件的服务使用
+func PublicURL(baseURL, key string) string {
+	return strings.TrimRight(baseURL, "/") + "/" + key
+}
+
+// validateKey 校验对象键，避免写到存储目录之外
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package blob
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"qahub/pkg/config"
+)
+
+func TestNew(t *testing.T) {
+	if _, err := New(config.Blob{Driver: "", Dir: t.TempDir()}); err != nil {
+		t.Fatalf("empty driver should fall back to local: %v", err)
+	}
+	if _, err := New(config.Blob{Driver: "local"}); err == nil {
+		t.Fatal("expected error when dir is not configured")
+	}
+	if _, err := New(config.Blob{Driver: "tape", Dir: t.TempDir()}); err == nil {
+		t.Fatal("expected error for unknown driver")
+	}
+}
+
+func TestAvatarURL(t *testing.T) {
+	if got := AvatarURL("http://localhost/blobs/", "avatars/1/abc", AvatarSizeSmall); got != "http://localhost/blobs/avatars/1/abc/64.png" {
+		t.Errorf("unexpected avatar url %q", got)
+	}
+	if got := AvatarURL("http://localhost/blobs", "", AvatarSizeLarge); got != "" {
+		t.Errorf("user without avatar should have empty url, got %q", got)
+	}
+}
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := NewLocalStore(dir, "http://localhost:8081/blobs")
+	if err != nil {
+		t.Fatalf("NewLocalStore returned error: %v", err)
+	}
+
+	if err := store.Put(ctx, "avatars/1/abc/64.png", []byte("png"), "image/png"); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+	if got := store.URL("avatars/1/abc/64.png"); got != "http://localhost:8081/blobs/avatars/1/abc/64.png" {
+		t.Errorf("unexpected url %q", got)
+	}
+	for _, key := range []string{"", "/etc/passwd", "../escape", "avatars/../../escape", "avatars//x"} {
+		if err := store.Put(ctx, key, []byte("x"), "text/plain"); err != ErrInvalidKey {
+			t.Errorf("Put(%q) should be rejected, got %v", key, err)
+		}
+	}
+
+	handler := store.(http.Handler)
+	get := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+	if rec := get("/blobs/avatars/1/abc/64.png"); rec.Code != http.StatusOK || rec.Body.String() != "png" || rec.Header().Get("Content-Type") != "image/png" {
+		t.Errorf("unexpected response %d %q %q", rec.Code, rec.Header().Get("Content-Type"), rec.Body.String())
+	}
+	for _, path := range []string{"/blobs/avatars/1/abc", "/blobs/../blob_test.go", "/other/avatars/1/abc/64.png"} {
+		if rec := get(path); rec.Code != http.StatusNotFound {
+			t.Errorf("GET %s should be not found, got %d", path, rec.Code)
+		}
+	}
+
+	if err := store.Delete(ctx, "avatars/1/abc"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "avatars", "1", "abc")); !os.IsNotExist(err) {
+		t.Errorf("avatar directory should be removed, got %v", err)
+	}
+	if err := store.Delete(ctx, "avatars/1/abc"); err != nil {
+		t.Errorf("deleting a missing prefix should not fail: %v", err)
+	}
+}
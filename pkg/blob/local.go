@@ -0,0 +1,100 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// localStore 将对象保存为本地目录中的文件，同时实现 http.Handler，按 baseURL 的路径提供下载
+type localStore struct {
+	dir      string
+	baseURL  string
+	basePath string // baseURL 中的路径部分，例如 "/blobs"
+}
+
+// NewLocalStore 创建一个将对象写入 dir 目录的 Store
+func NewLocalStore(dir, baseURL string) (Store, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("未配置文件存储目录")
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("文件访问地址无效: %w", err)
+	}
+	return &localStore{dir: dir, baseURL: baseURL, basePath: strings.TrimRight(u.Path, "/")}, nil
+}
+
+// Put 先写入临时文件再重命名，读取方不会看到写了一半的文件
+func (s *localStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("创建目录失败: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("创建临时文件失败: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("写入文件失败: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("设置文件权限失败: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Delete 删除 prefix 对应的文件或目录
+func (s *localStore) Delete(ctx context.Context, prefix string) error {
+	if err := validateKey(prefix); err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(s.dir, filepath.FromSlash(prefix)))
+}
+
+func (s *localStore) URL(key string) string {
+	return PublicURL(s.baseURL, key)
+}
+
+// ServeHTTP 提供对象的下载，不列出目录
+func (s *localStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	key, ok := strings.CutPrefix(r.URL.Path, s.basePath+"/")
+	if !ok || validateKey(key) != nil {
+		http.NotFound(w, r)
+		return
+	}
+	f, err := os.Open(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+}
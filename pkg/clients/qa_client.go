@@ -42,6 +42,20 @@ func (c *QAServiceClient) ListQuestions(ctx context.Context, page, pageSize int3
 	})
 }
 
+// BatchGetQuestions 按 ID 批量获取问题
+func (c *QAServiceClient) BatchGetQuestions(ctx context.Context, ids []int64) (*pb.BatchGetQuestionsResponse, error) {
+	return c.client.BatchGetQuestions(ctx, &pb.BatchGetQuestionsRequest{
+		Ids: ids,
+	})
+}
+
+// BatchGetAnswers 按 ID 批量获取回答
+func (c *QAServiceClient) BatchGetAnswers(ctx context.Context, ids []int64) (*pb.BatchGetAnswersResponse, error) {
+	return c.client.BatchGetAnswers(ctx, &pb.BatchGetAnswersRequest{
+		Ids: ids,
+	})
+}
+
 // Close 关闭客户端连接
 func (c *QAServiceClient) Close() error {
 	return c.conn.Close()
@@ -3,17 +3,20 @@ package clients
 import (
 	"context"
 	pb "qahub/api/proto/user"
+	"qahub/pkg/auth"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 // UserServiceClient 是 UserService 的 gRPC 客户端封装
 type UserServiceClient struct {
-	conn   *grpc.ClientConn
-	client pb.UserServiceClient
+	conn        *grpc.ClientConn
+	client      pb.UserServiceClient
+	adminClient pb.AdminUserServiceClient
 }
 
 // NewUserGrpcServer 创建一个新的 gRPC 服务端处理器
@@ -27,8 +30,9 @@ func NewUserServiceClient(address string) (*UserServiceClient, error) {
 	}
 	client := pb.NewUserServiceClient(conn)
 	return &UserServiceClient{
-		conn:   conn,
-		client: client,
+		conn:        conn,
+		client:      client,
+		adminClient: pb.NewAdminUserServiceClient(conn),
 	}, nil
 }
 
@@ -47,8 +51,38 @@ func (c *UserServiceClient) ValidateToken(ctx context.Context, token string) (*p
 	})
 }
 
-// GetUserProfile 获取用户信息
+// GetJWKS 获取校验访问令牌签名的公钥，可以直接作为 auth.KeySource 使用
+func (c *UserServiceClient) GetJWKS(ctx context.Context) (*auth.JWKS, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetJWKS(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	jwks := &auth.JWKS{Keys: make([]auth.JSONWebKey, 0, len(resp.Keys))}
+	for _, key := range resp.Keys {
+		jwks.Keys = append(jwks.Keys, auth.JSONWebKey{
+			Kty: key.Kty,
+			Kid: key.Kid,
+			Use: key.Use,
+			Alg: key.Alg,
+			N:   key.N,
+			E:   key.E,
+			Crv: key.Crv,
+			X:   key.X,
+		})
+	}
+	return jwks, nil
+}
+
+// GetUserProfile 获取用户信息，会转发调用方的认证信息
 func (c *UserServiceClient) GetUserProfile(ctx context.Context, userID int64) (*pb.GetUserProfileResponse, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
@@ -57,6 +91,18 @@ func (c *UserServiceClient) GetUserProfile(ctx context.Context, userID int64) (*
 	})
 }
 
+// ImportUser 在用户服务中创建导入的用户，会转发调用方的认证信息，调用方必须是管理员
+func (c *UserServiceClient) ImportUser(ctx context.Context, req *pb.ImportUserRequest) (*pb.ImportUserResponse, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if ok {
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	return c.adminClient.ImportUser(ctx, req)
+}
+
 // Close 关闭 gRPC 连接
 func (c *UserServiceClient) Close() error {
 	if c.conn != nil {
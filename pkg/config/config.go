@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -20,6 +21,9 @@ type Config struct {
 	Kafka         Kafka         `mapstructure:"kafka"`
 	Elasticsearch Elasticsearch `mapstructure:"elasticsearch"`
 	MongoDB       MongoDB       `mapstructure:"mongodb"`
+	Mail          Mail          `mapstructure:"mail"`
+	Blob          Blob          `mapstructure:"blob"`
+	Auth          Auth          `mapstructure:"auth"`
 	Services      Services      `mapstructure:"services"`
 }
 
@@ -83,6 +87,7 @@ type Kafka struct {
 type Topics struct {
 	QAEvents           string `mapstructure:"qa_events"`
 	NotificationEvents string `mapstructure:"notification_events"`
+	UserEvents         string `mapstructure:"user_events"`
 }
 
 // Elasticsearch 对应于 [elasticsearch] 配置部分
@@ -117,6 +122,35 @@ func (m *MongoDB) URI() string {
 	)
 }
 
+// Mail 对应于 [mail] 配置部分
+type Mail struct {
+	Driver   string `mapstructure:"driver"`   // 发送方式: smtp 或 file，file 只写入本地文件和日志，用于开发和测试
+	Host     string `mapstructure:"host"`     // SMTP 服务器地址
+	Port     int    `mapstructure:"port"`     // SMTP 服务器端口
+	Username string `mapstructure:"username"` // SMTP 认证用户名，为空时不认证
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from"` // 发件人地址
+	Dir      string `mapstructure:"dir"`  // file 方式下邮件的保存目录，为空时只写日志
+}
+
+// Addr 返回 SMTP 服务器的 host:port 地址
+func (m *Mail) Addr() string {
+	return fmt.Sprintf("%s:%d", m.Host, m.Port)
+}
+
+// Blob 对应于 [blob] 配置部分，决定头像等上传文件的保存位置
+type Blob struct {
+	Driver  string `mapstructure:"driver"`   // 存储方式: local，将文件写入本地目录
+	Dir     string `mapstructure:"dir"`      // local 方式下文件的保存目录
+	BaseURL string `mapstructure:"base_url"` // 客户端访问文件的地址前缀，local 方式下由 user-service 的 HTTP 端口按此路径提供文件
+}
+
+// Auth 对应于 [auth] 配置部分，决定 qa、search、notification 服务如何校验访问令牌
+type Auth struct {
+	JWKSCacheTTL    time.Duration `mapstructure:"jwks_cache_ttl"`   // user-service 公钥的缓存时间，遇到未知的 kid 时会立即重新获取，例如 "1h"
+	CheckRevocation bool          `mapstructure:"check_revocation"` // 是否通过 Redis 检查令牌所在的会话是否已被撤销 (登出、下线设备)
+}
+
 // Services 对应于 [services] 配置部分
 type Services struct {
 	UserService         UserService         `mapstructure:"user_service"`
@@ -128,25 +162,91 @@ type Services struct {
 
 // UserService 对应于 [services.user_service] 配置部分
 type UserService struct {
-	JWTSecret        string   `mapstructure:"jwt_secret"`
-	TokenExpireHours int      `mapstructure:"token_expire_hours"`
-	GrpcPort         string   `mapstructure:"grpc_port"`
-	HttpPort         string   `mapstructure:"http_port"`
-	PublicMethods    []string `mapstructure:"public_methods"`
+	AccessTokenTTL  time.Duration `mapstructure:"access_token_ttl"`  // 访问令牌有效期，例如 "15m"
+	RefreshTokenTTL time.Duration `mapstructure:"refresh_token_ttl"` // 刷新令牌有效期，每次轮换后重新计算，例如 "720h"
+	GrpcPort        string        `mapstructure:"grpc_port"`
+	HttpPort        string        `mapstructure:"http_port"`
+	PublicMethods   []string      `mapstructure:"public_methods"`
+
+	SigningKeys      []SigningKey `mapstructure:"signing_keys"`       // 访问令牌的签名密钥，公钥全部发布在 JWKS 中
+	ActiveSigningKey string       `mapstructure:"active_signing_key"` // 签发新令牌使用的密钥 kid，为空时使用第一个
+
+	PasswordResetTTL   time.Duration `mapstructure:"password_reset_ttl"`   // 密码重置令牌有效期，例如 "30m"
+	PasswordResetLimit int           `mapstructure:"password_reset_limit"` // 每个邮箱和每个 IP 每小时最多发起的重置请求数
+
+	EmailVerificationTTL   time.Duration `mapstructure:"email_verification_ttl"`   // 邮箱验证令牌有效期，例如 "24h"
+	EmailVerificationLimit int           `mapstructure:"email_verification_limit"` // 每个用户每小时最多重发的验证邮件数
+
+	LoginMaxFailures   int           `mapstructure:"login_max_failures"`    // 同一用户名连续登录失败多少次后临时锁定
+	LoginIPMaxFailures int           `mapstructure:"login_ip_max_failures"` // 同一 IP 登录失败多少次后临时锁定
+	LoginFailureWindow time.Duration `mapstructure:"login_failure_window"`  // 失败计数在最后一次失败后保留的时间，例如 "1h"
+	LoginLockout       time.Duration `mapstructure:"login_lockout"`         // 首次锁定的时长，之后每次失败翻倍，例如 "1m"
+	LoginMaxLockout    time.Duration `mapstructure:"login_max_lockout"`     // 锁定时长的上限，例如 "1h"
+
+	TrustedProxies []string `mapstructure:"trusted_proxies"` // 受信任的反向代理 (IP 或 CIDR)，只有来自这些地址的请求才采信 X-Forwarded-For 中的客户端 IP
+
+	UsernameChangeCooldown time.Duration `mapstructure:"username_change_cooldown"` // 两次修改用户名之间的最短间隔，例如 "720h"
+
+	FeedMaxItems int           `mapstructure:"feed_max_items"` // 每个用户的关注动态时间线最多保留的条数
+	FeedTTL      time.Duration `mapstructure:"feed_ttl"`       // 动态内容和时间线在没有新动态后保留的时间，例如 "720h"
+
+	AvatarMaxBytes     int64 `mapstructure:"avatar_max_bytes"`     // 上传头像文件的最大字节数
+	AvatarMaxDimension int   `mapstructure:"avatar_max_dimension"` // 上传头像的最大宽高 (像素)，超过时拒绝，避免解码过大的图片
+
+	TwoFactorChallengeTTL  time.Duration `mapstructure:"two_factor_challenge_ttl"`  // 两步验证登录挑战的有效期，例如 "5m"
+	TwoFactorRequiredRoles []string      `mapstructure:"two_factor_required_roles"` // 必须启用两步验证的角色，未启用时只授予普通用户权限
+
+	OAuthStateTTL  time.Duration            `mapstructure:"oauth_state_ttl"` // 单点登录从跳转到身份提供方到回调的时限，例如 "10m"
+	OAuthProviders map[string]OAuthProvider `mapstructure:"oauth_providers"` // 单点登录的身份提供方，键为登录地址中的 provider 名称
+
+	AccessTokenScopes AccessTokenScopes `mapstructure:"access_token_scopes"`
+}
+
+// SigningKey 对应于 [services.user_service.signing_keys] 中的一项
+type SigningKey struct {
+	ID             string `mapstructure:"kid"`
+	PrivateKeyFile string `mapstructure:"private_key_file"` // PEM 格式的 Ed25519 或 RSA (至少 2048 位) 私钥
+}
+
+// OAuthProvider 对应于 [services.user_service.oauth_providers.<name>] 配置部分
+type OAuthProvider struct {
+	Issuer       string   `mapstructure:"issuer"` // 通过 <issuer>/.well-known/openid-configuration 进行服务发现
+	ClientID     string   `mapstructure:"client_id"`
+	ClientSecret string   `mapstructure:"client_secret"`
+	RedirectURL  string   `mapstructure:"redirect_url"` // 指向网关的回调地址 /api/v1/auth/oauth/<name>/callback
+	Scopes       []string `mapstructure:"scopes"`       // 为空时请求 openid email profile
 }
 
 // QAService 对应于 [services.qa_service] 配置部分
 type QAService struct {
-	GrpcPort      string   `mapstructure:"grpc_port"`
-	HttpPort      string   `mapstructure:"http_port"`
-	PublicMethods []string `mapstructure:"public_methods"`
+	GrpcPort                string        `mapstructure:"grpc_port"`
+	HttpPort                string        `mapstructure:"http_port"`
+	PublicMethods           []string      `mapstructure:"public_methods"`
+	VerifiedEmailMethods    []string      `mapstructure:"verified_email_methods"`    // 要求邮箱已验证的方法，未验证的用户只能浏览，为空时不做限制
+	VoteNotifyWindow        time.Duration `mapstructure:"vote_notify_window"`        // 点赞通知的聚合窗口，例如 "1m"
+	TrendingRebuildInterval time.Duration `mapstructure:"trending_rebuild_interval"` // 热门排行榜的全量重建间隔，例如 "10m"
+	TrendingDecay           time.Duration `mapstructure:"trending_decay"`            // 热门分数的衰减周期，问题晚发布一个周期相当于互动量乘以 10，例如 "12h"
+	ReconcileInterval       time.Duration `mapstructure:"reconcile_interval"`        // 冗余计数的定期校对间隔，例如 "1h"
+	Cache                   QACache       `mapstructure:"cache"`
+
+	AccessTokenScopes AccessTokenScopes `mapstructure:"access_token_scopes"`
+}
+
+// QACache 对应于 [services.qa_service.cache] 配置部分
+type QACache struct {
+	Enabled   bool          `mapstructure:"enabled"`    // 是否启用问题详情和列表页的 Redis 缓存
+	TTL       time.Duration `mapstructure:"ttl"`        // 缓存过期时间，例如 "5m"
+	ListPages int           `mapstructure:"list_pages"` // 只缓存问题列表的前 N 页
 }
 
 // SearchService 对应于 [services.search_service] 配置部分
 type SearchService struct {
-	GrpcPort      string   `mapstructure:"grpc_port"`
-	HttpPort      string   `mapstructure:"http_port"`
-	PublicMethods []string `mapstructure:"public_methods"`
+	GrpcPort        string        `mapstructure:"grpc_port"`
+	HttpPort        string        `mapstructure:"http_port"`
+	PublicMethods   []string      `mapstructure:"public_methods"`
+	RelatedCacheTTL time.Duration `mapstructure:"related_cache_ttl"` // 相关问题结果的缓存时间
+
+	AccessTokenScopes AccessTokenScopes `mapstructure:"access_token_scopes"`
 }
 
 // NotificationService 对应于 [services.notification_service] 配置部分
@@ -154,8 +254,14 @@ type NotificationService struct {
 	GrpcPort      string   `mapstructure:"grpc_port"`
 	HttpPort      string   `mapstructure:"http_port"`
 	PublicMethods []string `mapstructure:"public_methods"`
+
+	AccessTokenScopes AccessTokenScopes `mapstructure:"access_token_scopes"`
 }
 
+// AccessTokenScopes 对应于各服务的 access_token_scopes 配置部分，键为权限范围 (read、write:qa、admin)，
+// 值为该范围允许通过个人访问令牌调用的方法。未列出的方法只能使用登录后的 JWT 调用。
+type AccessTokenScopes map[string][]string
+
 // Gateway 对应于 [service.gateway] 配置部分
 type Gateway struct {
 	Port                        string `mapstructure:"port"`
@@ -199,3 +305,7 @@ func (c *Config) QuestionCreatedDestination() string {
 func (c *Config) NotificationDestination() string {
 	return c.Kafka.Topics.NotificationEvents
 }
+
+func (c *Config) UserEventsDestination() string {
+	return c.Kafka.Topics.UserEvents
+}
@@ -0,0 +1,42 @@
+// Package i18n 定义了各服务共用的语言标识。
+package i18n
+
+import "strings"
+
+// 支持的语言
+const (
+	LanguageZH = "zh"
+	LanguageEN = "en"
+)
+
+// DefaultLanguage 是用户未设置或设置了不支持的语言时使用的语言
+const DefaultLanguage = LanguageZH
+
+// SupportedLanguages 按展示顺序列出所有支持的语言
+var SupportedLanguages = []string{LanguageZH, LanguageEN}
+
+// IsSupported 判断语言是否受支持，会先按 Normalize 的规则处理地区后缀
+func IsSupported(lang string) bool {
+	switch base(lang) {
+	case LanguageZH, LanguageEN:
+		return true
+	}
+	return false
+}
+
+// Normalize 将 "en-US"、"zh_CN" 这样的语言标签归一化为受支持的语言，无法识别时返回默认语言
+func Normalize(lang string) string {
+	if !IsSupported(lang) {
+		return DefaultLanguage
+	}
+	return base(lang)
+}
+
+// base 去掉语言标签中的地区部分并转为小写
+func base(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	return lang
+}
@@ -0,0 +1,32 @@
+package i18n
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{
+		"zh":    LanguageZH,
+		"en":    LanguageEN,
+		"en-US": LanguageEN,
+		"zh_CN": LanguageZH,
+		" EN ":  LanguageEN,
+		"":      DefaultLanguage,
+		"fr":    DefaultLanguage,
+	}
+	for input, want := range cases {
+		if got := Normalize(input); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	if !IsSupported("en-GB") {
+		t.Error("en-GB 应被视为受支持的语言")
+	}
+	if IsSupported("ja") {
+		t.Error("ja 不应被视为受支持的语言")
+	}
+	if IsSupported("") {
+		t.Error("空字符串不应被视为受支持的语言")
+	}
+}
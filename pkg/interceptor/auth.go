@@ -13,107 +13,161 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-// AuthUnaryServerInterceptor 创建一个 gRPC 服务端拦截器，用于通过 user-service 验证 token
-// 这个拦截器将 token 从 metadata 中提取出来，并调用 user-service 进行验证。
-func AuthUnaryServerInterceptor(userClient *clients.UserServiceClient, publicMethods ...string) grpc.UnaryServerInterceptor {
+// AuthUnaryServerInterceptor 创建一个 gRPC 服务端拦截器，用于验证请求中的 token
+// 这个拦截器将 token 从 metadata 中提取出来，JWT 由 verifier 在本地校验签名，
+// 个人访问令牌和未提供 verifier 时调用 user-service 进行验证。
+// publicMethods 允许匿名调用；调用时携带了 token 则同样校验并注入身份，token 无效时拒绝请求，
+// 以便这些方法能为已登录的用户返回匿名作者、点赞状态等与身份相关的内容。
+func AuthUnaryServerInterceptor(userClient *clients.UserServiceClient, verifier *auth.Verifier, publicMethods ...string) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		// 检查是否在白名单中
-		if slices.Contains(publicMethods, info.FullMethod) {
-			// 白名单路径，跳过认证
+		// 白名单路径未携带 token 时按匿名用户处理
+		if slices.Contains(publicMethods, info.FullMethod) && !hasAuthorization(ctx) {
 			return handler(ctx, req)
 		}
 
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Errorf(codes.Unauthenticated, "缺少认证信息 (metadata)")
+		identity, err := authenticate(ctx, userClient, verifier)
+		if err != nil {
+			return nil, err
 		}
 
-		authHeaders := md.Get("authorization")
-		if len(authHeaders) == 0 {
-			return nil, status.Errorf(codes.Unauthenticated, "请求未包含授权标头")
-		}
+		// 验证成功，将用户信息注入到 context 中，继续处理请求
+		return handler(auth.WithIdentity(ctx, identity), req)
+	}
+}
 
-		authHeader := authHeaders[0]
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		if tokenString == authHeader { // 如果没有 "Bearer " 前缀，TrimPrefix 不会改变字符串
-			return nil, status.Errorf(codes.Unauthenticated, "授权标头格式不正确，需要 'Bearer ' 前缀")
+// AuthStreamServerInterceptor 创建一个 gRPC 流服务端拦截器，用于验证请求中的 token
+// 验证方式与 AuthUnaryServerInterceptor 相同。
+func AuthStreamServerInterceptor(userClient *clients.UserServiceClient, verifier *auth.Verifier, publicMethods ...string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		// 白名单路径未携带 token 时按匿名用户处理
+		if slices.Contains(publicMethods, info.FullMethod) && !hasAuthorization(ss.Context()) {
+			return handler(srv, ss)
 		}
 
-		// 调用 user-service 的 ValidateToken RPC
-		validateResp, err := userClient.ValidateToken(ctx, tokenString)
+		identity, err := authenticate(ss.Context(), userClient, verifier)
 		if err != nil {
-			return nil, status.Errorf(codes.Unauthenticated, "token 验证失败: %v", err)
+			return err
 		}
 
-		// 将 structpb.Value map 转换为 jwt.MapClaims
-		claims := make(map[string]any)
-		for k, v := range validateResp.Claims {
-			claims[k] = v.AsInterface()
-		}
-
-		// 验证成功，将用户信息注入到 context 中
-		identity := auth.Identity{
-			UserID:   validateResp.UserId,
-			Username: validateResp.Username,
-			Claims:   claims,
-		}
-		newCtx := auth.WithIdentity(ctx, identity)
+		// 使用注入了用户信息的上下文创建一个包装的 ServerStream
+		wrappedSS := newWrappedServerStream(auth.WithIdentity(ss.Context(), identity), ss)
 
 		// 继续处理请求
-		return handler(newCtx, req)
+		return handler(srv, wrappedSS)
 	}
 }
 
-// AuthStreamServerInterceptor 创建一个 gRPC 流服务端拦截器，用于通过 user-service 验证 token
-// 这个拦截器将 token 从 metadata 中提取出来，并调用 user-service 进行验证。
-func AuthStreamServerInterceptor(userClient *clients.UserServiceClient, publicMethods ...string) grpc.StreamServerInterceptor {
-	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		// 检查是否在白名单中
-		if slices.Contains(publicMethods, info.FullMethod) {
-			// 白名单路径，跳过认证
-			return handler(srv, ss)
-		}
+// hasAuthorization 判断请求是否携带了授权标头
+func hasAuthorization(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	return ok && len(md.Get("authorization")) > 0
+}
 
-		md, ok := metadata.FromIncomingContext(ss.Context())
-		if !ok {
-			return status.Errorf(codes.Unauthenticated, "缺少认证信息 (metadata)")
-		}
+// authenticate 从 metadata 中提取 token 并验证，返回 token 所属用户的身份
+func authenticate(ctx context.Context, userClient *clients.UserServiceClient, verifier *auth.Verifier) (auth.Identity, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return auth.Identity{}, status.Errorf(codes.Unauthenticated, "缺少认证信息 (metadata)")
+	}
+
+	authHeaders := md.Get("authorization")
+	if len(authHeaders) == 0 {
+		return auth.Identity{}, status.Errorf(codes.Unauthenticated, "请求未包含授权标头")
+	}
 
-		authHeaders := md.Get("authorization")
-		if len(authHeaders) == 0 {
-			return status.Errorf(codes.Unauthenticated, "请求未包含授权标头")
+	authHeader := authHeaders[0]
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+	if tokenString == authHeader { // 如果没有 "Bearer " 前缀，TrimPrefix 不会改变字符串
+		return auth.Identity{}, status.Errorf(codes.Unauthenticated, "授权标头格式不正确，需要 'Bearer ' 前缀")
+	}
+
+	// JWT 在本地校验签名，不依赖 user-service 是否可用
+	if verifier != nil && !strings.HasPrefix(tokenString, auth.AccessTokenPrefix) {
+		identity, err := verifier.Verify(ctx, tokenString)
+		if err != nil {
+			return auth.Identity{}, status.Errorf(codes.Unauthenticated, "token 验证失败: %v", err)
 		}
+		return identity, nil
+	}
 
-		authHeader := authHeaders[0]
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		if tokenString == authHeader { // 如果没有 "Bearer " 前缀，TrimPrefix 不会改变字符串
-			return status.Errorf(codes.Unauthenticated, "授权标头格式不正确，需要 'Bearer ' 前缀")
+	// 个人访问令牌只保存在 user-service，调用其 ValidateToken RPC
+	validateResp, err := userClient.ValidateToken(ctx, tokenString)
+	if err != nil {
+		return auth.Identity{}, status.Errorf(codes.Unauthenticated, "token 验证失败: %v", err)
+	}
+
+	// 将 structpb.Value map 转换为 jwt.MapClaims
+	claims := make(map[string]any)
+	for k, v := range validateResp.Claims {
+		claims[k] = v.AsInterface()
+	}
+
+	return auth.Identity{
+		UserID:   validateResp.UserId,
+		Username: validateResp.Username,
+		Token:    tokenString,
+		Claims:   claims,
+	}, nil
+}
+
+// VerifiedEmailUnaryServerInterceptor 创建一个 gRPC 服务端拦截器，禁止邮箱未验证的用户调用指定的方法。
+// 它依赖认证拦截器注入的身份信息，必须放在认证拦截器之后。
+func VerifiedEmailUnaryServerInterceptor(methods ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !slices.Contains(methods, info.FullMethod) {
+			return handler(ctx, req)
 		}
 
-		// 调用 user-service 的 ValidateToken RPC
-		validateResp, err := userClient.ValidateToken(ss.Context(), tokenString)
-		if err != nil {
-			return status.Errorf(codes.Unauthenticated, "token 验证失败: %v", err)
+		identity, ok := auth.FromContext(ctx)
+		if ok && !identity.EmailVerified() {
+			return nil, status.Errorf(codes.PermissionDenied, "邮箱未验证，请先完成邮箱验证")
 		}
+		return handler(ctx, req)
+	}
+}
 
-		// 将 structpb.Value map 转换为 jwt.MapClaims
-		claims := make(map[string]any)
-		for k, v := range validateResp.Claims {
-			claims[k] = v.AsInterface()
+// AccessTokenScopeUnaryServerInterceptor 创建一个 gRPC 服务端拦截器，限制个人访问令牌能调用的方法。
+// scopes 的键是权限范围，值是该范围允许调用的方法；未列出的方法不能通过个人访问令牌调用。
+// 通过 JWT 登录的身份不受限制。它依赖认证拦截器注入的身份信息，必须放在认证拦截器之后。
+func AccessTokenScopeUnaryServerInterceptor(scopes map[string][]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := checkAccessTokenScope(ctx, info.FullMethod, scopes); err != nil {
+			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
 
-		// 验证成功，将用户信息注入到 context 中
-		identity := auth.Identity{
-			UserID:   validateResp.UserId,
-			Username: validateResp.Username,
-			Claims:   claims,
+// AccessTokenScopeStreamServerInterceptor 是 AccessTokenScopeUnaryServerInterceptor 的流版本。
+func AccessTokenScopeStreamServerInterceptor(scopes map[string][]string) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkAccessTokenScope(ss.Context(), info.FullMethod, scopes); err != nil {
+			return err
 		}
-		newCtx := auth.WithIdentity(ss.Context(), identity)
+		return handler(srv, ss)
+	}
+}
 
-		// 使用新的上下文创建一个包装的 ServerStream
-		wrappedSS := newWrappedServerStream(newCtx, ss)
+// checkAccessTokenScope 检查个人访问令牌是否拥有调用 method 所需的权限范围
+func checkAccessTokenScope(ctx context.Context, method string, scopes map[string][]string) error {
+	identity, ok := auth.FromContext(ctx)
+	if !ok || !identity.IsAccessToken() {
+		return nil
+	}
 
-		// 继续处理请求
-		return handler(srv, wrappedSS)
+	// 同一个方法列在多个范围下时，拥有其中任意一个即可
+	var missing string
+	for scope, methods := range scopes {
+		if !slices.Contains(methods, method) {
+			continue
+		}
+		if identity.HasScope(scope) {
+			return nil
+		}
+		missing = scope
+	}
+	if missing == "" {
+		return status.Errorf(codes.PermissionDenied, "该方法不能通过访问令牌调用")
 	}
+	return status.Errorf(codes.PermissionDenied, "访问令牌缺少 %s 权限", missing)
 }
@@ -3,6 +3,7 @@ package log_test
 import (
 	"context"
 	"log/slog"
+	"path/filepath"
 	"qahub/pkg/config"
 	"qahub/pkg/log"
 	"testing"
@@ -20,7 +21,7 @@ func TestInitLogger(t *testing.T) {
 			"app": "test-app",
 		},
 		File: lumberjack.Logger{
-			Filename:   "test.log",
+			Filename:   filepath.Join(t.TempDir(), "test.log"),
 			MaxSize:    5, // megabytes
 			MaxBackups: 3,
 			MaxAge:     28,   // days
@@ -0,0 +1,66 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"qahub/pkg/log"
+)
+
+// fileMailer 不真正发送邮件，而是把邮件写入本地目录并打印日志，用于本地开发和测试
+type fileMailer struct {
+	dir string
+}
+
+// NewFileMailer 创建一个将邮件写入 dir 目录的 Mailer，dir 为空时只写日志
+func NewFileMailer(dir string) Mailer {
+	return &fileMailer{dir: dir}
+}
+
+func (m *fileMailer) Send(ctx context.Context, msg Message) error {
+	logger := log.FromContext(ctx)
+
+	if m.dir == "" {
+		logger.Info("邮件未发送 (仅记录日志)",
+			slog.String("to", msg.To),
+			slog.String("subject", msg.Subject),
+			slog.String("body", msg.Body),
+		)
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return fmt.Errorf("创建邮件目录失败: %w", err)
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("%s_%s.eml", now.Format("20060102T150405.000000000"), sanitizeFileName(msg.To))
+	path := filepath.Join(m.dir, name)
+	if err := os.WriteFile(path, buildMessage("qahub@localhost", msg, now), 0o644); err != nil {
+		return fmt.Errorf("写入邮件文件失败: %w", err)
+	}
+
+	logger.Info("邮件已写入本地文件",
+		slog.String("to", msg.To),
+		slog.String("subject", msg.Subject),
+		slog.String("path", path),
+	)
+	return nil
+}
+
+// sanitizeFileName 将收件人地址中不适合出现在文件名里的字符替换掉
+func sanitizeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_', r == '@':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
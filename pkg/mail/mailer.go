@@ -0,0 +1,50 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+
+	"qahub/pkg/config"
+)
+
+// Message 是一封待发送的纯文本邮件
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer 定义了发送邮件所需的方法，便于在 SMTP 与本地文件实现之间切换
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New 根据配置中的 driver 创建对应的 Mailer
+func New(cfg config.Mail) (Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg), nil
+	case "file", "":
+		return NewFileMailer(cfg.Dir), nil
+	default:
+		return nil, fmt.Errorf("不支持的邮件发送方式: %s", cfg.Driver)
+	}
+}
+
+// buildMessage 按 RFC 5322 组装邮件原文，主题使用 MIME 编码以支持中文
+func buildMessage(from string, msg Message, date time.Time) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
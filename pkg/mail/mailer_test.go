@@ -0,0 +1,68 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"qahub/pkg/config"
+)
+
+func TestNew(t *testing.T) {
+	if _, err := New(config.Mail{Driver: "smtp", Host: "localhost", Port: 25}); err != nil {
+		t.Fatalf("smtp driver should be supported: %v", err)
+	}
+	if _, err := New(config.Mail{Driver: ""}); err != nil {
+		t.Fatalf("empty driver should fall back to file: %v", err)
+	}
+	if _, err := New(config.Mail{Driver: "carrier-pigeon"}); err == nil {
+		t.Fatal("expected error for unknown driver")
+	}
+}
+
+func TestBuildMessage(t *testing.T) {
+	msg := Message{To: "alice@example.com", Subject: "重置密码", Body: "第一行\n第二行"}
+	raw := string(buildMessage("QAHub <no-reply@qahub.local>", msg, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+	for _, want := range []string{
+		"From: QAHub <no-reply@qahub.local>\r\n",
+		"To: alice@example.com\r\n",
+		"Subject: =?utf-8?q?",
+		"Content-Type: text/plain; charset=UTF-8\r\n",
+		"\r\n\r\n第一行\r\n第二行",
+	} {
+		if !strings.Contains(raw, want) {
+			t.Errorf("message should contain %q, got:\n%s", want, raw)
+		}
+	}
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := t.TempDir()
+	mailer := NewFileMailer(dir)
+
+	err := mailer.Send(context.Background(), Message{To: "bob@example.com", Subject: "hello", Body: "token: abc"})
+	if err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected exactly one mail file, got %v (err=%v)", entries, err)
+	}
+	if !strings.HasSuffix(entries[0].Name(), "_bob@example.com.eml") {
+		t.Errorf("unexpected file name %q", entries[0].Name())
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	if !strings.Contains(string(data), "token: abc") {
+		t.Errorf("mail file should contain the body, got:\n%s", data)
+	}
+
+	// dir 为空时只写日志，不应报错
+	if err := NewFileMailer("").Send(context.Background(), Message{To: "bob@example.com"}); err != nil {
+		t.Fatalf("log-only mailer returned error: %v", err)
+	}
+}
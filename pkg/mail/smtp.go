@@ -0,0 +1,43 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"net/smtp"
+	"time"
+
+	"qahub/pkg/config"
+)
+
+// smtpMailer 通过 SMTP 服务器发送邮件
+type smtpMailer struct {
+	cfg config.Mail
+}
+
+// NewSMTPMailer 创建一个通过 SMTP 发送邮件的 Mailer
+func NewSMTPMailer(cfg config.Mail) Mailer {
+	return &smtpMailer{cfg: cfg}
+}
+
+func (m *smtpMailer) Send(ctx context.Context, msg Message) error {
+	from, err := mail.ParseAddress(m.cfg.From)
+	if err != nil {
+		return fmt.Errorf("发件人地址无效: %w", err)
+	}
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("收件人地址无效: %w", err)
+	}
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+
+	// net/smtp 不支持 context，这里只在发送前检查是否已被取消
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return smtp.SendMail(m.cfg.Addr(), auth, from.Address, []string{to.Address}, buildMessage(m.cfg.From, msg, time.Now()))
+}
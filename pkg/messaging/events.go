@@ -12,20 +12,32 @@ const (
 	EventQuestionUpdated EventType = "question.updated"
 	// EventQuestionDeleted 表示一个问题被删除的事件
 	EventQuestionDeleted EventType = "question.deleted"
+	// EventQuestionViewed 表示一个问题被浏览的事件
+	EventQuestionViewed EventType = "question.viewed"
 	// EventAnswerCreated 表示一个回答被创建的事件
 	EventAnswerCreated EventType = "answer.created"
 	// EventAnswerUpdated 表示一个回答被更新的事件
 	EventAnswerUpdated EventType = "answer.updated"
 	// EventAnswerDeleted 表示一个回答被删除的事件
 	EventAnswerDeleted EventType = "answer.deleted"
+	// EventAnswerUpvoted 表示一个回答被点赞的事件
+	EventAnswerUpvoted EventType = "answer.upvoted"
 	// EventAnswerDownvoted 表示一个回答被点踩的事件
 	EventAnswerDownvoted EventType = "answer.downvoted"
+	// EventAnswerVoteRetracted 表示用户撤销了对回答的点赞或点踩
+	EventAnswerVoteRetracted EventType = "answer.vote_retracted"
+	// EventAnswerAccepted 表示一个回答被提问者采纳的事件
+	EventAnswerAccepted EventType = "answer.accepted"
 	// EventCommentCreated 表示一个评论被创建的事件
 	EventCommentCreated EventType = "comment.created"
 	// EventCommentUpdated 表示一个评论被更新的事件
 	EventCommentUpdated EventType = "comment.updated"
 	// EventCommentDeleted 表示一个评论被删除的事件
 	EventCommentDeleted EventType = "comment.deleted"
+	// EventContentFlagged 表示问题或回答被版主标记为违规的事件
+	EventContentFlagged EventType = "content.flagged"
+	// EventUserUpdated 表示用户的公开资料（目前是用户名）被修改的事件，由用户服务发布
+	EventUserUpdated EventType = "user.updated"
 )
 
 // EventHeader 包含了所有事件共有的元数据
@@ -44,6 +56,7 @@ type QuestionPayload struct {
 	Tags       []string  `json:"tags,omitempty"`
 	AuthorID   int64     `json:"author_id"`
 	AuthorName string    `json:"author_name,omitempty"`
+	Anonymous  bool      `json:"anonymous,omitempty"` // 为 true 时 AuthorName 为空，AuthorID 只供声望等内部统计使用，不得对外展示或写入搜索索引
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 }
@@ -68,12 +81,109 @@ type QuestionDeletedEvent struct {
 	} `json:"payload"`
 }
 
+// QuestionViewedEvent 是问题浏览事件的完整结构
+type QuestionViewedEvent struct {
+	Header  EventHeader `json:"header"`
+	Payload struct {
+		ID       int64 `json:"id"`
+		ViewerID int64 `json:"viewer_id,omitempty"` // 未登录用户为 0
+	} `json:"payload"`
+}
+
+// AnswerPayload 是与回答相关的事件所携带的数据
+type AnswerPayload struct {
+	ID               int64     `json:"id"`
+	QuestionID       int64     `json:"question_id"`
+	QuestionAuthorID int64     `json:"question_author_id,omitempty"`
+	Content          string    `json:"content"`
+	AuthorID         int64     `json:"author_id"`
+	AuthorName       string    `json:"author_name,omitempty"`
+	Anonymous        bool      `json:"anonymous,omitempty"` // 含义同 QuestionPayload.Anonymous
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// AnswerEvent 是回答创建、删除、采纳事件的完整结构
+type AnswerEvent struct {
+	Header  EventHeader   `json:"header"`
+	Payload AnswerPayload `json:"payload"`
+}
+
+// VotePayload 是与回答投票相关的事件所携带的数据
+type VotePayload struct {
+	AnswerID   int64     `json:"answer_id"`
+	QuestionID int64     `json:"question_id"`
+	AuthorID   int64     `json:"author_id"` // 回答作者ID
+	VoterID    int64     `json:"voter_id"`
+	VoterName  string    `json:"voter_name,omitempty"`
+	IsUpvote   bool      `json:"is_upvote"` // 撤销事件中表示被撤销的是点赞还是点踩
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AnswerVotedEvent 是回答投票事件的完整结构
+type AnswerVotedEvent struct {
+	Header  EventHeader `json:"header"`
+	Payload VotePayload `json:"payload"`
+}
+
+// 被标记内容的类型
+const (
+	FlagTargetQuestion = "question"
+	FlagTargetAnswer   = "answer"
+)
+
+// FlagPayload 是内容被标记违规事件所携带的数据
+type FlagPayload struct {
+	TargetType string    `json:"target_type"` // "question" 或 "answer"
+	TargetID   int64     `json:"target_id"`
+	QuestionID int64     `json:"question_id"`
+	AuthorID   int64     `json:"author_id"` // 被标记内容的作者ID
+	ReporterID int64     `json:"reporter_id"`
+	Reason     string    `json:"reason,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ContentFlaggedEvent 是内容被标记违规事件的完整结构
+type ContentFlaggedEvent struct {
+	Header  EventHeader `json:"header"`
+	Payload FlagPayload `json:"payload"`
+}
+
+// UserPayload 是与用户相关的事件所携带的数据
+type UserPayload struct {
+	ID               int64     `json:"id"`
+	Username         string    `json:"username"`
+	PreviousUsername string    `json:"previous_username,omitempty"` // 修改前的用户名，用户名没有变化时为空
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// UserUpdatedEvent 是用户资料更新事件的完整结构
+type UserUpdatedEvent struct {
+	Header  EventHeader `json:"header"`
+	Payload UserPayload `json:"payload"`
+}
+
 // EventNotificationTriggered 表示一个通知被触发的事件
 const EventNotificationTriggered EventType = "notification.triggered"
 
 const (
 	NotificationTypeNewAnswer  = "new_answer"
 	NotificationTypeNewComment = "new_comment"
+	NotificationTypeUpvote     = "upvote"
+	// 修改建议被提出、通过或拒绝
+	NotificationTypeEditSuggested = "edit_suggested"
+	NotificationTypeEditApproved  = "edit_approved"
+	NotificationTypeEditRejected  = "edit_rejected"
+)
+
+// 通知模板参数的键，notification-service 按接收者的语言用这些参数渲染通知内容
+const (
+	NotificationParamSender          = "sender"           // 触发通知的用户名
+	NotificationParamSenderAnonymous = "sender_anonymous" // 值为 "true" 时发送者是匿名作者，渲染为本地化的匿名名称
+	NotificationParamQuestionTitle   = "question_title"
+	NotificationParamExcerpt         = "excerpt"  // 回答或评论的内容
+	NotificationParamTarget          = "target"   // 修改建议的目标类型，"question" 或 "answer"
+	NotificationParamProposer        = "proposer" // 修改建议的提出者，通知作者时使用
+	NotificationParamCount           = "count"    // 聚合通知的总人数，只有多于一人时设置
 )
 
 // NotificationPayload 是与通知相关的事件所携带的数据
@@ -81,9 +191,12 @@ type NotificationPayload struct {
 	RecipientID      int64  `json:"recipient_id"` // 接收通知的用户ID
 	SenderID         int64  `json:"sender_id"`
 	SenderName       string `json:"sender_name"`
-	NotificationType string `json:"notification_type"` // e.g., "new_answer", "new_comment"
-	Content          string `json:"content"`           // 通知内容
+	NotificationType string `json:"notification_type"` // e.g., "new_answer", "new_comment", "upvote"
+	Content          string `json:"content"`           // 固定的通知文本，仅在没有 Params 时使用（兼容旧事件）
 	TargetURL        string `json:"target_url"`        // 点击通知后跳转的URL
+
+	// Params 是渲染通知模板的参数，键见 NotificationParam* 常量
+	Params map[string]string `json:"params,omitempty"`
 }
 
 // NotificationTriggeredEvent 是通知触发事件的完整结构
@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	"qahub/pkg/auth"
-	"qahub/pkg/config"
 
 	"github.com/gin-gonic/gin"
 )
@@ -37,8 +36,8 @@ func NginxAuthMiddleware() gin.HandlerFunc {
 	}
 }
 
-// OptionalAuthMiddleware 创建一个Gin中间件，用于可选的JWT身份验证
-func OptionalAuthMiddleware() gin.HandlerFunc {
+// OptionalAuthMiddleware 创建一个Gin中间件，用于可选的JWT身份验证，令牌的签名由 verifier 在本地校验
+func OptionalAuthMiddleware(verifier *auth.Verifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -53,9 +52,7 @@ func OptionalAuthMiddleware() gin.HandlerFunc {
 		}
 
 		tokenString := parts[1]
-		var jwtSecret = []byte(config.Conf.Services.UserService.JWTSecret)
-
-		identity, err := auth.ParseToken(tokenString, jwtSecret)
+		identity, err := verifier.Verify(c.Request.Context(), tokenString)
 		if err == nil {
 			auth.InjectIntoGin(c, identity)
 		}
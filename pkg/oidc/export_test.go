@@ -0,0 +1,10 @@
+package oidc
+
+import "time"
+
+// SetMinRefreshInterval 修改 JWKS 的最短拉取间隔，返回恢复原值的函数
+func SetMinRefreshInterval(d time.Duration) (restore func()) {
+	previous := minRefreshInterval
+	minRefreshInterval = d
+	return func() { minRefreshInterval = previous }
+}
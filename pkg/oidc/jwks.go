@@ -0,0 +1,194 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// minRefreshInterval 是两次拉取 JWKS 之间的最短间隔，防止伪造的 kid 触发大量请求
+var minRefreshInterval = 10 * time.Second
+
+// jsonWebKey 是 JWKS 中的一个公钥 (RFC 7517)
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC 和 OKP
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKey 是解析后的公钥，alg 为空时不限制签名算法
+type publicKey struct {
+	alg string
+	key any
+}
+
+// keySet 缓存身份提供方的签名公钥。遇到未知的 kid 时重新拉取，
+// 身份提供方轮换密钥后无需重启服务。
+type keySet struct {
+	client *http.Client
+	uri    string
+
+	mu        sync.Mutex
+	keys      map[string]publicKey
+	fetchedAt time.Time
+}
+
+func newKeySet(client *http.Client, uri string) *keySet {
+	return &keySet{client: client, uri: uri}
+}
+
+// key 返回 kid 对应、可用于 alg 的公钥
+func (s *keySet) key(ctx context.Context, kid, alg string) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if key, ok := s.lookup(kid, alg); ok {
+		return key, nil
+	}
+	if s.keys != nil && time.Since(s.fetchedAt) < minRefreshInterval {
+		return nil, fmt.Errorf("oidc: 未知的签名密钥 %q", kid)
+	}
+
+	keys, err := s.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.keys, s.fetchedAt = keys, time.Now()
+
+	if key, ok := s.lookup(kid, alg); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("oidc: 未知的签名密钥 %q", kid)
+}
+
+// lookup 在缓存中查找公钥。令牌没有 kid 时，只有 JWKS 中恰好有一个公钥才能确定使用哪一个。
+func (s *keySet) lookup(kid, alg string) (any, bool) {
+	key, ok := s.keys[kid]
+	if !ok && kid == "" && len(s.keys) == 1 {
+		for _, only := range s.keys {
+			key, ok = only, true
+		}
+	}
+	if !ok || (key.alg != "" && key.alg != alg) {
+		return nil, false
+	}
+	return key.key, true
+}
+
+// fetch 拉取并解析 JWKS，跳过不用于签名和不支持的密钥
+func (s *keySet) fetch(ctx context.Context) (map[string]publicKey, error) {
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := getJSON(ctx, s.client, s.uri, &jwks); err != nil {
+		return nil, fmt.Errorf("oidc: 获取 JWKS 失败: %w", err)
+	}
+
+	keys := make(map[string]publicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = publicKey{alg: jwk.Alg, key: key}
+	}
+	return keys, nil
+}
+
+// publicKey 将 JWK 转换为 jwt 库校验签名所需的公钥类型
+func (k jsonWebKey) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("RSA 公钥指数无效")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		var ecdhCurve ecdh.Curve
+		switch k.Crv {
+		case "P-256":
+			curve, ecdhCurve = elliptic.P256(), ecdh.P256()
+		case "P-384":
+			curve, ecdhCurve = elliptic.P384(), ecdh.P384()
+		default:
+			return nil, fmt.Errorf("不支持的曲线 %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("EC 公钥坐标长度无效")
+		}
+		// 借助 ecdh 校验坐标确实在曲线上
+		point := append(append([]byte{4}, x...), y...)
+		if _, err := ecdhCurve.NewPublicKey(point); err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("不支持的曲线 %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("Ed25519 公钥长度无效")
+		}
+		return ed25519.PublicKey(x), nil
+
+	default:
+		return nil, fmt.Errorf("不支持的密钥类型 %q", k.Kty)
+	}
+}
+
+// decodeBigInt 解码 base64url 编码的大整数
+func decodeBigInt(s string) (*big.Int, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) == 0 {
+		return nil, errors.New("空的整数")
+	}
+	return new(big.Int).SetBytes(buf), nil
+}
@@ -0,0 +1,275 @@
+// Package oidc 实现了 OpenID Connect 授权码流程 (PKCE) 的客户端部分：服务发现、授权地址、
+// 授权码换取令牌，以及基于 JWKS 的 ID Token 校验。
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// clockSkew 是校验 ID Token 有效期时允许的时钟误差
+	clockSkew = time.Minute
+	// maxResponseSize 是读取身份提供方响应的上限，防止异常的响应占用过多内存
+	maxResponseSize = 1 << 20
+)
+
+// DefaultScopes 是未配置 scope 时请求的权限，足以获取用户标识、邮箱和名称
+var DefaultScopes = []string{"openid", "email", "profile"}
+
+// ErrInvalidIDToken 表示 ID Token 的签名、issuer、audience、有效期或 nonce 校验失败
+var ErrInvalidIDToken = errors.New("oidc: ID Token 无效")
+
+// supportedAlgorithms 是接受的 ID Token 签名算法，不接受 none 和 HMAC
+var supportedAlgorithms = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "EdDSA"}
+
+// Provider 是通过服务发现得到的身份提供方
+type Provider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+
+	client *http.Client
+	keys   *keySet
+}
+
+// Config 是在身份提供方注册的客户端信息
+type Config struct {
+	ClientID     string
+	ClientSecret string // 公共客户端可以为空，此时只依靠 PKCE 保护授权码
+	RedirectURL  string
+	Scopes       []string // 为空时使用 DefaultScopes
+}
+
+// Token 是令牌端点的响应
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// Claims 是 ID Token 中用于识别和创建用户的声明
+type Claims struct {
+	Subject           string // 用户在身份提供方的唯一标识，与 issuer 一起确定一个外部身份
+	Email             string
+	EmailVerified     bool // 身份提供方是否确认用户拥有该邮箱
+	Name              string
+	PreferredUsername string
+}
+
+// idTokenClaims 是 ID Token 的完整声明
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce             string `json:"nonce"`
+	AuthorizedParty   string `json:"azp"`
+	Email             string `json:"email"`
+	EmailVerified     any    `json:"email_verified"` // 部分身份提供方以字符串 "true" 返回
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+// Discover 读取 issuer 的发现文档，client 为 nil 时使用 http.DefaultClient
+func Discover(ctx context.Context, issuer string, client *http.Client) (*Provider, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	var provider Provider
+	wellKnown := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	if err := getJSON(ctx, client, wellKnown, &provider); err != nil {
+		return nil, fmt.Errorf("oidc: 服务发现失败: %w", err)
+	}
+	// 发现文档中的 issuer 必须与配置的完全一致，防止被替换为其他身份提供方
+	if provider.Issuer != issuer {
+		return nil, fmt.Errorf("oidc: issuer 不匹配，期望 %q，实际为 %q", issuer, provider.Issuer)
+	}
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" || provider.JWKSURI == "" {
+		return nil, errors.New("oidc: 发现文档缺少必要的端点")
+	}
+
+	provider.client = client
+	provider.keys = newKeySet(client, provider.JWKSURI)
+	return &provider, nil
+}
+
+// AuthCodeURL 返回将用户重定向到身份提供方的授权地址，verifier 是本次登录的 PKCE 校验码
+func (p *Provider) AuthCodeURL(cfg Config, state, nonce, verifier string) string {
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultScopes
+	}
+	if !slices.Contains(scopes, "openid") {
+		scopes = append([]string{"openid"}, scopes...)
+	}
+
+	params := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {cfg.ClientID},
+		"redirect_uri":          {cfg.RedirectURL},
+		"scope":                 {strings.Join(scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {Challenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+	separator := "?"
+	if strings.Contains(p.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return p.AuthorizationEndpoint + separator + params.Encode()
+}
+
+// Exchange 使用授权码和 PKCE 校验码换取令牌
+func (p *Provider) Exchange(ctx context.Context, cfg Config, code, verifier string) (*Token, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {cfg.RedirectURL},
+		"client_id":     {cfg.ClientID},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if cfg.ClientSecret != "" {
+		// client_secret_basic 要求先对客户端 ID 和密钥做表单编码 (RFC 6749 2.3.1)
+		req.SetBasicAuth(url.QueryEscape(cfg.ClientID), url.QueryEscape(cfg.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: 换取令牌失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("oidc: 读取令牌响应失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var oauthErr struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &oauthErr) == nil && oauthErr.Error != "" {
+			return nil, fmt.Errorf("oidc: 换取令牌失败: %s %s", oauthErr.Error, oauthErr.Description)
+		}
+		return nil, fmt.Errorf("oidc: 换取令牌失败: HTTP %d", resp.StatusCode)
+	}
+
+	var token Token
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("oidc: 解析令牌响应失败: %w", err)
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("oidc: 令牌响应中没有 id_token")
+	}
+	return &token, nil
+}
+
+// VerifyIDToken 校验 ID Token 的签名、issuer、audience、有效期和 nonce，返回其中的用户信息
+func (p *Provider) VerifyIDToken(ctx context.Context, clientID, rawIDToken, nonce string) (*Claims, error) {
+	var claims idTokenClaims
+	_, err := jwt.ParseWithClaims(rawIDToken, &claims,
+		func(token *jwt.Token) (any, error) {
+			kid, _ := token.Header["kid"].(string)
+			return p.keys.key(ctx, kid, token.Method.Alg())
+		},
+		jwt.WithValidMethods(supportedAlgorithms),
+		jwt.WithIssuer(p.Issuer),
+		jwt.WithAudience(clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	// 存在多个 audience 时，azp 必须是本客户端 (OpenID Connect Core 3.1.3.7)
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != clientID {
+		return nil, fmt.Errorf("%w: azp 不是本客户端", ErrInvalidIDToken)
+	}
+	if nonce == "" || subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce 不匹配", ErrInvalidIDToken)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: 缺少 sub", ErrInvalidIDToken)
+	}
+
+	return &Claims{
+		Subject:           claims.Subject,
+		Email:             claims.Email,
+		EmailVerified:     parseBool(claims.EmailVerified),
+		Name:              claims.Name,
+		PreferredUsername: claims.PreferredUsername,
+	}, nil
+}
+
+// NewVerifier 生成 PKCE 校验码 (RFC 7636)，即 43 个字符的 base64url 随机串
+func NewVerifier() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// Challenge 按 S256 方法计算校验码对应的 code_challenge
+func Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// getJSON 读取 url 的 JSON 响应
+func getJSON(ctx context.Context, client *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v)
+}
+
+// parseBool 解析布尔值或字符串形式的布尔声明
+func parseBool(v any) bool {
+	switch value := v.(type) {
+	case bool:
+		return value
+	case string:
+		b, _ := strconv.ParseBool(value)
+		return b
+	default:
+		return false
+	}
+}
@@ -0,0 +1,213 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"qahub/pkg/oidc"
+	"qahub/pkg/oidc/oidctest"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID     = "qahub"
+	testClientSecret = "secret"
+	testRedirectURL  = "http://localhost:8080/api/v1/auth/oauth/company/callback"
+)
+
+var testUser = oidctest.User{
+	Subject:           "user-1",
+	Email:             "alice@example.com",
+	EmailVerified:     true,
+	Name:              "Alice",
+	PreferredUsername: "alice",
+}
+
+func setup(t *testing.T) (*oidctest.Server, *oidc.Provider, oidc.Config) {
+	t.Helper()
+	idp := oidctest.NewServer(testClientID, testClientSecret)
+	t.Cleanup(idp.Close)
+
+	provider, err := oidc.Discover(context.Background(), idp.Issuer(), nil)
+	if err != nil {
+		t.Fatalf("discovery failed: %v", err)
+	}
+	return idp, provider, oidc.Config{ClientID: testClientID, ClientSecret: testClientSecret, RedirectURL: testRedirectURL}
+}
+
+// login 完成一次授权，返回授权码和本次使用的 nonce、校验码
+func login(t *testing.T, idp *oidctest.Server, provider *oidc.Provider, cfg oidc.Config) (code, nonce, verifier string) {
+	t.Helper()
+	verifier, err := oidc.NewVerifier()
+	if err != nil {
+		t.Fatalf("failed to generate verifier: %v", err)
+	}
+	nonce = "nonce-" + verifier[:8]
+
+	authURL := provider.AuthCodeURL(cfg, "state-1", nonce, verifier)
+	code, state, err := idp.Authorize(authURL, testUser)
+	if err != nil {
+		t.Fatalf("authorize failed: %v", err)
+	}
+	if state != "state-1" {
+		t.Fatalf("expected state to round-trip, got %q", state)
+	}
+	return code, nonce, verifier
+}
+
+func TestDiscover(t *testing.T) {
+	idp := oidctest.NewServer(testClientID, testClientSecret)
+	defer idp.Close()
+
+	if _, err := oidc.Discover(context.Background(), idp.Issuer()+"/", nil); err == nil {
+		t.Fatalf("expected issuer mismatch to be rejected")
+	}
+	if _, err := oidc.Discover(context.Background(), idp.Issuer()+"/missing", nil); err == nil {
+		t.Fatalf("expected missing discovery document to fail")
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	_, provider, cfg := setup(t)
+	cfg.Scopes = []string{"email"}
+
+	u, err := url.Parse(provider.AuthCodeURL(cfg, "state", "nonce", "verifier"))
+	if err != nil {
+		t.Fatalf("invalid url: %v", err)
+	}
+	params := u.Query()
+	if params.Get("scope") != "openid email" {
+		t.Fatalf("expected openid to be added to scopes, got %q", params.Get("scope"))
+	}
+	if params.Get("code_challenge") != oidc.Challenge("verifier") || params.Get("code_challenge_method") != "S256" {
+		t.Fatalf("expected S256 code challenge, got %v", params)
+	}
+}
+
+func TestChallenge(t *testing.T) {
+	// RFC 7636 附录 B 的测试向量
+	got := oidc.Challenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	if want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+func TestExchangeAndVerify(t *testing.T) {
+	ctx := context.Background()
+	idp, provider, cfg := setup(t)
+
+	code, nonce, verifier := login(t, idp, provider, cfg)
+	token, err := provider.Exchange(ctx, cfg, code, verifier)
+	if err != nil {
+		t.Fatalf("exchange failed: %v", err)
+	}
+
+	claims, err := provider.VerifyIDToken(ctx, cfg.ClientID, token.IDToken, nonce)
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	if claims.Subject != testUser.Subject || claims.Email != testUser.Email || !claims.EmailVerified ||
+		claims.PreferredUsername != testUser.PreferredUsername {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+
+	if _, err := provider.VerifyIDToken(ctx, cfg.ClientID, token.IDToken, "other-nonce"); !errors.Is(err, oidc.ErrInvalidIDToken) {
+		t.Fatalf("expected nonce mismatch to be rejected, got %v", err)
+	}
+	if _, err := provider.Exchange(ctx, cfg, code, verifier); err == nil {
+		t.Fatalf("expected authorization code to be single-use")
+	}
+}
+
+func TestExchangeRequiresVerifier(t *testing.T) {
+	idp, provider, cfg := setup(t)
+
+	code, _, _ := login(t, idp, provider, cfg)
+	other, _ := oidc.NewVerifier()
+	if _, err := provider.Exchange(context.Background(), cfg, code, other); err == nil {
+		t.Fatalf("expected wrong code_verifier to be rejected")
+	}
+}
+
+func TestVerifyIDTokenClaims(t *testing.T) {
+	ctx := context.Background()
+	idp, provider, _ := setup(t)
+
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   idp.Issuer(),
+			"sub":   "user-1",
+			"aud":   testClientID,
+			"iat":   time.Now().Unix(),
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"nonce": "n",
+		}
+	}
+	if _, err := provider.VerifyIDToken(ctx, testClientID, idp.SignIDToken(valid()), "n"); err != nil {
+		t.Fatalf("expected valid token to pass, got %v", err)
+	}
+
+	cases := map[string]func(jwt.MapClaims){
+		"wrong issuer":    func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+		"wrong audience":  func(c jwt.MapClaims) { c["aud"] = "other-client" },
+		"expired":         func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"missing expiry":  func(c jwt.MapClaims) { delete(c, "exp") },
+		"missing subject": func(c jwt.MapClaims) { delete(c, "sub") },
+		"foreign azp":     func(c jwt.MapClaims) { c["aud"] = []string{testClientID, "other"}; c["azp"] = "other" },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			claims := valid()
+			mutate(claims)
+			if _, err := provider.VerifyIDToken(ctx, testClientID, idp.SignIDToken(claims), "n"); !errors.Is(err, oidc.ErrInvalidIDToken) {
+				t.Fatalf("expected token to be rejected, got %v", err)
+			}
+		})
+	}
+
+	t.Run("email_verified as string", func(t *testing.T) {
+		claims := valid()
+		claims["email_verified"] = "true"
+		got, err := provider.VerifyIDToken(ctx, testClientID, idp.SignIDToken(claims), "n")
+		if err != nil || !got.EmailVerified {
+			t.Fatalf("expected string email_verified to be accepted, got %+v, %v", got, err)
+		}
+	})
+
+	t.Run("tampered signature", func(t *testing.T) {
+		token := idp.SignIDToken(valid())
+		tampered := token[:len(token)-4] + "AAAA"
+		if _, err := provider.VerifyIDToken(ctx, testClientID, tampered, "n"); !errors.Is(err, oidc.ErrInvalidIDToken) {
+			t.Fatalf("expected tampered token to be rejected, got %v", err)
+		}
+	})
+}
+
+func TestKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	idp, provider, _ := setup(t)
+
+	claims := jwt.MapClaims{
+		"iss": idp.Issuer(), "sub": "user-1", "aud": testClientID, "nonce": "n",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	}
+	if _, err := provider.VerifyIDToken(ctx, testClientID, idp.SignIDToken(claims), "n"); err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+
+	// 刚拉取过 JWKS 时不会因为未知的 kid 立即重新拉取
+	idp.RotateKey()
+	rotated := idp.SignIDToken(claims)
+	if _, err := provider.VerifyIDToken(ctx, testClientID, rotated, "n"); err == nil {
+		t.Fatalf("expected refresh to be rate limited")
+	}
+
+	defer oidc.SetMinRefreshInterval(0)()
+	if _, err := provider.VerifyIDToken(ctx, testClientID, rotated, "n"); err != nil {
+		t.Fatalf("expected rotated key to be fetched, got %v", err)
+	}
+}
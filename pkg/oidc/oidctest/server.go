@@ -0,0 +1,251 @@
+// Package oidctest 提供一个运行在本地的 OpenID Connect 身份提供方，用于测试授权码流程。
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"qahub/pkg/oidc"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// User 是在模拟身份提供方登录的用户
+type User struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// grant 是已签发但尚未换取令牌的授权码
+type grant struct {
+	redirectURI string
+	nonce       string
+	challenge   string
+	user        User
+}
+
+// Server 是模拟的身份提供方，只接受启用了 PKCE (S256) 的授权码流程
+type Server struct {
+	*httptest.Server
+	ClientID     string
+	ClientSecret string
+
+	mu     sync.Mutex
+	key    *rsa.PrivateKey
+	kid    int
+	grants map[string]grant
+}
+
+// NewServer 启动模拟的身份提供方，测试结束时需要调用 Close
+func NewServer(clientID, clientSecret string) *Server {
+	s := &Server{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		grants:       make(map[string]grant),
+	}
+	s.RotateKey()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", s.handleDiscovery)
+	mux.HandleFunc("GET /jwks", s.handleJWKS)
+	mux.HandleFunc("POST /token", s.handleToken)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Issuer 返回身份提供方的 issuer
+func (s *Server) Issuer() string {
+	return s.URL
+}
+
+// RotateKey 更换签名密钥，之后签发的 ID Token 使用新的 kid
+func (s *Server) RotateKey() {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.key = key
+	s.kid++
+}
+
+// Authorize 模拟用户在身份提供方完成登录并同意授权，返回回调地址中的授权码和 state
+func (s *Server) Authorize(authURL string, user User) (code, state string, err error) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return "", "", err
+	}
+	if !strings.HasPrefix(authURL, s.URL+"/authorize") {
+		return "", "", fmt.Errorf("授权地址不属于该身份提供方: %s", authURL)
+	}
+
+	params := u.Query()
+	switch {
+	case params.Get("response_type") != "code":
+		return "", "", errors.New("response_type 必须为 code")
+	case params.Get("client_id") != s.ClientID:
+		return "", "", errors.New("client_id 不匹配")
+	case !strings.Contains(" "+params.Get("scope")+" ", " openid "):
+		return "", "", errors.New("scope 中缺少 openid")
+	case params.Get("code_challenge_method") != "S256" || params.Get("code_challenge") == "":
+		return "", "", errors.New("缺少 S256 的 code_challenge")
+	case params.Get("state") == "" || params.Get("redirect_uri") == "":
+		return "", "", errors.New("缺少 state 或 redirect_uri")
+	}
+
+	code = rand.Text()
+	s.mu.Lock()
+	s.grants[code] = grant{
+		redirectURI: params.Get("redirect_uri"),
+		nonce:       params.Get("nonce"),
+		challenge:   params.Get("code_challenge"),
+		user:        user,
+	}
+	s.mu.Unlock()
+	return code, params.Get("state"), nil
+}
+
+// SignIDToken 使用当前的签名密钥签发任意声明的 ID Token，用于构造异常的令牌
+func (s *Server) SignIDToken(claims jwt.MapClaims) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.currentKid()
+	signed, err := token.SignedString(s.key)
+	if err != nil {
+		panic(err)
+	}
+	return signed
+}
+
+func (s *Server) currentKid() string {
+	return fmt.Sprintf("key-%d", s.kid)
+}
+
+func (s *Server) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                s.URL,
+		"authorization_endpoint":                s.URL + "/authorize",
+		"token_endpoint":                        s.URL + "/token",
+		"jwks_uri":                              s.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (s *Server) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	pub := s.key.PublicKey
+	kid := s.currentKid()
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, "invalid_request", err.Error())
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != s.ClientID || subtle.ConstantTimeCompare([]byte(clientSecret), []byte(s.ClientSecret)) != 1 {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, "unsupported_grant_type", "")
+		return
+	}
+
+	// 授权码只能使用一次
+	code := r.PostForm.Get("code")
+	s.mu.Lock()
+	g, ok := s.grants[code]
+	delete(s.grants, code)
+	s.mu.Unlock()
+	switch {
+	case !ok:
+		tokenError(w, "invalid_grant", "授权码无效或已被使用")
+		return
+	case r.PostForm.Get("redirect_uri") != g.redirectURI:
+		tokenError(w, "invalid_grant", "redirect_uri 不匹配")
+		return
+	case oidc.Challenge(r.PostForm.Get("code_verifier")) != g.challenge:
+		tokenError(w, "invalid_grant", "code_verifier 校验失败")
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            s.URL,
+		"sub":            g.user.Subject,
+		"aud":            s.ClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"email_verified": g.user.EmailVerified,
+	}
+	if g.nonce != "" {
+		claims["nonce"] = g.nonce
+	}
+	if g.user.Email != "" {
+		claims["email"] = g.user.Email
+	}
+	if g.user.Name != "" {
+		claims["name"] = g.user.Name
+	}
+	if g.user.PreferredUsername != "" {
+		claims["preferred_username"] = g.user.PreferredUsername
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": rand.Text(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     s.SignIDToken(claims),
+	})
+}
+
+func tokenError(w http.ResponseWriter, code, description string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code, "error_description": description})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,69 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret 是 RFC 6238 附录 B 中 SHA1 测试向量使用的密钥 "12345678901234567890"
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	// RFC 6238 附录 B 的 8 位验证码取后 6 位
+	cases := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, want := range cases {
+		got, err := Code(rfcSecret, time.Unix(unix, 0))
+		if err != nil {
+			t.Fatalf("Code(%d) 返回错误: %v", unix, err)
+		}
+		if got != want {
+			t.Errorf("Code(%d) = %s, 期望 %s", unix, got, want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret 返回错误: %v", err)
+	}
+	now := time.Unix(1700000000, 0)
+	current, _ := Code(secret, now)
+
+	if step, ok := Validate(secret, current, now); !ok || step != Step(now) {
+		t.Errorf("当前验证码应校验通过，step = %d, ok = %v", step, ok)
+	}
+	if _, ok := Validate(strings.ToLower(secret), current[:3]+" "+current[3:], now); !ok {
+		t.Error("应容忍小写密钥和验证码中的空格")
+	}
+
+	previous, _ := Code(secret, now.Add(-Period))
+	if step, ok := Validate(secret, previous, now); !ok || step != Step(now)-1 {
+		t.Errorf("上一个时间步的验证码应在允许误差内，step = %d, ok = %v", step, ok)
+	}
+
+	stale, _ := Code(secret, now.Add(-3*Period))
+	if _, ok := Validate(secret, stale, now); ok {
+		t.Error("超出允许误差的验证码不应校验通过")
+	}
+	if _, ok := Validate(secret, "12345", now); ok {
+		t.Error("位数不对的验证码不应校验通过")
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("QAHub", "alice", "JBSWY3DPEHPK3PXP")
+	want := "otpauth://totp/QAHub:alice?algorithm=SHA1&digits=6&issuer=QAHub&period=30&secret=JBSWY3DPEHPK3PXP"
+	if uri != want {
+		t.Errorf("URI = %s, 期望 %s", uri, want)
+	}
+}
@@ -0,0 +1,3 @@
+-- 000007_add_accepted_answer_to_questions.down.sql
+ALTER TABLE `questions`
+DROP COLUMN `accepted_answer_id`;
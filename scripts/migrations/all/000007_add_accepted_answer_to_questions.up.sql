@@ -0,0 +1,6 @@
+-- 000007_add_accepted_answer_to_questions.up.sql
+-- 不对 answers 建外键：删除问题时会级联删除回答，反向的 SET NULL 会与级联冲突，
+-- 回答被删除时由 qa-service 在同一事务中清除该字段
+ALTER TABLE `questions`
+ADD COLUMN `accepted_answer_id` BIGINT NULL DEFAULT NULL
+AFTER `user_id`;
@@ -0,0 +1,6 @@
+-- 000008_add_reputation_to_users.down.sql
+ALTER TABLE `users`
+DROP COLUMN `accepted_answer_count`,
+DROP COLUMN `answer_count`,
+DROP COLUMN `question_count`,
+DROP COLUMN `reputation`;
@@ -0,0 +1,7 @@
+-- 000008_add_reputation_to_users.up.sql
+-- 声望与统计字段由 user-service 根据 reputation_events 流水汇总维护
+ALTER TABLE `users`
+ADD COLUMN `reputation` BIGINT NOT NULL DEFAULT 0 AFTER `password`,
+ADD COLUMN `question_count` BIGINT NOT NULL DEFAULT 0 AFTER `reputation`,
+ADD COLUMN `answer_count` BIGINT NOT NULL DEFAULT 0 AFTER `question_count`,
+ADD COLUMN `accepted_answer_count` BIGINT NOT NULL DEFAULT 0 AFTER `answer_count`;
@@ -0,0 +1,2 @@
+-- 000009_create_reputation_events_table.down.sql
+DROP TABLE `reputation_events`;
@@ -0,0 +1,17 @@
+-- 000009_create_reputation_events_table.up.sql
+CREATE TABLE `reputation_events` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `user_id` BIGINT NOT NULL,
+    `event_id` VARCHAR(64) NOT NULL,
+    `reason` VARCHAR(32) NOT NULL,
+    `points` BIGINT NOT NULL DEFAULT 0,
+    `source_type` VARCHAR(16) NOT NULL,
+    `source_id` BIGINT NOT NULL,
+    `question_id` BIGINT NOT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `unique_event` (`event_id`, `user_id`, `source_type`, `source_id`),
+    KEY `idx_source` (`source_type`, `source_id`),
+    KEY `idx_question` (`question_id`),
+    FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,3 @@
+-- 000010_add_view_count_to_questions.down.sql
+ALTER TABLE `questions`
+DROP COLUMN `view_count`;
@@ -0,0 +1,4 @@
+-- 000010_add_view_count_to_questions.up.sql
+ALTER TABLE `questions`
+ADD COLUMN `view_count` BIGINT NOT NULL DEFAULT 0
+AFTER `accepted_answer_id`;
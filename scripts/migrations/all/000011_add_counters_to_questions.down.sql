@@ -0,0 +1,5 @@
+-- 000011_add_counters_to_questions.down.sql
+ALTER TABLE `questions`
+DROP COLUMN `last_activity_at`,
+DROP COLUMN `comment_count`,
+DROP COLUMN `answer_count`;
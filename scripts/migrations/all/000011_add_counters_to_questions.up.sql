@@ -0,0 +1,7 @@
+-- 000011_add_counters_to_questions.up.sql
+-- 回答数、评论数和最近活动时间由 qa-service 在写入回答、评论的同一事务中维护，
+-- 并由定期的计数校对任务修正偏差
+ALTER TABLE `questions`
+ADD COLUMN `answer_count` INT NOT NULL DEFAULT 0 AFTER `view_count`,
+ADD COLUMN `comment_count` INT NOT NULL DEFAULT 0 AFTER `answer_count`,
+ADD COLUMN `last_activity_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP AFTER `comment_count`;
@@ -0,0 +1,5 @@
+-- 000012_backfill_question_counters.down.sql
+UPDATE `questions`
+SET `answer_count` = 0,
+    `comment_count` = 0,
+    `updated_at` = `updated_at`;
@@ -0,0 +1,19 @@
+-- 000012_backfill_question_counters.up.sql
+-- 根据现有的回答和评论回填问题的计数与最近活动时间
+UPDATE `questions` q
+SET q.`answer_count` = (SELECT COUNT(*) FROM `answers` a WHERE a.`question_id` = q.`id`),
+    q.`comment_count` = (
+        SELECT COUNT(*) FROM `comments` c
+        JOIN `answers` a ON a.`id` = c.`answer_id`
+        WHERE a.`question_id` = q.`id`
+    ),
+    q.`last_activity_at` = GREATEST(
+        COALESCE(q.`updated_at`, q.`created_at`),
+        COALESCE((SELECT MAX(a.`updated_at`) FROM `answers` a WHERE a.`question_id` = q.`id`), q.`created_at`),
+        COALESCE((
+            SELECT MAX(c.`created_at`) FROM `comments` c
+            JOIN `answers` a ON a.`id` = c.`answer_id`
+            WHERE a.`question_id` = q.`id`
+        ), q.`created_at`)
+    ),
+    q.`updated_at` = q.`updated_at`;
@@ -0,0 +1,3 @@
+-- 000013_add_role_to_users.down.sql
+ALTER TABLE `users`
+DROP COLUMN `role`;
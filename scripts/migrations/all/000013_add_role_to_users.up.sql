@@ -0,0 +1,4 @@
+-- 000013_add_role_to_users.up.sql
+-- 用户角色：user（普通用户）、moderator（版主）、admin（管理员），会写入 JWT 的 role 声明
+ALTER TABLE `users`
+ADD COLUMN `role` VARCHAR(16) NOT NULL DEFAULT 'user' AFTER `password`;
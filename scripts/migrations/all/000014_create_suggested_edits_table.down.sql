@@ -0,0 +1,2 @@
+-- 000014_create_suggested_edits_table.down.sql
+DROP TABLE `suggested_edits`;
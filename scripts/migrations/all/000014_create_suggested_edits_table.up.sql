@@ -0,0 +1,21 @@
+-- 000014_create_suggested_edits_table.up.sql
+CREATE TABLE `suggested_edits` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `target_type` VARCHAR(16) NOT NULL,
+    `target_id` BIGINT NOT NULL,
+    `proposer_id` BIGINT NOT NULL,
+    `base_title` VARCHAR(255) NOT NULL DEFAULT '',
+    `base_content` TEXT NOT NULL,
+    `title` VARCHAR(255) NOT NULL DEFAULT '',
+    `content` TEXT NOT NULL,
+    `reason` VARCHAR(255) NOT NULL DEFAULT '',
+    `status` VARCHAR(16) NOT NULL DEFAULT 'pending',
+    `reviewer_id` BIGINT NULL,
+    `review_comment` VARCHAR(255) NOT NULL DEFAULT '',
+    `reviewed_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_target_status` (`target_type`, `target_id`, `status`),
+    KEY `idx_proposer` (`proposer_id`),
+    FOREIGN KEY (`proposer_id`) REFERENCES `users`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,6 @@
+-- 000015_add_is_anonymous_to_questions_answers.down.sql
+ALTER TABLE `answers`
+DROP COLUMN `is_anonymous`;
+
+ALTER TABLE `questions`
+DROP COLUMN `is_anonymous`;
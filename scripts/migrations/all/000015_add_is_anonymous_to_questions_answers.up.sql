@@ -0,0 +1,7 @@
+-- 000015_add_is_anonymous_to_questions_answers.up.sql
+-- 匿名发布的问题和回答：user_id 仍记录真实作者，用于权限校验和声望统计，只在对外展示时隐藏
+ALTER TABLE `questions`
+ADD COLUMN `is_anonymous` BOOLEAN NOT NULL DEFAULT FALSE AFTER `user_id`;
+
+ALTER TABLE `answers`
+ADD COLUMN `is_anonymous` BOOLEAN NOT NULL DEFAULT FALSE AFTER `user_id`;
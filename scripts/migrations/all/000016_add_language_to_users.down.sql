@@ -0,0 +1,3 @@
+-- 000016_add_language_to_users.down.sql
+ALTER TABLE `users`
+DROP COLUMN `language`;
@@ -0,0 +1,4 @@
+-- 000016_add_language_to_users.up.sql
+-- 用户的语言偏好（zh / en），通知服务按接收者的语言渲染通知内容
+ALTER TABLE `users`
+ADD COLUMN `language` VARCHAR(8) NOT NULL DEFAULT 'zh' AFTER `role`;
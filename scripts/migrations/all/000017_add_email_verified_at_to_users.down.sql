@@ -0,0 +1,3 @@
+-- 000017_add_email_verified_at_to_users.down.sql
+ALTER TABLE `users`
+DROP COLUMN `email_verified_at`;
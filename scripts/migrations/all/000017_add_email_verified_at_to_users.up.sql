@@ -0,0 +1,7 @@
+-- 000017_add_email_verified_at_to_users.up.sql
+-- 邮箱验证时间，为 NULL 表示邮箱尚未验证；修改邮箱后会被重置为 NULL
+-- 迁移前已注册的用户视为已验证，避免存量用户在上线后被限制发帖
+ALTER TABLE `users`
+ADD COLUMN `email_verified_at` DATETIME NULL DEFAULT NULL AFTER `email`;
+
+UPDATE `users` SET `email_verified_at` = `created_at`;
@@ -0,0 +1,3 @@
+-- 000018_create_two_factor_tables.down.sql
+DROP TABLE `user_recovery_codes`;
+DROP TABLE `user_two_factor`;
@@ -0,0 +1,22 @@
+-- 000018_create_two_factor_tables.up.sql
+-- 两步验证 (TOTP)：enabled_at 为 NULL 表示已生成密钥但尚未确认绑定
+CREATE TABLE `user_two_factor` (
+    `user_id` BIGINT NOT NULL,
+    `secret` VARCHAR(64) NOT NULL,
+    `enabled_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`user_id`),
+    FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- 恢复码只保存 SHA-256 哈希，每个恢复码只能使用一次
+CREATE TABLE `user_recovery_codes` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `user_id` BIGINT NOT NULL,
+    `code_hash` CHAR(64) NOT NULL,
+    `used_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_user_code` (`user_id`, `code_hash`),
+    FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
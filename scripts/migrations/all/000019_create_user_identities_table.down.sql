@@ -0,0 +1,2 @@
+-- 000019_create_user_identities_table.down.sql
+DROP TABLE `user_identities`;
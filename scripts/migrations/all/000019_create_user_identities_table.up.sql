@@ -0,0 +1,15 @@
+-- 000019_create_user_identities_table.up.sql
+-- 外部身份提供方 (OpenID Connect) 的账户与本地用户的关联，issuer 与 sub 确定唯一的外部身份
+CREATE TABLE `user_identities` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `user_id` BIGINT NOT NULL,
+    `provider` VARCHAR(64) NOT NULL,
+    `issuer` VARCHAR(255) NOT NULL,
+    `subject` VARCHAR(255) NOT NULL,
+    `email` VARCHAR(255) NOT NULL DEFAULT '',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_issuer_subject` (`issuer`, `subject`),
+    KEY `idx_user_id` (`user_id`),
+    FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
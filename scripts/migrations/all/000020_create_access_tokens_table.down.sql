@@ -0,0 +1,5 @@
+-- 000020_create_access_tokens_table.down.sql
+DROP TABLE `access_tokens`;
+
+ALTER TABLE `users`
+DROP COLUMN `user_type`;
@@ -0,0 +1,22 @@
+-- 000020_create_access_tokens_table.up.sql
+-- 用户类型：human（普通用户）、bot（机器人账户），机器人账户由管理员创建，只能通过个人访问令牌调用接口
+ALTER TABLE `users`
+ADD COLUMN `user_type` VARCHAR(16) NOT NULL DEFAULT 'human' AFTER `role`;
+
+-- 个人访问令牌只保存 SHA-256 哈希；scopes 以空格分隔，expires_at 为 NULL 表示永不过期
+CREATE TABLE `access_tokens` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `user_id` BIGINT NOT NULL,
+    `name` VARCHAR(64) NOT NULL,
+    `token_hash` CHAR(64) NOT NULL,
+    `token_hint` VARCHAR(8) NOT NULL,
+    `scopes` VARCHAR(255) NOT NULL,
+    `expires_at` TIMESTAMP NULL,
+    `last_used_at` TIMESTAMP NULL,
+    `revoked_at` TIMESTAMP NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_token_hash` (`token_hash`),
+    KEY `idx_user_id` (`user_id`),
+    FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
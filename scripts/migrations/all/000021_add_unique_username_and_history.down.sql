@@ -0,0 +1,6 @@
+-- 000021_add_unique_username_and_history.down.sql
+DROP TABLE `username_history`;
+
+ALTER TABLE `users`
+DROP INDEX `uk_username`,
+MODIFY COLUMN `username` VARCHAR(255) NOT NULL;
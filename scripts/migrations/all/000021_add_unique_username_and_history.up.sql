@@ -0,0 +1,45 @@
+-- 000021_add_unique_username_and_history.up.sql
+-- 登录按用户名查找用户，用户名必须唯一且不区分大小写。
+-- 先检查存量数据中只有大小写不同的用户名，存在冲突时中止迁移并报告冲突的用户名和用户ID，
+-- 处理方法见 scripts/migrations/README.md
+DROP PROCEDURE IF EXISTS `check_username_conflicts`;
+
+CREATE PROCEDURE `check_username_conflicts`()
+BEGIN
+    DECLARE conflicts TEXT;
+    DECLARE message VARCHAR(128);
+
+    SELECT GROUP_CONCAT(CONCAT(`username`, ' (', `ids`, ')') ORDER BY `username` SEPARATOR '; ')
+    INTO conflicts
+    FROM (
+        SELECT MIN(`username`) AS `username`, GROUP_CONCAT(`id` ORDER BY `id`) AS `ids`
+        FROM `users`
+        GROUP BY `username` COLLATE utf8mb4_unicode_ci
+        HAVING COUNT(*) > 1
+    ) AS duplicated;
+
+    IF conflicts IS NOT NULL THEN
+        SET message = LEFT(CONCAT('用户名冲突: ', conflicts), 128);
+        SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = message;
+    END IF;
+END;
+
+CALL `check_username_conflicts`();
+
+DROP PROCEDURE `check_username_conflicts`;
+
+ALTER TABLE `users`
+MODIFY COLUMN `username` VARCHAR(255) NOT NULL COLLATE utf8mb4_unicode_ci,
+ADD UNIQUE KEY `uk_username` (`username`);
+
+-- 用户名修改记录，旧用户名没有被其他用户使用时，仍然可以通过旧用户名找到该用户
+CREATE TABLE `username_history` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `user_id` BIGINT NOT NULL,
+    `username` VARCHAR(255) NOT NULL COLLATE utf8mb4_unicode_ci,
+    `changed_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_username` (`username`),
+    KEY `idx_user_id_changed_at` (`user_id`, `changed_at`),
+    FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
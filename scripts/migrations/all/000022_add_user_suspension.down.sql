@@ -0,0 +1,8 @@
+-- 000022_add_user_suspension.down.sql
+ALTER TABLE `users`
+DROP INDEX `idx_suspended_at`,
+DROP INDEX `idx_role`,
+DROP COLUMN `suspended_by`,
+DROP COLUMN `suspension_reason`,
+DROP COLUMN `suspended_until`,
+DROP COLUMN `suspended_at`;
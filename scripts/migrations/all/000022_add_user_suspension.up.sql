@@ -0,0 +1,10 @@
+-- 000022_add_user_suspension.up.sql
+-- 管理员封禁用户：suspended_at 不为 NULL 表示账户处于封禁状态，suspended_until 为 NULL 表示永久封禁，
+-- 到期后无需清理，登录和令牌校验时按 suspended_until 判断
+ALTER TABLE `users`
+ADD COLUMN `suspended_at` TIMESTAMP NULL DEFAULT NULL AFTER `user_type`,
+ADD COLUMN `suspended_until` TIMESTAMP NULL DEFAULT NULL AFTER `suspended_at`,
+ADD COLUMN `suspension_reason` VARCHAR(500) NOT NULL DEFAULT '' AFTER `suspended_until`,
+ADD COLUMN `suspended_by` BIGINT NULL DEFAULT NULL AFTER `suspension_reason`,
+ADD KEY `idx_role` (`role`),
+ADD KEY `idx_suspended_at` (`suspended_at`);
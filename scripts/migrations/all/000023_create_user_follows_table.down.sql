@@ -0,0 +1,2 @@
+-- 000023_create_user_follows_table.down.sql
+DROP TABLE `user_follows`;
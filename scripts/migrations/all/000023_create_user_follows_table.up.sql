@@ -0,0 +1,11 @@
+-- 000023_create_user_follows_table.up.sql
+-- 用户关注关系，关注者的个人动态由 user-service 消费问答事件后写入 Redis 时间线
+CREATE TABLE `user_follows` (
+    `follower_id` BIGINT NOT NULL,
+    `followee_id` BIGINT NOT NULL,
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`follower_id`, `followee_id`),
+    KEY `idx_followee_id_created_at` (`followee_id`, `created_at`),
+    FOREIGN KEY (`follower_id`) REFERENCES `users`(`id`) ON DELETE CASCADE,
+    FOREIGN KEY (`followee_id`) REFERENCES `users`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
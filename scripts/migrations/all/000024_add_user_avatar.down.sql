@@ -0,0 +1,3 @@
+-- 000024_add_user_avatar.down.sql
+ALTER TABLE `users`
+DROP COLUMN `avatar_key`;
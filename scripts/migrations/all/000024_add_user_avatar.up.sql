@@ -0,0 +1,5 @@
+-- 000024_add_user_avatar.up.sql
+-- 用户头像：avatar_key 是头像缩略图在文件存储中的目录，目录下每个尺寸一张 PNG，空字符串表示没有上传头像。
+-- 目录名包含原图的内容摘要，更换头像后地址随之改变，客户端可以长期缓存
+ALTER TABLE `users`
+ADD COLUMN `avatar_key` VARCHAR(255) NOT NULL DEFAULT '' AFTER `bio`;
@@ -0,0 +1,2 @@
+-- 000025_create_content_flags_table.down.sql
+DROP TABLE `content_flags`;
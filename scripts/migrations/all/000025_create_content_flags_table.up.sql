@@ -0,0 +1,16 @@
+-- 000025_create_content_flags_table.up.sql
+-- 版主对问题或回答的违规标记，每项内容只能被标记一次，标记后由 user-service 扣除作者声望
+CREATE TABLE `content_flags` (
+    `id` BIGINT NOT NULL AUTO_INCREMENT,
+    `target_type` VARCHAR(16) NOT NULL,
+    `target_id` BIGINT NOT NULL,
+    `question_id` BIGINT NOT NULL,
+    `author_id` BIGINT NOT NULL,
+    `reporter_id` BIGINT NOT NULL,
+    `reason` VARCHAR(255) NOT NULL DEFAULT '',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_target` (`target_type`, `target_id`),
+    KEY `idx_author` (`author_id`),
+    FOREIGN KEY (`reporter_id`) REFERENCES `users`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
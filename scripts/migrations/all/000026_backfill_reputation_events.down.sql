@@ -0,0 +1,12 @@
+-- 000026_backfill_reputation_events.down.sql
+DELETE FROM `reputation_events` WHERE `event_id` LIKE 'backfill:%';
+
+UPDATE `users` u SET
+    u.`reputation` = (SELECT COALESCE(SUM(e.`points`), 0) FROM `reputation_events` e WHERE e.`user_id` = u.`id`),
+    u.`question_count` = (SELECT COUNT(*) FROM `reputation_events` e WHERE e.`user_id` = u.`id` AND e.`reason` = 'question_asked' AND NOT EXISTS (
+        SELECT 1 FROM `reputation_events` r WHERE r.`user_id` = e.`user_id` AND r.`reason` = 'content_removed' AND r.`source_type` = e.`source_type` AND r.`source_id` = e.`source_id`)),
+    u.`answer_count` = (SELECT COUNT(*) FROM `reputation_events` e WHERE e.`user_id` = u.`id` AND e.`reason` = 'answer_posted' AND NOT EXISTS (
+        SELECT 1 FROM `reputation_events` r WHERE r.`user_id` = e.`user_id` AND r.`reason` = 'content_removed' AND r.`source_type` = e.`source_type` AND r.`source_id` = e.`source_id`)),
+    u.`accepted_answer_count` = (SELECT COUNT(*) FROM `reputation_events` e WHERE e.`user_id` = u.`id` AND e.`reason` = 'answer_accepted' AND NOT EXISTS (
+        SELECT 1 FROM `reputation_events` r WHERE r.`user_id` = e.`user_id` AND r.`reason` = 'content_removed' AND r.`source_type` = e.`source_type` AND r.`source_id` = e.`source_id`)),
+    u.`updated_at` = u.`updated_at`;
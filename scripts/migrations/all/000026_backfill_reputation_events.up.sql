@@ -0,0 +1,72 @@
+-- 000026_backfill_reputation_events.up.sql
+-- 为声望流水上线前已有的内容、采纳和投票补记流水，然后重新汇总所有用户的声望与统计字段。
+-- 补记的 event_id 以 backfill: 开头并由内容ID确定，重复执行不会重复记账。
+
+-- 提问：没有 question_asked 流水的问题
+INSERT IGNORE INTO `reputation_events` (`user_id`, `event_id`, `reason`, `points`, `source_type`, `source_id`, `question_id`, `created_at`)
+SELECT q.`user_id`, CONCAT('backfill:question:', q.`id`), 'question_asked', 2, 'question', q.`id`, q.`id`, q.`created_at`
+FROM `questions` q
+WHERE NOT EXISTS (
+    SELECT 1 FROM `reputation_events` e
+    WHERE e.`reason` = 'question_asked' AND e.`source_type` = 'question' AND e.`source_id` = q.`id`
+);
+
+-- 回答：没有 answer_posted 流水的回答（只计数，不加分）
+INSERT IGNORE INTO `reputation_events` (`user_id`, `event_id`, `reason`, `points`, `source_type`, `source_id`, `question_id`, `created_at`)
+SELECT a.`user_id`, CONCAT('backfill:answer:', a.`id`), 'answer_posted', 0, 'answer', a.`id`, a.`question_id`, a.`created_at`
+FROM `answers` a
+WHERE NOT EXISTS (
+    SELECT 1 FROM `reputation_events` e
+    WHERE e.`reason` = 'answer_posted' AND e.`source_type` = 'answer' AND e.`source_id` = a.`id`
+);
+
+-- 采纳：被他人采纳、但没有 answer_accepted 流水的回答
+INSERT IGNORE INTO `reputation_events` (`user_id`, `event_id`, `reason`, `points`, `source_type`, `source_id`, `question_id`)
+SELECT a.`user_id`, CONCAT('backfill:accepted:', a.`id`), 'answer_accepted', 15, 'answer', a.`id`, a.`question_id`
+FROM `questions` q
+JOIN `answers` a ON a.`id` = q.`accepted_answer_id`
+WHERE a.`user_id` <> q.`user_id`
+  AND NOT EXISTS (
+    SELECT 1 FROM `reputation_events` e
+    WHERE e.`reason` = 'answer_accepted' AND e.`source_type` = 'answer' AND e.`source_id` = a.`id`
+);
+
+-- 点赞：流水中每个回答的点赞净积分应等于他人点赞数 × 10，不一致时补记差额。
+-- 流水不记录投票人，只能按回答整体对账；差额为负说明有点赞随投票人账号一起被删除。
+INSERT IGNORE INTO `reputation_events` (`user_id`, `event_id`, `reason`, `points`, `source_type`, `source_id`, `question_id`)
+SELECT t.`user_id`, CONCAT('backfill:upvotes:', t.`id`), 'answer_upvoted', t.`expected` - t.`recorded`, 'answer', t.`id`, t.`question_id`
+FROM (
+    SELECT a.`id`, a.`user_id`, a.`question_id`,
+        (SELECT COUNT(*) FROM `answers_votes` v
+            WHERE v.`answer_id` = a.`id` AND v.`is_upvote` = 1 AND v.`user_id` <> a.`user_id`) * 10 AS `expected`,
+        (SELECT COALESCE(SUM(e.`points`), 0) FROM `reputation_events` e
+            WHERE e.`source_type` = 'answer' AND e.`source_id` = a.`id` AND e.`user_id` = a.`user_id`
+              AND e.`reason` IN ('answer_upvoted', 'upvote_retracted')) AS `recorded`
+    FROM `answers` a
+) t
+WHERE t.`expected` <> t.`recorded`;
+
+-- 点踩：此前点踩没有产生流水，同样按回答对账，每个他人的点踩 -2
+INSERT IGNORE INTO `reputation_events` (`user_id`, `event_id`, `reason`, `points`, `source_type`, `source_id`, `question_id`)
+SELECT t.`user_id`, CONCAT('backfill:downvotes:', t.`id`), 'answer_downvoted', t.`expected` - t.`recorded`, 'answer', t.`id`, t.`question_id`
+FROM (
+    SELECT a.`id`, a.`user_id`, a.`question_id`,
+        (SELECT COUNT(*) FROM `answers_votes` v
+            WHERE v.`answer_id` = a.`id` AND v.`is_upvote` = 0 AND v.`user_id` <> a.`user_id`) * -2 AS `expected`,
+        (SELECT COALESCE(SUM(e.`points`), 0) FROM `reputation_events` e
+            WHERE e.`source_type` = 'answer' AND e.`source_id` = a.`id` AND e.`user_id` = a.`user_id`
+              AND e.`reason` IN ('answer_downvoted', 'downvote_retracted')) AS `recorded`
+    FROM `answers` a
+) t
+WHERE t.`expected` <> t.`recorded`;
+
+-- 按 user-service 的 refreshUserStatsQuery 重新汇总所有用户
+UPDATE `users` u SET
+    u.`reputation` = (SELECT COALESCE(SUM(e.`points`), 0) FROM `reputation_events` e WHERE e.`user_id` = u.`id`),
+    u.`question_count` = (SELECT COUNT(*) FROM `reputation_events` e WHERE e.`user_id` = u.`id` AND e.`reason` = 'question_asked' AND NOT EXISTS (
+        SELECT 1 FROM `reputation_events` r WHERE r.`user_id` = e.`user_id` AND r.`reason` = 'content_removed' AND r.`source_type` = e.`source_type` AND r.`source_id` = e.`source_id`)),
+    u.`answer_count` = (SELECT COUNT(*) FROM `reputation_events` e WHERE e.`user_id` = u.`id` AND e.`reason` = 'answer_posted' AND NOT EXISTS (
+        SELECT 1 FROM `reputation_events` r WHERE r.`user_id` = e.`user_id` AND r.`reason` = 'content_removed' AND r.`source_type` = e.`source_type` AND r.`source_id` = e.`source_id`)),
+    u.`accepted_answer_count` = (SELECT COUNT(*) FROM `reputation_events` e WHERE e.`user_id` = u.`id` AND e.`reason` = 'answer_accepted' AND NOT EXISTS (
+        SELECT 1 FROM `reputation_events` r WHERE r.`user_id` = e.`user_id` AND r.`reason` = 'content_removed' AND r.`source_type` = e.`source_type` AND r.`source_id` = e.`source_id`)),
+    u.`updated_at` = u.`updated_at`;